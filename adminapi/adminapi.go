@@ -0,0 +1,174 @@
+package adminapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// RPCPath is the prefix of binding calls: POST RPCPath+"<Method>" with the arguments as a JSON array
+const RPCPath = "/api/v1/rpc/"
+
+const maxRequestSize = 32 * 1024 * 1024
+
+// Excluded lists the App bindings that are not reachable over the admin API, with the reason.
+// Every other exported App method is exposed automatically, so new bindings need no wiring.
+var Excluded = map[string]string{
+	// Callbacks the HTTP server uses to report into the app
+	"Emit":             "internal event callback",
+	"SendEvent":        "internal event callback",
+	"LogRequest":       "internal request log callback",
+	"UpdateRequestLog": "internal request log callback",
+	"LogScriptError":   "internal script error callback",
+
+	// Desktop dialogs
+	"SaveConfig":                      "opens a save dialog (use SaveCurrentConfig)",
+	"LoadConfig":                      "opens a file dialog (use LoadConfigFromPath)",
+	"SaveConfigToDirectoryWithDialog": "opens a directory dialog (use SaveConfigToDirectory)",
+	"ImportOpenAPISpecWithDialog":     "opens a file dialog",
+	"ImportHARWithDialog":             "opens a file dialog",
+	"ExportBundleWithDialog":          "opens a save dialog (use ExportBundle)",
+	"ImportBundleWithDialog":          "opens file and directory dialogs (use ImportBundle)",
+	"ExportOpenAPISpec":               "opens a save dialog",
+	"ExportLogs":                      "opens a save dialog",
+	"DownloadCACert":                  "opens a save dialog",
+	"ExportServerCertificate":         "opens a save dialog",
+	"GenerateMismatchedCertificate":   "opens a save dialog",
+	"SelectCertFile":                  "opens a file dialog",
+	"InstallCACertSystem":             "prompts for administrator privileges",
+}
+
+// Methods returns the names of target's exported methods reachable over the admin API, sorted
+func Methods(target interface{}) []string {
+	t := reflect.TypeOf(target)
+	names := make([]string, 0, t.NumMethod())
+	for i := 0; i < t.NumMethod(); i++ {
+		if name := t.Method(i).Name; Excluded[name] == "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Handler serves binding calls on target's exported methods. Requests must carry the token
+// as "Authorization: Bearer <token>".
+type Handler struct {
+	target reflect.Value
+	token  string
+}
+
+// NewHandler creates a handler calling methods on target (a pointer to the bound struct)
+func NewHandler(target interface{}, token string) *Handler {
+	return &Handler{target: reflect.ValueOf(target), token: token}
+}
+
+// ServeHTTP handles GET RPCPath (method list) and POST RPCPath+"<Method>" (call)
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "missing or invalid token")
+		return
+	}
+
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, strings.TrimSuffix(RPCPath, "/")), "/")
+	if name == "" {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "use GET to list methods")
+			return
+		}
+		writeJSON(w, http.StatusOK, Methods(h.target.Interface()))
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "use POST to call a method")
+		return
+	}
+
+	method := h.target.MethodByName(name)
+	if !method.IsValid() || Excluded[name] != "" {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown method %q", name))
+		return
+	}
+
+	args, err := decodeArgs(method.Type(), io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%s: %v", name, err))
+		return
+	}
+
+	result, err := call(method, args)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && tokenMatches(token, h.token)
+}
+
+func tokenMatches(given, token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+// decodeArgs reads a JSON array with one element per parameter (an empty body means no arguments)
+func decodeArgs(methodType reflect.Type, body io.Reader) ([]reflect.Value, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	var raw []json.RawMessage
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("arguments must be a JSON array: %v", err)
+		}
+	}
+	if len(raw) != methodType.NumIn() {
+		return nil, fmt.Errorf("expected %d argument(s), got %d", methodType.NumIn(), len(raw))
+	}
+
+	args := make([]reflect.Value, len(raw))
+	for i := range raw {
+		arg := reflect.New(methodType.In(i))
+		if err := json.Unmarshal(raw[i], arg.Interface()); err != nil {
+			return nil, fmt.Errorf("argument %d: %v", i+1, err)
+		}
+		args[i] = arg.Elem()
+	}
+	return args, nil
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// call invokes a method and splits its results into the value (nil if none) and the error
+func call(method reflect.Value, args []reflect.Value) (interface{}, error) {
+	results := method.Call(args)
+	var value interface{}
+	for _, result := range results {
+		if result.Type() == errorType {
+			if !result.IsNil() {
+				return nil, result.Interface().(error)
+			}
+			continue
+		}
+		value = result.Interface()
+	}
+	return value, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
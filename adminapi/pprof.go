@@ -0,0 +1,29 @@
+package adminapi
+
+import (
+	"net/http"
+	"net/http/pprof"
+)
+
+// ProfilingPath is where the net/http/pprof handlers are served when profiling is enabled
+const ProfilingPath = "/debug/pprof/"
+
+// NewProfilingHandler serves the pprof index and profiles under ProfilingPath. go tool pprof
+// cannot send an Authorization header, so the token may also be passed as ?token=<token>.
+func NewProfilingHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ProfilingPath, pprof.Index)
+	mux.HandleFunc(ProfilingPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(ProfilingPath+"profile", pprof.Profile)
+	mux.HandleFunc(ProfilingPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(ProfilingPath+"trace", pprof.Trace)
+
+	h := &Handler{token: token}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !h.authorized(r) && !tokenMatches(r.URL.Query().Get("token"), token) {
+			writeError(w, http.StatusUnauthorized, "missing or invalid token")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,275 @@
+package adminapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"mockelot/models"
+)
+
+// RESTPath is the prefix of the resource routes, which cover the operations test harnesses need
+// most (endpoints, server control, logs and reset) without the positional RPC arguments
+const RESTPath = "/api/v1/"
+
+// route maps an HTTP method and path to a binding. Path segments written as "{}" are parameters.
+type route struct {
+	method  string
+	path    string
+	binding string
+	args    func(r *http.Request, params []string, h *Handler) ([]interface{}, error)
+}
+
+var routes = []route{
+	{http.MethodGet, "endpoints", "GetEndpoints", noArgs},
+	{http.MethodPost, "endpoints", "AddEndpointWithConfig", bodyArg},
+	{http.MethodGet, "endpoints/{}", "GetEndpoint", paramArgs},
+	{http.MethodPut, "endpoints/{}", "UpdateEndpoint", endpointArg},
+	{http.MethodDelete, "endpoints/{}", "DeleteEndpoint", existingEndpointArgs},
+
+	{http.MethodGet, "server", "GetServerStatus", noArgs},
+	{http.MethodPost, "server/start", "StartServer", startArgs},
+	{http.MethodPost, "server/stop", "StopServer", noArgs},
+
+	{http.MethodGet, "logs", "SearchRequestLogs", logFilterArg},
+	{http.MethodDelete, "logs", "ClearRequestLogs", noArgs},
+	{http.MethodGet, "logs/{}", "GetRequestLogDetails", paramArgs},
+
+	{http.MethodPost, "reset", "ResetState", noArgs},
+}
+
+// ServeREST handles the resource routes under RESTPath. Responses use the same JSON and error
+// format as binding calls.
+func (h *Handler) ServeREST(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "missing or invalid token")
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, RESTPath), "/")
+	pathMatched := false
+	for _, rt := range routes {
+		params, ok := matchRoute(rt.path, path)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if rt.method != r.Method {
+			continue
+		}
+
+		args, err := rt.args(r, params, h)
+		if err != nil {
+			status := http.StatusBadRequest
+			var se *statusError
+			if errors.As(err, &se) {
+				status = se.status
+			}
+			writeError(w, status, err.Error())
+			return
+		}
+		result, err := h.callBinding(rt.binding, args)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if strings.Contains(err.Error(), "not found") {
+				status = http.StatusNotFound
+			}
+			writeError(w, status, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+		return
+	}
+
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s not allowed on %s", r.Method, r.URL.Path))
+		return
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s", r.URL.Path))
+}
+
+// statusError is an argument error with a status other than 400
+type statusError struct {
+	status  int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return e.message
+}
+
+// matchRoute matches a request path against a route path and returns the "{}" parameters
+func matchRoute(pattern, path string) ([]string, bool) {
+	patternParts := strings.Split(pattern, "/")
+	pathParts := strings.Split(path, "/")
+	if len(patternParts) != len(pathParts) {
+		return nil, false
+	}
+	var params []string
+	for i, part := range patternParts {
+		if part == "{}" {
+			param, err := url.PathUnescape(pathParts[i])
+			if err != nil || param == "" {
+				return nil, false
+			}
+			params = append(params, param)
+			continue
+		}
+		if part != pathParts[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// callBinding converts the arguments to the binding's parameter types through JSON and calls it
+func (h *Handler) callBinding(name string, args []interface{}) (interface{}, error) {
+	method := h.target.MethodByName(name)
+	if !method.IsValid() {
+		return nil, fmt.Errorf("binding %s is not available", name)
+	}
+	if len(args) != method.Type().NumIn() {
+		return nil, fmt.Errorf("%s: expected %d argument(s), got %d", name, method.Type().NumIn(), len(args))
+	}
+	values := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		data, err := json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+		value := reflect.New(method.Type().In(i))
+		if err := json.Unmarshal(data, value.Interface()); err != nil {
+			return nil, fmt.Errorf("argument %d: %v", i+1, err)
+		}
+		values[i] = value.Elem()
+	}
+	return call(method, values)
+}
+
+func noArgs(r *http.Request, params []string, h *Handler) ([]interface{}, error) {
+	return nil, nil
+}
+
+func paramArgs(r *http.Request, params []string, h *Handler) ([]interface{}, error) {
+	args := make([]interface{}, len(params))
+	for i, param := range params {
+		args[i] = param
+	}
+	return args, nil
+}
+
+// existingEndpointArgs passes the endpoint ID from the path, failing with 404 for unknown endpoints
+func existingEndpointArgs(r *http.Request, params []string, h *Handler) ([]interface{}, error) {
+	if _, err := h.callBinding("GetEndpoint", []interface{}{params[0]}); err != nil {
+		return nil, &statusError{status: http.StatusNotFound, message: err.Error()}
+	}
+	return paramArgs(r, params, h)
+}
+
+// bodyArg passes the JSON request body as the only argument
+func bodyArg(r *http.Request, params []string, h *Handler) ([]interface{}, error) {
+	var body json.RawMessage
+	if err := decodeBody(r, &body); err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("request body is required")
+	}
+	return []interface{}{body}, nil
+}
+
+// endpointArg passes the endpoint in the body, with its ID taken from the path
+func endpointArg(r *http.Request, params []string, h *Handler) ([]interface{}, error) {
+	var endpoint map[string]interface{}
+	if err := decodeBody(r, &endpoint); err != nil {
+		return nil, err
+	}
+	if endpoint == nil {
+		return nil, fmt.Errorf("request body must be an endpoint object")
+	}
+	if _, err := existingEndpointArgs(r, params, h); err != nil {
+		return nil, err
+	}
+	endpoint["id"] = params[0]
+	return []interface{}{endpoint}, nil
+}
+
+// startArgs reads {"port": n} from the body; without a port the server starts on the configured one
+func startArgs(r *http.Request, params []string, h *Handler) ([]interface{}, error) {
+	var body struct {
+		Port int `json:"port"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return nil, err
+	}
+	if body.Port == 0 {
+		result, err := h.callBinding("GetConfig", nil)
+		if err != nil {
+			return nil, err
+		}
+		if config, ok := result.(*models.AppConfig); ok && config != nil {
+			body.Port = config.Port
+		}
+	}
+	if body.Port <= 0 || body.Port > 65535 {
+		return nil, fmt.Errorf("invalid port %d", body.Port)
+	}
+	return []interface{}{body.Port}, nil
+}
+
+// logFilterArg builds a models.RequestLogFilter from the query string
+func logFilterArg(r *http.Request, params []string, h *Handler) ([]interface{}, error) {
+	query := r.URL.Query()
+	filter := models.RequestLogFilter{
+		Method:       query.Get("method"),
+		PathRegex:    query.Get("path"),
+		EndpointID:   query.Get("endpoint"),
+		ProxyUser:    query.Get("proxy_user"),
+		Since:        query.Get("since"),
+		Until:        query.Get("until"),
+		BodyContains: query.Get("body"),
+		Descending:   query.Get("order") == "desc",
+	}
+	for name, target := range map[string]*int{
+		"status_min": &filter.StatusMin,
+		"status_max": &filter.StatusMax,
+		"offset":     &filter.Offset,
+		"limit":      &filter.Limit,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", name, value)
+		}
+		*target = n
+	}
+	for _, header := range query["header"] {
+		name, pattern, _ := strings.Cut(header, ":")
+		filter.Headers = append(filter.Headers, models.LogHeaderMatcher{Name: name, Pattern: pattern})
+	}
+	return []interface{}{filter}, nil
+}
+
+// decodeBody decodes a JSON request body into v; an empty body leaves v unchanged
+func decodeBody(r *http.Request, v interface{}) error {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid request body: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,1312 @@
+// Code generated by cmd/apigen. DO NOT EDIT.
+
+package adminclient
+
+import (
+	"context"
+	"encoding/json"
+
+	"mockelot/models"
+)
+
+// AddEndpoint adds a new endpoint with specified type
+func (c *Client) AddEndpoint(ctx context.Context, name string, pathPrefix string, translationMode string, endpointType string) (models.Endpoint, error) {
+	var result models.Endpoint
+	err := c.call(ctx, "AddEndpoint", []interface{}{name, pathPrefix, translationMode, endpointType}, &result)
+	return result, err
+}
+
+// AddEndpointWithConfig adds a new endpoint with full configuration from wizard
+func (c *Client) AddEndpointWithConfig(ctx context.Context, config map[string]interface{}) (models.Endpoint, error) {
+	var result models.Endpoint
+	err := c.call(ctx, "AddEndpointWithConfig", []interface{}{config}, &result)
+	return result, err
+}
+
+// AddGroup adds a new group to the selected endpoint
+func (c *Client) AddGroup(ctx context.Context, name string) (models.ResponseGroup, error) {
+	var result models.ResponseGroup
+	err := c.call(ctx, "AddGroup", []interface{}{name}, &result)
+	return result, err
+}
+
+// AddRecentFile adds or updates a file in the recent files list
+func (c *Client) AddRecentFile(ctx context.Context, path string) error {
+	return c.call(ctx, "AddRecentFile", []interface{}{path}, nil)
+}
+
+// AddResponse adds a new response rule
+func (c *Client) AddResponse(ctx context.Context, response models.MethodResponse) (models.MethodResponse, error) {
+	var result models.MethodResponse
+	err := c.call(ctx, "AddResponse", []interface{}{response}, &result)
+	return result, err
+}
+
+// AdvanceVirtualClock moves the virtual clock forward (or back, if negative) by the given seconds
+func (c *Client) AdvanceVirtualClock(ctx context.Context, seconds int64) (string, error) {
+	var result string
+	err := c.call(ctx, "AdvanceVirtualClock", []interface{}{seconds}, &result)
+	return result, err
+}
+
+// ApplyCachePreset applies a caching header preset to a response, or to every response in a group.
+// targetID may be a response ID or a group ID; maxAge (seconds, 0 = preset default) tunes the max-age.
+func (c *Client) ApplyCachePreset(ctx context.Context, targetID string, preset string, maxAge int) error {
+	return c.call(ctx, "ApplyCachePreset", []interface{}{targetID, preset, maxAge}, nil)
+}
+
+// ApplyEncodingPreset replaces the body of a response (or every response in a group) with a
+// Unicode/encoding edge-case preset such as "invalid-utf8" or "rtl-override".
+// Presets that are not valid UTF-8 are stored base64 (body_base64) so editing the config cannot mangle them.
+func (c *Client) ApplyEncodingPreset(ctx context.Context, targetID string, preset string) error {
+	return c.call(ctx, "ApplyEncodingPreset", []interface{}{targetID, preset}, nil)
+}
+
+// CancelContainerStart cancels an ongoing container startup operation
+func (c *Client) CancelContainerStart(ctx context.Context, endpointID string) error {
+	return c.call(ctx, "CancelContainerStart", []interface{}{endpointID}, nil)
+}
+
+// CancelScheduledAction cancels a pending action, or reverts one that already fired
+func (c *Client) CancelScheduledAction(ctx context.Context, id string) error {
+	return c.call(ctx, "CancelScheduledAction", []interface{}{id}, nil)
+}
+
+// ClearAppLogs clears the application log entries kept in memory (the log file is kept)
+func (c *Client) ClearAppLogs(ctx context.Context) error {
+	return c.call(ctx, "ClearAppLogs", []interface{}{}, nil)
+}
+
+// ClearRequestLogs clears all request logs
+func (c *Client) ClearRequestLogs(ctx context.Context) error {
+	return c.call(ctx, "ClearRequestLogs", []interface{}{}, nil)
+}
+
+// ClearScriptErrors clears all script errors for a given response ID
+func (c *Client) ClearScriptErrors(ctx context.Context, responseID string) error {
+	return c.call(ctx, "ClearScriptErrors", []interface{}{responseID}, nil)
+}
+
+// ClearTrafficExamples removes the examples harvested from traffic for an endpoint's responses
+// and returns how many were removed
+func (c *Client) ClearTrafficExamples(ctx context.Context, endpointID string) (int, error) {
+	var result int
+	err := c.call(ctx, "ClearTrafficExamples", []interface{}{endpointID}, &result)
+	return result, err
+}
+
+// CloseWorkspace stops the server and containers of a background workspace and closes it; unsaved
+// changes are lost. The active workspace cannot be closed, switch to another one first.
+func (c *Client) CloseWorkspace(ctx context.Context, id string) error {
+	return c.call(ctx, "CloseWorkspace", []interface{}{id}, nil)
+}
+
+// ContainerHosts returns the remote Docker/Podman hosts container endpoints can run on
+// Implements server.ContainerHostSource for the container handler
+func (c *Client) ContainerHosts(ctx context.Context) ([]models.ContainerHost, error) {
+	var result []models.ContainerHost
+	err := c.call(ctx, "ContainerHosts", []interface{}{}, &result)
+	return result, err
+}
+
+// CrawlProxyEndpoint crawls the backend of a proxy endpoint from seed paths (following discovered links)
+// and saves the responses as a new, disabled mock endpoint with the same prefix and path translation
+func (c *Client) CrawlProxyEndpoint(ctx context.Context, endpointID string, options models.CrawlOptions) (*models.CrawlResult, error) {
+	var result *models.CrawlResult
+	err := c.call(ctx, "CrawlProxyEndpoint", []interface{}{endpointID, options}, &result)
+	return result, err
+}
+
+// CreateWorkspace opens a new, empty workspace and makes it active. The workspace that was active
+// keeps running in the background. Its HTTP port is one above the highest port of the open
+// workspaces, so both servers can run at once.
+func (c *Client) CreateWorkspace(ctx context.Context, name string) (models.WorkspaceInfo, error) {
+	var result models.WorkspaceInfo
+	err := c.call(ctx, "CreateWorkspace", []interface{}{name}, &result)
+	return result, err
+}
+
+// DefaultContainerHost returns the host of container endpoints without one (empty for this machine)
+func (c *Client) DefaultContainerHost(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "DefaultContainerHost", []interface{}{}, &result)
+	return result, err
+}
+
+// DeleteContainer is an alias for StopContainer (containers are removed when stopped)
+func (c *Client) DeleteContainer(ctx context.Context, endpointID string) error {
+	return c.call(ctx, "DeleteContainer", []interface{}{endpointID}, nil)
+}
+
+// DeleteEndpoint removes an endpoint by ID
+func (c *Client) DeleteEndpoint(ctx context.Context, id string) error {
+	return c.call(ctx, "DeleteEndpoint", []interface{}{id}, nil)
+}
+
+// DeleteMacro removes a macro by name
+func (c *Client) DeleteMacro(ctx context.Context, name string) error {
+	return c.call(ctx, "DeleteMacro", []interface{}{name}, nil)
+}
+
+// DeleteProfile removes a profile; deleting the active profile deactivates it
+func (c *Client) DeleteProfile(ctx context.Context, name string) error {
+	return c.call(ctx, "DeleteProfile", []interface{}{name}, nil)
+}
+
+// DeleteResponse removes a response rule by ID
+func (c *Client) DeleteResponse(ctx context.Context, id string) error {
+	return c.call(ctx, "DeleteResponse", []interface{}{id}, nil)
+}
+
+// DiscardDrafts reverts all draft responses to their published version (drafts that were never
+// published are removed) and returns how many drafts were discarded
+func (c *Client) DiscardDrafts(ctx context.Context) (int, error) {
+	var result int
+	err := c.call(ctx, "DiscardDrafts", []interface{}{}, &result)
+	return result, err
+}
+
+// DiscardRecovery removes unsaved changes left by an earlier session
+func (c *Client) DiscardRecovery(ctx context.Context, id string) error {
+	return c.call(ctx, "DiscardRecovery", []interface{}{id}, nil)
+}
+
+// DuplicateEndpoint adds a deep copy of an endpoint after the user endpoints. The copy and all of
+// its groups and responses get fresh IDs, and it is named "<name> (copy)" with "-copy" appended to
+// the path prefix, so it does not shadow the original.
+func (c *Client) DuplicateEndpoint(ctx context.Context, id string) (models.Endpoint, error) {
+	var result models.Endpoint
+	err := c.call(ctx, "DuplicateEndpoint", []interface{}{id}, &result)
+	return result, err
+}
+
+// DuplicateResponse inserts a deep copy of a response, or of a group with all of its responses,
+// right after the original, with fresh IDs. A copied group is named "<name> (copy)". A copied
+// response keeps its path pattern, so it only matches once the original is disabled or edited.
+// Returns the ID of the copy.
+func (c *Client) DuplicateResponse(ctx context.Context, id string) (string, error) {
+	var result string
+	err := c.call(ctx, "DuplicateResponse", []interface{}{id}, &result)
+	return result, err
+}
+
+// EmitEvent publishes an event to open event-stream responses, as a script's events.emit would.
+// A payload that parses as JSON is sent as that value, anything else as a string. Returns the
+// number of streams that received the event.
+func (c *Client) EmitEvent(ctx context.Context, name string, payload string) (int, error) {
+	var result int
+	err := c.call(ctx, "EmitEvent", []interface{}{name, payload}, &result)
+	return result, err
+}
+
+// ExportBackendSLA writes SLA reports for all proxy endpoints to a CSV file in the exports directory
+func (c *Client) ExportBackendSLA(ctx context.Context, window string) (string, error) {
+	var result string
+	err := c.call(ctx, "ExportBackendSLA", []interface{}{window}, &result)
+	return result, err
+}
+
+// ExportBundle writes the current config to a zip together with the files it references
+// (certificates, keys, CA bundles and .proto files), with relative paths so the bundle can be
+// imported on another machine
+func (c *Client) ExportBundle(ctx context.Context, path string) (*models.BundleReport, error) {
+	var result *models.BundleReport
+	err := c.call(ctx, "ExportBundle", []interface{}{path}, &result)
+	return result, err
+}
+
+// ExportDockerImageSpec generates a Dockerfile and docker-compose snippet that bundle
+// the headless server with the config at configPath (defaults to the current config file)
+func (c *Client) ExportDockerImageSpec(ctx context.Context, configPath string) (*models.DockerImageSpec, error) {
+	var result *models.DockerImageSpec
+	err := c.call(ctx, "ExportDockerImageSpec", []interface{}{configPath}, &result)
+	return result, err
+}
+
+// ExportKubernetesManifests generates a ConfigMap, Deployment and Service for running
+// the headless server with the config at configPath (defaults to the current config file)
+func (c *Client) ExportKubernetesManifests(ctx context.Context, configPath string) (string, error) {
+	var result string
+	err := c.call(ctx, "ExportKubernetesManifests", []interface{}{configPath}, &result)
+	return result, err
+}
+
+// ExportLogsAsCurl exports logs as a shell script with curl commands
+// endpointID filters logs by endpoint (empty string = all logs)
+// side can be "client" or "backend"
+func (c *Client) ExportLogsAsCurl(ctx context.Context, endpointID string, side string) error {
+	return c.call(ctx, "ExportLogsAsCurl", []interface{}{endpointID, side}, nil)
+}
+
+// ExportLogsAsHAR exports logs in HAR (HTTP Archive) format
+// endpointID filters logs by endpoint (empty string = all logs)
+// side can be "client" or "backend"
+func (c *Client) ExportLogsAsHAR(ctx context.Context, endpointID string, side string) error {
+	return c.call(ctx, "ExportLogsAsHAR", []interface{}{endpointID, side}, nil)
+}
+
+// ExportLogsAsJournal converts captured traffic into a replayable test and returns the file path.
+// format is "k6" (k6 script), "go" (Go test file) or "http" (REST client request file);
+// endpointID filters logs by endpoint (empty string = all logs); side can be "client" or "backend"
+func (c *Client) ExportLogsAsJournal(ctx context.Context, endpointID string, format string, side string) (string, error) {
+	var result string
+	err := c.call(ctx, "ExportLogsAsJournal", []interface{}{endpointID, format, side}, &result)
+	return result, err
+}
+
+// ExportLogsAsPcap writes captured traffic as synthesized TCP sessions to a .pcap file for Wireshark
+// and returns its path. endpointID filters logs by endpoint (empty string = all logs);
+// side is "client", "backend" (proxied requests) or "both"
+func (c *Client) ExportLogsAsPcap(ctx context.Context, endpointID string, side string) (string, error) {
+	var result string
+	err := c.call(ctx, "ExportLogsAsPcap", []interface{}{endpointID, side}, &result)
+	return result, err
+}
+
+// GenerateCurlCommand builds curl and HTTPie commands that reproduce a logged request, including its
+// method, headers and body. Proxied requests also get commands for the request sent to the backend.
+func (c *Client) GenerateCurlCommand(ctx context.Context, logID string) (models.CommandSnippets, error) {
+	var result models.CommandSnippets
+	err := c.call(ctx, "GenerateCurlCommand", []interface{}{logID}, &result)
+	return result, err
+}
+
+// GetActiveEnvironment returns the environment proxy endpoints currently use (empty = backend_url)
+func (c *Client) GetActiveEnvironment(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "GetActiveEnvironment", []interface{}{}, &result)
+	return result, err
+}
+
+// GetActiveListeners returns the names of the named listeners the running server listens on
+func (c *Client) GetActiveListeners(ctx context.Context) ([]string, error) {
+	var result []string
+	err := c.call(ctx, "GetActiveListeners", []interface{}{}, &result)
+	return result, err
+}
+
+// GetActiveProfile returns the profile whose variables are in use (empty = config variables only)
+func (c *Client) GetActiveProfile(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "GetActiveProfile", []interface{}{}, &result)
+	return result, err
+}
+
+// GetAdminAPISettings returns the admin API settings, including the token clients must send
+func (c *Client) GetAdminAPISettings(ctx context.Context) (models.AdminAPISettings, error) {
+	var result models.AdminAPISettings
+	err := c.call(ctx, "GetAdminAPISettings", []interface{}{}, &result)
+	return result, err
+}
+
+// GetAllResponseIDsWithErrors returns a list of all response IDs that have script errors
+func (c *Client) GetAllResponseIDsWithErrors(ctx context.Context) ([]string, error) {
+	var result []string
+	err := c.call(ctx, "GetAllResponseIDsWithErrors", []interface{}{}, &result)
+	return result, err
+}
+
+// GetAppLogs returns application log entries at or above level (debug, info, warn, error; empty =
+// all), optionally for one subsystem (app, server, proxy, container, socks5, dns, storage) and
+// after since (RFC3339), oldest first
+func (c *Client) GetAppLogs(ctx context.Context, level string, subsystem string, since string) ([]models.AppLogEntry, error) {
+	var result []models.AppLogEntry
+	err := c.call(ctx, "GetAppLogs", []interface{}{level, subsystem, since}, &result)
+	return result, err
+}
+
+// GetBackendSLA returns availability and latency stats for a proxy endpoint's backend
+// window is a duration such as "15m", "1h" or "7d" (default: 1h)
+func (c *Client) GetBackendSLA(ctx context.Context, endpointID string, window string) (*models.BackendSLA, error) {
+	var result *models.BackendSLA
+	err := c.call(ctx, "GetBackendSLA", []interface{}{endpointID, window}, &result)
+	return result, err
+}
+
+// GetBypassRuleStats returns the connections and bytes each SOCKS5 bypass rule has tunneled
+func (c *Client) GetBypassRuleStats(ctx context.Context) ([]models.BypassRuleStats, error) {
+	var result []models.BypassRuleStats
+	err := c.call(ctx, "GetBypassRuleStats", []interface{}{}, &result)
+	return result, err
+}
+
+// GetCACertInfo returns information about the CA certificate
+func (c *Client) GetCACertInfo(ctx context.Context) (models.CACertInfo, error) {
+	var result models.CACertInfo
+	err := c.call(ctx, "GetCACertInfo", []interface{}{}, &result)
+	return result, err
+}
+
+// GetCORSConfig returns the current CORS configuration
+func (c *Client) GetCORSConfig(ctx context.Context) (*models.CORSConfig, error) {
+	var result *models.CORSConfig
+	err := c.call(ctx, "GetCORSConfig", []interface{}{}, &result)
+	return result, err
+}
+
+// GetCertificateDetails returns the subject, names, validity window and fingerprints of the CA and,
+// while HTTPS is serving, the active server certificate. Certificates within
+// server.CertExpiryWarningDays of expiry are flagged as expiring.
+func (c *Client) GetCertificateDetails(ctx context.Context) ([]models.CertificateDetails, error) {
+	var result []models.CertificateDetails
+	err := c.call(ctx, "GetCertificateDetails", []interface{}{}, &result)
+	return result, err
+}
+
+// GetCircuitBreakers returns the circuit breaker state of every proxy endpoint that has one enabled
+func (c *Client) GetCircuitBreakers(ctx context.Context) ([]models.CircuitBreakerStatus, error) {
+	var result []models.CircuitBreakerStatus
+	err := c.call(ctx, "GetCircuitBreakers", []interface{}{}, &result)
+	return result, err
+}
+
+// GetClientThrottleStats returns the live counters of every client the throttle rules track,
+// most throttled first
+func (c *Client) GetClientThrottleStats(ctx context.Context) ([]models.ClientThrottleStats, error) {
+	var result []models.ClientThrottleStats
+	err := c.call(ctx, "GetClientThrottleStats", []interface{}{}, &result)
+	return result, err
+}
+
+// GetClientThrottles returns the per-client-IP throttle rules
+func (c *Client) GetClientThrottles(ctx context.Context) ([]models.ClientThrottleRule, error) {
+	var result []models.ClientThrottleRule
+	err := c.call(ctx, "GetClientThrottles", []interface{}{}, &result)
+	return result, err
+}
+
+// GetConfig returns the current configuration
+func (c *Client) GetConfig(ctx context.Context) (*models.AppConfig, error) {
+	var result *models.AppConfig
+	err := c.call(ctx, "GetConfig", []interface{}{}, &result)
+	return result, err
+}
+
+// GetContainerLogs retrieves container stdout/stderr logs
+func (c *Client) GetContainerLogs(ctx context.Context, endpointID string, tail int) (string, error) {
+	var result string
+	err := c.call(ctx, "GetContainerLogs", []interface{}{endpointID, tail}, &result)
+	return result, err
+}
+
+// GetContainerStats returns the resource usage stats for a container endpoint
+func (c *Client) GetContainerStats(ctx context.Context, endpointID string) (*models.ContainerStats, error) {
+	var result *models.ContainerStats
+	err := c.call(ctx, "GetContainerStats", []interface{}{endpointID}, &result)
+	return result, err
+}
+
+// GetContainerStatus returns the runtime status for a container endpoint
+func (c *Client) GetContainerStatus(ctx context.Context, endpointID string) (*models.ContainerStatus, error) {
+	var result *models.ContainerStatus
+	err := c.call(ctx, "GetContainerStatus", []interface{}{endpointID}, &result)
+	return result, err
+}
+
+// GetCurrentConfigPath returns the current config file path
+func (c *Client) GetCurrentConfigPath(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "GetCurrentConfigPath", []interface{}{}, &result)
+	return result, err
+}
+
+// GetDNSConfig returns the DNS listener configuration (nil if never configured)
+func (c *Client) GetDNSConfig(ctx context.Context) (*models.DNSConfig, error) {
+	var result *models.DNSConfig
+	err := c.call(ctx, "GetDNSConfig", []interface{}{}, &result)
+	return result, err
+}
+
+// GetDefaultCertNames returns the default DNS names and IP addresses that will be used for certificates
+// Returns a list of strings containing: localhost, machine hostname, and interface IP for default gateway
+func (c *Client) GetDefaultCertNames(ctx context.Context) ([]string, error) {
+	var result []string
+	err := c.call(ctx, "GetDefaultCertNames", []interface{}{}, &result)
+	return result, err
+}
+
+// GetDefaultContainerHeaders returns the default inbound headers for container endpoints
+func (c *Client) GetDefaultContainerHeaders(ctx context.Context) ([]models.HeaderManipulation, error) {
+	var result []models.HeaderManipulation
+	err := c.call(ctx, "GetDefaultContainerHeaders", []interface{}{}, &result)
+	return result, err
+}
+
+// GetDraftCount returns the number of responses with unpublished edits
+func (c *Client) GetDraftCount(ctx context.Context) (int, error) {
+	var result int
+	err := c.call(ctx, "GetDraftCount", []interface{}{}, &result)
+	return result, err
+}
+
+// GetEndpoint returns the endpoint with the given ID
+func (c *Client) GetEndpoint(ctx context.Context, id string) (models.Endpoint, error) {
+	var result models.Endpoint
+	err := c.call(ctx, "GetEndpoint", []interface{}{id}, &result)
+	return result, err
+}
+
+// GetEndpointHealth returns health status for an endpoint
+func (c *Client) GetEndpointHealth(ctx context.Context, endpointID string) (*models.HealthStatus, error) {
+	var result *models.HealthStatus
+	err := c.call(ctx, "GetEndpointHealth", []interface{}{endpointID}, &result)
+	return result, err
+}
+
+// GetEndpointListenerPorts returns the dedicated endpoint ports the running server listens on
+func (c *Client) GetEndpointListenerPorts(ctx context.Context) ([]int, error) {
+	var result []int
+	err := c.call(ctx, "GetEndpointListenerPorts", []interface{}{}, &result)
+	return result, err
+}
+
+// GetEndpointTypes returns the endpoint types provided by registered plugins
+func (c *Client) GetEndpointTypes(ctx context.Context) ([]models.EndpointTypeInfo, error) {
+	var result []models.EndpointTypeInfo
+	err := c.call(ctx, "GetEndpointTypes", []interface{}{}, &result)
+	return result, err
+}
+
+// GetEndpoints returns all endpoints sorted by DisplayOrder
+func (c *Client) GetEndpoints(ctx context.Context) ([]models.Endpoint, error) {
+	var result []models.Endpoint
+	err := c.call(ctx, "GetEndpoints", []interface{}{}, &result)
+	return result, err
+}
+
+// GetEnvironments returns the environment names defined by any proxy endpoint, sorted
+func (c *Client) GetEnvironments(ctx context.Context) ([]string, error) {
+	var result []string
+	err := c.call(ctx, "GetEnvironments", []interface{}{}, &result)
+	return result, err
+}
+
+// GetGRPCConfig returns the gRPC listener configuration (nil if never configured)
+func (c *Client) GetGRPCConfig(ctx context.Context) (*models.GRPCConfig, error) {
+	var result *models.GRPCConfig
+	err := c.call(ctx, "GetGRPCConfig", []interface{}{}, &result)
+	return result, err
+}
+
+// GetGRPCMethods lists the methods described by the configured .proto files
+func (c *Client) GetGRPCMethods(ctx context.Context) ([]models.GRPCMethodInfo, error) {
+	var result []models.GRPCMethodInfo
+	err := c.call(ctx, "GetGRPCMethods", []interface{}{}, &result)
+	return result, err
+}
+
+// GetItems returns all response items (responses and groups)
+func (c *Client) GetItems(ctx context.Context) ([]models.ResponseItem, error) {
+	var result []models.ResponseItem
+	err := c.call(ctx, "GetItems", []interface{}{}, &result)
+	return result, err
+}
+
+// GetJWTKeys returns the named keys of the jwt helpers in templates and scripts
+func (c *Client) GetJWTKeys(ctx context.Context) ([]models.JWTSigningKey, error) {
+	var result []models.JWTSigningKey
+	err := c.call(ctx, "GetJWTKeys", []interface{}{}, &result)
+	return result, err
+}
+
+// GetListeners returns the named listeners endpoints can be assigned to
+func (c *Client) GetListeners(ctx context.Context) ([]models.Listener, error) {
+	var result []models.Listener
+	err := c.call(ctx, "GetListeners", []interface{}{}, &result)
+	return result, err
+}
+
+// GetLogSettings returns the application log level and file settings
+func (c *Client) GetLogSettings(ctx context.Context) (models.LogSettings, error) {
+	var result models.LogSettings
+	err := c.call(ctx, "GetLogSettings", []interface{}{}, &result)
+	return result, err
+}
+
+// GetMacroRecording returns the name of the macro being recorded ("" when not recording)
+func (c *Client) GetMacroRecording(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "GetMacroRecording", []interface{}{}, &result)
+	return result, err
+}
+
+// GetMacros returns the recorded macros
+func (c *Client) GetMacros(ctx context.Context) ([]models.Macro, error) {
+	var result []models.Macro
+	err := c.call(ctx, "GetMacros", []interface{}{}, &result)
+	return result, err
+}
+
+// GetMarketplaceSources returns the configured endpoint bundle registries
+func (c *Client) GetMarketplaceSources(ctx context.Context) ([]models.MarketplaceSource, error) {
+	var result []models.MarketplaceSource
+	err := c.call(ctx, "GetMarketplaceSources", []interface{}{}, &result)
+	return result, err
+}
+
+// GetOfflineMode returns whether offline mode is on and how each proxy/container endpoint is covered
+func (c *Client) GetOfflineMode(ctx context.Context) (*models.OfflineModeStatus, error) {
+	var result *models.OfflineModeStatus
+	err := c.call(ctx, "GetOfflineMode", []interface{}{}, &result)
+	return result, err
+}
+
+// GetPACFile returns the proxy auto-config file as served at GetPACURL, for saving to disk
+func (c *Client) GetPACFile(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "GetPACFile", []interface{}{}, &result)
+	return result, err
+}
+
+// GetPACURL returns the URL of the proxy auto-config file on this machine's network address,
+// for pointing browsers and devices at the proxies for the taken-over domains only
+func (c *Client) GetPACURL(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "GetPACURL", []interface{}{}, &result)
+	return result, err
+}
+
+// GetPatternErrors returns all regex patterns in the current config that do not compile
+func (c *Client) GetPatternErrors(ctx context.Context) ([]models.PatternError, error) {
+	var result []models.PatternError
+	err := c.call(ctx, "GetPatternErrors", []interface{}{}, &result)
+	return result, err
+}
+
+// GetPcapCapture returns the path of the running capture ("" when not capturing)
+func (c *Client) GetPcapCapture(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "GetPcapCapture", []interface{}{}, &result)
+	return result, err
+}
+
+// GetProfiles returns the named variable sets of the config
+func (c *Client) GetProfiles(ctx context.Context) (map[string]map[string]string, error) {
+	var result map[string]map[string]string
+	err := c.call(ctx, "GetProfiles", []interface{}{}, &result)
+	return result, err
+}
+
+// GetProxyBackends returns the health and traffic of each backend in a proxy endpoint's pool
+func (c *Client) GetProxyBackends(ctx context.Context, endpointID string) ([]models.ProxyBackendStatus, error) {
+	var result []models.ProxyBackendStatus
+	err := c.call(ctx, "GetProxyBackends", []interface{}{endpointID}, &result)
+	return result, err
+}
+
+// GetRecentFiles returns the list of recent files with existence check
+// Limited to 24 most recent files (3 columns × 8 rows)
+func (c *Client) GetRecentFiles(ctx context.Context) ([]models.RecentFile, error) {
+	var result []models.RecentFile
+	err := c.call(ctx, "GetRecentFiles", []interface{}{}, &result)
+	return result, err
+}
+
+// GetRecoveries returns the unsaved changes left by an earlier session, newest first, so they
+// can be restored after a crash. Changes to a config file that was saved after them are
+// outdated; they are removed and not returned.
+func (c *Client) GetRecoveries(ctx context.Context) ([]models.RecoveryInfo, error) {
+	var result []models.RecoveryInfo
+	err := c.call(ctx, "GetRecoveries", []interface{}{}, &result)
+	return result, err
+}
+
+// GetRequestLogByID returns a specific request log by ID
+func (c *Client) GetRequestLogByID(ctx context.Context, id string) (*models.RequestLog, error) {
+	var result *models.RequestLog
+	err := c.call(ctx, "GetRequestLogByID", []interface{}{id}, &result)
+	return result, err
+}
+
+// GetRequestLogDetails returns the full RequestLog details for a given ID
+func (c *Client) GetRequestLogDetails(ctx context.Context, id string) (*models.RequestLog, error) {
+	var result *models.RequestLog
+	err := c.call(ctx, "GetRequestLogDetails", []interface{}{id}, &result)
+	return result, err
+}
+
+// GetRequestLogs returns all request log summaries
+func (c *Client) GetRequestLogs(ctx context.Context) ([]models.RequestLogSummary, error) {
+	var result []models.RequestLogSummary
+	err := c.call(ctx, "GetRequestLogs", []interface{}{}, &result)
+	return result, err
+}
+
+// GetRequestLogsByTraffic returns the request log summaries of one kind of traffic:
+// "intercepted" (HTTPS decrypted by SOCKS5 TLS interception) or "direct" (not through the SOCKS5 proxy).
+// An empty filter returns all logs.
+func (c *Client) GetRequestLogsByTraffic(ctx context.Context, traffic string) ([]models.RequestLogSummary, error) {
+	var result []models.RequestLogSummary
+	err := c.call(ctx, "GetRequestLogsByTraffic", []interface{}{traffic}, &result)
+	return result, err
+}
+
+// GetResponsePerfStats returns template/script execution time and error counts per response since
+// the server started, slowest (by total time) first
+func (c *Client) GetResponsePerfStats(ctx context.Context) ([]models.ResponsePerfStats, error) {
+	var result []models.ResponsePerfStats
+	err := c.call(ctx, "GetResponsePerfStats", []interface{}{}, &result)
+	return result, err
+}
+
+// GetResponses returns all response rules (legacy - for backward compatibility)
+func (c *Client) GetResponses(ctx context.Context) ([]models.MethodResponse, error) {
+	var result []models.MethodResponse
+	err := c.call(ctx, "GetResponses", []interface{}{}, &result)
+	return result, err
+}
+
+// GetRetryAfterReport checks the request logs for clients that came back before the Retry-After
+// they were sent (by client throttling, scheduled maintenance or any response) had elapsed
+func (c *Client) GetRetryAfterReport(ctx context.Context) (models.RetryAfterReport, error) {
+	var result models.RetryAfterReport
+	err := c.call(ctx, "GetRetryAfterReport", []interface{}{}, &result)
+	return result, err
+}
+
+// GetRuntimeHealth returns the goroutine and memory samples, any leak warnings and the largest
+// groups of goroutines sharing a stack
+func (c *Client) GetRuntimeHealth(ctx context.Context) (models.RuntimeHealth, error) {
+	var result models.RuntimeHealth
+	err := c.call(ctx, "GetRuntimeHealth", []interface{}{}, &result)
+	return result, err
+}
+
+// GetSOCKS5Config returns the current SOCKS5 and domain takeover configuration
+func (c *Client) GetSOCKS5Config(ctx context.Context) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := c.call(ctx, "GetSOCKS5Config", []interface{}{}, &result)
+	return result, err
+}
+
+// GetSchedule returns the running server's schedule with due times and states
+func (c *Client) GetSchedule(ctx context.Context) ([]models.ScheduledActionStatus, error) {
+	var result []models.ScheduledActionStatus
+	err := c.call(ctx, "GetSchedule", []interface{}{}, &result)
+	return result, err
+}
+
+// GetScheduledActions returns the actions scheduled at each server start
+func (c *Client) GetScheduledActions(ctx context.Context) ([]models.ScheduledAction, error) {
+	var result []models.ScheduledAction
+	err := c.call(ctx, "GetScheduledActions", []interface{}{}, &result)
+	return result, err
+}
+
+// GetScriptErrors returns all script errors for a given response ID
+func (c *Client) GetScriptErrors(ctx context.Context, responseID string) ([]json.RawMessage, error) {
+	var result []json.RawMessage
+	err := c.call(ctx, "GetScriptErrors", []interface{}{responseID}, &result)
+	return result, err
+}
+
+// GetScriptFetchConfig returns the http.fetch settings of script-mode responses
+func (c *Client) GetScriptFetchConfig(ctx context.Context) (*models.ScriptFetchConfig, error) {
+	var result *models.ScriptFetchConfig
+	err := c.call(ctx, "GetScriptFetchConfig", []interface{}{}, &result)
+	return result, err
+}
+
+// GetScriptModules returns the shared JavaScript modules that scripts load with require()
+func (c *Client) GetScriptModules(ctx context.Context) ([]models.ScriptModule, error) {
+	var result []models.ScriptModule
+	err := c.call(ctx, "GetScriptModules", []interface{}{}, &result)
+	return result, err
+}
+
+// GetScriptWarnings returns all scripts, templates and expressions in the current config that do not compile
+func (c *Client) GetScriptWarnings(ctx context.Context) ([]models.ScriptWarning, error) {
+	var result []models.ScriptWarning
+	err := c.call(ctx, "GetScriptWarnings", []interface{}{}, &result)
+	return result, err
+}
+
+// GetSelectedEndpointId returns the currently selected endpoint ID from ServerConfig
+func (c *Client) GetSelectedEndpointId(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "GetSelectedEndpointId", []interface{}{}, &result)
+	return result, err
+}
+
+// GetServerStatus returns the current server status
+func (c *Client) GetServerStatus(ctx context.Context) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := c.call(ctx, "GetServerStatus", []interface{}{}, &result)
+	return result, err
+}
+
+// GetStorageSettings returns the selected config storage backend
+func (c *Client) GetStorageSettings(ctx context.Context) (models.StorageSettings, error) {
+	var result models.StorageSettings
+	err := c.call(ctx, "GetStorageSettings", []interface{}{}, &result)
+	return result, err
+}
+
+// GetTrafficStats returns the bytes carried by each SOCKS5 tunnel destination and proxy endpoint,
+// with the bandwidth over the last few seconds; most traffic first
+func (c *Client) GetTrafficStats(ctx context.Context) ([]models.TrafficStats, error) {
+	var result []models.TrafficStats
+	err := c.call(ctx, "GetTrafficStats", []interface{}{}, &result)
+	return result, err
+}
+
+// GetTransactions groups request logs into logical transactions by correlation header
+// or SOCKS5 connection. If correlationHeader is empty, common correlation headers
+// (X-Correlation-ID, X-Request-ID, traceparent) are checked in order.
+func (c *Client) GetTransactions(ctx context.Context, correlationHeader string) ([]models.Transaction, error) {
+	var result []models.Transaction
+	err := c.call(ctx, "GetTransactions", []interface{}{correlationHeader}, &result)
+	return result, err
+}
+
+// GetUndoStatus reports whether edits can be undone or redone, and which
+func (c *Client) GetUndoStatus(ctx context.Context) (models.UndoStatus, error) {
+	var result models.UndoStatus
+	err := c.call(ctx, "GetUndoStatus", []interface{}{}, &result)
+	return result, err
+}
+
+// GetVariables returns the config variables that ${NAME} references in backend URLs, headers,
+// bodies and container environments resolve to
+func (c *Client) GetVariables(ctx context.Context) (map[string]string, error) {
+	var result map[string]string
+	err := c.call(ctx, "GetVariables", []interface{}{}, &result)
+	return result, err
+}
+
+// GetVirtualTime returns the current virtual time (RFC3339) used by time-dependent mock features
+func (c *Client) GetVirtualTime(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, "GetVirtualTime", []interface{}{}, &result)
+	return result, err
+}
+
+// ImportBundle extracts a bundle into destDir and loads its config, with the bundled files
+// referenced from there. The config is saved in destDir as config.yaml.
+func (c *Client) ImportBundle(ctx context.Context, zipPath string, destDir string) (*models.BundleReport, error) {
+	var result *models.BundleReport
+	err := c.call(ctx, "ImportBundle", []interface{}{zipPath, destDir}, &result)
+	return result, err
+}
+
+// ImportDockerComposeWithDialog creates a container endpoint from a docker-compose.yml: mainService
+// (the only service that publishes ports when empty) becomes the routed container and every other
+// service a sidecar on the shared network. Returns nil if the user cancelled the dialog.
+func (c *Client) ImportDockerComposeWithDialog(ctx context.Context, mainService string) (*models.Endpoint, error) {
+	var result *models.Endpoint
+	err := c.call(ctx, "ImportDockerComposeWithDialog", []interface{}{mainService}, &result)
+	return result, err
+}
+
+// InstallMarketplaceBundle downloads a bundle, verifies its checksum and adds its
+// endpoints to the current config (with fresh IDs, before system endpoints)
+func (c *Client) InstallMarketplaceBundle(ctx context.Context, sourceName string, bundleID string) ([]models.Endpoint, error) {
+	var result []models.Endpoint
+	err := c.call(ctx, "InstallMarketplaceBundle", []interface{}{sourceName, bundleID}, &result)
+	return result, err
+}
+
+// IsDirty returns true if current config differs from saved config
+func (c *Client) IsDirty(ctx context.Context) (bool, error) {
+	var result bool
+	err := c.call(ctx, "IsDirty", []interface{}{}, &result)
+	return result, err
+}
+
+// ListConfigBackups returns the backups of the current config file, newest first. A backup is
+// written on every save; the last 20 are kept.
+func (c *Client) ListConfigBackups(ctx context.Context) ([]models.ConfigBackup, error) {
+	var result []models.ConfigBackup
+	err := c.call(ctx, "ListConfigBackups", []interface{}{}, &result)
+	return result, err
+}
+
+// ListMarketplaceBundles fetches the indexes of all configured sources
+// Sources that fail are logged and skipped; an error is returned only if every source fails
+func (c *Client) ListMarketplaceBundles(ctx context.Context) ([]models.MarketplaceBundle, error) {
+	var result []models.MarketplaceBundle
+	err := c.call(ctx, "ListMarketplaceBundles", []interface{}{}, &result)
+	return result, err
+}
+
+// ListWorkspaces returns the open workspaces; the active one is marked
+func (c *Client) ListWorkspaces(ctx context.Context) ([]models.WorkspaceInfo, error) {
+	var result []models.WorkspaceInfo
+	err := c.call(ctx, "ListWorkspaces", []interface{}{}, &result)
+	return result, err
+}
+
+// LoadConfigFromPath loads configuration from a specific file path
+func (c *Client) LoadConfigFromPath(ctx context.Context, path string) (*models.AppConfig, error) {
+	var result *models.AppConfig
+	err := c.call(ctx, "LoadConfigFromPath", []interface{}{path}, &result)
+	return result, err
+}
+
+// MarkClean updates savedConfig to current state
+// Called after successful save
+func (c *Client) MarkClean(ctx context.Context) error {
+	return c.call(ctx, "MarkClean", []interface{}{}, nil)
+}
+
+// MarkDirty marks the config as dirty (without updating savedConfig)
+// Called when user makes changes in Server tab
+func (c *Client) MarkDirty(ctx context.Context) error {
+	return c.call(ctx, "MarkDirty", []interface{}{}, nil)
+}
+
+// MergeConfigs merges the other config file into the base file and loads the result as the current,
+// unsaved config (saving writes to basePath). Entries present in both files with different content are
+// resolved with the strategy: "base" keeps the base version, "other" takes the other file's version and
+// "both" keeps the base version and adds the other as a copy. Server settings come from the base file.
+func (c *Client) MergeConfigs(ctx context.Context, basePath string, otherPath string, strategy string) (*models.MergeReport, error) {
+	var result *models.MergeReport
+	err := c.call(ctx, "MergeConfigs", []interface{}{basePath, otherPath, strategy}, &result)
+	return result, err
+}
+
+// PollEvents returns all queued events and clears the queue
+// This is called by the frontend at regular intervals (polling)
+func (c *Client) PollEvents(ctx context.Context) ([]json.RawMessage, error) {
+	var result []json.RawMessage
+	err := c.call(ctx, "PollEvents", []interface{}{}, &result)
+	return result, err
+}
+
+// PollRequestLogs returns all queued request log summaries and clears the queue
+// This is called by the frontend at regular intervals (polling) for efficient batching
+// during high-volume traffic
+func (c *Client) PollRequestLogs(ctx context.Context) ([]models.RequestLogSummary, error) {
+	var result []models.RequestLogSummary
+	err := c.call(ctx, "PollRequestLogs", []interface{}{}, &result)
+	return result, err
+}
+
+// PreviewMergeConfigs reports how two config files would merge, without changing the current config
+func (c *Client) PreviewMergeConfigs(ctx context.Context, basePath string, otherPath string, strategy string) (*models.MergeReport, error) {
+	var result *models.MergeReport
+	err := c.call(ctx, "PreviewMergeConfigs", []interface{}{basePath, otherPath, strategy}, &result)
+	return result, err
+}
+
+// PublishDrafts makes all draft responses live and returns how many were published
+func (c *Client) PublishDrafts(ctx context.Context) (int, error) {
+	var result int
+	err := c.call(ctx, "PublishDrafts", []interface{}{}, &result)
+	return result, err
+}
+
+// PullDockerImage pulls a Docker image from the registry
+func (c *Client) PullDockerImage(ctx context.Context, imageName string) error {
+	return c.call(ctx, "PullDockerImage", []interface{}{imageName}, nil)
+}
+
+// Redo applies the last undone edit again
+func (c *Client) Redo(ctx context.Context) (models.UndoStatus, error) {
+	var result models.UndoStatus
+	err := c.call(ctx, "Redo", []interface{}{}, &result)
+	return result, err
+}
+
+// RegenerateCA regenerates the CA certificate and swaps it into the running server. Connections
+// already open keep their certificate; new handshakes use the new one.
+func (c *Client) RegenerateCA(ctx context.Context) error {
+	return c.call(ctx, "RegenerateCA", []interface{}{}, nil)
+}
+
+// RegistryCredentials returns the global private registry logins
+// Implements server.RegistryCredentialSource for the container handler's image pulls
+func (c *Client) RegistryCredentials(ctx context.Context) ([]models.RegistryCredential, error) {
+	var result []models.RegistryCredential
+	err := c.call(ctx, "RegistryCredentials", []interface{}{}, &result)
+	return result, err
+}
+
+// RemoveRecentFile removes a file from the recent files list
+func (c *Client) RemoveRecentFile(ctx context.Context, path string) error {
+	return c.call(ctx, "RemoveRecentFile", []interface{}{path}, nil)
+}
+
+// RenameWorkspace changes the display name of a workspace
+func (c *Client) RenameWorkspace(ctx context.Context, id string, name string) error {
+	return c.call(ctx, "RenameWorkspace", []interface{}{id, name}, nil)
+}
+
+// ReorderResponses reorders response rules based on the provided ID order
+func (c *Client) ReorderResponses(ctx context.Context, ids []string) error {
+	return c.call(ctx, "ReorderResponses", []interface{}{ids}, nil)
+}
+
+// ReplayRequest re-sends a logged client request and returns the new response next to the original one.
+// targetBaseURL (scheme://host[:port][/prefix]) receives the request's path and query; empty targets the running mock.
+func (c *Client) ReplayRequest(ctx context.Context, logID string, targetBaseURL string) (models.ReplayResult, error) {
+	var result models.ReplayResult
+	err := c.call(ctx, "ReplayRequest", []interface{}{logID, targetBaseURL}, &result)
+	return result, err
+}
+
+// ReplayRequests re-sends several logged client requests in order (see ReplayRequest).
+// A request that fails to send is reported in its result's error rather than stopping the run.
+func (c *Client) ReplayRequests(ctx context.Context, logIDs []string, targetBaseURL string) ([]models.ReplayResult, error) {
+	var result []models.ReplayResult
+	err := c.call(ctx, "ReplayRequests", []interface{}{logIDs, targetBaseURL}, &result)
+	return result, err
+}
+
+// ResetBypassRuleStats clears the SOCKS5 bypass rule counters
+func (c *Client) ResetBypassRuleStats(ctx context.Context) error {
+	return c.call(ctx, "ResetBypassRuleStats", []interface{}{}, nil)
+}
+
+// ResetCircuitBreaker closes a proxy endpoint's circuit and clears its counters (all of them if
+// endpointID is empty)
+func (c *Client) ResetCircuitBreaker(ctx context.Context, endpointID string) error {
+	return c.call(ctx, "ResetCircuitBreaker", []interface{}{endpointID}, nil)
+}
+
+// ResetClientThrottleStats forgets every tracked client, refilling their buckets
+func (c *Client) ResetClientThrottleStats(ctx context.Context) error {
+	return c.call(ctx, "ResetClientThrottleStats", []interface{}{}, nil)
+}
+
+// ResetResponsePerfStats clears the template/script execution statistics
+func (c *Client) ResetResponsePerfStats(ctx context.Context) error {
+	return c.call(ctx, "ResetResponsePerfStats", []interface{}{}, nil)
+}
+
+// ResetSequences moves sequence responses back to their first step. An empty response ID resets
+// every sequence.
+func (c *Client) ResetSequences(ctx context.Context, responseID string) error {
+	return c.call(ctx, "ResetSequences", []interface{}{responseID}, nil)
+}
+
+// ResetState returns the running mock to a clean slate between test runs: it clears the request
+// logs and script errors and resets sequences, circuit breakers and every statistics counter. The
+// config is kept.
+func (c *Client) ResetState(ctx context.Context) error {
+	return c.call(ctx, "ResetState", []interface{}{}, nil)
+}
+
+// ResetTrafficStats clears the SOCKS5 tunnel and proxy endpoint traffic counters
+func (c *Client) ResetTrafficStats(ctx context.Context) error {
+	return c.call(ctx, "ResetTrafficStats", []interface{}{}, nil)
+}
+
+// RestartContainer restarts a container endpoint
+func (c *Client) RestartContainer(ctx context.Context, endpointID string) error {
+	return c.call(ctx, "RestartContainer", []interface{}{endpointID}, nil)
+}
+
+// RestoreConfigBackup loads a backup of the current config file as the current, unsaved config.
+// Saving writes it back to the config file. The endpoint and response changes of the restore can
+// be undone.
+func (c *Client) RestoreConfigBackup(ctx context.Context, id string) (*models.AppConfig, error) {
+	var result *models.AppConfig
+	err := c.call(ctx, "RestoreConfigBackup", []interface{}{id}, &result)
+	return result, err
+}
+
+// RestoreRecovery loads unsaved changes from an earlier session into the active workspace. The
+// config file they belong to is loaded first, so the restored changes show as unsaved and can be
+// undone. The recovery file is removed.
+func (c *Client) RestoreRecovery(ctx context.Context, id string) (*models.AppConfig, error) {
+	var result *models.AppConfig
+	err := c.call(ctx, "RestoreRecovery", []interface{}{id}, &result)
+	return result, err
+}
+
+// RunMacro plays a macro back in the background, waiting each step's recorded delay.
+// Scheduled steps become runtime overrides on the running server, like ScheduleAction.
+func (c *Client) RunMacro(ctx context.Context, name string) error {
+	return c.call(ctx, "RunMacro", []interface{}{name}, nil)
+}
+
+// SaveConfigToDirectory saves the config in directory layout: dir/mockelot.yaml holds the server
+// settings and shared sections, and dir/endpoints holds one file per endpoint. The config stays
+// in this layout when it is saved again.
+func (c *Client) SaveConfigToDirectory(ctx context.Context, dir string) error {
+	return c.call(ctx, "SaveConfigToDirectory", []interface{}{dir}, nil)
+}
+
+// SaveCurrentConfig saves to the current config file (overwrites)
+func (c *Client) SaveCurrentConfig(ctx context.Context) error {
+	return c.call(ctx, "SaveCurrentConfig", []interface{}{}, nil)
+}
+
+// SaveCurrentConfigWithMessage saves to the current config file, describing the change with the
+// given message (used as the commit message by the Git storage backend)
+func (c *Client) SaveCurrentConfigWithMessage(ctx context.Context, message string) error {
+	return c.call(ctx, "SaveCurrentConfigWithMessage", []interface{}{message}, nil)
+}
+
+// ScheduleAction schedules an action on the running server, relative to now. It is not saved.
+func (c *Client) ScheduleAction(ctx context.Context, action models.ScheduledAction) (*models.ScheduledActionStatus, error) {
+	var result *models.ScheduledActionStatus
+	err := c.call(ctx, "ScheduleAction", []interface{}{action}, &result)
+	return result, err
+}
+
+// SearchRequestLogs returns one page of the summaries of the request logs matching a filter
+// (method, path regex, status range, endpoint, time window, body substring, header matchers)
+func (c *Client) SearchRequestLogs(ctx context.Context, filter models.RequestLogFilter) (models.RequestLogPage, error) {
+	var result models.RequestLogPage
+	err := c.call(ctx, "SearchRequestLogs", []interface{}{filter}, &result)
+	return result, err
+}
+
+// SetActiveEnvironment switches every proxy endpoint to its backend URL for the named environment.
+// Proxies without a URL for it keep their backend_url. An empty name switches all back to backend_url.
+func (c *Client) SetActiveEnvironment(ctx context.Context, name string) error {
+	return c.call(ctx, "SetActiveEnvironment", []interface{}{name}, nil)
+}
+
+// SetActiveProfile selects the profile whose variables are used, e.g. before starting the server.
+// A running server switches right away. An empty name uses the config variables only.
+func (c *Client) SetActiveProfile(ctx context.Context, name string) error {
+	return c.call(ctx, "SetActiveProfile", []interface{}{name}, nil)
+}
+
+// SetAdminAPISettings saves the admin API settings and starts, restarts or stops the listener.
+// An empty token is replaced with a random one.
+func (c *Client) SetAdminAPISettings(ctx context.Context, settings models.AdminAPISettings) (models.AdminAPISettings, error) {
+	var result models.AdminAPISettings
+	err := c.call(ctx, "SetAdminAPISettings", []interface{}{settings}, &result)
+	return result, err
+}
+
+// SetClientThrottles replaces the per-client-IP throttle rules. Rules without an ID get one;
+// the first enabled rule matching a request's client and endpoint applies.
+func (c *Client) SetClientThrottles(ctx context.Context, rules []models.ClientThrottleRule) error {
+	return c.call(ctx, "SetClientThrottles", []interface{}{rules}, nil)
+}
+
+// SetDNSConfig validates and stores the DNS configuration and restarts the DNS listener
+// if the server is running
+func (c *Client) SetDNSConfig(ctx context.Context, cfg models.DNSConfig) error {
+	return c.call(ctx, "SetDNSConfig", []interface{}{cfg}, nil)
+}
+
+// SetGRPCConfig validates the .proto files, stores the gRPC configuration and restarts the
+// gRPC listener if the server is running. Returns the methods described by the protos.
+func (c *Client) SetGRPCConfig(ctx context.Context, cfg models.GRPCConfig) ([]models.GRPCMethodInfo, error) {
+	var result []models.GRPCMethodInfo
+	err := c.call(ctx, "SetGRPCConfig", []interface{}{cfg}, &result)
+	return result, err
+}
+
+// SetItems replaces all response items for the selected endpoint
+func (c *Client) SetItems(ctx context.Context, items []models.ResponseItem) error {
+	return c.call(ctx, "SetItems", []interface{}{items}, nil)
+}
+
+// SetJWTKeys replaces the named JWT keys. Names must be unique and every key must parse.
+func (c *Client) SetJWTKeys(ctx context.Context, keys []models.JWTSigningKey) error {
+	return c.call(ctx, "SetJWTKeys", []interface{}{keys}, nil)
+}
+
+// SetListeners replaces the named listeners. Names and ports must be unique, and listeners that
+// endpoints are still assigned to cannot be removed.
+func (c *Client) SetListeners(ctx context.Context, listeners []models.Listener) error {
+	return c.call(ctx, "SetListeners", []interface{}{listeners}, nil)
+}
+
+// SetLogSettings applies and saves the application log level and file settings
+func (c *Client) SetLogSettings(ctx context.Context, settings models.LogSettings) error {
+	return c.call(ctx, "SetLogSettings", []interface{}{settings}, nil)
+}
+
+// SetOfflineMode switches all proxy and container endpoints to serve their recorded snapshot endpoints
+// instead of contacting backends (or back to live traffic). When enabling, completed responses in the
+// request log are first recorded into each endpoint's snapshot, creating the snapshot if needed.
+func (c *Client) SetOfflineMode(ctx context.Context, enabled bool) (*models.OfflineModeStatus, error) {
+	var result *models.OfflineModeStatus
+	err := c.call(ctx, "SetOfflineMode", []interface{}{enabled}, &result)
+	return result, err
+}
+
+// SetProfile creates or replaces a profile; its variables override the config variables while it
+// is active
+func (c *Client) SetProfile(ctx context.Context, name string, variables map[string]string) error {
+	return c.call(ctx, "SetProfile", []interface{}{name, variables}, nil)
+}
+
+// SetResponses replaces all response rules with the provided list
+func (c *Client) SetResponses(ctx context.Context, responses []models.MethodResponse) error {
+	return c.call(ctx, "SetResponses", []interface{}{responses}, nil)
+}
+
+// SetScheduledActions replaces the actions scheduled at server start (takes effect on the next start)
+func (c *Client) SetScheduledActions(ctx context.Context, actions []models.ScheduledAction) error {
+	return c.call(ctx, "SetScheduledActions", []interface{}{actions}, nil)
+}
+
+// SetScriptFetchConfig updates the http.fetch settings of script-mode responses
+func (c *Client) SetScriptFetchConfig(ctx context.Context, cfg models.ScriptFetchConfig) error {
+	return c.call(ctx, "SetScriptFetchConfig", []interface{}{cfg}, nil)
+}
+
+// SetScriptModules replaces the shared script modules. Names must be unique and every module must compile.
+func (c *Client) SetScriptModules(ctx context.Context, modules []models.ScriptModule) error {
+	return c.call(ctx, "SetScriptModules", []interface{}{modules}, nil)
+}
+
+// SetSelectedEndpointId sets the currently selected endpoint ID and saves to ServerConfig
+func (c *Client) SetSelectedEndpointId(ctx context.Context, endpointId string) error {
+	return c.call(ctx, "SetSelectedEndpointId", []interface{}{endpointId}, nil)
+}
+
+// SetStorageSettings selects where configs are loaded from and saved to and remembers the choice
+func (c *Client) SetStorageSettings(ctx context.Context, settings models.StorageSettings) error {
+	return c.call(ctx, "SetStorageSettings", []interface{}{settings}, nil)
+}
+
+// SetVariables replaces the config variables. Names not defined here or in the active profile are
+// looked up in the OS environment.
+func (c *Client) SetVariables(ctx context.Context, variables map[string]string) error {
+	return c.call(ctx, "SetVariables", []interface{}{variables}, nil)
+}
+
+// SetVirtualClock replaces the virtual clock (nil restores real time)
+func (c *Client) SetVirtualClock(ctx context.Context, clock *models.VirtualClock) error {
+	return c.call(ctx, "SetVirtualClock", []interface{}{clock}, nil)
+}
+
+// StartContainer starts a single container endpoint
+func (c *Client) StartContainer(ctx context.Context, endpointID string) error {
+	return c.call(ctx, "StartContainer", []interface{}{endpointID}, nil)
+}
+
+// StartContainers starts all container endpoints in the background
+// Events are sent via the event channel to the frontend
+func (c *Client) StartContainers(ctx context.Context) error {
+	return c.call(ctx, "StartContainers", []interface{}{}, nil)
+}
+
+// StartMacroRecording starts recording state changes (scheduled actions, offline mode and environment
+// switches) into a macro. Recording again under an existing name replaces that macro when stopped.
+func (c *Client) StartMacroRecording(ctx context.Context, name string) error {
+	return c.call(ctx, "StartMacroRecording", []interface{}{name}, nil)
+}
+
+// StartPcapCapture mirrors every completed request (client side, and the backend side of proxied
+// requests) into a pcap file as it is logged. An empty path creates a timestamped file in exports/.
+// Returns the path of the capture file.
+func (c *Client) StartPcapCapture(ctx context.Context, path string) (string, error) {
+	var result string
+	err := c.call(ctx, "StartPcapCapture", []interface{}{path}, &result)
+	return result, err
+}
+
+// StartServer starts the HTTP mock server on the specified port
+func (c *Client) StartServer(ctx context.Context, port int) error {
+	return c.call(ctx, "StartServer", []interface{}{port}, nil)
+}
+
+// StopContainer stops (and removes) a single container endpoint
+func (c *Client) StopContainer(ctx context.Context, endpointID string) error {
+	return c.call(ctx, "StopContainer", []interface{}{endpointID}, nil)
+}
+
+// StopMacroRecording ends the recording and saves the macro to the config
+func (c *Client) StopMacroRecording(ctx context.Context) (*models.Macro, error) {
+	var result *models.Macro
+	err := c.call(ctx, "StopMacroRecording", []interface{}{}, &result)
+	return result, err
+}
+
+// StopPcapCapture ends the live capture and closes the file
+func (c *Client) StopPcapCapture(ctx context.Context) error {
+	return c.call(ctx, "StopPcapCapture", []interface{}{}, nil)
+}
+
+// StopServer stops the HTTP mock server
+func (c *Client) StopServer(ctx context.Context) error {
+	return c.call(ctx, "StopServer", []interface{}{}, nil)
+}
+
+// SwitchWorkspace makes a workspace active. The bindings then act on its configuration, server and
+// request logs; the previously active workspace keeps running in the background.
+func (c *Client) SwitchWorkspace(ctx context.Context, id string) error {
+	return c.call(ctx, "SwitchWorkspace", []interface{}{id}, nil)
+}
+
+// TestContainerConfig tests a container configuration by creating a temporary container
+// This is called from the wizard before the endpoint is created
+func (c *Client) TestContainerConfig(ctx context.Context, config map[string]interface{}) error {
+	return c.call(ctx, "TestContainerConfig", []interface{}{config}, nil)
+}
+
+// TestContainerHost connects to a Docker/Podman host and reports its version
+func (c *Client) TestContainerHost(ctx context.Context, host models.ContainerHost) (string, error) {
+	var result string
+	err := c.call(ctx, "TestContainerHost", []interface{}{host}, &result)
+	return result, err
+}
+
+// TestProxyConnection tests connectivity to a proxy backend
+func (c *Client) TestProxyConnection(ctx context.Context, backendURL string) error {
+	return c.call(ctx, "TestProxyConnection", []interface{}{backendURL}, nil)
+}
+
+// Undo reverts the last endpoint or response edit
+func (c *Client) Undo(ctx context.Context) (models.UndoStatus, error) {
+	var result models.UndoStatus
+	err := c.call(ctx, "Undo", []interface{}{}, &result)
+	return result, err
+}
+
+// UpdateEndpoint updates an existing endpoint
+func (c *Client) UpdateEndpoint(ctx context.Context, endpoint models.Endpoint) error {
+	return c.call(ctx, "UpdateEndpoint", []interface{}{endpoint}, nil)
+}
+
+// UpdateResponse updates a single response configuration (legacy - updates first response)
+func (c *Client) UpdateResponse(ctx context.Context, response models.MethodResponse) error {
+	return c.call(ctx, "UpdateResponse", []interface{}{response}, nil)
+}
+
+// UpdateResponseByID updates a specific response rule by ID
+func (c *Client) UpdateResponseByID(ctx context.Context, response models.MethodResponse) error {
+	return c.call(ctx, "UpdateResponseByID", []interface{}{response}, nil)
+}
+
+// UpdateServerSettings updates server configuration fields
+// Does NOT save to disk - only updates in-memory config and emits events
+// Frontend should call MarkDirty() after this to mark config as dirty
+func (c *Client) UpdateServerSettings(ctx context.Context, settings models.ServerSettings) error {
+	return c.call(ctx, "UpdateServerSettings", []interface{}{settings}, nil)
+}
+
+// ValidateAndInspectDockerImage inspects a Docker image and returns metadata
+func (c *Client) ValidateAndInspectDockerImage(ctx context.Context, imageName string) (*models.DockerImageInfo, error) {
+	var result *models.DockerImageInfo
+	err := c.call(ctx, "ValidateAndInspectDockerImage", []interface{}{imageName}, &result)
+	return result, err
+}
+
+// ValidateCORSHeaderExpression validates a CORS header expression for syntax errors
+func (c *Client) ValidateCORSHeaderExpression(ctx context.Context, expression string) error {
+	return c.call(ctx, "ValidateCORSHeaderExpression", []interface{}{expression}, nil)
+}
+
+// ValidateCORSScript validates a CORS script for syntax errors
+func (c *Client) ValidateCORSScript(ctx context.Context, script string) error {
+	return c.call(ctx, "ValidateCORSScript", []interface{}{script}, nil)
+}
+
+// ValidateDelayExpression validates a response delay expression for syntax errors
+func (c *Client) ValidateDelayExpression(ctx context.Context, expression string) error {
+	return c.call(ctx, "ValidateDelayExpression", []interface{}{expression}, nil)
+}
+
+// ValidateDockerImage checks if a Docker image is available
+func (c *Client) ValidateDockerImage(ctx context.Context, imageName string) error {
+	return c.call(ctx, "ValidateDockerImage", []interface{}{imageName}, nil)
+}
+
+// ValidateEndpointPatterns checks an endpoint's patterns without applying it
+func (c *Client) ValidateEndpointPatterns(ctx context.Context, endpoint models.Endpoint) ([]models.PatternError, error) {
+	var result []models.PatternError
+	err := c.call(ctx, "ValidateEndpointPatterns", []interface{}{endpoint}, &result)
+	return result, err
+}
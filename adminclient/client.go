@@ -0,0 +1,95 @@
+// Package adminclient is a Go client for the Mockelot admin API. The binding methods in api.go are
+// generated from the App bindings by cmd/apigen, so the client can do everything the desktop UI can.
+package adminclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"mockelot/adminapi"
+)
+
+// Client calls App bindings on a running Mockelot through its admin API
+type Client struct {
+	BaseURL    string // e.g. "http://127.0.0.1:9091"
+	Token      string // Admin API token
+	HTTPClient *http.Client
+}
+
+// New creates a client for the admin API at baseURL
+func New(baseURL, token string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// Error is returned when the admin API rejects a call or the binding returns an error
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("admin API: %s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// Methods lists the bindings the server exposes
+func (c *Client) Methods(ctx context.Context) ([]string, error) {
+	var methods []string
+	err := c.do(ctx, http.MethodGet, c.BaseURL+adminapi.RPCPath, nil, &methods)
+	return methods, err
+}
+
+// call invokes a binding with its arguments and decodes the result into result (if not nil)
+func (c *Client) call(ctx context.Context, method string, args []interface{}, result interface{}) error {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("%s: %v", method, err)
+	}
+	return c.do(ctx, http.MethodPost, c.BaseURL+adminapi.RPCPath+method, body, result)
+}
+
+func (c *Client) do(ctx context.Context, httpMethod, url string, body []byte, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, httpMethod, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &apiErr) != nil || apiErr.Error == "" {
+			apiErr.Error = strings.TrimSpace(string(data))
+		}
+		return &Error{StatusCode: resp.StatusCode, Message: apiErr.Error}
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
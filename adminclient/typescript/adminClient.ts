@@ -0,0 +1,1088 @@
+// Code generated by cmd/apigen. DO NOT EDIT.
+import {models} from '../../frontend/wailsjs/go/models';
+import {main} from '../../frontend/wailsjs/go/models';
+
+// Raised when a call fails; status is the HTTP status of the admin API response
+export class AdminAPIError extends Error {
+  constructor(public status: number, message: string) {
+    super(message);
+  }
+}
+
+// Client for the Mockelot admin API. Every method mirrors the App binding of the same name.
+export class AdminClient {
+  constructor(private baseURL: string, private token: string) {}
+
+  private async call(method: string, args: any[]): Promise<any> {
+    const response = await fetch(this.baseURL.replace(/\/$/, '') + '/api/v1/rpc/' + method, {
+      method: 'POST',
+      headers: {'Authorization': 'Bearer ' + this.token, 'Content-Type': 'application/json'},
+      body: JSON.stringify(args),
+    });
+    const body = await response.json();
+    if (!response.ok) {
+      throw new AdminAPIError(response.status, body?.error ?? response.statusText);
+    }
+    return body;
+  }
+
+  // AddEndpoint adds a new endpoint with specified type
+  AddEndpoint(arg1:string,arg2:string,arg3:string,arg4:string):Promise<models.Endpoint> {
+    return this.call('AddEndpoint', [arg1, arg2, arg3, arg4]);
+  }
+
+  // AddEndpointWithConfig adds a new endpoint with full configuration from wizard
+  AddEndpointWithConfig(arg1:Record<string, any>):Promise<models.Endpoint> {
+    return this.call('AddEndpointWithConfig', [arg1]);
+  }
+
+  // AddGroup adds a new group to the selected endpoint
+  AddGroup(arg1:string):Promise<models.ResponseGroup> {
+    return this.call('AddGroup', [arg1]);
+  }
+
+  // AddRecentFile adds or updates a file in the recent files list
+  AddRecentFile(arg1:string):Promise<void> {
+    return this.call('AddRecentFile', [arg1]);
+  }
+
+  // AddResponse adds a new response rule
+  AddResponse(arg1:models.MethodResponse):Promise<models.MethodResponse> {
+    return this.call('AddResponse', [arg1]);
+  }
+
+  // AdvanceVirtualClock moves the virtual clock forward (or back, if negative) by the given seconds
+  AdvanceVirtualClock(arg1:number):Promise<string> {
+    return this.call('AdvanceVirtualClock', [arg1]);
+  }
+
+  // ApplyCachePreset applies a caching header preset to a response, or to every response in a group.
+  // targetID may be a response ID or a group ID; maxAge (seconds, 0 = preset default) tunes the max-age.
+  ApplyCachePreset(arg1:string,arg2:string,arg3:number):Promise<void> {
+    return this.call('ApplyCachePreset', [arg1, arg2, arg3]);
+  }
+
+  // ApplyEncodingPreset replaces the body of a response (or every response in a group) with a
+  // Unicode/encoding edge-case preset such as "invalid-utf8" or "rtl-override".
+  // Presets that are not valid UTF-8 are stored base64 (body_base64) so editing the config cannot mangle them.
+  ApplyEncodingPreset(arg1:string,arg2:string):Promise<void> {
+    return this.call('ApplyEncodingPreset', [arg1, arg2]);
+  }
+
+  // CancelContainerStart cancels an ongoing container startup operation
+  CancelContainerStart(arg1:string):Promise<void> {
+    return this.call('CancelContainerStart', [arg1]);
+  }
+
+  // CancelScheduledAction cancels a pending action, or reverts one that already fired
+  CancelScheduledAction(arg1:string):Promise<void> {
+    return this.call('CancelScheduledAction', [arg1]);
+  }
+
+  // ClearAppLogs clears the application log entries kept in memory (the log file is kept)
+  ClearAppLogs():Promise<void> {
+    return this.call('ClearAppLogs', []);
+  }
+
+  // ClearRequestLogs clears all request logs
+  ClearRequestLogs():Promise<void> {
+    return this.call('ClearRequestLogs', []);
+  }
+
+  // ClearScriptErrors clears all script errors for a given response ID
+  ClearScriptErrors(arg1:string):Promise<void> {
+    return this.call('ClearScriptErrors', [arg1]);
+  }
+
+  // ClearTrafficExamples removes the examples harvested from traffic for an endpoint's responses
+  // and returns how many were removed
+  ClearTrafficExamples(arg1:string):Promise<number> {
+    return this.call('ClearTrafficExamples', [arg1]);
+  }
+
+  // CloseWorkspace stops the server and containers of a background workspace and closes it; unsaved
+  // changes are lost. The active workspace cannot be closed, switch to another one first.
+  CloseWorkspace(arg1:string):Promise<void> {
+    return this.call('CloseWorkspace', [arg1]);
+  }
+
+  // ContainerHosts returns the remote Docker/Podman hosts container endpoints can run on
+  // Implements server.ContainerHostSource for the container handler
+  ContainerHosts():Promise<Array<models.ContainerHost>> {
+    return this.call('ContainerHosts', []);
+  }
+
+  // CrawlProxyEndpoint crawls the backend of a proxy endpoint from seed paths (following discovered links)
+  // and saves the responses as a new, disabled mock endpoint with the same prefix and path translation
+  CrawlProxyEndpoint(arg1:string,arg2:models.CrawlOptions):Promise<models.CrawlResult> {
+    return this.call('CrawlProxyEndpoint', [arg1, arg2]);
+  }
+
+  // CreateWorkspace opens a new, empty workspace and makes it active. The workspace that was active
+  // keeps running in the background. Its HTTP port is one above the highest port of the open
+  // workspaces, so both servers can run at once.
+  CreateWorkspace(arg1:string):Promise<models.WorkspaceInfo> {
+    return this.call('CreateWorkspace', [arg1]);
+  }
+
+  // DefaultContainerHost returns the host of container endpoints without one (empty for this machine)
+  DefaultContainerHost():Promise<string> {
+    return this.call('DefaultContainerHost', []);
+  }
+
+  // DeleteContainer is an alias for StopContainer (containers are removed when stopped)
+  DeleteContainer(arg1:string):Promise<void> {
+    return this.call('DeleteContainer', [arg1]);
+  }
+
+  // DeleteEndpoint removes an endpoint by ID
+  DeleteEndpoint(arg1:string):Promise<void> {
+    return this.call('DeleteEndpoint', [arg1]);
+  }
+
+  // DeleteMacro removes a macro by name
+  DeleteMacro(arg1:string):Promise<void> {
+    return this.call('DeleteMacro', [arg1]);
+  }
+
+  // DeleteProfile removes a profile; deleting the active profile deactivates it
+  DeleteProfile(arg1:string):Promise<void> {
+    return this.call('DeleteProfile', [arg1]);
+  }
+
+  // DeleteResponse removes a response rule by ID
+  DeleteResponse(arg1:string):Promise<void> {
+    return this.call('DeleteResponse', [arg1]);
+  }
+
+  // DiscardDrafts reverts all draft responses to their published version (drafts that were never
+  // published are removed) and returns how many drafts were discarded
+  DiscardDrafts():Promise<number> {
+    return this.call('DiscardDrafts', []);
+  }
+
+  // DiscardRecovery removes unsaved changes left by an earlier session
+  DiscardRecovery(arg1:string):Promise<void> {
+    return this.call('DiscardRecovery', [arg1]);
+  }
+
+  // DuplicateEndpoint adds a deep copy of an endpoint after the user endpoints. The copy and all of
+  // its groups and responses get fresh IDs, and it is named "<name> (copy)" with "-copy" appended to
+  // the path prefix, so it does not shadow the original.
+  DuplicateEndpoint(arg1:string):Promise<models.Endpoint> {
+    return this.call('DuplicateEndpoint', [arg1]);
+  }
+
+  // DuplicateResponse inserts a deep copy of a response, or of a group with all of its responses,
+  // right after the original, with fresh IDs. A copied group is named "<name> (copy)". A copied
+  // response keeps its path pattern, so it only matches once the original is disabled or edited.
+  // Returns the ID of the copy.
+  DuplicateResponse(arg1:string):Promise<string> {
+    return this.call('DuplicateResponse', [arg1]);
+  }
+
+  // EmitEvent publishes an event to open event-stream responses, as a script's events.emit would.
+  // A payload that parses as JSON is sent as that value, anything else as a string. Returns the
+  // number of streams that received the event.
+  EmitEvent(arg1:string,arg2:string):Promise<number> {
+    return this.call('EmitEvent', [arg1, arg2]);
+  }
+
+  // ExportBackendSLA writes SLA reports for all proxy endpoints to a CSV file in the exports directory
+  ExportBackendSLA(arg1:string):Promise<string> {
+    return this.call('ExportBackendSLA', [arg1]);
+  }
+
+  // ExportBundle writes the current config to a zip together with the files it references
+  // (certificates, keys, CA bundles and .proto files), with relative paths so the bundle can be
+  // imported on another machine
+  ExportBundle(arg1:string):Promise<models.BundleReport> {
+    return this.call('ExportBundle', [arg1]);
+  }
+
+  // ExportDockerImageSpec generates a Dockerfile and docker-compose snippet that bundle
+  // the headless server with the config at configPath (defaults to the current config file)
+  ExportDockerImageSpec(arg1:string):Promise<models.DockerImageSpec> {
+    return this.call('ExportDockerImageSpec', [arg1]);
+  }
+
+  // ExportKubernetesManifests generates a ConfigMap, Deployment and Service for running
+  // the headless server with the config at configPath (defaults to the current config file)
+  ExportKubernetesManifests(arg1:string):Promise<string> {
+    return this.call('ExportKubernetesManifests', [arg1]);
+  }
+
+  // ExportLogsAsCurl exports logs as a shell script with curl commands
+  // endpointID filters logs by endpoint (empty string = all logs)
+  // side can be "client" or "backend"
+  ExportLogsAsCurl(arg1:string,arg2:string):Promise<void> {
+    return this.call('ExportLogsAsCurl', [arg1, arg2]);
+  }
+
+  // ExportLogsAsHAR exports logs in HAR (HTTP Archive) format
+  // endpointID filters logs by endpoint (empty string = all logs)
+  // side can be "client" or "backend"
+  ExportLogsAsHAR(arg1:string,arg2:string):Promise<void> {
+    return this.call('ExportLogsAsHAR', [arg1, arg2]);
+  }
+
+  // ExportLogsAsJournal converts captured traffic into a replayable test and returns the file path.
+  // format is "k6" (k6 script), "go" (Go test file) or "http" (REST client request file);
+  // endpointID filters logs by endpoint (empty string = all logs); side can be "client" or "backend"
+  ExportLogsAsJournal(arg1:string,arg2:string,arg3:string):Promise<string> {
+    return this.call('ExportLogsAsJournal', [arg1, arg2, arg3]);
+  }
+
+  // ExportLogsAsPcap writes captured traffic as synthesized TCP sessions to a .pcap file for Wireshark
+  // and returns its path. endpointID filters logs by endpoint (empty string = all logs);
+  // side is "client", "backend" (proxied requests) or "both"
+  ExportLogsAsPcap(arg1:string,arg2:string):Promise<string> {
+    return this.call('ExportLogsAsPcap', [arg1, arg2]);
+  }
+
+  // GenerateCurlCommand builds curl and HTTPie commands that reproduce a logged request, including its
+  // method, headers and body. Proxied requests also get commands for the request sent to the backend.
+  GenerateCurlCommand(arg1:string):Promise<models.CommandSnippets> {
+    return this.call('GenerateCurlCommand', [arg1]);
+  }
+
+  // GetActiveEnvironment returns the environment proxy endpoints currently use (empty = backend_url)
+  GetActiveEnvironment():Promise<string> {
+    return this.call('GetActiveEnvironment', []);
+  }
+
+  // GetActiveListeners returns the names of the named listeners the running server listens on
+  GetActiveListeners():Promise<Array<string>> {
+    return this.call('GetActiveListeners', []);
+  }
+
+  // GetActiveProfile returns the profile whose variables are in use (empty = config variables only)
+  GetActiveProfile():Promise<string> {
+    return this.call('GetActiveProfile', []);
+  }
+
+  // GetAdminAPISettings returns the admin API settings, including the token clients must send
+  GetAdminAPISettings():Promise<models.AdminAPISettings> {
+    return this.call('GetAdminAPISettings', []);
+  }
+
+  // GetAllResponseIDsWithErrors returns a list of all response IDs that have script errors
+  GetAllResponseIDsWithErrors():Promise<Array<string>> {
+    return this.call('GetAllResponseIDsWithErrors', []);
+  }
+
+  // GetAppLogs returns application log entries at or above level (debug, info, warn, error; empty =
+  // all), optionally for one subsystem (app, server, proxy, container, socks5, dns, storage) and
+  // after since (RFC3339), oldest first
+  GetAppLogs(arg1:string,arg2:string,arg3:string):Promise<Array<models.AppLogEntry>> {
+    return this.call('GetAppLogs', [arg1, arg2, arg3]);
+  }
+
+  // GetBackendSLA returns availability and latency stats for a proxy endpoint's backend
+  // window is a duration such as "15m", "1h" or "7d" (default: 1h)
+  GetBackendSLA(arg1:string,arg2:string):Promise<models.BackendSLA> {
+    return this.call('GetBackendSLA', [arg1, arg2]);
+  }
+
+  // GetBypassRuleStats returns the connections and bytes each SOCKS5 bypass rule has tunneled
+  GetBypassRuleStats():Promise<Array<models.BypassRuleStats>> {
+    return this.call('GetBypassRuleStats', []);
+  }
+
+  // GetCACertInfo returns information about the CA certificate
+  GetCACertInfo():Promise<models.CACertInfo> {
+    return this.call('GetCACertInfo', []);
+  }
+
+  // GetCORSConfig returns the current CORS configuration
+  GetCORSConfig():Promise<models.CORSConfig> {
+    return this.call('GetCORSConfig', []);
+  }
+
+  // GetCertificateDetails returns the subject, names, validity window and fingerprints of the CA and,
+  // while HTTPS is serving, the active server certificate. Certificates within
+  // server.CertExpiryWarningDays of expiry are flagged as expiring.
+  GetCertificateDetails():Promise<Array<models.CertificateDetails>> {
+    return this.call('GetCertificateDetails', []);
+  }
+
+  // GetCircuitBreakers returns the circuit breaker state of every proxy endpoint that has one enabled
+  GetCircuitBreakers():Promise<Array<models.CircuitBreakerStatus>> {
+    return this.call('GetCircuitBreakers', []);
+  }
+
+  // GetClientThrottleStats returns the live counters of every client the throttle rules track,
+  // most throttled first
+  GetClientThrottleStats():Promise<Array<models.ClientThrottleStats>> {
+    return this.call('GetClientThrottleStats', []);
+  }
+
+  // GetClientThrottles returns the per-client-IP throttle rules
+  GetClientThrottles():Promise<Array<models.ClientThrottleRule>> {
+    return this.call('GetClientThrottles', []);
+  }
+
+  // GetConfig returns the current configuration
+  GetConfig():Promise<models.AppConfig> {
+    return this.call('GetConfig', []);
+  }
+
+  // GetContainerLogs retrieves container stdout/stderr logs
+  GetContainerLogs(arg1:string,arg2:number):Promise<string> {
+    return this.call('GetContainerLogs', [arg1, arg2]);
+  }
+
+  // GetContainerStats returns the resource usage stats for a container endpoint
+  GetContainerStats(arg1:string):Promise<models.ContainerStats> {
+    return this.call('GetContainerStats', [arg1]);
+  }
+
+  // GetContainerStatus returns the runtime status for a container endpoint
+  GetContainerStatus(arg1:string):Promise<models.ContainerStatus> {
+    return this.call('GetContainerStatus', [arg1]);
+  }
+
+  // GetCurrentConfigPath returns the current config file path
+  GetCurrentConfigPath():Promise<string> {
+    return this.call('GetCurrentConfigPath', []);
+  }
+
+  // GetDNSConfig returns the DNS listener configuration (nil if never configured)
+  GetDNSConfig():Promise<models.DNSConfig> {
+    return this.call('GetDNSConfig', []);
+  }
+
+  // GetDefaultCertNames returns the default DNS names and IP addresses that will be used for certificates
+  // Returns a list of strings containing: localhost, machine hostname, and interface IP for default gateway
+  GetDefaultCertNames():Promise<Array<string>> {
+    return this.call('GetDefaultCertNames', []);
+  }
+
+  // GetDefaultContainerHeaders returns the default inbound headers for container endpoints
+  GetDefaultContainerHeaders():Promise<Array<models.HeaderManipulation>> {
+    return this.call('GetDefaultContainerHeaders', []);
+  }
+
+  // GetDraftCount returns the number of responses with unpublished edits
+  GetDraftCount():Promise<number> {
+    return this.call('GetDraftCount', []);
+  }
+
+  // GetEndpoint returns the endpoint with the given ID
+  GetEndpoint(arg1:string):Promise<models.Endpoint> {
+    return this.call('GetEndpoint', [arg1]);
+  }
+
+  // GetEndpointHealth returns health status for an endpoint
+  GetEndpointHealth(arg1:string):Promise<models.HealthStatus> {
+    return this.call('GetEndpointHealth', [arg1]);
+  }
+
+  // GetEndpointListenerPorts returns the dedicated endpoint ports the running server listens on
+  GetEndpointListenerPorts():Promise<Array<number>> {
+    return this.call('GetEndpointListenerPorts', []);
+  }
+
+  // GetEndpointTypes returns the endpoint types provided by registered plugins
+  GetEndpointTypes():Promise<Array<models.EndpointTypeInfo>> {
+    return this.call('GetEndpointTypes', []);
+  }
+
+  // GetEndpoints returns all endpoints sorted by DisplayOrder
+  GetEndpoints():Promise<Array<models.Endpoint>> {
+    return this.call('GetEndpoints', []);
+  }
+
+  // GetEnvironments returns the environment names defined by any proxy endpoint, sorted
+  GetEnvironments():Promise<Array<string>> {
+    return this.call('GetEnvironments', []);
+  }
+
+  // GetGRPCConfig returns the gRPC listener configuration (nil if never configured)
+  GetGRPCConfig():Promise<models.GRPCConfig> {
+    return this.call('GetGRPCConfig', []);
+  }
+
+  // GetGRPCMethods lists the methods described by the configured .proto files
+  GetGRPCMethods():Promise<Array<models.GRPCMethodInfo>> {
+    return this.call('GetGRPCMethods', []);
+  }
+
+  // GetItems returns all response items (responses and groups)
+  GetItems():Promise<Array<models.ResponseItem>> {
+    return this.call('GetItems', []);
+  }
+
+  // GetJWTKeys returns the named keys of the jwt helpers in templates and scripts
+  GetJWTKeys():Promise<Array<models.JWTSigningKey>> {
+    return this.call('GetJWTKeys', []);
+  }
+
+  // GetListeners returns the named listeners endpoints can be assigned to
+  GetListeners():Promise<Array<models.Listener>> {
+    return this.call('GetListeners', []);
+  }
+
+  // GetLogSettings returns the application log level and file settings
+  GetLogSettings():Promise<models.LogSettings> {
+    return this.call('GetLogSettings', []);
+  }
+
+  // GetMacroRecording returns the name of the macro being recorded ("" when not recording)
+  GetMacroRecording():Promise<string> {
+    return this.call('GetMacroRecording', []);
+  }
+
+  // GetMacros returns the recorded macros
+  GetMacros():Promise<Array<models.Macro>> {
+    return this.call('GetMacros', []);
+  }
+
+  // GetMarketplaceSources returns the configured endpoint bundle registries
+  GetMarketplaceSources():Promise<Array<models.MarketplaceSource>> {
+    return this.call('GetMarketplaceSources', []);
+  }
+
+  // GetOfflineMode returns whether offline mode is on and how each proxy/container endpoint is covered
+  GetOfflineMode():Promise<models.OfflineModeStatus> {
+    return this.call('GetOfflineMode', []);
+  }
+
+  // GetPACFile returns the proxy auto-config file as served at GetPACURL, for saving to disk
+  GetPACFile():Promise<string> {
+    return this.call('GetPACFile', []);
+  }
+
+  // GetPACURL returns the URL of the proxy auto-config file on this machine's network address,
+  // for pointing browsers and devices at the proxies for the taken-over domains only
+  GetPACURL():Promise<string> {
+    return this.call('GetPACURL', []);
+  }
+
+  // GetPatternErrors returns all regex patterns in the current config that do not compile
+  GetPatternErrors():Promise<Array<models.PatternError>> {
+    return this.call('GetPatternErrors', []);
+  }
+
+  // GetPcapCapture returns the path of the running capture ("" when not capturing)
+  GetPcapCapture():Promise<string> {
+    return this.call('GetPcapCapture', []);
+  }
+
+  // GetProfiles returns the named variable sets of the config
+  GetProfiles():Promise<Record<string, Record<string, string>>> {
+    return this.call('GetProfiles', []);
+  }
+
+  // GetProxyBackends returns the health and traffic of each backend in a proxy endpoint's pool
+  GetProxyBackends(arg1:string):Promise<Array<models.ProxyBackendStatus>> {
+    return this.call('GetProxyBackends', [arg1]);
+  }
+
+  // GetRecentFiles returns the list of recent files with existence check
+  // Limited to 24 most recent files (3 columns × 8 rows)
+  GetRecentFiles():Promise<Array<models.RecentFile>> {
+    return this.call('GetRecentFiles', []);
+  }
+
+  // GetRecoveries returns the unsaved changes left by an earlier session, newest first, so they
+  // can be restored after a crash. Changes to a config file that was saved after them are
+  // outdated; they are removed and not returned.
+  GetRecoveries():Promise<Array<models.RecoveryInfo>> {
+    return this.call('GetRecoveries', []);
+  }
+
+  // GetRequestLogByID returns a specific request log by ID
+  GetRequestLogByID(arg1:string):Promise<models.RequestLog> {
+    return this.call('GetRequestLogByID', [arg1]);
+  }
+
+  // GetRequestLogDetails returns the full RequestLog details for a given ID
+  GetRequestLogDetails(arg1:string):Promise<models.RequestLog> {
+    return this.call('GetRequestLogDetails', [arg1]);
+  }
+
+  // GetRequestLogs returns all request log summaries
+  GetRequestLogs():Promise<Array<models.RequestLogSummary>> {
+    return this.call('GetRequestLogs', []);
+  }
+
+  // GetRequestLogsByTraffic returns the request log summaries of one kind of traffic:
+  // "intercepted" (HTTPS decrypted by SOCKS5 TLS interception) or "direct" (not through the SOCKS5 proxy).
+  // An empty filter returns all logs.
+  GetRequestLogsByTraffic(arg1:string):Promise<Array<models.RequestLogSummary>> {
+    return this.call('GetRequestLogsByTraffic', [arg1]);
+  }
+
+  // GetResponsePerfStats returns template/script execution time and error counts per response since
+  // the server started, slowest (by total time) first
+  GetResponsePerfStats():Promise<Array<models.ResponsePerfStats>> {
+    return this.call('GetResponsePerfStats', []);
+  }
+
+  // GetResponses returns all response rules (legacy - for backward compatibility)
+  GetResponses():Promise<Array<models.MethodResponse>> {
+    return this.call('GetResponses', []);
+  }
+
+  // GetRetryAfterReport checks the request logs for clients that came back before the Retry-After
+  // they were sent (by client throttling, scheduled maintenance or any response) had elapsed
+  GetRetryAfterReport():Promise<models.RetryAfterReport> {
+    return this.call('GetRetryAfterReport', []);
+  }
+
+  // GetRuntimeHealth returns the goroutine and memory samples, any leak warnings and the largest
+  // groups of goroutines sharing a stack
+  GetRuntimeHealth():Promise<models.RuntimeHealth> {
+    return this.call('GetRuntimeHealth', []);
+  }
+
+  // GetSOCKS5Config returns the current SOCKS5 and domain takeover configuration
+  GetSOCKS5Config():Promise<main.SOCKS5ConfigResponse> {
+    return this.call('GetSOCKS5Config', []);
+  }
+
+  // GetSchedule returns the running server's schedule with due times and states
+  GetSchedule():Promise<Array<models.ScheduledActionStatus>> {
+    return this.call('GetSchedule', []);
+  }
+
+  // GetScheduledActions returns the actions scheduled at each server start
+  GetScheduledActions():Promise<Array<models.ScheduledAction>> {
+    return this.call('GetScheduledActions', []);
+  }
+
+  // GetScriptErrors returns all script errors for a given response ID
+  GetScriptErrors(arg1:string):Promise<Array<main.ScriptErrorLog>> {
+    return this.call('GetScriptErrors', [arg1]);
+  }
+
+  // GetScriptFetchConfig returns the http.fetch settings of script-mode responses
+  GetScriptFetchConfig():Promise<models.ScriptFetchConfig> {
+    return this.call('GetScriptFetchConfig', []);
+  }
+
+  // GetScriptModules returns the shared JavaScript modules that scripts load with require()
+  GetScriptModules():Promise<Array<models.ScriptModule>> {
+    return this.call('GetScriptModules', []);
+  }
+
+  // GetScriptWarnings returns all scripts, templates and expressions in the current config that do not compile
+  GetScriptWarnings():Promise<Array<models.ScriptWarning>> {
+    return this.call('GetScriptWarnings', []);
+  }
+
+  // GetSelectedEndpointId returns the currently selected endpoint ID from ServerConfig
+  GetSelectedEndpointId():Promise<string> {
+    return this.call('GetSelectedEndpointId', []);
+  }
+
+  // GetServerStatus returns the current server status
+  GetServerStatus():Promise<main.ServerStatus> {
+    return this.call('GetServerStatus', []);
+  }
+
+  // GetStorageSettings returns the selected config storage backend
+  GetStorageSettings():Promise<models.StorageSettings> {
+    return this.call('GetStorageSettings', []);
+  }
+
+  // GetTrafficStats returns the bytes carried by each SOCKS5 tunnel destination and proxy endpoint,
+  // with the bandwidth over the last few seconds; most traffic first
+  GetTrafficStats():Promise<Array<models.TrafficStats>> {
+    return this.call('GetTrafficStats', []);
+  }
+
+  // GetTransactions groups request logs into logical transactions by correlation header
+  // or SOCKS5 connection. If correlationHeader is empty, common correlation headers
+  // (X-Correlation-ID, X-Request-ID, traceparent) are checked in order.
+  GetTransactions(arg1:string):Promise<Array<models.Transaction>> {
+    return this.call('GetTransactions', [arg1]);
+  }
+
+  // GetUndoStatus reports whether edits can be undone or redone, and which
+  GetUndoStatus():Promise<models.UndoStatus> {
+    return this.call('GetUndoStatus', []);
+  }
+
+  // GetVariables returns the config variables that ${NAME} references in backend URLs, headers,
+  // bodies and container environments resolve to
+  GetVariables():Promise<Record<string, string>> {
+    return this.call('GetVariables', []);
+  }
+
+  // GetVirtualTime returns the current virtual time (RFC3339) used by time-dependent mock features
+  GetVirtualTime():Promise<string> {
+    return this.call('GetVirtualTime', []);
+  }
+
+  // ImportBundle extracts a bundle into destDir and loads its config, with the bundled files
+  // referenced from there. The config is saved in destDir as config.yaml.
+  ImportBundle(arg1:string,arg2:string):Promise<models.BundleReport> {
+    return this.call('ImportBundle', [arg1, arg2]);
+  }
+
+  // ImportDockerComposeWithDialog creates a container endpoint from a docker-compose.yml: mainService
+  // (the only service that publishes ports when empty) becomes the routed container and every other
+  // service a sidecar on the shared network. Returns nil if the user cancelled the dialog.
+  ImportDockerComposeWithDialog(arg1:string):Promise<Promise<models.Endpoint>> {
+    return this.call('ImportDockerComposeWithDialog', [arg1]);
+  }
+
+  // InstallMarketplaceBundle downloads a bundle, verifies its checksum and adds its
+  // endpoints to the current config (with fresh IDs, before system endpoints)
+  InstallMarketplaceBundle(arg1:string,arg2:string):Promise<Array<models.Endpoint>> {
+    return this.call('InstallMarketplaceBundle', [arg1, arg2]);
+  }
+
+  // IsDirty returns true if current config differs from saved config
+  IsDirty():Promise<boolean> {
+    return this.call('IsDirty', []);
+  }
+
+  // ListConfigBackups returns the backups of the current config file, newest first. A backup is
+  // written on every save; the last 20 are kept.
+  ListConfigBackups():Promise<Array<models.ConfigBackup>> {
+    return this.call('ListConfigBackups', []);
+  }
+
+  // ListMarketplaceBundles fetches the indexes of all configured sources
+  // Sources that fail are logged and skipped; an error is returned only if every source fails
+  ListMarketplaceBundles():Promise<Array<models.MarketplaceBundle>> {
+    return this.call('ListMarketplaceBundles', []);
+  }
+
+  // ListWorkspaces returns the open workspaces; the active one is marked
+  ListWorkspaces():Promise<Array<models.WorkspaceInfo>> {
+    return this.call('ListWorkspaces', []);
+  }
+
+  // LoadConfigFromPath loads configuration from a specific file path
+  LoadConfigFromPath(arg1:string):Promise<models.AppConfig> {
+    return this.call('LoadConfigFromPath', [arg1]);
+  }
+
+  // MarkClean updates savedConfig to current state
+  // Called after successful save
+  MarkClean():Promise<void> {
+    return this.call('MarkClean', []);
+  }
+
+  // MarkDirty marks the config as dirty (without updating savedConfig)
+  // Called when user makes changes in Server tab
+  MarkDirty():Promise<void> {
+    return this.call('MarkDirty', []);
+  }
+
+  // MergeConfigs merges the other config file into the base file and loads the result as the current,
+  // unsaved config (saving writes to basePath). Entries present in both files with different content are
+  // resolved with the strategy: "base" keeps the base version, "other" takes the other file's version and
+  // "both" keeps the base version and adds the other as a copy. Server settings come from the base file.
+  MergeConfigs(arg1:string,arg2:string,arg3:string):Promise<models.MergeReport> {
+    return this.call('MergeConfigs', [arg1, arg2, arg3]);
+  }
+
+  // PollEvents returns all queued events and clears the queue
+  // This is called by the frontend at regular intervals (polling)
+  PollEvents():Promise<Array<main.Event>> {
+    return this.call('PollEvents', []);
+  }
+
+  // PollRequestLogs returns all queued request log summaries and clears the queue
+  // This is called by the frontend at regular intervals (polling) for efficient batching
+  // during high-volume traffic
+  PollRequestLogs():Promise<Array<models.RequestLogSummary>> {
+    return this.call('PollRequestLogs', []);
+  }
+
+  // PreviewMergeConfigs reports how two config files would merge, without changing the current config
+  PreviewMergeConfigs(arg1:string,arg2:string,arg3:string):Promise<models.MergeReport> {
+    return this.call('PreviewMergeConfigs', [arg1, arg2, arg3]);
+  }
+
+  // PublishDrafts makes all draft responses live and returns how many were published
+  PublishDrafts():Promise<number> {
+    return this.call('PublishDrafts', []);
+  }
+
+  // PullDockerImage pulls a Docker image from the registry
+  PullDockerImage(arg1:string):Promise<void> {
+    return this.call('PullDockerImage', [arg1]);
+  }
+
+  // Redo applies the last undone edit again
+  Redo():Promise<models.UndoStatus> {
+    return this.call('Redo', []);
+  }
+
+  // RegenerateCA regenerates the CA certificate and swaps it into the running server. Connections
+  // already open keep their certificate; new handshakes use the new one.
+  RegenerateCA():Promise<void> {
+    return this.call('RegenerateCA', []);
+  }
+
+  // RegistryCredentials returns the global private registry logins
+  // Implements server.RegistryCredentialSource for the container handler's image pulls
+  RegistryCredentials():Promise<Array<models.RegistryCredential>> {
+    return this.call('RegistryCredentials', []);
+  }
+
+  // RemoveRecentFile removes a file from the recent files list
+  RemoveRecentFile(arg1:string):Promise<void> {
+    return this.call('RemoveRecentFile', [arg1]);
+  }
+
+  // RenameWorkspace changes the display name of a workspace
+  RenameWorkspace(arg1:string,arg2:string):Promise<void> {
+    return this.call('RenameWorkspace', [arg1, arg2]);
+  }
+
+  // ReorderResponses reorders response rules based on the provided ID order
+  ReorderResponses(arg1:Array<string>):Promise<void> {
+    return this.call('ReorderResponses', [arg1]);
+  }
+
+  // ReplayRequest re-sends a logged client request and returns the new response next to the original one.
+  // targetBaseURL (scheme://host[:port][/prefix]) receives the request's path and query; empty targets the running mock.
+  ReplayRequest(arg1:string,arg2:string):Promise<models.ReplayResult> {
+    return this.call('ReplayRequest', [arg1, arg2]);
+  }
+
+  // ReplayRequests re-sends several logged client requests in order (see ReplayRequest).
+  // A request that fails to send is reported in its result's error rather than stopping the run.
+  ReplayRequests(arg1:Array<string>,arg2:string):Promise<Array<models.ReplayResult>> {
+    return this.call('ReplayRequests', [arg1, arg2]);
+  }
+
+  // ResetBypassRuleStats clears the SOCKS5 bypass rule counters
+  ResetBypassRuleStats():Promise<void> {
+    return this.call('ResetBypassRuleStats', []);
+  }
+
+  // ResetCircuitBreaker closes a proxy endpoint's circuit and clears its counters (all of them if
+  // endpointID is empty)
+  ResetCircuitBreaker(arg1:string):Promise<void> {
+    return this.call('ResetCircuitBreaker', [arg1]);
+  }
+
+  // ResetClientThrottleStats forgets every tracked client, refilling their buckets
+  ResetClientThrottleStats():Promise<void> {
+    return this.call('ResetClientThrottleStats', []);
+  }
+
+  // ResetResponsePerfStats clears the template/script execution statistics
+  ResetResponsePerfStats():Promise<void> {
+    return this.call('ResetResponsePerfStats', []);
+  }
+
+  // ResetSequences moves sequence responses back to their first step. An empty response ID resets
+  // every sequence.
+  ResetSequences(arg1:string):Promise<void> {
+    return this.call('ResetSequences', [arg1]);
+  }
+
+  // ResetState returns the running mock to a clean slate between test runs: it clears the request
+  // logs and script errors and resets sequences, circuit breakers and every statistics counter. The
+  // config is kept.
+  ResetState():Promise<void> {
+    return this.call('ResetState', []);
+  }
+
+  // ResetTrafficStats clears the SOCKS5 tunnel and proxy endpoint traffic counters
+  ResetTrafficStats():Promise<void> {
+    return this.call('ResetTrafficStats', []);
+  }
+
+  // RestartContainer restarts a container endpoint
+  RestartContainer(arg1:string):Promise<void> {
+    return this.call('RestartContainer', [arg1]);
+  }
+
+  // RestoreConfigBackup loads a backup of the current config file as the current, unsaved config.
+  // Saving writes it back to the config file. The endpoint and response changes of the restore can
+  // be undone.
+  RestoreConfigBackup(arg1:string):Promise<models.AppConfig> {
+    return this.call('RestoreConfigBackup', [arg1]);
+  }
+
+  // RestoreRecovery loads unsaved changes from an earlier session into the active workspace. The
+  // config file they belong to is loaded first, so the restored changes show as unsaved and can be
+  // undone. The recovery file is removed.
+  RestoreRecovery(arg1:string):Promise<models.AppConfig> {
+    return this.call('RestoreRecovery', [arg1]);
+  }
+
+  // RunMacro plays a macro back in the background, waiting each step's recorded delay.
+  // Scheduled steps become runtime overrides on the running server, like ScheduleAction.
+  RunMacro(arg1:string):Promise<void> {
+    return this.call('RunMacro', [arg1]);
+  }
+
+  // SaveConfigToDirectory saves the config in directory layout: dir/mockelot.yaml holds the server
+  // settings and shared sections, and dir/endpoints holds one file per endpoint. The config stays
+  // in this layout when it is saved again.
+  SaveConfigToDirectory(arg1:string):Promise<void> {
+    return this.call('SaveConfigToDirectory', [arg1]);
+  }
+
+  // SaveCurrentConfig saves to the current config file (overwrites)
+  SaveCurrentConfig():Promise<void> {
+    return this.call('SaveCurrentConfig', []);
+  }
+
+  // SaveCurrentConfigWithMessage saves to the current config file, describing the change with the
+  // given message (used as the commit message by the Git storage backend)
+  SaveCurrentConfigWithMessage(arg1:string):Promise<void> {
+    return this.call('SaveCurrentConfigWithMessage', [arg1]);
+  }
+
+  // ScheduleAction schedules an action on the running server, relative to now. It is not saved.
+  ScheduleAction(arg1:models.ScheduledAction):Promise<models.ScheduledActionStatus> {
+    return this.call('ScheduleAction', [arg1]);
+  }
+
+  // SearchRequestLogs returns one page of the summaries of the request logs matching a filter
+  // (method, path regex, status range, endpoint, time window, body substring, header matchers)
+  SearchRequestLogs(arg1:models.RequestLogFilter):Promise<models.RequestLogPage> {
+    return this.call('SearchRequestLogs', [arg1]);
+  }
+
+  // SetActiveEnvironment switches every proxy endpoint to its backend URL for the named environment.
+  // Proxies without a URL for it keep their backend_url. An empty name switches all back to backend_url.
+  SetActiveEnvironment(arg1:string):Promise<void> {
+    return this.call('SetActiveEnvironment', [arg1]);
+  }
+
+  // SetActiveProfile selects the profile whose variables are used, e.g. before starting the server.
+  // A running server switches right away. An empty name uses the config variables only.
+  SetActiveProfile(arg1:string):Promise<void> {
+    return this.call('SetActiveProfile', [arg1]);
+  }
+
+  // SetAdminAPISettings saves the admin API settings and starts, restarts or stops the listener.
+  // An empty token is replaced with a random one.
+  SetAdminAPISettings(arg1:models.AdminAPISettings):Promise<models.AdminAPISettings> {
+    return this.call('SetAdminAPISettings', [arg1]);
+  }
+
+  // SetClientThrottles replaces the per-client-IP throttle rules. Rules without an ID get one;
+  // the first enabled rule matching a request's client and endpoint applies.
+  SetClientThrottles(arg1:Array<models.ClientThrottleRule>):Promise<void> {
+    return this.call('SetClientThrottles', [arg1]);
+  }
+
+  // SetDNSConfig validates and stores the DNS configuration and restarts the DNS listener
+  // if the server is running
+  SetDNSConfig(arg1:models.DNSConfig):Promise<void> {
+    return this.call('SetDNSConfig', [arg1]);
+  }
+
+  // SetGRPCConfig validates the .proto files, stores the gRPC configuration and restarts the
+  // gRPC listener if the server is running. Returns the methods described by the protos.
+  SetGRPCConfig(arg1:models.GRPCConfig):Promise<Array<models.GRPCMethodInfo>> {
+    return this.call('SetGRPCConfig', [arg1]);
+  }
+
+  // SetItems replaces all response items for the selected endpoint
+  SetItems(arg1:Array<models.ResponseItem>):Promise<void> {
+    return this.call('SetItems', [arg1]);
+  }
+
+  // SetJWTKeys replaces the named JWT keys. Names must be unique and every key must parse.
+  SetJWTKeys(arg1:Array<models.JWTSigningKey>):Promise<void> {
+    return this.call('SetJWTKeys', [arg1]);
+  }
+
+  // SetListeners replaces the named listeners. Names and ports must be unique, and listeners that
+  // endpoints are still assigned to cannot be removed.
+  SetListeners(arg1:Array<models.Listener>):Promise<void> {
+    return this.call('SetListeners', [arg1]);
+  }
+
+  // SetLogSettings applies and saves the application log level and file settings
+  SetLogSettings(arg1:models.LogSettings):Promise<void> {
+    return this.call('SetLogSettings', [arg1]);
+  }
+
+  // SetOfflineMode switches all proxy and container endpoints to serve their recorded snapshot endpoints
+  // instead of contacting backends (or back to live traffic). When enabling, completed responses in the
+  // request log are first recorded into each endpoint's snapshot, creating the snapshot if needed.
+  SetOfflineMode(arg1:boolean):Promise<models.OfflineModeStatus> {
+    return this.call('SetOfflineMode', [arg1]);
+  }
+
+  // SetProfile creates or replaces a profile; its variables override the config variables while it
+  // is active
+  SetProfile(arg1:string,arg2:Record<string, string>):Promise<void> {
+    return this.call('SetProfile', [arg1, arg2]);
+  }
+
+  // SetResponses replaces all response rules with the provided list
+  SetResponses(arg1:Array<models.MethodResponse>):Promise<void> {
+    return this.call('SetResponses', [arg1]);
+  }
+
+  // SetScheduledActions replaces the actions scheduled at server start (takes effect on the next start)
+  SetScheduledActions(arg1:Array<models.ScheduledAction>):Promise<void> {
+    return this.call('SetScheduledActions', [arg1]);
+  }
+
+  // SetScriptFetchConfig updates the http.fetch settings of script-mode responses
+  SetScriptFetchConfig(arg1:models.ScriptFetchConfig):Promise<void> {
+    return this.call('SetScriptFetchConfig', [arg1]);
+  }
+
+  // SetScriptModules replaces the shared script modules. Names must be unique and every module must compile.
+  SetScriptModules(arg1:Array<models.ScriptModule>):Promise<void> {
+    return this.call('SetScriptModules', [arg1]);
+  }
+
+  // SetSelectedEndpointId sets the currently selected endpoint ID and saves to ServerConfig
+  SetSelectedEndpointId(arg1:string):Promise<void> {
+    return this.call('SetSelectedEndpointId', [arg1]);
+  }
+
+  // SetStorageSettings selects where configs are loaded from and saved to and remembers the choice
+  SetStorageSettings(arg1:models.StorageSettings):Promise<void> {
+    return this.call('SetStorageSettings', [arg1]);
+  }
+
+  // SetVariables replaces the config variables. Names not defined here or in the active profile are
+  // looked up in the OS environment.
+  SetVariables(arg1:Record<string, string>):Promise<void> {
+    return this.call('SetVariables', [arg1]);
+  }
+
+  // SetVirtualClock replaces the virtual clock (nil restores real time)
+  SetVirtualClock(arg1:models.VirtualClock):Promise<void> {
+    return this.call('SetVirtualClock', [arg1]);
+  }
+
+  // StartContainer starts a single container endpoint
+  StartContainer(arg1:string):Promise<void> {
+    return this.call('StartContainer', [arg1]);
+  }
+
+  // StartContainers starts all container endpoints in the background
+  // Events are sent via the event channel to the frontend
+  StartContainers():Promise<void> {
+    return this.call('StartContainers', []);
+  }
+
+  // StartMacroRecording starts recording state changes (scheduled actions, offline mode and environment
+  // switches) into a macro. Recording again under an existing name replaces that macro when stopped.
+  StartMacroRecording(arg1:string):Promise<void> {
+    return this.call('StartMacroRecording', [arg1]);
+  }
+
+  // StartPcapCapture mirrors every completed request (client side, and the backend side of proxied
+  // requests) into a pcap file as it is logged. An empty path creates a timestamped file in exports/.
+  // Returns the path of the capture file.
+  StartPcapCapture(arg1:string):Promise<string> {
+    return this.call('StartPcapCapture', [arg1]);
+  }
+
+  // StartServer starts the HTTP mock server on the specified port
+  StartServer(arg1:number):Promise<void> {
+    return this.call('StartServer', [arg1]);
+  }
+
+  // StopContainer stops (and removes) a single container endpoint
+  StopContainer(arg1:string):Promise<void> {
+    return this.call('StopContainer', [arg1]);
+  }
+
+  // StopMacroRecording ends the recording and saves the macro to the config
+  StopMacroRecording():Promise<models.Macro> {
+    return this.call('StopMacroRecording', []);
+  }
+
+  // StopPcapCapture ends the live capture and closes the file
+  StopPcapCapture():Promise<void> {
+    return this.call('StopPcapCapture', []);
+  }
+
+  // StopServer stops the HTTP mock server
+  StopServer():Promise<void> {
+    return this.call('StopServer', []);
+  }
+
+  // SwitchWorkspace makes a workspace active. The bindings then act on its configuration, server and
+  // request logs; the previously active workspace keeps running in the background.
+  SwitchWorkspace(arg1:string):Promise<void> {
+    return this.call('SwitchWorkspace', [arg1]);
+  }
+
+  // TestContainerConfig tests a container configuration by creating a temporary container
+  // This is called from the wizard before the endpoint is created
+  TestContainerConfig(arg1:Record<string, any>):Promise<void> {
+    return this.call('TestContainerConfig', [arg1]);
+  }
+
+  // TestContainerHost connects to a Docker/Podman host and reports its version
+  TestContainerHost(arg1:models.ContainerHost):Promise<string> {
+    return this.call('TestContainerHost', [arg1]);
+  }
+
+  // TestProxyConnection tests connectivity to a proxy backend
+  TestProxyConnection(arg1:string):Promise<void> {
+    return this.call('TestProxyConnection', [arg1]);
+  }
+
+  // Undo reverts the last endpoint or response edit
+  Undo():Promise<models.UndoStatus> {
+    return this.call('Undo', []);
+  }
+
+  // UpdateEndpoint updates an existing endpoint
+  UpdateEndpoint(arg1:models.Endpoint):Promise<void> {
+    return this.call('UpdateEndpoint', [arg1]);
+  }
+
+  // UpdateResponse updates a single response configuration (legacy - updates first response)
+  UpdateResponse(arg1:models.MethodResponse):Promise<void> {
+    return this.call('UpdateResponse', [arg1]);
+  }
+
+  // UpdateResponseByID updates a specific response rule by ID
+  UpdateResponseByID(arg1:models.MethodResponse):Promise<void> {
+    return this.call('UpdateResponseByID', [arg1]);
+  }
+
+  // UpdateServerSettings updates server configuration fields
+  // Does NOT save to disk - only updates in-memory config and emits events
+  // Frontend should call MarkDirty() after this to mark config as dirty
+  UpdateServerSettings(arg1:models.ServerSettings):Promise<void> {
+    return this.call('UpdateServerSettings', [arg1]);
+  }
+
+  // ValidateAndInspectDockerImage inspects a Docker image and returns metadata
+  ValidateAndInspectDockerImage(arg1:string):Promise<models.DockerImageInfo> {
+    return this.call('ValidateAndInspectDockerImage', [arg1]);
+  }
+
+  // ValidateCORSHeaderExpression validates a CORS header expression for syntax errors
+  ValidateCORSHeaderExpression(arg1:string):Promise<void> {
+    return this.call('ValidateCORSHeaderExpression', [arg1]);
+  }
+
+  // ValidateCORSScript validates a CORS script for syntax errors
+  ValidateCORSScript(arg1:string):Promise<void> {
+    return this.call('ValidateCORSScript', [arg1]);
+  }
+
+  // ValidateDelayExpression validates a response delay expression for syntax errors
+  ValidateDelayExpression(arg1:string):Promise<void> {
+    return this.call('ValidateDelayExpression', [arg1]);
+  }
+
+  // ValidateDockerImage checks if a Docker image is available
+  ValidateDockerImage(arg1:string):Promise<void> {
+    return this.call('ValidateDockerImage', [arg1]);
+  }
+
+  // ValidateEndpointPatterns checks an endpoint's patterns without applying it
+  ValidateEndpointPatterns(arg1:models.Endpoint):Promise<Array<models.PatternError>> {
+    return this.call('ValidateEndpointPatterns', [arg1]);
+  }
+}
@@ -1,17 +1,22 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	goruntime "runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,14 +26,33 @@ import (
 	"github.com/google/uuid"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 	"gopkg.in/yaml.v3"
+	"mockelot/adminapi"
 	"mockelot/config"
+	"mockelot/configdir"
+	"mockelot/endpointtype"
+	"mockelot/backoff"
+	"mockelot/bundle"
+	"mockelot/compose"
+	"mockelot/correlation"
+	"mockelot/crawler"
+	"mockelot/deploy"
 	"mockelot/export"
+	"mockelot/har"
+	"mockelot/leakwatch"
+	"mockelot/logger"
+	"mockelot/logsearch"
+	"mockelot/marketplace"
+	"mockelot/merge"
 	"mockelot/models"
 	"mockelot/openapi"
+	"mockelot/replay"
 	"mockelot/server"
 	containerruntime "mockelot/server/runtime"
+	"mockelot/storage"
 )
 
+var appLog = logger.For(logger.SubsystemApp)
+
 // ServerStatus represents the current state of the HTTP server
 type ServerStatus struct {
 	Running bool   `json:"running"`
@@ -61,6 +85,8 @@ type App struct {
 	config                 *models.AppConfig
 	serverConfigMgr        *config.ServerConfigManager
 	currentConfigPath      string                         // Path to the currently loaded/saved config file
+	storage                storage.Backend                // Where config files are loaded from and saved to
+	storageSettings        models.StorageSettings         // Selected storage backend (persisted in ~/.mockelot/storage.json)
 	savedConfig            *models.AppConfig              // Last saved state for dirty tracking
 	configMutex            sync.RWMutex                   // Protects config and savedConfig
 	requestLogs            []models.RequestLog
@@ -74,26 +100,36 @@ type App struct {
 	containerStartMutex    sync.Mutex                    // Mutex for thread-safe access to containerStartContexts
 	scriptErrors           map[string][]ScriptErrorLog   // Map of response ID to list of script errors
 	scriptErrorsMutex      sync.RWMutex                  // Mutex for thread-safe access to scriptErrors
+	macroRecording         *models.Macro                 // Macro being recorded (nil when not recording)
+	macroLastStep          time.Time                     // Time of the last recorded step (or recording start)
+	macroMutex             sync.Mutex                    // Protects macroRecording and macroLastStep
+	adminSettings          models.AdminAPISettings       // Admin API settings (persisted in ~/.mockelot/admin-api.json)
+	adminServer            *http.Server                  // Running admin API listener (nil when stopped)
+	adminMutex             sync.Mutex                    // Protects adminSettings and adminServer
+	headlessLog            *headlessLogger               // Set by mockelot serve: logs and events go to its stream instead of the frontend queues
+	leakWatcher            *leakwatch.Watcher            // Goroutine/heap growth detector
+	logSettings            models.LogSettings            // Application log settings (persisted in ~/.mockelot/logging.json)
+	logSettingsMutex       sync.Mutex
+	pcapFile               *os.File                      // Live pcap capture file (nil when not capturing)
+	pcapWriter             *export.PcapWriter            // Writer for the live capture
+	pcapWritten            map[string]bool               // Request log IDs already written to the capture
+	pcapMutex              sync.Mutex                    // Protects the pcap capture fields
+	undoHistory            *undoHistory                  // Undo/redo of endpoint and response edits
+	autosaveCancel         context.CancelFunc            // Stops the autosave loop (nil when not running)
+	recoverySums           map[string][32]byte           // Checksum of the config in each recovery file written
+	recoveryMutex          sync.Mutex                    // Serializes autosave passes
+	workspaces             []*workspace                  // Open workspaces, in creation order
+	activeWorkspace        *workspace                    // Workspace whose state is in the fields above
+	workspaceMutex         sync.RWMutex                  // Protects workspaces and activeWorkspace
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
 	app := &App{
-		config: &models.AppConfig{
-			Port: 8080,
-			Responses: []models.MethodResponse{
-				{
-					ID:          uuid.New().String(),
-					PathPattern: "/*",
-					Methods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-					StatusCode:  200,
-					StatusText:  "OK",
-					Headers:     make(map[string]string),
-					Body:        "",
-				},
-			},
-		},
+		config:                 defaultAppConfig(8080),
 		serverConfigMgr:        config.NewServerConfigManager(""),
+		storage:                storage.FileBackend{},
+		storageSettings:        models.StorageSettings{Backend: models.StorageBackendFile},
 		requestLogs:            make([]models.RequestLog, 0),
 		requestLogSummaryQueue: make([]models.RequestLogSummary, 0),
 		status: ServerStatus{
@@ -103,14 +139,22 @@ func NewApp() *App {
 		eventQueue:             make([]Event, 0),                       // Event queue for frontend polling
 		containerStartContexts: make(map[string]context.CancelFunc),
 		scriptErrors:           make(map[string][]ScriptErrorLog), // Script error tracking
+		undoHistory:            &undoHistory{},
+		recoverySums:           make(map[string][32]byte),
 	}
 
+	app.leakWatcher = leakwatch.New(app.reportRuntimeWarning)
+
+	// The first workspace; its logs and events reach the App through the workspace sink
+	app.activeWorkspace = newWorkspace(app, "Default")
+	app.workspaces = []*workspace{app.activeWorkspace}
+
 	// Initialize proxy handler (shared between server and container handler)
-	app.proxyHandler = server.NewProxyHandler(app)
+	app.proxyHandler = server.NewProxyHandler(app.activeWorkspace.sink)
 
 	// Initialize container handler (independent of server)
-	// App implements EventSender interface via SendEvent method
-	app.containerHandler = server.NewContainerHandler(app, app, app.proxyHandler)
+	sink := app.activeWorkspace.sink
+	app.containerHandler = server.NewContainerHandler(sink, sink, app.proxyHandler, sink, sink)
 
 	// Ensure all endpoints have DisplayOrder set
 	app.ensureDisplayOrder()
@@ -123,24 +167,56 @@ func NewApp() *App {
 	return app
 }
 
+// defaultAppConfig is the configuration of a new app or workspace: a catch-all 200 response
+func defaultAppConfig(port int) *models.AppConfig {
+	return &models.AppConfig{
+		Port: port,
+		Responses: []models.MethodResponse{
+			{
+				ID:          uuid.New().String(),
+				PathPattern: "/*",
+				Methods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
+				StatusCode:  200,
+				StatusText:  "OK",
+				Headers:     make(map[string]string),
+				Body:        "",
+			},
+		},
+	}
+}
+
 // startup is called when the app starts
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
 	// Event polling architecture: Frontend polls PollEvents() periodically
 	// No need for event sender goroutine
-	log.Println("[App.startup] Using polling-based event delivery")
+	appLog.Info("[App.startup] Using polling-based event delivery")
+
+	// Select the config storage backend chosen in settings
+	a.loadStorageSettings()
+
+	// Apply the application log level and file from settings
+	a.loadLogSettings()
+
+	// Start the admin API if it was enabled in settings
+	a.loadAdminAPISettings()
+
+	a.leakWatcher.Start()
+
+	// Keep unsaved changes in ~/.mockelot/recovery in case the app crashes
+	a.startAutosave()
 
 	// Load server configuration from old ~/.mockelot/server-config.yaml if it exists
 	// This provides migration path for users upgrading from old version
 	serverCfg, err := a.serverConfigMgr.Load()
 	if err != nil {
 		// Log error but continue with defaults
-		fmt.Printf("Failed to load server config, using defaults: %v\n", err)
+		appLog.Warn("Failed to load server config, using defaults: %v", err)
 	} else {
 		// Found old server-config.yaml, migrate to AppConfig
-		log.Println("Migrating server settings from old server-config.yaml to AppConfig")
-		log.Println("These settings will be marked as unsaved - please save to your main config file")
+		appLog.Info("Migrating server settings from old server-config.yaml to AppConfig")
+		appLog.Info("These settings will be marked as unsaved - please save to your main config file")
 
 		// Apply server config to app config
 		a.configMutex.Lock()
@@ -174,6 +250,11 @@ func (a *App) startup(ctx context.Context) {
 // This is non-blocking and thread-safe
 // All data is converted to map[string]interface{} to ensure proper Wails serialization
 func (a *App) SendEvent(source string, data interface{}) {
+	if a.headlessLog != nil {
+		a.headlessLog.SendEvent(source, data)
+		return
+	}
+
 	// Convert all data to map[string]interface{} for Wails serialization
 	var eventData map[string]interface{}
 
@@ -223,7 +304,7 @@ func (a *App) SendEvent(source string, data interface{}) {
 
 	default:
 		// Unknown type - log warning and create empty map
-		log.Printf("WARNING: Unknown event type %T for source %s", data, source)
+		appLog.Warn("Unknown event type %T for source %s", data, source)
 		eventData = map[string]interface{}{
 			"raw_value": fmt.Sprintf("%+v", data),
 			"type":      fmt.Sprintf("%T", data),
@@ -253,14 +334,26 @@ func (a *App) PollEvents() []Event {
 
 // shutdown is called when the app is closing
 func (a *App) shutdown(ctx context.Context) {
+	a.stopAutosave()
+	a.stopAdminAPI()
+	a.leakWatcher.Stop()
 	if a.server != nil {
 		a.server.Stop()
 	}
+	a.stopBackgroundWorkspaces()
 }
 
 // Emit implements the EventEmitter interface for Wails runtime events
 func (a *App) Emit(eventName string, data interface{}) {
-	runtime.EventsEmit(a.ctx, eventName, data)
+	a.emit(eventName, data)
+}
+
+// emit sends a Wails runtime event to the frontend; without a frontend (mockelot serve) it does nothing
+func (a *App) emit(eventName string, data ...interface{}) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, eventName, data...)
 }
 
 // StartServer starts the HTTP mock server on the specified port
@@ -280,13 +373,14 @@ func (a *App) StartServer(port int) error {
 
 	// If port changed, emit events to mark dirty
 	if portChanged {
-		runtime.EventsEmit(a.ctx, "config:port-changed", map[string]int{
+		a.emit("config:port-changed", map[string]int{
 			"http": port,
 		})
-		runtime.EventsEmit(a.ctx, "config:dirty", true)
+		a.emit("config:dirty", true)
 	}
 
-	a.server = server.NewHTTPServer(a.config, a, a, a, a.containerHandler, a.proxyHandler)
+	sink := a.activeSink()
+	a.server = server.NewHTTPServer(a.config, sink, sink, sink, a.containerHandler, a.proxyHandler)
 
 	err := a.server.Start()
 	if err != nil {
@@ -307,12 +401,12 @@ func (a *App) StartContainers() error {
 		return fmt.Errorf("server is not running")
 	}
 
-	log.Println("[StartContainers] Starting containers in background...")
+	appLog.Info("[StartContainers] Starting containers in background...")
 	// Start containers in goroutine so this function returns immediately
 	// Events will be sent via the event channel which is already listening
 	go func() {
 		if err := a.server.StartContainers(); err != nil {
-			log.Printf("[StartContainers] Error starting containers: %v", err)
+			appLog.Error("[StartContainers] Error starting containers: %v", err)
 		}
 	}()
 
@@ -343,6 +437,81 @@ func (a *App) GetServerStatus() ServerStatus {
 	return a.status
 }
 
+// GetEndpointListenerPorts returns the dedicated endpoint ports the running server listens on
+func (a *App) GetEndpointListenerPorts() []int {
+	if a.server == nil {
+		return []int{}
+	}
+	return a.server.EndpointListenerPorts()
+}
+
+// GetListeners returns the named listeners endpoints can be assigned to
+func (a *App) GetListeners() []models.Listener {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	if a.config.Listeners == nil {
+		return []models.Listener{}
+	}
+	return a.config.Listeners
+}
+
+// SetListeners replaces the named listeners. Names and ports must be unique, and listeners that
+// endpoints are still assigned to cannot be removed.
+func (a *App) SetListeners(listeners []models.Listener) error {
+	names := make(map[string]bool)
+	ports := make(map[int]bool)
+	for _, listener := range listeners {
+		if strings.TrimSpace(listener.Name) == "" {
+			return fmt.Errorf("listener name is required")
+		}
+		if names[listener.Name] {
+			return fmt.Errorf("duplicate listener name %q", listener.Name)
+		}
+		names[listener.Name] = true
+		if listener.Port < 1 || listener.Port > 65535 {
+			return fmt.Errorf("listener %s: invalid port %d", listener.Name, listener.Port)
+		}
+		if ports[listener.Port] {
+			return fmt.Errorf("listener %s: port %d is used by another listener", listener.Name, listener.Port)
+		}
+		ports[listener.Port] = true
+		if (listener.CertPath == "") != (listener.KeyPath == "") {
+			return fmt.Errorf("listener %s: certificate and key paths must be set together", listener.Name)
+		}
+		if !listener.TLS && (listener.CertPath != "" || listener.ClientCAPath != "") {
+			return fmt.Errorf("listener %s: certificate settings require tls", listener.Name)
+		}
+	}
+
+	a.configMutex.Lock()
+	if ports[a.config.Port] || (a.config.HTTPSEnabled && ports[a.config.HTTPSPort]) {
+		a.configMutex.Unlock()
+		return fmt.Errorf("listener ports cannot be the HTTP or HTTPS server port")
+	}
+	for _, endpoint := range a.config.Endpoints {
+		if endpoint.Listener != "" && !names[endpoint.Listener] {
+			a.configMutex.Unlock()
+			return fmt.Errorf("listener %q is used by endpoint %s", endpoint.Listener, endpoint.Name)
+		}
+	}
+	a.config.Listeners = listeners
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+	a.configMutex.Unlock()
+
+	a.emit("config:dirty", true)
+	return nil
+}
+
+// GetActiveListeners returns the names of the named listeners the running server listens on
+func (a *App) GetActiveListeners() []string {
+	if a.server == nil {
+		return []string{}
+	}
+	return a.server.ActiveListeners()
+}
+
 // GetConfig returns the current configuration
 func (a *App) GetConfig() *models.AppConfig {
 	return a.config
@@ -378,6 +547,7 @@ func (a *App) GetItems() []models.ResponseItem {
 
 // SetItems replaces all response items for the selected endpoint
 func (a *App) SetItems(items []models.ResponseItem) error {
+	edit := a.snapshotEdit("Edit responses")
 	// Get the selected endpoint ID
 	selectedId := a.GetSelectedEndpointId()
 	if selectedId == "" {
@@ -408,7 +578,15 @@ func (a *App) SetItems(items []models.ResponseItem) error {
 			endpoint := &a.config.Endpoints[i]
 			// Only set items for mock endpoints
 			if endpoint.Type == models.EndpointTypeMock {
+				// Reject patterns that do not compile
+				candidate := *endpoint
+				candidate.Items = items
+				if errs := server.ValidateEndpointPatterns(&candidate); len(errs) > 0 {
+					return &server.PatternValidationError{Errors: errs}
+				}
+				keepDraftBaselines(endpoint.Items, items)
 				endpoint.Items = items
+				a.commitEdit(edit)
 			} else {
 				return fmt.Errorf("cannot set items for non-mock endpoint")
 			}
@@ -422,13 +600,20 @@ func (a *App) SetItems(items []models.ResponseItem) error {
 	}
 
 	// Emit event to frontend
-	runtime.EventsEmit(a.ctx, "items:updated", items)
+	a.emit("items:updated", items)
+	for i := range a.config.Endpoints {
+		if a.config.Endpoints[i].ID == selectedId {
+			a.reportScriptWarnings(server.ValidateEndpointScripts(&a.config.Endpoints[i]))
+			break
+		}
+	}
 
 	return nil
 }
 
 // AddGroup adds a new group to the selected endpoint
 func (a *App) AddGroup(name string) (models.ResponseGroup, error) {
+	edit := a.snapshotEdit("Add group")
 	// Get the selected endpoint ID
 	selectedId := a.GetSelectedEndpointId()
 	if selectedId == "" {
@@ -455,6 +640,7 @@ func (a *App) AddGroup(name string) (models.ResponseGroup, error) {
 			if endpoint.Type == models.EndpointTypeMock {
 				endpoint.Items = append(endpoint.Items, item)
 				found = true
+				a.commitEdit(edit)
 			} else {
 				return models.ResponseGroup{}, fmt.Errorf("cannot add group to non-mock endpoint")
 			}
@@ -474,15 +660,132 @@ func (a *App) AddGroup(name string) (models.ResponseGroup, error) {
 	return group, nil
 }
 
+// ApplyCachePreset applies a caching header preset to a response, or to every response in a group.
+// targetID may be a response ID or a group ID; maxAge (seconds, 0 = preset default) tunes the max-age.
+func (a *App) ApplyCachePreset(targetID string, preset string, maxAge int) error {
+	edit := a.snapshotEdit("Apply cache preset")
+	cacheHeaders, err := models.CachePresetHeaders(preset, maxAge)
+	if err != nil {
+		return err
+	}
+
+	return a.applyToResponses(edit, targetID, func(resp *models.MethodResponse) error {
+		models.ApplyCacheHeaders(resp, cacheHeaders)
+		return nil
+	})
+}
+
+// ApplyEncodingPreset replaces the body of a response (or every response in a group) with a
+// Unicode/encoding edge-case preset such as "invalid-utf8" or "rtl-override".
+// Presets that are not valid UTF-8 are stored base64 (body_base64) so editing the config cannot mangle them.
+func (a *App) ApplyEncodingPreset(targetID string, preset string) error {
+	edit := a.snapshotEdit("Apply encoding preset")
+	if _, _, _, err := models.EncodingPresetBody(preset); err != nil {
+		return err
+	}
+
+	return a.applyToResponses(edit, targetID, func(resp *models.MethodResponse) error {
+		return models.ApplyEncodingPreset(resp, preset)
+	})
+}
+
+// applyToResponses applies a change to the response with targetID, or to every response in the group with targetID,
+// wherever it lives (top-level items, endpoints, API versions, legacy responses), then pushes the config to the server.
+// The edit is committed to the undo history only when the change was applied.
+func (a *App) applyToResponses(edit *pendingEdit, targetID string, apply func(*models.MethodResponse) error) error {
+	a.configMutex.Lock()
+	applied, err := applyToItems(a.config.Items, targetID, apply)
+	for i := range a.config.Endpoints {
+		if err != nil {
+			break
+		}
+		var found bool
+		found, err = applyToItems(a.config.Endpoints[i].Items, targetID, apply)
+		applied = applied || found
+		if versioning := a.config.Endpoints[i].Versioning; versioning != nil {
+			for j := range versioning.Versions {
+				if err != nil {
+					break
+				}
+				found, err = applyToItems(versioning.Versions[j].Items, targetID, apply)
+				applied = applied || found
+			}
+		}
+	}
+	for i := range a.config.Responses {
+		if err == nil && a.config.Responses[i].ID == targetID {
+			err = apply(&a.config.Responses[i])
+			applied = true
+		}
+	}
+	a.configMutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return fmt.Errorf("response or group not found: %s", targetID)
+	}
+	a.commitEdit(edit)
+
+	// If server is running, update it
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:dirty", true)
+
+	return nil
+}
+
+// applyToItems applies a change to the matching response or group; returns true if found
+func applyToItems(items []models.ResponseItem, targetID string, apply func(*models.MethodResponse) error) (bool, error) {
+	applied := false
+	for _, item := range items {
+		if item.Type == "response" && item.Response != nil && item.Response.ID == targetID {
+			if err := apply(item.Response); err != nil {
+				return true, err
+			}
+			applied = true
+		} else if item.Type == "group" && item.Group != nil {
+			groupMatch := item.Group.ID == targetID
+			for i := range item.Group.Responses {
+				if groupMatch || item.Group.Responses[i].ID == targetID {
+					if err := apply(&item.Group.Responses[i]); err != nil {
+						return true, err
+					}
+					applied = true
+				}
+			}
+			if groupMatch {
+				applied = true
+			}
+		}
+	}
+	return applied, nil
+}
+
 // UpdateResponse updates a single response configuration (legacy - updates first response)
 func (a *App) UpdateResponse(response models.MethodResponse) error {
+	edit := a.snapshotEdit("Update response")
 	// Ensure ID is set
 	if response.ID == "" {
 		response.ID = uuid.New().String()
 	}
+	if errs := server.ValidateResponsePatterns(&response); len(errs) > 0 {
+		return &server.PatternValidationError{Errors: errs}
+	}
+
+	var prev *models.MethodResponse
+	if len(a.config.Responses) > 0 && a.config.Responses[0].ID == response.ID {
+		prev = &a.config.Responses[0]
+	}
+	keepDraftBaseline(prev, &response)
 
 	// Update the config
 	a.config.Responses = []models.MethodResponse{response}
+	a.commitEdit(edit)
 
 	// If server is running, update it
 	if a.server != nil {
@@ -494,14 +797,29 @@ func (a *App) UpdateResponse(response models.MethodResponse) error {
 
 // SetResponses replaces all response rules with the provided list
 func (a *App) SetResponses(responses []models.MethodResponse) error {
+	edit := a.snapshotEdit("Edit responses")
 	// Ensure all responses have IDs
+	var errs []models.PatternError
 	for i := range responses {
 		if responses[i].ID == "" {
 			responses[i].ID = uuid.New().String()
 		}
+		errs = append(errs, server.ValidateResponsePatterns(&responses[i])...)
+	}
+	if len(errs) > 0 {
+		return &server.PatternValidationError{Errors: errs}
+	}
+
+	byID := make(map[string]*models.MethodResponse)
+	for i := range a.config.Responses {
+		byID[a.config.Responses[i].ID] = &a.config.Responses[i]
+	}
+	for i := range responses {
+		keepDraftBaseline(byID[responses[i].ID], &responses[i])
 	}
 
 	a.config.Responses = responses
+	a.commitEdit(edit)
 
 	// If server is running, update it
 	if a.server != nil {
@@ -509,19 +827,25 @@ func (a *App) SetResponses(responses []models.MethodResponse) error {
 	}
 
 	// Emit event to frontend
-	runtime.EventsEmit(a.ctx, "responses:updated", responses)
+	a.emit("responses:updated", responses)
 
 	return nil
 }
 
 // AddResponse adds a new response rule
 func (a *App) AddResponse(response models.MethodResponse) (models.MethodResponse, error) {
+	edit := a.snapshotEdit("Add response")
 	// Generate ID if not provided
 	if response.ID == "" {
 		response.ID = uuid.New().String()
 	}
+	if errs := server.ValidateResponsePatterns(&response); len(errs) > 0 {
+		return models.MethodResponse{}, &server.PatternValidationError{Errors: errs}
+	}
+	keepDraftBaseline(nil, &response)
 
 	a.config.Responses = append(a.config.Responses, response)
+	a.commitEdit(edit)
 
 	// If server is running, update it
 	if a.server != nil {
@@ -533,9 +857,15 @@ func (a *App) AddResponse(response models.MethodResponse) (models.MethodResponse
 
 // UpdateResponseByID updates a specific response rule by ID
 func (a *App) UpdateResponseByID(response models.MethodResponse) error {
+	edit := a.snapshotEdit("Update response")
+	if errs := server.ValidateResponsePatterns(&response); len(errs) > 0 {
+		return &server.PatternValidationError{Errors: errs}
+	}
 	for i, r := range a.config.Responses {
 		if r.ID == response.ID {
+			keepDraftBaseline(&a.config.Responses[i], &response)
 			a.config.Responses[i] = response
+			a.commitEdit(edit)
 			break
 		}
 	}
@@ -550,9 +880,11 @@ func (a *App) UpdateResponseByID(response models.MethodResponse) error {
 
 // DeleteResponse removes a response rule by ID
 func (a *App) DeleteResponse(id string) error {
+	edit := a.snapshotEdit("Delete response")
 	for i, r := range a.config.Responses {
 		if r.ID == id {
 			a.config.Responses = append(a.config.Responses[:i], a.config.Responses[i+1:]...)
+			a.commitEdit(edit)
 			break
 		}
 	}
@@ -567,6 +899,7 @@ func (a *App) DeleteResponse(id string) error {
 
 // ReorderResponses reorders response rules based on the provided ID order
 func (a *App) ReorderResponses(ids []string) error {
+	edit := a.snapshotEdit("Reorder responses")
 	// Create a map for quick lookup
 	responseMap := make(map[string]models.MethodResponse)
 	for _, r := range a.config.Responses {
@@ -582,6 +915,7 @@ func (a *App) ReorderResponses(ids []string) error {
 	}
 
 	a.config.Responses = newResponses
+	a.commitEdit(edit)
 
 	// If server is running, update it
 	if a.server != nil {
@@ -611,6 +945,18 @@ func (a *App) GetEndpoints() []models.Endpoint {
 	return endpoints
 }
 
+// GetEndpoint returns the endpoint with the given ID
+func (a *App) GetEndpoint(id string) (models.Endpoint, error) {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	for _, endpoint := range a.config.Endpoints {
+		if endpoint.ID == id {
+			return endpoint, nil
+		}
+	}
+	return models.Endpoint{}, fmt.Errorf("endpoint not found: %s", id)
+}
+
 // GetDefaultContainerHeaders returns the default inbound headers for container endpoints
 func (a *App) GetDefaultContainerHeaders() []models.HeaderManipulation {
 	return models.DefaultContainerInboundHeaders()
@@ -618,21 +964,20 @@ func (a *App) GetDefaultContainerHeaders() []models.HeaderManipulation {
 
 // AddEndpoint adds a new endpoint with specified type
 func (a *App) AddEndpoint(name string, pathPrefix string, translationMode string, endpointType string) (models.Endpoint, error) {
-	log.Printf("AddEndpoint called with: name=%s, pathPrefix=%s, translationMode=%s, endpointType=%s", name, pathPrefix, translationMode, endpointType)
+	edit := a.snapshotEdit("Add endpoint")
+	appLog.Debug("AddEndpoint called with: name=%s, pathPrefix=%s, translationMode=%s, endpointType=%s", name, pathPrefix, translationMode, endpointType)
 
 	// Validate translation mode
 	if translationMode != models.TranslationModeNone &&
 		translationMode != models.TranslationModeStrip &&
 		translationMode != models.TranslationModeTranslate {
-		log.Printf("Invalid translation mode '%s', defaulting to 'none'", translationMode)
+		appLog.Warn("Invalid translation mode '%s', defaulting to 'none'", translationMode)
 		translationMode = models.TranslationModeNone // Default to none if invalid
 	}
 
-	// Validate endpoint type
-	if endpointType != models.EndpointTypeMock &&
-		endpointType != models.EndpointTypeProxy &&
-		endpointType != models.EndpointTypeContainer {
-		log.Printf("Invalid endpoint type '%s', defaulting to 'mock'. Valid types: %s, %s, %s",
+	// Validate endpoint type (plugin-provided types are accepted too)
+	if !endpointtype.IsKnown(endpointType) {
+		appLog.Warn("Invalid endpoint type '%s', defaulting to 'mock'. Valid types: %s, %s, %s or a plugin type",
 			endpointType, models.EndpointTypeMock, models.EndpointTypeProxy, models.EndpointTypeContainer)
 		endpointType = models.EndpointTypeMock // Default to mock if invalid
 	}
@@ -675,6 +1020,11 @@ func (a *App) AddEndpoint(name string, pathPrefix string, translationMode string
 		}
 	}
 
+	// Reject patterns that do not compile
+	if errs := server.ValidateEndpointPatterns(&endpoint); len(errs) > 0 {
+		return models.Endpoint{}, &server.PatternValidationError{Errors: errs}
+	}
+
 	// Insert endpoint before system endpoints (like Rejections)
 	// Find the index of the first system endpoint
 	insertIndex := len(a.config.Endpoints)
@@ -693,6 +1043,7 @@ func (a *App) AddEndpoint(name string, pathPrefix string, translationMode string
 		// No system endpoints, append at end
 		a.config.Endpoints = append(a.config.Endpoints, endpoint)
 	}
+	a.commitEdit(edit)
 
 	// If server is running, update it
 	if a.server != nil {
@@ -700,14 +1051,14 @@ func (a *App) AddEndpoint(name string, pathPrefix string, translationMode string
 	}
 
 	// Emit event to frontend
-	runtime.EventsEmit(a.ctx, "endpoints:updated", a.config.Endpoints)
+	a.emit("endpoints:updated", a.config.Endpoints)
 
 	return endpoint, nil
 }
 
 // AddEndpointWithConfig adds a new endpoint with full configuration from wizard
 func (a *App) AddEndpointWithConfig(config map[string]interface{}) (models.Endpoint, error) {
-
+	edit := a.snapshotEdit("Add endpoint")
 	// Extract basic fields
 	name, _ := config["name"].(string)
 	pathPrefix, _ := config["path_prefix"].(string)
@@ -718,15 +1069,13 @@ func (a *App) AddEndpointWithConfig(config map[string]interface{}) (models.Endpo
 	if translationMode != models.TranslationModeNone &&
 		translationMode != models.TranslationModeStrip &&
 		translationMode != models.TranslationModeTranslate {
-		log.Printf("Invalid translation mode '%s', defaulting to 'none'", translationMode)
+		appLog.Warn("Invalid translation mode '%s', defaulting to 'none'", translationMode)
 		translationMode = models.TranslationModeNone
 	}
 
-	// Validate endpoint type
-	if endpointType != models.EndpointTypeMock &&
-		endpointType != models.EndpointTypeProxy &&
-		endpointType != models.EndpointTypeContainer {
-		log.Printf("Invalid endpoint type '%s', defaulting to 'mock'", endpointType)
+	// Validate endpoint type (plugin-provided types are accepted too)
+	if !endpointtype.IsKnown(endpointType) {
+		appLog.Warn("Invalid endpoint type '%s', defaulting to 'mock'", endpointType)
 		endpointType = models.EndpointTypeMock
 	}
 
@@ -739,6 +1088,15 @@ func (a *App) AddEndpointWithConfig(config map[string]interface{}) (models.Endpo
 		TranslationMode: translationMode,
 		Type:            endpointType,
 		Enabled:         &enabledTrue,
+		ListenPort:      getInt(config, "listen_port", 0),
+		Listener:        getString(config, "listener"),
+	}
+
+	if endpoint.ListenPort < 0 || endpoint.ListenPort > 65535 {
+		return models.Endpoint{}, fmt.Errorf("invalid listen port %d", endpoint.ListenPort)
+	}
+	if err := a.validateEndpointListener(&endpoint); err != nil {
+		return models.Endpoint{}, err
 	}
 
 	// Initialize type-specific configuration from wizard data
@@ -755,6 +1113,32 @@ func (a *App) AddEndpointWithConfig(config map[string]interface{}) (models.Endpo
 				StatusPassthrough: getBool(proxyConfig, "status_passthrough", true),
 			}
 
+			// Parse per-environment backend URLs
+			if environments, ok := proxyConfig["environments"].(map[string]interface{}); ok {
+				endpoint.ProxyConfig.Environments = make(map[string]string, len(environments))
+				for name, value := range environments {
+					if backendURL, ok := value.(string); ok {
+						endpoint.ProxyConfig.Environments[name] = backendURL
+					}
+				}
+			}
+
+			// Parse the load-balanced backend pool
+			if backends, ok := proxyConfig["backends"].([]interface{}); ok {
+				for _, value := range backends {
+					if backend, ok := value.(map[string]interface{}); ok {
+						endpoint.ProxyConfig.Backends = append(endpoint.ProxyConfig.Backends, models.ProxyBackend{
+							URL:    getString(backend, "url"),
+							Weight: getInt(backend, "weight", 0),
+						})
+					}
+				}
+			}
+			endpoint.ProxyConfig.BalanceStrategy = getString(proxyConfig, "balance_strategy")
+			if err := server.ValidateProxyBackends(endpoint.ProxyConfig); err != nil {
+				return models.Endpoint{}, err
+			}
+
 			// Parse status translations
 			if statusTranslations, ok := proxyConfig["status_translation"].([]interface{}); ok {
 				endpoint.ProxyConfig.StatusTranslation = parseStatusTranslations(statusTranslations)
@@ -797,6 +1181,7 @@ func (a *App) AddEndpointWithConfig(config map[string]interface{}) (models.Endpo
 				RestartPolicy:        getString(containerConfig, "restart_policy"),
 				HostNetworking:       getBool(containerConfig, "host_networking", false),
 				DockerSocketAccess:   getBool(containerConfig, "docker_socket_access", false),
+				ContainerHost:        getString(containerConfig, "container_host"),
 			}
 
 			// Parse inbound headers (if custom headers provided, they override defaults)
@@ -823,6 +1208,53 @@ func (a *App) AddEndpointWithConfig(config map[string]interface{}) (models.Endpo
 			} else {
 				endpoint.ContainerConfig.Environment = []models.EnvironmentVar{}
 			}
+
+			// Parse the sidecars of a multi-container group
+			if sidecars, ok := containerConfig["sidecars"].([]interface{}); ok {
+				endpoint.ContainerConfig.Sidecars = parseSidecars(sidecars)
+			}
+			endpoint.ContainerConfig.NetworkAlias = getString(containerConfig, "network_alias")
+			if err := server.ValidateContainerGroup(endpoint.ContainerConfig); err != nil {
+				return models.Endpoint{}, err
+			}
+
+			// Parse the private registry login
+			endpoint.ContainerConfig.RegistryAuth = parseRegistryCredential(containerConfig["registry_auth"])
+
+			// Parse the Dockerfile build
+			if build, ok := containerConfig["build"].(map[string]interface{}); ok {
+				endpoint.ContainerConfig.Build = &models.ContainerBuild{
+					ContextPath: getString(build, "context_path"),
+					Dockerfile:  getString(build, "dockerfile"),
+					Target:      getString(build, "target"),
+					NoCache:     getBool(build, "no_cache", false),
+				}
+				if args, ok := build["args"].([]interface{}); ok {
+					endpoint.ContainerConfig.Build.Args = parseEnvironmentVars(args)
+				}
+				if err := server.ValidateContainerBuild(endpoint.ContainerConfig); err != nil {
+					return models.Endpoint{}, err
+				}
+			}
+
+			// Parse the resource limits
+			if resources, ok := containerConfig["resources"].(map[string]interface{}); ok {
+				endpoint.ContainerConfig.Resources = &models.ContainerResources{
+					CPUShares: int64(getInt(resources, "cpu_shares", 0)),
+					CPUs:      getFloat(resources, "cpus", 0),
+					MemoryMB:  int64(getInt(resources, "memory_mb", 0)),
+					PidsLimit: int64(getInt(resources, "pids_limit", 0)),
+				}
+			}
+			if err := server.ValidateContainerResources(endpoint.ContainerConfig); err != nil {
+				return models.Endpoint{}, err
+			}
+
+			// Parse the readiness check
+			endpoint.ContainerConfig.Readiness = parseContainerReadiness(containerConfig["readiness"])
+			if err := server.ValidateContainerReadiness(endpoint.ContainerConfig); err != nil {
+				return models.Endpoint{}, err
+			}
 		} else {
 			// Initialize with defaults if no config provided
 			endpoint.ContainerConfig = &models.ContainerConfig{
@@ -838,6 +1270,15 @@ func (a *App) AddEndpointWithConfig(config map[string]interface{}) (models.Endpo
 				Environment:   []models.EnvironmentVar{},
 			}
 		}
+
+	default:
+		// Plugin types keep their settings as given
+		endpoint.PluginConfig, _ = config["plugin_config"].(map[string]interface{})
+	}
+
+	// Reject patterns that do not compile
+	if errs := server.ValidateEndpointPatterns(&endpoint); len(errs) > 0 {
+		return models.Endpoint{}, &server.PatternValidationError{Errors: errs}
 	}
 
 	// Insert endpoint before system endpoints (like Rejections)
@@ -858,8 +1299,9 @@ func (a *App) AddEndpointWithConfig(config map[string]interface{}) (models.Endpo
 		// No system endpoints, append at end
 		a.config.Endpoints = append(a.config.Endpoints, endpoint)
 	}
+	a.commitEdit(edit)
 
-	log.Printf("Created endpoint with full config: ID=%s, Name=%s, Type=%s", endpoint.ID, endpoint.Name, endpoint.Type)
+	appLog.Info("Created endpoint with full config: ID=%s, Name=%s, Type=%s", endpoint.ID, endpoint.Name, endpoint.Type)
 
 	// If server is running, update it
 	if a.server != nil {
@@ -867,7 +1309,7 @@ func (a *App) AddEndpointWithConfig(config map[string]interface{}) (models.Endpo
 	}
 
 	// Emit event to frontend
-	runtime.EventsEmit(a.ctx, "endpoints:updated", a.config.Endpoints)
+	a.emit("endpoints:updated", a.config.Endpoints)
 
 	return endpoint, nil
 }
@@ -887,6 +1329,13 @@ func getInt(m map[string]interface{}, key string, defaultVal int) int {
 	return defaultVal
 }
 
+func getFloat(m map[string]interface{}, key string, defaultVal float64) float64 {
+	if val, ok := m[key].(float64); ok {
+		return val
+	}
+	return defaultVal
+}
+
 func getBool(m map[string]interface{}, key string, defaultVal bool) bool {
 	if val, ok := m[key].(bool); ok {
 		return val
@@ -950,6 +1399,72 @@ func parseEnvironmentVars(data []interface{}) []models.EnvironmentVar {
 	return result
 }
 
+func parseSidecars(data []interface{}) []models.SidecarContainer {
+	result := []models.SidecarContainer{}
+	for _, item := range data {
+		if m, ok := item.(map[string]interface{}); ok {
+			sidecar := models.SidecarContainer{
+				Name:          getString(m, "name"),
+				ImageName:     getString(m, "image_name"),
+				PullOnStartup: getBool(m, "pull_on_startup", true),
+			}
+			if command, ok := m["command"].([]interface{}); ok {
+				sidecar.Command = getStringSlice(command)
+			}
+			if dependsOn, ok := m["depends_on"].([]interface{}); ok {
+				sidecar.DependsOn = getStringSlice(dependsOn)
+			}
+			if environment, ok := m["environment"].([]interface{}); ok {
+				sidecar.Environment = parseEnvironmentVars(environment)
+			}
+			if volumes, ok := m["volumes"].([]interface{}); ok {
+				sidecar.Volumes = parseVolumes(volumes)
+			}
+			sidecar.Readiness = parseContainerReadiness(m["readiness"])
+			result = append(result, sidecar)
+		}
+	}
+	return result
+}
+
+// parseRegistryCredential reads a registry login; nil when absent or without a username
+func parseRegistryCredential(data interface{}) *models.RegistryCredential {
+	m, ok := data.(map[string]interface{})
+	if !ok || getString(m, "username") == "" {
+		return nil
+	}
+	return &models.RegistryCredential{
+		Registry: getString(m, "registry"),
+		Username: getString(m, "username"),
+		Password: getString(m, "password"),
+	}
+}
+
+// parseContainerReadiness reads a readiness check; nil when absent or without a strategy
+func parseContainerReadiness(data interface{}) *models.ContainerReadiness {
+	m, ok := data.(map[string]interface{})
+	if !ok || getString(m, "strategy") == "" {
+		return nil
+	}
+	return &models.ContainerReadiness{
+		Strategy:       getString(m, "strategy"),
+		LogPattern:     getString(m, "log_pattern"),
+		Port:           getInt(m, "port", 0),
+		DelaySeconds:   getInt(m, "delay_seconds", 0),
+		TimeoutSeconds: getInt(m, "timeout_seconds", 0),
+	}
+}
+
+func getStringSlice(data []interface{}) []string {
+	var result []string
+	for _, item := range data {
+		if value, ok := item.(string); ok {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
 // ensureDomainTakeoverEndpoints creates/updates synthetic proxy endpoints for each domain in the takeover list.
 // These endpoints allow SOCKS5-intercepted domains to be proxied to their real backend while logging traffic.
 // IMPORTANT: Overlay endpoints must appear BEFORE the system-rejections endpoint in the array
@@ -1017,7 +1532,7 @@ func (a *App) ensureDomainTakeoverEndpoints() {
 		} else if strings.HasPrefix(endpoint.ID, overlayPrefix) {
 			// Skip old overlay endpoints - we'll add fresh ones
 			if _, expected := expectedOverlays[endpoint.ID]; !expected {
-				log.Printf("Removed stale overlay proxy endpoint: %s", endpoint.ID)
+				appLog.Info("Removed stale overlay proxy endpoint: %s", endpoint.ID)
 			}
 		} else {
 			// Keep user endpoints
@@ -1031,7 +1546,7 @@ func (a *App) ensureDomainTakeoverEndpoints() {
 	// Add overlay endpoints (DisplayOrder 999997)
 	for id, overlay := range expectedOverlays {
 		a.config.Endpoints = append(a.config.Endpoints, overlay)
-		log.Printf("Ensured overlay proxy endpoint for domain: %s", id)
+		appLog.Info("Ensured overlay proxy endpoint for domain: %s", id)
 	}
 
 	// Add SOCKS5 endpoint (DisplayOrder 999998, if it exists)
@@ -1137,6 +1652,42 @@ func (a *App) ensureRejectionsEndpoint() {
 	a.config.Endpoints = append(a.config.Endpoints, rejectionsEndpoint)
 }
 
+// validateEndpointListener checks that an endpoint's named listener exists. An endpoint is served
+// either by a named listener or by its own listen_port, not both.
+func (a *App) validateEndpointListener(endpoint *models.Endpoint) error {
+	if endpoint.Listener == "" {
+		return nil
+	}
+	if endpoint.ListenPort != 0 {
+		return fmt.Errorf("endpoint cannot have both a listener and a listen port")
+	}
+	for _, listener := range a.config.Listeners {
+		if listener.Name == endpoint.Listener {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown listener %q", endpoint.Listener)
+}
+
+// validateEndpointTransport checks the backend connection settings of a proxy or container endpoint
+func validateEndpointTransport(endpoint *models.Endpoint) error {
+	if err := server.ValidateProxyTransport(endpoint.ProxyConfig); err != nil {
+		return err
+	}
+	if err := server.ValidateProxyBackends(endpoint.ProxyConfig); err != nil {
+		return err
+	}
+	if endpoint.ProxyConfig != nil {
+		if err := server.ValidateCircuitBreaker(endpoint.ProxyConfig.CircuitBreaker); err != nil {
+			return err
+		}
+	}
+	if endpoint.ContainerConfig != nil {
+		return server.ValidateProxyTransport(&endpoint.ContainerConfig.ProxyConfig)
+	}
+	return nil
+}
+
 // ensureDisplayOrder ensures all endpoints have DisplayOrder set
 // Legacy configs may not have this field, so we set it based on array index
 func (a *App) ensureDisplayOrder() {
@@ -1149,11 +1700,44 @@ func (a *App) ensureDisplayOrder() {
 
 // UpdateEndpoint updates an existing endpoint
 func (a *App) UpdateEndpoint(endpoint models.Endpoint) error {
+	edit := a.snapshotEdit("Update endpoint")
+	if endpoint.ListenPort < 0 || endpoint.ListenPort > 65535 {
+		return fmt.Errorf("invalid listen port %d", endpoint.ListenPort)
+	}
+	if err := a.validateEndpointListener(&endpoint); err != nil {
+		return err
+	}
+	if err := validateEndpointTransport(&endpoint); err != nil {
+		return err
+	}
+	if err := server.ValidateEndpointAuth(endpoint.Auth); err != nil {
+		return err
+	}
+	if err := server.ValidateContainerGroup(endpoint.ContainerConfig); err != nil {
+		return err
+	}
+	if err := server.ValidateContainerBuild(endpoint.ContainerConfig); err != nil {
+		return err
+	}
+	if err := server.ValidateContainerResources(endpoint.ContainerConfig); err != nil {
+		return err
+	}
+	if err := server.ValidateContainerReadiness(endpoint.ContainerConfig); err != nil {
+		return err
+	}
+
 	for i := range a.config.Endpoints {
 		if a.config.Endpoints[i].ID == endpoint.ID {
 			// Preserve Items array (not sent from settings dialog)
 			existingItems := a.config.Endpoints[i].Items
 
+			// Reject patterns that do not compile
+			candidate := endpoint
+			candidate.Items = existingItems
+			if errs := server.ValidateEndpointPatterns(&candidate); len(errs) > 0 {
+				return &server.PatternValidationError{Errors: errs}
+			}
+
 			// Preserve runtime state for containers
 			var existingContainerID string
 			if a.config.Endpoints[i].ContainerConfig != nil {
@@ -1168,6 +1752,7 @@ func (a *App) UpdateEndpoint(endpoint models.Endpoint) error {
 			if a.config.Endpoints[i].ContainerConfig != nil && existingContainerID != "" {
 				a.config.Endpoints[i].ContainerConfig.ContainerID = existingContainerID
 			}
+			a.commitEdit(edit)
 
 			break
 		}
@@ -1179,13 +1764,20 @@ func (a *App) UpdateEndpoint(endpoint models.Endpoint) error {
 	}
 
 	// Emit event to frontend
-	runtime.EventsEmit(a.ctx, "endpoints:updated", a.config.Endpoints)
+	a.emit("endpoints:updated", a.config.Endpoints)
+	for i := range a.config.Endpoints {
+		if a.config.Endpoints[i].ID == endpoint.ID {
+			a.reportScriptWarnings(server.ValidateEndpointScripts(&a.config.Endpoints[i]))
+			break
+		}
+	}
 
 	return nil
 }
 
 // DeleteEndpoint removes an endpoint by ID
 func (a *App) DeleteEndpoint(id string) error {
+	edit := a.snapshotEdit("Delete endpoint")
 	for i, endpoint := range a.config.Endpoints {
 		if endpoint.ID == id {
 			// Prevent deletion of system endpoints
@@ -1193,6 +1785,7 @@ func (a *App) DeleteEndpoint(id string) error {
 				return fmt.Errorf("cannot delete system endpoint")
 			}
 			a.config.Endpoints = append(a.config.Endpoints[:i], a.config.Endpoints[i+1:]...)
+			a.commitEdit(edit)
 			break
 		}
 	}
@@ -1203,27 +1796,240 @@ func (a *App) DeleteEndpoint(id string) error {
 	}
 
 	// Emit event to frontend
-	runtime.EventsEmit(a.ctx, "endpoints:updated", a.config.Endpoints)
+	a.emit("endpoints:updated", a.config.Endpoints)
 
 	return nil
 }
 
-// GetEndpointHealth returns health status for an endpoint
-func (a *App) GetEndpointHealth(endpointID string) (*models.HealthStatus, error) {
-	if a.server == nil {
-		return nil, fmt.Errorf("server not running")
-	}
-
-	// Find endpoint
-	var endpoint *models.Endpoint
+// DuplicateEndpoint adds a deep copy of an endpoint after the user endpoints. The copy and all of
+// its groups and responses get fresh IDs, and it is named "<name> (copy)" with "-copy" appended to
+// the path prefix, so it does not shadow the original.
+func (a *App) DuplicateEndpoint(id string) (models.Endpoint, error) {
+	edit := a.snapshotEdit("Duplicate endpoint")
+	a.configMutex.Lock()
+	var duplicate models.Endpoint
+	found := false
 	for i := range a.config.Endpoints {
-		if a.config.Endpoints[i].ID == endpointID {
-			endpoint = &a.config.Endpoints[i]
+		if a.config.Endpoints[i].ID == id {
+			if a.config.Endpoints[i].IsSystem {
+				a.configMutex.Unlock()
+				return models.Endpoint{}, fmt.Errorf("cannot duplicate system endpoint")
+			}
+			if err := models.DeepCopy(&a.config.Endpoints[i], &duplicate); err != nil {
+				a.configMutex.Unlock()
+				return models.Endpoint{}, fmt.Errorf("could not copy endpoint: %v", err)
+			}
+			found = true
 			break
 		}
 	}
+	if !found {
+		a.configMutex.Unlock()
+		return models.Endpoint{}, fmt.Errorf("endpoint not found: %s", id)
+	}
 
-	if endpoint == nil {
+	assignNewIDs(&duplicate)
+	duplicate.Name, duplicate.PathPrefix = a.copyNameAndPrefix(duplicate.Name, duplicate.PathPrefix)
+	// Only one mock replaces an endpoint in offline mode
+	duplicate.SnapshotOf = ""
+	if errs := server.ValidateEndpointPatterns(&duplicate); len(errs) > 0 {
+		a.configMutex.Unlock()
+		return models.Endpoint{}, &server.PatternValidationError{Errors: errs}
+	}
+
+	insertIndex, nextOrder := a.userEndpointInsertPoint()
+	duplicate.DisplayOrder = nextOrder
+	a.config.Endpoints = append(a.config.Endpoints[:insertIndex], append([]models.Endpoint{duplicate}, a.config.Endpoints[insertIndex:]...)...)
+	a.commitEdit(edit)
+	a.configMutex.Unlock()
+
+	appLog.Info("Duplicated endpoint %s as %s (%s)", id, duplicate.Name, duplicate.ID)
+
+	// If server is running, update it
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	// Emit events to frontend
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:dirty", true)
+
+	return duplicate, nil
+}
+
+// copyNameAndPrefix returns the name and path prefix of a copy of an endpoint, numbered when an
+// earlier copy has them (caller holds configMutex)
+func (a *App) copyNameAndPrefix(name, prefix string) (string, string) {
+	names := make(map[string]bool)
+	prefixes := make(map[string]bool)
+	for _, endpoint := range a.config.Endpoints {
+		names[endpoint.Name] = true
+		prefixes[endpoint.PathPrefix] = true
+	}
+
+	base := strings.TrimSuffix(prefix, "/")
+	if base == "" {
+		base = "/"
+	} else {
+		base += "-"
+	}
+	for n := 1; ; n++ {
+		copyName, copyPrefix := name+" (copy)", base+"copy"
+		if n > 1 {
+			copyName = fmt.Sprintf("%s (copy %d)", name, n)
+			copyPrefix = fmt.Sprintf("%scopy-%d", base, n)
+		}
+		if !names[copyName] && !prefixes[copyPrefix] {
+			return copyName, copyPrefix
+		}
+	}
+}
+
+// DuplicateResponse inserts a deep copy of a response, or of a group with all of its responses,
+// right after the original, with fresh IDs. A copied group is named "<name> (copy)". A copied
+// response keeps its path pattern, so it only matches once the original is disabled or edited.
+// Returns the ID of the copy.
+func (a *App) DuplicateResponse(id string) (string, error) {
+	edit := a.snapshotEdit("Duplicate response")
+	a.configMutex.Lock()
+	copyID := ""
+	var copyErr error
+	a.forEachItemList(func(items []models.ResponseItem) []models.ResponseItem {
+		if copyID != "" || copyErr != nil {
+			return items
+		}
+		items, copyID, copyErr = duplicateInItems(items, id)
+		return items
+	})
+	a.configMutex.Unlock()
+
+	if copyErr != nil {
+		return "", copyErr
+	}
+	if copyID == "" {
+		return "", fmt.Errorf("response or group not found: %s", id)
+	}
+	a.commitEdit(edit)
+
+	appLog.Info("Duplicated response or group %s as %s", id, copyID)
+
+	// If server is running, update it
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	// Emit events to frontend
+	a.emit("items:updated", a.GetItems())
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:dirty", true)
+
+	return copyID, nil
+}
+
+// duplicateInItems inserts a copy of the response or group with id after it, wherever it is in
+// items; returns the items and the ID of the copy ("" if not found)
+func duplicateInItems(items []models.ResponseItem, id string) ([]models.ResponseItem, string, error) {
+	for i, item := range items {
+		switch {
+		case item.Response != nil && item.Response.ID == id:
+			var duplicate models.MethodResponse
+			if err := models.DeepCopy(item.Response, &duplicate); err != nil {
+				return items, "", fmt.Errorf("could not copy response: %v", err)
+			}
+			assignNewResponseID(&duplicate)
+			copied := models.ResponseItem{Type: "response", Response: &duplicate}
+			return append(items[:i+1], append([]models.ResponseItem{copied}, items[i+1:]...)...), duplicate.ID, nil
+
+		case item.Group != nil && item.Group.ID == id:
+			var duplicate models.ResponseGroup
+			if err := models.DeepCopy(item.Group, &duplicate); err != nil {
+				return items, "", fmt.Errorf("could not copy group: %v", err)
+			}
+			copied := []models.ResponseItem{{Type: "group", Group: &duplicate}}
+			assignNewItemIDs(copied)
+			duplicate.Name += " (copy)"
+			return append(items[:i+1], append(copied, items[i+1:]...)...), duplicate.ID, nil
+
+		case item.Group != nil:
+			responses := item.Group.Responses
+			for j := range responses {
+				if responses[j].ID != id {
+					continue
+				}
+				var duplicate models.MethodResponse
+				if err := models.DeepCopy(&responses[j], &duplicate); err != nil {
+					return items, "", fmt.Errorf("could not copy response: %v", err)
+				}
+				assignNewResponseID(&duplicate)
+				item.Group.Responses = append(responses[:j+1], append([]models.MethodResponse{duplicate}, responses[j+1:]...)...)
+				return items, duplicate.ID, nil
+			}
+		}
+	}
+	return items, "", nil
+}
+
+// GetPatternErrors returns all regex patterns in the current config that do not compile
+func (a *App) GetPatternErrors() []models.PatternError {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+
+	errs := server.ValidatePatterns(a.config)
+	if errs == nil {
+		errs = []models.PatternError{}
+	}
+	return errs
+}
+
+// GetScriptWarnings returns all scripts, templates and expressions in the current config that do not compile
+func (a *App) GetScriptWarnings() []models.ScriptWarning {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+
+	warnings := server.ValidateScripts(a.config)
+	if warnings == nil {
+		warnings = []models.ScriptWarning{}
+	}
+	return warnings
+}
+
+// reportScriptWarnings logs scripts that do not compile and notifies the frontend. Broken scripts are
+// not rejected (they may be work in progress); they would otherwise only fail on first request.
+func (a *App) reportScriptWarnings(warnings []models.ScriptWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+	for _, w := range warnings {
+		appLog.Warn("Script warning: %s", w.String())
+	}
+	a.emit("scripts:invalid", warnings)
+}
+
+// ValidateEndpointPatterns checks an endpoint's patterns without applying it
+func (a *App) ValidateEndpointPatterns(endpoint models.Endpoint) []models.PatternError {
+	errs := server.ValidateEndpointPatterns(&endpoint)
+	if errs == nil {
+		errs = []models.PatternError{}
+	}
+	return errs
+}
+
+// GetEndpointHealth returns health status for an endpoint
+func (a *App) GetEndpointHealth(endpointID string) (*models.HealthStatus, error) {
+	if a.server == nil {
+		return nil, fmt.Errorf("server not running")
+	}
+
+	// Find endpoint
+	var endpoint *models.Endpoint
+	for i := range a.config.Endpoints {
+		if a.config.Endpoints[i].ID == endpointID {
+			endpoint = &a.config.Endpoints[i]
+			break
+		}
+	}
+
+	if endpoint == nil {
 		return nil, fmt.Errorf("endpoint not found")
 	}
 
@@ -1241,11 +2047,142 @@ func (a *App) GetEndpointHealth(endpointID string) (*models.HealthStatus, error)
 		}
 		return status, nil
 	default:
+		if plugin, ok := endpointtype.Lookup(endpoint.Type); ok {
+			health := plugin.Health(endpoint)
+			return &models.HealthStatus{
+				EndpointID:   endpointID,
+				Healthy:      health.Ready,
+				LastCheck:    time.Now().Format(time.RFC3339),
+				ErrorMessage: health.Reason,
+			}, nil
+		}
 		// Mock endpoints are always healthy
 		return &models.HealthStatus{EndpointID: endpointID, Healthy: true}, nil
 	}
 }
 
+// GetProxyBackends returns the health and traffic of each backend in a proxy endpoint's pool
+func (a *App) GetProxyBackends(endpointID string) ([]models.ProxyBackendStatus, error) {
+	a.configMutex.RLock()
+	var cfg *models.ProxyConfig
+	for i := range a.config.Endpoints {
+		endpoint := a.config.Endpoints[i]
+		if endpoint.ID == endpointID && endpoint.Type == models.EndpointTypeProxy && endpoint.ProxyConfig != nil {
+			proxyConfig := *endpoint.ProxyConfig
+			cfg = &proxyConfig
+			break
+		}
+	}
+	a.configMutex.RUnlock()
+	if cfg == nil {
+		return nil, fmt.Errorf("proxy endpoint not found: %s", endpointID)
+	}
+	return a.proxyHandler.BackendPoolStatus(endpointID, cfg), nil
+}
+
+// GetCircuitBreakers returns the circuit breaker state of every proxy endpoint that has one enabled
+func (a *App) GetCircuitBreakers() []models.CircuitBreakerStatus {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.proxyHandler.CircuitStatus(a.config.Endpoints)
+}
+
+// ResetCircuitBreaker closes a proxy endpoint's circuit and clears its counters (all of them if
+// endpointID is empty)
+func (a *App) ResetCircuitBreaker(endpointID string) {
+	a.proxyHandler.ResetCircuit(endpointID)
+}
+
+// GetEndpointTypes returns the endpoint types provided by registered plugins
+func (a *App) GetEndpointTypes() []models.EndpointTypeInfo {
+	return endpointtype.Registered()
+}
+
+// GetBackendSLA returns availability and latency stats for a proxy endpoint's backend
+// window is a duration such as "15m", "1h" or "7d" (default: 1h)
+func (a *App) GetBackendSLA(endpointID string, window string) (*models.BackendSLA, error) {
+	duration, err := parseSLAWindow(window)
+	if err != nil {
+		return nil, err
+	}
+
+	a.configMutex.RLock()
+	var endpoint *models.Endpoint
+	for i := range a.config.Endpoints {
+		if a.config.Endpoints[i].ID == endpointID {
+			endpoint = &a.config.Endpoints[i]
+			break
+		}
+	}
+	if endpoint == nil || endpoint.Type != models.EndpointTypeProxy {
+		a.configMutex.RUnlock()
+		return nil, fmt.Errorf("proxy endpoint not found: %s", endpointID)
+	}
+	name := endpoint.Name
+	backendURL := ""
+	if endpoint.ProxyConfig != nil {
+		backendURL = server.ConfigVariables(a.config).Expand(endpoint.ProxyConfig.ResolveBackendURL(a.config.ActiveEnvironment))
+	}
+	a.configMutex.RUnlock()
+
+	report := a.proxyHandler.GetBackendSLA(endpointID, duration)
+	report.EndpointName = name
+	report.BackendURL = backendURL
+	return report, nil
+}
+
+// ExportBackendSLA writes SLA reports for all proxy endpoints to a CSV file in the exports directory
+func (a *App) ExportBackendSLA(window string) (string, error) {
+	duration, err := parseSLAWindow(window)
+	if err != nil {
+		return "", err
+	}
+
+	a.configMutex.RLock()
+	variables := server.ConfigVariables(a.config)
+	var reports []models.BackendSLA
+	for _, endpoint := range a.config.Endpoints {
+		if endpoint.Type != models.EndpointTypeProxy {
+			continue
+		}
+		report := a.proxyHandler.GetBackendSLA(endpoint.ID, duration)
+		report.EndpointName = endpoint.Name
+		if endpoint.ProxyConfig != nil {
+			report.BackendURL = variables.Expand(endpoint.ProxyConfig.ResolveBackendURL(a.config.ActiveEnvironment))
+		}
+		reports = append(reports, *report)
+	}
+	a.configMutex.RUnlock()
+
+	exporter := export.NewLogExporter("")
+	filePath, err := exporter.ExportSLAToCSV(reports)
+	if err != nil {
+		return "", fmt.Errorf("failed to export SLA report: %v", err)
+	}
+
+	appLog.Info("Exported SLA report for %d backends to: %s", len(reports), filePath)
+	return filePath, nil
+}
+
+// parseSLAWindow parses an SLA window duration, accepting a "d" suffix for days
+func parseSLAWindow(window string) (time.Duration, error) {
+	if window == "" {
+		return time.Hour, nil
+	}
+	if strings.HasSuffix(window, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+		if err != nil || days <= 0 {
+			return 0, fmt.Errorf("invalid window: %s", window)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(window)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid window: %s", window)
+	}
+	return duration, nil
+}
+
 // TestProxyConnection tests connectivity to a proxy backend
 func (a *App) TestProxyConnection(backendURL string) error {
 	client := &http.Client{Timeout: 5 * time.Second}
@@ -1260,7 +2197,7 @@ func (a *App) TestProxyConnection(backendURL string) error {
 // ValidateDockerImage checks if a Docker image is available
 func (a *App) ValidateDockerImage(imageName string) error {
 	// Create Docker client
-	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	dockerClient, err := a.dockerClient()
 	if err != nil {
 		return fmt.Errorf("Docker not available: %w", err)
 	}
@@ -1278,10 +2215,60 @@ func (a *App) ValidateDockerImage(imageName string) error {
 	return nil
 }
 
+// RegistryCredentials returns the global private registry logins
+// Implements server.RegistryCredentialSource for the container handler's image pulls
+func (a *App) RegistryCredentials() []models.RegistryCredential {
+	return a.config.RegistryCredentials
+}
+
+// ContainerHosts returns the remote Docker/Podman hosts container endpoints can run on
+// Implements server.ContainerHostSource for the container handler
+func (a *App) ContainerHosts() []models.ContainerHost {
+	return a.config.ContainerHosts
+}
+
+// DefaultContainerHost returns the host of container endpoints without one (empty for this machine)
+func (a *App) DefaultContainerHost() string {
+	return a.config.DefaultContainerHost
+}
+
+// TestContainerHost connects to a Docker/Podman host and reports its version
+func (a *App) TestContainerHost(host models.ContainerHost) (string, error) {
+	if err := server.ValidateContainerHosts([]models.ContainerHost{host}, ""); err != nil {
+		return "", err
+	}
+	daemonClient, err := containerruntime.NewDaemonClient(server.DaemonConfig(host))
+	if err != nil {
+		return "", err
+	}
+	defer daemonClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	version, err := daemonClient.ServerVersion(ctx)
+	if err != nil {
+		return "", fmt.Errorf("container host %s not responding: %w", host.Host, err)
+	}
+	return fmt.Sprintf("%s %s (%s/%s)", version.Platform.Name, version.Version, version.Os, version.Arch), nil
+}
+
+// dockerClient creates an API client for the default container host (DOCKER_HOST and the local
+// socket when there is none)
+func (a *App) dockerClient() (*client.Client, error) {
+	host, err := server.ResolveContainerHost(nil, a.config.ContainerHosts, a.config.DefaultContainerHost)
+	if err != nil {
+		return nil, err
+	}
+	if host != nil {
+		return containerruntime.NewDaemonClient(server.DaemonConfig(*host))
+	}
+	return client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+}
+
 // PullDockerImage pulls a Docker image from the registry
 func (a *App) PullDockerImage(imageName string) error {
 	// Create Docker client
-	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	dockerClient, err := a.dockerClient()
 	if err != nil {
 		return fmt.Errorf("Docker not available: %w", err)
 	}
@@ -1291,7 +2278,11 @@ func (a *App) PullDockerImage(imageName string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second) // 5 minute timeout for pull
 	defer cancel()
 
-	reader, err := dockerClient.ImagePull(ctx, imageName, image.PullOptions{})
+	registryAuth, err := server.ResolveRegistryAuth(imageName, nil, a.config.RegistryCredentials)
+	if err != nil {
+		return err
+	}
+	reader, err := dockerClient.ImagePull(ctx, imageName, image.PullOptions{RegistryAuth: registryAuth})
 	if err != nil {
 		return fmt.Errorf("failed to pull image: %w", err)
 	}
@@ -1309,7 +2300,7 @@ func (a *App) PullDockerImage(imageName string) error {
 // ValidateAndInspectDockerImage inspects a Docker image and returns metadata
 func (a *App) ValidateAndInspectDockerImage(imageName string) (*models.DockerImageInfo, error) {
 	// Create Docker client
-	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	dockerClient, err := a.dockerClient()
 	if err != nil {
 		return nil, fmt.Errorf("Docker not available: %w", err)
 	}
@@ -1592,7 +2583,7 @@ func (a *App) CancelContainerStart(endpointID string) error {
 	// Remove from map (cleanup will also happen in deferred function of StartContainer)
 	delete(a.containerStartContexts, endpointID)
 
-	log.Printf("Container startup cancelled for endpoint: %s", endpointID)
+	appLog.Info("Container startup cancelled for endpoint: %s", endpointID)
 	return nil
 }
 
@@ -1655,8 +2646,14 @@ func (a *App) GetContainerLogs(endpointID string, tail int) (string, error) {
 		}
 	}
 
-	ctx := context.Background()
-	return a.containerHandler.GetContainerLogs(ctx, endpointID, tail)
+	for i := range a.config.Endpoints {
+		if a.config.Endpoints[i].ID == endpointID {
+			ctx := context.Background()
+			return a.containerHandler.GetContainerLogs(ctx, &a.config.Endpoints[i], tail)
+		}
+	}
+
+	return "", fmt.Errorf("endpoint not found")
 }
 
 // TestContainerConfig tests a container configuration by creating a temporary container
@@ -1685,13 +2682,24 @@ func (a *App) TestContainerConfig(config map[string]interface{}) error {
 		environment = parseEnvironmentVars(envData)
 	}
 
-	_ = getBool(config, "host_networking", false)           // Parsed but not used - not yet supported in runtime interface
-	_ = getBool(config, "docker_socket_access", false)      // Parsed but not used - not yet supported in runtime interface
+	registryCred := parseRegistryCredential(config["registry_auth"])
+
+	hostNetworking := getBool(config, "host_networking", false)
+	dockerSocketAccess := getBool(config, "docker_socket_access", false)
 	healthCheckEnabled := getBool(config, "health_check_enabled", false)
 	healthCheckPath := getString(config, "health_check_path")
 
-	// Create temporary container runtime
-	containerRuntime, err := containerruntime.DetectRuntime()
+	// Create temporary container runtime on the endpoint's container host
+	host, err := server.ResolveContainerHost(&models.ContainerConfig{ContainerHost: getString(config, "container_host")}, a.config.ContainerHosts, a.config.DefaultContainerHost)
+	if err != nil {
+		return err
+	}
+	var containerRuntime containerruntime.ContainerRuntime
+	if host != nil {
+		containerRuntime, err = containerruntime.ConnectRuntime(server.DaemonConfig(*host))
+	} else {
+		containerRuntime, err = containerruntime.DetectRuntime()
+	}
 	if err != nil {
 		return fmt.Errorf("Docker/Podman not available: %w", err)
 	}
@@ -1704,7 +2712,7 @@ func (a *App) TestContainerConfig(config map[string]interface{}) error {
 	// Cleanup on error or completion
 	defer func() {
 		if containerID != "" {
-			log.Printf("Cleaning up test container: %s", testName)
+			appLog.Info("Cleaning up test container: %s", testName)
 			cleanupCtx := context.Background()
 			containerRuntime.StopContainer(cleanupCtx, containerID, 5)
 			containerRuntime.RemoveContainer(cleanupCtx, containerID, true)
@@ -1717,8 +2725,12 @@ func (a *App) TestContainerConfig(config map[string]interface{}) error {
 	err = containerRuntime.ValidateImage(ctx, imageName)
 	if err != nil {
 		// Image not found, try to pull
-		log.Printf("Pulling image for test: %s", imageName)
-		reader, err := containerRuntime.PullImage(ctx, imageName)
+		appLog.Info("Pulling image for test: %s", imageName)
+		registryAuth, err := server.ResolveRegistryAuth(imageName, registryCred, a.config.RegistryCredentials)
+		if err != nil {
+			return err
+		}
+		reader, err := containerRuntime.PullImage(ctx, imageName, registryAuth)
 		if err != nil {
 			return fmt.Errorf("failed to pull image: %w", err)
 		}
@@ -1755,8 +2767,6 @@ func (a *App) TestContainerConfig(config map[string]interface{}) error {
 	}
 
 	// Create container
-	// TODO: HostNetworking and DockerSocketAccess options not yet supported in runtime interface
-	// These are validated in the wizard but not used for testing
 	createConfig := &containerruntime.ContainerCreateConfig{
 		Name:         testName,
 		Image:        imageName,
@@ -1765,7 +2775,9 @@ func (a *App) TestContainerConfig(config map[string]interface{}) error {
 		PortBindings: map[string]string{
 			fmt.Sprintf("%d/tcp", containerPort): "0", // Random host port
 		},
-		Mounts: mounts,
+		Mounts:       mounts,
+		HostNetwork:  hostNetworking,
+		DockerSocket: dockerSocketAccess,
 	}
 
 	containerID, err = containerRuntime.CreateContainer(ctx, createConfig)
@@ -1793,13 +2805,15 @@ func (a *App) TestContainerConfig(config map[string]interface{}) error {
 
 	// Perform health check if enabled
 	if healthCheckEnabled && healthCheckPath != "" {
-		portKey := fmt.Sprintf("%d/tcp", containerPort)
-		hostPort, ok := info.Ports[portKey]
+		hostPort, ok := info.Ports[fmt.Sprintf("%d/tcp", containerPort)]
+		if hostNetworking {
+			hostPort, ok = strconv.Itoa(containerPort), true
+		}
 		if !ok || hostPort == "" {
 			return fmt.Errorf("container port %d not bound to host", containerPort)
 		}
 
-		healthURL := fmt.Sprintf("http://127.0.0.1:%s%s", hostPort, healthCheckPath)
+		healthURL := fmt.Sprintf("http://%s%s", net.JoinHostPort(containerRuntime.PublishHost(), hostPort), healthCheckPath)
 		client := &http.Client{Timeout: 5 * time.Second}
 
 		resp, err := client.Get(healthURL)
@@ -1817,12 +2831,80 @@ func (a *App) TestContainerConfig(config map[string]interface{}) error {
 	return nil
 }
 
+// ImportDockerComposeWithDialog creates a container endpoint from a docker-compose.yml: mainService
+// (the only service that publishes ports when empty) becomes the routed container and every other
+// service a sidecar on the shared network. Returns nil if the user cancelled the dialog.
+func (a *App) ImportDockerComposeWithDialog(mainService string) (*models.Endpoint, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import Docker Compose File",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Compose Files", Pattern: "*.yaml;*.yml"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil // User cancelled
+	}
+
+	result, err := compose.ImportFile(path, mainService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import compose file: %v", err)
+	}
+	for _, warning := range result.Warnings {
+		appLog.Warn("Compose import: %s", warning)
+	}
+	if err := server.ValidateContainerGroup(result.Config); err != nil {
+		return nil, err
+	}
+	if err := server.ValidateContainerBuild(result.Config); err != nil {
+		return nil, err
+	}
+	if err := server.ValidateContainerResources(result.Config); err != nil {
+		return nil, err
+	}
+	if err := server.ValidateContainerReadiness(result.Config); err != nil {
+		return nil, err
+	}
+
+	edit := a.snapshotEdit("Import Docker Compose")
+	a.configMutex.Lock()
+	defer a.configMutex.Unlock()
+
+	enabled := true
+	insertIndex, nextOrder := a.userEndpointInsertPoint()
+	endpoint := models.Endpoint{
+		ID:              uuid.New().String(),
+		Name:            result.MainService,
+		PathPrefix:      "/" + result.MainService,
+		TranslationMode: models.TranslationModeStrip,
+		Type:            models.EndpointTypeContainer,
+		Enabled:         &enabled,
+		DisplayOrder:    nextOrder,
+		ContainerConfig: result.Config,
+	}
+	a.config.Endpoints = append(a.config.Endpoints[:insertIndex], append([]models.Endpoint{endpoint}, a.config.Endpoints[insertIndex:]...)...)
+	a.commitEdit(edit)
+
+	appLog.Info("Imported %s from %s with %d sidecar(s)", result.MainService, path, len(result.Config.Sidecars))
+
+	// If server is running, update it
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:dirty", true)
+	return &endpoint, nil
+}
+
 // GetSelectedEndpointId returns the currently selected endpoint ID from ServerConfig
 func (a *App) GetSelectedEndpointId() string {
 	// Load from server config
 	serverCfg, err := a.serverConfigMgr.Load()
 	if err != nil {
-		fmt.Printf("Failed to load selected endpoint ID: %v\n", err)
+		appLog.Warn("Failed to load selected endpoint ID: %v", err)
 		// Return first endpoint ID if available
 		if len(a.config.Endpoints) > 0 {
 			return a.config.Endpoints[0].ID
@@ -1839,8 +2921,8 @@ func (a *App) SetSelectedEndpointId(endpointId string) error {
 	a.configMutex.Unlock()
 
 	// Emit events to frontend
-	runtime.EventsEmit(a.ctx, "endpoint:selected", endpointId)
-	runtime.EventsEmit(a.ctx, "config:dirty", true)
+	a.emit("endpoint:selected", endpointId)
+	a.emit("config:dirty", true)
 
 	return nil
 }
@@ -1851,13 +2933,13 @@ func (a *App) SaveCurrentConfig() error {
 		return fmt.Errorf("no file currently loaded - use Save As instead")
 	}
 
-	if err := a.saveConfigToPath(a.currentConfigPath); err != nil {
+	if err := a.saveConfigToPath(a.currentConfigPath, ""); err != nil {
 		return err
 	}
 
 	// Mark as clean after successful save
-	runtime.EventsEmit(a.ctx, "config:dirty", false)
-	runtime.EventsEmit(a.ctx, "config:path", a.currentConfigPath)
+	a.emit("config:dirty", false)
+	a.emit("config:path", a.currentConfigPath)
 
 	return nil
 }
@@ -1893,10 +2975,46 @@ func (a *App) SaveConfig() error {
 	}
 
 	// Save and update current path
-	if err := a.saveConfigToPath(path); err != nil {
+	if err := a.saveConfigToPath(path, ""); err != nil {
+		return err
+	}
+	a.markSavedAs(path)
+	return nil
+}
+
+// SaveConfigToDirectory saves the config in directory layout: dir/mockelot.yaml holds the server
+// settings and shared sections, and dir/endpoints holds one file per endpoint. The config stays
+// in this layout when it is saved again.
+func (a *App) SaveConfigToDirectory(dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, configdir.EndpointsDir), 0755); err != nil {
+		return fmt.Errorf("could not create config directory: %v", err)
+	}
+	path := configdir.MainPath(dir)
+	if err := a.saveConfigToPath(path, ""); err != nil {
+		return err
+	}
+	a.markSavedAs(path)
+	return nil
+}
+
+// SaveConfigToDirectoryWithDialog asks for a directory, then saves the config there in
+// directory layout
+func (a *App) SaveConfigToDirectoryWithDialog() error {
+	dir, err := runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:                "Save Configuration to Directory",
+		CanCreateDirectories: true,
+	})
+	if err != nil {
 		return err
 	}
+	if dir == "" {
+		return nil // User cancelled
+	}
+	return a.SaveConfigToDirectory(dir)
+}
 
+// markSavedAs makes path the current config file after a save, with the config clean
+func (a *App) markSavedAs(path string) {
 	// Update path and mark as clean
 	a.configMutex.Lock()
 	a.currentConfigPath = path
@@ -1904,56 +3022,132 @@ func (a *App) SaveConfig() error {
 	a.configMutex.Unlock()
 
 	// Emit events
-	runtime.EventsEmit(a.ctx, "config:saved", path)
-	runtime.EventsEmit(a.ctx, "config:dirty", false)
-	runtime.EventsEmit(a.ctx, "config:path", path)
+	a.emit("config:saved", path)
+	a.emit("config:dirty", false)
+	a.emit("config:path", path)
 
 	a.AddRecentFile(path)
-	return nil
 }
 
-// saveConfigToPath saves the configuration to the specified path
-func (a *App) saveConfigToPath(path string) error {
-	// Create UserConfig with all settings (server settings + user content)
-	userConfig := &models.UserConfig{
-		// User content
-		Responses:      a.config.Responses,
-		Items:          a.config.Items,
-		Endpoints:      a.config.Endpoints,
+// SaveCurrentConfigWithMessage saves to the current config file, describing the change with the
+// given message (used as the commit message by the Git storage backend)
+func (a *App) SaveCurrentConfigWithMessage(message string) error {
+	if a.currentConfigPath == "" {
+		return fmt.Errorf("no file currently loaded - use Save As instead")
+	}
 
-		// Server settings (now included in UserConfig)
-		Port:                   a.config.Port,
-		HTTP2Enabled:           a.config.HTTP2Enabled,
-		HTTPSEnabled:           a.config.HTTPSEnabled,
-		HTTPSPort:              a.config.HTTPSPort,
-		HTTPToHTTPSRedirect:    a.config.HTTPToHTTPSRedirect,
-		CertMode:               a.config.CertMode,
-		CertPaths:              a.config.CertPaths,
-		CertNames:              a.config.CertNames,
+	if err := a.saveConfigToPath(a.currentConfigPath, message); err != nil {
+		return err
+	}
 
-		// Shared settings
-		CORS:           a.config.CORS,
-		SOCKS5Config:   a.config.SOCKS5Config,
-		DomainTakeover: a.config.DomainTakeover,
+	a.emit("config:dirty", false)
+	a.emit("config:path", a.currentConfigPath)
 
-		// UI state
-		SelectedEndpointId: a.config.SelectedEndpointId,
+	return nil
+}
 
-		// Metadata
-		LastModified:   time.Now(),
+// saveConfigToPath saves the configuration to the specified path through the storage backend
+func (a *App) saveConfigToPath(path string, message string) error {
+	userConfig := a.currentUserConfig()
+	if configdir.IsLayout(path) {
+		if err := a.saveConfigDir(path, userConfig, message); err != nil {
+			return err
+		}
+		a.backupConfig(path, userConfig)
+		return nil
 	}
 
-	// Save to YAML file
-	file, err := os.Create(path)
+	// Encode as YAML and hand it to the storage backend
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(userConfig); err != nil {
+		return fmt.Errorf("could not encode config: %v", err)
+	}
+	encoder.Close()
+	if err := a.storage.Save(path, buf.Bytes(), message); err != nil {
+		return err
+	}
+	a.backupConfig(path, userConfig)
+	return nil
+}
+
+// saveConfigDir saves a config in directory layout, as one change of the storage backend
+func (a *App) saveConfigDir(path string, userConfig *models.UserConfig, message string) error {
+	saver, ok := a.storage.(storage.FileSetSaver)
+	if !ok {
+		return fmt.Errorf("configs in directory layout can only be saved with file or git storage")
+	}
+	files, err := configdir.Files(userConfig, path)
 	if err != nil {
-		return fmt.Errorf("could not create file: %v", err)
+		return err
 	}
-	defer file.Close()
+	return saver.SaveFiles(files, message)
+}
 
-	encoder := yaml.NewEncoder(file)
-	encoder.SetIndent(2)
-	defer encoder.Close()
-	return encoder.Encode(userConfig)
+// currentUserConfig returns the current config in its file form
+func (a *App) currentUserConfig() *models.UserConfig {
+	return appConfigToUserConfig(a.config)
+}
+
+// appConfigToUserConfig converts an AppConfig to its file form
+func appConfigToUserConfig(cfg *models.AppConfig) *models.UserConfig {
+	// Create UserConfig with all settings (server settings + user content)
+	return &models.UserConfig{
+		// User content
+		Responses:      cfg.Responses,
+		Items:          cfg.Items,
+		Endpoints:      cfg.Endpoints,
+
+		// Server settings (now included in UserConfig)
+		Port:                   cfg.Port,
+		HTTP2Enabled:           cfg.HTTP2Enabled,
+		HTTPSEnabled:           cfg.HTTPSEnabled,
+		HTTPSPort:              cfg.HTTPSPort,
+		HTTPToHTTPSRedirect:    cfg.HTTPToHTTPSRedirect,
+		CertMode:               cfg.CertMode,
+		CertPaths:              cfg.CertPaths,
+		CertNames:              cfg.CertNames,
+		ClientAuth:             cfg.ClientAuth,
+		Limits:                 cfg.Limits,
+		VirtualClock:           cfg.VirtualClock,
+		OfflineMode:            cfg.OfflineMode,
+		ActiveEnvironment:      cfg.ActiveEnvironment,
+		Variables:              cfg.Variables,
+		Profiles:               cfg.Profiles,
+		ActiveProfile:          cfg.ActiveProfile,
+
+		// Shared settings
+		CORS:           cfg.CORS,
+		SOCKS5Config:   cfg.SOCKS5Config,
+		HTTPProxy:      cfg.HTTPProxy,
+		DomainTakeover: cfg.DomainTakeover,
+		GRPC:           cfg.GRPC,
+		DNS:            cfg.DNS,
+
+		// Scheduled actions
+		ScheduledActions: cfg.ScheduledActions,
+		Macros:           cfg.Macros,
+		ScriptModules:    cfg.ScriptModules,
+		ScriptFetch:      cfg.ScriptFetch,
+		Listeners:        cfg.Listeners,
+		ClientThrottles:  cfg.ClientThrottles,
+		JWTKeys:          cfg.JWTKeys,
+
+		// Container registries and hosts
+		RegistryCredentials:  cfg.RegistryCredentials,
+		ContainerHosts:       cfg.ContainerHosts,
+		DefaultContainerHost: cfg.DefaultContainerHost,
+
+		// Marketplace
+		MarketplaceSources: cfg.MarketplaceSources,
+
+		// UI state
+		SelectedEndpointId: cfg.SelectedEndpointId,
+
+		// Metadata
+		LastModified:   time.Now(),
+	}
 }
 
 // LoadConfig loads user configuration (request processing rules + CORS) from a YAML file
@@ -1972,121 +3166,14 @@ func (a *App) LoadConfig() (*models.AppConfig, error) {
 		return nil, nil // User cancelled
 	}
 
-	// Load from YAML file
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("could not open file: %v", err)
-	}
-	defer file.Close()
-
-	var userCfg models.UserConfig
-	decoder := yaml.NewDecoder(file)
-	if err := decoder.Decode(&userCfg); err != nil {
-		return nil, fmt.Errorf("could not decode config: %v", err)
-	}
-
-	// Ensure all responses have IDs
-	for i := range userCfg.Responses {
-		if userCfg.Responses[i].ID == "" {
-			userCfg.Responses[i].ID = uuid.New().String()
-		}
-	}
-	for i := range userCfg.Items {
-		if userCfg.Items[i].Type == "response" && userCfg.Items[i].Response != nil {
-			if userCfg.Items[i].Response.ID == "" {
-				userCfg.Items[i].Response.ID = uuid.New().String()
-			}
-		} else if userCfg.Items[i].Type == "group" && userCfg.Items[i].Group != nil {
-			if userCfg.Items[i].Group.ID == "" {
-				userCfg.Items[i].Group.ID = uuid.New().String()
-			}
-			for j := range userCfg.Items[i].Group.Responses {
-				if userCfg.Items[i].Group.Responses[j].ID == "" {
-					userCfg.Items[i].Group.Responses[j].ID = uuid.New().String()
-				}
-			}
-		}
-	}
-
-	// Convert UserConfig to AppConfig
-	a.configMutex.Lock()
-	a.config = userConfigToAppConfig(&userCfg, a.config)
-	a.currentConfigPath = path
-
-	// Mark as clean (just loaded)
-	a.savedConfig = a.deepCopyConfig(a.config)
-	a.configMutex.Unlock()
-
-	// If there's no selected endpoint or the selected endpoint doesn't exist anymore,
-	// select the first endpoint
-	if len(a.config.Endpoints) > 0 {
-		selectedId := a.GetSelectedEndpointId()
-		validSelection := false
-		for _, endpoint := range a.config.Endpoints {
-			if endpoint.ID == selectedId {
-				validSelection = true
-				break
-			}
-		}
-
-		if !validSelection {
-			// Select first endpoint (don't call SetSelectedEndpointId as it marks dirty)
-			a.config.SelectedEndpointId = a.config.Endpoints[0].ID
-		}
-	}
-
-	// Ensure all endpoints have DisplayOrder set (for legacy configs)
-	a.ensureDisplayOrder()
-
-	// Ensure system endpoints exist (order matters: overlay before SOCKS5 before rejections)
-	a.ensureDomainTakeoverEndpoints()
-	a.ensureSOCKS5ProxyEndpoint()
-	a.ensureRejectionsEndpoint()
-
-	// Update server if running
-	if a.server != nil {
-		a.server.UpdateConfig(a.config)
-		// Start monitoring for any container endpoints in the loaded config
-		// This will detect and track any containers already running from previous sessions
-		a.server.EnsureContainerMonitoring()
-	} else {
-		// Even if server isn't running, we can still monitor containers
-		if a.containerHandler != nil {
-			var containerEndpoints []*models.Endpoint
-			for i := range a.config.Endpoints {
-				endpoint := &a.config.Endpoints[i]
-				if endpoint.Type == models.EndpointTypeContainer {
-					containerEndpoints = append(containerEndpoints, endpoint)
-				}
-			}
-
-			if len(containerEndpoints) > 0 {
-				a.containerHandler.StopPolling() // Stop any existing polling
-				a.containerHandler.StartContainerStatusPolling(containerEndpoints)
-				a.containerHandler.StartContainerStatsPolling(containerEndpoints)
-			}
-		}
-	}
-
-	// Emit events to frontend
-	runtime.EventsEmit(a.ctx, "responses:updated", a.config.Responses)
-	runtime.EventsEmit(a.ctx, "items:updated", a.config.Items)
-	runtime.EventsEmit(a.ctx, "endpoints:updated", a.config.Endpoints)
-	runtime.EventsEmit(a.ctx, "config:loaded", a.config)
-	runtime.EventsEmit(a.ctx, "config:dirty", false)
-	runtime.EventsEmit(a.ctx, "config:path", path)
-
-	// Add to recent files
-	a.AddRecentFile(path)
-
-	return a.config, nil
+	return a.LoadConfigFromPath(path)
 }
 
 // getRecentFilesPath returns the path to the recent files JSON file
 func (a *App) getRecentFilesPath() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		log.Printf("Failed to get home directory: %v", err)
+		appLog.Error("Failed to get home directory: %v", err)
 		return ""
 	}
 	configDir := filepath.Join(homeDir, ".mockelot")
@@ -2251,27 +3338,38 @@ func (a *App) RemoveRecentFile(path string) error {
 
 // LoadConfigFromPath loads configuration from a specific file path
 func (a *App) LoadConfigFromPath(path string) (*models.AppConfig, error) {
-	// Check if file exists
-	if _, err := os.Stat(path); err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("file does not exist: %s", path)
-		}
-		return nil, fmt.Errorf("failed to access file: %v", err)
+	userCfg, path, err := a.readConfig(path)
+	if err != nil {
+		return nil, err
 	}
+	return a.loadUserConfig(userCfg, path)
+}
 
-	// Load from YAML file
-	file, err := os.Open(path)
+// readConfig reads a config through the storage backend. A config in directory layout is joined
+// from its main file and endpoint files; the returned path is then the main file.
+func (a *App) readConfig(path string) (*models.UserConfig, string, error) {
+	if configdir.IsLayout(path) {
+		path = configdir.MainPath(path)
+		userCfg, err := configdir.Read(path, a.storage.Load)
+		return userCfg, path, err
+	}
+
+	// Load from YAML file (through the storage backend)
+	data, err := a.storage.Load(path)
 	if err != nil {
-		return nil, fmt.Errorf("could not open file: %v", err)
+		return nil, "", err
 	}
-	defer file.Close()
 
 	var userCfg models.UserConfig
-	decoder := yaml.NewDecoder(file)
-	if err := decoder.Decode(&userCfg); err != nil {
-		return nil, fmt.Errorf("could not decode config: %v", err)
+	if err := yaml.Unmarshal(data, &userCfg); err != nil {
+		return nil, "", fmt.Errorf("could not decode config: %v", err)
 	}
+	return &userCfg, path, nil
+}
 
+// loadUserConfig makes a config read from path the current, clean config. A config with patterns
+// that do not compile is refused.
+func (a *App) loadUserConfig(userCfg *models.UserConfig, path string) (*models.AppConfig, error) {
 	// Ensure all responses have IDs
 	for i := range userCfg.Responses {
 		if userCfg.Responses[i].ID == "" {
@@ -2297,7 +3395,12 @@ func (a *App) LoadConfigFromPath(path string) (*models.AppConfig, error) {
 
 	// Convert UserConfig to AppConfig
 	a.configMutex.Lock()
-	a.config = userConfigToAppConfig(&userCfg, a.config)
+	config := userConfigToAppConfig(userCfg, a.config)
+	if err := checkConfigPatterns(config); err != nil {
+		a.configMutex.Unlock()
+		return nil, err
+	}
+	a.config = config
 	a.currentConfigPath = path
 
 	// Mark as clean (just loaded)
@@ -2355,13 +3458,19 @@ func (a *App) LoadConfigFromPath(path string) (*models.AppConfig, error) {
 		}
 	}
 
+	// Scripts that will fail are reported right away
+	a.reportScriptWarnings(server.ValidateScripts(a.config))
+
 	// Emit events to frontend
-	runtime.EventsEmit(a.ctx, "responses:updated", a.config.Responses)
-	runtime.EventsEmit(a.ctx, "items:updated", a.config.Items)
-	runtime.EventsEmit(a.ctx, "endpoints:updated", a.config.Endpoints)
-	runtime.EventsEmit(a.ctx, "config:loaded", a.config)
-	runtime.EventsEmit(a.ctx, "config:dirty", false)
-	runtime.EventsEmit(a.ctx, "config:path", path)
+	a.emit("responses:updated", a.config.Responses)
+	a.emit("items:updated", a.config.Items)
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:loaded", a.config)
+	a.emit("config:dirty", false)
+	a.emit("config:path", path)
+
+	// Edits of the previous config cannot be undone into this one
+	a.clearUndoHistory()
 
 	// Add to recent files
 	a.AddRecentFile(path)
@@ -2369,6 +3478,16 @@ func (a *App) LoadConfigFromPath(path string) (*models.AppConfig, error) {
 	return a.config, nil
 }
 
+// checkConfigPatterns is the gate a whole config passes before it becomes the current config:
+// a config with patterns that do not compile is refused
+func checkConfigPatterns(cfg *models.AppConfig) error {
+	if errs := server.ValidatePatterns(cfg); len(errs) > 0 {
+		appLog.Warn("Refused config with %d invalid pattern(s)", len(errs))
+		return &server.PatternValidationError{Errors: errs}
+	}
+	return nil
+}
+
 // ImportOpenAPISpecWithDialog imports an OpenAPI/Swagger specification file
 // Shows a file dialog and imports with the specified append mode
 func (a *App) ImportOpenAPISpecWithDialog(appendMode bool) (*models.AppConfig, error) {
@@ -2399,6 +3518,21 @@ func (a *App) importOpenAPISpecWithMode(appendMode bool) (*models.AppConfig, err
 		return nil, fmt.Errorf("failed to import OpenAPI spec: %v", err)
 	}
 
+	if err := a.importItems(items, appendMode); err != nil {
+		return nil, err
+	}
+	return a.config, nil
+}
+
+// importItems adds imported items to the selected endpoint (the first endpoint if none is selected,
+// legacy items if there are no endpoints), replacing its items unless appendMode is set. Items
+// with patterns that do not compile are refused.
+func (a *App) importItems(items []models.ResponseItem, appendMode bool) error {
+	if errs := server.ValidateItemPatterns(items); len(errs) > 0 {
+		return &server.PatternValidationError{Errors: errs}
+	}
+	edit := a.snapshotEdit("Import responses")
+
 	// Get selected endpoint ID
 	selectedEndpointId := a.GetSelectedEndpointId()
 
@@ -2436,6 +3570,7 @@ func (a *App) importOpenAPISpecWithMode(appendMode bool) (*models.AppConfig, err
 			a.config.Items = items
 		}
 	}
+	a.commitEdit(edit)
 
 	// Update server if running
 	if a.server != nil {
@@ -2443,11 +3578,133 @@ func (a *App) importOpenAPISpecWithMode(appendMode bool) (*models.AppConfig, err
 	}
 
 	// Emit event to frontend
-	runtime.EventsEmit(a.ctx, "items:updated", items)
+	a.emit("items:updated", items)
+	return nil
+}
 
+// ImportHARWithDialog imports a .har file exported from browser DevTools, creating one group of
+// responses per recorded host. Shows a file dialog and imports with the specified append mode.
+func (a *App) ImportHARWithDialog(appendMode bool) (*models.AppConfig, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import HAR File",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "HAR Files", Pattern: "*.har;*.json"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil // User cancelled
+	}
+
+	items, count, err := har.ImportFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import HAR file: %v", err)
+	}
+	appLog.Info("Imported %d response(s) from %d host(s) in %s", count, len(items), path)
+
+	if err := a.importItems(items, appendMode); err != nil {
+		return nil, err
+	}
+	a.emit("config:dirty", true)
 	return a.config, nil
 }
 
+// harvestOpenAPIExample attaches a completed exchange to the OpenAPI-imported response it exercised
+func (a *App) harvestOpenAPIExample(log *models.RequestLog) {
+	a.configMutex.Lock()
+	harvested := openapi.HarvestExample(a.config.Endpoints, log)
+	a.configMutex.Unlock()
+	if harvested {
+		a.emit("config:dirty", true)
+	}
+}
+
+// ClearTrafficExamples removes the examples harvested from traffic for an endpoint's responses
+// and returns how many were removed
+func (a *App) ClearTrafficExamples(endpointID string) (int, error) {
+	edit := a.snapshotEdit("Clear traffic examples")
+	a.configMutex.Lock()
+	defer a.configMutex.Unlock()
+
+	for i := range a.config.Endpoints {
+		endpoint := &a.config.Endpoints[i]
+		if endpoint.ID != endpointID {
+			continue
+		}
+		removed := 0
+		clear := func(resp *models.MethodResponse) {
+			removed += len(resp.Examples)
+			resp.Examples = nil
+		}
+		for j := range endpoint.Items {
+			item := &endpoint.Items[j]
+			if item.Response != nil {
+				clear(item.Response)
+			}
+			if item.Group != nil {
+				for k := range item.Group.Responses {
+					clear(&item.Group.Responses[k])
+				}
+			}
+		}
+		if removed > 0 {
+			a.commitEdit(edit)
+			a.emit("config:dirty", true)
+		}
+		return removed, nil
+	}
+	return 0, fmt.Errorf("endpoint not found")
+}
+
+// ExportOpenAPISpec exports the selected mock endpoint's responses as an OpenAPI 3 document
+// format is "yaml" or "json". Returns the saved file path (empty if the user cancelled).
+func (a *App) ExportOpenAPISpec(format string) (string, error) {
+	if format != "json" {
+		format = "yaml"
+	}
+
+	selectedEndpointId := a.GetSelectedEndpointId()
+	var endpoint *models.Endpoint
+	for i := range a.config.Endpoints {
+		if a.config.Endpoints[i].ID == selectedEndpointId {
+			endpoint = &a.config.Endpoints[i]
+			break
+		}
+	}
+	if endpoint == nil {
+		return "", fmt.Errorf("no endpoint selected")
+	}
+	if endpoint.Type != models.EndpointTypeMock {
+		return "", fmt.Errorf("endpoint %s is not a mock endpoint", endpoint.Name)
+	}
+
+	data, err := openapi.ExportSpec(endpoint, format)
+	if err != nil {
+		return "", fmt.Errorf("failed to export OpenAPI spec: %v", err)
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export OpenAPI Specification",
+		DefaultFilename: "openapi." + format,
+		Filters: []runtime.FileFilter{
+			{DisplayName: strings.ToUpper(format) + " Files", Pattern: "*." + format},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", nil // User cancelled
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("could not write file: %v", err)
+	}
+	return path, nil
+}
+
 // GetRequestLogs returns all request log summaries
 func (a *App) GetRequestLogs() []models.RequestLogSummary {
 	a.logMutex.RLock()
@@ -2457,60 +3714,236 @@ func (a *App) GetRequestLogs() []models.RequestLogSummary {
 	summaries := make([]models.RequestLogSummary, len(a.requestLogs))
 	for i, log := range a.requestLogs {
 		summaries[i] = models.RequestLogSummary{
-			ID:             log.ID,
-			Timestamp:      log.Timestamp,
-			EndpointID:     log.EndpointID,
-			Method:         log.ClientRequest.Method,
-			Path:           log.ClientRequest.Path,
-			SourceIP:       log.ClientRequest.SourceIP,
-			ClientStatus:   log.ClientResponse.StatusCode,
-			ClientRTT:      log.ClientResponse.RTTMs,
-			HasBackend:     log.BackendRequest != nil || log.BackendResponse != nil,
-			ClientBodySize: len(log.ClientRequest.Body),
+			ID:              log.ID,
+			Timestamp:       log.Timestamp,
+			EndpointID:      log.EndpointID,
+			Method:          log.ClientRequest.Method,
+			Path:            log.ClientRequest.Path,
+			SourceIP:        log.ClientRequest.SourceIP,
+			ClientStatus:    log.ClientResponse.StatusCode,
+			ClientRTT:       log.ClientResponse.RTTMs,
+			HasBackend:      log.BackendRequest != nil || log.BackendResponse != nil,
+			ClientBodySize:  len(log.ClientRequest.Body),
+			AssertionStatus: log.Assertion.Status(),
 		}
 		if log.BackendResponse != nil {
 			summaries[i].BackendStatus = log.BackendResponse.StatusCode
 			summaries[i].BackendRTT = log.BackendResponse.RTTMs
 		}
+		if log.SOCKS5Info != nil {
+			summaries[i].ViaSOCKS5 = true
+			summaries[i].Intercepted = log.SOCKS5Info.IsIntercepted
+			summaries[i].TargetHost = log.SOCKS5Info.TargetHost
+			summaries[i].TargetPort = log.SOCKS5Info.TargetPort
+		}
 	}
 	return summaries
 }
 
-// GetRequestLogByID returns a specific request log by ID
-func (a *App) GetRequestLogByID(id string) *models.RequestLog {
-	a.logMutex.RLock()
-	defer a.logMutex.RUnlock()
-
-	for _, log := range a.requestLogs {
-		if log.ID == id {
-			return &log
+// GetRequestLogsByTraffic returns the request log summaries of one kind of traffic:
+// "intercepted" (HTTPS decrypted by SOCKS5 TLS interception) or "direct" (not through the SOCKS5 proxy).
+// An empty filter returns all logs.
+func (a *App) GetRequestLogsByTraffic(traffic string) []models.RequestLogSummary {
+	summaries := a.GetRequestLogs()
+	if traffic == "" {
+		return summaries
+	}
+
+	filtered := []models.RequestLogSummary{}
+	for _, summary := range summaries {
+		switch traffic {
+		case models.TrafficIntercepted:
+			if summary.Intercepted {
+				filtered = append(filtered, summary)
+			}
+		case models.TrafficDirect:
+			if !summary.ViaSOCKS5 {
+				filtered = append(filtered, summary)
+			}
 		}
 	}
-	return nil
+	return filtered
 }
 
-// ClearRequestLogs clears all request logs
-func (a *App) ClearRequestLogs() {
-	a.logMutex.Lock()
-	defer a.logMutex.Unlock()
-
-	a.requestLogs = make([]models.RequestLog, 0)
-	runtime.EventsEmit(a.ctx, "logs:cleared", nil)
-}
+// SearchRequestLogs returns one page of the summaries of the request logs matching a filter
+// (method, path regex, status range, endpoint, time window, body substring, header matchers)
+func (a *App) SearchRequestLogs(filter models.RequestLogFilter) (models.RequestLogPage, error) {
+	matcher, err := logsearch.Compile(filter)
+	if err != nil {
+		return models.RequestLogPage{}, err
+	}
 
-// ExportLogs exports logs in the specified format
-func (a *App) ExportLogs(format string) error {
 	a.logMutex.RLock()
-	logs := make([]models.RequestLog, len(a.requestLogs))
-	copy(logs, a.requestLogs)
-	a.logMutex.RUnlock()
+	defer a.logMutex.RUnlock()
 
-	var defaultName string
-	var pattern string
-	if format == "csv" {
-		defaultName = "request-logs.csv"
+	indexes, total := matcher.Page(a.requestLogs)
+	page := models.RequestLogPage{
+		Total:  total,
+		Offset: filter.Offset,
+		Logs:   make([]models.RequestLogSummary, len(indexes)),
+	}
+	for i, index := range indexes {
+		page.Logs[i] = summarizeRequestLog(&a.requestLogs[index])
+	}
+	return page, nil
+}
+
+// summarizeRequestLog builds the lightweight summary of a request log shown in the log list
+func summarizeRequestLog(log *models.RequestLog) models.RequestLogSummary {
+	summary := models.RequestLogSummary{
+		ID:               log.ID,
+		Timestamp:        log.Timestamp,
+		EndpointID:       log.EndpointID,
+		Method:           log.ClientRequest.Method,
+		Path:             log.ClientRequest.Path,
+		SourceIP:         log.ClientRequest.SourceIP,
+		ClientStatus:     log.ClientResponse.StatusCode,
+		ClientRTT:        log.ClientResponse.RTTMs,
+		HasBackend:       log.BackendRequest != nil || log.BackendResponse != nil,
+		ClientBodySize:   len(log.ClientRequest.Body),
+		ValidationFailed: log.ValidationFailed,
+		ResponseFailed:   log.ResponseFailed,
+		AssertionStatus:  log.Assertion.Status(),
+	}
+	if log.BackendResponse != nil {
+		summary.BackendStatus = log.BackendResponse.StatusCode
+		summary.BackendRTT = log.BackendResponse.RTTMs
+	}
+	if log.SOCKS5Info != nil {
+		summary.ViaSOCKS5 = true
+		summary.Intercepted = log.SOCKS5Info.IsIntercepted
+		summary.TargetHost = log.SOCKS5Info.TargetHost
+		summary.TargetPort = log.SOCKS5Info.TargetPort
+		summary.ProxyUser = log.SOCKS5Info.ProxyUser
+		summary.BytesSent = log.SOCKS5Info.BytesSent
+		summary.BytesReceived = log.SOCKS5Info.BytesReceived
+	}
+	return summary
+}
+
+// ReplayRequest re-sends a logged client request and returns the new response next to the original one.
+// targetBaseURL (scheme://host[:port][/prefix]) receives the request's path and query; empty targets the running mock.
+func (a *App) ReplayRequest(logID string, targetBaseURL string) (models.ReplayResult, error) {
+	results, err := a.ReplayRequests([]string{logID}, targetBaseURL)
+	if err != nil {
+		return models.ReplayResult{}, err
+	}
+	return results[0], nil
+}
+
+// ReplayRequests re-sends several logged client requests in order (see ReplayRequest).
+// A request that fails to send is reported in its result's error rather than stopping the run.
+func (a *App) ReplayRequests(logIDs []string, targetBaseURL string) ([]models.ReplayResult, error) {
+	if targetBaseURL == "" {
+		if a.server == nil {
+			return nil, fmt.Errorf("server is not running; start it or give a target URL")
+		}
+		targetBaseURL = fmt.Sprintf("http://localhost:%d", a.status.Port)
+	}
+
+	a.logMutex.RLock()
+	logs := make([]models.RequestLog, 0, len(logIDs))
+	for _, id := range logIDs {
+		found := false
+		for i := range a.requestLogs {
+			if a.requestLogs[i].ID == id {
+				logs = append(logs, a.requestLogs[i])
+				found = true
+				break
+			}
+		}
+		if !found {
+			a.logMutex.RUnlock()
+			return nil, fmt.Errorf("request log with ID %s not found", id)
+		}
+	}
+	a.logMutex.RUnlock()
+
+	replayer := replay.NewReplayer()
+	results := make([]models.ReplayResult, len(logs))
+	for i := range logs {
+		results[i] = replayer.Replay(&logs[i], targetBaseURL)
+	}
+	return results, nil
+}
+
+// GetRequestLogByID returns a specific request log by ID
+func (a *App) GetRequestLogByID(id string) *models.RequestLog {
+	a.logMutex.RLock()
+	defer a.logMutex.RUnlock()
+
+	for _, log := range a.requestLogs {
+		if log.ID == id {
+			return &log
+		}
+	}
+	return nil
+}
+
+// ClearRequestLogs clears all request logs
+func (a *App) ClearRequestLogs() {
+	a.logMutex.Lock()
+	defer a.logMutex.Unlock()
+
+	a.requestLogs = make([]models.RequestLog, 0)
+	if a.server != nil {
+		a.server.ClearRequestHistory()
+	}
+	a.emit("logs:cleared", nil)
+}
+
+// GetTransactions groups request logs into logical transactions by correlation header
+// or SOCKS5 connection. If correlationHeader is empty, common correlation headers
+// (X-Correlation-ID, X-Request-ID, traceparent) are checked in order.
+func (a *App) GetTransactions(correlationHeader string) []models.Transaction {
+	a.logMutex.RLock()
+	logs := make([]models.RequestLog, len(a.requestLogs))
+	copy(logs, a.requestLogs)
+	a.logMutex.RUnlock()
+
+	var headers []string
+	if correlationHeader != "" {
+		headers = []string{correlationHeader}
+	}
+	return correlation.GroupTransactions(logs, headers)
+}
+
+// GetRetryAfterReport checks the request logs for clients that came back before the Retry-After
+// they were sent (by client throttling, scheduled maintenance or any response) had elapsed
+func (a *App) GetRetryAfterReport() models.RetryAfterReport {
+	a.logMutex.RLock()
+	logs := make([]models.RequestLog, len(a.requestLogs))
+	copy(logs, a.requestLogs)
+	a.logMutex.RUnlock()
+
+	return backoff.Analyze(logs)
+}
+
+// ExportLogs exports logs in the specified format: "json" (default), "csv", "ndjson" (one log per line)
+// or "har" (HAR 1.2, client side). Logs are streamed to the file rather than built in memory.
+func (a *App) ExportLogs(format string) error {
+	a.logMutex.RLock()
+	logs := make([]models.RequestLog, len(a.requestLogs))
+	copy(logs, a.requestLogs)
+	a.logMutex.RUnlock()
+
+	var defaultName string
+	var pattern string
+	switch format {
+	case "csv":
+		defaultName = "request-logs.csv"
 		pattern = "*.csv"
-	} else {
+	case "ndjson":
+		defaultName = "request-logs.ndjson"
+		pattern = "*.ndjson"
+	case "har":
+		defaultName = "request-logs.har"
+		pattern = "*.har"
+	case "pcap":
+		defaultName = "request-logs.pcap"
+		pattern = "*.pcap"
+	default:
+		format = "json"
 		defaultName = "request-logs.json"
 		pattern = "*.json"
 	}
@@ -2519,7 +3952,7 @@ func (a *App) ExportLogs(format string) error {
 		Title:           "Export Logs",
 		DefaultFilename: defaultName,
 		Filters: []runtime.FileFilter{
-			{DisplayName: fmt.Sprintf("%s Files", format), Pattern: pattern},
+			{DisplayName: fmt.Sprintf("%s Files", strings.ToUpper(format)), Pattern: pattern},
 		},
 	})
 	if err != nil {
@@ -2535,9 +3968,20 @@ func (a *App) ExportLogs(format string) error {
 	}
 	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(logs)
+	switch format {
+	case "csv":
+		return export.WriteCSV(file, logs)
+	case "ndjson":
+		return export.WriteNDJSON(file, logs)
+	case "har":
+		return export.WriteHAR(file, logs, "client")
+	case "pcap":
+		return export.WritePcap(file, logs, "both")
+	default:
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(logs)
+	}
 }
 
 // ExportLogsAsHAR exports logs in HAR (HTTP Archive) format
@@ -2564,7 +4008,7 @@ func (a *App) ExportLogsAsHAR(endpointID string, side string) error {
 		return fmt.Errorf("failed to export HAR: %v", err)
 	}
 
-	log.Printf("Exported %d logs to HAR file: %s", len(filteredLogs), filePath)
+	appLog.Info("Exported %d logs to HAR file: %s", len(filteredLogs), filePath)
 	return nil
 }
 
@@ -2572,30 +4016,7 @@ func (a *App) ExportLogsAsHAR(endpointID string, side string) error {
 // endpointID filters logs by endpoint (empty string = all logs)
 // side can be "client" or "backend"
 func (a *App) ExportLogsAsCurl(endpointID string, side string) error {
-	a.logMutex.RLock()
-	var filteredLogs []models.RequestLog
-	var endpointName string
-
-	if endpointID == "" {
-		filteredLogs = make([]models.RequestLog, len(a.requestLogs))
-		copy(filteredLogs, a.requestLogs)
-		endpointName = "All Endpoints"
-	} else {
-		// Find endpoint name
-		for i := range a.config.Endpoints {
-			if a.config.Endpoints[i].ID == endpointID {
-				endpointName = a.config.Endpoints[i].Name
-				break
-			}
-		}
-		// Filter logs
-		for _, log := range a.requestLogs {
-			if log.EndpointID == endpointID {
-				filteredLogs = append(filteredLogs, log)
-			}
-		}
-	}
-	a.logMutex.RUnlock()
+	filteredLogs, endpointName := a.endpointLogs(endpointID)
 
 	exporter := export.NewLogExporter("")
 	filePath, err := exporter.ExportToCurl(filteredLogs, side, endpointName)
@@ -2603,10 +4024,156 @@ func (a *App) ExportLogsAsCurl(endpointID string, side string) error {
 		return fmt.Errorf("failed to export curl script: %v", err)
 	}
 
-	log.Printf("Exported %d logs to curl script: %s", len(filteredLogs), filePath)
+	appLog.Info("Exported %d logs to curl script: %s", len(filteredLogs), filePath)
 	return nil
 }
 
+// GenerateCurlCommand builds curl and HTTPie commands that reproduce a logged request, including its
+// method, headers and body. Proxied requests also get commands for the request sent to the backend.
+func (a *App) GenerateCurlCommand(logID string) (models.CommandSnippets, error) {
+	requestLog := a.GetRequestLogByID(logID)
+	if requestLog == nil {
+		return models.CommandSnippets{}, fmt.Errorf("request log not found: %s", logID)
+	}
+	return export.GenerateSnippets(requestLog), nil
+}
+
+// ExportLogsAsPcap writes captured traffic as synthesized TCP sessions to a .pcap file for Wireshark
+// and returns its path. endpointID filters logs by endpoint (empty string = all logs);
+// side is "client", "backend" (proxied requests) or "both"
+func (a *App) ExportLogsAsPcap(endpointID string, side string) (string, error) {
+	filteredLogs, _ := a.endpointLogs(endpointID)
+
+	exporter := export.NewLogExporter("")
+	filePath, err := exporter.ExportToPcap(filteredLogs, side)
+	if err != nil {
+		return "", fmt.Errorf("failed to export pcap: %v", err)
+	}
+
+	appLog.Info("Exported %d logs to pcap: %s", len(filteredLogs), filePath)
+	return filePath, nil
+}
+
+// StartPcapCapture mirrors every completed request (client side, and the backend side of proxied
+// requests) into a pcap file as it is logged. An empty path creates a timestamped file in exports/.
+// Returns the path of the capture file.
+func (a *App) StartPcapCapture(path string) (string, error) {
+	a.pcapMutex.Lock()
+	defer a.pcapMutex.Unlock()
+	if a.pcapFile != nil {
+		return "", fmt.Errorf("already capturing to %s", a.pcapFile.Name())
+	}
+
+	if path == "" {
+		path = filepath.Join("exports", fmt.Sprintf("capture_%s.pcap", time.Now().Format("20060102_150405")))
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("could not create capture directory: %v", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("could not create pcap file: %v", err)
+	}
+	writer, err := export.NewPcapWriter(file)
+	if err != nil {
+		file.Close()
+		return "", err
+	}
+
+	a.pcapFile = file
+	a.pcapWriter = writer
+	a.pcapWritten = make(map[string]bool)
+	appLog.Info("Capturing traffic to %s", path)
+	return path, nil
+}
+
+// StopPcapCapture ends the live capture and closes the file
+func (a *App) StopPcapCapture() error {
+	a.pcapMutex.Lock()
+	defer a.pcapMutex.Unlock()
+	if a.pcapFile == nil {
+		return fmt.Errorf("no capture is running")
+	}
+	err := a.pcapFile.Close()
+	appLog.Info("Stopped capture to %s (%d requests)", a.pcapFile.Name(), len(a.pcapWritten))
+	a.pcapFile = nil
+	a.pcapWriter = nil
+	a.pcapWritten = nil
+	return err
+}
+
+// GetPcapCapture returns the path of the running capture ("" when not capturing)
+func (a *App) GetPcapCapture() string {
+	a.pcapMutex.Lock()
+	defer a.pcapMutex.Unlock()
+	if a.pcapFile == nil {
+		return ""
+	}
+	return a.pcapFile.Name()
+}
+
+// mirrorToPcap writes a completed request to the live capture, once
+func (a *App) mirrorToPcap(requestLog *models.RequestLog) {
+	if requestLog.ClientResponse.StatusCode == nil {
+		return // Still pending; written when the log is updated
+	}
+	a.pcapMutex.Lock()
+	defer a.pcapMutex.Unlock()
+	if a.pcapWriter == nil || a.pcapWritten[requestLog.ID] {
+		return
+	}
+	a.pcapWritten[requestLog.ID] = true
+	if err := a.pcapWriter.WriteLog(requestLog, "both"); err != nil {
+		appLog.Error("Failed to write pcap capture: %v", err)
+	}
+}
+
+// ExportLogsAsJournal converts captured traffic into a replayable test and returns the file path.
+// format is "k6" (k6 script), "go" (Go test file) or "http" (REST client request file);
+// endpointID filters logs by endpoint (empty string = all logs); side can be "client" or "backend"
+func (a *App) ExportLogsAsJournal(endpointID string, format string, side string) (string, error) {
+	filteredLogs, endpointName := a.endpointLogs(endpointID)
+	if len(filteredLogs) == 0 {
+		return "", fmt.Errorf("no captured requests to export")
+	}
+
+	exporter := export.NewLogExporter("")
+	filePath, err := exporter.ExportToJournal(filteredLogs, format, side, endpointName)
+	if err != nil {
+		return "", fmt.Errorf("failed to export %s journal: %v", format, err)
+	}
+
+	appLog.Info("Exported %d logs to %s journal: %s", len(filteredLogs), format, filePath)
+	return filePath, nil
+}
+
+// endpointLogs returns a copy of the logs of one endpoint (all logs if endpointID is empty) and the endpoint's name
+func (a *App) endpointLogs(endpointID string) ([]models.RequestLog, string) {
+	a.logMutex.RLock()
+	defer a.logMutex.RUnlock()
+
+	if endpointID == "" {
+		logs := make([]models.RequestLog, len(a.requestLogs))
+		copy(logs, a.requestLogs)
+		return logs, "All Endpoints"
+	}
+
+	var endpointName string
+	for i := range a.config.Endpoints {
+		if a.config.Endpoints[i].ID == endpointID {
+			endpointName = a.config.Endpoints[i].Name
+			break
+		}
+	}
+	var logs []models.RequestLog
+	for _, log := range a.requestLogs {
+		if log.EndpointID == endpointID {
+			logs = append(logs, log)
+		}
+	}
+	return logs, endpointName
+}
+
 // HTTPS Certificate Management Methods
 
 // GetCACertInfo returns information about the CA certificate
@@ -2630,6 +4197,19 @@ func (a *App) GetCACertInfo() (models.CACertInfo, error) {
 	return info, nil
 }
 
+// GetCertificateDetails returns the subject, names, validity window and fingerprints of the CA and,
+// while HTTPS is serving, the active server certificate. Certificates within
+// server.CertExpiryWarningDays of expiry are flagged as expiring.
+func (a *App) GetCertificateDetails() []models.CertificateDetails {
+	if a.server != nil && a.status.Running {
+		return a.server.CertificateDetails()
+	}
+
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return server.InspectCertificates(a.config, nil)
+}
+
 // GetDefaultCertNames returns the default DNS names and IP addresses that will be used for certificates
 // Returns a list of strings containing: localhost, machine hostname, and interface IP for default gateway
 func (a *App) GetDefaultCertNames() ([]string, error) {
@@ -2645,7 +4225,8 @@ func (a *App) GetDefaultCertNames() ([]string, error) {
 	return result, nil
 }
 
-// RegenerateCA regenerates the CA certificate and restarts the HTTPS server
+// RegenerateCA regenerates the CA certificate and swaps it into the running server. Connections
+// already open keep their certificate; new handshakes use the new one.
 func (a *App) RegenerateCA() error {
 	certManager, err := server.NewCertificateManager()
 	if err != nil {
@@ -2658,16 +4239,15 @@ func (a *App) RegenerateCA() error {
 		return fmt.Errorf("failed to generate CA certificate: %w", err)
 	}
 
-	// Restart HTTPS server if it's running
-	if a.server != nil && a.status.Running && a.config.HTTPSEnabled {
-		err = a.server.RestartHTTPS()
-		if err != nil {
-			return fmt.Errorf("failed to restart HTTPS server: %w", err)
+	// Reload serving and interception certificates without restarting the listeners
+	if a.server != nil && a.status.Running {
+		if err := a.server.ReloadCertificates(); err != nil {
+			return fmt.Errorf("failed to reload certificates: %w", err)
 		}
 	}
 
 	// Emit event to frontend
-	runtime.EventsEmit(a.ctx, "ca:regenerated", nil)
+	a.emit("ca:regenerated", nil)
 
 	return nil
 }
@@ -2712,6 +4292,103 @@ func (a *App) DownloadCACert() (string, error) {
 	return path, nil
 }
 
+// ExportServerCertificate saves the certificate the HTTPS server is presenting, for certificate
+// pinning tests. With includeChain the CA is exported too. format is "pem" (one file) or "der"
+// (one file per certificate, numbered after the first). Returns the written paths.
+func (a *App) ExportServerCertificate(includeChain bool, format string) ([]string, error) {
+	if a.server == nil || !a.status.Running {
+		return nil, fmt.Errorf("server is not running")
+	}
+
+	chain, err := a.server.ServingChain(includeChain)
+	if err != nil {
+		return nil, err
+	}
+	if !includeChain {
+		chain = chain[:1]
+	}
+	files, err := server.EncodeCertificates(chain, format)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := ".pem"
+	if format == models.CertFormatDER {
+		ext = ".der"
+	}
+	defaultName := "mockelot-server"
+	if includeChain {
+		defaultName = "mockelot-chain"
+	}
+
+	// Show save dialog
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Server Certificate",
+		DefaultFilename: defaultName + ext,
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Certificate Files", Pattern: "*" + ext},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return []string{}, nil // User cancelled
+	}
+
+	paths := make([]string, len(files))
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	for i, data := range files {
+		paths[i] = path
+		if i > 0 {
+			paths[i] = fmt.Sprintf("%s-%d%s", base, i, ext)
+		}
+		if err := os.WriteFile(paths[i], data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to save certificate: %w", err)
+		}
+	}
+
+	return paths, nil
+}
+
+// GenerateMismatchedCertificate creates a certificate for the given hostnames that pinning clients
+// must reject (kind: "untrusted-ca", "new-key", "wrong-host" or "expired") and saves it as PEM, with
+// its private key next to it as .key. Serve it with the cert-provided certificate mode to run the
+// negative test. Returns the certificate path.
+func (a *App) GenerateMismatchedCertificate(hostnames []string, kind string) (string, error) {
+	a.configMutex.RLock()
+	certPEM, keyPEM, err := server.GenerateMismatchedCert(a.config, kind, hostnames)
+	a.configMutex.RUnlock()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate certificate: %w", err)
+	}
+
+	// Show save dialog
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Save Mismatched Certificate",
+		DefaultFilename: "mockelot-" + kind + ".pem",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Certificate Files", Pattern: "*.pem;*.crt"},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", nil // User cancelled
+	}
+
+	keyPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".key"
+	if err := os.WriteFile(path, certPEM, 0644); err != nil {
+		return "", fmt.Errorf("failed to save certificate: %w", err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return "", fmt.Errorf("failed to save private key: %w", err)
+	}
+
+	return path, nil
+}
+
 // InstallCACertSystem installs the CA certificate at the system level
 // Requires administrator/root privileges
 func (a *App) InstallCACertSystem() error {
@@ -2846,6 +4523,40 @@ func (a *App) UpdateServerSettings(settings models.ServerSettings) error {
 	a.configMutex.Lock()
 	defer a.configMutex.Unlock()
 
+	// Reject domain patterns that do not compile before applying anything
+	errs := server.ValidateDomainPatterns(settings.DomainTakeover)
+	errs = append(errs, server.ValidateBypassRules(settings.SOCKS5Config)...)
+	if len(errs) > 0 {
+		return &server.PatternValidationError{Errors: errs}
+	}
+	if err := server.ValidateSOCKS5Access(settings.SOCKS5Config); err != nil {
+		return err
+	}
+	if err := server.ValidateClientAuth(settings.ClientAuth); err != nil {
+		return err
+	}
+	if err := server.ValidateHTTPProxy(settings.HTTPProxy); err != nil {
+		return err
+	}
+	if err := server.ValidateServerLimits(settings.Limits); err != nil {
+		return err
+	}
+	if err := server.ValidateRegistryCredentials(settings.RegistryCredentials); err != nil {
+		return err
+	}
+	if settings.ContainerHosts != nil || settings.DefaultContainerHost != nil {
+		hosts, defaultHost := a.config.ContainerHosts, a.config.DefaultContainerHost
+		if settings.ContainerHosts != nil {
+			hosts = settings.ContainerHosts
+		}
+		if settings.DefaultContainerHost != nil {
+			defaultHost = *settings.DefaultContainerHost
+		}
+		if err := server.ValidateContainerHosts(hosts, defaultHost); err != nil {
+			return err
+		}
+	}
+
 	// Update AppConfig fields (only those provided - nil means don't update)
 	if settings.Port != nil {
 		a.config.Port = *settings.Port
@@ -2871,22 +4582,49 @@ func (a *App) UpdateServerSettings(settings models.ServerSettings) error {
 	if settings.CertNames != nil {
 		a.config.CertNames = settings.CertNames
 	}
+	if settings.ClientAuth != nil {
+		a.config.ClientAuth = settings.ClientAuth
+	}
+	// New certificate names or files, and client certificate settings, take effect for the next TLS handshake
+	if (settings.CertMode != nil || settings.CertPaths != nil || settings.CertNames != nil || settings.ClientAuth != nil) && a.server != nil && a.status.Running {
+		if err := a.server.ReloadCertificates(); err != nil {
+			return fmt.Errorf("failed to reload certificates: %w", err)
+		}
+	}
 	if settings.CORS != nil {
 		a.config.CORS = *settings.CORS
 	}
+	if settings.Limits != nil {
+		a.config.Limits = settings.Limits
+	}
 	if settings.SOCKS5Config != nil {
 		a.config.SOCKS5Config = settings.SOCKS5Config
 	}
+	if settings.HTTPProxy != nil {
+		a.config.HTTPProxy = settings.HTTPProxy
+	}
 	if settings.DomainTakeover != nil {
 		a.config.DomainTakeover = settings.DomainTakeover
 		// Recreate synthetic overlay endpoints for the new domain configuration
 		a.ensureDomainTakeoverEndpoints()
 		// Notify frontend about endpoint changes
-		runtime.EventsEmit(a.ctx, "endpoints:updated", a.config.Endpoints)
+		a.emit("endpoints:updated", a.config.Endpoints)
+	}
+	if settings.MarketplaceSources != nil {
+		a.config.MarketplaceSources = settings.MarketplaceSources
+	}
+	if settings.RegistryCredentials != nil {
+		a.config.RegistryCredentials = settings.RegistryCredentials
+	}
+	if settings.ContainerHosts != nil {
+		a.config.ContainerHosts = settings.ContainerHosts
+	}
+	if settings.DefaultContainerHost != nil {
+		a.config.DefaultContainerHost = *settings.DefaultContainerHost
 	}
 
 	// Emit config updated event
-	runtime.EventsEmit(a.ctx, "config:updated", a.config)
+	a.emit("config:updated", a.config)
 
 	return nil
 }
@@ -2931,6 +4669,11 @@ func (a *App) GetSOCKS5Config() SOCKS5ConfigResponse {
 	}
 }
 
+// ValidateDelayExpression validates a response delay expression for syntax errors
+func (a *App) ValidateDelayExpression(expression string) error {
+	return server.ValidateDelayExpression(expression)
+}
+
 // ValidateCORSHeaderExpression validates a CORS header expression for syntax errors
 func (a *App) ValidateCORSHeaderExpression(expression string) error {
 	return server.ValidateHeaderExpression(expression)
@@ -2941,6 +4684,12 @@ func (a *App) LogRequest(log models.RequestLog) {
 	a.logMutex.Lock()
 	a.requestLogs = append(a.requestLogs, log)
 	a.logMutex.Unlock()
+	a.mirrorToPcap(&log)
+	a.harvestOpenAPIExample(&log)
+	if a.headlessLog != nil {
+		a.headlessLog.LogRequest(log)
+		return
+	}
 
 	// Create lightweight summary for frontend
 	summary := models.RequestLogSummary{
@@ -2956,6 +4705,7 @@ func (a *App) LogRequest(log models.RequestLog) {
 		ClientBodySize: len(log.ClientRequest.Body),
 		ValidationFailed: log.ValidationFailed,
 		ResponseFailed:   log.ResponseFailed,
+		AssertionStatus:  log.Assertion.Status(),
 	}
 
 	// Add backend info if present
@@ -2964,94 +4714,2333 @@ func (a *App) LogRequest(log models.RequestLog) {
 		summary.BackendRTT = log.BackendResponse.RTTMs
 	}
 
+	// Add SOCKS5 destination if the request came through the proxy
+	if log.SOCKS5Info != nil {
+		summary.ViaSOCKS5 = true
+		summary.Intercepted = log.SOCKS5Info.IsIntercepted
+		summary.TargetHost = log.SOCKS5Info.TargetHost
+		summary.TargetPort = log.SOCKS5Info.TargetPort
+		summary.ProxyUser = log.SOCKS5Info.ProxyUser
+		summary.BytesSent = log.SOCKS5Info.BytesSent
+		summary.BytesReceived = log.SOCKS5Info.BytesReceived
+	}
+
 	// Set pending status
 	summary.Pending = false // By default, logs are complete
 
-	// Queue summary for frontend polling (more efficient than individual events during high traffic)
-	a.requestLogQueueMutex.Lock()
-	a.requestLogSummaryQueue = append(a.requestLogSummaryQueue, summary)
-	a.requestLogQueueMutex.Unlock()
+	// Queue summary for frontend polling (more efficient than individual events during high traffic)
+	a.requestLogQueueMutex.Lock()
+	a.requestLogSummaryQueue = append(a.requestLogSummaryQueue, summary)
+	a.requestLogQueueMutex.Unlock()
+}
+
+// UpdateRequestLog updates an existing request log (used for two-phase logging)
+// This allows showing pending requests immediately, then updating them when complete
+func (a *App) UpdateRequestLog(log models.RequestLog) {
+	a.logMutex.Lock()
+
+	// Find and update the existing log
+	found := false
+	for i := range a.requestLogs {
+		if a.requestLogs[i].ID == log.ID {
+			a.requestLogs[i] = log
+			found = true
+			break
+		}
+	}
+
+	// If not found, just append it (fallback behavior)
+	if !found {
+		a.requestLogs = append(a.requestLogs, log)
+	}
+
+	a.logMutex.Unlock()
+	a.mirrorToPcap(&log)
+	a.harvestOpenAPIExample(&log)
+	if a.headlessLog != nil {
+		a.headlessLog.UpdateRequestLog(log)
+		return
+	}
+
+	// Create updated summary for frontend
+	summary := models.RequestLogSummary{
+		ID:         log.ID,
+		Timestamp:  log.Timestamp,
+		EndpointID: log.EndpointID,
+		Method:     log.ClientRequest.Method,
+		Path:       log.ClientRequest.Path,
+		SourceIP:   log.ClientRequest.SourceIP,
+		ClientStatus: log.ClientResponse.StatusCode,
+		ClientRTT:  log.ClientResponse.RTTMs,
+		HasBackend: log.BackendRequest != nil || log.BackendResponse != nil,
+		ClientBodySize: len(log.ClientRequest.Body),
+		Pending:    false, // Update means request is complete
+		ValidationFailed: log.ValidationFailed,
+		ResponseFailed:   log.ResponseFailed,
+		AssertionStatus:  log.Assertion.Status(),
+	}
+
+	// Add backend info if present
+	if log.BackendResponse != nil {
+		summary.BackendStatus = log.BackendResponse.StatusCode
+		summary.BackendRTT = log.BackendResponse.RTTMs
+	}
+
+	// Add SOCKS5 destination if the request came through the proxy
+	if log.SOCKS5Info != nil {
+		summary.ViaSOCKS5 = true
+		summary.Intercepted = log.SOCKS5Info.IsIntercepted
+		summary.TargetHost = log.SOCKS5Info.TargetHost
+		summary.TargetPort = log.SOCKS5Info.TargetPort
+		summary.ProxyUser = log.SOCKS5Info.ProxyUser
+		summary.BytesSent = log.SOCKS5Info.BytesSent
+		summary.BytesReceived = log.SOCKS5Info.BytesReceived
+	}
+
+	// Queue updated summary
+	a.requestLogQueueMutex.Lock()
+	a.requestLogSummaryQueue = append(a.requestLogSummaryQueue, summary)
+	a.requestLogQueueMutex.Unlock()
+}
+
+// GetRequestLogDetails returns the full RequestLog details for a given ID
+func (a *App) GetRequestLogDetails(id string) (*models.RequestLog, error) {
+	a.logMutex.RLock()
+	defer a.logMutex.RUnlock()
+
+	for i := range a.requestLogs {
+		if a.requestLogs[i].ID == id {
+			return &a.requestLogs[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("request log with ID %s not found", id)
+}
+
+// PollRequestLogs returns all queued request log summaries and clears the queue
+// This is called by the frontend at regular intervals (polling) for efficient batching
+// during high-volume traffic
+func (a *App) PollRequestLogs() []models.RequestLogSummary {
+	a.requestLogQueueMutex.Lock()
+	defer a.requestLogQueueMutex.Unlock()
+
+	// Get current summaries
+	summaries := a.requestLogSummaryQueue
+
+	// Clear the queue
+	a.requestLogSummaryQueue = make([]models.RequestLogSummary, 0)
+
+	return summaries
+}
+
+// ========== Marketplace ==========
+
+// GetMarketplaceSources returns the configured endpoint bundle registries
+func (a *App) GetMarketplaceSources() []models.MarketplaceSource {
+	return a.config.MarketplaceSources
+}
+
+// ListMarketplaceBundles fetches the indexes of all configured sources
+// Sources that fail are logged and skipped; an error is returned only if every source fails
+func (a *App) ListMarketplaceBundles() ([]models.MarketplaceBundle, error) {
+	client := marketplace.NewClient("")
+
+	bundles := []models.MarketplaceBundle{}
+	var firstErr error
+	for _, source := range a.config.MarketplaceSources {
+		sourceBundles, err := client.ListBundles(source)
+		if err != nil {
+			appLog.Error("Marketplace: failed to list bundles from %s: %v", source.Name, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		bundles = append(bundles, sourceBundles...)
+	}
+
+	if len(bundles) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return bundles, nil
+}
+
+// InstallMarketplaceBundle downloads a bundle, verifies its checksum and adds its
+// endpoints to the current config (with fresh IDs, before system endpoints)
+func (a *App) InstallMarketplaceBundle(sourceName string, bundleID string) ([]models.Endpoint, error) {
+	var source *models.MarketplaceSource
+	for i := range a.config.MarketplaceSources {
+		if a.config.MarketplaceSources[i].Name == sourceName {
+			source = &a.config.MarketplaceSources[i]
+			break
+		}
+	}
+	if source == nil {
+		return nil, fmt.Errorf("marketplace source not found: %s", sourceName)
+	}
+
+	client := marketplace.NewClient("")
+	bundles, err := client.ListBundles(*source)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle *models.MarketplaceBundle
+	for i := range bundles {
+		if bundles[i].ID == bundleID {
+			bundle = &bundles[i]
+			break
+		}
+	}
+	if bundle == nil {
+		return nil, fmt.Errorf("bundle %s not found in source %s", bundleID, sourceName)
+	}
+
+	bundleFile, err := client.FetchBundle(*source, *bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	edit := a.snapshotEdit("Install marketplace bundle")
+	// Find insertion point before system endpoints and the next free display order
+	insertIndex, nextOrder := a.userEndpointInsertPoint()
+
+	installed := make([]models.Endpoint, 0, len(bundleFile.Endpoints))
+	var errs []models.PatternError
+	for _, endpoint := range bundleFile.Endpoints {
+		assignNewIDs(&endpoint)
+		endpoint.IsSystem = false
+		endpoint.DisplayOrder = nextOrder
+		nextOrder++
+		if endpoint.Type == "" {
+			endpoint.Type = models.EndpointTypeMock
+		}
+		errs = append(errs, server.ValidateEndpointPatterns(&endpoint)...)
+		installed = append(installed, endpoint)
+	}
+	if len(errs) > 0 {
+		return nil, &server.PatternValidationError{Errors: errs}
+	}
+
+	a.config.Endpoints = append(a.config.Endpoints[:insertIndex], append(installed, a.config.Endpoints[insertIndex:]...)...)
+	a.commitEdit(edit)
+
+	appLog.Info("Marketplace: installed bundle %s (%d endpoints) from %s", bundle.ID, len(installed), sourceName)
+
+	// If server is running, update it
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	// Emit events to frontend
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:dirty", true)
+
+	return installed, nil
+}
+
+// userEndpointInsertPoint returns the index before the first system endpoint and the next free display order
+func (a *App) userEndpointInsertPoint() (int, int) {
+	insertIndex := len(a.config.Endpoints)
+	nextOrder := 0
+	for i, ep := range a.config.Endpoints {
+		if ep.IsSystem {
+			if insertIndex == len(a.config.Endpoints) {
+				insertIndex = i
+			}
+			continue
+		}
+		if ep.DisplayOrder >= nextOrder {
+			nextOrder = ep.DisplayOrder + 1
+		}
+	}
+	return insertIndex, nextOrder
+}
+
+// assignNewIDs gives an endpoint and all of its groups and responses fresh IDs
+// Used when endpoints are imported from outside the current config
+func assignNewIDs(endpoint *models.Endpoint) {
+	endpoint.ID = uuid.New().String()
+	assignNewItemIDs(endpoint.Items)
+	if endpoint.Versioning != nil {
+		for i := range endpoint.Versioning.Versions {
+			assignNewItemIDs(endpoint.Versioning.Versions[i].Items)
+		}
+	}
+	if endpoint.ContainerConfig != nil {
+		endpoint.ContainerConfig.ContainerID = ""
+	}
+}
+
+// assignNewItemIDs gives every response and group in items a fresh ID
+func assignNewItemIDs(items []models.ResponseItem) {
+	for i := range items {
+		item := &items[i]
+		if item.Response != nil {
+			assignNewResponseID(item.Response)
+		}
+		if item.Group != nil {
+			item.Group.ID = uuid.New().String()
+			for j := range item.Group.Responses {
+				assignNewResponseID(&item.Group.Responses[j])
+			}
+		}
+	}
+}
+
+// assignNewResponseID gives a response a fresh ID; the published version of a draft follows, so
+// discarding the draft keeps the new ID
+func assignNewResponseID(resp *models.MethodResponse) {
+	resp.ID = uuid.New().String()
+	if resp.Published != nil {
+		resp.Published.ID = resp.ID
+	}
+}
+
+// ========== Deployment ==========
+
+// ExportDockerImageSpec generates a Dockerfile and docker-compose snippet that bundle
+// the headless server with the config at configPath (defaults to the current config file)
+func (a *App) ExportDockerImageSpec(configPath string) (*models.DockerImageSpec, error) {
+	cfg, path, err := a.loadDeploymentConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := deploy.OptionsFromConfig(cfg, path)
+	opts.ConfigDirLayout = configdir.IsLayout(path)
+	return &models.DockerImageSpec{
+		ConfigPath: path,
+		Dockerfile: deploy.GenerateDockerfile(opts),
+		Compose:    deploy.GenerateCompose(opts),
+	}, nil
+}
+
+// ExportKubernetesManifests generates a ConfigMap, Deployment and Service for running
+// the headless server with the config at configPath (defaults to the current config file)
+func (a *App) ExportKubernetesManifests(configPath string) (string, error) {
+	cfg, path, err := a.loadDeploymentConfig(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	var content []byte
+	if configdir.IsLayout(path) {
+		// The ConfigMap holds one file: the endpoint files are joined into it
+		userCfg, err := readUserConfigFile(path)
+		if err != nil {
+			return "", err
+		}
+		content, err = yaml.Marshal(userCfg)
+		if err != nil {
+			return "", fmt.Errorf("could not encode config: %v", err)
+		}
+	} else {
+		content, err = os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("could not read config file: %v", err)
+		}
+	}
+
+	opts := deploy.OptionsFromConfig(cfg, path)
+	return deploy.GenerateKubernetesManifests(opts, content), nil
+}
+
+// loadDeploymentConfig reads the config to package for deployment
+// An empty path means the current config file, which must be saved first
+func (a *App) loadDeploymentConfig(configPath string) (*models.AppConfig, string, error) {
+	if configPath == "" {
+		if a.currentConfigPath == "" {
+			return nil, "", fmt.Errorf("no config file loaded - save the config first")
+		}
+		if a.IsDirty() {
+			return nil, "", fmt.Errorf("config has unsaved changes - save before exporting")
+		}
+		configPath = a.currentConfigPath
+	}
+	if configdir.IsLayout(configPath) {
+		configPath = configdir.MainPath(configPath)
+	}
+
+	userCfg, err := readUserConfigFile(configPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return userConfigToAppConfig(userCfg, nil), configPath, nil
+}
+
+// readUserConfigFile decodes a YAML config file, or a config in directory layout, without applying it
+func readUserConfigFile(path string) (*models.UserConfig, error) {
+	if configdir.IsLayout(path) {
+		return configdir.Read(path, os.ReadFile)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %v", err)
+	}
+	defer file.Close()
+
+	var userCfg models.UserConfig
+	decoder := yaml.NewDecoder(file)
+	if err := decoder.Decode(&userCfg); err != nil {
+		return nil, fmt.Errorf("could not decode config: %v", err)
+	}
+
+	return &userCfg, nil
+}
+
+// ========== Config Merge ==========
+
+// PreviewMergeConfigs reports how two config files would merge, without changing the current config
+func (a *App) PreviewMergeConfigs(basePath, otherPath, strategy string) (*models.MergeReport, error) {
+	_, report, err := mergeConfigFiles(basePath, otherPath, strategy)
+	return report, err
+}
+
+// MergeConfigs merges the other config file into the base file and loads the result as the current,
+// unsaved config (saving writes to basePath). Entries present in both files with different content are
+// resolved with the strategy: "base" keeps the base version, "other" takes the other file's version and
+// "both" keeps the base version and adds the other as a copy. Server settings come from the base file.
+func (a *App) MergeConfigs(basePath, otherPath, strategy string) (*models.MergeReport, error) {
+	merged, report, err := mergeConfigFiles(basePath, otherPath, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	appLog.Info("Merged %s into %s (%s): %d endpoint(s) added, %d item(s) added, %d conflict(s)",
+		otherPath, basePath, report.Strategy, len(report.EndpointsAdded), report.ItemsAdded, len(report.Conflicts))
+	edit := a.snapshotEdit("Merge configs")
+	if err := a.loadUnsavedConfig(merged, basePath); err != nil {
+		return nil, err
+	}
+	a.commitEdit(edit)
+	return report, nil
+}
+
+// loadUnsavedConfig makes a config the current config without marking it saved (saving writes
+// it to path). A config with patterns that do not compile is refused.
+func (a *App) loadUnsavedConfig(userCfg *models.UserConfig, path string) error {
+	a.configMutex.Lock()
+	config := userConfigToAppConfig(userCfg, a.config)
+	if err := checkConfigPatterns(config); err != nil {
+		a.configMutex.Unlock()
+		return err
+	}
+	a.config = config
+	a.currentConfigPath = path
+	a.configMutex.Unlock()
+
+	// Keep the selection if it survived
+	if len(a.config.Endpoints) > 0 {
+		validSelection := false
+		for _, endpoint := range a.config.Endpoints {
+			if endpoint.ID == a.config.SelectedEndpointId {
+				validSelection = true
+				break
+			}
+		}
+		if !validSelection {
+			a.config.SelectedEndpointId = a.config.Endpoints[0].ID
+		}
+	}
+
+	a.ensureDisplayOrder()
+	a.ensureDomainTakeoverEndpoints()
+	a.ensureSOCKS5ProxyEndpoint()
+	a.ensureRejectionsEndpoint()
+
+	// Update server if running
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+		a.server.EnsureContainerMonitoring()
+	}
+
+	a.reportScriptWarnings(server.ValidateScripts(a.config))
+
+	// Emit events to frontend (the config has not been saved yet)
+	a.emit("responses:updated", a.config.Responses)
+	a.emit("items:updated", a.config.Items)
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:loaded", a.config)
+	a.emit("config:dirty", true)
+	a.emit("config:path", path)
+	return nil
+}
+
+// mergeConfigFiles reads and merges two config files
+func mergeConfigFiles(basePath, otherPath, strategy string) (*models.UserConfig, *models.MergeReport, error) {
+	base, err := readUserConfigFile(basePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("base config: %v", err)
+	}
+	other, err := readUserConfigFile(otherPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("other config: %v", err)
+	}
+	return merge.Merge(base, other, strategy)
+}
+
+// ========== Config Bundles ==========
+
+// ExportBundle writes the current config to a zip together with the files it references
+// (certificates, keys, CA bundles and .proto files), with relative paths so the bundle can be
+// imported on another machine
+func (a *App) ExportBundle(path string) (*models.BundleReport, error) {
+	a.configMutex.RLock()
+	userConfig := a.currentUserConfig()
+	a.configMutex.RUnlock()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not create bundle: %v", err)
+	}
+	report, err := bundle.Export(userConfig, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	appLog.Info("Exported config bundle %s (%d file(s))", path, len(report.Files))
+	for _, warning := range report.Warnings {
+		appLog.Warn("Bundle export: %s", warning)
+	}
+	return report, nil
+}
+
+// ImportBundle extracts a bundle into destDir and loads its config, with the bundled files
+// referenced from there. The config is saved in destDir as config.yaml.
+func (a *App) ImportBundle(zipPath, destDir string) (*models.BundleReport, error) {
+	userCfg, report, err := bundle.Import(zipPath, destDir)
+	if err != nil {
+		return nil, err
+	}
+	configPath, err := filepath.Abs(filepath.Join(destDir, bundle.ConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.loadUserConfig(userCfg, configPath); err != nil {
+		return nil, err
+	}
+
+	appLog.Info("Imported config bundle %s into %s (%d file(s))", zipPath, destDir, len(report.Files))
+	for _, warning := range report.Warnings {
+		appLog.Warn("Bundle import: %s", warning)
+	}
+	return report, nil
+}
+
+// ExportBundleWithDialog asks where to save a bundle of the current config, then exports it
+func (a *App) ExportBundleWithDialog() (*models.BundleReport, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Config Bundle",
+		DefaultFilename: "mockelot-bundle.zip",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Zip Files", Pattern: "*.zip"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil // User cancelled
+	}
+	return a.ExportBundle(path)
+}
+
+// ImportBundleWithDialog asks for a bundle and the directory to extract it to, then imports it
+func (a *App) ImportBundleWithDialog() (*models.BundleReport, error) {
+	zipPath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import Config Bundle",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Zip Files", Pattern: "*.zip"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if zipPath == "" {
+		return nil, nil // User cancelled
+	}
+
+	destDir, err := runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:                "Extract Bundle To",
+		CanCreateDirectories: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if destDir == "" {
+		return nil, nil // User cancelled
+	}
+	return a.ImportBundle(zipPath, destDir)
+}
+
+// ========== Backend Crawl ==========
+
+// CrawlProxyEndpoint crawls the backend of a proxy endpoint from seed paths (following discovered links)
+// and saves the responses as a new, disabled mock endpoint with the same prefix and path translation
+func (a *App) CrawlProxyEndpoint(endpointID string, options models.CrawlOptions) (*models.CrawlResult, error) {
+	a.configMutex.RLock()
+	var proxyEndpoint *models.Endpoint
+	for i := range a.config.Endpoints {
+		if a.config.Endpoints[i].ID == endpointID {
+			ep := a.config.Endpoints[i]
+			proxyEndpoint = &ep
+			break
+		}
+	}
+	environment := a.config.ActiveEnvironment
+	variables := server.ConfigVariables(a.config)
+	a.configMutex.RUnlock()
+
+	if proxyEndpoint == nil {
+		return nil, fmt.Errorf("endpoint not found: %s", endpointID)
+	}
+	if proxyEndpoint.Type != models.EndpointTypeProxy || proxyEndpoint.ProxyConfig == nil {
+		return nil, fmt.Errorf("endpoint %s is not a proxy endpoint", proxyEndpoint.Name)
+	}
+	backendURL := variables.Expand(proxyEndpoint.ProxyConfig.ResolveBackendURL(environment))
+
+	c, err := crawler.New(backendURL, options, time.Duration(proxyEndpoint.ProxyConfig.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, result := c.Crawl(a.ctx)
+	if len(responses) == 0 {
+		return result, fmt.Errorf("crawl produced no responses (%d request(s) failed)", len(result.Errors))
+	}
+
+	items := make([]models.ResponseItem, 0, len(responses))
+	for i := range responses {
+		items = append(items, models.ResponseItem{Type: "response", Response: &responses[i]})
+	}
+
+	snapshot := newSnapshotEndpoint(proxyEndpoint, items)
+	if errs := server.ValidateEndpointPatterns(&snapshot); len(errs) > 0 {
+		return result, &server.PatternValidationError{Errors: errs}
+	}
+
+	edit := a.snapshotEdit("Crawl backend")
+	a.configMutex.Lock()
+	insertIndex, nextOrder := a.userEndpointInsertPoint()
+	snapshot.DisplayOrder = nextOrder
+	a.config.Endpoints = append(a.config.Endpoints[:insertIndex], append([]models.Endpoint{snapshot}, a.config.Endpoints[insertIndex:]...)...)
+	a.commitEdit(edit)
+	a.configMutex.Unlock()
+
+	appLog.Info("Crawl: created snapshot %s with %d responses from %s", snapshot.Name, len(responses), backendURL)
+
+	// If server is running, update it
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	// Emit events to frontend
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:dirty", true)
+
+	result.EndpointID = snapshot.ID
+	return result, nil
+}
+
+// newSnapshotEndpoint creates a mock endpoint holding recorded responses of a proxy/container endpoint.
+// The snapshot shares the source's prefix, so it starts disabled to avoid shadowing it.
+func newSnapshotEndpoint(source *models.Endpoint, items []models.ResponseItem) models.Endpoint {
+	enabled := false
+	return models.Endpoint{
+		ID:               uuid.New().String(),
+		Name:             source.Name + " (snapshot)",
+		PathPrefix:       source.PathPrefix,
+		TranslationMode:  source.TranslationMode,
+		TranslatePattern: source.TranslatePattern,
+		TranslateReplace: source.TranslateReplace,
+		Enabled:          &enabled,
+		DomainFilter:     source.DomainFilter,
+		SnapshotOf:       source.ID,
+		Type:             models.EndpointTypeMock,
+		Items:            items,
+	}
+}
+
+// ========== Offline Mode ==========
+
+// Response headers not copied from recorded responses (recomputed when the mock is served)
+var unrecordedHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Content-Encoding":  true,
+	"Transfer-Encoding": true,
+	"Connection":        true,
+	"Date":              true,
+}
+
+// GetOfflineMode returns whether offline mode is on and how each proxy/container endpoint is covered
+func (a *App) GetOfflineMode() *models.OfflineModeStatus {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.offlineStatus(nil)
+}
+
+// SetOfflineMode switches all proxy and container endpoints to serve their recorded snapshot endpoints
+// instead of contacting backends (or back to live traffic). When enabling, completed responses in the
+// request log are first recorded into each endpoint's snapshot, creating the snapshot if needed.
+func (a *App) SetOfflineMode(enabled bool) (*models.OfflineModeStatus, error) {
+	a.logMutex.RLock()
+	logs := make([]models.RequestLog, len(a.requestLogs))
+	copy(logs, a.requestLogs)
+	a.logMutex.RUnlock()
+
+	a.configMutex.Lock()
+	recorded := make(map[string]int)
+	if enabled {
+		var sources []models.Endpoint
+		for _, endpoint := range a.config.Endpoints {
+			if endpoint.Type == models.EndpointTypeProxy || endpoint.Type == models.EndpointTypeContainer {
+				sources = append(sources, endpoint)
+			}
+		}
+
+		for i := range sources {
+			source := &sources[i]
+			snapshotIndex := -1
+			for j := range a.config.Endpoints {
+				if a.config.Endpoints[j].Type == models.EndpointTypeMock && a.config.Endpoints[j].SnapshotOf == source.ID {
+					snapshotIndex = j
+					break
+				}
+			}
+
+			var existing []models.ResponseItem
+			if snapshotIndex >= 0 {
+				existing = a.config.Endpoints[snapshotIndex].Items
+			}
+			responses := recordedResponses(source.ID, logs, existing)
+			if len(responses) == 0 {
+				continue
+			}
+			recorded[source.ID] = len(responses)
+
+			items := make([]models.ResponseItem, 0, len(responses))
+			for j := range responses {
+				items = append(items, models.ResponseItem{Type: "response", Response: &responses[j]})
+			}
+
+			if snapshotIndex >= 0 {
+				a.config.Endpoints[snapshotIndex].Items = append(a.config.Endpoints[snapshotIndex].Items, items...)
+			} else {
+				snapshot := newSnapshotEndpoint(source, items)
+				insertIndex, nextOrder := a.userEndpointInsertPoint()
+				snapshot.DisplayOrder = nextOrder
+				a.config.Endpoints = append(a.config.Endpoints[:insertIndex], append([]models.Endpoint{snapshot}, a.config.Endpoints[insertIndex:]...)...)
+			}
+			appLog.Info("Offline mode: recorded %d response(s) for %s", len(responses), source.Name)
+		}
+	}
+	a.config.OfflineMode = enabled
+	status := a.offlineStatus(recorded)
+	a.configMutex.Unlock()
+
+	// If server is running, update it
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	// Emit events to frontend
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("offline:changed", status)
+	a.emit("config:dirty", true)
+
+	a.recordMacroStep(models.MacroStep{Action: models.MacroActionOfflineMode, Enabled: enabled})
+	return status, nil
+}
+
+// offlineStatus reports snapshot coverage of each proxy/container endpoint (caller must hold configMutex)
+func (a *App) offlineStatus(recorded map[string]int) *models.OfflineModeStatus {
+	status := &models.OfflineModeStatus{Enabled: a.config.OfflineMode}
+	for _, endpoint := range a.config.Endpoints {
+		if endpoint.Type != models.EndpointTypeProxy && endpoint.Type != models.EndpointTypeContainer {
+			continue
+		}
+		entry := models.OfflineEndpointStatus{
+			EndpointID:   endpoint.ID,
+			EndpointName: endpoint.Name,
+			Recorded:     recorded[endpoint.ID],
+		}
+		for _, candidate := range a.config.Endpoints {
+			if candidate.Type == models.EndpointTypeMock && candidate.SnapshotOf == endpoint.ID {
+				entry.SnapshotID = candidate.ID
+				for _, item := range candidate.Items {
+					if item.Type == "response" && item.Response != nil {
+						entry.Responses++
+					} else if item.Type == "group" && item.Group != nil {
+						entry.Responses += len(item.Group.Responses)
+					}
+				}
+				break
+			}
+		}
+		status.Endpoints = append(status.Endpoints, entry)
+	}
+	return status
+}
+
+// recordedResponses converts the most recent completed backend response for each method and path
+// of an endpoint into a static mock response, skipping paths the snapshot already covers
+func recordedResponses(endpointID string, logs []models.RequestLog, existing []models.ResponseItem) []models.MethodResponse {
+	covered := make(map[string]bool)
+	cover := func(resp *models.MethodResponse) {
+		for _, method := range resp.Methods {
+			covered[strings.ToUpper(method)+" "+resp.PathPattern] = true
+		}
+	}
+	for _, item := range existing {
+		if item.Response != nil {
+			cover(item.Response)
+		}
+		if item.Group != nil {
+			for i := range item.Group.Responses {
+				cover(&item.Group.Responses[i])
+			}
+		}
+	}
+
+	var responses []models.MethodResponse
+	for i := len(logs) - 1; i >= 0; i-- {
+		entry := &logs[i]
+		if entry.EndpointID != endpointID || entry.ResponseFailed || entry.ClientResponse.StatusCode == nil || entry.BodyTruncated {
+			continue
+		}
+		// Only record responses that actually came from the backend
+		if entry.BackendResponse == nil || entry.BackendResponse.StatusCode == nil {
+			continue
+		}
+
+		// Snapshots are matched against the translated path, which is what the backend saw
+		path := entry.ClientRequest.Path
+		if entry.BackendRequest != nil && entry.BackendRequest.Path != "" {
+			path = entry.BackendRequest.Path
+		}
+		method := strings.ToUpper(entry.ClientRequest.Method)
+		key := method + " " + path
+		if covered[key] {
+			continue
+		}
+		covered[key] = true
+
+		headers := make(map[string]string)
+		for name, values := range entry.ClientResponse.Headers {
+			if len(values) > 0 && !unrecordedHeaders[http.CanonicalHeaderKey(name)] {
+				headers[name] = strings.Join(values, ", ")
+			}
+		}
+
+		statusCode := *entry.ClientResponse.StatusCode
+		responses = append(responses, models.MethodResponse{
+			ID:           uuid.New().String(),
+			PathPattern:  path,
+			Methods:      []string{method},
+			StatusCode:   statusCode,
+			StatusText:   http.StatusText(statusCode),
+			Headers:      headers,
+			Body:         entry.ClientResponse.Body,
+			ResponseMode: models.ResponseModeStatic,
+		})
+	}
+
+	// Restore chronological order
+	for i, j := 0, len(responses)-1; i < j; i, j = i+1, j-1 {
+		responses[i], responses[j] = responses[j], responses[i]
+	}
+	return responses
+}
+
+// ========== Drafts ==========
+
+// PublishDrafts makes all draft responses live and returns how many were published
+func (a *App) PublishDrafts() int {
+	edit := a.snapshotEdit("Publish drafts")
+	a.configMutex.Lock()
+	count := 0
+	a.forEachItemList(func(items []models.ResponseItem) []models.ResponseItem {
+		walkResponses(items, func(resp *models.MethodResponse) {
+			if resp.Draft {
+				resp.Draft = false
+				resp.Published = nil
+				count++
+			}
+		})
+		return items
+	})
+	for i := range a.config.Responses {
+		if a.config.Responses[i].Draft {
+			a.config.Responses[i].Draft = false
+			a.config.Responses[i].Published = nil
+			count++
+		}
+	}
+	a.configMutex.Unlock()
+
+	if count > 0 {
+		a.commitEdit(edit)
+		appLog.Info("Published %d draft response(s)", count)
+		a.emitDraftChanges()
+	}
+	return count
+}
+
+// DiscardDrafts reverts all draft responses to their published version (drafts that were never
+// published are removed) and returns how many drafts were discarded
+func (a *App) DiscardDrafts() int {
+	edit := a.snapshotEdit("Discard drafts")
+	a.configMutex.Lock()
+	count := 0
+	a.forEachItemList(func(items []models.ResponseItem) []models.ResponseItem {
+		result := items[:0]
+		for _, item := range items {
+			if item.Response != nil && item.Response.Draft {
+				count++
+				if item.Response.Published == nil {
+					continue
+				}
+				*item.Response = *item.Response.Published
+			}
+			if item.Group != nil {
+				responses := item.Group.Responses[:0]
+				for _, resp := range item.Group.Responses {
+					if resp.Draft {
+						count++
+						if resp.Published == nil {
+							continue
+						}
+						resp = *resp.Published
+					}
+					responses = append(responses, resp)
+				}
+				item.Group.Responses = responses
+			}
+			result = append(result, item)
+		}
+		return result
+	})
+	responses := a.config.Responses[:0]
+	for _, resp := range a.config.Responses {
+		if resp.Draft {
+			count++
+			if resp.Published == nil {
+				continue
+			}
+			resp = *resp.Published
+		}
+		responses = append(responses, resp)
+	}
+	a.config.Responses = responses
+	a.configMutex.Unlock()
+
+	if count > 0 {
+		a.commitEdit(edit)
+		appLog.Info("Discarded %d draft response(s)", count)
+		a.emitDraftChanges()
+	}
+	return count
+}
+
+// GetDraftCount returns the number of responses with unpublished edits
+func (a *App) GetDraftCount() int {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	count := 0
+	countDrafts := func(resp *models.MethodResponse) {
+		if resp.Draft {
+			count++
+		}
+	}
+	walkResponses(a.config.Items, countDrafts)
+	for i := range a.config.Endpoints {
+		walkResponses(a.config.Endpoints[i].Items, countDrafts)
+		if versioning := a.config.Endpoints[i].Versioning; versioning != nil {
+			for j := range versioning.Versions {
+				walkResponses(versioning.Versions[j].Items, countDrafts)
+			}
+		}
+	}
+	for i := range a.config.Responses {
+		countDrafts(&a.config.Responses[i])
+	}
+	return count
+}
+
+// forEachItemList applies fn to every item list in the config (legacy items, endpoint items and
+// version items), replacing each list with the result. Caller must hold configMutex.
+func (a *App) forEachItemList(fn func([]models.ResponseItem) []models.ResponseItem) {
+	a.config.Items = fn(a.config.Items)
+	for i := range a.config.Endpoints {
+		endpoint := &a.config.Endpoints[i]
+		endpoint.Items = fn(endpoint.Items)
+		if endpoint.Versioning != nil {
+			for j := range endpoint.Versioning.Versions {
+				endpoint.Versioning.Versions[j].Items = fn(endpoint.Versioning.Versions[j].Items)
+			}
+		}
+	}
+}
+
+func (a *App) emitDraftChanges() {
+	// If server is running, update it
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	a.emit("responses:updated", a.config.Responses)
+	a.emit("items:updated", a.GetItems())
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:dirty", true)
+}
+
+// walkResponses calls fn for every response in the items, including grouped responses
+func walkResponses(items []models.ResponseItem, fn func(resp *models.MethodResponse)) {
+	for _, item := range items {
+		if item.Response != nil {
+			fn(item.Response)
+		}
+		if item.Group != nil {
+			for i := range item.Group.Responses {
+				fn(&item.Group.Responses[i])
+			}
+		}
+	}
+}
+
+// keepDraftBaselines records the previously published version of responses that became drafts,
+// so the server keeps serving it while the draft is edited
+func keepDraftBaselines(previous, items []models.ResponseItem) {
+	byID := make(map[string]*models.MethodResponse)
+	walkResponses(previous, func(resp *models.MethodResponse) {
+		byID[resp.ID] = resp
+	})
+
+	walkResponses(items, func(resp *models.MethodResponse) {
+		keepDraftBaseline(byID[resp.ID], resp)
+	})
+}
+
+// keepDraftBaseline records the previously published version of a response that replaces prev
+// (nil for a new response), so the server keeps serving it while the draft is edited
+func keepDraftBaseline(prev, resp *models.MethodResponse) {
+	if !resp.Draft {
+		resp.Published = nil
+		return
+	}
+	if resp.Published != nil || prev == nil {
+		return
+	}
+	if prev.Draft {
+		resp.Published = prev.Published
+		return
+	}
+	published := *prev
+	published.Draft = false
+	published.Published = nil
+	resp.Published = &published
+}
+
+// ========== Virtual Clock ==========
+
+// GetVirtualTime returns the current virtual time (RFC3339) used by time-dependent mock features
+func (a *App) GetVirtualTime() string {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.config.VirtualClock.Now().Format(time.RFC3339)
+}
+
+// SetVirtualClock replaces the virtual clock (nil restores real time)
+func (a *App) SetVirtualClock(clock *models.VirtualClock) error {
+	if clock != nil && clock.FrozenAt != "" {
+		if _, err := time.Parse(time.RFC3339, clock.FrozenAt); err != nil {
+			return fmt.Errorf("invalid frozen_at time: %v", err)
+		}
+	}
+
+	a.configMutex.Lock()
+	a.config.VirtualClock = clock
+	a.configMutex.Unlock()
+
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+	a.emit("config:dirty", true)
+	return nil
+}
+
+// AdvanceVirtualClock moves the virtual clock forward (or back, if negative) by the given seconds
+func (a *App) AdvanceVirtualClock(seconds int64) string {
+	a.configMutex.Lock()
+	clock := models.VirtualClock{}
+	if a.config.VirtualClock != nil {
+		clock = *a.config.VirtualClock
+	}
+	if clock.FrozenAt != "" {
+		clock.FrozenAt = clock.Now().Add(time.Duration(seconds) * time.Second).Format(time.RFC3339)
+	} else {
+		clock.OffsetSeconds += seconds
+	}
+	a.config.VirtualClock = &clock
+	now := clock.Now().Format(time.RFC3339)
+	a.configMutex.Unlock()
+
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+	a.emit("config:dirty", true)
+	return now
+}
+
+// ========== Response Sequences ==========
+
+// ResetSequences moves sequence responses back to their first step. An empty response ID resets
+// every sequence.
+func (a *App) ResetSequences(responseID string) {
+	if a.server != nil {
+		a.server.ResetSequences(responseID)
+	}
+}
+
+// ========== Performance Stats ==========
+
+// GetResponsePerfStats returns template/script execution time and error counts per response since
+// the server started, slowest (by total time) first
+func (a *App) GetResponsePerfStats() []models.ResponsePerfStats {
+	if a.server == nil {
+		return []models.ResponsePerfStats{}
+	}
+	stats := a.server.PerfStats().Snapshot()
+
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	type responseOwner struct {
+		endpoint *models.Endpoint
+		response *models.MethodResponse
+	}
+	owners := make(map[string]responseOwner)
+	for i := range a.config.Endpoints {
+		endpoint := &a.config.Endpoints[i]
+		record := func(resp *models.MethodResponse) {
+			owners[resp.ID] = responseOwner{endpoint: endpoint, response: resp}
+		}
+		walkResponses(endpoint.Items, record)
+		if endpoint.Versioning != nil {
+			for _, version := range endpoint.Versioning.Versions {
+				walkResponses(version.Items, record)
+			}
+		}
+	}
+	for i := range stats {
+		if owner, ok := owners[stats[i].ResponseID]; ok {
+			stats[i].EndpointID = owner.endpoint.ID
+			stats[i].EndpointName = owner.endpoint.Name
+			stats[i].PathPattern = owner.response.PathPattern
+			stats[i].Methods = owner.response.Methods
+		}
+	}
+	return stats
+}
+
+// ResetResponsePerfStats clears the template/script execution statistics
+func (a *App) ResetResponsePerfStats() {
+	if a.server != nil {
+		a.server.PerfStats().Reset()
+	}
+}
+
+// GetBypassRuleStats returns the connections and bytes each SOCKS5 bypass rule has tunneled
+func (a *App) GetBypassRuleStats() []models.BypassRuleStats {
+	if a.server == nil {
+		return []models.BypassRuleStats{}
+	}
+	return a.server.BypassStats().Snapshot()
+}
+
+// ResetBypassRuleStats clears the SOCKS5 bypass rule counters
+func (a *App) ResetBypassRuleStats() {
+	if a.server != nil {
+		a.server.BypassStats().Reset()
+	}
+}
+
+// GetTrafficStats returns the bytes carried by each SOCKS5 tunnel destination and proxy endpoint,
+// with the bandwidth over the last few seconds; most traffic first
+func (a *App) GetTrafficStats() []models.TrafficStats {
+	if a.server == nil {
+		return []models.TrafficStats{}
+	}
+	return a.server.Traffic().Snapshot()
+}
+
+// ResetTrafficStats clears the SOCKS5 tunnel and proxy endpoint traffic counters
+func (a *App) ResetTrafficStats() {
+	if a.server != nil {
+		a.server.Traffic().Reset()
+	}
+}
+
+// GetClientThrottles returns the per-client-IP throttle rules
+func (a *App) GetClientThrottles() []models.ClientThrottleRule {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	if a.config.ClientThrottles == nil {
+		return []models.ClientThrottleRule{}
+	}
+	return a.config.ClientThrottles
+}
+
+// SetClientThrottles replaces the per-client-IP throttle rules. Rules without an ID get one;
+// the first enabled rule matching a request's client and endpoint applies.
+func (a *App) SetClientThrottles(rules []models.ClientThrottleRule) error {
+	ids := make(map[string]bool)
+	for i := range rules {
+		rule := &rules[i]
+		if rule.ID == "" {
+			rule.ID = uuid.New().String()
+		}
+		if ids[rule.ID] {
+			return fmt.Errorf("duplicate throttle rule ID %q", rule.ID)
+		}
+		ids[rule.ID] = true
+		if rule.MaxRPS <= 0 {
+			return fmt.Errorf("throttle rule %s: max_rps must be greater than 0", rule.Name)
+		}
+		if rule.Burst < 0 || rule.PenaltyDelayMs < 0 {
+			return fmt.Errorf("throttle rule %s: burst and penalty delay cannot be negative", rule.Name)
+		}
+		if rule.Status != 0 && (rule.Status < 400 || rule.Status > 599) {
+			return fmt.Errorf("throttle rule %s: status must be a 4xx or 5xx code", rule.Name)
+		}
+		if source := rule.Source; source != "" && source != "*" && net.ParseIP(source) == nil {
+			if _, _, err := net.ParseCIDR(source); err != nil {
+				return fmt.Errorf("throttle rule %s: source %q is not an IP address or CIDR range", rule.Name, source)
+			}
+		}
+	}
+
+	a.configMutex.Lock()
+	endpointIDs := make(map[string]bool, len(a.config.Endpoints))
+	for _, endpoint := range a.config.Endpoints {
+		endpointIDs[endpoint.ID] = true
+	}
+	for _, rule := range rules {
+		if rule.EndpointID != "" && !endpointIDs[rule.EndpointID] {
+			a.configMutex.Unlock()
+			return fmt.Errorf("throttle rule %s: endpoint not found: %s", rule.Name, rule.EndpointID)
+		}
+	}
+	a.config.ClientThrottles = rules
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+	a.configMutex.Unlock()
+
+	a.emit("config:dirty", true)
+	return nil
+}
+
+// GetClientThrottleStats returns the live counters of every client the throttle rules track,
+// most throttled first
+func (a *App) GetClientThrottleStats() []models.ClientThrottleStats {
+	if a.server == nil {
+		return []models.ClientThrottleStats{}
+	}
+	return a.server.ClientThrottleStats()
+}
+
+// ResetClientThrottleStats forgets every tracked client, refilling their buckets
+func (a *App) ResetClientThrottleStats() {
+	if a.server != nil {
+		a.server.ClientThrottle().Reset()
+	}
+}
+
+// ResetState returns the running mock to a clean slate between test runs: it clears the request
+// logs and script errors and resets sequences, circuit breakers and every statistics counter. The
+// config is kept.
+func (a *App) ResetState() {
+	a.ClearRequestLogs()
+	a.ResetSequences("")
+	a.ResetResponsePerfStats()
+	a.ResetBypassRuleStats()
+	a.ResetTrafficStats()
+	a.ResetClientThrottleStats()
+	a.ResetCircuitBreaker("")
+
+	a.scriptErrorsMutex.Lock()
+	a.scriptErrors = make(map[string][]ScriptErrorLog)
+	a.scriptErrorsMutex.Unlock()
+	a.emit("state:reset", nil)
+}
+
+// ========== Scheduled Actions ==========
+
+// GetScheduledActions returns the actions scheduled at each server start
+func (a *App) GetScheduledActions() []models.ScheduledAction {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	if a.config.ScheduledActions == nil {
+		return []models.ScheduledAction{}
+	}
+	return a.config.ScheduledActions
+}
+
+// SetScheduledActions replaces the actions scheduled at server start (takes effect on the next start)
+func (a *App) SetScheduledActions(actions []models.ScheduledAction) error {
+	for i := range actions {
+		if err := server.ValidateScheduledAction(actions[i]); err != nil {
+			return fmt.Errorf("scheduled action %d: %v", i+1, err)
+		}
+		if actions[i].ID == "" {
+			actions[i].ID = uuid.New().String()
+		}
+	}
+
+	a.configMutex.Lock()
+	a.config.ScheduledActions = actions
+	a.configMutex.Unlock()
+
+	a.emit("config:dirty", true)
+	return nil
+}
+
+// GetSchedule returns the running server's schedule with due times and states
+func (a *App) GetSchedule() []models.ScheduledActionStatus {
+	if a.server == nil {
+		return []models.ScheduledActionStatus{}
+	}
+	return a.server.Scheduler().Status()
+}
+
+// ScheduleAction schedules an action on the running server, relative to now. It is not saved.
+func (a *App) ScheduleAction(action models.ScheduledAction) (*models.ScheduledActionStatus, error) {
+	if a.server == nil {
+		return nil, fmt.Errorf("server is not running")
+	}
+	status, err := a.server.Scheduler().Add(action)
+	if err != nil {
+		return nil, err
+	}
+	a.recordMacroStep(models.MacroStep{
+		Action:     action.Action,
+		After:      action.After,
+		ResponseID: action.ResponseID,
+		EndpointID: action.EndpointID,
+		StatusCode: action.StatusCode,
+		Body:       action.Body,
+	})
+	return &status, nil
+}
+
+// CancelScheduledAction cancels a pending action, or reverts one that already fired
+func (a *App) CancelScheduledAction(id string) error {
+	if a.server == nil {
+		return fmt.Errorf("server is not running")
+	}
+	return a.server.Scheduler().Cancel(id)
+}
+
+// ========== Script Modules ==========
+
+// GetScriptModules returns the shared JavaScript modules that scripts load with require()
+func (a *App) GetScriptModules() []models.ScriptModule {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	if a.config.ScriptModules == nil {
+		return []models.ScriptModule{}
+	}
+	return a.config.ScriptModules
+}
+
+// SetScriptModules replaces the shared script modules. Names must be unique and every module must compile.
+func (a *App) SetScriptModules(modules []models.ScriptModule) error {
+	if warnings := server.ValidateScriptModules(modules); len(warnings) > 0 {
+		return fmt.Errorf("%s", warnings[0].String())
+	}
+
+	a.configMutex.Lock()
+	a.config.ScriptModules = modules
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+	a.configMutex.Unlock()
+
+	a.emit("config:dirty", true)
+	return nil
+}
+
+// GetJWTKeys returns the named keys of the jwt helpers in templates and scripts
+func (a *App) GetJWTKeys() []models.JWTSigningKey {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	if a.config.JWTKeys == nil {
+		return []models.JWTSigningKey{}
+	}
+	return a.config.JWTKeys
+}
+
+// SetJWTKeys replaces the named JWT keys. Names must be unique and every key must parse.
+func (a *App) SetJWTKeys(keys []models.JWTSigningKey) error {
+	if err := server.ValidateJWTKeys(keys); err != nil {
+		return err
+	}
+
+	a.configMutex.Lock()
+	a.config.JWTKeys = keys
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+	a.configMutex.Unlock()
+
+	a.emit("config:dirty", true)
+	return nil
+}
+
+// GetScriptFetchConfig returns the http.fetch settings of script-mode responses
+func (a *App) GetScriptFetchConfig() *models.ScriptFetchConfig {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	if a.config.ScriptFetch == nil {
+		return &models.ScriptFetchConfig{}
+	}
+	return a.config.ScriptFetch
+}
+
+// SetScriptFetchConfig updates the http.fetch settings of script-mode responses
+func (a *App) SetScriptFetchConfig(cfg models.ScriptFetchConfig) error {
+	if cfg.TimeoutMs < 0 || cfg.MaxResponseBytes < 0 {
+		return fmt.Errorf("timeout and maximum response size cannot be negative")
+	}
+	for _, host := range cfg.AllowedHosts {
+		if strings.TrimSpace(host) == "" || strings.Contains(host, "/") {
+			return fmt.Errorf("invalid allowed host %q (use a host name, host:port or *.domain)", host)
+		}
+	}
+
+	a.configMutex.Lock()
+	a.config.ScriptFetch = &cfg
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+	a.configMutex.Unlock()
+
+	a.emit("config:dirty", true)
+	return nil
+}
+
+// ========== Macros ==========
+
+// StartMacroRecording starts recording state changes (scheduled actions, offline mode and environment
+// switches) into a macro. Recording again under an existing name replaces that macro when stopped.
+func (a *App) StartMacroRecording(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("macro name is required")
+	}
+
+	a.macroMutex.Lock()
+	defer a.macroMutex.Unlock()
+	if a.macroRecording != nil {
+		return fmt.Errorf("already recording macro %q", a.macroRecording.Name)
+	}
+	a.macroRecording = &models.Macro{Name: name, RecordedAt: time.Now().Format(time.RFC3339), Steps: []models.MacroStep{}}
+	a.macroLastStep = time.Now()
+	appLog.Info("Recording macro %q", name)
+	return nil
+}
+
+// StopMacroRecording ends the recording and saves the macro to the config
+func (a *App) StopMacroRecording() (*models.Macro, error) {
+	a.macroMutex.Lock()
+	macro := a.macroRecording
+	a.macroRecording = nil
+	a.macroMutex.Unlock()
+	if macro == nil {
+		return nil, fmt.Errorf("no macro is being recorded")
+	}
+
+	a.configMutex.Lock()
+	replaced := false
+	for i := range a.config.Macros {
+		if a.config.Macros[i].Name == macro.Name {
+			a.config.Macros[i] = *macro
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		a.config.Macros = append(a.config.Macros, *macro)
+	}
+	a.configMutex.Unlock()
+
+	appLog.Info("Recorded macro %q with %d steps", macro.Name, len(macro.Steps))
+	a.emit("config:dirty", true)
+	return macro, nil
+}
+
+// GetMacroRecording returns the name of the macro being recorded ("" when not recording)
+func (a *App) GetMacroRecording() string {
+	a.macroMutex.Lock()
+	defer a.macroMutex.Unlock()
+	if a.macroRecording == nil {
+		return ""
+	}
+	return a.macroRecording.Name
+}
+
+// GetMacros returns the recorded macros
+func (a *App) GetMacros() []models.Macro {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	if a.config.Macros == nil {
+		return []models.Macro{}
+	}
+	return a.config.Macros
+}
+
+// DeleteMacro removes a macro by name
+func (a *App) DeleteMacro(name string) error {
+	a.configMutex.Lock()
+	defer a.configMutex.Unlock()
+	for i := range a.config.Macros {
+		if a.config.Macros[i].Name == name {
+			a.config.Macros = append(a.config.Macros[:i], a.config.Macros[i+1:]...)
+			a.emit("config:dirty", true)
+			return nil
+		}
+	}
+	return fmt.Errorf("macro not found: %s", name)
+}
+
+// RunMacro plays a macro back in the background, waiting each step's recorded delay.
+// Scheduled steps become runtime overrides on the running server, like ScheduleAction.
+func (a *App) RunMacro(name string) error {
+	a.configMutex.RLock()
+	var macro *models.Macro
+	for i := range a.config.Macros {
+		if a.config.Macros[i].Name == name {
+			copied := a.config.Macros[i]
+			macro = &copied
+			break
+		}
+	}
+	a.configMutex.RUnlock()
+	if macro == nil {
+		return fmt.Errorf("macro not found: %s", name)
+	}
+
+	delays := make([]time.Duration, len(macro.Steps))
+	for i, step := range macro.Steps {
+		delay, err := parseMacroDelay(step.Delay)
+		if err != nil {
+			return fmt.Errorf("macro step %d: %v", i+1, err)
+		}
+		delays[i] = delay
+		if isMacroScheduleStep(step) {
+			if a.server == nil {
+				return fmt.Errorf("server is not running")
+			}
+			if err := server.ValidateScheduledAction(macroStepAction(macro.Name, step)); err != nil {
+				return fmt.Errorf("macro step %d: %v", i+1, err)
+			}
+		}
+	}
+
+	go func() {
+		appLog.Info("Running macro %q (%d steps)", macro.Name, len(macro.Steps))
+		for i, step := range macro.Steps {
+			time.Sleep(delays[i])
+			progress := map[string]interface{}{
+				"name":   macro.Name,
+				"step":   i + 1,
+				"steps":  len(macro.Steps),
+				"action": step.Action,
+			}
+			if err := a.runMacroStep(macro.Name, step); err != nil {
+				appLog.Error("Macro %q step %d (%s) failed: %v", macro.Name, i+1, step.Action, err)
+				progress["error"] = err.Error()
+			}
+			a.emit("macro:progress", progress)
+		}
+		appLog.Info("Macro %q finished", macro.Name)
+	}()
+	return nil
+}
+
+// runMacroStep applies one step of a macro
+func (a *App) runMacroStep(macroName string, step models.MacroStep) error {
+	switch step.Action {
+	case models.MacroActionOfflineMode:
+		_, err := a.SetOfflineMode(step.Enabled)
+		return err
+	case models.MacroActionEnvironment:
+		return a.SetActiveEnvironment(step.Value)
+	default:
+		_, err := a.ScheduleAction(macroStepAction(macroName, step))
+		return err
+	}
+}
+
+// recordMacroStep appends a step to the macro being recorded, if any
+func (a *App) recordMacroStep(step models.MacroStep) {
+	a.macroMutex.Lock()
+	defer a.macroMutex.Unlock()
+	if a.macroRecording == nil {
+		return
+	}
+	now := time.Now()
+	if elapsed := now.Sub(a.macroLastStep).Round(100 * time.Millisecond); elapsed > 0 {
+		step.Delay = elapsed.String()
+	}
+	a.macroLastStep = now
+	a.macroRecording.Steps = append(a.macroRecording.Steps, step)
+}
+
+// isMacroScheduleStep reports whether a step is applied through the scheduler
+func isMacroScheduleStep(step models.MacroStep) bool {
+	return step.Action != models.MacroActionOfflineMode && step.Action != models.MacroActionEnvironment
+}
+
+// macroStepAction converts a scheduled macro step to a scheduler action (a fresh ID is assigned when added)
+func macroStepAction(macroName string, step models.MacroStep) models.ScheduledAction {
+	return models.ScheduledAction{
+		Name:       "macro " + macroName,
+		After:      step.After,
+		Action:     step.Action,
+		ResponseID: step.ResponseID,
+		EndpointID: step.EndpointID,
+		StatusCode: step.StatusCode,
+		Body:       step.Body,
+	}
+}
+
+// parseMacroDelay parses a step delay; empty means no wait
+func parseMacroDelay(delay string) (time.Duration, error) {
+	if delay == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(delay)
+	if err != nil {
+		return 0, fmt.Errorf("invalid delay %q: %v", delay, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid delay %q: must not be negative", delay)
+	}
+	return d, nil
+}
+
+// ========== Event Bus ==========
+
+// EmitEvent publishes an event to open event-stream responses, as a script's events.emit would.
+// A payload that parses as JSON is sent as that value, anything else as a string. Returns the
+// number of streams that received the event.
+func (a *App) EmitEvent(name string, payload string) (int, error) {
+	if name == "" {
+		return 0, fmt.Errorf("event name is required")
+	}
+	if a.server == nil {
+		return 0, fmt.Errorf("server is not running")
+	}
+
+	var value interface{} = payload
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(payload), &parsed); err == nil {
+		value = parsed
+	}
+	return a.server.PublishEvent(name, value), nil
+}
+
+// ========== gRPC ==========
+
+// GetGRPCConfig returns the gRPC listener configuration (nil if never configured)
+func (a *App) GetGRPCConfig() *models.GRPCConfig {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.config.GRPC
+}
+
+// SetGRPCConfig validates the .proto files, stores the gRPC configuration and restarts the
+// gRPC listener if the server is running. Returns the methods described by the protos.
+func (a *App) SetGRPCConfig(cfg models.GRPCConfig) ([]models.GRPCMethodInfo, error) {
+	var methods []models.GRPCMethodInfo
+	if cfg.Enabled || len(cfg.ProtoFiles) > 0 {
+		var err error
+		methods, err = server.ListGRPCMethods(&cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range cfg.Methods {
+		if cfg.Methods[i].ID == "" {
+			cfg.Methods[i].ID = uuid.New().String()
+		}
+	}
+
+	a.configMutex.Lock()
+	a.config.GRPC = &cfg
+	a.configMutex.Unlock()
+
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+		if err := a.server.RestartGRPC(); err != nil {
+			return methods, fmt.Errorf("failed to restart gRPC server: %v", err)
+		}
+	}
+	a.emit("config:dirty", true)
+	return methods, nil
+}
+
+// GetGRPCMethods lists the methods described by the configured .proto files
+func (a *App) GetGRPCMethods() ([]models.GRPCMethodInfo, error) {
+	a.configMutex.RLock()
+	cfg := a.config.GRPC
+	a.configMutex.RUnlock()
+
+	if cfg == nil || len(cfg.ProtoFiles) == 0 {
+		return []models.GRPCMethodInfo{}, nil
+	}
+	return server.ListGRPCMethods(cfg)
+}
+
+// ========== DNS ==========
+
+// GetDNSConfig returns the DNS listener configuration (nil if never configured)
+func (a *App) GetDNSConfig() *models.DNSConfig {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.config.DNS
+}
+
+// SetDNSConfig validates and stores the DNS configuration and restarts the DNS listener
+// if the server is running
+func (a *App) SetDNSConfig(cfg models.DNSConfig) error {
+	for i := range cfg.Records {
+		record := &cfg.Records[i]
+		if record.ID == "" {
+			record.ID = uuid.New().String()
+		}
+		if err := validateDNSRecord(record); err != nil {
+			return err
+		}
+	}
+	if cfg.MockAddress != "" && net.ParseIP(cfg.MockAddress).To4() == nil {
+		return fmt.Errorf("mock address %q is not an IPv4 address", cfg.MockAddress)
+	}
+	if cfg.MockAddressV6 != "" && net.ParseIP(cfg.MockAddressV6) == nil {
+		return fmt.Errorf("mock IPv6 address %q is not an IP address", cfg.MockAddressV6)
+	}
+
+	a.configMutex.Lock()
+	a.config.DNS = &cfg
+	a.configMutex.Unlock()
+
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+		if err := a.server.RestartDNS(); err != nil {
+			return fmt.Errorf("failed to restart DNS server: %v", err)
+		}
+	}
+	a.emit("config:dirty", true)
+	return nil
+}
+
+// validateDNSRecord normalizes the record type and checks the value fits it
+func validateDNSRecord(record *models.DNSRecord) error {
+	record.Type = strings.ToUpper(record.Type)
+	if record.Name == "" {
+		return fmt.Errorf("DNS record needs a name")
+	}
+	switch record.Type {
+	case models.DNSRecordA:
+		if record.Value != "" && net.ParseIP(record.Value).To4() == nil {
+			return fmt.Errorf("DNS record %s: %q is not an IPv4 address", record.Name, record.Value)
+		}
+	case models.DNSRecordAAAA:
+		if record.Value != "" && net.ParseIP(record.Value) == nil {
+			return fmt.Errorf("DNS record %s: %q is not an IP address", record.Name, record.Value)
+		}
+	case models.DNSRecordCNAME:
+		if record.Value == "" {
+			return fmt.Errorf("DNS record %s: CNAME needs a target", record.Name)
+		}
+	default:
+		return fmt.Errorf("DNS record %s: unsupported type %q", record.Name, record.Type)
+	}
+	return nil
+}
+
+// ========== PAC File ==========
+
+// GetPACURL returns the URL of the proxy auto-config file on this machine's network address,
+// for pointing browsers and devices at the proxies for the taken-over domains only
+func (a *App) GetPACURL() string {
+	a.configMutex.RLock()
+	port := a.config.Port
+	a.configMutex.RUnlock()
+	return fmt.Sprintf("http://%s%s", net.JoinHostPort(server.DefaultPACHost(), strconv.Itoa(port)), models.ReservedPathPAC)
+}
+
+// GetPACFile returns the proxy auto-config file as served at GetPACURL, for saving to disk
+func (a *App) GetPACFile() string {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return server.BuildPACFile(a.config.DomainTakeover, a.config.SOCKS5Config, a.config.HTTPProxy, server.DefaultPACHost())
+}
+
+// ========== Environments ==========
+
+// GetEnvironments returns the environment names defined by any proxy endpoint, sorted
+func (a *App) GetEnvironments() []string {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.environmentNames()
+}
+
+// environmentNames collects the proxy environment names (caller must hold configMutex)
+func (a *App) environmentNames() []string {
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, endpoint := range a.config.Endpoints {
+		if endpoint.Type != models.EndpointTypeProxy || endpoint.ProxyConfig == nil {
+			continue
+		}
+		for name := range endpoint.ProxyConfig.Environments {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetActiveEnvironment returns the environment proxy endpoints currently use (empty = backend_url)
+func (a *App) GetActiveEnvironment() string {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.config.ActiveEnvironment
+}
+
+// SetActiveEnvironment switches every proxy endpoint to its backend URL for the named environment.
+// Proxies without a URL for it keep their backend_url. An empty name switches all back to backend_url.
+func (a *App) SetActiveEnvironment(name string) error {
+	a.configMutex.Lock()
+	if name != "" {
+		known := false
+		for _, existing := range a.environmentNames() {
+			if existing == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			a.configMutex.Unlock()
+			return fmt.Errorf("no proxy endpoint defines environment %q", name)
+		}
+	}
+	a.config.ActiveEnvironment = name
+	a.configMutex.Unlock()
+
+	// The proxy handler is shared, so switch it even if the server is not running
+	a.proxyHandler.SetActiveEnvironment(name)
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	appLog.Info("Active environment: %q", name)
+	a.recordMacroStep(models.MacroStep{Action: models.MacroActionEnvironment, Value: name})
+	a.emit("environment:changed", name)
+	a.emit("config:dirty", true)
+	return nil
+}
+
+// ========== Variables and Profiles ==========
+
+// GetVariables returns the config variables that ${NAME} references in backend URLs, headers,
+// bodies and container environments resolve to
+func (a *App) GetVariables() map[string]string {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.config.Variables
+}
+
+// SetVariables replaces the config variables. Names not defined here or in the active profile are
+// looked up in the OS environment.
+func (a *App) SetVariables(variables map[string]string) error {
+	if err := server.ValidateVariables(variables); err != nil {
+		return err
+	}
+	a.configMutex.Lock()
+	a.config.Variables = variables
+	a.configMutex.Unlock()
+
+	a.applyVariables()
+	return nil
+}
+
+// GetProfiles returns the named variable sets of the config
+func (a *App) GetProfiles() map[string]map[string]string {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.config.Profiles
+}
+
+// SetProfile creates or replaces a profile; its variables override the config variables while it
+// is active
+func (a *App) SetProfile(name string, variables map[string]string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	if err := server.ValidateVariables(variables); err != nil {
+		return fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	a.configMutex.Lock()
+	profiles := make(map[string]map[string]string, len(a.config.Profiles)+1)
+	for existing, values := range a.config.Profiles {
+		profiles[existing] = values
+	}
+	profiles[name] = variables
+	a.config.Profiles = profiles
+	a.configMutex.Unlock()
+
+	a.applyVariables()
+	return nil
+}
+
+// DeleteProfile removes a profile; deleting the active profile deactivates it
+func (a *App) DeleteProfile(name string) error {
+	a.configMutex.Lock()
+	if _, ok := a.config.Profiles[name]; !ok {
+		a.configMutex.Unlock()
+		return fmt.Errorf("profile %q not found", name)
+	}
+	profiles := make(map[string]map[string]string, len(a.config.Profiles))
+	for existing, values := range a.config.Profiles {
+		if existing != name {
+			profiles[existing] = values
+		}
+	}
+	a.config.Profiles = profiles
+	deactivated := a.config.ActiveProfile == name
+	if deactivated {
+		a.config.ActiveProfile = ""
+	}
+	a.configMutex.Unlock()
+
+	a.applyVariables()
+	if deactivated {
+		a.emit("profile:changed", "")
+	}
+	return nil
 }
 
-// UpdateRequestLog updates an existing request log (used for two-phase logging)
-// This allows showing pending requests immediately, then updating them when complete
-func (a *App) UpdateRequestLog(log models.RequestLog) {
-	a.logMutex.Lock()
+// GetActiveProfile returns the profile whose variables are in use (empty = config variables only)
+func (a *App) GetActiveProfile() string {
+	a.configMutex.RLock()
+	defer a.configMutex.RUnlock()
+	return a.config.ActiveProfile
+}
 
-	// Find and update the existing log
-	found := false
-	for i := range a.requestLogs {
-		if a.requestLogs[i].ID == log.ID {
-			a.requestLogs[i] = log
-			found = true
-			break
+// SetActiveProfile selects the profile whose variables are used, e.g. before starting the server.
+// A running server switches right away. An empty name uses the config variables only.
+func (a *App) SetActiveProfile(name string) error {
+	a.configMutex.Lock()
+	if _, ok := a.config.Profiles[name]; name != "" && !ok {
+		a.configMutex.Unlock()
+		return fmt.Errorf("profile %q not found", name)
+	}
+	a.config.ActiveProfile = name
+	a.configMutex.Unlock()
+
+	a.applyVariables()
+	appLog.Info("Active profile: %q", name)
+	a.emit("profile:changed", name)
+	return nil
+}
+
+// applyVariables passes changed variables or profiles on to the proxy handler and running server
+func (a *App) applyVariables() {
+	a.configMutex.RLock()
+	variables := server.ConfigVariables(a.config)
+	a.configMutex.RUnlock()
+
+	// The proxy handler is shared, so update it even if the server is not running
+	a.proxyHandler.SetVariables(variables)
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+	a.emit("config:dirty", true)
+}
+
+// ========== Config Storage ==========
+
+// getStorageSettingsPath returns the path to the storage settings JSON file
+func (a *App) getStorageSettingsPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		appLog.Error("Failed to get home directory: %v", err)
+		return ""
+	}
+	return filepath.Join(homeDir, ".mockelot", "storage.json")
+}
+
+// loadStorageSettings selects the storage backend saved in the settings file (local files if none)
+func (a *App) loadStorageSettings() {
+	settingsPath := a.getStorageSettingsPath()
+	if settingsPath == "" {
+		return
+	}
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			appLog.Error("Failed to read storage settings: %v", err)
 		}
+		return
 	}
 
-	// If not found, just append it (fallback behavior)
-	if !found {
-		a.requestLogs = append(a.requestLogs, log)
+	var settings models.StorageSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		appLog.Error("Failed to parse storage settings: %v", err)
+		return
 	}
+	backend, err := storage.New(settings)
+	if err != nil {
+		appLog.Warn("Invalid storage settings, using local files: %v", err)
+		return
+	}
+	a.storage = backend
+	a.storageSettings = settings
+}
 
-	a.logMutex.Unlock()
+// GetStorageSettings returns the selected config storage backend
+func (a *App) GetStorageSettings() models.StorageSettings {
+	return a.storageSettings
+}
 
-	// Create updated summary for frontend
-	summary := models.RequestLogSummary{
-		ID:         log.ID,
-		Timestamp:  log.Timestamp,
-		EndpointID: log.EndpointID,
-		Method:     log.ClientRequest.Method,
-		Path:       log.ClientRequest.Path,
-		SourceIP:   log.ClientRequest.SourceIP,
-		ClientStatus: log.ClientResponse.StatusCode,
-		ClientRTT:  log.ClientResponse.RTTMs,
-		HasBackend: log.BackendRequest != nil || log.BackendResponse != nil,
-		ClientBodySize: len(log.ClientRequest.Body),
-		Pending:    false, // Update means request is complete
-		ValidationFailed: log.ValidationFailed,
-		ResponseFailed:   log.ResponseFailed,
+// SetStorageSettings selects where configs are loaded from and saved to and remembers the choice
+func (a *App) SetStorageSettings(settings models.StorageSettings) error {
+	if settings.Backend == "" {
+		settings.Backend = models.StorageBackendFile
+	}
+	backend, err := storage.New(settings)
+	if err != nil {
+		return err
 	}
 
-	// Add backend info if present
-	if log.BackendResponse != nil {
-		summary.BackendStatus = log.BackendResponse.StatusCode
-		summary.BackendRTT = log.BackendResponse.RTTMs
+	settingsPath := a.getStorageSettingsPath()
+	if settingsPath == "" {
+		return fmt.Errorf("failed to get storage settings path")
+	}
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal storage settings: %v", err)
+	}
+	// Settings may contain credentials
+	if err := os.WriteFile(settingsPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write storage settings: %v", err)
 	}
 
-	// Queue updated summary
-	a.requestLogQueueMutex.Lock()
-	a.requestLogSummaryQueue = append(a.requestLogSummaryQueue, summary)
-	a.requestLogQueueMutex.Unlock()
+	a.storage = backend
+	a.storageSettings = settings
+	return nil
 }
 
-// GetRequestLogDetails returns the full RequestLog details for a given ID
-func (a *App) GetRequestLogDetails(id string) (*models.RequestLog, error) {
-	a.logMutex.RLock()
-	defer a.logMutex.RUnlock()
+// ========== Admin API ==========
 
-	for i := range a.requestLogs {
-		if a.requestLogs[i].ID == id {
-			return &a.requestLogs[i], nil
+// getAdminAPISettingsPath returns the path to the admin API settings JSON file
+func (a *App) getAdminAPISettingsPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		appLog.Error("Failed to get home directory: %v", err)
+		return ""
+	}
+	return filepath.Join(homeDir, ".mockelot", "admin-api.json")
+}
+
+// loadAdminAPISettings reads the admin API settings and starts the listener if it is enabled
+func (a *App) loadAdminAPISettings() {
+	settingsPath := a.getAdminAPISettingsPath()
+	if settingsPath == "" {
+		return
+	}
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			appLog.Error("Failed to read admin API settings: %v", err)
 		}
+		return
 	}
 
-	return nil, fmt.Errorf("request log with ID %s not found", id)
+	var settings models.AdminAPISettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		appLog.Error("Failed to parse admin API settings: %v", err)
+		return
+	}
+
+	a.adminMutex.Lock()
+	defer a.adminMutex.Unlock()
+	a.adminSettings = settings
+	if settings.Enabled {
+		if err := a.startAdminAPI(); err != nil {
+			appLog.Error("Failed to start admin API: %v", err)
+		}
+	}
 }
 
-// PollRequestLogs returns all queued request log summaries and clears the queue
-// This is called by the frontend at regular intervals (polling) for efficient batching
-// during high-volume traffic
-func (a *App) PollRequestLogs() []models.RequestLogSummary {
-	a.requestLogQueueMutex.Lock()
-	defer a.requestLogQueueMutex.Unlock()
+// GetAdminAPISettings returns the admin API settings, including the token clients must send
+func (a *App) GetAdminAPISettings() models.AdminAPISettings {
+	a.adminMutex.Lock()
+	defer a.adminMutex.Unlock()
+	return a.adminSettings
+}
 
-	// Get current summaries
-	summaries := a.requestLogSummaryQueue
+// SetAdminAPISettings saves the admin API settings and starts, restarts or stops the listener.
+// An empty token is replaced with a random one.
+func (a *App) SetAdminAPISettings(settings models.AdminAPISettings) (models.AdminAPISettings, error) {
+	if settings.Port < 0 || settings.Port > 65535 {
+		return settings, fmt.Errorf("invalid admin API port %d", settings.Port)
+	}
+	if settings.Token == "" {
+		token, err := generateAdminToken()
+		if err != nil {
+			return settings, err
+		}
+		settings.Token = token
+	}
 
-	// Clear the queue
-	a.requestLogSummaryQueue = make([]models.RequestLogSummary, 0)
+	settingsPath := a.getAdminAPISettingsPath()
+	if settingsPath == "" {
+		return settings, fmt.Errorf("failed to get admin API settings path")
+	}
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+		return settings, fmt.Errorf("failed to create config directory: %v", err)
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return settings, fmt.Errorf("failed to marshal admin API settings: %v", err)
+	}
+	// The token grants full control of the app
+	if err := os.WriteFile(settingsPath, data, 0600); err != nil {
+		return settings, fmt.Errorf("failed to write admin API settings: %v", err)
+	}
 
-	return summaries
+	a.adminMutex.Lock()
+	defer a.adminMutex.Unlock()
+	a.stopAdminAPILocked()
+	a.adminSettings = settings
+	if settings.Enabled {
+		if err := a.startAdminAPI(); err != nil {
+			return settings, err
+		}
+	}
+	return settings, nil
+}
+
+// generateAdminToken returns a random admin API token
+func generateAdminToken() (string, error) {
+	token := make([]byte, 24)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate token: %v", err)
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// startAdminAPI starts the admin listener on the loopback interface (adminMutex must be held)
+func (a *App) startAdminAPI() error {
+	port := a.adminSettings.Port
+	if port == 0 {
+		port = models.DefaultAdminAPIPort
+	}
+
+	mux := http.NewServeMux()
+	handler := adminapi.NewHandler(a, a.adminSettings.Token)
+	mux.Handle(adminapi.RPCPath, handler)
+	mux.Handle(strings.TrimSuffix(adminapi.RPCPath, "/"), handler)
+	mux.HandleFunc(adminapi.RESTPath, handler.ServeREST)
+	if a.adminSettings.Profiling {
+		mux.Handle(adminapi.ProfilingPath, adminapi.NewProfilingHandler(a.adminSettings.Token))
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on admin API port %d: %v", port, err)
+	}
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	a.adminServer = srv
+	go func() {
+		appLog.Info("Admin API listening on 127.0.0.1:%d", port)
+		if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			appLog.Error("Admin API error: %v", err)
+		}
+	}()
+	return nil
+}
+
+// stopAdminAPI shuts the admin listener down
+func (a *App) stopAdminAPI() {
+	a.adminMutex.Lock()
+	defer a.adminMutex.Unlock()
+	a.stopAdminAPILocked()
+}
+
+func (a *App) stopAdminAPILocked() {
+	if a.adminServer == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := a.adminServer.Shutdown(ctx); err != nil {
+		appLog.Error("Admin API shutdown error: %v", err)
+	}
+	a.adminServer = nil
+	appLog.Info("Admin API stopped")
+}
+
+// ========== Runtime Health ==========
+
+// GetRuntimeHealth returns the goroutine and memory samples, any leak warnings and the largest
+// groups of goroutines sharing a stack
+func (a *App) GetRuntimeHealth() models.RuntimeHealth {
+	report := a.leakWatcher.Report()
+	report.Profiling = a.GetAdminAPISettings().Profiling
+	return report
+}
+
+// reportRuntimeWarning logs sustained goroutine or heap growth and forwards it to the frontend
+func (a *App) reportRuntimeWarning(warning models.RuntimeWarning) {
+	appLog.Warn("Possible leak: %s", warning.Message)
+	a.emit("runtime:warning", warning)
+}
+
+// ========== Application Log ==========
+
+// getLogSettingsPath returns the path to the application log settings JSON file
+func (a *App) getLogSettingsPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		appLog.Error("Failed to get home directory: %v", err)
+		return ""
+	}
+	return filepath.Join(homeDir, ".mockelot", "logging.json")
+}
+
+// loadLogSettings reads the application log settings and applies them
+func (a *App) loadLogSettings() {
+	settingsPath := a.getLogSettingsPath()
+	if settingsPath == "" {
+		return
+	}
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			appLog.Error("Failed to read log settings: %v", err)
+		}
+		return
+	}
+
+	var settings models.LogSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		appLog.Error("Failed to parse log settings: %v", err)
+		return
+	}
+	if err := applyLogSettings(settings); err != nil {
+		appLog.Warn("Invalid log settings, using defaults: %v", err)
+		return
+	}
+	a.logSettingsMutex.Lock()
+	a.logSettings = settings
+	a.logSettingsMutex.Unlock()
+}
+
+// applyLogSettings sets the level and file of the application log
+func applyLogSettings(settings models.LogSettings) error {
+	level, err := logger.ParseLevel(settings.Level)
+	if err != nil {
+		return err
+	}
+	if err := logger.Default.SetFile(settings.File, settings.MaxSizeMB, settings.MaxBackups); err != nil {
+		return err
+	}
+	logger.Default.SetMinLevel(level)
+	return nil
+}
+
+// GetLogSettings returns the application log level and file settings
+func (a *App) GetLogSettings() models.LogSettings {
+	a.logSettingsMutex.Lock()
+	defer a.logSettingsMutex.Unlock()
+	return a.logSettings
+}
+
+// SetLogSettings applies and saves the application log level and file settings
+func (a *App) SetLogSettings(settings models.LogSettings) error {
+	if settings.MaxSizeMB < 0 || settings.MaxBackups < 0 {
+		return fmt.Errorf("log file size and backup count must not be negative")
+	}
+	if err := applyLogSettings(settings); err != nil {
+		return err
+	}
+
+	settingsPath := a.getLogSettingsPath()
+	if settingsPath == "" {
+		return fmt.Errorf("failed to get log settings path")
+	}
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal log settings: %v", err)
+	}
+	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write log settings: %v", err)
+	}
+
+	a.logSettingsMutex.Lock()
+	a.logSettings = settings
+	a.logSettingsMutex.Unlock()
+	return nil
+}
+
+// GetAppLogs returns application log entries at or above level (debug, info, warn, error; empty =
+// all), optionally for one subsystem (app, server, proxy, container, socks5, dns, storage) and
+// after since (RFC3339), oldest first
+func (a *App) GetAppLogs(level string, subsystem string, since string) ([]models.AppLogEntry, error) {
+	minLevel := logger.DEBUG
+	if level != "" {
+		var err error
+		if minLevel, err = logger.ParseLevel(level); err != nil {
+			return nil, err
+		}
+	}
+	var sinceTime time.Time
+	if since != "" {
+		var err error
+		if sinceTime, err = time.Parse(time.RFC3339Nano, since); err != nil {
+			return nil, fmt.Errorf("invalid since time %q (expected RFC3339)", since)
+		}
+	}
+	return logger.Default.Entries(minLevel, subsystem, sinceTime), nil
+}
+
+// ClearAppLogs clears the application log entries kept in memory (the log file is kept)
+func (a *App) ClearAppLogs() {
+	logger.Default.Clear()
 }
 
 // ========== Script Error Management ==========
@@ -3061,7 +7050,7 @@ func (a *App) LogScriptError(responseID, path, method, errorMsg string) {
 	a.scriptErrorsMutex.Lock()
 	defer a.scriptErrorsMutex.Unlock()
 
-	log.Printf("LogScriptError called: responseID=%s, path=%s, method=%s, error=%s", responseID, path, method, errorMsg)
+	appLog.Debug("LogScriptError called: responseID=%s, path=%s, method=%s, error=%s", responseID, path, method, errorMsg)
 
 	errorLog := ScriptErrorLog{
 		Timestamp:  time.Now(),
@@ -3090,8 +7079,8 @@ func (a *App) LogScriptError(responseID, path, method, errorMsg string) {
 		"error":       errorMsg,
 		"timestamp":   errorLog.Timestamp.Format(time.RFC3339),
 	}
-	log.Printf("Emitting script:error event with data: %+v", eventData)
-	runtime.EventsEmit(a.ctx, "script:error", eventData)
+	appLog.Debug("Emitting script:error event with data: %+v", eventData)
+	a.emit("script:error", eventData)
 }
 
 // GetScriptErrors returns all script errors for a given response ID
@@ -3116,7 +7105,7 @@ func (a *App) ClearScriptErrors(responseID string) {
 	delete(a.scriptErrors, responseID)
 
 	// Emit event to frontend via Wails runtime (not polling queue)
-	runtime.EventsEmit(a.ctx, "script:error:cleared", map[string]interface{}{
+	a.emit("script:error:cleared", map[string]interface{}{
 		"response_id": responseID,
 	})
 }
@@ -3168,7 +7157,8 @@ func (a *App) configsEqual(c1, c2 *models.AppConfig) bool {
 
 	// Compare cert paths/names
 	if !certPathsEqual(c1.CertPaths, c2.CertPaths) ||
-		!stringSlicesEqual(c1.CertNames, c2.CertNames) {
+		!stringSlicesEqual(c1.CertNames, c2.CertNames) ||
+		!jsonEqual(c1.ClientAuth, c2.ClientAuth) {
 		return false
 	}
 
@@ -3177,16 +7167,90 @@ func (a *App) configsEqual(c1, c2 *models.AppConfig) bool {
 		return false
 	}
 
+	// Compare request limits and virtual clock
+	if !jsonEqual(c1.Limits, c2.Limits) || !jsonEqual(c1.VirtualClock, c2.VirtualClock) {
+		return false
+	}
+	if c1.OfflineMode != c2.OfflineMode || c1.ActiveEnvironment != c2.ActiveEnvironment {
+		return false
+	}
+	if c1.ActiveProfile != c2.ActiveProfile || !jsonEqual(c1.Variables, c2.Variables) || !jsonEqual(c1.Profiles, c2.Profiles) {
+		return false
+	}
+
 	// Compare SOCKS5
 	if !socks5ConfigEqual(c1.SOCKS5Config, c2.SOCKS5Config) {
 		return false
 	}
+	if !jsonEqual(c1.HTTPProxy, c2.HTTPProxy) {
+		return false
+	}
+
+	// Compare gRPC listener and method responses
+	if !jsonEqual(c1.GRPC, c2.GRPC) {
+		return false
+	}
+
+	// Compare DNS listener and records
+	if !jsonEqual(c1.DNS, c2.DNS) {
+		return false
+	}
+
+	// Compare scheduled actions
+	if !jsonEqual(c1.ScheduledActions, c2.ScheduledActions) {
+		return false
+	}
+
+	// Compare macros
+	if !jsonEqual(c1.Macros, c2.Macros) {
+		return false
+	}
+
+	// Compare script modules
+	if !jsonEqual(c1.ScriptModules, c2.ScriptModules) {
+		return false
+	}
+
+	// Compare script fetch settings
+	if !jsonEqual(c1.ScriptFetch, c2.ScriptFetch) {
+		return false
+	}
+
+	// Compare named listeners
+	if !jsonEqual(c1.Listeners, c2.Listeners) {
+		return false
+	}
+
+	// Compare client throttles
+	if !jsonEqual(c1.ClientThrottles, c2.ClientThrottles) {
+		return false
+	}
+
+	// Compare JWT keys
+	if !jsonEqual(c1.JWTKeys, c2.JWTKeys) {
+		return false
+	}
 
 	// Compare DomainTakeover
 	if !domainTakeoverEqual(c1.DomainTakeover, c2.DomainTakeover) {
 		return false
 	}
 
+	// Compare marketplace sources
+	if !jsonEqual(c1.MarketplaceSources, c2.MarketplaceSources) {
+		return false
+	}
+
+	// Compare registry credentials
+	if !jsonEqual(c1.RegistryCredentials, c2.RegistryCredentials) {
+		return false
+	}
+
+	// Compare container hosts
+	if !jsonEqual(c1.ContainerHosts, c2.ContainerHosts) || c1.DefaultContainerHost != c2.DefaultContainerHost {
+		return false
+	}
+
 	// Compare SelectedEndpointId
 	if c1.SelectedEndpointId != c2.SelectedEndpointId {
 		return false
@@ -3257,7 +7321,8 @@ func socks5ConfigEqual(s1, s2 *models.SOCKS5Config) bool {
 		s1.Port == s2.Port &&
 		s1.Authentication == s2.Authentication &&
 		s1.Username == s2.Username &&
-		s1.Password == s2.Password
+		s1.Password == s2.Password &&
+		jsonEqual(s1.BypassRules, s2.BypassRules)
 }
 
 // domainTakeoverEqual compares two DomainTakeover configs for equality
@@ -3313,7 +7378,7 @@ func (a *App) MarkDirty() {
 	// savedConfig remains at last saved state
 
 	// Emit event to update UI
-	runtime.EventsEmit(a.ctx, "config:dirty", true)
+	a.emit("config:dirty", true)
 }
 
 // MarkClean updates savedConfig to current state
@@ -3326,7 +7391,7 @@ func (a *App) MarkClean() {
 	a.savedConfig = a.deepCopyConfig(a.config)
 
 	// Emit event to update UI
-	runtime.EventsEmit(a.ctx, "config:dirty", false)
+	a.emit("config:dirty", false)
 }
 
 // deepCopyConfig creates a deep copy of AppConfig
@@ -3338,13 +7403,13 @@ func (a *App) deepCopyConfig(config *models.AppConfig) *models.AppConfig {
 	// Use JSON marshaling for deep copy
 	data, err := json.Marshal(config)
 	if err != nil {
-		log.Printf("Error marshaling config for deep copy: %v", err)
+		appLog.Error("Error marshaling config for deep copy: %v", err)
 		return nil
 	}
 
 	var copy models.AppConfig
 	if err := json.Unmarshal(data, &copy); err != nil {
-		log.Printf("Error unmarshaling config for deep copy: %v", err)
+		appLog.Error("Error unmarshaling config for deep copy: %v", err)
 		return nil
 	}
 
@@ -3376,9 +7441,23 @@ func userConfigToAppConfig(userCfg *models.UserConfig, serverCfg *models.AppConf
 		Endpoints:           userCfg.Endpoints,
 		CORS:                userCfg.CORS,
 		SOCKS5Config:        userCfg.SOCKS5Config,
+		HTTPProxy:           userCfg.HTTPProxy,
 		DomainTakeover:      userCfg.DomainTakeover,
+		GRPC:                userCfg.GRPC,
+		DNS:                 userCfg.DNS,
+		ScheduledActions:    userCfg.ScheduledActions,
+		Macros:              userCfg.Macros,
+		ScriptModules:       userCfg.ScriptModules,
+		ScriptFetch:         userCfg.ScriptFetch,
+		Listeners:           userCfg.Listeners,
+		ClientThrottles:     userCfg.ClientThrottles,
+		JWTKeys:             userCfg.JWTKeys,
+		RegistryCredentials: userCfg.RegistryCredentials,
+		ContainerHosts:      userCfg.ContainerHosts,
+		MarketplaceSources:  userCfg.MarketplaceSources,
 		SelectedEndpointId:  userCfg.SelectedEndpointId,
 	}
+	appCfg.DefaultContainerHost = userCfg.DefaultContainerHost
 
 	// Server settings now come from UserConfig (unified format)
 	// Use values from UserConfig if present (non-zero), otherwise keep defaults
@@ -3400,6 +7479,14 @@ func userConfigToAppConfig(userCfg *models.UserConfig, serverCfg *models.AppConf
 	if len(userCfg.CertNames) > 0 {
 		appCfg.CertNames = userCfg.CertNames
 	}
+	appCfg.ClientAuth = userCfg.ClientAuth
+	appCfg.Limits = userCfg.Limits
+	appCfg.VirtualClock = userCfg.VirtualClock
+	appCfg.OfflineMode = userCfg.OfflineMode
+	appCfg.ActiveEnvironment = userCfg.ActiveEnvironment
+	appCfg.Variables = userCfg.Variables
+	appCfg.Profiles = userCfg.Profiles
+	appCfg.ActiveProfile = userCfg.ActiveProfile
 
 	// If we have an existing server config (for migration), preserve settings that aren't in the file
 	if serverCfg != nil {
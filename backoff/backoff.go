@@ -0,0 +1,142 @@
+// Package backoff checks the request log for clients that ignore Retry-After: after a response
+// told a client to wait, its next request to the same endpoint should not come sooner.
+package backoff
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+// advice is a Retry-After a client was given and has not acted on yet
+type advice struct {
+	log       *models.RequestLog
+	at        time.Time
+	wait      time.Duration
+	violation int // Index in the report's violations, or -1 while the client has not come back early
+}
+
+// Analyze pairs every response carrying Retry-After with the same client's following requests to
+// the same endpoint. Log timestamps have one-second resolution, so a request is only reported as
+// early when the logged gap is shorter than the wait (a request in the same second as a one-second
+// Retry-After is early; one in the next second is given the benefit of the doubt).
+func Analyze(logs []models.RequestLog) models.RetryAfterReport {
+	report := models.RetryAfterReport{Violations: []models.RetryAfterViolation{}}
+
+	type entry struct {
+		log *models.RequestLog
+		at  time.Time
+	}
+	entries := make([]entry, 0, len(logs))
+	for i := range logs {
+		at, err := time.Parse(time.RFC3339Nano, logs[i].Timestamp)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry{log: &logs[i], at: at})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].at.Before(entries[j].at) })
+
+	pending := make(map[string]*advice) // Client IP + "|" + endpoint ID -> open advice
+	for _, e := range entries {
+		key := clientIP(e.log.ClientRequest.SourceIP) + "|" + e.log.EndpointID
+
+		if open := pending[key]; open != nil {
+			gap := e.at.Sub(open.at)
+			if gap < open.wait {
+				if open.violation < 0 {
+					report.Violated++
+					open.violation = len(report.Violations)
+					report.Violations = append(report.Violations, newViolation(open, e.log, gap))
+				}
+				report.Violations[open.violation].EarlyRequests++
+			} else {
+				if open.violation < 0 {
+					report.Honored++
+				}
+				delete(pending, key)
+			}
+		}
+
+		if wait, ok := retryAfter(e.log, e.at); ok {
+			report.Advertised++
+			// An early request that was told to wait again starts a new wait
+			pending[key] = &advice{log: e.log, at: e.at, wait: wait, violation: -1}
+		}
+	}
+
+	for _, open := range pending {
+		if open.violation < 0 {
+			report.NoRetry++
+		}
+	}
+	return report
+}
+
+func newViolation(open *advice, retry *models.RequestLog, gap time.Duration) models.RetryAfterViolation {
+	violation := models.RetryAfterViolation{
+		ClientIP:     clientIP(open.log.ClientRequest.SourceIP),
+		EndpointID:   open.log.EndpointID,
+		Path:         open.log.ClientRequest.Path,
+		AdvisedLogID: open.log.ID,
+		RetryAfterMs: open.wait.Milliseconds(),
+		RetryLogID:   retry.ID,
+		GapMs:        gap.Milliseconds(),
+	}
+	if open.log.ClientResponse.StatusCode != nil {
+		violation.StatusCode = *open.log.ClientResponse.StatusCode
+	}
+	return violation
+}
+
+// retryAfter returns the wait a logged response advertised: Retry-After in seconds, or an HTTP
+// date (measured from the response's Date header, else from when it was logged)
+func retryAfter(log *models.RequestLog, loggedAt time.Time) (time.Duration, bool) {
+	if log.ClientResponse.StatusCode == nil {
+		return 0, false
+	}
+	value := strings.TrimSpace(headerValue(log.ClientResponse.Headers, "Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	until, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	from := loggedAt
+	if date, err := http.ParseTime(headerValue(log.ClientResponse.Headers, "Date")); err == nil {
+		from = date
+	}
+	if wait := until.Sub(from); wait > 0 {
+		return wait, true
+	}
+	return 0, false
+}
+
+// clientIP strips the port from a logged client address
+func clientIP(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+func headerValue(headers map[string][]string, name string) string {
+	for key, values := range headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+// Package backup keeps timestamped copies of saved configs, the most recent few per config file
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+// DefaultKeep is how many backups are kept per config file
+const DefaultKeep = 20
+
+// timeFormat names backup files; it sorts in time order
+const timeFormat = "20060102-150405.000"
+
+// headerPrefix starts the comment line a backup begins with, naming the config it was taken of
+const headerPrefix = "# Mockelot backup of "
+
+// Store keeps backups in a directory, one subdirectory per config file
+type Store struct {
+	dir  string
+	keep int
+}
+
+// NewStore creates a store in dir keeping the last keep backups per config (DefaultKeep if 0)
+func NewStore(dir string, keep int) *Store {
+	if keep <= 0 {
+		keep = DefaultKeep
+	}
+	return &Store{dir: dir, keep: keep}
+}
+
+// DefaultDir returns ~/.mockelot/backups
+func DefaultDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine home directory: %v", err)
+	}
+	return filepath.Join(homeDir, ".mockelot", "backups"), nil
+}
+
+// configDir returns the directory of a config's backups, named after the file and a hash of its
+// absolute path so configs with the same name do not share backups
+func (s *Store) configDir(configPath string) (string, error) {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid config path %s: %v", configPath, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(absPath), filepath.Ext(absPath))
+	sum := sha256.Sum256([]byte(absPath))
+	return filepath.Join(s.dir, fmt.Sprintf("%s-%x", name, sum[:4])), nil
+}
+
+// Save writes a backup of a config and removes the oldest backups beyond the kept number
+func (s *Store) Save(configPath string, data []byte) (*models.ConfigBackup, error) {
+	dir, err := s.configDir(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create backup directory: %v", err)
+	}
+
+	absPath, _ := filepath.Abs(configPath)
+	now := time.Now()
+	id := now.Format(timeFormat) + ".yaml"
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s%s\n", headerPrefix, absPath)
+	buf.Write(data)
+	if err := os.WriteFile(filepath.Join(dir, id), buf.Bytes(), 0600); err != nil {
+		return nil, fmt.Errorf("could not write backup: %v", err)
+	}
+
+	backups, err := s.List(configPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, old := range backups[min(len(backups), s.keep):] {
+		os.Remove(filepath.Join(dir, old.ID))
+	}
+
+	return &models.ConfigBackup{ID: id, ConfigPath: absPath, Created: now, Size: int64(buf.Len())}, nil
+}
+
+// List returns the backups of a config, newest first
+func (s *Store) List(configPath string) ([]models.ConfigBackup, error) {
+	dir, err := s.configDir(configPath)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []models.ConfigBackup{}, nil
+		}
+		return nil, fmt.Errorf("could not read backups: %v", err)
+	}
+
+	absPath, _ := filepath.Abs(configPath)
+	backups := []models.ConfigBackup{}
+	for _, entry := range entries {
+		created, err := time.ParseInLocation(timeFormat, strings.TrimSuffix(entry.Name(), ".yaml"), time.Local)
+		if entry.IsDir() || err != nil {
+			continue
+		}
+		backup := models.ConfigBackup{ID: entry.Name(), ConfigPath: absPath, Created: created}
+		if info, err := entry.Info(); err == nil {
+			backup.Size = info.Size()
+		}
+		backups = append(backups, backup)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ID > backups[j].ID
+	})
+	return backups, nil
+}
+
+// Read returns the config saved in a backup
+func (s *Store) Read(configPath, id string) ([]byte, error) {
+	if id == "" || filepath.Base(id) != id || filepath.Ext(id) != ".yaml" {
+		return nil, fmt.Errorf("invalid backup ID: %s", id)
+	}
+	dir, err := s.configDir(configPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("backup %s does not exist", id)
+		}
+		return nil, fmt.Errorf("could not read backup: %v", err)
+	}
+
+	// Drop the header line
+	if end := bytes.IndexByte(data, '\n'); end >= 0 && bytes.HasPrefix(data, []byte(headerPrefix)) {
+		data = data[end+1:]
+	}
+	return data, nil
+}
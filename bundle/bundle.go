@@ -0,0 +1,381 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"mockelot/models"
+)
+
+// ConfigFile is the name of the config inside a bundle
+const ConfigFile = "config.yaml"
+
+// Directories of a bundle the referenced files are stored in
+const (
+	certsDir  = "certs"
+	protosDir = "protos"
+)
+
+// maxFileSize caps the size of one file extracted from a bundle
+const maxFileSize = 64 << 20
+
+// fileRef is a file or directory path in a config
+type fileRef struct {
+	path *string
+	dir  string // Bundle directory the file goes to
+}
+
+// fileRefs returns the file references of a config: certificates and keys of the HTTPS server,
+// listeners and proxy backends, and the .proto files and import directories of the gRPC listener
+func fileRefs(cfg *models.UserConfig) (files []fileRef, protoDirs []*string) {
+	addCerts := func(paths ...*string) {
+		for _, p := range paths {
+			if *p != "" {
+				files = append(files, fileRef{path: p, dir: certsDir})
+			}
+		}
+	}
+	addTLS := func(tls *models.ProxyTLSConfig) {
+		if tls != nil {
+			addCerts(&tls.CACertPath, &tls.ClientCertPath, &tls.ClientKeyPath)
+		}
+	}
+
+	addCerts(&cfg.CertPaths.CACertPath, &cfg.CertPaths.CAKeyPath, &cfg.CertPaths.ServerCertPath,
+		&cfg.CertPaths.ServerKeyPath, &cfg.CertPaths.ServerBundlePath)
+	if cfg.ClientAuth != nil {
+		addCerts(&cfg.ClientAuth.CACertPath)
+	}
+	for i := range cfg.Listeners {
+		listener := &cfg.Listeners[i]
+		addCerts(&listener.CertPath, &listener.KeyPath, &listener.ClientCAPath)
+	}
+	for i := range cfg.Endpoints {
+		endpoint := &cfg.Endpoints[i]
+		if endpoint.ProxyConfig != nil {
+			addTLS(endpoint.ProxyConfig.TLS)
+		}
+		if endpoint.ContainerConfig != nil {
+			addTLS(endpoint.ContainerConfig.ProxyConfig.TLS)
+		}
+	}
+
+	if cfg.GRPC != nil {
+		for i := range cfg.GRPC.ProtoFiles {
+			files = append(files, fileRef{path: &cfg.GRPC.ProtoFiles[i], dir: protosDir})
+		}
+		for i := range cfg.GRPC.ImportPaths {
+			protoDirs = append(protoDirs, &cfg.GRPC.ImportPaths[i])
+		}
+	}
+	return files, protoDirs
+}
+
+// exporter writes the files of a bundle, each source file once
+type exporter struct {
+	zw      *zip.Writer
+	report  *models.BundleReport
+	written map[string]string // Bundle path by absolute source path
+	names   map[string]bool   // Bundle paths in use
+}
+
+// Export writes a config and the files it references to a zip. The files are stored under
+// certs/ and protos/ and the config, stored as config.yaml, refers to them by these relative
+// paths. Proto files take the .proto files of their directory along, for their imports.
+// References to files that cannot be read are kept as they are and reported.
+func Export(cfg *models.UserConfig, w io.Writer) (*models.BundleReport, error) {
+	var bundled models.UserConfig
+	if err := models.DeepCopy(cfg, &bundled); err != nil {
+		return nil, fmt.Errorf("could not copy config: %v", err)
+	}
+
+	e := &exporter{
+		zw:      zip.NewWriter(w),
+		report:  &models.BundleReport{},
+		written: make(map[string]string),
+		names:   map[string]bool{ConfigFile: true},
+	}
+
+	files, protoDirs := fileRefs(&bundled)
+
+	// Proto directories first, so proto files inside them keep their place in the tree
+	for _, dir := range protoDirs {
+		name, err := e.addProtoDir(*dir)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			e.report.Warnings = append(e.report.Warnings, fmt.Sprintf("import path %s is not bundled: not a readable directory", *dir))
+			continue
+		}
+		*dir = name
+	}
+	for _, ref := range files {
+		if ref.dir == protosDir {
+			if _, err := e.addProtoDir(filepath.Dir(*ref.path)); err != nil {
+				return nil, err
+			}
+		}
+		if err := e.addFile(ref); err != nil {
+			return nil, err
+		}
+	}
+
+	e.addWarnings(&bundled)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&bundled); err != nil {
+		return nil, fmt.Errorf("could not encode config: %v", err)
+	}
+	encoder.Close()
+	if err := e.write(ConfigFile, buf.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := e.zw.Close(); err != nil {
+		return nil, fmt.Errorf("could not write bundle: %v", err)
+	}
+
+	sort.Strings(e.report.Files)
+	return e.report, nil
+}
+
+// addFile stores a referenced file and points the reference at its bundle path
+func (e *exporter) addFile(ref fileRef) error {
+	source, err := filepath.Abs(*ref.path)
+	if err != nil {
+		return fmt.Errorf("invalid path %s: %v", *ref.path, err)
+	}
+	if name, ok := e.written[source]; ok {
+		*ref.path = name
+		return nil
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		e.report.Warnings = append(e.report.Warnings, fmt.Sprintf("%s is not bundled: %v", *ref.path, err))
+		return nil
+	}
+	name := e.uniqueName(path.Join(ref.dir, filepath.Base(source)))
+	if err := e.write(name, data); err != nil {
+		return err
+	}
+	e.written[source] = name
+	*ref.path = name
+	return nil
+}
+
+// addProtoDir stores the .proto files under a directory, keeping their layout, and returns the
+// directory's bundle path ("" when it is not a readable directory)
+func (e *exporter) addProtoDir(dir string) (string, error) {
+	source, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %s: %v", dir, err)
+	}
+	if name, ok := e.written[source]; ok {
+		return name, nil
+	}
+	if info, err := os.Stat(source); err != nil || !info.IsDir() {
+		return "", nil
+	}
+
+	name := e.uniqueName(path.Join(protosDir, filepath.Base(source)))
+	e.written[source] = name
+	err = filepath.WalkDir(source, func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(file) != ".proto" {
+			return err
+		}
+		rel, err := filepath.Rel(source, file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		// A file under two bundled directories is stored in both (each is an import root);
+		// references to it use the first copy
+		target := path.Join(name, filepath.ToSlash(rel))
+		if _, seen := e.written[file]; !seen {
+			e.written[file] = target
+		}
+		return e.write(target, data)
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not bundle proto directory %s: %v", dir, err)
+	}
+	return name, nil
+}
+
+// addWarnings reports the settings of a config that still point at the exporting machine
+func (e *exporter) addWarnings(cfg *models.UserConfig) {
+	if cfg.HTTPSEnabled && (cfg.CertMode == "" || cfg.CertMode == models.CertModeAuto) {
+		e.report.Warnings = append(e.report.Warnings,
+			"the auto-generated CA is not bundled: the importing machine generates its own (use ca-provided mode to share a CA)")
+	}
+	for _, endpoint := range cfg.Endpoints {
+		if endpoint.ContainerConfig == nil {
+			continue
+		}
+		for _, volume := range endpoint.ContainerConfig.Volumes {
+			if strings.ContainsAny(volume.HostPath, `/\`) {
+				e.report.Warnings = append(e.report.Warnings,
+					fmt.Sprintf("endpoint %s: volume %s is not bundled", endpoint.Name, volume.HostPath))
+			}
+		}
+		if build := endpoint.ContainerConfig.Build; build != nil && build.ContextPath != "" {
+			e.report.Warnings = append(e.report.Warnings,
+				fmt.Sprintf("endpoint %s: build context %s is not bundled", endpoint.Name, build.ContextPath))
+		}
+	}
+}
+
+// uniqueName returns name, or name with a counter before the extension if it is taken
+func (e *exporter) uniqueName(name string) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := name
+	for i := 2; e.names[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+	e.names[candidate] = true
+	return candidate
+}
+
+// write adds a file to the zip
+func (e *exporter) write(name string, data []byte) error {
+	w, err := e.zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("could not add %s to bundle: %v", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("could not add %s to bundle: %v", name, err)
+	}
+	e.report.Files = append(e.report.Files, name)
+	return nil
+}
+
+// Import extracts a bundle into destDir and returns its config, with the references to bundled
+// files pointing at the extracted files (absolute paths). The config is written to destDir as
+// config.yaml with these paths, so the directory can be loaded as it is.
+func Import(zipPath, destDir string) (*models.UserConfig, *models.BundleReport, error) {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not open bundle: %v", err)
+	}
+	defer zr.Close()
+
+	destDir, err = filepath.Abs(destDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid destination %s: %v", destDir, err)
+	}
+
+	var cfg *models.UserConfig
+	report := &models.BundleReport{}
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		target, err := extractPath(destDir, file.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := readZipFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		if file.Name == ConfigFile {
+			cfg = &models.UserConfig{}
+			if err := yaml.Unmarshal(data, cfg); err != nil {
+				return nil, nil, fmt.Errorf("could not decode bundled config: %v", err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, nil, fmt.Errorf("could not create %s: %v", filepath.Dir(target), err)
+		}
+		if err := os.WriteFile(target, data, 0600); err != nil {
+			return nil, nil, fmt.Errorf("could not write %s: %v", target, err)
+		}
+		report.Files = append(report.Files, file.Name)
+	}
+	if cfg == nil {
+		return nil, nil, fmt.Errorf("bundle has no %s", ConfigFile)
+	}
+
+	// Point the bundle-relative references at the extracted files
+	files, protoDirs := fileRefs(cfg)
+	for _, ref := range files {
+		resolveRef(ref.path, destDir, report)
+	}
+	for _, dir := range protoDirs {
+		resolveRef(dir, destDir, report)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		return nil, nil, fmt.Errorf("could not encode config: %v", err)
+	}
+	encoder.Close()
+	if err := os.WriteFile(filepath.Join(destDir, ConfigFile), buf.Bytes(), 0644); err != nil {
+		return nil, nil, fmt.Errorf("could not write config: %v", err)
+	}
+
+	sort.Strings(report.Files)
+	return cfg, report, nil
+}
+
+// resolveRef makes a bundle-relative reference absolute under destDir. References that were
+// not bundled (absolute paths, files missing at export) are kept and reported.
+func resolveRef(ref *string, destDir string, report *models.BundleReport) {
+	if *ref == "" || filepath.IsAbs(*ref) {
+		if *ref != "" {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s was not bundled and is used as it is", *ref))
+		}
+		return
+	}
+	resolved := filepath.Join(destDir, filepath.FromSlash(*ref))
+	if _, err := os.Stat(resolved); err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%s is not in the bundle and is used as it is", *ref))
+		return
+	}
+	*ref = resolved
+}
+
+// extractPath returns where a bundle file is extracted to, refusing names that would leave destDir
+func extractPath(destDir, name string) (string, error) {
+	clean := path.Clean(strings.ReplaceAll(name, `\`, "/"))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || filepath.VolumeName(clean) != "" {
+		return "", fmt.Errorf("bundle contains an invalid path: %s", name)
+	}
+	return filepath.Join(destDir, filepath.FromSlash(clean)), nil
+}
+
+// readZipFile reads a bundle file, up to maxFileSize
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s from bundle: %v", file.Name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s from bundle: %v", file.Name, err)
+	}
+	if len(data) > maxFileSize {
+		return nil, fmt.Errorf("%s in bundle is larger than %d MB", file.Name, maxFileSize>>20)
+	}
+	return data, nil
+}
@@ -0,0 +1,349 @@
+// Command apigen generates the admin API clients from the App bindings and checks API parity.
+//
+// Every exported App method is a Wails binding and, unless listed in adminapi.Excluded, an admin API
+// method. apigen writes a Go client (adminclient/api.go) and a TypeScript client
+// (adminclient/typescript/adminClient.ts) covering all of them, and fails when a binding is missing
+// from the Wails frontend bindings. With -check it only reports whether the generated files are
+// up to date, for CI.
+//
+//	go run ./cmd/apigen          # regenerate
+//	go run ./cmd/apigen -check   # fail if anything is stale or missing
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"mockelot/adminapi"
+)
+
+const (
+	goClientPath  = "adminclient/api.go"
+	tsClientPath  = "adminclient/typescript/adminClient.ts"
+	wailsDTSPath  = "frontend/wailsjs/go/main/App.d.ts"
+	generatedNote = "Code generated by cmd/apigen. DO NOT EDIT."
+)
+
+// binding is one exported App method
+type binding struct {
+	name    string
+	doc     []string // Doc comment lines
+	params  []param
+	results []string // Non-error result types, as Go source
+}
+
+type param struct {
+	name   string
+	goType string
+}
+
+func main() {
+	root := flag.String("root", ".", "Repository root")
+	check := flag.Bool("check", false, "Report stale or missing generated files instead of writing them")
+	flag.Parse()
+
+	if err := run(*root, *check); err != nil {
+		fmt.Fprintln(os.Stderr, "apigen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(root string, check bool) error {
+	bindings, err := parseBindings(root)
+	if err != nil {
+		return err
+	}
+
+	dts, err := os.ReadFile(filepath.Join(root, wailsDTSPath))
+	if err != nil {
+		return err
+	}
+	signatures := parseDTS(string(dts))
+	if err := checkParity(bindings, signatures); err != nil {
+		return err
+	}
+
+	var exposed []binding
+	for _, b := range bindings {
+		if adminapi.Excluded[b.name] == "" {
+			exposed = append(exposed, b)
+		}
+	}
+
+	goClient, err := renderGoClient(exposed)
+	if err != nil {
+		return err
+	}
+	outputs := map[string][]byte{
+		goClientPath: goClient,
+		tsClientPath: renderTSClient(exposed, signatures),
+	}
+
+	var stale []string
+	for _, path := range []string{goClientPath, tsClientPath} {
+		full := filepath.Join(root, path)
+		existing, _ := os.ReadFile(full)
+		if bytes.Equal(existing, outputs[path]) {
+			continue
+		}
+		if check {
+			stale = append(stale, path)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(full, outputs[path], 0644); err != nil {
+			return err
+		}
+		fmt.Println("wrote", path)
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("generated clients are out of date (run go run ./cmd/apigen): %s", strings.Join(stale, ", "))
+	}
+	return nil
+}
+
+// parseBindings collects the exported methods on *App from the main package sources, sorted by name
+func parseBindings(root string) ([]binding, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(root, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	mainTypes := make(map[string]bool)
+	var methods []*ast.FuncDecl
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if file.Name.Name != "main" {
+			continue
+		}
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						mainTypes[ts.Name.Name] = true
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv != nil && d.Name.IsExported() && isAppReceiver(d.Recv) {
+					methods = append(methods, d)
+				}
+			}
+		}
+	}
+
+	bindings := make([]binding, 0, len(methods))
+	for _, fn := range methods {
+		b := binding{name: fn.Name.Name}
+		if fn.Doc != nil {
+			b.doc = strings.Split(strings.TrimSpace(fn.Doc.Text()), "\n")
+		}
+		for i, field := range fn.Type.Params.List {
+			goType := typeString(field.Type, mainTypes)
+			if len(field.Names) == 0 {
+				b.params = append(b.params, param{name: fmt.Sprintf("arg%d", i+1), goType: goType})
+			}
+			for _, name := range field.Names {
+				b.params = append(b.params, param{name: paramName(name.Name), goType: goType})
+			}
+		}
+		if fn.Type.Results != nil {
+			for _, field := range fn.Type.Results.List {
+				if ident, ok := field.Type.(*ast.Ident); ok && ident.Name == "error" {
+					continue
+				}
+				b.results = append(b.results, typeString(field.Type, mainTypes))
+			}
+		}
+		if len(b.results) > 1 {
+			return nil, fmt.Errorf("%s: bindings return at most one value and an error", b.name)
+		}
+		bindings = append(bindings, b)
+	}
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].name < bindings[j].name })
+	return bindings, nil
+}
+
+func isAppReceiver(recv *ast.FieldList) bool {
+	star, ok := recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "App"
+}
+
+// paramName avoids clashes with the names used in the generated method bodies
+func paramName(name string) string {
+	switch name {
+	case "ctx", "c", "result", "err":
+		return name + "Arg"
+	}
+	return name
+}
+
+// typeString renders a type for the client package. Types declared in package main cannot be
+// imported, so values of those types are passed through as raw JSON.
+func typeString(expr ast.Expr, mainTypes map[string]bool) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if mainTypes[t.Name] {
+			return "json.RawMessage"
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X, mainTypes) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		inner := typeString(t.X, mainTypes)
+		if inner == "json.RawMessage" {
+			return inner
+		}
+		return "*" + inner
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt, mainTypes)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key, mainTypes) + "]" + typeString(t.Value, mainTypes)
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	panic(fmt.Sprintf("unsupported binding type %T", expr))
+}
+
+// parseDTS maps binding names to their TypeScript signature ("(arg1:string):Promise<void>")
+func parseDTS(dts string) map[string]string {
+	signatures := make(map[string]string)
+	for _, m := range regexp.MustCompile(`(?m)^export function (\w+)(\(.*\):Promise<.*>);$`).FindAllStringSubmatch(dts, -1) {
+		signatures[m[1]] = m[2]
+	}
+	return signatures
+}
+
+// checkParity fails when the Wails frontend bindings and the App methods disagree
+func checkParity(bindings []binding, signatures map[string]string) error {
+	var problems []string
+	seen := make(map[string]bool, len(bindings))
+	for _, b := range bindings {
+		seen[b.name] = true
+		if _, ok := signatures[b.name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s is missing from %s", b.name, wailsDTSPath))
+		}
+	}
+	for name := range signatures {
+		if !seen[name] {
+			problems = append(problems, fmt.Sprintf("%s in %s is not an App method", name, wailsDTSPath))
+		}
+	}
+	for name := range adminapi.Excluded {
+		if !seen[name] {
+			problems = append(problems, fmt.Sprintf("adminapi.Excluded lists %s, which is not an App method", name))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("binding parity check failed:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func renderGoClient(bindings []binding) ([]byte, error) {
+	var body bytes.Buffer
+	for _, b := range bindings {
+		var params, args []string
+		params = append(params, "ctx context.Context")
+		for _, p := range b.params {
+			params = append(params, p.name+" "+p.goType)
+			args = append(args, p.name)
+		}
+
+		for _, line := range b.doc {
+			fmt.Fprintf(&body, "// %s\n", line)
+		}
+		call := fmt.Sprintf("c.call(ctx, %q, []interface{}{%s}", b.name, strings.Join(args, ", "))
+		if len(b.results) == 0 {
+			fmt.Fprintf(&body, "func (c *Client) %s(%s) error {\n\treturn %s, nil)\n}\n\n", b.name, strings.Join(params, ", "), call)
+			continue
+		}
+		fmt.Fprintf(&body, "func (c *Client) %s(%s) (%s, error) {\n", b.name, strings.Join(params, ", "), b.results[0])
+		fmt.Fprintf(&body, "\tvar result %s\n\terr := %s, &result)\n\treturn result, err\n}\n\n", b.results[0], call)
+	}
+
+	imports := []string{`"context"`}
+	if strings.Contains(body.String(), "json.RawMessage") {
+		imports = append(imports, `"encoding/json"`)
+	}
+	imports = append(imports, "")
+	if strings.Contains(body.String(), "models.") {
+		imports = append(imports, `"mockelot/models"`)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// %s\n\npackage adminclient\n\nimport (\n\t%s\n)\n\n", generatedNote, strings.Join(imports, "\n\t"))
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func renderTSClient(bindings []binding, signatures map[string]string) []byte {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// %s\n", generatedNote)
+	out.WriteString(`import {models} from '../../frontend/wailsjs/go/models';
+import {main} from '../../frontend/wailsjs/go/models';
+
+// Raised when a call fails; status is the HTTP status of the admin API response
+export class AdminAPIError extends Error {
+  constructor(public status: number, message: string) {
+    super(message);
+  }
+}
+
+// Client for the Mockelot admin API. Every method mirrors the App binding of the same name.
+export class AdminClient {
+  constructor(private baseURL: string, private token: string) {}
+
+  private async call(method: string, args: any[]): Promise<any> {
+    const response = await fetch(this.baseURL.replace(/\/$/, '') + '` + adminapi.RPCPath + `' + method, {
+      method: 'POST',
+      headers: {'Authorization': 'Bearer ' + this.token, 'Content-Type': 'application/json'},
+      body: JSON.stringify(args),
+    });
+    const body = await response.json();
+    if (!response.ok) {
+      throw new AdminAPIError(response.status, body?.error ?? response.statusText);
+    }
+    return body;
+  }
+`)
+	argPattern := regexp.MustCompile(`(arg\d+):`)
+	for _, b := range bindings {
+		signature := signatures[b.name]
+		var args []string
+		for _, m := range argPattern.FindAllStringSubmatch(signature, -1) {
+			args = append(args, m[1])
+		}
+		out.WriteString("\n")
+		for _, line := range b.doc {
+			fmt.Fprintf(&out, "  // %s\n", line)
+		}
+		fmt.Fprintf(&out, "  %s%s {\n    return this.call('%s', [%s]);\n  }\n", b.name, signature, b.name, strings.Join(args, ", "))
+	}
+	out.WriteString("}\n")
+	return out.Bytes()
+}
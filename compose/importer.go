@@ -0,0 +1,406 @@
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"mockelot/models"
+)
+
+// Service is the subset of a docker-compose service needed to build a container endpoint. Fields
+// with more than one syntax (short and long) are kept as YAML nodes and read by the helpers below.
+type Service struct {
+	Image       string      `yaml:"image"`
+	Build       yaml.Node   `yaml:"build"`
+	Command     yaml.Node   `yaml:"command"`
+	Environment yaml.Node   `yaml:"environment"`
+	Ports       []yaml.Node `yaml:"ports"`
+	Volumes     []yaml.Node `yaml:"volumes"`
+	DependsOn   yaml.Node   `yaml:"depends_on"`
+	Healthcheck yaml.Node   `yaml:"healthcheck"`
+}
+
+// Result is a container endpoint configuration built from a compose file
+type Result struct {
+	MainService string                  // Service the endpoint routes to
+	Config      *models.ContainerConfig // Main service with the other services as sidecars
+	Warnings    []string                // Settings that were skipped
+}
+
+// ImportFile reads a docker-compose.yml and converts it to a container endpoint configuration.
+// mainService is the service requests are routed to; when empty, the only service that publishes
+// ports is used.
+func ImportFile(path, mainService string) (*Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compose file: %w", err)
+	}
+	return Import(data, filepath.Dir(path), mainService)
+}
+
+// Import converts compose file contents; relative bind mounts and build contexts are resolved
+// against baseDir
+func Import(data []byte, baseDir, mainService string) (*Result, error) {
+	names, services, err := parseServices(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if mainService == "" {
+		var published []string
+		for _, name := range names {
+			if len(services[name].Ports) > 0 {
+				published = append(published, name)
+			}
+		}
+		if len(published) != 1 {
+			return nil, fmt.Errorf("choose the service to route requests to: %s", strings.Join(names, ", "))
+		}
+		mainService = published[0]
+	}
+	main, ok := services[mainService]
+	if !ok {
+		return nil, fmt.Errorf("service %q not found in compose file (services: %s)", mainService, strings.Join(names, ", "))
+	}
+
+	result := &Result{MainService: mainService}
+	var image string
+	var build *models.ContainerBuild
+	if !main.Build.IsZero() {
+		// The routed service may be built from source; image: then names the built image
+		build, err = buildConfig(&main.Build, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", mainService, err)
+		}
+		image = main.Image
+	} else if image, err = serviceImage(mainService, main); err != nil {
+		return nil, err
+	}
+	containerPort, err := mainContainerPort(main.Ports)
+	if err != nil {
+		return nil, fmt.Errorf("service %s: %w", mainService, err)
+	}
+
+	result.Config = &models.ContainerConfig{
+		ProxyConfig: models.ProxyConfig{
+			TimeoutSeconds:    30,
+			StatusPassthrough: true,
+			InboundHeaders:    models.DefaultContainerInboundHeaders(),
+		},
+		ImageName:     image,
+		ContainerPort: containerPort,
+		PullOnStartup: build == nil,
+		Build:         build,
+		Environment:   result.environment(mainService, &main.Environment),
+		Volumes:       result.volumes(mainService, main.Volumes, baseDir),
+		NetworkAlias:  mainService,
+	}
+	if command := commandArgs(&main.Command); len(command) > 0 {
+		result.warn("service %s: command is not supported for the main container, the image command is used", mainService)
+	}
+
+	for _, name := range names {
+		if name == mainService {
+			continue
+		}
+		service := services[name]
+		image, err := serviceImage(name, service)
+		if err != nil {
+			return nil, err
+		}
+		if len(service.Ports) > 0 {
+			result.warn("service %s: ports are not published, other containers reach it as %s", name, name)
+		}
+
+		var dependsOn []string
+		for _, dependency := range dependencyNames(&service.DependsOn) {
+			if dependency == mainService {
+				result.warn("service %s: depends_on %s dropped, the main container always starts last", name, dependency)
+				continue
+			}
+			dependsOn = append(dependsOn, dependency)
+		}
+
+		result.Config.Sidecars = append(result.Config.Sidecars, models.SidecarContainer{
+			Name:          name,
+			ImageName:     image,
+			Command:       commandArgs(&service.Command),
+			Environment:   result.environment(name, &service.Environment),
+			Volumes:       result.volumes(name, service.Volumes, baseDir),
+			DependsOn:     dependsOn,
+			PullOnStartup: true,
+		})
+	}
+
+	// depends_on with condition: service_healthy waits for the image healthcheck of the dependency
+	healthy := make(map[string]bool)
+	for _, name := range names {
+		service := services[name]
+		for _, dependency := range healthyDependencies(&service.DependsOn) {
+			healthy[dependency] = true
+		}
+	}
+	for i := range result.Config.Sidecars {
+		sidecar := &result.Config.Sidecars[i]
+		if !healthy[sidecar.Name] {
+			continue
+		}
+		if service := services[sidecar.Name]; !service.Healthcheck.IsZero() {
+			result.warn("service %s: healthcheck is not imported, set a readiness check for it", sidecar.Name)
+			continue
+		}
+		sidecar.Readiness = &models.ContainerReadiness{Strategy: "healthcheck"}
+	}
+
+	return result, nil
+}
+
+// parseServices decodes the services of a compose file, keeping the order they were written in
+func parseServices(data []byte) ([]string, map[string]Service, error) {
+	var file struct {
+		Services yaml.Node `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse compose file: %w", err)
+	}
+	if file.Services.Kind != yaml.MappingNode || len(file.Services.Content) == 0 {
+		return nil, nil, fmt.Errorf("compose file has no services")
+	}
+
+	var names []string
+	services := make(map[string]Service)
+	for i := 0; i+1 < len(file.Services.Content); i += 2 {
+		name := file.Services.Content[i].Value
+		var service Service
+		if err := file.Services.Content[i+1].Decode(&service); err != nil {
+			return nil, nil, fmt.Errorf("service %s: %w", name, err)
+		}
+		names = append(names, name)
+		services[name] = service
+	}
+	return names, services, nil
+}
+
+// serviceImage returns the image of a service; services that are only built are not supported
+func serviceImage(name string, service Service) (string, error) {
+	if service.Image != "" {
+		return service.Image, nil
+	}
+	if !service.Build.IsZero() {
+		return "", fmt.Errorf("service %s is built from source (build:); only the routed service can be built, build it and set image: first", name)
+	}
+	return "", fmt.Errorf("service %s has no image", name)
+}
+
+// buildConfig reads build: as a context directory or as a map with context, dockerfile, args
+// (list or map) and target. The context is resolved against baseDir.
+func buildConfig(node *yaml.Node, baseDir string) (*models.ContainerBuild, error) {
+	var long struct {
+		Context    string    `yaml:"context"`
+		Dockerfile string    `yaml:"dockerfile"`
+		Args       yaml.Node `yaml:"args"`
+		Target     string    `yaml:"target"`
+	}
+	if node.Kind == yaml.ScalarNode {
+		long.Context = node.Value
+	} else if err := node.Decode(&long); err != nil {
+		return nil, fmt.Errorf("invalid build: %w", err)
+	}
+	if long.Context == "" {
+		long.Context = "."
+	}
+	if !filepath.IsAbs(long.Context) {
+		long.Context = filepath.Join(baseDir, long.Context)
+	}
+
+	build := &models.ContainerBuild{
+		ContextPath: long.Context,
+		Dockerfile:  long.Dockerfile,
+		Target:      long.Target,
+	}
+	switch long.Args.Kind {
+	case yaml.SequenceNode:
+		for _, item := range long.Args.Content {
+			name, value, _ := strings.Cut(item.Value, "=")
+			build.Args = append(build.Args, models.EnvironmentVar{Name: name, Value: value})
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(long.Args.Content); i += 2 {
+			build.Args = append(build.Args, models.EnvironmentVar{Name: long.Args.Content[i].Value, Value: long.Args.Content[i+1].Value})
+		}
+	}
+	return build, nil
+}
+
+// mainContainerPort is the container side of the first published port ("8080:80", "80/tcp", or
+// the long syntax with target:)
+func mainContainerPort(ports []yaml.Node) (int, error) {
+	if len(ports) == 0 {
+		return 80, nil
+	}
+	port := &ports[0]
+	if port.Kind == yaml.MappingNode {
+		var long struct {
+			Target int `yaml:"target"`
+		}
+		if err := port.Decode(&long); err != nil || long.Target == 0 {
+			return 0, fmt.Errorf("port has no target")
+		}
+		return long.Target, nil
+	}
+
+	spec := port.Value
+	if slash := strings.Index(spec, "/"); slash >= 0 {
+		spec = spec[:slash]
+	}
+	if colon := strings.LastIndex(spec, ":"); colon >= 0 {
+		spec = spec[colon+1:]
+	}
+	if dash := strings.Index(spec, "-"); dash >= 0 {
+		spec = spec[:dash] // Port range: route to the first port
+	}
+	containerPort, err := strconv.Atoi(spec)
+	if err != nil || containerPort <= 0 || containerPort > 65535 {
+		return 0, fmt.Errorf("invalid port %q", port.Value)
+	}
+	return containerPort, nil
+}
+
+// environment reads the list ("KEY=value") or map form of environment:. A key without a value
+// takes its value from Mockelot's own environment, as docker compose does.
+func (r *Result) environment(service string, node *yaml.Node) []models.EnvironmentVar {
+	env := []models.EnvironmentVar{}
+	add := func(name string, value *string) {
+		if value == nil {
+			hostValue, ok := os.LookupEnv(name)
+			if !ok {
+				r.warn("service %s: %s is not set in the environment, skipped", service, name)
+				return
+			}
+			value = &hostValue
+		}
+		env = append(env, models.EnvironmentVar{Name: name, Value: *value})
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if name, value, ok := strings.Cut(item.Value, "="); ok {
+				add(name, &value)
+			} else {
+				add(name, nil)
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value := node.Content[i+1]
+			if value.Tag == "!!null" {
+				add(node.Content[i].Value, nil)
+			} else {
+				add(node.Content[i].Value, &value.Value)
+			}
+		}
+	}
+	return env
+}
+
+// volumes converts bind mounts ("./data:/var/lib/data:ro" or the long syntax). Named and anonymous
+// volumes have no host directory, so they are skipped.
+func (r *Result) volumes(service string, nodes []yaml.Node, baseDir string) []models.VolumeMapping {
+	volumes := []models.VolumeMapping{}
+	for i := range nodes {
+		var source, target string
+		var readOnly bool
+		if nodes[i].Kind == yaml.MappingNode {
+			var long struct {
+				Type     string `yaml:"type"`
+				Source   string `yaml:"source"`
+				Target   string `yaml:"target"`
+				ReadOnly bool   `yaml:"read_only"`
+			}
+			if err := nodes[i].Decode(&long); err != nil || (long.Type != "" && long.Type != "bind") {
+				r.warn("service %s: only bind mounts are imported, volume %s skipped", service, long.Target)
+				continue
+			}
+			source, target, readOnly = long.Source, long.Target, long.ReadOnly
+		} else {
+			parts := strings.Split(nodes[i].Value, ":")
+			if len(parts) < 2 {
+				r.warn("service %s: anonymous volume %s skipped", service, nodes[i].Value)
+				continue
+			}
+			source, target = parts[0], parts[1]
+			readOnly = len(parts) > 2 && strings.Contains(parts[2], "ro")
+		}
+
+		switch {
+		case strings.HasPrefix(source, "~"):
+			home, _ := os.UserHomeDir()
+			source = filepath.Join(home, source[1:])
+		case strings.HasPrefix(source, "."):
+			source = filepath.Join(baseDir, source)
+		case !filepath.IsAbs(source) && !strings.HasPrefix(source, "/"):
+			r.warn("service %s: named volume %s skipped, mount a host directory instead", service, source)
+			continue
+		}
+		volumes = append(volumes, models.VolumeMapping{HostPath: source, ContainerPath: target, ReadOnly: readOnly})
+	}
+	return volumes
+}
+
+// commandArgs reads command: as a list, or as a string split on whitespace
+func commandArgs(node *yaml.Node) []string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var args []string
+		for _, item := range node.Content {
+			args = append(args, item.Value)
+		}
+		return args
+	case yaml.ScalarNode:
+		return strings.Fields(node.Value)
+	}
+	return nil
+}
+
+// dependencyNames reads depends_on as a list of services or a map keyed by service
+func dependencyNames(node *yaml.Node) []string {
+	var names []string
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			names = append(names, item.Value)
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			names = append(names, node.Content[i].Value)
+		}
+	}
+	return names
+}
+
+// healthyDependencies returns the services of a depends_on map with condition: service_healthy
+func healthyDependencies(node *yaml.Node) []string {
+	var names []string
+	if node.Kind != yaml.MappingNode {
+		return names
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var long struct {
+			Condition string `yaml:"condition"`
+		}
+		if err := node.Content[i+1].Decode(&long); err == nil && long.Condition == "service_healthy" {
+			names = append(names, node.Content[i].Value)
+		}
+	}
+	return names
+}
+
+// warn records a setting that could not be imported
+func (r *Result) warn(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
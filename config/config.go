@@ -3,15 +3,17 @@ package config
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 
+	"mockelot/logger"
 	"mockelot/models"
 )
 
+var configLog = logger.For(logger.SubsystemApp)
+
 const DefaultConfigFile = "config.json"
 
 type ConfigManager struct {
@@ -161,7 +163,7 @@ func (cm *ConfigManager) Save(config *models.AppConfig) error {
 		return fmt.Errorf("could not replace config file: %v", err)
 	}
 
-	log.Println("Configuration saved successfully")
+	configLog.Info("Configuration saved successfully")
 	return nil
 }
 
@@ -180,7 +182,7 @@ func (cm *ConfigManager) WatchConfigChanges(interval time.Duration, onConfigChan
 			if file.ModTime().After(lastModified) {
 				config, err := cm.Load()
 				if err != nil {
-					log.Printf("Error loading updated config: %v", err)
+					configLog.Error("Error loading updated config: %v", err)
 					continue
 				}
 
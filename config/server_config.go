@@ -2,7 +2,6 @@ package config
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"sync"
@@ -24,7 +23,7 @@ func NewServerConfigManager(customPath string) *ServerConfigManager {
 		// Use user's home directory
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
-			log.Printf("Could not determine home directory, using current directory: %v", err)
+			configLog.Warn("Could not determine home directory, using current directory: %v", err)
 			customPath = DefaultServerConfigFile
 		} else {
 			// Store in ~/.mockelot/server-config.yaml
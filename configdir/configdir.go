@@ -0,0 +1,188 @@
+// Package configdir reads and writes configs in directory layout: mockelot.yaml holds the server
+// settings and shared sections, and endpoints/ holds one file per endpoint, so endpoints can be
+// reviewed and merged on their own in version control.
+package configdir
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"mockelot/models"
+	"mockelot/storage"
+)
+
+// Names of the directory layout
+const (
+	MainFile     = "mockelot.yaml"
+	EndpointsDir = "endpoints"
+)
+
+// nonSlugChars are the characters replaced when an endpoint name becomes a file name
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// IsLayout reports whether path is a config in directory layout: a directory, or a mockelot.yaml
+// next to an endpoints directory
+func IsLayout(path string) bool {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return true
+	}
+	if filepath.Base(path) != MainFile {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(filepath.Dir(path), EndpointsDir))
+	return err == nil && info.IsDir()
+}
+
+// MainPath returns the main file of a config in directory layout, given the directory or the file
+func MainPath(path string) string {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return filepath.Join(path, MainFile)
+	}
+	return path
+}
+
+// Read joins a config in directory layout. The main file is read with load; the endpoint files
+// follow the endpoints of the main file (if any), in display order.
+func Read(path string, load func(string) ([]byte, error)) (*models.UserConfig, error) {
+	mainPath := MainPath(path)
+	data, err := load(mainPath)
+	if err != nil {
+		return nil, err
+	}
+	var cfg models.UserConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("could not decode %s: %v", MainFile, err)
+	}
+
+	dir := filepath.Join(filepath.Dir(mainPath), EndpointsDir)
+	names, err := endpointFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fileByID := make(map[string]string)
+	for _, endpoint := range cfg.Endpoints {
+		fileByID[endpoint.ID] = MainFile
+	}
+	var endpoints []models.Endpoint
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %v", name, err)
+		}
+		var endpoint models.Endpoint
+		if err := yaml.Unmarshal(data, &endpoint); err != nil {
+			return nil, fmt.Errorf("could not decode %s: %v", name, err)
+		}
+		// A copied endpoint file keeps the ID of the original
+		if other, ok := fileByID[endpoint.ID]; ok && endpoint.ID != "" {
+			return nil, fmt.Errorf("endpoint ID %s is used in both %s and %s", endpoint.ID, other, name)
+		}
+		fileByID[endpoint.ID] = name
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		return endpoints[i].DisplayOrder < endpoints[j].DisplayOrder
+	})
+	cfg.Endpoints = append(cfg.Endpoints, endpoints...)
+	return &cfg, nil
+}
+
+// Files splits a config into the files of its directory layout at path: the main file with
+// everything but the endpoints, then one file per endpoint named after it. System endpoints stay
+// in the main file. Endpoint files no longer in use are returned without data, for removal.
+func Files(cfg *models.UserConfig, path string) ([]storage.File, error) {
+	mainPath := MainPath(path)
+	dir := filepath.Join(filepath.Dir(mainPath), EndpointsDir)
+
+	main := *cfg
+	main.Endpoints = nil
+	var endpoints []models.Endpoint
+	for _, endpoint := range cfg.Endpoints {
+		if endpoint.IsSystem {
+			main.Endpoints = append(main.Endpoints, endpoint)
+		} else {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	data, err := encode(&main)
+	if err != nil {
+		return nil, err
+	}
+	files := []storage.File{{Path: mainPath, Data: data}}
+
+	used := make(map[string]bool)
+	for i := range endpoints {
+		data, err := encode(&endpoints[i])
+		if err != nil {
+			return nil, err
+		}
+		name := fileName(endpoints[i], used)
+		files = append(files, storage.File{Path: filepath.Join(dir, name), Data: data})
+	}
+
+	existing, err := endpointFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range existing {
+		if !used[name] {
+			files = append(files, storage.File{Path: filepath.Join(dir, name)})
+		}
+	}
+	return files, nil
+}
+
+// fileName returns the file name of an endpoint: its name in lower case with dashes, numbered
+// when another endpoint has the same name
+func fileName(endpoint models.Endpoint, used map[string]bool) string {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(endpoint.Name), "-"), "-")
+	if slug == "" {
+		slug = "endpoint"
+	}
+	name := slug + ".yaml"
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s-%d.yaml", slug, i)
+	}
+	used[name] = true
+	return name
+}
+
+// endpointFiles returns the YAML files in the endpoints directory, sorted (none if it is missing)
+func endpointFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read %s: %v", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// encode writes a value as YAML, indented like saved config files
+func encode(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("could not encode config: %v", err)
+	}
+	encoder.Close()
+	return buf.Bytes(), nil
+}
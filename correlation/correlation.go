@@ -0,0 +1,153 @@
+package correlation
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+const socks5EndpointID = "system-socks5-proxy"
+
+// DefaultHeaders are checked (in order) when no correlation header is specified
+var DefaultHeaders = []string{"X-Correlation-ID", "X-Request-ID", "Traceparent"}
+
+// GroupTransactions groups request logs into transactions.
+// A log joins a header transaction when it carries one of the correlation headers;
+// otherwise it joins the SOCKS5 connection its client address belongs to (a new
+// transaction starts at each CONNECT from that address).
+// Logs matching neither are left ungrouped. Transactions are returned in order of first request.
+func GroupTransactions(logs []models.RequestLog, headers []string) []models.Transaction {
+	if len(headers) == 0 {
+		headers = DefaultHeaders
+	}
+
+	// Client addresses seen on SOCKS5 logs; tunneled requests carry the same address
+	socksAddrs := make(map[string]bool)
+	for _, log := range logs {
+		if log.EndpointID == socks5EndpointID && log.ClientRequest.SourceIP != "" {
+			socksAddrs[log.ClientRequest.SourceIP] = true
+		}
+	}
+
+	var transactions []*models.Transaction
+	byID := make(map[string]*models.Transaction)
+	activeSOCKS := make(map[string]*models.Transaction) // client address -> open SOCKS5 transaction
+	ends := make(map[string]time.Time)                  // transaction ID -> latest completion time
+
+	for _, log := range logs {
+		var tx *models.Transaction
+		addr := log.ClientRequest.SourceIP
+
+		if name, value := correlationValue(log.ClientRequest.Headers, headers); value != "" {
+			id := models.TransactionTypeHeader + ":" + strings.ToLower(name) + "=" + value
+			tx = byID[id]
+			if tx == nil {
+				tx = &models.Transaction{
+					ID:    id,
+					Type:  models.TransactionTypeHeader,
+					Key:   name,
+					Value: value,
+				}
+			}
+		} else if addr != "" && socksAddrs[addr] {
+			// Each CONNECT opens a new SOCKS5 transaction for its client address
+			isConnect := log.EndpointID == socks5EndpointID && log.ClientRequest.Method == "CONNECT"
+			tx = activeSOCKS[addr]
+			if tx == nil || isConnect {
+				tx = &models.Transaction{
+					ID:   models.TransactionTypeSOCKS + ":" + log.ID,
+					Type: models.TransactionTypeSOCKS,
+					Key:  addr,
+				}
+				activeSOCKS[addr] = tx
+			}
+			if tx.Value == "" && log.SOCKS5Info != nil {
+				tx.Value = fmt.Sprintf("%s:%d", log.SOCKS5Info.TargetHost, log.SOCKS5Info.TargetPort)
+			}
+		}
+
+		if tx == nil {
+			continue
+		}
+		if _, exists := byID[tx.ID]; !exists {
+			byID[tx.ID] = tx
+			transactions = append(transactions, tx)
+		}
+		addToTransaction(tx, log, ends)
+	}
+
+	result := make([]models.Transaction, 0, len(transactions))
+	for _, tx := range transactions {
+		if start, err := time.Parse(time.RFC3339, tx.StartTime); err == nil {
+			if end, ok := ends[tx.ID]; ok && end.After(start) {
+				tx.DurationMs = end.Sub(start).Milliseconds()
+			}
+		}
+		result = append(result, *tx)
+	}
+	return result
+}
+
+// addToTransaction appends a log to a transaction and updates its aggregates
+func addToTransaction(tx *models.Transaction, log models.RequestLog, ends map[string]time.Time) {
+	tx.RequestIDs = append(tx.RequestIDs, log.ID)
+	tx.RequestCount++
+	if tx.StartTime == "" {
+		tx.StartTime = log.Timestamp
+	}
+	tx.EndTime = log.Timestamp
+
+	if log.ValidationFailed || log.ResponseFailed ||
+		(log.ClientResponse.StatusCode != nil && *log.ClientResponse.StatusCode >= 400) {
+		tx.ErrorCount++
+	}
+
+	var rtt int64
+	if log.ClientResponse.RTTMs != nil {
+		rtt = *log.ClientResponse.RTTMs
+		tx.TotalRTTMs += rtt
+		if rtt > tx.MaxRTTMs {
+			tx.MaxRTTMs = rtt
+		}
+	}
+
+	if ts, err := time.Parse(time.RFC3339, log.Timestamp); err == nil {
+		end := ts.Add(time.Duration(rtt) * time.Millisecond)
+		if end.After(ends[tx.ID]) {
+			ends[tx.ID] = end
+		}
+	}
+}
+
+// correlationValue returns the first configured correlation header present on a request.
+// For W3C traceparent headers only the trace ID is used, so spans of one trace group together.
+func correlationValue(requestHeaders map[string][]string, names []string) (string, string) {
+	for _, name := range names {
+		values := requestHeaders[http.CanonicalHeaderKey(name)]
+		if len(values) == 0 {
+			// Headers captured from HTTP/2 or raw maps may not be canonicalized
+			for key, v := range requestHeaders {
+				if strings.EqualFold(key, name) {
+					values = v
+					break
+				}
+			}
+		}
+		if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+			continue
+		}
+
+		value := strings.TrimSpace(values[0])
+		if strings.EqualFold(name, "traceparent") {
+			// version-traceid-parentid-flags
+			if parts := strings.Split(value, "-"); len(parts) == 4 {
+				value = parts[1]
+			}
+		}
+		return name, value
+	}
+	return "", ""
+}
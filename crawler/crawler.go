@@ -0,0 +1,266 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"mockelot/models"
+)
+
+const (
+	defaultMaxPages = 100
+	defaultMaxDepth = 3
+	maxBodySize     = 5 << 20 // 5 MiB per crawled response
+)
+
+// Response headers copied into generated mocks (hop-by-hop, length and encoding headers are dropped)
+var keptHeaders = []string{"Content-Type", "Cache-Control", "ETag", "Last-Modified", "Location", "Link"}
+
+// Links in HTML pages
+var hrefPattern = regexp.MustCompile(`(?i)href\s*=\s*["']([^"'#]+)["']`)
+
+// Crawler walks a backend API from seed paths and records each response as a mock
+type Crawler struct {
+	backend    *url.URL // Backend base URL, with a trailing slash so relative links stay under its path
+	options    models.CrawlOptions
+	httpClient *http.Client
+}
+
+type queueEntry struct {
+	path  string // path plus optional query
+	depth int
+}
+
+// New creates a crawler for the given backend base URL
+func New(backendURL string, options models.CrawlOptions, timeout time.Duration) (*Crawler, error) {
+	backend, err := url.Parse(backendURL)
+	if err != nil || backend.Scheme == "" || backend.Host == "" {
+		return nil, fmt.Errorf("invalid backend URL: %s", backendURL)
+	}
+	if options.MaxPages <= 0 {
+		options.MaxPages = defaultMaxPages
+	}
+	if options.MaxDepth <= 0 {
+		options.MaxDepth = defaultMaxDepth
+	}
+	if !strings.HasSuffix(backend.Path, "/") {
+		backend.Path += "/"
+		if backend.RawPath != "" {
+			backend.RawPath += "/"
+		}
+	}
+	if len(options.SeedPaths) == 0 {
+		options.SeedPaths = []string{backend.EscapedPath()}
+	}
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &Crawler{
+		backend: backend,
+		options: options,
+		httpClient: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse // Record redirects as-is
+			},
+		},
+	}, nil
+}
+
+// Crawl fetches the seed paths breadth-first, following discovered links on the same host,
+// and returns one GET mock response per distinct path
+func (c *Crawler) Crawl(ctx context.Context) ([]models.MethodResponse, *models.CrawlResult) {
+	result := &models.CrawlResult{}
+	var responses []models.MethodResponse
+
+	seen := make(map[string]bool)
+	var queue []queueEntry
+	for _, seed := range c.options.SeedPaths {
+		if path := c.normalize(seed); path != "" && !seen[pathOnly(path)] {
+			seen[pathOnly(path)] = true
+			queue = append(queue, queueEntry{path: path})
+		}
+	}
+
+	for len(queue) > 0 && result.PagesFetched < c.options.MaxPages {
+		if ctx.Err() != nil {
+			result.Errors = append(result.Errors, "crawl cancelled")
+			break
+		}
+
+		entry := queue[0]
+		queue = queue[1:]
+
+		resp, body, err := c.fetch(ctx, entry.path)
+		result.PagesFetched++
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("GET %s: %v", entry.path, err))
+			continue
+		}
+
+		responses = append(responses, c.toMockResponse(entry.path, resp, body))
+
+		if c.options.FollowLinks != nil && !*c.options.FollowLinks {
+			continue
+		}
+		if entry.depth+1 > c.options.MaxDepth {
+			continue
+		}
+		for _, link := range c.discoverLinks(resp, body) {
+			if !seen[pathOnly(link)] {
+				seen[pathOnly(link)] = true
+				queue = append(queue, queueEntry{path: link, depth: entry.depth + 1})
+			}
+		}
+	}
+
+	result.Responses = len(responses)
+	return responses, result
+}
+
+// fetch performs a GET against the backend
+func (c *Crawler) fetch(ctx context.Context, path string) (*http.Response, []byte, error) {
+	ref, err := url.Parse(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	target := c.backend.ResolveReference(ref)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json, text/html;q=0.9, */*;q=0.8")
+	for name, value := range c.options.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// toMockResponse converts a backend response into a static mock response for its path
+func (c *Crawler) toMockResponse(path string, resp *http.Response, body []byte) models.MethodResponse {
+	headers := make(map[string]string)
+	for _, name := range keptHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	pattern := pathOnly(path)
+	if unescaped, err := url.PathUnescape(pattern); err == nil {
+		pattern = unescaped
+	}
+
+	return models.MethodResponse{
+		ID:           uuid.New().String(),
+		PathPattern:  pattern,
+		Methods:      []string{http.MethodGet},
+		StatusCode:   resp.StatusCode,
+		StatusText:   http.StatusText(resp.StatusCode),
+		Headers:      headers,
+		Body:         string(body),
+		ResponseMode: models.ResponseModeStatic,
+	}
+}
+
+// discoverLinks extracts same-host links from Link/Location headers, JSON string values and HTML hrefs
+func (c *Crawler) discoverLinks(resp *http.Response, body []byte) []string {
+	var candidates []string
+
+	if location := resp.Header.Get("Location"); location != "" {
+		candidates = append(candidates, location)
+	}
+	for _, link := range resp.Header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			start, end := strings.Index(part, "<"), strings.Index(part, ">")
+			if start >= 0 && end > start {
+				candidates = append(candidates, part[start+1:end])
+			}
+		}
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if strings.Contains(contentType, "json") {
+		var data interface{}
+		if err := json.Unmarshal(body, &data); err == nil {
+			collectJSONLinks(data, &candidates)
+		}
+	} else if strings.Contains(contentType, "html") {
+		for _, match := range hrefPattern.FindAllSubmatch(body, -1) {
+			candidates = append(candidates, string(match[1]))
+		}
+	}
+
+	var links []string
+	for _, candidate := range candidates {
+		if path := c.normalize(candidate); path != "" {
+			links = append(links, path)
+		}
+	}
+	return links
+}
+
+// collectJSONLinks gathers string values that look like URLs or absolute paths (HAL/JSON:API/HATEOAS refs)
+func collectJSONLinks(value interface{}, links *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, child := range v {
+			collectJSONLinks(child, links)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectJSONLinks(child, links)
+		}
+	case string:
+		if strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://") ||
+			(strings.HasPrefix(v, "/") && !strings.HasPrefix(v, "//") && !strings.ContainsAny(v, " \n")) {
+			*links = append(*links, v)
+		}
+	}
+}
+
+// normalize resolves a link against the backend and returns its path (and query) if it is on the same host
+func (c *Crawler) normalize(link string) string {
+	ref, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return ""
+	}
+	resolved := c.backend.ResolveReference(ref)
+	if resolved.Host != c.backend.Host || (resolved.Scheme != "http" && resolved.Scheme != "https") {
+		return ""
+	}
+	path := resolved.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if resolved.RawQuery != "" {
+		path += "?" + resolved.RawQuery
+	}
+	return path
+}
+
+// pathOnly strips the query string
+func pathOnly(path string) string {
+	p, _, _ := strings.Cut(path, "?")
+	return p
+}
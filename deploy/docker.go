@@ -0,0 +1,145 @@
+package deploy
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"mockelot/models"
+)
+
+const (
+	// ContainerConfigPath is where the bundled config lives inside the image
+	ContainerConfigPath = "/etc/mockelot/config.yaml"
+	// ContainerConfigDir is where a config in directory layout lives inside the image
+	ContainerConfigDir = "/etc/mockelot"
+
+	defaultImageName = "mockelot-server"
+	binaryName       = "mockelot"
+)
+
+// PortSpec is a port the headless server listens on
+type PortSpec struct {
+	Name     string // Short name (e.g., "http", "https", "socks5")
+	Port     int
+	Protocol string // "TCP"
+}
+
+// Options describes what the generated deployment artifacts should contain
+type Options struct {
+	ImageName         string     // Image/service name (default: mockelot-server)
+	ConfigFileName    string     // Base name of the config file in the build context
+	ConfigDirLayout   bool       // The config is a main file with an endpoints directory next to it
+	Ports             []PortSpec // Listener ports exposed by the server
+	NeedsDockerSocket bool       // Whether container endpoints require access to the host Docker socket
+}
+
+// OptionsFromConfig derives deployment options from a loaded config
+func OptionsFromConfig(cfg *models.AppConfig, configPath string) Options {
+	opts := Options{
+		ImageName:      defaultImageName,
+		ConfigFileName: filepath.Base(configPath),
+	}
+
+	port := cfg.Port
+	if port == 0 {
+		port = 8080
+	}
+	opts.Ports = append(opts.Ports, PortSpec{Name: "http", Port: port, Protocol: "TCP"})
+
+	if cfg.HTTPSEnabled {
+		httpsPort := cfg.HTTPSPort
+		if httpsPort == 0 {
+			httpsPort = 8443
+		}
+		opts.Ports = append(opts.Ports, PortSpec{Name: "https", Port: httpsPort, Protocol: "TCP"})
+	}
+
+	if cfg.SOCKS5Config != nil && cfg.SOCKS5Config.Enabled {
+		socksPort := cfg.SOCKS5Config.Port
+		if socksPort == 0 {
+			socksPort = 1080
+		}
+		opts.Ports = append(opts.Ports, PortSpec{Name: "socks5", Port: socksPort, Protocol: "TCP"})
+	}
+
+	for _, endpoint := range cfg.Endpoints {
+		if endpoint.Type == models.EndpointTypeContainer && endpoint.IsEnabled() {
+			opts.NeedsDockerSocket = true
+			break
+		}
+	}
+
+	return opts
+}
+
+// GenerateDockerfile returns a Dockerfile that packages a prebuilt Linux mockelot
+// binary with the config and runs it in headless mode
+func GenerateDockerfile(opts Options) string {
+	var b strings.Builder
+
+	b.WriteString("# Generated by Mockelot\n")
+	b.WriteString("# Build context must contain a Linux mockelot binary and the config\n")
+	if opts.ConfigDirLayout {
+		fmt.Fprintf(&b, "# (%s and the endpoints directory).\n", opts.ConfigFileName)
+	} else {
+		fmt.Fprintf(&b, "# file (%s).\n", opts.ConfigFileName)
+	}
+	b.WriteString("# Build the binary with `go build` after building the frontend, since it embeds\n")
+	b.WriteString("# frontend/dist and the Wails runtime. Without the `production` or `dev` tags that\n")
+	b.WriteString("# `wails build` sets, Wails does not link GTK or WebKit, so the image needs neither.\n")
+	b.WriteString("FROM debian:12-slim\n\n")
+	b.WriteString("RUN apt-get update && apt-get install -y --no-install-recommends ca-certificates \\\n")
+	b.WriteString("    && rm -rf /var/lib/apt/lists/*\n\n")
+	fmt.Fprintf(&b, "COPY %s /usr/local/bin/%s\n", binaryName, binaryName)
+	configPath := ContainerConfigPath
+	if opts.ConfigDirLayout {
+		configPath = ContainerConfigDir
+		fmt.Fprintf(&b, "COPY %s %s/%s\n", opts.ConfigFileName, ContainerConfigDir, opts.ConfigFileName)
+		fmt.Fprintf(&b, "COPY endpoints %s/endpoints\n\n", ContainerConfigDir)
+	} else {
+		fmt.Fprintf(&b, "COPY %s %s\n\n", opts.ConfigFileName, ContainerConfigPath)
+	}
+
+	ports := make([]string, 0, len(opts.Ports))
+	for _, p := range opts.Ports {
+		ports = append(ports, fmt.Sprintf("%d", p.Port))
+	}
+	if len(ports) > 0 {
+		fmt.Fprintf(&b, "EXPOSE %s\n\n", strings.Join(ports, " "))
+	}
+
+	fmt.Fprintf(&b, "ENTRYPOINT [\"/usr/local/bin/%s\", \"serve\", \"--config\", \"%s\"]\n", binaryName, configPath)
+
+	return b.String()
+}
+
+// GenerateCompose returns a docker-compose snippet that builds and runs the image
+func GenerateCompose(opts Options) string {
+	var b strings.Builder
+
+	b.WriteString("# Generated by Mockelot\n")
+	b.WriteString("services:\n")
+	fmt.Fprintf(&b, "  %s:\n", opts.ImageName)
+	b.WriteString("    build:\n")
+	b.WriteString("      context: .\n")
+	b.WriteString("      dockerfile: Dockerfile\n")
+	fmt.Fprintf(&b, "    image: %s:latest\n", opts.ImageName)
+
+	if len(opts.Ports) > 0 {
+		b.WriteString("    ports:\n")
+		for _, p := range opts.Ports {
+			fmt.Fprintf(&b, "      - \"%d:%d\"\n", p.Port, p.Port)
+		}
+	}
+
+	if opts.NeedsDockerSocket {
+		b.WriteString("    # Container endpoints are started through the host Docker daemon\n")
+		b.WriteString("    volumes:\n")
+		b.WriteString("      - /var/run/docker.sock:/var/run/docker.sock\n")
+	}
+
+	b.WriteString("    restart: unless-stopped\n")
+
+	return b.String()
+}
@@ -0,0 +1,128 @@
+package deploy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"mockelot/models"
+)
+
+// GenerateKubernetesManifests returns a multi-document YAML with a ConfigMap holding
+// the config, a Deployment running the headless server and a Service exposing it.
+// Liveness and readiness probes target the server's reserved health endpoints.
+func GenerateKubernetesManifests(opts Options, configContent []byte) string {
+	name := opts.ImageName
+	if name == "" {
+		name = defaultImageName
+	}
+	configMapName := name + "-config"
+	configKey := path.Base(ContainerConfigPath)
+
+	var b strings.Builder
+
+	// ConfigMap with the config file
+	b.WriteString("# Generated by Mockelot\n")
+	b.WriteString("apiVersion: v1\n")
+	b.WriteString("kind: ConfigMap\n")
+	b.WriteString("metadata:\n")
+	fmt.Fprintf(&b, "  name: %s\n", configMapName)
+	writeLabels(&b, name, "  ")
+	b.WriteString("data:\n")
+	fmt.Fprintf(&b, "  %s: |\n", configKey)
+	for _, line := range strings.Split(strings.TrimRight(string(configContent), "\n"), "\n") {
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(&b, "    %s\n", line)
+	}
+
+	// Deployment
+	b.WriteString("---\n")
+	b.WriteString("apiVersion: apps/v1\n")
+	b.WriteString("kind: Deployment\n")
+	b.WriteString("metadata:\n")
+	fmt.Fprintf(&b, "  name: %s\n", name)
+	writeLabels(&b, name, "  ")
+	b.WriteString("spec:\n")
+	b.WriteString("  replicas: 1\n")
+	b.WriteString("  selector:\n")
+	b.WriteString("    matchLabels:\n")
+	fmt.Fprintf(&b, "      app.kubernetes.io/name: %s\n", name)
+	b.WriteString("  template:\n")
+	b.WriteString("    metadata:\n")
+	writeLabels(&b, name, "      ")
+	b.WriteString("    spec:\n")
+	b.WriteString("      containers:\n")
+	fmt.Fprintf(&b, "        - name: %s\n", name)
+	fmt.Fprintf(&b, "          image: %s:latest\n", name)
+	if len(opts.Ports) > 0 {
+		b.WriteString("          ports:\n")
+		for _, p := range opts.Ports {
+			fmt.Fprintf(&b, "            - name: %s\n", p.Name)
+			fmt.Fprintf(&b, "              containerPort: %d\n", p.Port)
+			fmt.Fprintf(&b, "              protocol: %s\n", p.Protocol)
+		}
+	}
+	b.WriteString("          livenessProbe:\n")
+	writeHTTPProbe(&b, models.ReservedPathHealth, "            ")
+	b.WriteString("          readinessProbe:\n")
+	writeHTTPProbe(&b, models.ReservedPathReady, "            ")
+	b.WriteString("          volumeMounts:\n")
+	b.WriteString("            - name: config\n")
+	fmt.Fprintf(&b, "              mountPath: %s\n", ContainerConfigPath)
+	fmt.Fprintf(&b, "              subPath: %s\n", configKey)
+	b.WriteString("              readOnly: true\n")
+	if opts.NeedsDockerSocket {
+		b.WriteString("            # Container endpoints are started through the node's Docker daemon\n")
+		b.WriteString("            - name: docker-socket\n")
+		b.WriteString("              mountPath: /var/run/docker.sock\n")
+	}
+	b.WriteString("      volumes:\n")
+	b.WriteString("        - name: config\n")
+	b.WriteString("          configMap:\n")
+	fmt.Fprintf(&b, "            name: %s\n", configMapName)
+	if opts.NeedsDockerSocket {
+		b.WriteString("        - name: docker-socket\n")
+		b.WriteString("          hostPath:\n")
+		b.WriteString("            path: /var/run/docker.sock\n")
+		b.WriteString("            type: Socket\n")
+	}
+
+	// Service
+	b.WriteString("---\n")
+	b.WriteString("apiVersion: v1\n")
+	b.WriteString("kind: Service\n")
+	b.WriteString("metadata:\n")
+	fmt.Fprintf(&b, "  name: %s\n", name)
+	writeLabels(&b, name, "  ")
+	b.WriteString("spec:\n")
+	b.WriteString("  selector:\n")
+	fmt.Fprintf(&b, "    app.kubernetes.io/name: %s\n", name)
+	b.WriteString("  ports:\n")
+	for _, p := range opts.Ports {
+		fmt.Fprintf(&b, "    - name: %s\n", p.Name)
+		fmt.Fprintf(&b, "      port: %d\n", p.Port)
+		fmt.Fprintf(&b, "      targetPort: %s\n", p.Name)
+		fmt.Fprintf(&b, "      protocol: %s\n", p.Protocol)
+	}
+
+	return b.String()
+}
+
+// writeLabels writes the standard labels block at the given indentation
+func writeLabels(b *strings.Builder, name string, indent string) {
+	fmt.Fprintf(b, "%slabels:\n", indent)
+	fmt.Fprintf(b, "%s  app.kubernetes.io/name: %s\n", indent, name)
+	fmt.Fprintf(b, "%s  app.kubernetes.io/managed-by: mockelot\n", indent)
+}
+
+// writeHTTPProbe writes an httpGet probe against the "http" port
+func writeHTTPProbe(b *strings.Builder, probePath string, indent string) {
+	fmt.Fprintf(b, "%shttpGet:\n", indent)
+	fmt.Fprintf(b, "%s  path: %s\n", indent, probePath)
+	fmt.Fprintf(b, "%s  port: http\n", indent)
+	fmt.Fprintf(b, "%speriodSeconds: 10\n", indent)
+	fmt.Fprintf(b, "%sfailureThreshold: 3\n", indent)
+}
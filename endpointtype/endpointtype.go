@@ -0,0 +1,140 @@
+// Package endpointtype lets new kinds of endpoints (gRPC, MQTT, S3, in-house protocols, ...) be
+// added from separate packages. A plugin registers itself from an init function:
+//
+//	func init() {
+//		endpointtype.Register(&s3Plugin{})
+//	}
+//
+// and is compiled in with a blank import (import _ "example.com/mockelot-s3"). Endpoints whose
+// type matches the plugin's are then routed, health-checked, started and stopped through it,
+// reading their settings from Endpoint.PluginConfig.
+package endpointtype
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"mockelot/models"
+)
+
+// Plugin implements an endpoint type
+type Plugin interface {
+	// Info describes the type; Info().Type is the value of Endpoint.Type it serves
+	Info() models.EndpointTypeInfo
+
+	// Match is called once an endpoint of this type matched the request's port, domain and path
+	// prefix. Returning false lets matching continue with the next endpoint.
+	Match(endpoint *models.Endpoint, r *http.Request) bool
+
+	// Serve answers a matched request. The server records the request and whatever is written
+	// to w in the request log.
+	Serve(w http.ResponseWriter, r *http.Request, req Request)
+
+	// Health reports whether the endpoint can serve traffic (shown in the readiness report)
+	Health(endpoint *models.Endpoint) Health
+
+	// Start is called when the server starts, or an enabled endpoint of this type is added
+	Start(ctx context.Context, endpoint *models.Endpoint) error
+
+	// Stop is called when the server stops, or the endpoint is removed or disabled
+	Stop(ctx context.Context, endpoint *models.Endpoint) error
+}
+
+// Request carries what the server resolved for a matched request
+type Request struct {
+	Endpoint      *models.Endpoint
+	Path          string   // Request path after the endpoint's path translation
+	CaptureGroups []string // Regex path prefix capture groups (empty for plain prefixes)
+	Body          []byte   // Request body (r.Body can also still be read)
+}
+
+// Health is the state of one plugin endpoint
+type Health struct {
+	Ready  bool
+	Status string // Short state, e.g. "connected" or "starting"
+	Reason string // Why the endpoint is not ready
+}
+
+var (
+	pluginsMutex sync.RWMutex
+	plugins      = make(map[string]Plugin)
+)
+
+// builtinTypes are served by the server itself and cannot be replaced
+var builtinTypes = map[string]bool{
+	models.EndpointTypeMock:      true,
+	models.EndpointTypeProxy:     true,
+	models.EndpointTypeContainer: true,
+}
+
+// Register makes an endpoint type available. It panics if the type is empty, built in or
+// already registered, as database/sql.Register does for drivers.
+func Register(plugin Plugin) {
+	info := plugin.Info()
+	if info.Type == "" {
+		panic("endpointtype: Register called with an empty type")
+	}
+	if builtinTypes[info.Type] {
+		panic(fmt.Sprintf("endpointtype: %q is a built-in endpoint type", info.Type))
+	}
+
+	pluginsMutex.Lock()
+	defer pluginsMutex.Unlock()
+	if _, exists := plugins[info.Type]; exists {
+		panic(fmt.Sprintf("endpointtype: Register called twice for type %q", info.Type))
+	}
+	plugins[info.Type] = plugin
+}
+
+// Lookup returns the plugin serving an endpoint type
+func Lookup(endpointType string) (Plugin, bool) {
+	pluginsMutex.RLock()
+	defer pluginsMutex.RUnlock()
+	plugin, ok := plugins[endpointType]
+	return plugin, ok
+}
+
+// IsBuiltin reports whether an endpoint type is served by the server itself
+func IsBuiltin(endpointType string) bool {
+	return builtinTypes[endpointType]
+}
+
+// IsKnown reports whether an endpoint type is built in or registered
+func IsKnown(endpointType string) bool {
+	if IsBuiltin(endpointType) {
+		return true
+	}
+	_, ok := Lookup(endpointType)
+	return ok
+}
+
+// Registered describes the registered plugin types, sorted by type
+func Registered() []models.EndpointTypeInfo {
+	pluginsMutex.RLock()
+	defer pluginsMutex.RUnlock()
+	infos := make([]models.EndpointTypeInfo, 0, len(plugins))
+	for _, plugin := range plugins {
+		infos = append(infos, plugin.Info())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Type < infos[j].Type })
+	return infos
+}
+
+// DecodeConfig decodes an endpoint's plugin_config into v (a pointer to the plugin's settings struct)
+func DecodeConfig(endpoint *models.Endpoint, v interface{}) error {
+	if len(endpoint.PluginConfig) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(endpoint.PluginConfig)
+	if err != nil {
+		return fmt.Errorf("invalid plugin config: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid plugin config for endpoint %s: %w", endpoint.Name, err)
+	}
+	return nil
+}
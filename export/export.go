@@ -1,11 +1,11 @@
 package export
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -39,33 +39,9 @@ func (le *LogExporter) ExportToCSV(logs []models.RequestLog) (string, error) {
 	}
 	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Write CSV headers
-	headers := []string{
-		"ID", "Timestamp", "Method", "Path", "SourceIP", "UserAgent", "Protocol",
-	}
-	if err := writer.Write(headers); err != nil {
-		return "", fmt.Errorf("error writing CSV headers: %v", err)
-	}
-
-	// Write log entries
-	for _, log := range logs {
-		record := []string{
-			log.ID,
-			log.Timestamp,
-			log.ClientRequest.Method,
-			log.ClientRequest.Path,
-			log.ClientRequest.SourceIP,
-			log.ClientRequest.UserAgent,
-			log.ClientRequest.Protocol,
-		}
-		if err := writer.Write(record); err != nil {
-			return "", fmt.Errorf("error writing log entry to CSV: %v", err)
-		}
+	if err := WriteCSV(file, logs); err != nil {
+		return "", err
 	}
-
 	return fullPath, nil
 }
 
@@ -100,9 +76,9 @@ type HARLog struct {
 }
 
 type HARContent struct {
-	Version string      `json:"version"`
-	Creator HARCreator  `json:"creator"`
-	Entries []HAREntry  `json:"entries"`
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
 }
 
 type HARCreator struct {
@@ -115,23 +91,39 @@ type HAREntry struct {
 	Time            float64     `json:"time"`
 	Request         HARRequest  `json:"request"`
 	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
 }
 
 type HARRequest struct {
-	Method      string            `json:"method"`
-	URL         string            `json:"url"`
-	HTTPVersion string            `json:"httpVersion"`
-	Headers     []HARNameValue    `json:"headers"`
-	QueryString []HARNameValue    `json:"queryString"`
-	PostData    *HARPostData      `json:"postData,omitempty"`
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
 }
 
 type HARResponse struct {
-	Status      int               `json:"status"`
-	StatusText  string            `json:"statusText"`
-	HTTPVersion string            `json:"httpVersion"`
-	Headers     []HARNameValue    `json:"headers"`
-	Content     HARContent_       `json:"content"`
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent_    `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARTimings splits an entry's time; -1 means the phase was not measured
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
 }
 
 type HARContent_ struct {
@@ -162,153 +154,152 @@ func (le *LogExporter) ExportToHAR(logs []models.RequestLog, side string) (strin
 	filename := fmt.Sprintf("request_logs_%s_%s.har", side, time.Now().Format("20060102_150405"))
 	fullPath := filepath.Join(le.outputDir, filename)
 
-	// Build HAR structure
-	entries := make([]HAREntry, 0, len(logs))
-	for _, log := range logs {
-		// Select client or backend side
-		var method, fullURL, body string
-		var queryParams, reqHeaders, respHeaders map[string][]string
-		var statusCode *int
-		var statusText string
-		var respBody string
-		var rttMs *int64
-
-		if side == "backend" && log.BackendRequest != nil {
-			method = log.BackendRequest.Method
-			fullURL = log.BackendRequest.FullURL
-			queryParams = log.BackendRequest.QueryParams
-			reqHeaders = log.BackendRequest.Headers
-			body = log.BackendRequest.Body
-
-			if log.BackendResponse != nil {
-				statusCode = log.BackendResponse.StatusCode
-				statusText = log.BackendResponse.StatusText
-				respHeaders = log.BackendResponse.Headers
-				respBody = log.BackendResponse.Body
-				rttMs = log.BackendResponse.RTTMs
-			}
-		} else {
-			method = log.ClientRequest.Method
-			fullURL = log.ClientRequest.FullURL
-			queryParams = log.ClientRequest.QueryParams
-			reqHeaders = log.ClientRequest.Headers
-			body = log.ClientRequest.Body
-
-			statusCode = log.ClientResponse.StatusCode
-			statusText = log.ClientResponse.StatusText
-			respHeaders = log.ClientResponse.Headers
-			respBody = log.ClientResponse.Body
-			rttMs = log.ClientResponse.RTTMs
-		}
-
-		// Parse query string
-		harQueryParams := []HARNameValue{}
-		for key, values := range queryParams {
-			for _, value := range values {
-				harQueryParams = append(harQueryParams, HARNameValue{Name: key, Value: value})
-			}
-		}
-
-		// Convert request headers
-		harReqHeaders := make([]HARNameValue, 0, len(reqHeaders))
-		for k, values := range reqHeaders {
-			for _, v := range values {
-				harReqHeaders = append(harReqHeaders, HARNameValue{Name: k, Value: v})
-			}
-		}
+	// Write to file
+	file, err := os.Create(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("could not create HAR file: %v", err)
+	}
+	defer file.Close()
 
-		// Convert response headers
-		harRespHeaders := make([]HARNameValue, 0, len(respHeaders))
-		for k, values := range respHeaders {
-			for _, v := range values {
-				harRespHeaders = append(harRespHeaders, HARNameValue{Name: k, Value: v})
-			}
-		}
+	if err := WriteHAR(file, logs, side); err != nil {
+		return "", err
+	}
+	return fullPath, nil
+}
 
-		// Build request
-		harReq := HARRequest{
-			Method:      method,
-			URL:         fullURL,
-			HTTPVersion: "HTTP/1.1",
-			Headers:     harReqHeaders,
-			QueryString: harQueryParams,
+// harEntry converts one request log to a HAR entry
+// side can be "client" or "backend"
+func harEntry(log *models.RequestLog, side string) HAREntry {
+	// Select client or backend side
+	var method, fullURL, body string
+	var queryParams, reqHeaders, respHeaders map[string][]string
+	var statusCode *int
+	var statusText string
+	var respBody string
+	var delayMs, rttMs *int64
+
+	if side == "backend" && log.BackendRequest != nil {
+		method = log.BackendRequest.Method
+		fullURL = log.BackendRequest.FullURL
+		queryParams = log.BackendRequest.QueryParams
+		reqHeaders = log.BackendRequest.Headers
+		body = log.BackendRequest.Body
+
+		if log.BackendResponse != nil {
+			statusCode = log.BackendResponse.StatusCode
+			statusText = log.BackendResponse.StatusText
+			respHeaders = log.BackendResponse.Headers
+			respBody = log.BackendResponse.Body
+			delayMs = log.BackendResponse.DelayMs
+			rttMs = log.BackendResponse.RTTMs
 		}
+	} else {
+		method = log.ClientRequest.Method
+		fullURL = log.ClientRequest.FullURL
+		queryParams = log.ClientRequest.QueryParams
+		reqHeaders = log.ClientRequest.Headers
+		body = log.ClientRequest.Body
+
+		statusCode = log.ClientResponse.StatusCode
+		statusText = log.ClientResponse.StatusText
+		respHeaders = log.ClientResponse.Headers
+		respBody = log.ClientResponse.Body
+		delayMs = log.ClientResponse.DelayMs
+		rttMs = log.ClientResponse.RTTMs
+	}
 
-		// Add post data if present
-		if body != "" {
-			contentType := ""
-			if values, ok := reqHeaders["Content-Type"]; ok && len(values) > 0 {
-				contentType = values[0]
-			}
-			harReq.PostData = &HARPostData{
-				MimeType: contentType,
-				Text:     body,
-			}
-		}
+	httpVersion := log.ClientRequest.Protocol
+	if httpVersion == "" {
+		httpVersion = "HTTP/1.1"
+	}
 
-		// Build response
-		status := 0
-		if statusCode != nil {
-			status = *statusCode
-		}
+	// Build request
+	harReq := HARRequest{
+		Method:      method,
+		URL:         fullURL,
+		HTTPVersion: httpVersion,
+		Cookies:     []HARNameValue{},
+		Headers:     harNameValues(reqHeaders),
+		QueryString: harNameValues(queryParams),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
 
-		respContentType := ""
-		if values, ok := respHeaders["Content-Type"]; ok && len(values) > 0 {
-			respContentType = values[0]
+	// Add post data if present
+	if body != "" {
+		harReq.PostData = &HARPostData{
+			MimeType: firstHeader(reqHeaders, "Content-Type"),
+			Text:     body,
 		}
+	}
 
-		harResp := HARResponse{
-			Status:      status,
-			StatusText:  statusText,
-			HTTPVersion: "HTTP/1.1",
-			Headers:     harRespHeaders,
-			Content: HARContent_{
-				Size:     len(respBody),
-				MimeType: respContentType,
-				Text:     respBody,
-			},
-		}
+	// Build response
+	status := 0
+	if statusCode != nil {
+		status = *statusCode
+	}
 
-		// Calculate time (RTT in ms)
-		timeMs := 0.0
-		if rttMs != nil {
-			timeMs = float64(*rttMs)
-		}
+	harResp := HARResponse{
+		Status:      status,
+		StatusText:  statusText,
+		HTTPVersion: httpVersion,
+		Cookies:     []HARNameValue{},
+		Headers:     harNameValues(respHeaders),
+		Content: HARContent_{
+			Size:     len(respBody),
+			MimeType: firstHeader(respHeaders, "Content-Type"),
+			Text:     respBody,
+		},
+		RedirectURL: firstHeader(respHeaders, "Location"),
+		HeadersSize: -1,
+		BodySize:    len(respBody),
+	}
 
-		entry := HAREntry{
-			StartedDateTime: log.Timestamp,
-			Time:            timeMs,
-			Request:         harReq,
-			Response:        harResp,
-		}
-		entries = append(entries, entry)
+	// Calculate time (RTT in ms), split into waiting for the first byte and receiving the rest
+	timeMs := 0.0
+	if rttMs != nil {
+		timeMs = float64(*rttMs)
+	}
+	timings := HARTimings{Send: 0, Wait: timeMs, Receive: 0}
+	if delayMs != nil && float64(*delayMs) <= timeMs {
+		timings.Wait = float64(*delayMs)
+		timings.Receive = timeMs - timings.Wait
 	}
 
-	har := HARLog{
-		Log: HARContent{
-			Version: "1.2",
-			Creator: HARCreator{
-				Name:    "Mockelot",
-				Version: "1.0",
-			},
-			Entries: entries,
-		},
+	return HAREntry{
+		StartedDateTime: log.Timestamp,
+		Time:            timeMs,
+		Request:         harReq,
+		Response:        harResp,
+		Timings:         timings,
 	}
+}
 
-	// Write to file
-	file, err := os.Create(fullPath)
-	if err != nil {
-		return "", fmt.Errorf("could not create HAR file: %v", err)
+// harNameValues flattens a header or query map into sorted HAR name/value pairs
+func harNameValues(values map[string][]string) []HARNameValue {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
 	}
-	defer file.Close()
+	sort.Strings(names)
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(har); err != nil {
-		return "", fmt.Errorf("error writing HAR file: %v", err)
+	pairs := make([]HARNameValue, 0, len(values))
+	for _, name := range names {
+		for _, value := range values[name] {
+			pairs = append(pairs, HARNameValue{Name: name, Value: value})
+		}
 	}
+	return pairs
+}
 
-	return fullPath, nil
+// firstHeader returns the first value of a header (case-insensitive), or ""
+func firstHeader(headers map[string][]string, name string) string {
+	for key, values := range headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
 }
 
 // ExportToCurl exports logs as a shell script with curl commands
@@ -341,47 +332,10 @@ func (le *LogExporter) ExportToCurl(logs []models.RequestLog, side string, endpo
 	fmt.Fprintf(file, "\n")
 
 	// Write curl commands for each log
-	for i, log := range logs {
-		var method, fullURL, path, body string
-		var headers map[string][]string
-
-		// Select client or backend side
-		if side == "backend" && log.BackendRequest != nil {
-			method = log.BackendRequest.Method
-			fullURL = log.BackendRequest.FullURL
-			path = log.BackendRequest.Path
-			headers = log.BackendRequest.Headers
-			body = log.BackendRequest.Body
-		} else {
-			method = log.ClientRequest.Method
-			fullURL = log.ClientRequest.FullURL
-			path = log.ClientRequest.Path
-			headers = log.ClientRequest.Headers
-			body = log.ClientRequest.Body
-		}
-
-		fmt.Fprintf(file, "# Request %d - %s %s\n", i+1, method, path)
-		fmt.Fprintf(file, "curl -X %s '%s'", method, escapeSingleQuote(fullURL))
-
-		// Add headers
-		for key, values := range headers {
-			// Skip certain headers that curl adds automatically
-			if key == "Host" || key == "User-Agent" || key == "Accept-Encoding" {
-				continue
-			}
-			for _, value := range values {
-				fmt.Fprintf(file, " \\\n  -H '%s: %s'", escapeSingleQuote(key), escapeSingleQuote(value))
-			}
-		}
-
-		// Add request body if present
-		if body != "" {
-			// Escape body for shell
-			escapedBody := escapeSingleQuote(body)
-			fmt.Fprintf(file, " \\\n  -d '%s'", escapedBody)
-		}
-
-		fmt.Fprintf(file, "\n\n")
+	for i := range logs {
+		req := snippetFor(&logs[i], side)
+		fmt.Fprintf(file, "# Request %d - %s %s\n", i+1, req.method, req.url)
+		fmt.Fprintf(file, "%s\n\n", curlCommand(req))
 	}
 
 	// Make script executable
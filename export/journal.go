@@ -0,0 +1,266 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+// Journal formats
+const (
+	JournalK6   = "k6"   // k6 load-test script (JavaScript)
+	JournalGo   = "go"   // Go test file using net/http
+	JournalHTTP = "http" // Plain HTTP request file (JetBrains / VS Code REST Client)
+)
+
+// journalSkipHeaders are set by the HTTP client itself and left out of replayed requests
+var journalSkipHeaders = map[string]bool{
+	"Host":              true,
+	"Content-Length":    true,
+	"Connection":        true,
+	"Accept-Encoding":   true,
+	"Transfer-Encoding": true,
+}
+
+// journalStep is one captured request to replay, with the status it received
+type journalStep struct {
+	method  string
+	target  string // Path and query, relative to the base URL
+	headers map[string][]string
+	names   []string // Header names in output order
+	body    string
+	status  int // 0 = no response was recorded
+}
+
+// ExportToJournal converts captured traffic into a runnable test artifact (format: "k6", "go" or "http").
+// Each request is replayed against BASE_URL (default: the origin of the first request) and checked
+// against the status code it originally received. side can be "client" or "backend".
+func (le *LogExporter) ExportToJournal(logs []models.RequestLog, format string, side string, endpointName string) (string, error) {
+	var extension string
+	switch format {
+	case JournalK6:
+		extension = "js"
+	case JournalGo:
+		extension = "go"
+	case JournalHTTP:
+		extension = "http"
+	default:
+		return "", fmt.Errorf("unknown journal format: %s", format)
+	}
+
+	steps, baseURL := journalSteps(logs, side)
+
+	var content string
+	switch format {
+	case JournalK6:
+		content = renderK6(steps, baseURL, endpointName)
+	case JournalGo:
+		content = renderGoTest(steps, baseURL, endpointName)
+	case JournalHTTP:
+		content = renderHTTPFile(steps, baseURL, endpointName)
+	}
+
+	// Ensure export directory exists
+	if err := os.MkdirAll(le.outputDir, 0755); err != nil {
+		return "", fmt.Errorf("could not create export directory: %v", err)
+	}
+
+	// Go only compiles test files named *_test.go
+	filename := fmt.Sprintf("replay_%s_%s.%s", side, time.Now().Format("20060102_150405"), extension)
+	if format == JournalGo {
+		filename = fmt.Sprintf("replay_%s_%s_test.go", side, time.Now().Format("20060102_150405"))
+	}
+	fullPath := filepath.Join(le.outputDir, filename)
+
+	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("could not write journal file: %v", err)
+	}
+	return fullPath, nil
+}
+
+// journalSteps extracts the requests to replay and the origin of the first one
+func journalSteps(logs []models.RequestLog, side string) ([]journalStep, string) {
+	baseURL := "http://localhost:8080"
+	steps := make([]journalStep, 0, len(logs))
+
+	for i, log := range logs {
+		var step journalStep
+		var fullURL string
+
+		// Select client or backend side
+		if side == "backend" && log.BackendRequest != nil {
+			step.method = log.BackendRequest.Method
+			fullURL = log.BackendRequest.FullURL
+			step.headers = log.BackendRequest.Headers
+			step.body = log.BackendRequest.Body
+			if log.BackendResponse != nil && log.BackendResponse.StatusCode != nil {
+				step.status = *log.BackendResponse.StatusCode
+			}
+		} else {
+			step.method = log.ClientRequest.Method
+			fullURL = log.ClientRequest.FullURL
+			step.headers = log.ClientRequest.Headers
+			step.body = log.ClientRequest.Body
+			if log.ClientResponse.StatusCode != nil {
+				step.status = *log.ClientResponse.StatusCode
+			}
+		}
+
+		step.target = fullURL
+		if parsed, err := url.Parse(fullURL); err == nil && parsed.Host != "" {
+			step.target = parsed.RequestURI()
+			if i == 0 {
+				baseURL = parsed.Scheme + "://" + parsed.Host
+			}
+		}
+
+		for name := range step.headers {
+			if !journalSkipHeaders[name] {
+				step.names = append(step.names, name)
+			}
+		}
+		sort.Strings(step.names)
+		steps = append(steps, step)
+	}
+	return steps, baseURL
+}
+
+// jsString quotes a string as a JavaScript string literal
+func jsString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+func renderK6(steps []journalStep, baseURL string, endpointName string) string {
+	var b strings.Builder
+	b.WriteString("import http from 'k6/http';\n")
+	b.WriteString("import { check } from 'k6';\n\n")
+	fmt.Fprintf(&b, "// Exported from Mockelot - %s\n", time.Now().Format(time.RFC3339))
+	if endpointName != "" {
+		fmt.Fprintf(&b, "// Endpoint: %s\n", endpointName)
+	}
+	fmt.Fprintf(&b, "// Total requests: %d\n", len(steps))
+	b.WriteString("// Run with: k6 run -e BASE_URL=http://host:port <this file>\n\n")
+	fmt.Fprintf(&b, "const BASE_URL = __ENV.BASE_URL || %s;\n\n", jsString(baseURL))
+	b.WriteString("export default function () {\n")
+	b.WriteString("  let res;\n")
+
+	for i, step := range steps {
+		fmt.Fprintf(&b, "\n  // Request %d - %s %s\n", i+1, step.method, step.target)
+		body := "null"
+		if step.body != "" {
+			body = jsString(step.body)
+		}
+		fmt.Fprintf(&b, "  res = http.request(%s, BASE_URL + %s, %s, {\n", jsString(step.method), jsString(step.target), body)
+		b.WriteString("    headers: {\n")
+		for _, name := range step.names {
+			fmt.Fprintf(&b, "      %s: %s,\n", jsString(name), jsString(strings.Join(step.headers[name], ", ")))
+		}
+		b.WriteString("    },\n")
+		b.WriteString("  });\n")
+		if step.status != 0 {
+			fmt.Fprintf(&b, "  check(res, { %s: (r) => r.status === %d });\n", jsString(fmt.Sprintf("request %d: status is %d", i+1, step.status)), step.status)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func renderGoTest(steps []journalStep, baseURL string, endpointName string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Exported from Mockelot - %s\n", time.Now().Format(time.RFC3339))
+	if endpointName != "" {
+		fmt.Fprintf(&b, "// Endpoint: %s\n", endpointName)
+	}
+	fmt.Fprintf(&b, "// Total requests: %d\n", len(steps))
+	b.WriteString("// Run with: BASE_URL=http://host:port go test -run TestReplay\n\n")
+	b.WriteString("package replay_test\n\n")
+	b.WriteString("import (\n\t\"net/http\"\n\t\"os\"\n\t\"strings\"\n\t\"testing\"\n)\n\n")
+	b.WriteString("func TestReplay(t *testing.T) {\n")
+	fmt.Fprintf(&b, "\tbaseURL := %s\n", strconv.Quote(baseURL))
+	b.WriteString("\tif env := os.Getenv(\"BASE_URL\"); env != \"\" {\n\t\tbaseURL = env\n\t}\n\n")
+	b.WriteString("\tsteps := []struct {\n")
+	b.WriteString("\t\tname    string\n\t\tmethod  string\n\t\ttarget  string\n\t\theaders map[string]string\n\t\tbody    string\n\t\tstatus  int // 0 = not checked\n")
+	b.WriteString("\t}{\n")
+	for i, step := range steps {
+		b.WriteString("\t\t{\n")
+		fmt.Fprintf(&b, "\t\t\tname:   %s,\n", strconv.Quote(fmt.Sprintf("%02d %s %s", i+1, step.method, step.target)))
+		fmt.Fprintf(&b, "\t\t\tmethod: %s,\n", strconv.Quote(step.method))
+		fmt.Fprintf(&b, "\t\t\ttarget: %s,\n", strconv.Quote(step.target))
+		if len(step.names) > 0 {
+			b.WriteString("\t\t\theaders: map[string]string{\n")
+			for _, name := range step.names {
+				fmt.Fprintf(&b, "\t\t\t\t%s: %s,\n", strconv.Quote(name), strconv.Quote(strings.Join(step.headers[name], ", ")))
+			}
+			b.WriteString("\t\t\t},\n")
+		}
+		if step.body != "" {
+			fmt.Fprintf(&b, "\t\t\tbody:   %s,\n", strconv.Quote(step.body))
+		}
+		if step.status != 0 {
+			fmt.Fprintf(&b, "\t\t\tstatus: %d,\n", step.status)
+		}
+		b.WriteString("\t\t},\n")
+	}
+	b.WriteString("\t}\n\n")
+	b.WriteString(`	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			req, err := http.NewRequest(step.method, baseURL+step.target, strings.NewReader(step.body))
+			if err != nil {
+				t.Fatalf("building request: %v", err)
+			}
+			for name, value := range step.headers {
+				req.Header.Set(name, value)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("sending request: %v", err)
+			}
+			resp.Body.Close()
+
+			if step.status != 0 && resp.StatusCode != step.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, step.status)
+			}
+		})
+	}
+}
+`)
+	return b.String()
+}
+
+func renderHTTPFile(steps []journalStep, baseURL string, endpointName string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Exported from Mockelot - %s\n", time.Now().Format(time.RFC3339))
+	if endpointName != "" {
+		fmt.Fprintf(&b, "# Endpoint: %s\n", endpointName)
+	}
+	fmt.Fprintf(&b, "# Total requests: %d\n\n", len(steps))
+	fmt.Fprintf(&b, "@baseUrl = %s\n", baseURL)
+
+	for i, step := range steps {
+		fmt.Fprintf(&b, "\n### Request %d - %s %s\n", i+1, step.method, step.target)
+		if step.status != 0 {
+			fmt.Fprintf(&b, "# Expected status: %d\n", step.status)
+		}
+		fmt.Fprintf(&b, "%s {{baseUrl}}%s\n", step.method, step.target)
+		for _, name := range step.names {
+			for _, value := range step.headers[name] {
+				fmt.Fprintf(&b, "%s: %s\n", name, value)
+			}
+		}
+		if step.body != "" {
+			fmt.Fprintf(&b, "\n%s\n", step.body)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,448 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mockelot/models"
+)
+
+const (
+	pcapSnapLen      = 262144
+	pcapLinkEthernet = 1
+	pcapSegmentSize  = 1460 // TCP payload per packet (Ethernet MTU)
+)
+
+// TCP flags
+const (
+	tcpFIN = 0x01
+	tcpSYN = 0x02
+	tcpPSH = 0x08
+	tcpACK = 0x10
+)
+
+var (
+	pcapClientMAC = net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	pcapServerMAC = net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x02}
+)
+
+// PcapWriter writes request logs as synthesized TCP sessions in the classic libpcap format.
+// Each exchange becomes a handshake, the HTTP/1.1 request and response, and a close, so Wireshark
+// decodes the traffic as HTTP. Safe for concurrent use.
+type PcapWriter struct {
+	mutex    sync.Mutex
+	w        io.Writer
+	nextPort uint16            // Ephemeral port for exchanges whose client port is unknown
+	hosts    map[string]net.IP // Resolved host names
+}
+
+// NewPcapWriter writes the pcap file header and returns a writer for the packets
+func NewPcapWriter(w io.Writer) (*PcapWriter, error) {
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:], 0xa1b2c3d4)
+	binary.LittleEndian.PutUint16(header[4:], 2)
+	binary.LittleEndian.PutUint16(header[6:], 4)
+	binary.LittleEndian.PutUint32(header[16:], pcapSnapLen)
+	binary.LittleEndian.PutUint32(header[20:], pcapLinkEthernet)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &PcapWriter{w: w, nextPort: 49152, hosts: make(map[string]net.IP)}, nil
+}
+
+// WriteLog writes the exchanges of a request log. side is "client" (client <-> mock), "backend"
+// (mock <-> backend, proxied requests only) or "both".
+func (p *PcapWriter) WriteLog(log *models.RequestLog, side string) error {
+	if side != "backend" {
+		if err := p.writeExchange(clientExchange(log)); err != nil {
+			return err
+		}
+	}
+	if side != "client" && log.BackendRequest != nil {
+		if err := p.writeExchange(backendExchange(log)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportToPcap writes logs to a .pcap file for Wireshark; side is "client", "backend" or "both"
+func (le *LogExporter) ExportToPcap(logs []models.RequestLog, side string) (string, error) {
+	if err := os.MkdirAll(le.outputDir, 0755); err != nil {
+		return "", fmt.Errorf("could not create export directory: %v", err)
+	}
+
+	filename := fmt.Sprintf("request_logs_%s_%s.pcap", side, time.Now().Format("20060102_150405"))
+	fullPath := filepath.Join(le.outputDir, filename)
+
+	file, err := os.Create(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("could not create pcap file: %v", err)
+	}
+	defer file.Close()
+
+	if err := WritePcap(file, logs, side); err != nil {
+		return "", err
+	}
+	return fullPath, nil
+}
+
+// WritePcap writes a pcap file with one synthesized TCP session per exchange
+func WritePcap(w io.Writer, logs []models.RequestLog, side string) error {
+	writer, err := NewPcapWriter(w)
+	if err != nil {
+		return err
+	}
+	for i := range logs {
+		if err := writer.WriteLog(&logs[i], side); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pcapExchange is one HTTP request/response pair on its own TCP connection
+type pcapExchange struct {
+	clientAddr string // "ip:port" (port may be missing)
+	serverURL  string
+	request    []byte
+	response   []byte // nil if no response was recorded
+	start      time.Time
+	duration   time.Duration // Request to end of response
+}
+
+func clientExchange(log *models.RequestLog) pcapExchange {
+	req := log.ClientRequest
+	ex := pcapExchange{
+		clientAddr: req.SourceIP,
+		serverURL:  req.FullURL,
+		request:    serializeRequest(req.Method, req.FullURL, req.Headers, req.Body),
+		start:      logTime(log),
+	}
+	resp := log.ClientResponse
+	if resp.StatusCode != nil {
+		ex.response = serializeResponse(*resp.StatusCode, resp.StatusText, resp.Headers, resp.Body)
+	}
+	if resp.RTTMs != nil {
+		ex.duration = time.Duration(*resp.RTTMs) * time.Millisecond
+	}
+	return ex
+}
+
+func backendExchange(log *models.RequestLog) pcapExchange {
+	req := log.BackendRequest
+	ex := pcapExchange{
+		clientAddr: "127.0.0.1",
+		serverURL:  req.FullURL,
+		request:    serializeRequest(req.Method, req.FullURL, req.Headers, req.Body),
+		start:      logTime(log),
+	}
+	if resp := log.BackendResponse; resp != nil {
+		if resp.StatusCode != nil {
+			ex.response = serializeResponse(*resp.StatusCode, resp.StatusText, resp.Headers, resp.Body)
+		}
+		if resp.RTTMs != nil {
+			ex.duration = time.Duration(*resp.RTTMs) * time.Millisecond
+		}
+	}
+	return ex
+}
+
+func logTime(log *models.RequestLog) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, log.Timestamp); err == nil {
+		return t
+	}
+	return time.Now()
+}
+
+// serializeRequest renders a logged request as HTTP/1.1 with a Content-Length matching the logged body
+func serializeRequest(method, rawURL string, headers map[string][]string, body string) []byte {
+	target, host := "/", ""
+	if parsed, err := url.Parse(rawURL); err == nil {
+		target = parsed.RequestURI()
+		host = parsed.Host
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s HTTP/1.1\r\n", method, target)
+	if len(headers["Host"]) == 0 && host != "" {
+		fmt.Fprintf(&buf, "Host: %s\r\n", host)
+	}
+	writeHeaders(&buf, headers, body)
+	buf.WriteString(body)
+	return buf.Bytes()
+}
+
+func serializeResponse(status int, statusText string, headers map[string][]string, body string) []byte {
+	if statusText == "" {
+		statusText = http.StatusText(status)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "HTTP/1.1 %d %s\r\n", status, statusText)
+	writeHeaders(&buf, headers, body)
+	buf.WriteString(body)
+	return buf.Bytes()
+}
+
+// writeHeaders writes headers sorted by name, replacing the framing headers with a Content-Length for body
+func writeHeaders(buf *bytes.Buffer, headers map[string][]string, body string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.EqualFold(name, "Content-Length") || strings.EqualFold(name, "Transfer-Encoding") {
+			continue
+		}
+		for _, value := range headers[name] {
+			fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+		}
+	}
+	fmt.Fprintf(buf, "Content-Length: %d\r\n\r\n", len(body))
+}
+
+// tcpEndpoint is one side of a synthesized connection
+type tcpEndpoint struct {
+	ip   net.IP
+	port uint16
+	mac  net.HardwareAddr
+	seq  uint32
+}
+
+func (p *PcapWriter) writeExchange(ex pcapExchange) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	server := tcpEndpoint{ip: net.IPv4(127, 0, 0, 1), port: 80, mac: pcapServerMAC, seq: 1000}
+	if parsed, err := url.Parse(ex.serverURL); err == nil {
+		if parsed.Scheme == "https" {
+			server.port = 443
+		}
+		if port, err := strconv.Atoi(parsed.Port()); err == nil {
+			server.port = uint16(port)
+		}
+		server.ip = p.hostIP(parsed.Hostname(), server.ip)
+	}
+
+	client := tcpEndpoint{ip: net.IPv4(127, 0, 0, 1), mac: pcapClientMAC, seq: 5000}
+	host, portText, err := net.SplitHostPort(ex.clientAddr)
+	if err != nil {
+		host = ex.clientAddr
+	}
+	client.ip = p.hostIP(host, client.ip)
+	if port, err := strconv.Atoi(portText); err == nil && port > 0 {
+		client.port = uint16(port)
+	} else {
+		client.port = p.nextPort
+		p.nextPort++
+		if p.nextPort == 0 {
+			p.nextPort = 49152
+		}
+	}
+	// Both ends of a packet must share an address family
+	if (client.ip.To4() == nil) != (server.ip.To4() == nil) {
+		if server.ip.To4() == nil {
+			client.ip = net.IPv6loopback
+		} else {
+			client.ip = net.IPv4(127, 0, 0, 1)
+		}
+	}
+
+	ts := ex.start
+	step := func(d time.Duration) time.Time {
+		ts = ts.Add(d)
+		return ts
+	}
+
+	// Handshake
+	if err := p.packet(step(0), &client, &server, tcpSYN, nil); err != nil {
+		return err
+	}
+	if err := p.packet(step(time.Microsecond*50), &server, &client, tcpSYN|tcpACK, nil); err != nil {
+		return err
+	}
+	if err := p.packet(step(time.Microsecond*50), &client, &server, tcpACK, nil); err != nil {
+		return err
+	}
+
+	if err := p.segments(step(time.Microsecond*50), &client, &server, ex.request); err != nil {
+		return err
+	}
+	if ex.response != nil {
+		if err := p.packet(step(time.Microsecond*50), &server, &client, tcpACK, nil); err != nil {
+			return err
+		}
+		responseAt := ex.start.Add(ex.duration)
+		if !responseAt.After(ts) {
+			responseAt = step(time.Microsecond * 50)
+		}
+		ts = responseAt
+		if err := p.segments(ts, &server, &client, ex.response); err != nil {
+			return err
+		}
+	}
+
+	// Close
+	if err := p.packet(step(time.Microsecond*50), &server, &client, tcpFIN|tcpACK, nil); err != nil {
+		return err
+	}
+	if err := p.packet(step(time.Microsecond*50), &client, &server, tcpFIN|tcpACK, nil); err != nil {
+		return err
+	}
+	return p.packet(step(time.Microsecond*50), &server, &client, tcpACK, nil)
+}
+
+// segments sends data in MSS-sized packets, the last one with PSH
+func (p *PcapWriter) segments(ts time.Time, from, to *tcpEndpoint, data []byte) error {
+	for len(data) > 0 {
+		n := min(len(data), pcapSegmentSize)
+		flags := byte(tcpACK)
+		if n == len(data) {
+			flags |= tcpPSH
+		}
+		if err := p.packet(ts, from, to, flags, data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// packet writes one Ethernet/IP/TCP frame and advances the sender's sequence number
+func (p *PcapWriter) packet(ts time.Time, from, to *tcpEndpoint, flags byte, payload []byte) error {
+	tcp := make([]byte, 20+len(payload))
+	binary.BigEndian.PutUint16(tcp[0:], from.port)
+	binary.BigEndian.PutUint16(tcp[2:], to.port)
+	binary.BigEndian.PutUint32(tcp[4:], from.seq)
+	if flags&tcpACK != 0 {
+		binary.BigEndian.PutUint32(tcp[8:], to.seq)
+	}
+	tcp[12] = 5 << 4 // Header length: 5 words
+	tcp[13] = flags
+	binary.BigEndian.PutUint16(tcp[14:], 65535) // Window
+	copy(tcp[20:], payload)
+
+	var frame []byte
+	if src4, dst4 := from.ip.To4(), to.ip.To4(); src4 != nil && dst4 != nil {
+		binary.BigEndian.PutUint16(tcp[16:], transportChecksum(ipv4PseudoHeader(src4, dst4, len(tcp)), tcp))
+		ip := make([]byte, 20)
+		ip[0] = 0x45
+		binary.BigEndian.PutUint16(ip[2:], uint16(20+len(tcp)))
+		ip[6] = 0x40 // Don't fragment
+		ip[8] = 64   // TTL
+		ip[9] = 6    // TCP
+		copy(ip[12:], src4)
+		copy(ip[16:], dst4)
+		binary.BigEndian.PutUint16(ip[10:], transportChecksum(nil, ip))
+		frame = ethernetFrame(from.mac, to.mac, 0x0800, append(ip, tcp...))
+	} else {
+		src16, dst16 := from.ip.To16(), to.ip.To16()
+		binary.BigEndian.PutUint16(tcp[16:], transportChecksum(ipv6PseudoHeader(src16, dst16, len(tcp)), tcp))
+		ip := make([]byte, 40)
+		ip[0] = 0x60
+		binary.BigEndian.PutUint16(ip[4:], uint16(len(tcp)))
+		ip[6] = 6  // TCP
+		ip[7] = 64 // Hop limit
+		copy(ip[8:], src16)
+		copy(ip[24:], dst16)
+		frame = ethernetFrame(from.mac, to.mac, 0x86dd, append(ip, tcp...))
+	}
+
+	record := make([]byte, 16)
+	binary.LittleEndian.PutUint32(record[0:], uint32(ts.Unix()))
+	binary.LittleEndian.PutUint32(record[4:], uint32(ts.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(record[8:], uint32(len(frame)))
+	binary.LittleEndian.PutUint32(record[12:], uint32(len(frame)))
+	if _, err := p.w.Write(append(record, frame...)); err != nil {
+		return err
+	}
+
+	// SYN and FIN consume a sequence number
+	from.seq += uint32(len(payload))
+	if flags&(tcpSYN|tcpFIN) != 0 {
+		from.seq++
+	}
+	return nil
+}
+
+func ethernetFrame(src, dst net.HardwareAddr, etherType uint16, payload []byte) []byte {
+	frame := make([]byte, 14, 14+len(payload))
+	copy(frame[0:], dst)
+	copy(frame[6:], src)
+	binary.BigEndian.PutUint16(frame[12:], etherType)
+	return append(frame, payload...)
+}
+
+func ipv4PseudoHeader(src, dst net.IP, length int) []byte {
+	pseudo := make([]byte, 12)
+	copy(pseudo[0:], src)
+	copy(pseudo[4:], dst)
+	pseudo[9] = 6
+	binary.BigEndian.PutUint16(pseudo[10:], uint16(length))
+	return pseudo
+}
+
+func ipv6PseudoHeader(src, dst net.IP, length int) []byte {
+	pseudo := make([]byte, 40)
+	copy(pseudo[0:], src)
+	copy(pseudo[16:], dst)
+	binary.BigEndian.PutUint32(pseudo[32:], uint32(length))
+	pseudo[39] = 6
+	return pseudo
+}
+
+// transportChecksum computes the Internet checksum over the pseudo-header and data
+func transportChecksum(pseudo, data []byte) uint16 {
+	var sum uint32
+	add := func(b []byte) {
+		for i := 0; i+1 < len(b); i += 2 {
+			sum += uint32(b[i])<<8 | uint32(b[i+1])
+		}
+		if len(b)%2 == 1 {
+			sum += uint32(b[len(b)-1]) << 8
+		}
+	}
+	add(pseudo)
+	add(data)
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
+
+// hostIP returns host as an IP, resolving names once per writer; fallback is used when that fails
+func (p *PcapWriter) hostIP(host string, fallback net.IP) net.IP {
+	if host == "" {
+		return fallback
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip
+	}
+	if strings.EqualFold(host, "localhost") {
+		return fallback
+	}
+	ip, cached := p.hosts[host]
+	if !cached {
+		if addrs, err := net.LookupIP(host); err == nil && len(addrs) > 0 {
+			ip = addrs[0]
+		}
+		p.hosts[host] = ip
+	}
+	if ip == nil {
+		return fallback
+	}
+	return ip
+}
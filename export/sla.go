@@ -0,0 +1,58 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"mockelot/models"
+)
+
+// ExportSLAToCSV writes per-backend SLA reports to a CSV file
+func (le *LogExporter) ExportSLAToCSV(reports []models.BackendSLA) (string, error) {
+	// Ensure export directory exists
+	if err := os.MkdirAll(le.outputDir, 0755); err != nil {
+		return "", fmt.Errorf("could not create export directory: %v", err)
+	}
+
+	// Generate filename with timestamp
+	filename := fmt.Sprintf("backend_sla_%s.csv", time.Now().Format("20060102_150405"))
+	fullPath := filepath.Join(le.outputDir, filename)
+
+	file, err := os.Create(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("could not create CSV file: %v", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	headers := []string{
+		"EndpointID", "Endpoint", "BackendURL", "Window", "From", "To",
+		"HealthChecks", "HealthyChecks", "UptimePercent",
+		"Requests", "SuccessfulRequests", "FailedRequests", "SuccessRatePercent",
+		"AvgLatencyMs", "P50LatencyMs", "P95LatencyMs", "P99LatencyMs", "MaxLatencyMs",
+	}
+	if err := writer.Write(headers); err != nil {
+		return "", fmt.Errorf("error writing CSV headers: %v", err)
+	}
+
+	for _, r := range reports {
+		record := []string{
+			r.EndpointID, r.EndpointName, r.BackendURL, r.Window, r.From, r.To,
+			strconv.Itoa(r.HealthChecks), strconv.Itoa(r.HealthyChecks), strconv.FormatFloat(r.UptimePercent, 'f', 2, 64),
+			strconv.Itoa(r.Requests), strconv.Itoa(r.SuccessfulRequests), strconv.Itoa(r.FailedRequests), strconv.FormatFloat(r.SuccessRatePercent, 'f', 2, 64),
+			strconv.FormatInt(r.AvgLatencyMs, 10), strconv.FormatInt(r.P50LatencyMs, 10), strconv.FormatInt(r.P95LatencyMs, 10),
+			strconv.FormatInt(r.P99LatencyMs, 10), strconv.FormatInt(r.MaxLatencyMs, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return "", fmt.Errorf("error writing SLA entry to CSV: %v", err)
+		}
+	}
+
+	return fullPath, nil
+}
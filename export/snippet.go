@@ -0,0 +1,171 @@
+package export
+
+import (
+	"fmt"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strings"
+
+	"mockelot/models"
+)
+
+// snippetSkipHeaders are computed by curl/HTTPie from the command and left out of snippets
+var snippetSkipHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Connection":        true,
+	"Transfer-Encoding": true,
+	"Accept-Encoding":   true, // Replaced by --compressed (curl) or the HTTPie default
+}
+
+// snippetRequest is the side of a logged request a snippet reproduces
+type snippetRequest struct {
+	method   string
+	url      string
+	headers  map[string][]string
+	body     string
+	protocol string
+}
+
+// GenerateSnippets builds curl and HTTPie commands reproducing a logged request.
+// Proxied requests also get commands for the request sent to the backend.
+func GenerateSnippets(log *models.RequestLog) models.CommandSnippets {
+	client := snippetFor(log, "client")
+	snippets := models.CommandSnippets{
+		Curl:   curlCommand(client),
+		HTTPie: httpieCommand(client),
+	}
+	if log.BackendRequest != nil {
+		backend := snippetFor(log, "backend")
+		snippets.BackendCurl = curlCommand(backend)
+		snippets.BackendHTTPie = httpieCommand(backend)
+	}
+	return snippets
+}
+
+func snippetFor(log *models.RequestLog, side string) snippetRequest {
+	if side == "backend" && log.BackendRequest != nil {
+		return snippetRequest{
+			method:  log.BackendRequest.Method,
+			url:     log.BackendRequest.FullURL,
+			headers: log.BackendRequest.Headers,
+			body:    log.BackendRequest.Body,
+		}
+	}
+	return snippetRequest{
+		method:   log.ClientRequest.Method,
+		url:      log.ClientRequest.FullURL,
+		headers:  log.ClientRequest.Headers,
+		body:     log.ClientRequest.Body,
+		protocol: log.ClientRequest.Protocol,
+	}
+}
+
+// curlCommand renders a request as a curl command line
+func curlCommand(req snippetRequest) string {
+	parts := []string{"curl"}
+	switch {
+	case req.method == "" || (req.method == "GET" && req.body == "") || (req.method == "POST" && req.body != ""):
+		// curl's default method for the request
+	case req.method == "HEAD" && req.body == "":
+		parts = append(parts, "--head")
+	default:
+		parts = append(parts, "-X "+req.method)
+	}
+	switch req.protocol {
+	case "HTTP/1.0":
+		parts = append(parts, "--http1.0")
+	case "HTTP/2", "HTTP/2.0":
+		parts = append(parts, "--http2")
+	}
+	parts = []string{strings.Join(append(parts, shellQuote(req.url)), " ")}
+
+	for _, header := range snippetHeaders(req) {
+		parts = append(parts, "-H "+shellQuote(curlHeader(header[0], header[1])))
+	}
+	if acceptsCompression(req.headers) {
+		parts = append(parts, "--compressed")
+	}
+	if req.body != "" {
+		parts = append(parts, "--data-binary "+shellQuote(req.body))
+	}
+	return strings.Join(parts, " \\\n  ")
+}
+
+// httpieCommand renders a request as an HTTPie command line
+func httpieCommand(req snippetRequest) string {
+	method := req.method
+	if method == "" {
+		method = "GET"
+	}
+	parts := []string{"http " + method + " " + shellQuote(req.url)}
+
+	for _, header := range snippetHeaders(req) {
+		if header[1] == "" {
+			parts = append(parts, shellQuote(header[0]+";"))
+		} else {
+			parts = append(parts, shellQuote(header[0]+":"+header[1]))
+		}
+	}
+	if req.body != "" {
+		parts = append(parts, "--raw "+shellQuote(req.body))
+	}
+	return strings.Join(parts, " \\\n  ")
+}
+
+// snippetHeaders returns the (name, value) pairs to send, sorted by name.
+// Host is only kept when it differs from the URL's host.
+func snippetHeaders(req snippetRequest) [][2]string {
+	names := make([]string, 0, len(req.headers))
+	for name := range req.headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	urlHost := ""
+	if parsed, err := url.Parse(req.url); err == nil {
+		urlHost = parsed.Host
+	}
+
+	var headers [][2]string
+	for _, name := range names {
+		canonical := textproto.CanonicalMIMEHeaderKey(name)
+		if snippetSkipHeaders[canonical] {
+			continue
+		}
+		for _, value := range req.headers[name] {
+			if canonical == "Host" && strings.EqualFold(value, urlHost) {
+				continue
+			}
+			headers = append(headers, [2]string{name, value})
+		}
+	}
+	return headers
+}
+
+// curlHeader formats a header for -H; "Name;" sends a header with an empty value
+func curlHeader(name, value string) string {
+	if value == "" {
+		return name + ";"
+	}
+	return fmt.Sprintf("%s: %s", name, value)
+}
+
+func acceptsCompression(headers map[string][]string) bool {
+	for name, values := range headers {
+		if !strings.EqualFold(name, "Accept-Encoding") {
+			continue
+		}
+		for _, value := range values {
+			if strings.Contains(value, "gzip") || strings.Contains(value, "deflate") || strings.Contains(value, "br") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shellQuote wraps a string in single quotes for POSIX shells
+func shellQuote(s string) string {
+	return "'" + escapeSingleQuote(s) + "'"
+}
@@ -0,0 +1,165 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"mockelot/models"
+)
+
+// csvCellLimit is the longest text Excel keeps in a cell; longer bodies are cut with a marker
+const csvCellLimit = 32000
+
+// WriteCSV streams logs as CSV, one row per request, flushing as it goes
+func WriteCSV(w io.Writer, logs []models.RequestLog) error {
+	writer := csv.NewWriter(w)
+
+	// Write CSV headers
+	headers := []string{
+		"ID", "Timestamp", "EndpointID", "Method", "URL", "Path", "Protocol", "SourceIP", "UserAgent",
+		"Status", "StatusText", "DelayMs", "RTTMs", "RequestContentType", "RequestBody",
+		"ResponseContentType", "ResponseBody", "BackendURL", "BackendStatus", "BackendRTTMs",
+		"ValidationFailed", "ResponseFailed",
+	}
+	if err := writer.Write(headers); err != nil {
+		return fmt.Errorf("error writing CSV headers: %v", err)
+	}
+
+	// Write log entries
+	for i := range logs {
+		log := &logs[i]
+		var backendURL, backendStatus, backendRTT string
+		if log.BackendRequest != nil {
+			backendURL = log.BackendRequest.FullURL
+		}
+		if log.BackendResponse != nil {
+			backendStatus = optionalInt(log.BackendResponse.StatusCode)
+			backendRTT = optionalInt64(log.BackendResponse.RTTMs)
+		}
+
+		record := []string{
+			log.ID,
+			log.Timestamp,
+			log.EndpointID,
+			log.ClientRequest.Method,
+			log.ClientRequest.FullURL,
+			log.ClientRequest.Path,
+			log.ClientRequest.Protocol,
+			log.ClientRequest.SourceIP,
+			log.ClientRequest.UserAgent,
+			optionalInt(log.ClientResponse.StatusCode),
+			log.ClientResponse.StatusText,
+			optionalInt64(log.ClientResponse.DelayMs),
+			optionalInt64(log.ClientResponse.RTTMs),
+			firstHeader(log.ClientRequest.Headers, "Content-Type"),
+			csvCell(log.ClientRequest.Body),
+			firstHeader(log.ClientResponse.Headers, "Content-Type"),
+			csvCell(log.ClientResponse.Body),
+			backendURL,
+			backendStatus,
+			backendRTT,
+			strconv.FormatBool(log.ValidationFailed),
+			strconv.FormatBool(log.ResponseFailed),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("error writing log entry to CSV: %v", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing CSV: %v", err)
+	}
+	return nil
+}
+
+// WriteNDJSON streams logs as newline-delimited JSON, one complete log per line
+func WriteNDJSON(w io.Writer, logs []models.RequestLog) error {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	for i := range logs {
+		if err := encoder.Encode(&logs[i]); err != nil {
+			return fmt.Errorf("error writing log entry to NDJSON: %v", err)
+		}
+	}
+	return buffered.Flush()
+}
+
+// WriteHAR streams logs as a HAR 1.2 archive, encoding one entry at a time
+// side can be "client" or "backend"
+func WriteHAR(w io.Writer, logs []models.RequestLog, side string) error {
+	buffered := bufio.NewWriter(w)
+	creator, _ := json.Marshal(HARCreator{Name: "Mockelot", Version: "1.0"})
+	fmt.Fprintf(buffered, "{\"log\":{\"version\":\"1.2\",\"creator\":%s,\"entries\":[\n", creator)
+
+	for i := range logs {
+		entry, err := json.Marshal(harEntry(&logs[i], side))
+		if err != nil {
+			return fmt.Errorf("error writing HAR entry: %v", err)
+		}
+		if i > 0 {
+			buffered.WriteString(",\n")
+		}
+		if _, err := buffered.Write(entry); err != nil {
+			return fmt.Errorf("error writing HAR file: %v", err)
+		}
+	}
+
+	buffered.WriteString("\n]}}\n")
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("error writing HAR file: %v", err)
+	}
+	return nil
+}
+
+// ExportToNDJSON exports logs as newline-delimited JSON
+func (le *LogExporter) ExportToNDJSON(logs []models.RequestLog) (string, error) {
+	// Ensure export directory exists
+	if err := os.MkdirAll(le.outputDir, 0755); err != nil {
+		return "", fmt.Errorf("could not create export directory: %v", err)
+	}
+
+	// Generate filename with timestamp
+	filename := fmt.Sprintf("request_logs_%s.ndjson", time.Now().Format("20060102_150405"))
+	fullPath := filepath.Join(le.outputDir, filename)
+
+	file, err := os.Create(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("could not create NDJSON file: %v", err)
+	}
+	defer file.Close()
+
+	if err := WriteNDJSON(file, logs); err != nil {
+		return "", err
+	}
+	return fullPath, nil
+}
+
+// csvCell cuts text that would overflow a spreadsheet cell
+func csvCell(text string) string {
+	if len(text) <= csvCellLimit {
+		return text
+	}
+	return text[:csvCellLimit] + fmt.Sprintf("...[truncated, %d bytes total]", len(text))
+}
+
+func optionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func optionalInt64(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(*value, 10)
+}
@@ -12,6 +12,8 @@ import {
   GetRequestLogs,
   ClearRequestLogs,
   ImportOpenAPISpecWithDialog,
+  ExportBundleWithDialog,
+  ImportBundleWithDialog,
   GetCACertInfo,
   RegenerateCA,
   DownloadCACert,
@@ -32,7 +34,22 @@ import {
   RestartContainer,
   GetContainerStatus,
   GetRequestLogDetails,
-  PollRequestLogs
+  PollRequestLogs,
+  ListWorkspaces,
+  CreateWorkspace,
+  SwitchWorkspace,
+  RenameWorkspace,
+  CloseWorkspace,
+  Undo,
+  Redo,
+  GetUndoStatus,
+  ListConfigBackups,
+  RestoreConfigBackup,
+  GetRecoveries,
+  RestoreRecovery,
+  DiscardRecovery,
+  DuplicateEndpoint,
+  DuplicateResponse
 } from '../../wailsjs/go/main/App'
 import { EventsOn, EventsOff } from '../../wailsjs/runtime/runtime'
 
@@ -70,6 +87,12 @@ export const useServerStore = defineStore('server', () => {
   const isDirty = ref(false)
   const currentFilePath = ref<string>('')
 
+  // Workspace State
+  const workspaces = ref<models.WorkspaceInfo[]>([])
+
+  // Undo State
+  const undoStatus = ref<models.UndoStatus>(new models.UndoStatus({ can_undo: false, can_redo: false }))
+
   // Unsaved Changes Dialog State
   const showUnsavedChangesDialog = ref(false)
   let unsavedChangesResolve: ((value: boolean) => void) | null = null
@@ -292,6 +315,50 @@ export const useServerStore = defineStore('server', () => {
     }
   }
 
+  // Config bundles (the imported config arrives through config:loaded)
+  async function exportBundle(): Promise<models.BundleReport | null> {
+    return await ExportBundleWithDialog()
+  }
+
+  async function importBundle(): Promise<models.BundleReport | null> {
+    return await ImportBundleWithDialog()
+  }
+
+  // Undo Actions (the restored endpoints and items arrive through their update events)
+  async function undo() {
+    undoStatus.value = await Undo()
+  }
+
+  async function redo() {
+    undoStatus.value = await Redo()
+  }
+
+  async function refreshUndoStatus() {
+    undoStatus.value = await GetUndoStatus()
+  }
+
+  // Config Backups
+  async function listBackups(): Promise<models.ConfigBackup[]> {
+    return (await ListConfigBackups()) || []
+  }
+
+  async function restoreBackup(id: string) {
+    config.value = await RestoreConfigBackup(id)
+  }
+
+  // Crash Recovery (unsaved changes autosaved by an earlier session)
+  async function getRecoveries(): Promise<models.RecoveryInfo[]> {
+    return (await GetRecoveries()) || []
+  }
+
+  async function restoreRecovery(id: string) {
+    config.value = await RestoreRecovery(id)
+  }
+
+  async function discardRecovery(id: string) {
+    await DiscardRecovery(id)
+  }
+
   // Endpoint Actions
   async function refreshEndpoints() {
     try {
@@ -364,6 +431,23 @@ export const useServerStore = defineStore('server', () => {
     }
   }
 
+  // Copies an endpoint with all of its responses and selects the copy
+  async function duplicateEndpointById(id: string) {
+    try {
+      const copy = await DuplicateEndpoint(id)
+      await refreshEndpoints()
+      await selectEndpoint(copy.id)
+    } catch (error) {
+      console.error('Failed to duplicate endpoint:', error)
+      throw error
+    }
+  }
+
+  // Copies a response or group in place (the items arrive through items:updated)
+  async function duplicateResponse(id: string): Promise<string> {
+    return await DuplicateResponse(id)
+  }
+
   // HTTPS Actions
   async function loadCAInfo() {
     try {
@@ -500,6 +584,58 @@ export const useServerStore = defineStore('server', () => {
     }
   }
 
+  // Workspace Actions
+  async function refreshWorkspaces() {
+    try {
+      workspaces.value = (await ListWorkspaces()) || []
+    } catch (error) {
+      console.error('Failed to list workspaces:', error)
+    }
+  }
+
+  async function createWorkspace(name: string) {
+    const info = await CreateWorkspace(name)
+    await refreshWorkspaces()
+    return info
+  }
+
+  async function switchWorkspace(id: string) {
+    await SwitchWorkspace(id)
+    await refreshWorkspaces()
+  }
+
+  async function renameWorkspace(id: string, name: string) {
+    await RenameWorkspace(id, name)
+    await refreshWorkspaces()
+  }
+
+  async function closeWorkspace(id: string) {
+    await CloseWorkspace(id)
+    await refreshWorkspaces()
+  }
+
+  // reloadWorkspace replaces everything shown with the state of the newly active workspace
+  async function reloadWorkspace() {
+    requestLogs.value = []
+    requestLogCache.value.clear()
+    selectedLogId.value = null
+    scriptErrors.value = new Map()
+    endpointHealth.value = new Map()
+    containerStatus.value = new Map()
+    containerStats.value = new Map()
+
+    await refreshStatus()
+    await refreshConfig()
+    await refreshEndpoints()
+    if (!endpoints.value.some(ep => ep.id === selectedEndpointId.value) && endpoints.value.length > 0) {
+      await selectEndpoint(endpoints.value[0].id)
+    } else {
+      await refreshItems()
+    }
+    await refreshLogs()
+    await refreshUndoStatus()
+  }
+
   // Start health polling for proxy and container endpoints
   let healthPollingInterval: number | null = null
 
@@ -602,6 +738,8 @@ export const useServerStore = defineStore('server', () => {
     EventsOff('config:path')
     EventsOff('config:port-changed')
     EventsOff('config:loaded')
+    EventsOff('workspaces:updated')
+    EventsOff('workspace:switched')
     // NOTE: ctr:* events are handled via polling in HeaderBar.vue
 
     console.log('Setting up script:error event listener')
@@ -662,6 +800,10 @@ export const useServerStore = defineStore('server', () => {
       isDirty.value = dirty
     })
 
+    EventsOn('undo:changed', (newStatus: models.UndoStatus) => {
+      undoStatus.value = newStatus
+    })
+
     EventsOn('config:path', (path: string) => {
       currentFilePath.value = path
     })
@@ -681,6 +823,16 @@ export const useServerStore = defineStore('server', () => {
       await refreshItems()
     })
 
+    // Workspace events - another workspace's config, endpoints and logs are now active
+    EventsOn('workspaces:updated', (list: models.WorkspaceInfo[]) => {
+      workspaces.value = list || []
+    })
+
+    EventsOn('workspace:switched', async () => {
+      console.log('Workspace switched, reloading store...')
+      await reloadWorkspace()
+    })
+
     // NOTE: ctr:status, ctr:stats, and ctr:progress events are now handled via polling
     // in HeaderBar.vue, which updates the store directly
 
@@ -688,6 +840,8 @@ export const useServerStore = defineStore('server', () => {
     refreshItems()
     refreshConfig()
     refreshEndpoints()
+    refreshWorkspaces()
+    refreshUndoStatus()
 
     // Load selected endpoint ID
     GetSelectedEndpointId().then(id => {
@@ -722,6 +876,8 @@ export const useServerStore = defineStore('server', () => {
     isDirty,
     currentFilePath,
     showUnsavedChangesDialog,
+    workspaces,
+    undoStatus,
     // Getters
     isRunning,
     port,
@@ -750,6 +906,17 @@ export const useServerStore = defineStore('server', () => {
     clearLogs,
     selectLog,
     importOpenAPISpec,
+    exportBundle,
+    importBundle,
+    // Undo and Backup Actions
+    undo,
+    redo,
+    refreshUndoStatus,
+    listBackups,
+    restoreBackup,
+    getRecoveries,
+    restoreRecovery,
+    discardRecovery,
     // Endpoint Actions
     refreshEndpoints,
     selectEndpoint,
@@ -757,6 +924,8 @@ export const useServerStore = defineStore('server', () => {
     addNewEndpointWithConfig,
     updateEndpointById,
     deleteEndpointById,
+    duplicateEndpointById,
+    duplicateResponse,
     // HTTPS Actions
     loadCAInfo,
     regenerateCA,
@@ -772,6 +941,12 @@ export const useServerStore = defineStore('server', () => {
     getFilePath,
     checkUnsavedChanges,
     respondToUnsavedChanges,
+    // Workspace Actions
+    refreshWorkspaces,
+    createWorkspace,
+    switchWorkspace,
+    renameWorkspace,
+    closeWorkspace,
     // Health Check Actions
     refreshEndpointHealth,
     testProxyConnection,
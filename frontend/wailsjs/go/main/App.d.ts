@@ -13,34 +13,114 @@ export function AddRecentFile(arg1:string):Promise<void>;
 
 export function AddResponse(arg1:models.MethodResponse):Promise<models.MethodResponse>;
 
+export function AdvanceVirtualClock(arg1:number):Promise<string>;
+
+export function ApplyCachePreset(arg1:string,arg2:string,arg3:number):Promise<void>;
+
+export function ApplyEncodingPreset(arg1:string,arg2:string):Promise<void>;
+
 export function CancelContainerStart(arg1:string):Promise<void>;
 
+export function CancelScheduledAction(arg1:string):Promise<void>;
+
+export function ClearAppLogs():Promise<void>;
+
 export function ClearRequestLogs():Promise<void>;
 
 export function ClearScriptErrors(arg1:string):Promise<void>;
 
+export function ClearTrafficExamples(arg1:string):Promise<number>;
+
+export function CloseWorkspace(arg1:string):Promise<void>;
+
+export function ContainerHosts():Promise<Array<models.ContainerHost>>;
+
+export function CrawlProxyEndpoint(arg1:string,arg2:models.CrawlOptions):Promise<models.CrawlResult>;
+
+export function CreateWorkspace(arg1:string):Promise<models.WorkspaceInfo>;
+
+export function DefaultContainerHost():Promise<string>;
+
 export function DeleteContainer(arg1:string):Promise<void>;
 
 export function DeleteEndpoint(arg1:string):Promise<void>;
 
+export function DeleteMacro(arg1:string):Promise<void>;
+
+export function DeleteProfile(arg1:string):Promise<void>;
+
 export function DeleteResponse(arg1:string):Promise<void>;
 
+export function DiscardDrafts():Promise<number>;
+
+export function DiscardRecovery(arg1:string):Promise<void>;
+
 export function DownloadCACert():Promise<string>;
 
+export function DuplicateEndpoint(arg1:string):Promise<models.Endpoint>;
+
+export function DuplicateResponse(arg1:string):Promise<string>;
+
 export function Emit(arg1:string,arg2:any):Promise<void>;
 
+export function EmitEvent(arg1:string,arg2:string):Promise<number>;
+
+export function ExportBackendSLA(arg1:string):Promise<string>;
+
+export function ExportBundle(arg1:string):Promise<models.BundleReport>;
+
+export function ExportBundleWithDialog():Promise<models.BundleReport>;
+
+export function ExportDockerImageSpec(arg1:string):Promise<models.DockerImageSpec>;
+
+export function ExportKubernetesManifests(arg1:string):Promise<string>;
+
 export function ExportLogs(arg1:string):Promise<void>;
 
 export function ExportLogsAsCurl(arg1:string,arg2:string):Promise<void>;
 
 export function ExportLogsAsHAR(arg1:string,arg2:string):Promise<void>;
 
+export function ExportLogsAsJournal(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function ExportLogsAsPcap(arg1:string,arg2:string):Promise<string>;
+
+export function ExportOpenAPISpec(arg1:string):Promise<string>;
+
+export function ExportServerCertificate(arg1:boolean,arg2:string):Promise<Array<string>>;
+
+export function GenerateCurlCommand(arg1:string):Promise<models.CommandSnippets>;
+
+export function GenerateMismatchedCertificate(arg1:Array<string>,arg2:string):Promise<string>;
+
+export function GetActiveEnvironment():Promise<string>;
+
+export function GetActiveListeners():Promise<Array<string>>;
+
+export function GetActiveProfile():Promise<string>;
+
+export function GetAdminAPISettings():Promise<models.AdminAPISettings>;
+
 export function GetAllResponseIDsWithErrors():Promise<Array<string>>;
 
+export function GetAppLogs(arg1:string,arg2:string,arg3:string):Promise<Array<models.AppLogEntry>>;
+
+export function GetBackendSLA(arg1:string,arg2:string):Promise<models.BackendSLA>;
+
+export function GetBypassRuleStats():Promise<Array<models.BypassRuleStats>>;
+
 export function GetCACertInfo():Promise<models.CACertInfo>;
 
 export function GetCORSConfig():Promise<models.CORSConfig>;
 
+export function GetCertificateDetails():Promise<Array<models.CertificateDetails>>;
+
+export function GetCircuitBreakers():Promise<Array<models.CircuitBreakerStatus>>;
+
+export function GetClientThrottleStats():Promise<Array<models.ClientThrottleStats>>;
+
+export function GetClientThrottles():Promise<Array<models.ClientThrottleRule>>;
+
 export function GetConfig():Promise<models.AppConfig>;
 
 export function GetContainerLogs(arg1:string,arg2:number):Promise<string>;
@@ -51,40 +131,130 @@ export function GetContainerStatus(arg1:string):Promise<models.ContainerStatus>;
 
 export function GetCurrentConfigPath():Promise<string>;
 
+export function GetDNSConfig():Promise<models.DNSConfig>;
+
 export function GetDefaultCertNames():Promise<Array<string>>;
 
 export function GetDefaultContainerHeaders():Promise<Array<models.HeaderManipulation>>;
 
+export function GetDraftCount():Promise<number>;
+
+export function GetEndpoint(arg1:string):Promise<models.Endpoint>;
+
 export function GetEndpointHealth(arg1:string):Promise<models.HealthStatus>;
 
+export function GetEndpointListenerPorts():Promise<Array<number>>;
+
+export function GetEndpointTypes():Promise<Array<models.EndpointTypeInfo>>;
+
 export function GetEndpoints():Promise<Array<models.Endpoint>>;
 
+export function GetEnvironments():Promise<Array<string>>;
+
+export function GetGRPCConfig():Promise<models.GRPCConfig>;
+
+export function GetGRPCMethods():Promise<Array<models.GRPCMethodInfo>>;
+
 export function GetItems():Promise<Array<models.ResponseItem>>;
 
+export function GetJWTKeys():Promise<Array<models.JWTSigningKey>>;
+
+export function GetListeners():Promise<Array<models.Listener>>;
+
+export function GetLogSettings():Promise<models.LogSettings>;
+
+export function GetMacroRecording():Promise<string>;
+
+export function GetMacros():Promise<Array<models.Macro>>;
+
+export function GetMarketplaceSources():Promise<Array<models.MarketplaceSource>>;
+
+export function GetOfflineMode():Promise<models.OfflineModeStatus>;
+
+export function GetPACFile():Promise<string>;
+
+export function GetPACURL():Promise<string>;
+
+export function GetPatternErrors():Promise<Array<models.PatternError>>;
+
+export function GetPcapCapture():Promise<string>;
+
+export function GetProfiles():Promise<Record<string, Record<string, string>>>;
+
+export function GetProxyBackends(arg1:string):Promise<Array<models.ProxyBackendStatus>>;
+
 export function GetRecentFiles():Promise<Array<models.RecentFile>>;
 
+export function GetRecoveries():Promise<Array<models.RecoveryInfo>>;
+
 export function GetRequestLogByID(arg1:string):Promise<models.RequestLog>;
 
 export function GetRequestLogDetails(arg1:string):Promise<models.RequestLog>;
 
 export function GetRequestLogs():Promise<Array<models.RequestLogSummary>>;
 
+export function GetRequestLogsByTraffic(arg1:string):Promise<Array<models.RequestLogSummary>>;
+
+export function GetResponsePerfStats():Promise<Array<models.ResponsePerfStats>>;
+
 export function GetResponses():Promise<Array<models.MethodResponse>>;
 
+export function GetRetryAfterReport():Promise<models.RetryAfterReport>;
+
+export function GetRuntimeHealth():Promise<models.RuntimeHealth>;
+
 export function GetSOCKS5Config():Promise<main.SOCKS5ConfigResponse>;
 
+export function GetSchedule():Promise<Array<models.ScheduledActionStatus>>;
+
+export function GetScheduledActions():Promise<Array<models.ScheduledAction>>;
+
 export function GetScriptErrors(arg1:string):Promise<Array<main.ScriptErrorLog>>;
 
+export function GetScriptFetchConfig():Promise<models.ScriptFetchConfig>;
+
+export function GetScriptModules():Promise<Array<models.ScriptModule>>;
+
+export function GetScriptWarnings():Promise<Array<models.ScriptWarning>>;
+
 export function GetSelectedEndpointId():Promise<string>;
 
 export function GetServerStatus():Promise<main.ServerStatus>;
 
+export function GetStorageSettings():Promise<models.StorageSettings>;
+
+export function GetTrafficStats():Promise<Array<models.TrafficStats>>;
+
+export function GetTransactions(arg1:string):Promise<Array<models.Transaction>>;
+
+export function GetUndoStatus():Promise<models.UndoStatus>;
+
+export function GetVariables():Promise<Record<string, string>>;
+
+export function GetVirtualTime():Promise<string>;
+
+export function ImportBundle(arg1:string,arg2:string):Promise<models.BundleReport>;
+
+export function ImportBundleWithDialog():Promise<models.BundleReport>;
+
+export function ImportDockerComposeWithDialog(arg1:string):Promise<Promise<models.Endpoint>>;
+
+export function ImportHARWithDialog(arg1:boolean):Promise<models.AppConfig>;
+
 export function ImportOpenAPISpecWithDialog(arg1:boolean):Promise<models.AppConfig>;
 
 export function InstallCACertSystem():Promise<void>;
 
+export function InstallMarketplaceBundle(arg1:string,arg2:string):Promise<Array<models.Endpoint>>;
+
 export function IsDirty():Promise<boolean>;
 
+export function ListConfigBackups():Promise<Array<models.ConfigBackup>>;
+
+export function ListMarketplaceBundles():Promise<Array<models.MarketplaceBundle>>;
+
+export function ListWorkspaces():Promise<Array<models.WorkspaceInfo>>;
+
 export function LoadConfig():Promise<models.AppConfig>;
 
 export function LoadConfigFromPath(arg1:string):Promise<models.AppConfig>;
@@ -97,48 +267,142 @@ export function MarkClean():Promise<void>;
 
 export function MarkDirty():Promise<void>;
 
+export function MergeConfigs(arg1:string,arg2:string,arg3:string):Promise<models.MergeReport>;
+
 export function PollEvents():Promise<Array<main.Event>>;
 
 export function PollRequestLogs():Promise<Array<models.RequestLogSummary>>;
 
+export function PreviewMergeConfigs(arg1:string,arg2:string,arg3:string):Promise<models.MergeReport>;
+
+export function PublishDrafts():Promise<number>;
+
 export function PullDockerImage(arg1:string):Promise<void>;
 
+export function Redo():Promise<models.UndoStatus>;
+
 export function RegenerateCA():Promise<void>;
 
+export function RegistryCredentials():Promise<Array<models.RegistryCredential>>;
+
 export function RemoveRecentFile(arg1:string):Promise<void>;
 
+export function RenameWorkspace(arg1:string,arg2:string):Promise<void>;
+
 export function ReorderResponses(arg1:Array<string>):Promise<void>;
 
+export function ReplayRequest(arg1:string,arg2:string):Promise<models.ReplayResult>;
+
+export function ReplayRequests(arg1:Array<string>,arg2:string):Promise<Array<models.ReplayResult>>;
+
+export function ResetBypassRuleStats():Promise<void>;
+
+export function ResetCircuitBreaker(arg1:string):Promise<void>;
+
+export function ResetClientThrottleStats():Promise<void>;
+
+export function ResetResponsePerfStats():Promise<void>;
+
+export function ResetSequences(arg1:string):Promise<void>;
+
+export function ResetState():Promise<void>;
+
+export function ResetTrafficStats():Promise<void>;
+
 export function RestartContainer(arg1:string):Promise<void>;
 
+export function RestoreConfigBackup(arg1:string):Promise<models.AppConfig>;
+
+export function RestoreRecovery(arg1:string):Promise<models.AppConfig>;
+
+export function RunMacro(arg1:string):Promise<void>;
+
 export function SaveConfig():Promise<void>;
 
+export function SaveConfigToDirectory(arg1:string):Promise<void>;
+
+export function SaveConfigToDirectoryWithDialog():Promise<void>;
+
 export function SaveCurrentConfig():Promise<void>;
 
+export function SaveCurrentConfigWithMessage(arg1:string):Promise<void>;
+
+export function ScheduleAction(arg1:models.ScheduledAction):Promise<models.ScheduledActionStatus>;
+
+export function SearchRequestLogs(arg1:models.RequestLogFilter):Promise<models.RequestLogPage>;
+
 export function SelectCertFile(arg1:string):Promise<string>;
 
 export function SendEvent(arg1:string,arg2:any):Promise<void>;
 
+export function SetActiveEnvironment(arg1:string):Promise<void>;
+
+export function SetActiveProfile(arg1:string):Promise<void>;
+
+export function SetAdminAPISettings(arg1:models.AdminAPISettings):Promise<models.AdminAPISettings>;
+
+export function SetClientThrottles(arg1:Array<models.ClientThrottleRule>):Promise<void>;
+
+export function SetDNSConfig(arg1:models.DNSConfig):Promise<void>;
+
+export function SetGRPCConfig(arg1:models.GRPCConfig):Promise<Array<models.GRPCMethodInfo>>;
+
 export function SetItems(arg1:Array<models.ResponseItem>):Promise<void>;
 
+export function SetJWTKeys(arg1:Array<models.JWTSigningKey>):Promise<void>;
+
+export function SetListeners(arg1:Array<models.Listener>):Promise<void>;
+
+export function SetLogSettings(arg1:models.LogSettings):Promise<void>;
+
+export function SetOfflineMode(arg1:boolean):Promise<models.OfflineModeStatus>;
+
+export function SetProfile(arg1:string,arg2:Record<string, string>):Promise<void>;
+
 export function SetResponses(arg1:Array<models.MethodResponse>):Promise<void>;
 
+export function SetScheduledActions(arg1:Array<models.ScheduledAction>):Promise<void>;
+
+export function SetScriptFetchConfig(arg1:models.ScriptFetchConfig):Promise<void>;
+
+export function SetScriptModules(arg1:Array<models.ScriptModule>):Promise<void>;
+
 export function SetSelectedEndpointId(arg1:string):Promise<void>;
 
+export function SetStorageSettings(arg1:models.StorageSettings):Promise<void>;
+
+export function SetVariables(arg1:Record<string, string>):Promise<void>;
+
+export function SetVirtualClock(arg1:models.VirtualClock):Promise<void>;
+
 export function StartContainer(arg1:string):Promise<void>;
 
 export function StartContainers():Promise<void>;
 
+export function StartMacroRecording(arg1:string):Promise<void>;
+
+export function StartPcapCapture(arg1:string):Promise<string>;
+
 export function StartServer(arg1:number):Promise<void>;
 
 export function StopContainer(arg1:string):Promise<void>;
 
+export function StopMacroRecording():Promise<models.Macro>;
+
+export function StopPcapCapture():Promise<void>;
+
 export function StopServer():Promise<void>;
 
+export function SwitchWorkspace(arg1:string):Promise<void>;
+
 export function TestContainerConfig(arg1:Record<string, any>):Promise<void>;
 
+export function TestContainerHost(arg1:models.ContainerHost):Promise<string>;
+
 export function TestProxyConnection(arg1:string):Promise<void>;
 
+export function Undo():Promise<models.UndoStatus>;
+
 export function UpdateEndpoint(arg1:models.Endpoint):Promise<void>;
 
 export function UpdateRequestLog(arg1:models.RequestLog):Promise<void>;
@@ -155,4 +419,8 @@ export function ValidateCORSHeaderExpression(arg1:string):Promise<void>;
 
 export function ValidateCORSScript(arg1:string):Promise<void>;
 
+export function ValidateDelayExpression(arg1:string):Promise<void>;
+
 export function ValidateDockerImage(arg1:string):Promise<void>;
+
+export function ValidateEndpointPatterns(arg1:models.Endpoint):Promise<Array<models.PatternError>>;
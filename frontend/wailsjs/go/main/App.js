@@ -22,10 +22,30 @@ export function AddResponse(arg1) {
   return window['go']['main']['App']['AddResponse'](arg1);
 }
 
+export function AdvanceVirtualClock(arg1) {
+  return window['go']['main']['App']['AdvanceVirtualClock'](arg1);
+}
+
+export function ApplyCachePreset(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ApplyCachePreset'](arg1, arg2, arg3);
+}
+
+export function ApplyEncodingPreset(arg1, arg2) {
+  return window['go']['main']['App']['ApplyEncodingPreset'](arg1, arg2);
+}
+
 export function CancelContainerStart(arg1) {
   return window['go']['main']['App']['CancelContainerStart'](arg1);
 }
 
+export function CancelScheduledAction(arg1) {
+  return window['go']['main']['App']['CancelScheduledAction'](arg1);
+}
+
+export function ClearAppLogs() {
+  return window['go']['main']['App']['ClearAppLogs']();
+}
+
 export function ClearRequestLogs() {
   return window['go']['main']['App']['ClearRequestLogs']();
 }
@@ -34,6 +54,30 @@ export function ClearScriptErrors(arg1) {
   return window['go']['main']['App']['ClearScriptErrors'](arg1);
 }
 
+export function ClearTrafficExamples(arg1) {
+  return window['go']['main']['App']['ClearTrafficExamples'](arg1);
+}
+
+export function CloseWorkspace(arg1) {
+  return window['go']['main']['App']['CloseWorkspace'](arg1);
+}
+
+export function ContainerHosts() {
+  return window['go']['main']['App']['ContainerHosts']();
+}
+
+export function CrawlProxyEndpoint(arg1, arg2) {
+  return window['go']['main']['App']['CrawlProxyEndpoint'](arg1, arg2);
+}
+
+export function CreateWorkspace(arg1) {
+  return window['go']['main']['App']['CreateWorkspace'](arg1);
+}
+
+export function DefaultContainerHost() {
+  return window['go']['main']['App']['DefaultContainerHost']();
+}
+
 export function DeleteContainer(arg1) {
   return window['go']['main']['App']['DeleteContainer'](arg1);
 }
@@ -42,18 +86,66 @@ export function DeleteEndpoint(arg1) {
   return window['go']['main']['App']['DeleteEndpoint'](arg1);
 }
 
+export function DeleteMacro(arg1) {
+  return window['go']['main']['App']['DeleteMacro'](arg1);
+}
+
+export function DeleteProfile(arg1) {
+  return window['go']['main']['App']['DeleteProfile'](arg1);
+}
+
 export function DeleteResponse(arg1) {
   return window['go']['main']['App']['DeleteResponse'](arg1);
 }
 
+export function DiscardDrafts() {
+  return window['go']['main']['App']['DiscardDrafts']();
+}
+
+export function DiscardRecovery(arg1) {
+  return window['go']['main']['App']['DiscardRecovery'](arg1);
+}
+
 export function DownloadCACert() {
   return window['go']['main']['App']['DownloadCACert']();
 }
 
+export function DuplicateEndpoint(arg1) {
+  return window['go']['main']['App']['DuplicateEndpoint'](arg1);
+}
+
+export function DuplicateResponse(arg1) {
+  return window['go']['main']['App']['DuplicateResponse'](arg1);
+}
+
 export function Emit(arg1, arg2) {
   return window['go']['main']['App']['Emit'](arg1, arg2);
 }
 
+export function EmitEvent(arg1, arg2) {
+  return window['go']['main']['App']['EmitEvent'](arg1, arg2);
+}
+
+export function ExportBackendSLA(arg1) {
+  return window['go']['main']['App']['ExportBackendSLA'](arg1);
+}
+
+export function ExportBundle(arg1) {
+  return window['go']['main']['App']['ExportBundle'](arg1);
+}
+
+export function ExportBundleWithDialog() {
+  return window['go']['main']['App']['ExportBundleWithDialog']();
+}
+
+export function ExportDockerImageSpec(arg1) {
+  return window['go']['main']['App']['ExportDockerImageSpec'](arg1);
+}
+
+export function ExportKubernetesManifests(arg1) {
+  return window['go']['main']['App']['ExportKubernetesManifests'](arg1);
+}
+
 export function ExportLogs(arg1) {
   return window['go']['main']['App']['ExportLogs'](arg1);
 }
@@ -66,10 +158,62 @@ export function ExportLogsAsHAR(arg1, arg2) {
   return window['go']['main']['App']['ExportLogsAsHAR'](arg1, arg2);
 }
 
+export function ExportLogsAsJournal(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportLogsAsJournal'](arg1, arg2, arg3);
+}
+
+export function ExportLogsAsPcap(arg1, arg2) {
+  return window['go']['main']['App']['ExportLogsAsPcap'](arg1, arg2);
+}
+
+export function ExportOpenAPISpec(arg1) {
+  return window['go']['main']['App']['ExportOpenAPISpec'](arg1);
+}
+
+export function ExportServerCertificate(arg1, arg2) {
+  return window['go']['main']['App']['ExportServerCertificate'](arg1, arg2);
+}
+
+export function GenerateCurlCommand(arg1) {
+  return window['go']['main']['App']['GenerateCurlCommand'](arg1);
+}
+
+export function GenerateMismatchedCertificate(arg1, arg2) {
+  return window['go']['main']['App']['GenerateMismatchedCertificate'](arg1, arg2);
+}
+
+export function GetActiveEnvironment() {
+  return window['go']['main']['App']['GetActiveEnvironment']();
+}
+
+export function GetActiveListeners() {
+  return window['go']['main']['App']['GetActiveListeners']();
+}
+
+export function GetActiveProfile() {
+  return window['go']['main']['App']['GetActiveProfile']();
+}
+
+export function GetAdminAPISettings() {
+  return window['go']['main']['App']['GetAdminAPISettings']();
+}
+
 export function GetAllResponseIDsWithErrors() {
   return window['go']['main']['App']['GetAllResponseIDsWithErrors']();
 }
 
+export function GetAppLogs(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetAppLogs'](arg1, arg2, arg3);
+}
+
+export function GetBackendSLA(arg1, arg2) {
+  return window['go']['main']['App']['GetBackendSLA'](arg1, arg2);
+}
+
+export function GetBypassRuleStats() {
+  return window['go']['main']['App']['GetBypassRuleStats']();
+}
+
 export function GetCACertInfo() {
   return window['go']['main']['App']['GetCACertInfo']();
 }
@@ -78,6 +222,22 @@ export function GetCORSConfig() {
   return window['go']['main']['App']['GetCORSConfig']();
 }
 
+export function GetCertificateDetails() {
+  return window['go']['main']['App']['GetCertificateDetails']();
+}
+
+export function GetCircuitBreakers() {
+  return window['go']['main']['App']['GetCircuitBreakers']();
+}
+
+export function GetClientThrottleStats() {
+  return window['go']['main']['App']['GetClientThrottleStats']();
+}
+
+export function GetClientThrottles() {
+  return window['go']['main']['App']['GetClientThrottles']();
+}
+
 export function GetConfig() {
   return window['go']['main']['App']['GetConfig']();
 }
@@ -98,6 +258,10 @@ export function GetCurrentConfigPath() {
   return window['go']['main']['App']['GetCurrentConfigPath']();
 }
 
+export function GetDNSConfig() {
+  return window['go']['main']['App']['GetDNSConfig']();
+}
+
 export function GetDefaultCertNames() {
   return window['go']['main']['App']['GetDefaultCertNames']();
 }
@@ -106,22 +270,106 @@ export function GetDefaultContainerHeaders() {
   return window['go']['main']['App']['GetDefaultContainerHeaders']();
 }
 
+export function GetDraftCount() {
+  return window['go']['main']['App']['GetDraftCount']();
+}
+
+export function GetEndpoint(arg1) {
+  return window['go']['main']['App']['GetEndpoint'](arg1);
+}
+
 export function GetEndpointHealth(arg1) {
   return window['go']['main']['App']['GetEndpointHealth'](arg1);
 }
 
+export function GetEndpointListenerPorts() {
+  return window['go']['main']['App']['GetEndpointListenerPorts']();
+}
+
+export function GetEndpointTypes() {
+  return window['go']['main']['App']['GetEndpointTypes']();
+}
+
 export function GetEndpoints() {
   return window['go']['main']['App']['GetEndpoints']();
 }
 
+export function GetEnvironments() {
+  return window['go']['main']['App']['GetEnvironments']();
+}
+
+export function GetGRPCConfig() {
+  return window['go']['main']['App']['GetGRPCConfig']();
+}
+
+export function GetGRPCMethods() {
+  return window['go']['main']['App']['GetGRPCMethods']();
+}
+
 export function GetItems() {
   return window['go']['main']['App']['GetItems']();
 }
 
+export function GetJWTKeys() {
+  return window['go']['main']['App']['GetJWTKeys']();
+}
+
+export function GetListeners() {
+  return window['go']['main']['App']['GetListeners']();
+}
+
+export function GetLogSettings() {
+  return window['go']['main']['App']['GetLogSettings']();
+}
+
+export function GetMacroRecording() {
+  return window['go']['main']['App']['GetMacroRecording']();
+}
+
+export function GetMacros() {
+  return window['go']['main']['App']['GetMacros']();
+}
+
+export function GetMarketplaceSources() {
+  return window['go']['main']['App']['GetMarketplaceSources']();
+}
+
+export function GetOfflineMode() {
+  return window['go']['main']['App']['GetOfflineMode']();
+}
+
+export function GetPACFile() {
+  return window['go']['main']['App']['GetPACFile']();
+}
+
+export function GetPACURL() {
+  return window['go']['main']['App']['GetPACURL']();
+}
+
+export function GetPatternErrors() {
+  return window['go']['main']['App']['GetPatternErrors']();
+}
+
+export function GetPcapCapture() {
+  return window['go']['main']['App']['GetPcapCapture']();
+}
+
+export function GetProfiles() {
+  return window['go']['main']['App']['GetProfiles']();
+}
+
+export function GetProxyBackends(arg1) {
+  return window['go']['main']['App']['GetProxyBackends'](arg1);
+}
+
 export function GetRecentFiles() {
   return window['go']['main']['App']['GetRecentFiles']();
 }
 
+export function GetRecoveries() {
+  return window['go']['main']['App']['GetRecoveries']();
+}
+
 export function GetRequestLogByID(arg1) {
   return window['go']['main']['App']['GetRequestLogByID'](arg1);
 }
@@ -134,18 +382,54 @@ export function GetRequestLogs() {
   return window['go']['main']['App']['GetRequestLogs']();
 }
 
+export function GetRequestLogsByTraffic(arg1) {
+  return window['go']['main']['App']['GetRequestLogsByTraffic'](arg1);
+}
+
+export function GetResponsePerfStats() {
+  return window['go']['main']['App']['GetResponsePerfStats']();
+}
+
 export function GetResponses() {
   return window['go']['main']['App']['GetResponses']();
 }
 
+export function GetRetryAfterReport() {
+  return window['go']['main']['App']['GetRetryAfterReport']();
+}
+
+export function GetRuntimeHealth() {
+  return window['go']['main']['App']['GetRuntimeHealth']();
+}
+
 export function GetSOCKS5Config() {
   return window['go']['main']['App']['GetSOCKS5Config']();
 }
 
+export function GetSchedule() {
+  return window['go']['main']['App']['GetSchedule']();
+}
+
+export function GetScheduledActions() {
+  return window['go']['main']['App']['GetScheduledActions']();
+}
+
 export function GetScriptErrors(arg1) {
   return window['go']['main']['App']['GetScriptErrors'](arg1);
 }
 
+export function GetScriptFetchConfig() {
+  return window['go']['main']['App']['GetScriptFetchConfig']();
+}
+
+export function GetScriptModules() {
+  return window['go']['main']['App']['GetScriptModules']();
+}
+
+export function GetScriptWarnings() {
+  return window['go']['main']['App']['GetScriptWarnings']();
+}
+
 export function GetSelectedEndpointId() {
   return window['go']['main']['App']['GetSelectedEndpointId']();
 }
@@ -154,6 +438,46 @@ export function GetServerStatus() {
   return window['go']['main']['App']['GetServerStatus']();
 }
 
+export function GetStorageSettings() {
+  return window['go']['main']['App']['GetStorageSettings']();
+}
+
+export function GetTrafficStats() {
+  return window['go']['main']['App']['GetTrafficStats']();
+}
+
+export function GetTransactions(arg1) {
+  return window['go']['main']['App']['GetTransactions'](arg1);
+}
+
+export function GetUndoStatus() {
+  return window['go']['main']['App']['GetUndoStatus']();
+}
+
+export function GetVariables() {
+  return window['go']['main']['App']['GetVariables']();
+}
+
+export function GetVirtualTime() {
+  return window['go']['main']['App']['GetVirtualTime']();
+}
+
+export function ImportBundle(arg1, arg2) {
+  return window['go']['main']['App']['ImportBundle'](arg1, arg2);
+}
+
+export function ImportBundleWithDialog() {
+  return window['go']['main']['App']['ImportBundleWithDialog']();
+}
+
+export function ImportDockerComposeWithDialog(arg1) {
+  return window['go']['main']['App']['ImportDockerComposeWithDialog'](arg1);
+}
+
+export function ImportHARWithDialog(arg1) {
+  return window['go']['main']['App']['ImportHARWithDialog'](arg1);
+}
+
 export function ImportOpenAPISpecWithDialog(arg1) {
   return window['go']['main']['App']['ImportOpenAPISpecWithDialog'](arg1);
 }
@@ -162,10 +486,26 @@ export function InstallCACertSystem() {
   return window['go']['main']['App']['InstallCACertSystem']();
 }
 
+export function InstallMarketplaceBundle(arg1, arg2) {
+  return window['go']['main']['App']['InstallMarketplaceBundle'](arg1, arg2);
+}
+
 export function IsDirty() {
   return window['go']['main']['App']['IsDirty']();
 }
 
+export function ListConfigBackups() {
+  return window['go']['main']['App']['ListConfigBackups']();
+}
+
+export function ListMarketplaceBundles() {
+  return window['go']['main']['App']['ListMarketplaceBundles']();
+}
+
+export function ListWorkspaces() {
+  return window['go']['main']['App']['ListWorkspaces']();
+}
+
 export function LoadConfig() {
   return window['go']['main']['App']['LoadConfig']();
 }
@@ -190,6 +530,10 @@ export function MarkDirty() {
   return window['go']['main']['App']['MarkDirty']();
 }
 
+export function MergeConfigs(arg1, arg2, arg3) {
+  return window['go']['main']['App']['MergeConfigs'](arg1, arg2, arg3);
+}
+
 export function PollEvents() {
   return window['go']['main']['App']['PollEvents']();
 }
@@ -198,34 +542,122 @@ export function PollRequestLogs() {
   return window['go']['main']['App']['PollRequestLogs']();
 }
 
+export function PreviewMergeConfigs(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PreviewMergeConfigs'](arg1, arg2, arg3);
+}
+
+export function PublishDrafts() {
+  return window['go']['main']['App']['PublishDrafts']();
+}
+
 export function PullDockerImage(arg1) {
   return window['go']['main']['App']['PullDockerImage'](arg1);
 }
 
+export function Redo() {
+  return window['go']['main']['App']['Redo']();
+}
+
 export function RegenerateCA() {
   return window['go']['main']['App']['RegenerateCA']();
 }
 
+export function RegistryCredentials() {
+  return window['go']['main']['App']['RegistryCredentials']();
+}
+
 export function RemoveRecentFile(arg1) {
   return window['go']['main']['App']['RemoveRecentFile'](arg1);
 }
 
+export function RenameWorkspace(arg1, arg2) {
+  return window['go']['main']['App']['RenameWorkspace'](arg1, arg2);
+}
+
 export function ReorderResponses(arg1) {
   return window['go']['main']['App']['ReorderResponses'](arg1);
 }
 
+export function ReplayRequest(arg1, arg2) {
+  return window['go']['main']['App']['ReplayRequest'](arg1, arg2);
+}
+
+export function ReplayRequests(arg1, arg2) {
+  return window['go']['main']['App']['ReplayRequests'](arg1, arg2);
+}
+
+export function ResetBypassRuleStats() {
+  return window['go']['main']['App']['ResetBypassRuleStats']();
+}
+
+export function ResetCircuitBreaker(arg1) {
+  return window['go']['main']['App']['ResetCircuitBreaker'](arg1);
+}
+
+export function ResetClientThrottleStats() {
+  return window['go']['main']['App']['ResetClientThrottleStats']();
+}
+
+export function ResetResponsePerfStats() {
+  return window['go']['main']['App']['ResetResponsePerfStats']();
+}
+
+export function ResetSequences(arg1) {
+  return window['go']['main']['App']['ResetSequences'](arg1);
+}
+
+export function ResetState() {
+  return window['go']['main']['App']['ResetState']();
+}
+
+export function ResetTrafficStats() {
+  return window['go']['main']['App']['ResetTrafficStats']();
+}
+
 export function RestartContainer(arg1) {
   return window['go']['main']['App']['RestartContainer'](arg1);
 }
 
+export function RestoreConfigBackup(arg1) {
+  return window['go']['main']['App']['RestoreConfigBackup'](arg1);
+}
+
+export function RestoreRecovery(arg1) {
+  return window['go']['main']['App']['RestoreRecovery'](arg1);
+}
+
+export function RunMacro(arg1) {
+  return window['go']['main']['App']['RunMacro'](arg1);
+}
+
 export function SaveConfig() {
   return window['go']['main']['App']['SaveConfig']();
 }
 
+export function SaveConfigToDirectory(arg1) {
+  return window['go']['main']['App']['SaveConfigToDirectory'](arg1);
+}
+
+export function SaveConfigToDirectoryWithDialog() {
+  return window['go']['main']['App']['SaveConfigToDirectoryWithDialog']();
+}
+
 export function SaveCurrentConfig() {
   return window['go']['main']['App']['SaveCurrentConfig']();
 }
 
+export function SaveCurrentConfigWithMessage(arg1) {
+  return window['go']['main']['App']['SaveCurrentConfigWithMessage'](arg1);
+}
+
+export function ScheduleAction(arg1) {
+  return window['go']['main']['App']['ScheduleAction'](arg1);
+}
+
+export function SearchRequestLogs(arg1) {
+  return window['go']['main']['App']['SearchRequestLogs'](arg1);
+}
+
 export function SelectCertFile(arg1) {
   return window['go']['main']['App']['SelectCertFile'](arg1);
 }
@@ -234,18 +666,86 @@ export function SendEvent(arg1, arg2) {
   return window['go']['main']['App']['SendEvent'](arg1, arg2);
 }
 
+export function SetActiveEnvironment(arg1) {
+  return window['go']['main']['App']['SetActiveEnvironment'](arg1);
+}
+
+export function SetActiveProfile(arg1) {
+  return window['go']['main']['App']['SetActiveProfile'](arg1);
+}
+
+export function SetAdminAPISettings(arg1) {
+  return window['go']['main']['App']['SetAdminAPISettings'](arg1);
+}
+
+export function SetClientThrottles(arg1) {
+  return window['go']['main']['App']['SetClientThrottles'](arg1);
+}
+
+export function SetDNSConfig(arg1) {
+  return window['go']['main']['App']['SetDNSConfig'](arg1);
+}
+
+export function SetGRPCConfig(arg1) {
+  return window['go']['main']['App']['SetGRPCConfig'](arg1);
+}
+
 export function SetItems(arg1) {
   return window['go']['main']['App']['SetItems'](arg1);
 }
 
+export function SetJWTKeys(arg1) {
+  return window['go']['main']['App']['SetJWTKeys'](arg1);
+}
+
+export function SetListeners(arg1) {
+  return window['go']['main']['App']['SetListeners'](arg1);
+}
+
+export function SetLogSettings(arg1) {
+  return window['go']['main']['App']['SetLogSettings'](arg1);
+}
+
+export function SetOfflineMode(arg1) {
+  return window['go']['main']['App']['SetOfflineMode'](arg1);
+}
+
+export function SetProfile(arg1, arg2) {
+  return window['go']['main']['App']['SetProfile'](arg1, arg2);
+}
+
 export function SetResponses(arg1) {
   return window['go']['main']['App']['SetResponses'](arg1);
 }
 
+export function SetScheduledActions(arg1) {
+  return window['go']['main']['App']['SetScheduledActions'](arg1);
+}
+
+export function SetScriptFetchConfig(arg1) {
+  return window['go']['main']['App']['SetScriptFetchConfig'](arg1);
+}
+
+export function SetScriptModules(arg1) {
+  return window['go']['main']['App']['SetScriptModules'](arg1);
+}
+
 export function SetSelectedEndpointId(arg1) {
   return window['go']['main']['App']['SetSelectedEndpointId'](arg1);
 }
 
+export function SetStorageSettings(arg1) {
+  return window['go']['main']['App']['SetStorageSettings'](arg1);
+}
+
+export function SetVariables(arg1) {
+  return window['go']['main']['App']['SetVariables'](arg1);
+}
+
+export function SetVirtualClock(arg1) {
+  return window['go']['main']['App']['SetVirtualClock'](arg1);
+}
+
 export function StartContainer(arg1) {
   return window['go']['main']['App']['StartContainer'](arg1);
 }
@@ -254,6 +754,14 @@ export function StartContainers() {
   return window['go']['main']['App']['StartContainers']();
 }
 
+export function StartMacroRecording(arg1) {
+  return window['go']['main']['App']['StartMacroRecording'](arg1);
+}
+
+export function StartPcapCapture(arg1) {
+  return window['go']['main']['App']['StartPcapCapture'](arg1);
+}
+
 export function StartServer(arg1) {
   return window['go']['main']['App']['StartServer'](arg1);
 }
@@ -262,18 +770,38 @@ export function StopContainer(arg1) {
   return window['go']['main']['App']['StopContainer'](arg1);
 }
 
+export function StopMacroRecording() {
+  return window['go']['main']['App']['StopMacroRecording']();
+}
+
+export function StopPcapCapture() {
+  return window['go']['main']['App']['StopPcapCapture']();
+}
+
 export function StopServer() {
   return window['go']['main']['App']['StopServer']();
 }
 
+export function SwitchWorkspace(arg1) {
+  return window['go']['main']['App']['SwitchWorkspace'](arg1);
+}
+
 export function TestContainerConfig(arg1) {
   return window['go']['main']['App']['TestContainerConfig'](arg1);
 }
 
+export function TestContainerHost(arg1) {
+  return window['go']['main']['App']['TestContainerHost'](arg1);
+}
+
 export function TestProxyConnection(arg1) {
   return window['go']['main']['App']['TestProxyConnection'](arg1);
 }
 
+export function Undo() {
+  return window['go']['main']['App']['Undo']();
+}
+
 export function UpdateEndpoint(arg1) {
   return window['go']['main']['App']['UpdateEndpoint'](arg1);
 }
@@ -306,6 +834,14 @@ export function ValidateCORSScript(arg1) {
   return window['go']['main']['App']['ValidateCORSScript'](arg1);
 }
 
+export function ValidateDelayExpression(arg1) {
+  return window['go']['main']['App']['ValidateDelayExpression'](arg1);
+}
+
 export function ValidateDockerImage(arg1) {
   return window['go']['main']['App']['ValidateDockerImage'](arg1);
 }
+
+export function ValidateEndpointPatterns(arg1) {
+  return window['go']['main']['App']['ValidateEndpointPatterns'](arg1);
+}
@@ -106,34 +106,46 @@ export namespace main {
 
 export namespace models {
 	
-	export class DomainConfig {
-	    id: string;
-	    pattern: string;
-	    overlay_mode: boolean;
-	    enabled: boolean;
+	export class ResponseDefaults {
+	    status_code?: number;
+	    headers?: Record<string, string>;
+	    response_delay?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new DomainConfig(source);
+	        return new ResponseDefaults(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.pattern = source["pattern"];
-	        this.overlay_mode = source["overlay_mode"];
-	        this.enabled = source["enabled"];
+	        this.status_code = source["status_code"];
+	        this.headers = source["headers"];
+	        this.response_delay = source["response_delay"];
 	    }
 	}
-	export class DomainTakeoverConfig {
-	    domains: DomainConfig[];
+	export class ResponseGroup {
+	    id?: string;
+	    name: string;
+	    expanded?: boolean;
+	    enabled?: boolean;
+	    use_global_cors?: boolean;
+	    defaults?: ResponseDefaults;
+	    deprecation?: DeprecationPolicy;
+	    responses?: MethodResponse[];
 	
 	    static createFrom(source: any = {}) {
-	        return new DomainTakeoverConfig(source);
+	        return new ResponseGroup(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.domains = this.convertValues(source["domains"], DomainConfig);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.expanded = source["expanded"];
+	        this.enabled = source["enabled"];
+	        this.use_global_cors = source["use_global_cors"];
+	        this.defaults = this.convertValues(source["defaults"], ResponseDefaults);
+	        this.deprecation = this.convertValues(source["deprecation"], DeprecationPolicy);
+	        this.responses = this.convertValues(source["responses"], MethodResponse);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -154,60 +166,42 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class SOCKS5Config {
-	    enabled: boolean;
-	    port: number;
-	    authentication: boolean;
-	    username?: string;
-	    password?: string;
-	    track_requests: boolean;
-	
-	    static createFrom(source: any = {}) {
-	        return new SOCKS5Config(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.enabled = source["enabled"];
-	        this.port = source["port"];
-	        this.authentication = source["authentication"];
-	        this.username = source["username"];
-	        this.password = source["password"];
-	        this.track_requests = source["track_requests"];
-	    }
-	}
-	export class CORSHeader {
+	export class ParameterSchema {
 	    name: string;
-	    expression: string;
+	    in: string;
+	    required?: boolean;
+	    schema?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new CORSHeader(source);
+	        return new ParameterSchema(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.expression = source["expression"];
+	        this.in = source["in"];
+	        this.required = source["required"];
+	        this.schema = source["schema"];
 	    }
 	}
-	export class CORSConfig {
+	export class SchemaValidation {
 	    enabled: boolean;
-	    mode?: string;
-	    header_expressions?: CORSHeader[];
-	    script?: string;
-	    options_default_status?: number;
+	    body?: string;
+	    body_required?: boolean;
+	    parameters?: ParameterSchema[];
+	    reject?: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new CORSConfig(source);
+	        return new SchemaValidation(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.enabled = source["enabled"];
-	        this.mode = source["mode"];
-	        this.header_expressions = this.convertValues(source["header_expressions"], CORSHeader);
-	        this.script = source["script"];
-	        this.options_default_status = source["options_default_status"];
+	        this.body = source["body"];
+	        this.body_required = source["body_required"];
+	        this.parameters = this.convertValues(source["parameters"], ParameterSchema);
+	        this.reject = source["reject"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -228,88 +222,48 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class CertPaths {
-	    ca_cert_path?: string;
-	    ca_key_path?: string;
-	    server_cert_path?: string;
-	    server_key_path?: string;
-	    server_bundle_path?: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new CertPaths(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.ca_cert_path = source["ca_cert_path"];
-	        this.ca_key_path = source["ca_key_path"];
-	        this.server_cert_path = source["server_cert_path"];
-	        this.server_key_path = source["server_key_path"];
-	        this.server_bundle_path = source["server_bundle_path"];
-	    }
-	}
-	export class EnvironmentVar {
+	export class HeaderValidation {
 	    name: string;
+	    mode?: string;
 	    value?: string;
+	    pattern?: string;
 	    expression?: string;
+	    required?: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new EnvironmentVar(source);
+	        return new HeaderValidation(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
+	        this.mode = source["mode"];
 	        this.value = source["value"];
+	        this.pattern = source["pattern"];
 	        this.expression = source["expression"];
+	        this.required = source["required"];
 	    }
 	}
-	export class VolumeMapping {
-	    host_path: string;
-	    container_path: string;
-	    read_only: boolean;
-	
-	    static createFrom(source: any = {}) {
-	        return new VolumeMapping(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.host_path = source["host_path"];
-	        this.container_path = source["container_path"];
-	        this.read_only = source["read_only"];
-	    }
-	}
-	export class ContainerConfig {
-	    proxy_config: ProxyConfig;
-	    image_name: string;
-	    container_port: number;
-	    exposed_ports?: string[];
-	    pull_on_startup: boolean;
-	    restart_policy?: string;
-	    volumes?: VolumeMapping[];
-	    environment?: EnvironmentVar[];
-	    host_networking?: boolean;
-	    docker_socket_access?: boolean;
-	    restart_on_server_start?: boolean;
+	export class RequestValidation {
+	    mode?: string;
+	    pattern?: string;
+	    match_type?: string;
+	    script?: string;
+	    headers?: HeaderValidation[];
+	    schema?: SchemaValidation;
 	
 	    static createFrom(source: any = {}) {
-	        return new ContainerConfig(source);
+	        return new RequestValidation(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.proxy_config = this.convertValues(source["proxy_config"], ProxyConfig);
-	        this.image_name = source["image_name"];
-	        this.container_port = source["container_port"];
-	        this.exposed_ports = source["exposed_ports"];
-	        this.pull_on_startup = source["pull_on_startup"];
-	        this.restart_policy = source["restart_policy"];
-	        this.volumes = this.convertValues(source["volumes"], VolumeMapping);
-	        this.environment = this.convertValues(source["environment"], EnvironmentVar);
-	        this.host_networking = source["host_networking"];
-	        this.docker_socket_access = source["docker_socket_access"];
-	        this.restart_on_server_start = source["restart_on_server_start"];
+	        this.mode = source["mode"];
+	        this.pattern = source["pattern"];
+	        this.match_type = source["match_type"];
+	        this.script = source["script"];
+	        this.headers = this.convertValues(source["headers"], HeaderValidation);
+	        this.schema = this.convertValues(source["schema"], SchemaValidation);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -330,66 +284,66 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class StatusTranslation {
-	    from_pattern: string;
-	    to_code: number;
+	export class FaultInjection {
+	    enabled: boolean;
+	    latency?: string;
+	    latency_ms?: number;
+	    latency_max_ms?: number;
+	    latency_stddev_ms?: number;
+	    error_rate?: number;
+	    error_status?: number;
+	    error_body?: string;
+	    reset_rate?: number;
+	    truncate_rate?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new StatusTranslation(source);
+	        return new FaultInjection(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.from_pattern = source["from_pattern"];
-	        this.to_code = source["to_code"];
+	        this.enabled = source["enabled"];
+	        this.latency = source["latency"];
+	        this.latency_ms = source["latency_ms"];
+	        this.latency_max_ms = source["latency_max_ms"];
+	        this.latency_stddev_ms = source["latency_stddev_ms"];
+	        this.error_rate = source["error_rate"];
+	        this.error_status = source["error_status"];
+	        this.error_body = source["error_body"];
+	        this.reset_rate = source["reset_rate"];
+	        this.truncate_rate = source["truncate_rate"];
 	    }
 	}
-	export class HeaderManipulation {
+	export class RawHeader {
 	    name: string;
-	    mode: string;
-	    value?: string;
-	    expression?: string;
+	    value: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new HeaderManipulation(source);
+	        return new RawHeader(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.mode = source["mode"];
 	        this.value = source["value"];
-	        this.expression = source["expression"];
 	    }
 	}
-	export class ProxyConfig {
-	    backend_url: string;
-	    timeout_seconds: number;
-	    inbound_headers?: HeaderManipulation[];
-	    outbound_headers?: HeaderManipulation[];
-	    status_passthrough: boolean;
-	    status_translation?: StatusTranslation[];
-	    body_transform?: string;
-	    health_check_enabled: boolean;
-	    health_check_interval: number;
-	    health_check_path?: string;
+	export class HeaderQuirks {
+	    headers?: RawHeader[];
+	    filler_count?: number;
+	    filler_size?: number;
+	    raw?: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new ProxyConfig(source);
+	        return new HeaderQuirks(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.backend_url = source["backend_url"];
-	        this.timeout_seconds = source["timeout_seconds"];
-	        this.inbound_headers = this.convertValues(source["inbound_headers"], HeaderManipulation);
-	        this.outbound_headers = this.convertValues(source["outbound_headers"], HeaderManipulation);
-	        this.status_passthrough = source["status_passthrough"];
-	        this.status_translation = this.convertValues(source["status_translation"], StatusTranslation);
-	        this.body_transform = source["body_transform"];
-	        this.health_check_enabled = source["health_check_enabled"];
-	        this.health_check_interval = source["health_check_interval"];
-	        this.health_check_path = source["health_check_path"];
+	        this.headers = this.convertValues(source["headers"], RawHeader);
+	        this.filler_count = source["filler_count"];
+	        this.filler_size = source["filler_size"];
+	        this.raw = source["raw"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -410,96 +364,186 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class DomainFilter {
-	    mode: string;
-	    patterns?: string[];
+	export class BodyGenerator {
+	    kind: string;
+	    size_bytes?: number;
+	    pattern?: string;
+	    count?: number;
+	    element?: string;
+	    gzip?: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new DomainFilter(source);
+	        return new BodyGenerator(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.mode = source["mode"];
-	        this.patterns = source["patterns"];
+	        this.kind = source["kind"];
+	        this.size_bytes = source["size_bytes"];
+	        this.pattern = source["pattern"];
+	        this.count = source["count"];
+	        this.element = source["element"];
+	        this.gzip = source["gzip"];
 	    }
 	}
-	export class Endpoint {
-	    id: string;
-	    name: string;
-	    path_prefix: string;
-	    translation_mode: string;
-	    translate_pattern?: string;
-	    translate_replace?: string;
-	    enabled?: boolean;
-	    is_system?: boolean;
-	    display_order?: number;
-	    domain_filter?: DomainFilter;
-	    type: string;
-	    items?: ResponseItem[];
-	    proxy_config?: ProxyConfig;
-	    container_config?: ContainerConfig;
+	export class SequenceStep {
+	    status_code?: number;
+	    headers?: Record<string, string>;
+	    body?: string;
+	    response_delay?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Endpoint(source);
+	        return new SequenceStep(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.path_prefix = source["path_prefix"];
-	        this.translation_mode = source["translation_mode"];
-	        this.translate_pattern = source["translate_pattern"];
-	        this.translate_replace = source["translate_replace"];
-	        this.enabled = source["enabled"];
-	        this.is_system = source["is_system"];
-	        this.display_order = source["display_order"];
-	        this.domain_filter = this.convertValues(source["domain_filter"], DomainFilter);
-	        this.type = source["type"];
-	        this.items = this.convertValues(source["items"], ResponseItem);
-	        this.proxy_config = this.convertValues(source["proxy_config"], ProxyConfig);
-	        this.container_config = this.convertValues(source["container_config"], ContainerConfig);
+	        this.status_code = source["status_code"];
+	        this.headers = source["headers"];
+	        this.body = source["body"];
+	        this.response_delay = source["response_delay"];
 	    }
-	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
 	}
-	export class ResponseGroup {
+	export class DeprecationPolicy {
+	    announce_at?: string;
+	    deprecated_at?: string;
+	    sunset_at?: string;
+	    link?: string;
+	    sunset_link?: string;
+	    enforce_sunset?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeprecationPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.announce_at = source["announce_at"];
+	        this.deprecated_at = source["deprecated_at"];
+	        this.sunset_at = source["sunset_at"];
+	        this.link = source["link"];
+	        this.sunset_link = source["sunset_link"];
+	        this.enforce_sunset = source["enforce_sunset"];
+	    }
+	}
+	export class ResponseVariant {
+	    value: string;
+	    status_code?: number;
+	    headers?: Record<string, string>;
+	    body?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResponseVariant(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.status_code = source["status_code"];
+	        this.headers = source["headers"];
+	        this.body = source["body"];
+	    }
+	}
+	export class TrafficExample {
+	    captured_at: string;
+	    method: string;
+	    path: string;
+	    request_content_type?: string;
+	    request_body?: string;
+	    status_code: number;
+	    response_content_type?: string;
+	    response_body?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrafficExample(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.captured_at = source["captured_at"];
+	        this.method = source["method"];
+	        this.path = source["path"];
+	        this.request_content_type = source["request_content_type"];
+	        this.request_body = source["request_body"];
+	        this.status_code = source["status_code"];
+	        this.response_content_type = source["response_content_type"];
+	        this.response_body = source["response_body"];
+	    }
+	}
+	export class MethodResponse {
 	    id?: string;
-	    name: string;
-	    expanded?: boolean;
 	    enabled?: boolean;
+	    path_pattern: string;
+	    methods: string[];
+	    status_code: number;
+	    status_text?: string;
+	    headers?: Record<string, string>;
+	    body?: string;
+	    body_base64?: boolean;
+	    response_delay?: number;
+	    delay_expression?: string;
+	    bandwidth_kbps?: number;
+	    range_mode?: string;
+	    variant_header?: string;
+	    variants?: ResponseVariant[];
+	    protocols?: string[];
+	    protocol_variants?: ResponseVariant[];
+	    deprecation?: DeprecationPolicy;
+	    event_stream?: string;
+	    sequence?: SequenceStep[];
+	    sequence_end?: string;
+	    fault_injection?: FaultInjection;
+	    generator?: BodyGenerator;
+	    header_quirks?: HeaderQuirks;
+	    response_mode?: string;
+	    script_body?: string;
+	    request_validation?: RequestValidation;
 	    use_global_cors?: boolean;
-	    responses?: MethodResponse[];
+	    draft?: boolean;
+	    published?: MethodResponse;
+	    field_mappings?: FieldMapping[];
+	    openapi_operation?: string;
+	    examples?: TrafficExample[];
 	
 	    static createFrom(source: any = {}) {
-	        return new ResponseGroup(source);
+	        return new MethodResponse(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.expanded = source["expanded"];
 	        this.enabled = source["enabled"];
+	        this.path_pattern = source["path_pattern"];
+	        this.methods = source["methods"];
+	        this.status_code = source["status_code"];
+	        this.status_text = source["status_text"];
+	        this.headers = source["headers"];
+	        this.body = source["body"];
+	        this.body_base64 = source["body_base64"];
+	        this.response_delay = source["response_delay"];
+	        this.delay_expression = source["delay_expression"];
+	        this.bandwidth_kbps = source["bandwidth_kbps"];
+	        this.range_mode = source["range_mode"];
+	        this.variant_header = source["variant_header"];
+	        this.variants = this.convertValues(source["variants"], ResponseVariant);
+	        this.protocols = source["protocols"];
+	        this.protocol_variants = this.convertValues(source["protocol_variants"], ResponseVariant);
+	        this.deprecation = this.convertValues(source["deprecation"], DeprecationPolicy);
+	        this.event_stream = source["event_stream"];
+	        this.sequence = this.convertValues(source["sequence"], SequenceStep);
+	        this.sequence_end = source["sequence_end"];
+	        this.fault_injection = this.convertValues(source["fault_injection"], FaultInjection);
+	        this.generator = this.convertValues(source["generator"], BodyGenerator);
+	        this.header_quirks = this.convertValues(source["header_quirks"], HeaderQuirks);
+	        this.response_mode = source["response_mode"];
+	        this.script_body = source["script_body"];
+	        this.request_validation = this.convertValues(source["request_validation"], RequestValidation);
 	        this.use_global_cors = source["use_global_cors"];
-	        this.responses = this.convertValues(source["responses"], MethodResponse);
+	        this.draft = source["draft"];
+	        this.published = this.convertValues(source["published"], MethodResponse);
+	        this.field_mappings = this.convertValues(source["field_mappings"], FieldMapping);
+	        this.openapi_operation = source["openapi_operation"];
+	        this.examples = this.convertValues(source["examples"], TrafficExample);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -554,46 +598,124 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class HeaderValidation {
+	export class APIVersion {
+	    version: string;
+	    items?: ResponseItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new APIVersion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.items = this.convertValues(source["items"], ResponseItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MarketplaceSource {
 	    name: string;
-	    mode?: string;
-	    value?: string;
-	    pattern?: string;
-	    expression?: string;
-	    required?: boolean;
+	    type: string;
+	    url: string;
+	    ref?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new HeaderValidation(source);
+	        return new MarketplaceSource(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.mode = source["mode"];
+	        this.type = source["type"];
+	        this.url = source["url"];
+	        this.ref = source["ref"];
+	    }
+	}
+	export class ScheduledAction {
+	    id?: string;
+	    name?: string;
+	    after: string;
+	    action: string;
+	    response_id?: string;
+	    endpoint_id?: string;
+	    status_code?: number;
+	    body?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScheduledAction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.after = source["after"];
+	        this.action = source["action"];
+	        this.response_id = source["response_id"];
+	        this.endpoint_id = source["endpoint_id"];
+	        this.status_code = source["status_code"];
+	        this.body = source["body"];
+	    }
+	}
+	export class MacroStep {
+	    delay?: string;
+	    action: string;
+	    after?: string;
+	    response_id?: string;
+	    endpoint_id?: string;
+	    status_code?: number;
+	    body?: string;
+	    enabled?: boolean;
+	    value?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MacroStep(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.delay = source["delay"];
+	        this.action = source["action"];
+	        this.after = source["after"];
+	        this.response_id = source["response_id"];
+	        this.endpoint_id = source["endpoint_id"];
+	        this.status_code = source["status_code"];
+	        this.body = source["body"];
+	        this.enabled = source["enabled"];
 	        this.value = source["value"];
-	        this.pattern = source["pattern"];
-	        this.expression = source["expression"];
-	        this.required = source["required"];
 	    }
 	}
-	export class RequestValidation {
-	    mode?: string;
-	    pattern?: string;
-	    match_type?: string;
-	    script?: string;
-	    headers?: HeaderValidation[];
+	export class Macro {
+	    name: string;
+	    recorded_at?: string;
+	    steps: MacroStep[];
 	
 	    static createFrom(source: any = {}) {
-	        return new RequestValidation(source);
+	        return new Macro(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.mode = source["mode"];
-	        this.pattern = source["pattern"];
-	        this.match_type = source["match_type"];
-	        this.script = source["script"];
-	        this.headers = this.convertValues(source["headers"], HeaderValidation);
+	        this.name = source["name"];
+	        this.recorded_at = source["recorded_at"];
+	        this.steps = this.convertValues(source["steps"], MacroStep);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -614,40 +736,56 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class MethodResponse {
+	export class GRPCMethodResponse {
 	    id?: string;
+	    method: string;
 	    enabled?: boolean;
-	    path_pattern: string;
-	    methods: string[];
-	    status_code: number;
-	    status_text?: string;
-	    headers?: Record<string, string>;
-	    body?: string;
-	    response_delay?: number;
 	    response_mode?: string;
+	    body?: string;
 	    script_body?: string;
-	    request_validation?: RequestValidation;
-	    use_global_cors?: boolean;
+	    status_code?: number;
+	    status_message?: string;
+	    metadata?: Record<string, string>;
+	    delay?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new MethodResponse(source);
+	        return new GRPCMethodResponse(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
+	        this.method = source["method"];
 	        this.enabled = source["enabled"];
-	        this.path_pattern = source["path_pattern"];
-	        this.methods = source["methods"];
-	        this.status_code = source["status_code"];
-	        this.status_text = source["status_text"];
-	        this.headers = source["headers"];
-	        this.body = source["body"];
-	        this.response_delay = source["response_delay"];
 	        this.response_mode = source["response_mode"];
+	        this.body = source["body"];
 	        this.script_body = source["script_body"];
-	        this.request_validation = this.convertValues(source["request_validation"], RequestValidation);
-	        this.use_global_cors = source["use_global_cors"];
+	        this.status_code = source["status_code"];
+	        this.status_message = source["status_message"];
+	        this.metadata = source["metadata"];
+	        this.delay = source["delay"];
+	    }
+	}
+	export class GRPCConfig {
+	    enabled: boolean;
+	    port?: number;
+	    proto_files?: string[];
+	    import_paths?: string[];
+	    reflection?: boolean;
+	    methods?: GRPCMethodResponse[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GRPCConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.port = source["port"];
+	        this.proto_files = source["proto_files"];
+	        this.import_paths = source["import_paths"];
+	        this.reflection = source["reflection"];
+	        this.methods = this.convertValues(source["methods"], GRPCMethodResponse);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -668,49 +806,50 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class AppConfig {
-	    port: number;
-	    responses?: MethodResponse[];
-	    items?: ResponseItem[];
-	    endpoints?: Endpoint[];
-	    // Go type: time
-	    last_modified?: any;
-	    http2_enabled?: boolean;
-	    https_enabled?: boolean;
-	    https_port?: number;
-	    http_to_https_redirect?: boolean;
-	    cert_mode?: string;
-	    cert_paths?: CertPaths;
-	    cert_names?: string[];
-	    cors?: CORSConfig;
-	    socks5_config?: SOCKS5Config;
-	    domain_takeover?: DomainTakeoverConfig;
-	    container_log_line_limit?: number;
-	    selected_endpoint_id?: string;
+	export class DNSRecord {
+	    id: string;
+	    enabled: boolean;
+	    name: string;
+	    type: string;
+	    value?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new AppConfig(source);
+	        return new DNSRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.enabled = source["enabled"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.value = source["value"];
+	    }
+	}
+	export class DNSConfig {
+	    enabled: boolean;
+	    port?: number;
+	    mock_address?: string;
+	    mock_address_v6?: string;
+	    upstream?: string;
+	    ttl?: number;
+	    takeover?: boolean;
+	    records?: DNSRecord[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DNSConfig(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
 	        this.port = source["port"];
-	        this.responses = this.convertValues(source["responses"], MethodResponse);
-	        this.items = this.convertValues(source["items"], ResponseItem);
-	        this.endpoints = this.convertValues(source["endpoints"], Endpoint);
-	        this.last_modified = this.convertValues(source["last_modified"], null);
-	        this.http2_enabled = source["http2_enabled"];
-	        this.https_enabled = source["https_enabled"];
-	        this.https_port = source["https_port"];
-	        this.http_to_https_redirect = source["http_to_https_redirect"];
-	        this.cert_mode = source["cert_mode"];
-	        this.cert_paths = this.convertValues(source["cert_paths"], CertPaths);
-	        this.cert_names = source["cert_names"];
-	        this.cors = this.convertValues(source["cors"], CORSConfig);
-	        this.socks5_config = this.convertValues(source["socks5_config"], SOCKS5Config);
-	        this.domain_takeover = this.convertValues(source["domain_takeover"], DomainTakeoverConfig);
-	        this.container_log_line_limit = source["container_log_line_limit"];
-	        this.selected_endpoint_id = source["selected_endpoint_id"];
+	        this.mock_address = source["mock_address"];
+	        this.mock_address_v6 = source["mock_address_v6"];
+	        this.upstream = source["upstream"];
+	        this.ttl = source["ttl"];
+	        this.takeover = source["takeover"];
+	        this.records = this.convertValues(source["records"], DNSRecord);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -731,9 +870,1389 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class CACertInfo {
-	    exists: boolean;
-	    generated?: string;
+	export class DomainConfig {
+	    id: string;
+	    pattern: string;
+	    overlay_mode: boolean;
+	    enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DomainConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.pattern = source["pattern"];
+	        this.overlay_mode = source["overlay_mode"];
+	        this.enabled = source["enabled"];
+	    }
+	}
+	export class DomainTakeoverConfig {
+	    domains: DomainConfig[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DomainTakeoverConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.domains = this.convertValues(source["domains"], DomainConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SOCKS5User {
+	    username: string;
+	    password: string;
+	    description?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SOCKS5User(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.username = source["username"];
+	        this.password = source["password"];
+	        this.description = source["description"];
+	    }
+	}
+	export class HTTPProxyConfig {
+	    enabled: boolean;
+	    port: number;
+	    authentication: boolean;
+	    username?: string;
+	    password?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HTTPProxyConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.port = source["port"];
+	        this.authentication = source["authentication"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	    }
+	}
+	export class BypassRule {
+	    id: string;
+	    enabled: boolean;
+	    domain?: string;
+	    cidr?: string;
+	    ports?: number[];
+	    description?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BypassRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.enabled = source["enabled"];
+	        this.domain = source["domain"];
+	        this.cidr = source["cidr"];
+	        this.ports = source["ports"];
+	        this.description = source["description"];
+	    }
+	}
+	export class SOCKS5Config {
+	    enabled: boolean;
+	    port: number;
+	    authentication: boolean;
+	    username?: string;
+	    password?: string;
+	    track_requests: boolean;
+	    bypass_rules?: BypassRule[];
+	    transparent_pass_through?: boolean;
+	    allowed_clients?: string[];
+	    users?: SOCKS5User[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SOCKS5Config(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.port = source["port"];
+	        this.authentication = source["authentication"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	        this.track_requests = source["track_requests"];
+	        this.bypass_rules = this.convertValues(source["bypass_rules"], BypassRule);
+	        this.transparent_pass_through = source["transparent_pass_through"];
+	        this.allowed_clients = source["allowed_clients"];
+	        this.users = this.convertValues(source["users"], SOCKS5User);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CORSHeader {
+	    name: string;
+	    expression: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CORSHeader(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.expression = source["expression"];
+	    }
+	}
+	export class BundleReport {
+	    files: string[];
+	    warnings?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BundleReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.files = source["files"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class CORSConfig {
+	    enabled: boolean;
+	    mode?: string;
+	    header_expressions?: CORSHeader[];
+	    script?: string;
+	    options_default_status?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CORSConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.mode = source["mode"];
+	        this.header_expressions = this.convertValues(source["header_expressions"], CORSHeader);
+	        this.script = source["script"];
+	        this.options_default_status = source["options_default_status"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class VirtualClock {
+	    offset_seconds?: number;
+	    frozen_at?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new VirtualClock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.offset_seconds = source["offset_seconds"];
+	        this.frozen_at = source["frozen_at"];
+	    }
+	}
+	export class ServerLimits {
+	    max_header_bytes?: number;
+	    max_body_bytes?: number;
+	    read_header_timeout_sec?: number;
+	    read_timeout_sec?: number;
+	    write_timeout_sec?: number;
+	    idle_timeout_sec?: number;
+	    max_connections?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServerLimits(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.max_header_bytes = source["max_header_bytes"];
+	        this.max_body_bytes = source["max_body_bytes"];
+	        this.read_header_timeout_sec = source["read_header_timeout_sec"];
+	        this.read_timeout_sec = source["read_timeout_sec"];
+	        this.write_timeout_sec = source["write_timeout_sec"];
+	        this.idle_timeout_sec = source["idle_timeout_sec"];
+	        this.max_connections = source["max_connections"];
+	    }
+	}
+	export class ClientAuthConfig {
+	    mode?: string;
+	    ca_cert_path?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClientAuthConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.ca_cert_path = source["ca_cert_path"];
+	    }
+	}
+	export class ClientCertInfo {
+	    subject: string;
+	    common_name?: string;
+	    issuer: string;
+	    serial_number: string;
+	    dns_names?: string[];
+	    email_addresses?: string[];
+	    ip_addresses?: string[];
+	    uris?: string[];
+	    not_before: string;
+	    not_after: string;
+	    fingerprint: string;
+	    verified: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClientCertInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.subject = source["subject"];
+	        this.common_name = source["common_name"];
+	        this.issuer = source["issuer"];
+	        this.serial_number = source["serial_number"];
+	        this.dns_names = source["dns_names"];
+	        this.email_addresses = source["email_addresses"];
+	        this.ip_addresses = source["ip_addresses"];
+	        this.uris = source["uris"];
+	        this.not_before = source["not_before"];
+	        this.not_after = source["not_after"];
+	        this.fingerprint = source["fingerprint"];
+	        this.verified = source["verified"];
+	    }
+	}
+	export class CertPaths {
+	    ca_cert_path?: string;
+	    ca_key_path?: string;
+	    server_cert_path?: string;
+	    server_key_path?: string;
+	    server_bundle_path?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertPaths(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ca_cert_path = source["ca_cert_path"];
+	        this.ca_key_path = source["ca_key_path"];
+	        this.server_cert_path = source["server_cert_path"];
+	        this.server_key_path = source["server_key_path"];
+	        this.server_bundle_path = source["server_bundle_path"];
+	    }
+	}
+	export class EnvironmentVar {
+	    name: string;
+	    value?: string;
+	    expression?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EnvironmentVar(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.value = source["value"];
+	        this.expression = source["expression"];
+	    }
+	}
+	export class VolumeMapping {
+	    host_path: string;
+	    container_path: string;
+	    read_only: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new VolumeMapping(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host_path = source["host_path"];
+	        this.container_path = source["container_path"];
+	        this.read_only = source["read_only"];
+	    }
+	}
+	export class ContainerResources {
+	    cpu_shares?: number;
+	    cpus?: number;
+	    memory_mb?: number;
+	    pids_limit?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerResources(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cpu_shares = source["cpu_shares"];
+	        this.cpus = source["cpus"];
+	        this.memory_mb = source["memory_mb"];
+	        this.pids_limit = source["pids_limit"];
+	    }
+	}
+	export class ContainerReadiness {
+	    strategy: string;
+	    log_pattern?: string;
+	    port?: number;
+	    delay_seconds?: number;
+	    timeout_seconds?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerReadiness(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.strategy = source["strategy"];
+	        this.log_pattern = source["log_pattern"];
+	        this.port = source["port"];
+	        this.delay_seconds = source["delay_seconds"];
+	        this.timeout_seconds = source["timeout_seconds"];
+	    }
+	}
+	export class ContainerHost {
+	    name: string;
+	    host: string;
+	    runtime?: string;
+	    tls_ca_cert?: string;
+	    tls_cert?: string;
+	    tls_key?: string;
+	    publish_host?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerHost(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.host = source["host"];
+	        this.runtime = source["runtime"];
+	        this.tls_ca_cert = source["tls_ca_cert"];
+	        this.tls_cert = source["tls_cert"];
+	        this.tls_key = source["tls_key"];
+	        this.publish_host = source["publish_host"];
+	    }
+	}
+	export class RegistryCredential {
+	    registry?: string;
+	    username: string;
+	    password: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RegistryCredential(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.registry = source["registry"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	    }
+	}
+	export class ContainerBuild {
+	    context_path: string;
+	    dockerfile?: string;
+	    args?: EnvironmentVar[];
+	    target?: string;
+	    no_cache?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerBuild(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.context_path = source["context_path"];
+	        this.dockerfile = source["dockerfile"];
+	        this.args = this.convertValues(source["args"], EnvironmentVar);
+	        this.target = source["target"];
+	        this.no_cache = source["no_cache"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SidecarContainer {
+	    name: string;
+	    image_name: string;
+	    command?: string[];
+	    environment?: EnvironmentVar[];
+	    volumes?: VolumeMapping[];
+	    depends_on?: string[];
+	    pull_on_startup?: boolean;
+	    readiness?: ContainerReadiness;
+	
+	    static createFrom(source: any = {}) {
+	        return new SidecarContainer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.image_name = source["image_name"];
+	        this.command = source["command"];
+	        this.environment = this.convertValues(source["environment"], EnvironmentVar);
+	        this.volumes = this.convertValues(source["volumes"], VolumeMapping);
+	        this.depends_on = source["depends_on"];
+	        this.pull_on_startup = source["pull_on_startup"];
+	        this.readiness = this.convertValues(source["readiness"], ContainerReadiness);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ContainerConfig {
+	    proxy_config: ProxyConfig;
+	    image_name: string;
+	    container_port: number;
+	    exposed_ports?: string[];
+	    pull_on_startup: boolean;
+	    restart_policy?: string;
+	    volumes?: VolumeMapping[];
+	    environment?: EnvironmentVar[];
+	    host_networking?: boolean;
+	    docker_socket_access?: boolean;
+	    restart_on_server_start?: boolean;
+	    readiness?: ContainerReadiness;
+	    resources?: ContainerResources;
+	    container_host?: string;
+	    registry_auth?: RegistryCredential;
+	    build?: ContainerBuild;
+	    sidecars?: SidecarContainer[];
+	    network_alias?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.proxy_config = this.convertValues(source["proxy_config"], ProxyConfig);
+	        this.image_name = source["image_name"];
+	        this.container_port = source["container_port"];
+	        this.exposed_ports = source["exposed_ports"];
+	        this.pull_on_startup = source["pull_on_startup"];
+	        this.restart_policy = source["restart_policy"];
+	        this.volumes = this.convertValues(source["volumes"], VolumeMapping);
+	        this.environment = this.convertValues(source["environment"], EnvironmentVar);
+	        this.host_networking = source["host_networking"];
+	        this.docker_socket_access = source["docker_socket_access"];
+	        this.restart_on_server_start = source["restart_on_server_start"];
+	        this.readiness = this.convertValues(source["readiness"], ContainerReadiness);
+	        this.resources = this.convertValues(source["resources"], ContainerResources);
+	        this.container_host = source["container_host"];
+	        this.registry_auth = this.convertValues(source["registry_auth"], RegistryCredential);
+	        this.build = this.convertValues(source["build"], ContainerBuild);
+	        this.sidecars = this.convertValues(source["sidecars"], SidecarContainer);
+	        this.network_alias = source["network_alias"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class URLRewriteRule {
+	    from: string;
+	    to: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new URLRewriteRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class StatusTranslation {
+	    from_pattern: string;
+	    to_code: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusTranslation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from_pattern = source["from_pattern"];
+	        this.to_code = source["to_code"];
+	    }
+	}
+	export class FieldMapping {
+	    op: string;
+	    path: string;
+	    to?: string;
+	    value?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new FieldMapping(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.op = source["op"];
+	        this.path = source["path"];
+	        this.to = source["to"];
+	        this.value = source["value"];
+	    }
+	}
+	export class ProxyTransportConfig {
+	    max_idle_conns?: number;
+	    max_conns_per_host?: number;
+	    idle_conn_timeout_seconds?: number;
+	    disable_keep_alives?: boolean;
+	    http2?: string;
+	    tls_min_version?: string;
+	    tls_handshake_timeout_seconds?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProxyTransportConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.max_idle_conns = source["max_idle_conns"];
+	        this.max_conns_per_host = source["max_conns_per_host"];
+	        this.idle_conn_timeout_seconds = source["idle_conn_timeout_seconds"];
+	        this.disable_keep_alives = source["disable_keep_alives"];
+	        this.http2 = source["http2"];
+	        this.tls_min_version = source["tls_min_version"];
+	        this.tls_handshake_timeout_seconds = source["tls_handshake_timeout_seconds"];
+	    }
+	}
+	export class ProxyTLSConfig {
+	    insecure_skip_verify?: boolean;
+	    ca_cert_path?: string;
+	    client_cert_path?: string;
+	    client_key_path?: string;
+	    server_name?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProxyTLSConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.insecure_skip_verify = source["insecure_skip_verify"];
+	        this.ca_cert_path = source["ca_cert_path"];
+	        this.client_cert_path = source["client_cert_path"];
+	        this.client_key_path = source["client_key_path"];
+	        this.server_name = source["server_name"];
+	    }
+	}
+	export class CircuitBreakerConfig {
+	    enabled: boolean;
+	    failure_threshold?: number;
+	    failure_statuses?: string[];
+	    open_seconds?: number;
+	    half_open_probes?: number;
+	    fallback_status?: number;
+	    fallback_headers?: Record<string, string>;
+	    fallback_body?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CircuitBreakerConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.failure_threshold = source["failure_threshold"];
+	        this.failure_statuses = source["failure_statuses"];
+	        this.open_seconds = source["open_seconds"];
+	        this.half_open_probes = source["half_open_probes"];
+	        this.fallback_status = source["fallback_status"];
+	        this.fallback_headers = source["fallback_headers"];
+	        this.fallback_body = source["fallback_body"];
+	    }
+	}
+	export class ProxyBackend {
+	    url: string;
+	    weight?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProxyBackend(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.weight = source["weight"];
+	    }
+	}
+	export class HeaderManipulation {
+	    name: string;
+	    mode: string;
+	    value?: string;
+	    expression?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeaderManipulation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.mode = source["mode"];
+	        this.value = source["value"];
+	        this.expression = source["expression"];
+	    }
+	}
+	export class ProxyConfig {
+	    backend_url: string;
+	    environments?: Record<string, string>;
+	    timeout_seconds: number;
+	    backends?: ProxyBackend[];
+	    balance_strategy?: string;
+	    transport?: ProxyTransportConfig;
+	    tls?: ProxyTLSConfig;
+	    circuit_breaker?: CircuitBreakerConfig;
+	    inbound_headers?: HeaderManipulation[];
+	    outbound_headers?: HeaderManipulation[];
+	    status_passthrough: boolean;
+	    status_translation?: StatusTranslation[];
+	    field_mappings?: FieldMapping[];
+	    body_transform?: string;
+	    rewrite_body_urls?: boolean;
+	    body_url_rewrites?: URLRewriteRule[];
+	    assertion_script?: string;
+	    log_body_limit?: number;
+	    health_check_enabled: boolean;
+	    health_check_interval: number;
+	    health_check_path?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProxyConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.backend_url = source["backend_url"];
+	        this.environments = source["environments"];
+	        this.timeout_seconds = source["timeout_seconds"];
+	        this.backends = this.convertValues(source["backends"], ProxyBackend);
+	        this.balance_strategy = source["balance_strategy"];
+	        this.transport = this.convertValues(source["transport"], ProxyTransportConfig);
+	        this.tls = this.convertValues(source["tls"], ProxyTLSConfig);
+	        this.circuit_breaker = this.convertValues(source["circuit_breaker"], CircuitBreakerConfig);
+	        this.inbound_headers = this.convertValues(source["inbound_headers"], HeaderManipulation);
+	        this.outbound_headers = this.convertValues(source["outbound_headers"], HeaderManipulation);
+	        this.status_passthrough = source["status_passthrough"];
+	        this.status_translation = this.convertValues(source["status_translation"], StatusTranslation);
+	        this.field_mappings = this.convertValues(source["field_mappings"], FieldMapping);
+	        this.body_transform = source["body_transform"];
+	        this.rewrite_body_urls = source["rewrite_body_urls"];
+	        this.body_url_rewrites = this.convertValues(source["body_url_rewrites"], URLRewriteRule);
+	        this.assertion_script = source["assertion_script"];
+	        this.log_body_limit = source["log_body_limit"];
+	        this.health_check_enabled = source["health_check_enabled"];
+	        this.health_check_interval = source["health_check_interval"];
+	        this.health_check_path = source["health_check_path"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BasicAuthUser {
+	    username: string;
+	    password: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BasicAuthUser(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.username = source["username"];
+	        this.password = source["password"];
+	    }
+	}
+	export class JWTAuth {
+	    secret?: string;
+	    public_key?: string;
+	    jwks?: string;
+	    jwks_url?: string;
+	    key?: string;
+	    algorithms?: string[];
+	    issuer?: string;
+	    audience?: string;
+	    required_claims?: Record<string, string>;
+	    required_scopes?: string[];
+	    clock_skew_seconds?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new JWTAuth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.secret = source["secret"];
+	        this.public_key = source["public_key"];
+	        this.jwks = source["jwks"];
+	        this.jwks_url = source["jwks_url"];
+	        this.key = source["key"];
+	        this.algorithms = source["algorithms"];
+	        this.issuer = source["issuer"];
+	        this.audience = source["audience"];
+	        this.required_claims = source["required_claims"];
+	        this.required_scopes = source["required_scopes"];
+	        this.clock_skew_seconds = source["clock_skew_seconds"];
+	    }
+	}
+	export class EndpointAuth {
+	    enabled: boolean;
+	    api_keys?: string[];
+	    api_key_header?: string;
+	    api_key_query?: string;
+	    basic_users?: BasicAuthUser[];
+	    bearer_tokens?: string[];
+	    jwt?: JWTAuth;
+	    realm?: string;
+	    unauthorized_body?: string;
+	    forbidden_body?: string;
+	    content_type?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EndpointAuth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.api_keys = source["api_keys"];
+	        this.api_key_header = source["api_key_header"];
+	        this.api_key_query = source["api_key_query"];
+	        this.basic_users = this.convertValues(source["basic_users"], BasicAuthUser);
+	        this.bearer_tokens = source["bearer_tokens"];
+	        this.jwt = this.convertValues(source["jwt"], JWTAuth);
+	        this.realm = source["realm"];
+	        this.unauthorized_body = source["unauthorized_body"];
+	        this.forbidden_body = source["forbidden_body"];
+	        this.content_type = source["content_type"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DomainFilter {
+	    mode: string;
+	    patterns?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DomainFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.patterns = source["patterns"];
+	    }
+	}
+	export class VersionRouting {
+	    source: string;
+	    name?: string;
+	    default?: string;
+	    versions: APIVersion[];
+	
+	    static createFrom(source: any = {}) {
+	        return new VersionRouting(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.name = source["name"];
+	        this.default = source["default"];
+	        this.versions = this.convertValues(source["versions"], APIVersion);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class EndpointTypeInfo {
+	    type: string;
+	    name: string;
+	    description?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EndpointTypeInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	    }
+	}
+	export class Listener {
+	    name: string;
+	    port: number;
+	    tls?: boolean;
+	    cert_path?: string;
+	    key_path?: string;
+	    client_ca_path?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Listener(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.port = source["port"];
+	        this.tls = source["tls"];
+	        this.cert_path = source["cert_path"];
+	        this.key_path = source["key_path"];
+	        this.client_ca_path = source["client_ca_path"];
+	    }
+	}
+	export class Endpoint {
+	    id: string;
+	    name: string;
+	    path_prefix: string;
+	    translation_mode: string;
+	    translate_pattern?: string;
+	    translate_replace?: string;
+	    enabled?: boolean;
+	    is_system?: boolean;
+	    display_order?: number;
+	    listen_port?: number;
+	    listener?: string;
+	    defaults?: ResponseDefaults;
+	    fault_injection?: FaultInjection;
+	    bandwidth_kbps?: number;
+	    auth?: EndpointAuth;
+	    versioning?: VersionRouting;
+	    domain_filter?: DomainFilter;
+	    snapshot_of?: string;
+	    type: string;
+	    items?: ResponseItem[];
+	    proxy_config?: ProxyConfig;
+	    container_config?: ContainerConfig;
+	    plugin_config?: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Endpoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.path_prefix = source["path_prefix"];
+	        this.translation_mode = source["translation_mode"];
+	        this.translate_pattern = source["translate_pattern"];
+	        this.translate_replace = source["translate_replace"];
+	        this.enabled = source["enabled"];
+	        this.is_system = source["is_system"];
+	        this.display_order = source["display_order"];
+	        this.listen_port = source["listen_port"];
+	        this.listener = source["listener"];
+	        this.defaults = this.convertValues(source["defaults"], ResponseDefaults);
+	        this.fault_injection = this.convertValues(source["fault_injection"], FaultInjection);
+	        this.bandwidth_kbps = source["bandwidth_kbps"];
+	        this.auth = this.convertValues(source["auth"], EndpointAuth);
+	        this.versioning = this.convertValues(source["versioning"], VersionRouting);
+	        this.domain_filter = this.convertValues(source["domain_filter"], DomainFilter);
+	        this.snapshot_of = source["snapshot_of"];
+	        this.type = source["type"];
+	        this.items = this.convertValues(source["items"], ResponseItem);
+	        this.proxy_config = this.convertValues(source["proxy_config"], ProxyConfig);
+	        this.container_config = this.convertValues(source["container_config"], ContainerConfig);
+	        this.plugin_config = source["plugin_config"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScriptFetchConfig {
+	    enabled: boolean;
+	    allowed_hosts?: string[];
+	    timeout_ms?: number;
+	    max_response_bytes?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScriptFetchConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.allowed_hosts = source["allowed_hosts"];
+	        this.timeout_ms = source["timeout_ms"];
+	        this.max_response_bytes = source["max_response_bytes"];
+	    }
+	}
+	export class ScriptModule {
+	    name: string;
+	    description?: string;
+	    code: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScriptModule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.code = source["code"];
+	    }
+	}
+	export class ClientThrottleRule {
+	    id: string;
+	    name?: string;
+	    enabled: boolean;
+	    source?: string;
+	    endpoint_id?: string;
+	    max_rps: number;
+	    burst?: number;
+	    penalty_delay_ms?: number;
+	    status?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClientThrottleRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.enabled = source["enabled"];
+	        this.source = source["source"];
+	        this.endpoint_id = source["endpoint_id"];
+	        this.max_rps = source["max_rps"];
+	        this.burst = source["burst"];
+	        this.penalty_delay_ms = source["penalty_delay_ms"];
+	        this.status = source["status"];
+	    }
+	}
+	export class JWTSigningKey {
+	    name: string;
+	    algorithm: string;
+	    secret?: string;
+	    private_key?: string;
+	    public_key?: string;
+	    kid?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JWTSigningKey(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.algorithm = source["algorithm"];
+	        this.secret = source["secret"];
+	        this.private_key = source["private_key"];
+	        this.public_key = source["public_key"];
+	        this.kid = source["kid"];
+	    }
+	}
+	export class AppConfig {
+	    port: number;
+	    responses?: MethodResponse[];
+	    items?: ResponseItem[];
+	    endpoints?: Endpoint[];
+	    // Go type: time
+	    last_modified?: any;
+	    http2_enabled?: boolean;
+	    https_enabled?: boolean;
+	    https_port?: number;
+	    http_to_https_redirect?: boolean;
+	    cert_mode?: string;
+	    cert_paths?: CertPaths;
+	    cert_names?: string[];
+	    client_auth?: ClientAuthConfig;
+	    limits?: ServerLimits;
+	    virtual_clock?: VirtualClock;
+	    offline_mode?: boolean;
+	    active_environment?: string;
+	    variables?: Record<string, string>;
+	    profiles?: Record<string, Record<string, string>>;
+	    active_profile?: string;
+	    cors?: CORSConfig;
+	    socks5_config?: SOCKS5Config;
+	    http_proxy?: HTTPProxyConfig;
+	    domain_takeover?: DomainTakeoverConfig;
+	    grpc?: GRPCConfig;
+	    dns?: DNSConfig;
+	    scheduled_actions?: ScheduledAction[];
+	    macros?: Macro[];
+	    script_modules?: ScriptModule[];
+	    script_fetch?: ScriptFetchConfig;
+	    listeners?: Listener[];
+	    client_throttles?: ClientThrottleRule[];;
+	    jwt_keys?: JWTSigningKey[];
+	    container_log_line_limit?: number;
+	    registry_credentials?: RegistryCredential[];
+	    container_hosts?: ContainerHost[];
+	    default_container_host?: string;
+	    marketplace_sources?: MarketplaceSource[];
+	    selected_endpoint_id?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.port = source["port"];
+	        this.responses = this.convertValues(source["responses"], MethodResponse);
+	        this.items = this.convertValues(source["items"], ResponseItem);
+	        this.endpoints = this.convertValues(source["endpoints"], Endpoint);
+	        this.last_modified = this.convertValues(source["last_modified"], null);
+	        this.http2_enabled = source["http2_enabled"];
+	        this.https_enabled = source["https_enabled"];
+	        this.https_port = source["https_port"];
+	        this.http_to_https_redirect = source["http_to_https_redirect"];
+	        this.cert_mode = source["cert_mode"];
+	        this.cert_paths = this.convertValues(source["cert_paths"], CertPaths);
+	        this.cert_names = source["cert_names"];
+	        this.client_auth = this.convertValues(source["client_auth"], ClientAuthConfig);
+	        this.limits = this.convertValues(source["limits"], ServerLimits);
+	        this.virtual_clock = this.convertValues(source["virtual_clock"], VirtualClock);
+	        this.offline_mode = source["offline_mode"];
+	        this.active_environment = source["active_environment"];
+	        this.variables = source["variables"];
+	        this.profiles = source["profiles"];
+	        this.active_profile = source["active_profile"];
+	        this.cors = this.convertValues(source["cors"], CORSConfig);
+	        this.socks5_config = this.convertValues(source["socks5_config"], SOCKS5Config);
+	        this.http_proxy = this.convertValues(source["http_proxy"], HTTPProxyConfig);
+	        this.domain_takeover = this.convertValues(source["domain_takeover"], DomainTakeoverConfig);
+	        this.grpc = this.convertValues(source["grpc"], GRPCConfig);
+	        this.dns = this.convertValues(source["dns"], DNSConfig);
+	        this.scheduled_actions = this.convertValues(source["scheduled_actions"], ScheduledAction);
+	        this.macros = this.convertValues(source["macros"], Macro);
+	        this.script_modules = this.convertValues(source["script_modules"], ScriptModule);
+	        this.script_fetch = this.convertValues(source["script_fetch"], ScriptFetchConfig);
+	        this.listeners = this.convertValues(source["listeners"], Listener);
+	        this.client_throttles = this.convertValues(source["client_throttles"], ClientThrottleRule);
+	        this.jwt_keys = this.convertValues(source["jwt_keys"], JWTSigningKey);
+	        this.container_log_line_limit = source["container_log_line_limit"];
+	        this.registry_credentials = this.convertValues(source["registry_credentials"], RegistryCredential);
+	        this.container_hosts = this.convertValues(source["container_hosts"], ContainerHost);
+	        this.default_container_host = source["default_container_host"];
+	        this.marketplace_sources = this.convertValues(source["marketplace_sources"], MarketplaceSource);
+	        this.selected_endpoint_id = source["selected_endpoint_id"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AppLogEntry {
+	    seq: number;
+	    timestamp: string;
+	    level: string;
+	    subsystem: string;
+	    message: string;
+	    fields?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppLogEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.timestamp = source["timestamp"];
+	        this.level = source["level"];
+	        this.subsystem = source["subsystem"];
+	        this.message = source["message"];
+	        this.fields = source["fields"];
+	    }
+	}
+	export class AssertionResult {
+	    passed: boolean;
+	    message?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AssertionResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.passed = source["passed"];
+	        this.message = source["message"];
+	    }
+	}
+	export class ProxyBackendStatus {
+	    url: string;
+	    weight: number;
+	    healthy: boolean;
+	    requests: number;
+	    failures: number;
+	    last_error?: string;
+	    last_failure?: string;
+	    last_check?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProxyBackendStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.weight = source["weight"];
+	        this.healthy = source["healthy"];
+	        this.requests = source["requests"];
+	        this.failures = source["failures"];
+	        this.last_error = source["last_error"];
+	        this.last_failure = source["last_failure"];
+	        this.last_check = source["last_check"];
+	    }
+	}
+	export class BackendSLA {
+	    endpoint_id: string;
+	    endpoint_name?: string;
+	    backend_url?: string;
+	    window: string;
+	    from: string;
+	    to: string;
+	    health_checks: number;
+	    healthy_checks: number;
+	    uptime_percent: number;
+	    requests: number;
+	    successful_requests: number;
+	    failed_requests: number;
+	    success_rate_percent: number;
+	    avg_latency_ms: number;
+	    p50_latency_ms: number;
+	    p95_latency_ms: number;
+	    p99_latency_ms: number;
+	    max_latency_ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackendSLA(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint_id = source["endpoint_id"];
+	        this.endpoint_name = source["endpoint_name"];
+	        this.backend_url = source["backend_url"];
+	        this.window = source["window"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.health_checks = source["health_checks"];
+	        this.healthy_checks = source["healthy_checks"];
+	        this.uptime_percent = source["uptime_percent"];
+	        this.requests = source["requests"];
+	        this.successful_requests = source["successful_requests"];
+	        this.failed_requests = source["failed_requests"];
+	        this.success_rate_percent = source["success_rate_percent"];
+	        this.avg_latency_ms = source["avg_latency_ms"];
+	        this.p50_latency_ms = source["p50_latency_ms"];
+	        this.p95_latency_ms = source["p95_latency_ms"];
+	        this.p99_latency_ms = source["p99_latency_ms"];
+	        this.max_latency_ms = source["max_latency_ms"];
+	    }
+	}
+	
+	export class BypassRuleStats {
+	    rule_id: string;
+	    connections: number;
+	    bytes_sent: number;
+	    bytes_received: number;
+	    last_matched?: string;
+	    last_target?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BypassRuleStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rule_id = source["rule_id"];
+	        this.connections = source["connections"];
+	        this.bytes_sent = source["bytes_sent"];
+	        this.bytes_received = source["bytes_received"];
+	        this.last_matched = source["last_matched"];
+	        this.last_target = source["last_target"];
+	    }
+	}
+	export class CACertInfo {
+	    exists: boolean;
+	    generated?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new CACertInfo(source);
@@ -741,140 +2260,1223 @@ export namespace models {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.exists = source["exists"];
-	        this.generated = source["generated"];
+	        this.exists = source["exists"];
+	        this.generated = source["generated"];
+	    }
+	}
+	
+	
+	
+	export class CertificateDetails {
+	    role: string;
+	    subject?: string;
+	    issuer?: string;
+	    serial_number?: string;
+	    dns_names?: string[];
+	    ip_addresses?: string[];
+	    not_before?: string;
+	    not_after?: string;
+	    days_remaining: number;
+	    expiring: boolean;
+	    expired: boolean;
+	    sha1_fingerprint?: string;
+	    sha256_fingerprint?: string;
+	    public_key_pin?: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.role = source["role"];
+	        this.subject = source["subject"];
+	        this.issuer = source["issuer"];
+	        this.serial_number = source["serial_number"];
+	        this.dns_names = source["dns_names"];
+	        this.ip_addresses = source["ip_addresses"];
+	        this.not_before = source["not_before"];
+	        this.not_after = source["not_after"];
+	        this.days_remaining = source["days_remaining"];
+	        this.expiring = source["expiring"];
+	        this.expired = source["expired"];
+	        this.sha1_fingerprint = source["sha1_fingerprint"];
+	        this.sha256_fingerprint = source["sha256_fingerprint"];
+	        this.public_key_pin = source["public_key_pin"];
+	        this.error = source["error"];
+	    }
+	}
+	
+	export class CircuitBreakerStatus {
+	    endpoint_id: string;
+	    endpoint_name?: string;
+	    state: string;
+	    consecutive_failures: number;
+	    opened_at?: string;
+	    retry_at?: string;
+	    times_opened: number;
+	    short_circuited: number;
+	    last_failure?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CircuitBreakerStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint_id = source["endpoint_id"];
+	        this.endpoint_name = source["endpoint_name"];
+	        this.state = source["state"];
+	        this.consecutive_failures = source["consecutive_failures"];
+	        this.opened_at = source["opened_at"];
+	        this.retry_at = source["retry_at"];
+	        this.times_opened = source["times_opened"];
+	        this.short_circuited = source["short_circuited"];
+	        this.last_failure = source["last_failure"];
+	    }
+	}
+	export class ClientThrottleStats {
+	    rule_id: string;
+	    rule_name?: string;
+	    client_ip: string;
+	    allowed: number;
+	    throttled: number;
+	    tokens: number;
+	    last_seen?: string;
+	    last_throttled?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClientThrottleStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rule_id = source["rule_id"];
+	        this.rule_name = source["rule_name"];
+	        this.client_ip = source["client_ip"];
+	        this.allowed = source["allowed"];
+	        this.throttled = source["throttled"];
+	        this.tokens = source["tokens"];
+	        this.last_seen = source["last_seen"];
+	        this.last_throttled = source["last_throttled"];
+	    }
+	}
+	export class ConfigBackup {
+	    id: string;
+	    config_path: string;
+	    // Go type: time
+	    created: any;
+	    size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConfigBackup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.config_path = source["config_path"];
+	        this.created = this.convertValues(source["created"], null);
+	        this.size = source["size"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ContainerStats {
+	    endpoint_id: string;
+	    cpu_percent: number;
+	    memory_usage_mb: number;
+	    memory_limit_mb: number;
+	    memory_percent: number;
+	    network_rx_bytes: number;
+	    network_tx_bytes: number;
+	    block_read_bytes: number;
+	    block_write_bytes: number;
+	    pids: number;
+	    last_check: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint_id = source["endpoint_id"];
+	        this.cpu_percent = source["cpu_percent"];
+	        this.memory_usage_mb = source["memory_usage_mb"];
+	        this.memory_limit_mb = source["memory_limit_mb"];
+	        this.memory_percent = source["memory_percent"];
+	        this.network_rx_bytes = source["network_rx_bytes"];
+	        this.network_tx_bytes = source["network_tx_bytes"];
+	        this.block_read_bytes = source["block_read_bytes"];
+	        this.block_write_bytes = source["block_write_bytes"];
+	        this.pids = source["pids"];
+	        this.last_check = source["last_check"];
+	    }
+	}
+	export class ContainerStatus {
+	    endpoint_id: string;
+	    container_id: string;
+	    running: boolean;
+	    status: string;
+	    gone: boolean;
+	    last_check: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint_id = source["endpoint_id"];
+	        this.container_id = source["container_id"];
+	        this.running = source["running"];
+	        this.status = source["status"];
+	        this.gone = source["gone"];
+	        this.last_check = source["last_check"];
+	    }
+	}
+	export class CrawlOptions {
+	    seed_paths: string[];
+	    max_pages?: number;
+	    max_depth?: number;
+	    follow_links?: boolean;
+	    headers?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new CrawlOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seed_paths = source["seed_paths"];
+	        this.max_pages = source["max_pages"];
+	        this.max_depth = source["max_depth"];
+	        this.follow_links = source["follow_links"];
+	        this.headers = source["headers"];
+	    }
+	}
+	export class CrawlResult {
+	    endpoint_id: string;
+	    pages_fetched: number;
+	    responses: number;
+	    errors?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CrawlResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint_id = source["endpoint_id"];
+	        this.pages_fetched = source["pages_fetched"];
+	        this.responses = source["responses"];
+	        this.errors = source["errors"];
+	    }
+	}
+	
+	export class DockerImageInfo {
+	    image_name: string;
+	    exposed_ports: string[];
+	    volumes: string[];
+	    environment: Record<string, string>;
+	    working_dir?: string;
+	    entrypoint?: string[];
+	    cmd?: string[];
+	    labels?: Record<string, string>;
+	    suggested_health_check_path?: string;
+	    is_http_service: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DockerImageInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.image_name = source["image_name"];
+	        this.exposed_ports = source["exposed_ports"];
+	        this.volumes = source["volumes"];
+	        this.environment = source["environment"];
+	        this.working_dir = source["working_dir"];
+	        this.entrypoint = source["entrypoint"];
+	        this.cmd = source["cmd"];
+	        this.labels = source["labels"];
+	        this.suggested_health_check_path = source["suggested_health_check_path"];
+	        this.is_http_service = source["is_http_service"];
+	    }
+	}
+	export class DockerImageSpec {
+	    config_path: string;
+	    dockerfile: string;
+	    compose: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DockerImageSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.config_path = source["config_path"];
+	        this.dockerfile = source["dockerfile"];
+	        this.compose = source["compose"];
+	    }
+	}
+	
+	
+	
+	
+	
+	
+	
+	export class GRPCMethodInfo {
+	    method: string;
+	    input_type: string;
+	    output_type: string;
+	    client_streaming: boolean;
+	    server_streaming: boolean;
+	    example_body: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GRPCMethodInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.method = source["method"];
+	        this.input_type = source["input_type"];
+	        this.output_type = source["output_type"];
+	        this.client_streaming = source["client_streaming"];
+	        this.server_streaming = source["server_streaming"];
+	        this.example_body = source["example_body"];
+	    }
+	}
+	
+	export class GRPCRequestInfo {
+	    service: string;
+	    method: string;
+	    status_code: number;
+	    status: string;
+	    messages: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GRPCRequestInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.service = source["service"];
+	        this.method = source["method"];
+	        this.status_code = source["status_code"];
+	        this.status = source["status"];
+	        this.messages = source["messages"];
+	    }
+	}
+	export class AdminAPISettings {
+	    enabled: boolean;
+	    port?: number;
+	    token?: string;
+	    profiling?: boolean;;
+	
+	    static createFrom(source: any = {}) {
+	        return new AdminAPISettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.port = source["port"];
+	        this.token = source["token"];
+	        this.profiling = source["profiling"];
+	    }
+	}
+	export class GitStorage {
+	    commit_message?: string;
+	    pull_on_load?: boolean;
+	    push?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitStorage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.commit_message = source["commit_message"];
+	        this.pull_on_load = source["pull_on_load"];
+	        this.push = source["push"];
+	    }
+	}
+	export class HTTPStorage {
+	    base_url: string;
+	    headers?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new HTTPStorage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.base_url = source["base_url"];
+	        this.headers = source["headers"];
+	    }
+	}
+	
+	
+	export class HealthStatus {
+	    endpoint_id: string;
+	    healthy: boolean;
+	    last_check: string;
+	    error_message?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HealthStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint_id = source["endpoint_id"];
+	        this.healthy = source["healthy"];
+	        this.last_check = source["last_check"];
+	        this.error_message = source["error_message"];
+	    }
+	}
+	export class LogSettings {
+	    level?: string;
+	    file?: string;
+	    max_size_mb?: number;
+	    max_backups?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.level = source["level"];
+	        this.file = source["file"];
+	        this.max_size_mb = source["max_size_mb"];
+	        this.max_backups = source["max_backups"];
+	    }
+	}
+	export class MarketplaceBundle {
+	    id: string;
+	    name: string;
+	    description?: string;
+	    version?: string;
+	    tags?: string[];
+	    path: string;
+	    sha256: string;
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketplaceBundle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.version = source["version"];
+	        this.tags = source["tags"];
+	        this.path = source["path"];
+	        this.sha256 = source["sha256"];
+	        this.source = source["source"];
+	    }
+	}
+	
+	export class AuthOutcome {
+	    result: string;
+	    method?: string;
+	    principal?: string;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuthOutcome(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.result = source["result"];
+	        this.method = source["method"];
+	        this.principal = source["principal"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class MatchInfo {
+	    endpoint_id?: string;
+	    endpoint_name?: string;
+	    group_id?: string;
+	    group_name?: string;
+	    response_id?: string;
+	    path_pattern?: string;
+	    translated_path?: string;
+	    sequence_step?: number;
+	    sequence_length?: number;
+	    fault?: string;
+	    throttle?: string;;
+	    circuit?: string;
+	    auth?: AuthOutcome;
+	    explanation?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MatchInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint_id = source["endpoint_id"];
+	        this.endpoint_name = source["endpoint_name"];
+	        this.group_id = source["group_id"];
+	        this.group_name = source["group_name"];
+	        this.response_id = source["response_id"];
+	        this.path_pattern = source["path_pattern"];
+	        this.translated_path = source["translated_path"];
+	        this.sequence_step = source["sequence_step"];
+	        this.sequence_length = source["sequence_length"];
+	        this.fault = source["fault"];
+	        this.throttle = source["throttle"];
+	        this.circuit = source["circuit"];
+	        this.auth = this.convertValues(source["auth"], AuthOutcome);
+	        this.explanation = source["explanation"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MergeConflict {
+	    kind: string;
+	    endpoint: string;
+	    name?: string;
+	    base_id: string;
+	    other_id: string;
+	    resolution: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeConflict(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.endpoint = source["endpoint"];
+	        this.name = source["name"];
+	        this.base_id = source["base_id"];
+	        this.other_id = source["other_id"];
+	        this.resolution = source["resolution"];
+	    }
+	}
+	export class MergeReport {
+	    strategy: string;
+	    endpoints_added?: string[];
+	    items_added: number;
+	    unchanged: number;
+	    conflicts?: MergeConflict[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.strategy = source["strategy"];
+	        this.endpoints_added = source["endpoints_added"];
+	        this.items_added = source["items_added"];
+	        this.unchanged = source["unchanged"];
+	        this.conflicts = this.convertValues(source["conflicts"], MergeConflict);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class OfflineEndpointStatus {
+	    endpoint_id: string;
+	    endpoint_name: string;
+	    snapshot_id?: string;
+	    responses: number;
+	    recorded: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OfflineEndpointStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint_id = source["endpoint_id"];
+	        this.endpoint_name = source["endpoint_name"];
+	        this.snapshot_id = source["snapshot_id"];
+	        this.responses = source["responses"];
+	        this.recorded = source["recorded"];
+	    }
+	}
+	export class OfflineModeStatus {
+	    enabled: boolean;
+	    endpoints?: OfflineEndpointStatus[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OfflineModeStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.endpoints = this.convertValues(source["endpoints"], OfflineEndpointStatus);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PatternError {
+	    endpoint_id?: string;
+	    endpoint_name?: string;
+	    response_id?: string;
+	    field: string;
+	    pattern: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PatternError(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint_id = source["endpoint_id"];
+	        this.endpoint_name = source["endpoint_name"];
+	        this.response_id = source["response_id"];
+	        this.field = source["field"];
+	        this.pattern = source["pattern"];
+	        this.error = source["error"];
+	    }
+	}
+	
+	export class RecentFile {
+	    path: string;
+	    // Go type: time
+	    last_accessed: any;
+	    exists: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.last_accessed = this.convertValues(source["last_accessed"], null);
+	        this.exists = source["exists"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecoveryInfo {
+	    id: string;
+	    workspace: string;
+	    config_path?: string;
+	    // Go type: time
+	    saved: any;
+	    endpoints: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecoveryInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.workspace = source["workspace"];
+	        this.config_path = source["config_path"];
+	        this.saved = this.convertValues(source["saved"], null);
+	        this.endpoints = source["endpoints"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SOCKS5RequestInfo {
+	    target_host: string;
+	    target_port: number;
+	    protocol: string;
+	    is_intercepted: boolean;
+	    via_http_proxy?: boolean;
+	    proxy_user?: string;
+	    bytes_sent?: number;
+	    bytes_received?: number;
+	    duration_ms?: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SOCKS5RequestInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.target_host = source["target_host"];
+	        this.target_port = source["target_port"];
+	        this.protocol = source["protocol"];
+	        this.is_intercepted = source["is_intercepted"];
+	        this.via_http_proxy = source["via_http_proxy"];
+	        this.proxy_user = source["proxy_user"];
+	        this.bytes_sent = source["bytes_sent"];
+	        this.bytes_received = source["bytes_received"];
+	        this.duration_ms = source["duration_ms"];
+	        this.error = source["error"];
 	    }
 	}
+	export class CommandSnippets {
+	    curl: string;
+	    httpie: string;
+	    backend_curl?: string;
+	    backend_httpie?: string;
 	
+	    static createFrom(source: any = {}) {
+	        return new CommandSnippets(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.curl = source["curl"];
+	        this.httpie = source["httpie"];
+	        this.backend_curl = source["backend_curl"];
+	        this.backend_httpie = source["backend_httpie"];
+	    }
+	}
+	export class ReplayResult {
+	    log_id: string;
+	    method: string;
+	    url: string;
+	    status_code?: number;
+	    status_text?: string;
+	    headers?: Record<string, Array<string>>;
+	    body?: string;
+	    rtt_ms: number;
+	    error?: string;
+	    original_status?: number;
+	    original_headers?: Record<string, Array<string>>;
+	    original_body?: string;
+	    status_matches: boolean;
+	    body_matches: boolean;
 	
+	    static createFrom(source: any = {}) {
+	        return new ReplayResult(source);
+	    }
 	
-	export class ContainerStats {
-	    endpoint_id: string;
-	    cpu_percent: number;
-	    memory_usage_mb: number;
-	    memory_limit_mb: number;
-	    memory_percent: number;
-	    network_rx_bytes: number;
-	    network_tx_bytes: number;
-	    block_read_bytes: number;
-	    block_write_bytes: number;
-	    pids: number;
-	    last_check: string;
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.log_id = source["log_id"];
+	        this.method = source["method"];
+	        this.url = source["url"];
+	        this.status_code = source["status_code"];
+	        this.status_text = source["status_text"];
+	        this.headers = source["headers"];
+	        this.body = source["body"];
+	        this.rtt_ms = source["rtt_ms"];
+	        this.error = source["error"];
+	        this.original_status = source["original_status"];
+	        this.original_headers = source["original_headers"];
+	        this.original_body = source["original_body"];
+	        this.status_matches = source["status_matches"];
+	        this.body_matches = source["body_matches"];
+	    }
+	}
+	export class RequestLog {
+	    id: string;
+	    timestamp: string;
+	    endpoint_id?: string;
+	    validation_failed?: boolean;
+	    response_failed?: boolean;
+	    body_truncated?: boolean;
+	    socks5_info?: SOCKS5RequestInfo;
+	    grpc_info?: GRPCRequestInfo;
+	    assertion?: AssertionResult;
+	    match?: MatchInfo;
+	    // Go type: struct { Method string "json:\"method\""; FullURL string "json:\"full_url\""; Path string "json:\"path\""; QueryParams map[string][]string "json:\"query_params,omitempty\""; Headers map[string][]string "json:\"headers,omitempty\""; Body string "json:\"body,omitempty\""; Protocol string "json:\"protocol,omitempty\""; SourceIP string "json:\"source_ip\""; UserAgent string "json:\"user_agent,omitempty\"" }
+	    client_request: any;
+	    // Go type: struct { StatusCode *int "json:\"status_code,omitempty\""; StatusText string "json:\"status_text,omitempty\""; Headers map[string][]string "json:\"headers,omitempty\""; Body string "json:\"body,omitempty\""; DelayMs *int64 "json:\"delay_ms,omitempty\""; RTTMs *int64 "json:\"rtt_ms,omitempty\"" }
+	    client_response: any;
+	    // Go type: struct { Method string "json:\"method\""; FullURL string "json:\"full_url\""; Path string "json:\"path\""; QueryParams map[string][]string "json:\"query_params,omitempty\""; Headers map[string][]string "json:\"headers,omitempty\""; Body string "json:\"body,omitempty\"" }
+	    backend_request?: any;
+	    // Go type: struct { StatusCode *int "json:\"status_code,omitempty\""; StatusText string "json:\"status_text,omitempty\""; Headers map[string][]string "json:\"headers,omitempty\""; Body string "json:\"body,omitempty\""; DelayMs *int64 "json:\"delay_ms,omitempty\""; RTTMs *int64 "json:\"rtt_ms,omitempty\"" }
+	    backend_response?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new RequestLog(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.timestamp = source["timestamp"];
+	        this.endpoint_id = source["endpoint_id"];
+	        this.validation_failed = source["validation_failed"];
+	        this.response_failed = source["response_failed"];
+	        this.body_truncated = source["body_truncated"];
+	        this.socks5_info = this.convertValues(source["socks5_info"], SOCKS5RequestInfo);
+	        this.grpc_info = this.convertValues(source["grpc_info"], GRPCRequestInfo);
+	        this.assertion = this.convertValues(source["assertion"], AssertionResult);
+	        this.match = this.convertValues(source["match"], MatchInfo);
+	        this.client_request = this.convertValues(source["client_request"], Object);
+	        this.client_response = this.convertValues(source["client_response"], Object);
+	        this.backend_request = this.convertValues(source["backend_request"], Object);
+	        this.backend_response = this.convertValues(source["backend_response"], Object);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LogHeaderMatcher {
+	    name: string;
+	    pattern?: string;
+	    response?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogHeaderMatcher(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.pattern = source["pattern"];
+	        this.response = source["response"];
+	    }
+	}
+	export class RequestLogFilter {
+	    method?: string;
+	    path_regex?: string;
+	    status_min?: number;
+	    status_max?: number;
+	    endpoint_id?: string;
+	    proxy_user?: string;
+	    since?: string;
+	    until?: string;
+	    body_contains?: string;
+	    headers?: LogHeaderMatcher[];
+	    descending?: boolean;
+	    offset?: number;
+	    limit?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RequestLogFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.method = source["method"];
+	        this.path_regex = source["path_regex"];
+	        this.status_min = source["status_min"];
+	        this.status_max = source["status_max"];
+	        this.endpoint_id = source["endpoint_id"];
+	        this.proxy_user = source["proxy_user"];
+	        this.since = source["since"];
+	        this.until = source["until"];
+	        this.body_contains = source["body_contains"];
+	        this.headers = this.convertValues(source["headers"], LogHeaderMatcher);
+	        this.descending = source["descending"];
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RequestLogSummary {
+	    id: string;
+	    timestamp: string;
+	    endpoint_id?: string;
+	    method: string;
+	    path: string;
+	    source_ip: string;
+	    client_status?: number;
+	    backend_status?: number;
+	    client_rtt?: number;
+	    backend_rtt?: number;
+	    has_backend: boolean;
+	    client_body_size: number;
+	    pending: boolean;
+	    validation_failed?: boolean;
+	    response_failed?: boolean;
+	    target_host?: string;
+	    target_port?: number;
+	    via_socks5?: boolean;
+	    intercepted?: boolean;
+	    proxy_user?: string;
+	    bytes_sent?: number;
+	    bytes_received?: number;
+	    assertion_status?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RequestLogSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.timestamp = source["timestamp"];
+	        this.endpoint_id = source["endpoint_id"];
+	        this.method = source["method"];
+	        this.path = source["path"];
+	        this.source_ip = source["source_ip"];
+	        this.client_status = source["client_status"];
+	        this.backend_status = source["backend_status"];
+	        this.client_rtt = source["client_rtt"];
+	        this.backend_rtt = source["backend_rtt"];
+	        this.has_backend = source["has_backend"];
+	        this.client_body_size = source["client_body_size"];
+	        this.pending = source["pending"];
+	        this.validation_failed = source["validation_failed"];
+	        this.response_failed = source["response_failed"];
+	        this.target_host = source["target_host"];
+	        this.target_port = source["target_port"];
+	        this.via_socks5 = source["via_socks5"];
+	        this.intercepted = source["intercepted"];
+	        this.proxy_user = source["proxy_user"];
+	        this.bytes_sent = source["bytes_sent"];
+	        this.bytes_received = source["bytes_received"];
+	        this.assertion_status = source["assertion_status"];
+	    }
+	}
+	export class RequestLogPage {
+	    total: number;
+	    offset: number;
+	    logs: RequestLogSummary[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RequestLogPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.total = source["total"];
+	        this.offset = source["offset"];
+	        this.logs = this.convertValues(source["logs"], RequestLogSummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	export class ResponsePerfStats {
+	    response_id: string;
+	    endpoint_id?: string;
+	    endpoint_name?: string;
+	    path_pattern?: string;
+	    methods?: string[];
+	    mode: string;
+	    executions: number;
+	    errors: number;
+	    error_rate: number;
+	    total_ms: number;
+	    avg_ms: number;
+	    max_ms: number;
+	    last_error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResponsePerfStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.response_id = source["response_id"];
+	        this.endpoint_id = source["endpoint_id"];
+	        this.endpoint_name = source["endpoint_name"];
+	        this.path_pattern = source["path_pattern"];
+	        this.methods = source["methods"];
+	        this.mode = source["mode"];
+	        this.executions = source["executions"];
+	        this.errors = source["errors"];
+	        this.error_rate = source["error_rate"];
+	        this.total_ms = source["total_ms"];
+	        this.avg_ms = source["avg_ms"];
+	        this.max_ms = source["max_ms"];
+	        this.last_error = source["last_error"];
+	    }
+	}
+	
+	export class GoroutineGroup {
+	    count: number;
+	    function: string;
+	    stack: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new ContainerStats(source);
+	        return new GoroutineGroup(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.endpoint_id = source["endpoint_id"];
-	        this.cpu_percent = source["cpu_percent"];
-	        this.memory_usage_mb = source["memory_usage_mb"];
-	        this.memory_limit_mb = source["memory_limit_mb"];
-	        this.memory_percent = source["memory_percent"];
-	        this.network_rx_bytes = source["network_rx_bytes"];
-	        this.network_tx_bytes = source["network_tx_bytes"];
-	        this.block_read_bytes = source["block_read_bytes"];
-	        this.block_write_bytes = source["block_write_bytes"];
-	        this.pids = source["pids"];
-	        this.last_check = source["last_check"];
+	        this.count = source["count"];
+	        this.function = source["function"];
+	        this.stack = source["stack"];
 	    }
 	}
-	export class ContainerStatus {
-	    endpoint_id: string;
-	    container_id: string;
-	    running: boolean;
-	    status: string;
-	    gone: boolean;
-	    last_check: string;
+	export class RuntimeSample {
+	    time: string;
+	    goroutines: number;
+	    heap_alloc: number;
+	    heap_objects: number;
+	    sys: number;
+	    num_gc: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ContainerStatus(source);
+	        return new RuntimeSample(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.endpoint_id = source["endpoint_id"];
-	        this.container_id = source["container_id"];
-	        this.running = source["running"];
-	        this.status = source["status"];
-	        this.gone = source["gone"];
-	        this.last_check = source["last_check"];
+	        this.time = source["time"];
+	        this.goroutines = source["goroutines"];
+	        this.heap_alloc = source["heap_alloc"];
+	        this.heap_objects = source["heap_objects"];
+	        this.sys = source["sys"];
+	        this.num_gc = source["num_gc"];
 	    }
 	}
-	export class DockerImageInfo {
-	    image_name: string;
-	    exposed_ports: string[];
-	    volumes: string[];
-	    environment: Record<string, string>;
-	    working_dir?: string;
-	    entrypoint?: string[];
-	    cmd?: string[];
-	    labels?: Record<string, string>;
-	    suggested_health_check_path?: string;
-	    is_http_service: boolean;
+	export class RuntimeWarning {
+	    time: string;
+	    metric: string;
+	    from: number;
+	    to: number;
+	    window: string;
+	    message: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new DockerImageInfo(source);
+	        return new RuntimeWarning(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.image_name = source["image_name"];
-	        this.exposed_ports = source["exposed_ports"];
-	        this.volumes = source["volumes"];
-	        this.environment = source["environment"];
-	        this.working_dir = source["working_dir"];
-	        this.entrypoint = source["entrypoint"];
-	        this.cmd = source["cmd"];
-	        this.labels = source["labels"];
-	        this.suggested_health_check_path = source["suggested_health_check_path"];
-	        this.is_http_service = source["is_http_service"];
+	        this.time = source["time"];
+	        this.metric = source["metric"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.window = source["window"];
+	        this.message = source["message"];
 	    }
 	}
+	export class RuntimeHealth {
+	    current: RuntimeSample;
+	    samples: RuntimeSample[];
+	    warnings: RuntimeWarning[];
+	    top_goroutines: GoroutineGroup[];
+	    profiling: boolean;
 	
+	    static createFrom(source: any = {}) {
+	        return new RuntimeHealth(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.current = this.convertValues(source["current"], RuntimeSample);
+	        this.samples = this.convertValues(source["samples"], RuntimeSample);
+	        this.warnings = this.convertValues(source["warnings"], RuntimeWarning);
+	        this.top_goroutines = this.convertValues(source["top_goroutines"], GoroutineGroup);
+	        this.profiling = source["profiling"];
+	    }
 	
-	
-	
-	
-	
-	export class HealthStatus {
-	    endpoint_id: string;
-	    healthy: boolean;
-	    last_check: string;
-	    error_message?: string;
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RetryAfterViolation {
+	    client_ip: string;
+	    endpoint_id?: string;
+	    path: string;
+	    advised_log_id: string;
+	    status_code: number;
+	    retry_after_ms: number;
+	    retry_log_id: string;
+	    gap_ms: number;
+	    early_requests: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new HealthStatus(source);
+	        return new RetryAfterViolation(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.client_ip = source["client_ip"];
 	        this.endpoint_id = source["endpoint_id"];
-	        this.healthy = source["healthy"];
-	        this.last_check = source["last_check"];
-	        this.error_message = source["error_message"];
+	        this.path = source["path"];
+	        this.advised_log_id = source["advised_log_id"];
+	        this.status_code = source["status_code"];
+	        this.retry_after_ms = source["retry_after_ms"];
+	        this.retry_log_id = source["retry_log_id"];
+	        this.gap_ms = source["gap_ms"];
+	        this.early_requests = source["early_requests"];
 	    }
 	}
-	
-	
-	export class RecentFile {
-	    path: string;
-	    // Go type: time
-	    last_accessed: any;
-	    exists: boolean;
+	export class RetryAfterReport {
+	    advertised: number;
+	    honored: number;
+	    violated: number;
+	    no_retry: number;
+	    violations: RetryAfterViolation[];
 	
 	    static createFrom(source: any = {}) {
-	        return new RecentFile(source);
+	        return new RetryAfterReport(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.last_accessed = this.convertValues(source["last_accessed"], null);
-	        this.exists = source["exists"];
+	        this.advertised = source["advertised"];
+	        this.honored = source["honored"];
+	        this.violated = source["violated"];
+	        this.no_retry = source["no_retry"];
+	        this.violations = this.convertValues(source["violations"], RetryAfterViolation);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -895,56 +3497,49 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class SOCKS5RequestInfo {
-	    target_host: string;
-	    target_port: number;
-	    protocol: string;
-	    is_intercepted: boolean;
+	export class S3Storage {
+	    endpoint?: string;
+	    region: string;
+	    bucket: string;
+	    prefix?: string;
+	    access_key_id: string;
+	    secret_access_key: string;
+	    session_token?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new SOCKS5RequestInfo(source);
+	        return new S3Storage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.target_host = source["target_host"];
-	        this.target_port = source["target_port"];
-	        this.protocol = source["protocol"];
-	        this.is_intercepted = source["is_intercepted"];
+	        this.endpoint = source["endpoint"];
+	        this.region = source["region"];
+	        this.bucket = source["bucket"];
+	        this.prefix = source["prefix"];
+	        this.access_key_id = source["access_key_id"];
+	        this.secret_access_key = source["secret_access_key"];
+	        this.session_token = source["session_token"];
 	    }
 	}
-	export class RequestLog {
-	    id: string;
-	    timestamp: string;
-	    endpoint_id?: string;
-	    validation_failed?: boolean;
-	    response_failed?: boolean;
-	    socks5_info?: SOCKS5RequestInfo;
-	    // Go type: struct { Method string "json:\"method\""; FullURL string "json:\"full_url\""; Path string "json:\"path\""; QueryParams map[string][]string "json:\"query_params,omitempty\""; Headers map[string][]string "json:\"headers,omitempty\""; Body string "json:\"body,omitempty\""; Protocol string "json:\"protocol,omitempty\""; SourceIP string "json:\"source_ip\""; UserAgent string "json:\"user_agent,omitempty\"" }
-	    client_request: any;
-	    // Go type: struct { StatusCode *int "json:\"status_code,omitempty\""; StatusText string "json:\"status_text,omitempty\""; Headers map[string][]string "json:\"headers,omitempty\""; Body string "json:\"body,omitempty\""; DelayMs *int64 "json:\"delay_ms,omitempty\""; RTTMs *int64 "json:\"rtt_ms,omitempty\"" }
-	    client_response: any;
-	    // Go type: struct { Method string "json:\"method\""; FullURL string "json:\"full_url\""; Path string "json:\"path\""; QueryParams map[string][]string "json:\"query_params,omitempty\""; Headers map[string][]string "json:\"headers,omitempty\""; Body string "json:\"body,omitempty\"" }
-	    backend_request?: any;
-	    // Go type: struct { StatusCode *int "json:\"status_code,omitempty\""; StatusText string "json:\"status_text,omitempty\""; Headers map[string][]string "json:\"headers,omitempty\""; Body string "json:\"body,omitempty\""; DelayMs *int64 "json:\"delay_ms,omitempty\""; RTTMs *int64 "json:\"rtt_ms,omitempty\"" }
-	    backend_response?: any;
+	
+	
+	
+	export class ScheduledActionStatus {
+	    action: ScheduledAction;
+	    due_at: string;
+	    fired_at?: string;
+	    state: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new RequestLog(source);
+	        return new ScheduledActionStatus(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.timestamp = source["timestamp"];
-	        this.endpoint_id = source["endpoint_id"];
-	        this.validation_failed = source["validation_failed"];
-	        this.response_failed = source["response_failed"];
-	        this.socks5_info = this.convertValues(source["socks5_info"], SOCKS5RequestInfo);
-	        this.client_request = this.convertValues(source["client_request"], Object);
-	        this.client_response = this.convertValues(source["client_response"], Object);
-	        this.backend_request = this.convertValues(source["backend_request"], Object);
-	        this.backend_response = this.convertValues(source["backend_response"], Object);
+	        this.action = this.convertValues(source["action"], ScheduledAction);
+	        this.due_at = source["due_at"];
+	        this.fired_at = source["fired_at"];
+	        this.state = source["state"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -965,55 +3560,29 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class RequestLogSummary {
-	    id: string;
-	    timestamp: string;
+	
+	export class ScriptWarning {
 	    endpoint_id?: string;
-	    method: string;
-	    path: string;
-	    source_ip: string;
-	    client_status?: number;
-	    backend_status?: number;
-	    client_rtt?: number;
-	    backend_rtt?: number;
-	    has_backend: boolean;
-	    client_body_size: number;
-	    pending: boolean;
-	    validation_failed?: boolean;
-	    response_failed?: boolean;
-	    target_host?: string;
-	    target_port?: number;
+	    endpoint_name?: string;
+	    response_id?: string;
+	    field: string;
+	    error: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new RequestLogSummary(source);
+	        return new ScriptWarning(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.timestamp = source["timestamp"];
 	        this.endpoint_id = source["endpoint_id"];
-	        this.method = source["method"];
-	        this.path = source["path"];
-	        this.source_ip = source["source_ip"];
-	        this.client_status = source["client_status"];
-	        this.backend_status = source["backend_status"];
-	        this.client_rtt = source["client_rtt"];
-	        this.backend_rtt = source["backend_rtt"];
-	        this.has_backend = source["has_backend"];
-	        this.client_body_size = source["client_body_size"];
-	        this.pending = source["pending"];
-	        this.validation_failed = source["validation_failed"];
-	        this.response_failed = source["response_failed"];
-	        this.target_host = source["target_host"];
-	        this.target_port = source["target_port"];
+	        this.endpoint_name = source["endpoint_name"];
+	        this.response_id = source["response_id"];
+	        this.field = source["field"];
+	        this.error = source["error"];
 	    }
 	}
 	
 	
-	
-	
-	
 	export class ServerSettings {
 	    port?: number;
 	    http2_enabled?: boolean;
@@ -1023,9 +3592,16 @@ export namespace models {
 	    cert_mode?: string;
 	    cert_paths?: CertPaths;
 	    cert_names?: string[];
+	    client_auth?: ClientAuthConfig;
 	    cors?: CORSConfig;
+	    limits?: ServerLimits;
 	    socks5_config?: SOCKS5Config;
+	    http_proxy?: HTTPProxyConfig;
 	    domain_takeover?: DomainTakeoverConfig;
+	    marketplace_sources?: MarketplaceSource[];
+	    registry_credentials?: RegistryCredential[];
+	    container_hosts?: ContainerHost[];
+	    default_container_host?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ServerSettings(source);
@@ -1041,9 +3617,53 @@ export namespace models {
 	        this.cert_mode = source["cert_mode"];
 	        this.cert_paths = this.convertValues(source["cert_paths"], CertPaths);
 	        this.cert_names = source["cert_names"];
+	        this.client_auth = this.convertValues(source["client_auth"], ClientAuthConfig);
 	        this.cors = this.convertValues(source["cors"], CORSConfig);
+	        this.limits = this.convertValues(source["limits"], ServerLimits);
 	        this.socks5_config = this.convertValues(source["socks5_config"], SOCKS5Config);
+	        this.http_proxy = this.convertValues(source["http_proxy"], HTTPProxyConfig);
 	        this.domain_takeover = this.convertValues(source["domain_takeover"], DomainTakeoverConfig);
+	        this.marketplace_sources = this.convertValues(source["marketplace_sources"], MarketplaceSource);
+	        this.registry_credentials = this.convertValues(source["registry_credentials"], RegistryCredential);
+	        this.container_hosts = this.convertValues(source["container_hosts"], ContainerHost);
+	        this.default_container_host = source["default_container_host"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class StorageSettings {
+	    backend: string;
+	    git?: GitStorage;
+	    http?: HTTPStorage;
+	    s3?: S3Storage;
+	
+	    static createFrom(source: any = {}) {
+	        return new StorageSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.backend = source["backend"];
+	        this.git = this.convertValues(source["git"], GitStorage);
+	        this.http = this.convertValues(source["http"], HTTPStorage);
+	        this.s3 = this.convertValues(source["s3"], S3Storage);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1064,6 +3684,115 @@ export namespace models {
 		    return a;
 		}
 	}
+	export class TrafficStats {
+	    kind: string;
+	    key: string;
+	    name?: string;
+	    connections: number;
+	    bytes_in: number;
+	    bytes_out: number;
+	    in_rate: number;
+	    out_rate: number;
+	    last_active?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrafficStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.key = source["key"];
+	        this.name = source["name"];
+	        this.connections = source["connections"];
+	        this.bytes_in = source["bytes_in"];
+	        this.bytes_out = source["bytes_out"];
+	        this.in_rate = source["in_rate"];
+	        this.out_rate = source["out_rate"];
+	        this.last_active = source["last_active"];
+	    }
+	}
+	export class Transaction {
+	    id: string;
+	    type: string;
+	    key: string;
+	    value: string;
+	    request_ids: string[];
+	    request_count: number;
+	    error_count: number;
+	    start_time: string;
+	    end_time: string;
+	    duration_ms: number;
+	    total_rtt_ms: number;
+	    max_rtt_ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Transaction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.type = source["type"];
+	        this.key = source["key"];
+	        this.value = source["value"];
+	        this.request_ids = source["request_ids"];
+	        this.request_count = source["request_count"];
+	        this.error_count = source["error_count"];
+	        this.start_time = source["start_time"];
+	        this.end_time = source["end_time"];
+	        this.duration_ms = source["duration_ms"];
+	        this.total_rtt_ms = source["total_rtt_ms"];
+	        this.max_rtt_ms = source["max_rtt_ms"];
+	    }
+	}
+	export class UndoStatus {
+	    can_undo: boolean;
+	    can_redo: boolean;
+	    undo_label?: string;
+	    redo_label?: string;
+	    undo_steps: number;
+	    redo_steps: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UndoStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.can_undo = source["can_undo"];
+	        this.can_redo = source["can_redo"];
+	        this.undo_label = source["undo_label"];
+	        this.redo_label = source["redo_label"];
+	        this.undo_steps = source["undo_steps"];
+	        this.redo_steps = source["redo_steps"];
+	    }
+	}
+	export class WorkspaceInfo {
+	    id: string;
+	    name: string;
+	    active: boolean;
+	    running: boolean;
+	    port: number;
+	    config_path?: string;
+	    dirty: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.active = source["active"];
+	        this.running = source["running"];
+	        this.port = source["port"];
+	        this.config_path = source["config_path"];
+	        this.dirty = source["dirty"];
+	    }
+	}
+	
 	
 
 }
@@ -0,0 +1,187 @@
+package har
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"mockelot/models"
+)
+
+// Response headers not copied into mocks (recomputed when the mock is served)
+var skippedHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Content-Encoding":  true,
+	"Transfer-Encoding": true,
+	"Connection":        true,
+	"Keep-Alive":        true,
+	"Date":              true,
+}
+
+// File is the subset of a HAR 1.2 archive needed to build mocks
+type File struct {
+	Log struct {
+		Entries []Entry `json:"entries"`
+	} `json:"log"`
+}
+
+// Entry is one recorded request/response pair
+type Entry struct {
+	Request struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+	} `json:"request"`
+	Response struct {
+		Status     int         `json:"status"`
+		StatusText string      `json:"statusText"`
+		Headers    []NameValue `json:"headers"`
+		Content    struct {
+			MimeType string `json:"mimeType"`
+			Text     string `json:"text"`
+			Encoding string `json:"encoding"` // "base64" for binary bodies
+		} `json:"content"`
+	} `json:"response"`
+}
+
+// NameValue is a HAR header
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ImportFile reads a .har file exported from browser DevTools and converts it to response items
+func ImportFile(path string) ([]models.ResponseItem, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read file: %w", err)
+	}
+	return Import(data)
+}
+
+// Import converts a HAR archive into one group of static responses per host. Each response matches
+// the recorded method and path and returns the recorded status, headers and body. When a method and
+// path were recorded more than once, the last recording wins. Failed entries (status 0, e.g. blocked
+// or cancelled requests) and non-HTTP URLs are skipped. Returns the items and the number of responses.
+func Import(data []byte) ([]models.ResponseItem, int, error) {
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse HAR file: %w", err)
+	}
+
+	groups := make(map[string]*models.ResponseGroup)
+	var hosts []string
+	covered := make(map[string]bool)
+	count := 0
+
+	// Walk backwards so the most recent recording of each request wins
+	for i := len(file.Log.Entries) - 1; i >= 0; i-- {
+		entry := &file.Log.Entries[i]
+		if entry.Response.Status == 0 {
+			continue
+		}
+		requestURL, err := url.Parse(entry.Request.URL)
+		if err != nil || (requestURL.Scheme != "http" && requestURL.Scheme != "https") {
+			continue
+		}
+
+		path := requestURL.Path
+		if path == "" {
+			path = "/"
+		}
+		method := strings.ToUpper(entry.Request.Method)
+		key := method + " " + requestURL.Host + path
+		if covered[key] {
+			continue
+		}
+		covered[key] = true
+
+		response, err := toMockResponse(entry, method, path)
+		if err != nil {
+			return nil, 0, fmt.Errorf("entry %d (%s %s): %w", i, method, entry.Request.URL, err)
+		}
+
+		group := groups[requestURL.Host]
+		if group == nil {
+			enabled := true
+			expanded := true
+			group = &models.ResponseGroup{
+				ID:       uuid.New().String(),
+				Name:     requestURL.Host,
+				Enabled:  &enabled,
+				Expanded: &expanded,
+			}
+			groups[requestURL.Host] = group
+			hosts = append(hosts, requestURL.Host)
+		}
+		group.Responses = append(group.Responses, response)
+		count++
+	}
+
+	if count == 0 {
+		return nil, 0, fmt.Errorf("HAR file contains no completed HTTP requests")
+	}
+
+	// Hosts and responses are restored to recording order
+	items := make([]models.ResponseItem, 0, len(hosts))
+	for i := len(hosts) - 1; i >= 0; i-- {
+		group := groups[hosts[i]]
+		for a, b := 0, len(group.Responses)-1; a < b; a, b = a+1, b-1 {
+			group.Responses[a], group.Responses[b] = group.Responses[b], group.Responses[a]
+		}
+		items = append(items, models.ResponseItem{Type: "group", Group: group})
+	}
+	return items, count, nil
+}
+
+// toMockResponse converts a recorded response into a static mock response
+func toMockResponse(entry *Entry, method, path string) (models.MethodResponse, error) {
+	headers := make(map[string]string)
+	for _, header := range entry.Response.Headers {
+		// HTTP/2 pseudo-headers (":status") are not real headers
+		if strings.HasPrefix(header.Name, ":") {
+			continue
+		}
+		name := http.CanonicalHeaderKey(header.Name)
+		if skippedHeaders[name] {
+			continue
+		}
+		if existing, ok := headers[name]; ok {
+			headers[name] = existing + ", " + header.Value
+		} else {
+			headers[name] = header.Value
+		}
+	}
+	if _, ok := headers["Content-Type"]; !ok && entry.Response.Content.MimeType != "" {
+		headers["Content-Type"] = entry.Response.Content.MimeType
+	}
+
+	body := entry.Response.Content.Text
+	if entry.Response.Content.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			return models.MethodResponse{}, fmt.Errorf("invalid base64 body: %w", err)
+		}
+		body = string(decoded)
+	}
+
+	statusText := entry.Response.StatusText
+	if statusText == "" {
+		statusText = http.StatusText(entry.Response.Status)
+	}
+
+	return models.MethodResponse{
+		ID:           uuid.New().String(),
+		PathPattern:  path,
+		Methods:      []string{method},
+		StatusCode:   entry.Response.Status,
+		StatusText:   statusText,
+		Headers:      headers,
+		Body:         body,
+		ResponseMode: models.ResponseModeStatic,
+	}, nil
+}
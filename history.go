@@ -0,0 +1,316 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+	"mockelot/backup"
+	"mockelot/models"
+)
+
+// maxUndoSteps caps the undo history; the oldest edits are forgotten first
+const maxUndoSteps = 50
+
+// editState is the part of the config the undo history covers: endpoints and responses
+type editState struct {
+	Endpoints []models.Endpoint       `json:"endpoints"`
+	Items     []models.ResponseItem   `json:"items"`
+	Responses []models.MethodResponse `json:"responses"`
+}
+
+// editSnapshot is the edit state before an edit (undo) or after an undone one (redo)
+type editSnapshot struct {
+	label string
+	state []byte // JSON of the editState
+}
+
+// undoHistory is the in-memory undo and redo stack of one workspace
+type undoHistory struct {
+	undo  []editSnapshot
+	redo  []editSnapshot
+	mutex sync.Mutex
+}
+
+// captureEditState encodes the endpoints and responses of a config (caller holds configMutex)
+func captureEditState(cfg *models.AppConfig) []byte {
+	data, err := json.Marshal(editState{Endpoints: cfg.Endpoints, Items: cfg.Items, Responses: cfg.Responses})
+	if err != nil {
+		appLog.Error("Could not snapshot config for undo: %v", err)
+		return nil
+	}
+	return data
+}
+
+// pendingEdit is the state before an edit that has not succeeded yet
+type pendingEdit struct {
+	snapshot editSnapshot
+	history  *undoHistory
+}
+
+// snapshotEdit remembers the endpoints and responses before an edit, so the edit can be undone.
+// Call it before taking configMutex, and pass the result to commitEdit once the edit succeeded;
+// an edit that is rejected leaves the undo and redo stacks as they were.
+func (a *App) snapshotEdit(label string) *pendingEdit {
+	a.configMutex.RLock()
+	state := captureEditState(a.config)
+	history := a.undoHistory
+	a.configMutex.RUnlock()
+	if state == nil || history == nil {
+		return nil
+	}
+	return &pendingEdit{snapshot: editSnapshot{label: label, state: state}, history: history}
+}
+
+// commitEdit adds the snapshot of a successful edit to the undo stack and clears the redo stack.
+// It does not take configMutex, so it can be called with the lock held.
+func (a *App) commitEdit(edit *pendingEdit) {
+	if edit == nil {
+		return
+	}
+	history := edit.history
+	history.mutex.Lock()
+	history.undo = append(history.undo, edit.snapshot)
+	if len(history.undo) > maxUndoSteps {
+		history.undo = history.undo[len(history.undo)-maxUndoSteps:]
+	}
+	history.redo = nil
+	status := history.status()
+	history.mutex.Unlock()
+	a.emit("undo:changed", status)
+}
+
+// Undo reverts the last endpoint or response edit
+func (a *App) Undo() (models.UndoStatus, error) {
+	return a.stepHistory(true)
+}
+
+// Redo applies the last undone edit again
+func (a *App) Redo() (models.UndoStatus, error) {
+	return a.stepHistory(false)
+}
+
+// stepHistory moves one step back (undo) or forward (redo) in the history. Steps that would not
+// change anything are skipped.
+func (a *App) stepHistory(undo bool) (models.UndoStatus, error) {
+	a.configMutex.Lock()
+	history := a.undoHistory
+	current := captureEditState(a.config)
+
+	history.mutex.Lock()
+	from, to := &history.undo, &history.redo
+	if !undo {
+		from, to = to, from
+	}
+	var step *editSnapshot
+	for len(*from) > 0 {
+		last := (*from)[len(*from)-1]
+		*from = (*from)[:len(*from)-1]
+		if !bytes.Equal(last.state, current) {
+			step = &last
+			break
+		}
+	}
+	if step == nil {
+		history.mutex.Unlock()
+		a.configMutex.Unlock()
+		if undo {
+			return a.GetUndoStatus(), fmt.Errorf("nothing to undo")
+		}
+		return a.GetUndoStatus(), fmt.Errorf("nothing to redo")
+	}
+	*to = append(*to, editSnapshot{label: step.label, state: current})
+	history.mutex.Unlock()
+
+	var state editState
+	if err := json.Unmarshal(step.state, &state); err != nil {
+		a.configMutex.Unlock()
+		return a.GetUndoStatus(), fmt.Errorf("could not restore snapshot: %v", err)
+	}
+	// Container IDs are runtime state that snapshots do not hold: keep them for the endpoints
+	// that remain, and stop the containers of the endpoints the step removes
+	containerIDs := make(map[string]string)
+	removed := make(map[string]models.Endpoint)
+	for _, endpoint := range a.config.Endpoints {
+		if endpoint.ContainerConfig != nil && endpoint.ContainerConfig.ContainerID != "" {
+			containerIDs[endpoint.ID] = endpoint.ContainerConfig.ContainerID
+			removed[endpoint.ID] = endpoint
+		}
+	}
+	for i := range state.Endpoints {
+		endpoint := &state.Endpoints[i]
+		delete(removed, endpoint.ID)
+		if endpoint.ContainerConfig != nil {
+			endpoint.ContainerConfig.ContainerID = containerIDs[endpoint.ID]
+		}
+	}
+
+	a.config.Endpoints = state.Endpoints
+	a.config.Items = state.Items
+	a.config.Responses = state.Responses
+
+	// The selected endpoint may have been added by the reverted edit
+	selectionValid := false
+	for _, endpoint := range a.config.Endpoints {
+		if endpoint.ID == a.config.SelectedEndpointId {
+			selectionValid = true
+			break
+		}
+	}
+	if !selectionValid && len(a.config.Endpoints) > 0 {
+		a.config.SelectedEndpointId = a.config.Endpoints[0].ID
+	}
+	a.configMutex.Unlock()
+
+	if undo {
+		appLog.Info("Undo: %s", step.label)
+	} else {
+		appLog.Info("Redo: %s", step.label)
+	}
+
+	// Update server if running
+	if a.server != nil {
+		a.server.UpdateConfig(a.config)
+	}
+
+	if a.containerHandler != nil {
+		for _, endpoint := range removed {
+			endpoint := endpoint
+			go func() {
+				if err := a.containerHandler.StopContainer(context.Background(), &endpoint); err != nil {
+					appLog.Warn("Could not stop container of removed endpoint %s: %v", endpoint.Name, err)
+				}
+			}()
+		}
+	}
+
+	a.emit("responses:updated", a.config.Responses)
+	a.emit("items:updated", a.config.Items)
+	a.emit("endpoints:updated", a.config.Endpoints)
+	a.emit("config:dirty", a.IsDirty())
+	status := a.GetUndoStatus()
+	a.emit("undo:changed", status)
+	return status, nil
+}
+
+// GetUndoStatus reports whether edits can be undone or redone, and which
+func (a *App) GetUndoStatus() models.UndoStatus {
+	a.configMutex.RLock()
+	history := a.undoHistory
+	a.configMutex.RUnlock()
+
+	if history == nil {
+		return models.UndoStatus{}
+	}
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	return history.status()
+}
+
+// status reports the undo and redo steps (caller holds the history mutex)
+func (h *undoHistory) status() models.UndoStatus {
+	status := models.UndoStatus{}
+	status.UndoSteps = len(h.undo)
+	status.RedoSteps = len(h.redo)
+	status.CanUndo = status.UndoSteps > 0
+	status.CanRedo = status.RedoSteps > 0
+	if status.CanUndo {
+		status.UndoLabel = h.undo[len(h.undo)-1].label
+	}
+	if status.CanRedo {
+		status.RedoLabel = h.redo[len(h.redo)-1].label
+	}
+	return status
+}
+
+// clearUndoHistory forgets all edits, e.g., when another config is loaded
+func (a *App) clearUndoHistory() {
+	a.configMutex.RLock()
+	history := a.undoHistory
+	a.configMutex.RUnlock()
+	if history == nil {
+		return
+	}
+	history.mutex.Lock()
+	history.undo = nil
+	history.redo = nil
+	history.mutex.Unlock()
+	a.emit("undo:changed", models.UndoStatus{})
+}
+
+// ========== Config Backups ==========
+
+// backupConfig keeps a copy of a config that was just saved to path. Failures are logged; the
+// save itself has succeeded.
+func (a *App) backupConfig(path string, userConfig *models.UserConfig) {
+	store, err := a.backupStore()
+	if err != nil {
+		appLog.Warn("Config backup skipped: %v", err)
+		return
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(userConfig); err != nil {
+		appLog.Warn("Config backup skipped: could not encode config: %v", err)
+		return
+	}
+	encoder.Close()
+	if _, err := store.Save(path, buf.Bytes()); err != nil {
+		appLog.Warn("Config backup of %s failed: %v", path, err)
+	}
+}
+
+// backupStore returns the backup store in ~/.mockelot/backups
+func (a *App) backupStore() (*backup.Store, error) {
+	dir, err := backup.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return backup.NewStore(dir, backup.DefaultKeep), nil
+}
+
+// ListConfigBackups returns the backups of the current config file, newest first. A backup is
+// written on every save; the last 20 are kept.
+func (a *App) ListConfigBackups() ([]models.ConfigBackup, error) {
+	if a.currentConfigPath == "" {
+		return []models.ConfigBackup{}, nil
+	}
+	store, err := a.backupStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.List(a.currentConfigPath)
+}
+
+// RestoreConfigBackup loads a backup of the current config file as the current, unsaved config.
+// Saving writes it back to the config file. The endpoint and response changes of the restore can
+// be undone.
+func (a *App) RestoreConfigBackup(id string) (*models.AppConfig, error) {
+	if a.currentConfigPath == "" {
+		return nil, fmt.Errorf("no config file loaded")
+	}
+	store, err := a.backupStore()
+	if err != nil {
+		return nil, err
+	}
+	data, err := store.Read(a.currentConfigPath, id)
+	if err != nil {
+		return nil, err
+	}
+	var userCfg models.UserConfig
+	if err := yaml.Unmarshal(data, &userCfg); err != nil {
+		return nil, fmt.Errorf("could not decode backup: %v", err)
+	}
+
+	edit := a.snapshotEdit("Restore backup")
+	if err := a.loadUnsavedConfig(&userCfg, a.currentConfigPath); err != nil {
+		return nil, err
+	}
+	a.commitEdit(edit)
+	appLog.Info("Restored backup %s of %s", id, a.currentConfigPath)
+	return a.config, nil
+}
@@ -0,0 +1,238 @@
+// Package leakwatch samples the process's goroutine count and heap periodically and warns when
+// either grows at every sample over a window, which usually means something is leaking (for
+// example a health check loop started again on every config update but never stopped).
+package leakwatch
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"mockelot/models"
+)
+
+const (
+	// SampleInterval is how often the goroutine count and heap are read
+	SampleInterval = 30 * time.Second
+
+	// WindowSamples is how many consecutive samples must each grow before a warning (5 minutes)
+	WindowSamples = 10
+
+	maxSamples  = 120 // One hour of readings
+	maxWarnings = 50
+	topGroups   = 10
+
+	// Growth over the window must also be large enough to matter
+	minGoroutineGrowth = 20
+	minHeapGrowth      = 16 * 1024 * 1024
+	minGrowthRatio     = 0.25
+)
+
+// Watcher keeps the samples and warnings; warnings are also passed to the callback
+type Watcher struct {
+	mutex    sync.Mutex
+	samples  []models.RuntimeSample
+	warnings []models.RuntimeWarning
+	warned   map[string]bool // Metrics already reported for the current run of growth
+	onWarn   func(models.RuntimeWarning)
+	stop     chan struct{}
+}
+
+// New creates a watcher; onWarn (may be nil) is called for every new warning
+func New(onWarn func(models.RuntimeWarning)) *Watcher {
+	return &Watcher{
+		warned: make(map[string]bool),
+		onWarn: onWarn,
+	}
+}
+
+// Start samples every SampleInterval until Stop is called
+func (w *Watcher) Start() {
+	w.mutex.Lock()
+	if w.stop != nil {
+		w.mutex.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	w.stop = stop
+	w.mutex.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(SampleInterval)
+		defer ticker.Stop()
+		w.Sample()
+		for {
+			select {
+			case <-ticker.C:
+				w.Sample()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends periodic sampling
+func (w *Watcher) Stop() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+}
+
+// Sample takes a reading and checks both metrics for sustained growth
+func (w *Watcher) Sample() {
+	sample := read()
+
+	w.mutex.Lock()
+	w.samples = append(w.samples, sample)
+	if len(w.samples) > maxSamples {
+		w.samples = w.samples[len(w.samples)-maxSamples:]
+	}
+	var warnings []models.RuntimeWarning
+	if warning, ok := w.check("goroutines", func(s models.RuntimeSample) uint64 { return uint64(s.Goroutines) }, minGoroutineGrowth); ok {
+		warnings = append(warnings, warning)
+	}
+	if warning, ok := w.check("heap", func(s models.RuntimeSample) uint64 { return s.HeapAlloc }, minHeapGrowth); ok {
+		warnings = append(warnings, warning)
+	}
+	w.warnings = append(w.warnings, warnings...)
+	if len(w.warnings) > maxWarnings {
+		w.warnings = w.warnings[len(w.warnings)-maxWarnings:]
+	}
+	w.mutex.Unlock()
+
+	if w.onWarn != nil {
+		for _, warning := range warnings {
+			w.onWarn(warning)
+		}
+	}
+}
+
+// check reports a metric that grew at every one of the last WindowSamples samples by at least
+// minGrowth and minGrowthRatio overall. A metric is reported once per run of growth (mutex held).
+func (w *Watcher) check(metric string, value func(models.RuntimeSample) uint64, minGrowth uint64) (models.RuntimeWarning, bool) {
+	if len(w.samples) < WindowSamples {
+		return models.RuntimeWarning{}, false
+	}
+	window := w.samples[len(w.samples)-WindowSamples:]
+	for i := 1; i < len(window); i++ {
+		if value(window[i]) <= value(window[i-1]) {
+			w.warned[metric] = false
+			return models.RuntimeWarning{}, false
+		}
+	}
+
+	from, to := value(window[0]), value(window[len(window)-1])
+	if to-from < minGrowth || float64(to-from) < float64(from)*minGrowthRatio || w.warned[metric] {
+		return models.RuntimeWarning{}, false
+	}
+	w.warned[metric] = true
+
+	duration := SampleInterval * (WindowSamples - 1)
+	message := fmt.Sprintf("%s grew from %d to %d over %s", metric, from, to, duration)
+	if metric == "heap" {
+		message = fmt.Sprintf("heap grew from %.1f MB to %.1f MB over %s", float64(from)/(1024*1024), float64(to)/(1024*1024), duration)
+	}
+	return models.RuntimeWarning{
+		Time:    window[len(window)-1].Time,
+		Metric:  metric,
+		From:    from,
+		To:      to,
+		Window:  duration.String(),
+		Message: message,
+	}, true
+}
+
+// Report returns a fresh reading with the sample history, the warnings so far and the largest
+// groups of goroutines
+func (w *Watcher) Report() models.RuntimeHealth {
+	report := models.RuntimeHealth{
+		Current:       read(),
+		TopGoroutines: GoroutineGroups(topGroups),
+	}
+	w.mutex.Lock()
+	report.Samples = append([]models.RuntimeSample{}, w.samples...)
+	report.Warnings = append([]models.RuntimeWarning{}, w.warnings...)
+	w.mutex.Unlock()
+	return report
+}
+
+func read() models.RuntimeSample {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return models.RuntimeSample{
+		Time:        time.Now().Format(time.RFC3339),
+		Goroutines:  runtime.NumGoroutine(),
+		HeapAlloc:   mem.HeapAlloc,
+		HeapObjects: mem.HeapObjects,
+		Sys:         mem.Sys,
+		NumGC:       mem.NumGC,
+	}
+}
+
+// GoroutineGroups returns up to limit groups of goroutines sharing a stack, largest first
+func GoroutineGroups(limit int) []models.GoroutineGroup {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return []models.GoroutineGroup{}
+	}
+	groups := parseGoroutineProfile(buf.String())
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Count > groups[j].Count })
+	if len(groups) > limit {
+		groups = groups[:limit]
+	}
+	return groups
+}
+
+// parseGoroutineProfile parses the debug=1 goroutine profile: a "<count> @ <pcs>" line per stack,
+// followed by "#\t<pc>\t<function>+<offset>\t<file>:<line>" frames
+func parseGoroutineProfile(profile string) []models.GoroutineGroup {
+	groups := []models.GoroutineGroup{}
+	var current *models.GoroutineGroup
+	scanner := bufio.NewScanner(strings.NewReader(profile))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if count, _, ok := strings.Cut(line, " @ "); ok {
+			var n int
+			if _, err := fmt.Sscanf(count, "%d", &n); err == nil {
+				groups = append(groups, models.GoroutineGroup{Count: n})
+				current = &groups[len(groups)-1]
+			}
+			continue
+		}
+		if current == nil || !strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		function, _, _ := strings.Cut(fields[2], "+")
+		current.Stack = append(current.Stack, function)
+	}
+
+	for i := range groups {
+		group := &groups[i]
+		if len(group.Stack) == 0 {
+			continue
+		}
+		group.Function = group.Stack[len(group.Stack)-1]
+		for _, function := range group.Stack {
+			if strings.HasPrefix(function, "mockelot") || strings.HasPrefix(function, "main.") {
+				group.Function = function
+				break
+			}
+		}
+	}
+	return groups
+}
@@ -1,11 +1,16 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/google/uuid"
+	"mockelot/models"
 )
 
 // LogLevel represents the severity of a log entry
@@ -18,6 +23,20 @@ const (
 	ERROR
 )
 
+// Subsystems that write to the application log
+const (
+	SubsystemApp       = "app"
+	SubsystemServer    = "server"
+	SubsystemProxy     = "proxy"
+	SubsystemContainer = "container"
+	SubsystemSOCKS5    = "socks5"
+	SubsystemDNS       = "dns"
+	SubsystemStorage   = "storage"
+)
+
+// DefaultMaxEntries is how many entries the default hub keeps in memory
+const DefaultMaxEntries = 5000
+
 // String returns the string representation of the log level
 func (l LogLevel) String() string {
 	switch l {
@@ -34,143 +53,240 @@ func (l LogLevel) String() string {
 	}
 }
 
-// LogEntry represents a single log entry
-type LogEntry struct {
-	ID        string `json:"id"`
-	Timestamp string `json:"timestamp"`
-	Level     string `json:"level"`
-	Source    string `json:"source"`
-	Message   string `json:"message"`
-	Category  string `json:"category"` // "backend" or "frontend"
+// ParseLevel parses "debug", "info", "warn" or "error" (case-insensitive); empty means INFO
+func ParseLevel(level string) (LogLevel, error) {
+	switch strings.ToUpper(strings.TrimSpace(level)) {
+	case "DEBUG":
+		return DEBUG, nil
+	case "", "INFO":
+		return INFO, nil
+	case "WARN", "WARNING":
+		return WARN, nil
+	case "ERROR":
+		return ERROR, nil
+	}
+	return INFO, fmt.Errorf("invalid log level %q (expected debug, info, warn or error)", level)
 }
 
-// EventSender interface for sending events to frontend
-type EventSender interface {
-	SendEvent(source string, data interface{})
+// Hub collects the entries of every subsystem logger: it keeps the most recent ones in memory for
+// the in-app viewer, prints them to the console and optionally appends them to a rotating file
+type Hub struct {
+	mutex      sync.RWMutex
+	minLevel   LogLevel
+	entries    []models.AppLogEntry // Ring buffer of the most recent entries, oldest first
+	maxEntries int
+	seq        int64
+	console    io.Writer
+	file       *RotatingFile
 }
 
-// Logger is a structured logger with levels and event emission
-type Logger struct {
-	minLevel    LogLevel
-	eventSender EventSender
-	entries     []LogEntry
-	mutex       sync.RWMutex
-	maxEntries  int
-	source      string // Source identifier (e.g., "backend", "server", "app")
+// Default is the hub the subsystem loggers returned by For write to
+var Default = NewHub(INFO, DefaultMaxEntries)
+
+// For returns the logger of a subsystem on the default hub
+func For(subsystem string) *Logger {
+	return Default.Logger(subsystem)
 }
 
-// NewLogger creates a new logger instance
-func NewLogger(source string, minLevel LogLevel, maxEntries int, eventSender EventSender) *Logger {
-	return &Logger{
-		minLevel:    minLevel,
-		eventSender: eventSender,
-		entries:     make([]LogEntry, 0, maxEntries),
-		maxEntries:  maxEntries,
-		source:      source,
+// NewHub creates a hub that prints to stderr and keeps up to maxEntries entries
+func NewHub(minLevel LogLevel, maxEntries int) *Hub {
+	return &Hub{
+		minLevel:   minLevel,
+		entries:    make([]models.AppLogEntry, 0, maxEntries),
+		maxEntries: maxEntries,
+		console:    os.Stderr,
 	}
 }
 
-// log is the internal logging method
-func (l *Logger) log(level LogLevel, format string, args ...interface{}) {
-	// Skip if below minimum level
-	if level < l.minLevel {
-		return
+// Logger returns a logger writing to this hub under the given subsystem
+func (h *Hub) Logger(subsystem string) *Logger {
+	return &Logger{hub: h, subsystem: subsystem}
+}
+
+// SetMinLevel sets the minimum level recorded by every subsystem
+func (h *Hub) SetMinLevel(level LogLevel) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.minLevel = level
+}
+
+// GetMinLevel returns the current minimum log level
+func (h *Hub) GetMinLevel() LogLevel {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return h.minLevel
+}
+
+// SetConsole sets where entries are printed (nil to stop printing)
+func (h *Hub) SetConsole(w io.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.console = w
+}
+
+// SetFile appends entries as JSON lines to path, rotating it at maxSizeMB and keeping maxBackups
+// old files. An empty path closes the current file.
+func (h *Hub) SetFile(path string, maxSizeMB, maxBackups int) error {
+	var file *RotatingFile
+	if path != "" {
+		var err error
+		if file, err = OpenRotatingFile(path, maxSizeMB, maxBackups); err != nil {
+			return err
+		}
+	}
+
+	h.mutex.Lock()
+	old := h.file
+	h.file = file
+	h.mutex.Unlock()
+	if old != nil {
+		old.Close()
 	}
+	return nil
+}
 
-	message := fmt.Sprintf(format, args...)
+// write records an entry that passed the level check
+func (h *Hub) write(level LogLevel, subsystem string, fields map[string]string, message string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if level < h.minLevel {
+		return
+	}
 
-	entry := LogEntry{
-		ID:        uuid.New().String(),
-		Timestamp: time.Now().Format(time.RFC3339),
+	now := time.Now()
+	h.seq++
+	entry := models.AppLogEntry{
+		Seq:       h.seq,
+		Timestamp: now.Format(time.RFC3339Nano),
 		Level:     level.String(),
-		Source:    l.source,
+		Subsystem: subsystem,
 		Message:   message,
-		Category:  "backend",
+		Fields:    fields,
 	}
 
-	l.mutex.Lock()
 	// Circular buffer: remove oldest if at capacity
-	if len(l.entries) >= l.maxEntries {
-		l.entries = l.entries[1:]
+	if len(h.entries) >= h.maxEntries {
+		h.entries = h.entries[1:]
 	}
-	l.entries = append(l.entries, entry)
-	l.mutex.Unlock()
+	h.entries = append(h.entries, entry)
 
-	// Send event to frontend
-	if l.eventSender != nil {
-		l.eventSender.SendEvent("log", entry)
+	if h.console != nil {
+		fmt.Fprintf(h.console, "%s %-5s %s: %s%s\n", now.Format("2006/01/02 15:04:05"), entry.Level, subsystem, message, formatFields(fields))
+	}
+	if h.file != nil {
+		if data, err := json.Marshal(entry); err == nil {
+			h.file.Write(append(data, '\n'))
+		}
 	}
 }
 
-// Debug logs a debug message
-func (l *Logger) Debug(format string, args ...interface{}) {
-	l.log(DEBUG, format, args...)
+// Entries returns the stored entries at or above level, optionally limited to one subsystem and
+// to entries after since (zero = all), oldest first
+func (h *Hub) Entries(level LogLevel, subsystem string, since time.Time) []models.AppLogEntry {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	result := []models.AppLogEntry{}
+	for _, entry := range h.entries {
+		entryLevel, _ := ParseLevel(entry.Level)
+		if entryLevel < level || (subsystem != "" && entry.Subsystem != subsystem) {
+			continue
+		}
+		if !since.IsZero() {
+			if t, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil && !t.After(since) {
+				continue
+			}
+		}
+		result = append(result, entry)
+	}
+	return result
 }
 
-// Info logs an informational message
-func (l *Logger) Info(format string, args ...interface{}) {
-	l.log(INFO, format, args...)
+// Clear clears all stored log entries
+func (h *Hub) Clear() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.entries = make([]models.AppLogEntry, 0, h.maxEntries)
 }
 
-// Warn logs a warning message
-func (l *Logger) Warn(format string, args ...interface{}) {
-	l.log(WARN, format, args...)
+// Count returns the number of stored log entries
+func (h *Hub) Count() int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.entries)
 }
 
-// Error logs an error message
-func (l *Logger) Error(format string, args ...interface{}) {
-	l.log(ERROR, format, args...)
+// StdWriter returns a writer for the standard log package that records each line as an INFO
+// entry of the subsystem, so output from code not using a subsystem logger is not lost
+func (h *Hub) StdWriter(subsystem string) io.Writer {
+	return stdWriter{logger: h.Logger(subsystem)}
 }
 
-// GetLogs returns all stored log entries
-func (l *Logger) GetLogs() []LogEntry {
-	l.mutex.RLock()
-	defer l.mutex.RUnlock()
+type stdWriter struct {
+	logger *Logger
+}
 
-	// Return a copy to prevent external modification
-	logs := make([]LogEntry, len(l.entries))
-	copy(logs, l.entries)
-	return logs
+func (w stdWriter) Write(p []byte) (int, error) {
+	w.logger.log(INFO, strings.TrimRight(string(p), "\n"))
+	return len(p), nil
 }
 
-// GetLogsSince returns log entries since the given timestamp
-func (l *Logger) GetLogsSince(since string) []LogEntry {
-	l.mutex.RLock()
-	defer l.mutex.RUnlock()
+// Logger writes the entries of one subsystem, with optional structured fields
+type Logger struct {
+	hub       *Hub
+	subsystem string
+	fields    map[string]string
+}
 
-	var result []LogEntry
-	for _, entry := range l.entries {
-		if entry.Timestamp > since {
-			result = append(result, entry)
-		}
+// With returns a logger that adds a key=value field to every entry
+func (l *Logger) With(key string, value interface{}) *Logger {
+	fields := make(map[string]string, len(l.fields)+1)
+	for k, v := range l.fields {
+		fields[k] = v
 	}
-	return result
+	fields[key] = fmt.Sprint(value)
+	return &Logger{hub: l.hub, subsystem: l.subsystem, fields: fields}
 }
 
-// Clear clears all stored log entries
-func (l *Logger) Clear() {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	l.entries = make([]LogEntry, 0, l.maxEntries)
+// log is the internal logging method
+func (l *Logger) log(level LogLevel, message string) {
+	l.hub.write(level, l.subsystem, l.fields, message)
 }
 
-// SetMinLevel sets the minimum log level
-func (l *Logger) SetMinLevel(level LogLevel) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	l.minLevel = level
+// Debug logs a debug message
+func (l *Logger) Debug(format string, args ...interface{}) {
+	if l.hub.GetMinLevel() <= DEBUG {
+		l.log(DEBUG, fmt.Sprintf(format, args...))
+	}
 }
 
-// GetMinLevel returns the current minimum log level
-func (l *Logger) GetMinLevel() LogLevel {
-	l.mutex.RLock()
-	defer l.mutex.RUnlock()
-	return l.minLevel
+// Info logs an informational message
+func (l *Logger) Info(format string, args ...interface{}) {
+	l.log(INFO, fmt.Sprintf(format, args...))
 }
 
-// Count returns the number of stored log entries
-func (l *Logger) Count() int {
-	l.mutex.RLock()
-	defer l.mutex.RUnlock()
-	return len(l.entries)
+// Warn logs a warning message
+func (l *Logger) Warn(format string, args ...interface{}) {
+	l.log(WARN, fmt.Sprintf(format, args...))
+}
+
+// Error logs an error message
+func (l *Logger) Error(format string, args ...interface{}) {
+	l.log(ERROR, fmt.Sprintf(format, args...))
+}
+
+func formatFields(fields map[string]string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%q", key, fields[key])
+	}
+	return b.String()
 }
@@ -0,0 +1,178 @@
+package logsearch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Matcher is a compiled RequestLogFilter
+type Matcher struct {
+	filter  models.RequestLogFilter
+	path    *regexp.Regexp
+	since   time.Time
+	until   time.Time
+	body    string
+	headers []headerMatcher
+}
+
+type headerMatcher struct {
+	name     string
+	pattern  *regexp.Regexp
+	response bool
+}
+
+// Compile validates a filter and prepares its regular expressions and time window
+func Compile(filter models.RequestLogFilter) (*Matcher, error) {
+	m := &Matcher{filter: filter, body: strings.ToLower(filter.BodyContains)}
+
+	if filter.PathRegex != "" {
+		re, err := regexp.Compile(filter.PathRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path regex: %v", err)
+		}
+		m.path = re
+	}
+	if filter.StatusMin != 0 && filter.StatusMax != 0 && filter.StatusMin > filter.StatusMax {
+		return nil, fmt.Errorf("status range %d-%d is empty", filter.StatusMin, filter.StatusMax)
+	}
+
+	var err error
+	if filter.Since != "" {
+		if m.since, err = time.Parse(time.RFC3339, filter.Since); err != nil {
+			return nil, fmt.Errorf("invalid since time: %v", err)
+		}
+	}
+	if filter.Until != "" {
+		if m.until, err = time.Parse(time.RFC3339, filter.Until); err != nil {
+			return nil, fmt.Errorf("invalid until time: %v", err)
+		}
+	}
+
+	for _, header := range filter.Headers {
+		if header.Name == "" {
+			return nil, fmt.Errorf("header matcher needs a name")
+		}
+		hm := headerMatcher{name: header.Name, response: header.Response}
+		if header.Pattern != "" {
+			re, err := regexp.Compile(header.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for header %s: %v", header.Name, err)
+			}
+			hm.pattern = re
+		}
+		m.headers = append(m.headers, hm)
+	}
+	return m, nil
+}
+
+// Matches reports whether a log satisfies every condition of the filter
+func (m *Matcher) Matches(log *models.RequestLog) bool {
+	f := &m.filter
+	if f.Method != "" && !strings.EqualFold(f.Method, log.ClientRequest.Method) {
+		return false
+	}
+	if f.EndpointID != "" && f.EndpointID != log.EndpointID {
+		return false
+	}
+	if f.ProxyUser != "" && (log.SOCKS5Info == nil || f.ProxyUser != log.SOCKS5Info.ProxyUser) {
+		return false
+	}
+	if m.path != nil && !m.path.MatchString(log.ClientRequest.Path) {
+		return false
+	}
+
+	if f.StatusMin != 0 || f.StatusMax != 0 {
+		status := log.ClientResponse.StatusCode
+		if status == nil {
+			return false
+		}
+		if (f.StatusMin != 0 && *status < f.StatusMin) || (f.StatusMax != 0 && *status > f.StatusMax) {
+			return false
+		}
+	}
+
+	if !m.since.IsZero() || !m.until.IsZero() {
+		at, err := time.Parse(time.RFC3339, log.Timestamp)
+		if err != nil {
+			return false
+		}
+		if (!m.since.IsZero() && at.Before(m.since)) || (!m.until.IsZero() && !at.Before(m.until)) {
+			return false
+		}
+	}
+
+	if m.body != "" &&
+		!strings.Contains(strings.ToLower(log.ClientRequest.Body), m.body) &&
+		!strings.Contains(strings.ToLower(log.ClientResponse.Body), m.body) {
+		return false
+	}
+
+	for _, hm := range m.headers {
+		headers := log.ClientRequest.Headers
+		if hm.response {
+			headers = log.ClientResponse.Headers
+		}
+		if !hm.matches(headers) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether the header is present and (if a pattern is set) one of its values matches
+func (hm headerMatcher) matches(headers map[string][]string) bool {
+	for name, values := range headers {
+		if !strings.EqualFold(name, hm.name) {
+			continue
+		}
+		if hm.pattern == nil {
+			return true
+		}
+		for _, value := range values {
+			if hm.pattern.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Page returns the indexes of the matching logs in the requested page (oldest first unless Descending)
+// and the total number of matches
+func (m *Matcher) Page(logs []models.RequestLog) (indexes []int, total int) {
+	limit := m.filter.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	offset := m.filter.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	for n := range logs {
+		i := n
+		if m.filter.Descending {
+			i = len(logs) - 1 - n
+		}
+		if !m.Matches(&logs[i]) {
+			continue
+		}
+		if total >= offset && len(indexes) < limit {
+			indexes = append(indexes, i)
+		}
+		total++
+	}
+	return indexes, total
+}
@@ -2,6 +2,10 @@ package main
 
 import (
 	"embed"
+	"log"
+	"os"
+
+	"mockelot/logger"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -12,6 +16,15 @@ import (
 var assets embed.FS
 
 func main() {
+	// Route the standard logger (third-party packages) into the application log
+	log.SetFlags(0)
+	log.SetOutput(logger.Default.StdWriter(logger.SubsystemApp))
+
+	// Headless mode: `mockelot serve --config config.yaml` runs the servers without the desktop UI
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		os.Exit(runServe(os.Args[2:]))
+	}
+
 	// Create an instance of the app structure
 	app := NewApp()
 
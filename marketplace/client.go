@@ -0,0 +1,281 @@
+package marketplace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"mockelot/logger"
+	"mockelot/models"
+)
+
+var marketplaceLog = logger.For(logger.SubsystemApp)
+
+const (
+	indexFileName   = "index.json"
+	maxDownloadSize = 10 << 20 // 10 MiB cap for index and bundle downloads
+)
+
+// Client fetches registry indexes and bundles from marketplace sources
+type Client struct {
+	cacheDir   string
+	httpClient *http.Client
+}
+
+// NewClient creates a marketplace client
+// Git sources are cloned under cacheDir; if empty, ~/.mockelot/marketplace is used
+func NewClient(cacheDir string) *Client {
+	if cacheDir == "" {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			cacheDir = filepath.Join(homeDir, ".mockelot", "marketplace")
+		} else {
+			cacheDir = filepath.Join(os.TempDir(), "mockelot-marketplace")
+		}
+	}
+	return &Client{
+		cacheDir:   cacheDir,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ListBundles fetches the index for a source and returns its bundles
+// For git sources the repository is cloned (or refreshed) first
+func (c *Client) ListBundles(source models.MarketplaceSource) ([]models.MarketplaceBundle, error) {
+	data, err := c.readIndex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var index Index
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("could not decode index for source %s: %v", source.Name, err)
+	}
+
+	bundles := make([]models.MarketplaceBundle, 0, len(index.Bundles))
+	for _, entry := range index.Bundles {
+		if entry.ID == "" || entry.Path == "" {
+			marketplaceLog.Warn("Marketplace: skipping bundle without id/path in source %s", source.Name)
+			continue
+		}
+		bundles = append(bundles, models.MarketplaceBundle{
+			ID:          entry.ID,
+			Name:        entry.Name,
+			Description: entry.Description,
+			Version:     entry.Version,
+			Tags:        entry.Tags,
+			Path:        entry.Path,
+			SHA256:      strings.ToLower(entry.SHA256),
+			Source:      source.Name,
+		})
+	}
+
+	return bundles, nil
+}
+
+// FetchBundle downloads a bundle, verifies its checksum and decodes it
+// Bundles without a checksum in the index are rejected
+func (c *Client) FetchBundle(source models.MarketplaceSource, bundle models.MarketplaceBundle) (*BundleFile, error) {
+	if bundle.SHA256 == "" {
+		return nil, fmt.Errorf("bundle %s has no checksum in the index", bundle.ID)
+	}
+
+	data, err := c.readBundle(source, bundle.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(actual, bundle.SHA256) {
+		return nil, fmt.Errorf("checksum mismatch for bundle %s: expected %s, got %s", bundle.ID, bundle.SHA256, actual)
+	}
+
+	var file BundleFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("could not decode bundle %s: %v", bundle.ID, err)
+	}
+	if len(file.Endpoints) == 0 {
+		return nil, fmt.Errorf("bundle %s contains no endpoints", bundle.ID)
+	}
+
+	return &file, nil
+}
+
+// readIndex returns the raw index.json content for a source
+func (c *Client) readIndex(source models.MarketplaceSource) ([]byte, error) {
+	switch source.Type {
+	case models.MarketplaceSourceHTTP, "":
+		return c.download(source.URL)
+	case models.MarketplaceSourceGit:
+		repoDir, err := c.syncRepo(source)
+		if err != nil {
+			return nil, err
+		}
+		indexPath, err := repoFile(repoDir, indexFileName)
+		if err != nil {
+			return nil, err
+		}
+		return os.ReadFile(indexPath)
+	default:
+		return nil, fmt.Errorf("unknown marketplace source type: %s", source.Type)
+	}
+}
+
+// readBundle returns the raw bundle content, resolving bundlePath against the source
+func (c *Client) readBundle(source models.MarketplaceSource, bundlePath string) ([]byte, error) {
+	switch source.Type {
+	case models.MarketplaceSourceHTTP, "":
+		base, err := url.Parse(source.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source URL: %v", err)
+		}
+		ref, err := url.Parse(bundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle path: %v", err)
+		}
+		return c.download(base.ResolveReference(ref).String())
+	case models.MarketplaceSourceGit:
+		fullPath, err := repoFile(c.repoDir(source), bundlePath)
+		if err != nil {
+			return nil, err
+		}
+		return os.ReadFile(fullPath)
+	default:
+		return nil, fmt.Errorf("unknown marketplace source type: %s", source.Type)
+	}
+}
+
+// repoFile resolves a slash-separated path inside a repository checkout, following symlinks,
+// and rejects paths (or links) that lead outside the checkout
+func repoFile(repoDir, relPath string) (string, error) {
+	root, err := filepath.EvalSymlinks(repoDir)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve repository checkout: %v", err)
+	}
+	inside := func(path string) bool {
+		rel, err := filepath.Rel(root, path)
+		return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	}
+
+	fullPath := filepath.Join(root, filepath.FromSlash(relPath))
+	if !inside(fullPath) {
+		return "", fmt.Errorf("path escapes repository: %s", relPath)
+	}
+	fullPath, err = filepath.EvalSymlinks(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve %s: %v", relPath, err)
+	}
+	if !inside(fullPath) {
+		return "", fmt.Errorf("path escapes repository: %s", relPath)
+	}
+	return fullPath, nil
+}
+
+// download fetches a URL with a size cap
+func (c *Client) download(rawURL string) ([]byte, error) {
+	resp, err := c.httpClient.Get(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: status %d", rawURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rawURL, err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("%s exceeds maximum size of %d bytes", rawURL, maxDownloadSize)
+	}
+
+	return data, nil
+}
+
+// repoDir returns the local checkout directory for a git source
+func (c *Client) repoDir(source models.MarketplaceSource) string {
+	sum := sha256.Sum256([]byte(source.URL + "#" + source.Ref))
+	return filepath.Join(c.cacheDir, hex.EncodeToString(sum[:8]))
+}
+
+// syncRepo brings the cached checkout of a git source up to date. An existing checkout is
+// fetched and reset to the source's ref; a fresh shallow clone is made only when there is no
+// checkout yet or updating it fails.
+func (c *Client) syncRepo(source models.MarketplaceSource) (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", fmt.Errorf("git is required for git marketplace sources: %w", err)
+	}
+	if err := os.MkdirAll(c.cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("could not create marketplace cache directory: %v", err)
+	}
+
+	repoDir := c.repoDir(source)
+	if _, err := os.Stat(filepath.Join(repoDir, ".git")); err == nil {
+		err := updateRepo(repoDir, source.Ref)
+		if err == nil {
+			marketplaceLog.Info("Marketplace: updated %s in %s", source.URL, repoDir)
+			return repoDir, nil
+		}
+		marketplaceLog.Warn("Marketplace: could not update %s, cloning it again: %v", source.URL, err)
+	}
+
+	// Clone into a temporary directory first so a failed clone keeps the previous checkout
+	tmpDir, err := os.MkdirTemp(c.cacheDir, "clone-*")
+	if err != nil {
+		return "", fmt.Errorf("could not create temporary clone directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	args := []string{"clone", "--depth", "1"}
+	if source.Ref != "" {
+		args = append(args, "--branch", source.Ref)
+	}
+	args = append(args, "--", source.URL, tmpDir)
+
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git clone failed for %s: %v: %s", source.URL, err, strings.TrimSpace(string(output)))
+	}
+
+	if err := os.RemoveAll(repoDir); err != nil {
+		return "", fmt.Errorf("could not remove stale checkout: %v", err)
+	}
+	if err := os.Rename(tmpDir, repoDir); err != nil {
+		return "", fmt.Errorf("could not move checkout into place: %v", err)
+	}
+
+	marketplaceLog.Info("Marketplace: synced %s into %s", source.URL, repoDir)
+	return repoDir, nil
+}
+
+// updateRepo fetches the latest commit of ref (the remote's default branch when empty) into an
+// existing shallow checkout and resets the working tree to it
+func updateRepo(repoDir, ref string) error {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	for _, args := range [][]string{
+		{"fetch", "--depth", "1", "origin", ref},
+		{"reset", "--hard", "FETCH_HEAD"},
+		{"clean", "-fdx"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", repoDir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
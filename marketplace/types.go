@@ -0,0 +1,28 @@
+package marketplace
+
+import "mockelot/models"
+
+// Index is the registry manifest (index.json) listing available bundles
+type Index struct {
+	Name    string        `json:"name,omitempty" yaml:"name,omitempty"` // Registry display name
+	Bundles []IndexBundle `json:"bundles" yaml:"bundles"`               // Available bundles
+}
+
+// IndexBundle is a single bundle entry in a registry index
+type IndexBundle struct {
+	ID          string   `json:"id" yaml:"id"`
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string   `json:"version,omitempty" yaml:"version,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Path        string   `json:"path" yaml:"path"`     // Relative to the index location, or an absolute URL (http sources only)
+	SHA256      string   `json:"sha256" yaml:"sha256"` // Hex-encoded SHA-256 of the bundle file
+}
+
+// BundleFile is the on-disk format of a bundle: a named set of endpoints
+// using the same schema as the endpoints section of a config file
+type BundleFile struct {
+	Name        string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Endpoints   []models.Endpoint `json:"endpoints" yaml:"endpoints"`
+}
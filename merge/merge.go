@@ -0,0 +1,347 @@
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"mockelot/models"
+)
+
+// Merge resolutions recorded in conflicts
+const (
+	resolutionKeptBase  = "kept base"
+	resolutionTookOther = "took other"
+	resolutionKeptBoth  = "kept both"
+)
+
+type merger struct {
+	strategy string
+	report   *models.MergeReport
+}
+
+// Merge combines two configs into a new one. Endpoints are matched by ID, then by name and type;
+// groups by ID, then name; responses by ID, then methods and path pattern. Entries only in the
+// other config are added, and entries that differ are resolved with the given strategy.
+// Server settings always come from the base config.
+func Merge(base, other *models.UserConfig, strategy string) (*models.UserConfig, *models.MergeReport, error) {
+	if strategy == "" {
+		strategy = models.MergeStrategyBase
+	}
+	if strategy != models.MergeStrategyBase && strategy != models.MergeStrategyOther && strategy != models.MergeStrategyBoth {
+		return nil, nil, fmt.Errorf("unknown merge strategy: %s", strategy)
+	}
+
+	var merged models.UserConfig
+	if err := models.DeepCopy(base, &merged); err != nil {
+		return nil, nil, fmt.Errorf("could not copy base config: %v", err)
+	}
+	var incoming models.UserConfig
+	if err := models.DeepCopy(other, &incoming); err != nil {
+		return nil, nil, fmt.Errorf("could not copy other config: %v", err)
+	}
+
+	m := &merger{strategy: strategy, report: &models.MergeReport{Strategy: strategy}}
+	merged.Endpoints = m.mergeEndpoints(merged.Endpoints, incoming.Endpoints)
+	merged.Items = m.mergeItems("(legacy items)", merged.Items, incoming.Items)
+	return &merged, m.report, nil
+}
+
+func (m *merger) mergeEndpoints(result, other []models.Endpoint) []models.Endpoint {
+	maxOrder := 0
+	for _, endpoint := range result {
+		if endpoint.DisplayOrder > maxOrder {
+			maxOrder = endpoint.DisplayOrder
+		}
+	}
+
+	for _, endpoint := range other {
+		// System endpoints are recreated on load
+		if endpoint.IsSystem {
+			continue
+		}
+
+		index := findEndpoint(result, &endpoint)
+		if index < 0 {
+			maxOrder++
+			endpoint.DisplayOrder = maxOrder
+			result = append(result, endpoint)
+			m.report.EndpointsAdded = append(m.report.EndpointsAdded, endpoint.Name)
+			continue
+		}
+		target := &result[index]
+
+		// Mock endpoints merge item by item and version by version; their own settings are resolved separately
+		if target.Type == models.EndpointTypeMock && endpoint.Type == models.EndpointTypeMock {
+			var versions []models.APIVersion
+			if target.Versioning != nil {
+				versions = target.Versioning.Versions
+			}
+			if sameEndpointSettings(target, &endpoint) {
+				m.report.Unchanged++
+			} else {
+				resolution := resolutionKeptBase
+				if m.strategy == models.MergeStrategyOther {
+					items := target.Items
+					id, order := target.ID, target.DisplayOrder
+					*target = endpoint
+					target.ID, target.DisplayOrder, target.Items = id, order, items
+					resolution = resolutionTookOther
+				}
+				m.conflict("endpoint", target.Name, "", target.ID, endpoint.ID, resolution)
+			}
+			target.Items = m.mergeItems(target.Name, target.Items, endpoint.Items)
+			if endpoint.Versioning != nil {
+				if target.Versioning == nil {
+					routing := *endpoint.Versioning
+					target.Versioning = &routing
+				}
+				target.Versioning.Versions = m.mergeVersions(target.Name, versions, endpoint.Versioning.Versions)
+			} else if target.Versioning != nil {
+				target.Versioning.Versions = versions
+			}
+			continue
+		}
+
+		if sameEndpointSettings(target, &endpoint) && jsonEqual(target.Items, endpoint.Items) {
+			m.report.Unchanged++
+			continue
+		}
+		switch m.strategy {
+		case models.MergeStrategyOther:
+			// Keep the base ID and position so references (selection, snapshots) stay valid
+			id, order := target.ID, target.DisplayOrder
+			*target = endpoint
+			target.ID, target.DisplayOrder = id, order
+			m.conflict("endpoint", target.Name, "", id, endpoint.ID, resolutionTookOther)
+		case models.MergeStrategyBoth:
+			baseID := target.ID
+			maxOrder++
+			duplicate := endpoint
+			duplicate.ID = uuid.New().String()
+			duplicate.Name = endpoint.Name + " (merged)"
+			duplicate.DisplayOrder = maxOrder
+			result = append(result, duplicate)
+			m.conflict("endpoint", endpoint.Name, "", baseID, endpoint.ID, resolutionKeptBoth)
+		default:
+			m.conflict("endpoint", target.Name, "", target.ID, endpoint.ID, resolutionKeptBase)
+		}
+	}
+	return result
+}
+
+// mergeVersions matches API versions by name and merges the items of each; versions only in other are added
+func (m *merger) mergeVersions(endpointName string, result, other []models.APIVersion) []models.APIVersion {
+	for _, version := range other {
+		index := -1
+		for i := range result {
+			if result[i].Version == version.Version {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			result = append(result, version)
+			m.report.ItemsAdded += len(version.Items)
+			continue
+		}
+		result[index].Items = m.mergeItems(endpointName+" "+version.Version, result[index].Items, version.Items)
+	}
+	return result
+}
+
+func (m *merger) mergeItems(endpointName string, result, other []models.ResponseItem) []models.ResponseItem {
+	for _, item := range other {
+		switch {
+		case item.Type == "response" && item.Response != nil:
+			index := -1
+			for i := range result {
+				if result[i].Type == "response" && result[i].Response != nil && sameResponseKey(result[i].Response, item.Response) {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				result = append(result, item)
+				m.report.ItemsAdded++
+				continue
+			}
+			if duplicate := m.mergeResponse(endpointName, result[index].Response, item.Response); duplicate != nil {
+				result = append(result, models.ResponseItem{Type: "response", Response: duplicate})
+			}
+
+		case item.Type == "group" && item.Group != nil:
+			index := -1
+			for i := range result {
+				if result[i].Type == "group" && result[i].Group != nil && sameGroupKey(result[i].Group, item.Group) {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				result = append(result, item)
+				m.report.ItemsAdded++
+				continue
+			}
+
+			target := result[index].Group
+			if sameGroupSettings(target, item.Group) {
+				m.report.Unchanged++
+			} else {
+				resolution := resolutionKeptBase
+				if m.strategy == models.MergeStrategyOther {
+					responses := target.Responses
+					id := target.ID
+					*target = *item.Group
+					target.ID, target.Responses = id, responses
+					resolution = resolutionTookOther
+				}
+				m.conflict("group", endpointName, target.Name, target.ID, item.Group.ID, resolution)
+			}
+			target.Responses = m.mergeResponses(endpointName, target.Responses, item.Group.Responses)
+		}
+	}
+	return result
+}
+
+func (m *merger) mergeResponses(endpointName string, result, other []models.MethodResponse) []models.MethodResponse {
+	for i := range other {
+		index := -1
+		for j := range result {
+			if sameResponseKey(&result[j], &other[i]) {
+				index = j
+				break
+			}
+		}
+		if index < 0 {
+			result = append(result, other[i])
+			m.report.ItemsAdded++
+			continue
+		}
+		if duplicate := m.mergeResponse(endpointName, &result[index], &other[i]); duplicate != nil {
+			result = append(result, *duplicate)
+		}
+	}
+	return result
+}
+
+// mergeResponse resolves a matched response in place; with the "both" strategy it returns the copy to add
+func (m *merger) mergeResponse(endpointName string, target, incoming *models.MethodResponse) *models.MethodResponse {
+	if sameResponse(target, incoming) {
+		m.report.Unchanged++
+		return nil
+	}
+
+	name := responseName(target)
+	switch m.strategy {
+	case models.MergeStrategyOther:
+		id := target.ID
+		*target = *incoming
+		target.ID = id
+		m.conflict("response", endpointName, name, id, incoming.ID, resolutionTookOther)
+	case models.MergeStrategyBoth:
+		duplicate := *incoming
+		duplicate.ID = uuid.New().String()
+		m.conflict("response", endpointName, name, target.ID, incoming.ID, resolutionKeptBoth)
+		return &duplicate
+	default:
+		m.conflict("response", endpointName, name, target.ID, incoming.ID, resolutionKeptBase)
+	}
+	return nil
+}
+
+func (m *merger) conflict(kind, endpoint, name, baseID, otherID, resolution string) {
+	m.report.Conflicts = append(m.report.Conflicts, models.MergeConflict{
+		Kind:       kind,
+		Endpoint:   endpoint,
+		Name:       name,
+		BaseID:     baseID,
+		OtherID:    otherID,
+		Resolution: resolution,
+	})
+}
+
+// findEndpoint returns the index of the endpoint matching by ID, or by name and type
+func findEndpoint(endpoints []models.Endpoint, endpoint *models.Endpoint) int {
+	for i := range endpoints {
+		if endpoint.ID != "" && endpoints[i].ID == endpoint.ID {
+			return i
+		}
+	}
+	for i := range endpoints {
+		if endpoints[i].Name == endpoint.Name && endpoints[i].Type == endpoint.Type && !endpoints[i].IsSystem {
+			return i
+		}
+	}
+	return -1
+}
+
+func sameGroupKey(a, b *models.ResponseGroup) bool {
+	if a.ID != "" && a.ID == b.ID {
+		return true
+	}
+	return a.Name == b.Name
+}
+
+func sameResponseKey(a, b *models.MethodResponse) bool {
+	if a.ID != "" && a.ID == b.ID {
+		return true
+	}
+	return responseName(a) == responseName(b)
+}
+
+// responseName identifies a response by its methods and path pattern (e.g. "GET,POST /users")
+func responseName(resp *models.MethodResponse) string {
+	methods := make([]string, len(resp.Methods))
+	for i, method := range resp.Methods {
+		methods[i] = strings.ToUpper(method)
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ",") + " " + resp.PathPattern
+}
+
+// sameEndpointSettings compares endpoints ignoring IDs, ordering, items and versions
+func sameEndpointSettings(a, b *models.Endpoint) bool {
+	left, right := *a, *b
+	left.ID, right.ID = "", ""
+	left.DisplayOrder, right.DisplayOrder = 0, 0
+	left.Items, right.Items = nil, nil
+	left.Versioning, right.Versioning = routingSettings(a.Versioning), routingSettings(b.Versioning)
+	return jsonEqual(left, right)
+}
+
+// routingSettings returns a copy of version routing without its versions
+func routingSettings(routing *models.VersionRouting) *models.VersionRouting {
+	if routing == nil {
+		return nil
+	}
+	settings := *routing
+	settings.Versions = nil
+	return &settings
+}
+
+// sameGroupSettings compares groups ignoring IDs and responses
+func sameGroupSettings(a, b *models.ResponseGroup) bool {
+	left, right := *a, *b
+	left.ID, right.ID = "", ""
+	left.Responses, right.Responses = nil, nil
+	return jsonEqual(left, right)
+}
+
+// sameResponse compares responses ignoring IDs
+func sameResponse(a, b *models.MethodResponse) bool {
+	left, right := *a, *b
+	left.ID, right.ID = "", ""
+	return jsonEqual(left, right)
+}
+
+func jsonEqual(a, b interface{}) bool {
+	aJSON, err1 := json.Marshal(a)
+	bJSON, err2 := json.Marshal(b)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	return string(aJSON) == string(bJSON)
+}
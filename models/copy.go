@@ -0,0 +1,16 @@
+package models
+
+import (
+	"encoding/json"
+)
+
+// DeepCopy copies src into dst through JSON, so the copy shares no maps, slices or pointers
+// with src. Fields that are not serialized (`json:"-"`, such as ContainerConfig.ContainerID)
+// are reset in the copy.
+func DeepCopy(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
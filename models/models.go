@@ -1,7 +1,11 @@
 package models
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf16"
 )
 
 // ResponseMode constants
@@ -11,6 +15,48 @@ const (
 	ResponseModeScript   = "script"   // JavaScript (goja) for complex logic
 )
 
+// CachePreset constants
+const (
+	CachePresetNone                 = "none"                   // Remove caching headers
+	CachePresetNoStore              = "no-store"               // Never cache
+	CachePresetImmutable            = "immutable"              // Cache forever (fingerprinted assets)
+	CachePresetStaleWhileRevalidate = "stale-while-revalidate" // Serve stale while refreshing in the background
+	CachePresetPrivate              = "private"                // Browser-only cache with max-age
+)
+
+// EncodingPreset constants (response bodies for exercising client parsers)
+const (
+	EncodingPresetUTF8BOM        = "utf8-bom"        // JSON preceded by a UTF-8 byte order mark
+	EncodingPresetUTF16BOM       = "utf16-bom"       // JSON encoded as UTF-16LE with a byte order mark (raw)
+	EncodingPresetMixedEncodings = "mixed-encodings" // UTF-8 text followed by Latin-1 and Windows-1252 bytes, labelled UTF-8 (raw)
+	EncodingPresetLoneSurrogates = "lone-surrogates" // JSON with unpaired \uD800/\uDC00 escapes
+	EncodingPresetRTLOverride    = "rtl-override"    // JSON with right-to-left override and other bidi control characters
+	EncodingPresetEmoji          = "emoji"           // JSON full of ZWJ sequences, skin tones, flags and combining marks
+	EncodingPresetInvalidUTF8    = "invalid-utf8"    // Overlong, truncated, surrogate and stray bytes, labelled UTF-8 (raw)
+)
+
+// RangeMode constants
+const (
+	RangeModeIgnore = "ignore" // Ignore Range headers and always return the full body (default)
+	RangeModeHonor  = "honor"  // Serve 206 partial content, including multipart/byteranges for multiple ranges
+	RangeModeReject = "reject" // Answer every Range request with 416 Range Not Satisfiable
+)
+
+// BodyGenerator kinds
+const (
+	GeneratorZeros     = "zeros"      // size_bytes zero bytes (highly compressible)
+	GeneratorRandom    = "random"     // size_bytes pseudo-random bytes (incompressible)
+	GeneratorRepeat    = "repeat"     // pattern repeated up to size_bytes
+	GeneratorJSONArray = "json_array" // JSON array of count elements
+)
+
+// VersionSource constants
+const (
+	VersionSourcePath   = "path"   // First path segment after the endpoint prefix (e.g., /v2/users)
+	VersionSourceHeader = "header" // Request header (e.g., API-Version: 2)
+	VersionSourceQuery  = "query"  // Query parameter (e.g., ?version=2)
+)
+
 // ValidationMode constants
 const (
 	ValidationModeNone   = "none"   // No validation (default) - always match
@@ -61,6 +107,13 @@ const (
 	EndpointTypeContainer = "container" // Docker container management
 )
 
+// EndpointTypeInfo describes an endpoint type provided by a plugin
+type EndpointTypeInfo struct {
+	Type        string `json:"type"`                  // Value of Endpoint.Type
+	Name        string `json:"name"`                  // Display name (e.g., "S3 bucket")
+	Description string `json:"description,omitempty"` // One-line description
+}
+
 // HeaderManipulation mode constants for proxy endpoints
 const (
 	HeaderModeDrop       = "drop"       // Drop the header
@@ -68,6 +121,14 @@ const (
 	HeaderModeExpression = "expression" // JS expression for dynamic value
 )
 
+// FieldMapping operation constants for JSON body rewriting
+const (
+	FieldMappingRename = "rename" // Rename the field, keeping it where it is
+	FieldMappingRemove = "remove" // Delete the field
+	FieldMappingSet    = "set"    // Set the field to a constant value (creating it if needed)
+	FieldMappingMove   = "move"   // Move the field to another path
+)
+
 // DomainFilterMode constants for endpoint domain filtering
 const (
 	DomainFilterModeAny      = "any"      // Match any domain (no filtering)
@@ -75,6 +136,20 @@ const (
 	DomainFilterModeSpecific = "specific" // Match specific selected domains
 )
 
+// Reserved paths served by the mock server itself (never routed to endpoints)
+const (
+	ReservedPathHealth = "/__health"    // Liveness: server is up, config hash
+	ReservedPathReady  = "/__ready"     // Readiness: all enabled container and plugin endpoints are ready
+	ReservedPathDocs   = "/__docs"      // Browsable HTML docs of the active endpoints and their responses
+	ReservedPathPAC    = "/__proxy.pac" // Proxy auto-config routing the taken-over domains through the proxies
+)
+
+// MarketplaceSourceType constants for endpoint bundle registries
+const (
+	MarketplaceSourceHTTP = "http" // index.json served over HTTP(S)
+	MarketplaceSourceGit  = "git"  // Git repository with index.json at its root
+)
+
 // HeaderValidation defines validation for a single request header
 type HeaderValidation struct {
 	Name       string `json:"name" yaml:"name"`                                 // Header name to validate
@@ -92,6 +167,46 @@ type RequestValidation struct {
 	MatchType string              `json:"match_type,omitempty" yaml:"match_type,omitempty"` // For static: "exact" or "contains"
 	Script    string              `json:"script,omitempty" yaml:"script,omitempty"`         // JavaScript validation script
 	Headers   []HeaderValidation  `json:"headers,omitempty" yaml:"headers,omitempty"`       // Header validations (AND logic with body)
+	Schema    *SchemaValidation   `json:"schema,omitempty" yaml:"schema,omitempty"`         // OpenAPI schema validation (AND logic with body and headers)
+}
+
+// SchemaValidation checks requests against OpenAPI schemas (attached by OpenAPI import)
+type SchemaValidation struct {
+	Enabled      bool              `json:"enabled" yaml:"enabled"`                                 // Whether schema validation runs
+	Body         string            `json:"body,omitempty" yaml:"body,omitempty"`                   // Schema (JSON) for the request body
+	BodyRequired bool              `json:"body_required,omitempty" yaml:"body_required,omitempty"` // Whether a request body must be present
+	Parameters   []ParameterSchema `json:"parameters,omitempty" yaml:"parameters,omitempty"`       // Query, path and header parameter schemas
+	Reject       bool              `json:"reject,omitempty" yaml:"reject,omitempty"`               // Answer violations with 400 and a structured error body instead of trying the next response
+}
+
+// Parameter locations for schema validation
+const (
+	ParameterInQuery  = "query"
+	ParameterInPath   = "path"
+	ParameterInHeader = "header"
+)
+
+// ParameterSchema is the schema of one request parameter
+type ParameterSchema struct {
+	Name     string `json:"name" yaml:"name"`                             // Parameter name
+	In       string `json:"in" yaml:"in"`                                 // "query", "path", or "header"
+	Required bool   `json:"required,omitempty" yaml:"required,omitempty"` // Whether the parameter must be present
+	Schema   string `json:"schema,omitempty" yaml:"schema,omitempty"`     // Schema (JSON) for the parameter value
+}
+
+// SchemaViolation describes one way a request does not match its schema
+type SchemaViolation struct {
+	In      string `json:"in"`             // "body", "query", "path", or "header"
+	Name    string `json:"name,omitempty"` // Parameter name (parameters) or JSON pointer (body), e.g. "/items/0/id"
+	Message string `json:"message"`        // What is wrong
+}
+
+// String formats the violation for logs, e.g. "body /email: string doesn't match the format"
+func (v SchemaViolation) String() string {
+	if v.Name == "" {
+		return v.In + ": " + v.Message
+	}
+	return v.In + " " + v.Name + ": " + v.Message
 }
 
 // MethodResponse represents the configuration for a specific HTTP method's response
@@ -104,11 +219,43 @@ type MethodResponse struct {
 	StatusText    string            `json:"status_text,omitempty" yaml:"status_text,omitempty"`       // Status text description
 	Headers       map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`               // Response headers
 	Body          string            `json:"body,omitempty" yaml:"body,omitempty"`                     // Response body (used for static and template modes)
+	BodyBase64    bool              `json:"body_base64,omitempty" yaml:"body_base64,omitempty"`       // Body is base64 and sent as the decoded raw bytes (static mode)
 	ResponseDelay int               `json:"response_delay,omitempty" yaml:"response_delay,omitempty"` // Delay in milliseconds before sending response
+	DelayExpression    string             `json:"delay_expression,omitempty" yaml:"delay_expression,omitempty"` // JavaScript expression returning the delay in ms (overrides response_delay)
+	BandwidthKBps      int                `json:"bandwidth_kbps,omitempty" yaml:"bandwidth_kbps,omitempty"`     // Caps the body's transfer rate in KB/s to simulate a slow network (overrides the endpoint's, 0 = endpoint's)
+	RangeMode          string             `json:"range_mode,omitempty" yaml:"range_mode,omitempty"`             // Range request handling: "ignore" (default), "honor", or "reject"
+	VariantHeader      string             `json:"variant_header,omitempty" yaml:"variant_header,omitempty"`     // Request header that selects a variant (e.g., Accept-Language); emitted in Vary
+	Variants           []ResponseVariant  `json:"variants,omitempty" yaml:"variants,omitempty"`                 // Per-header-value overrides of status, headers and body
+	Protocols          []string           `json:"protocols,omitempty" yaml:"protocols,omitempty"`               // Only match requests over these protocols: HTTP/1.0, HTTP/1.1, HTTP/2, HTTP/3 (empty = any)
+	ProtocolVariants   []ResponseVariant  `json:"protocol_variants,omitempty" yaml:"protocol_variants,omitempty"` // Per-protocol overrides of status, headers and body (value is the protocol, "*" = fallback)
+	Deprecation        *DeprecationPolicy `json:"deprecation,omitempty" yaml:"deprecation,omitempty"`           // Deprecation/Sunset headers driven by the virtual clock
+	EventStream        string             `json:"event_stream,omitempty" yaml:"event_stream,omitempty"`         // Hold the response open and stream events with this name as Server-Sent Events ("*" = all)
+	Sequence           []SequenceStep     `json:"sequence,omitempty" yaml:"sequence,omitempty"`                 // Sequence mode: successive calls serve successive steps
+	SequenceEnd        string             `json:"sequence_end,omitempty" yaml:"sequence_end,omitempty"`         // After the last step: "stick" (default) or "loop"
+	FaultInjection     *FaultInjection    `json:"fault_injection,omitempty" yaml:"fault_injection,omitempty"`   // Chaos mode: random latency, errors, resets and truncation (overrides the endpoint's)
+	Generator          *BodyGenerator     `json:"generator,omitempty" yaml:"generator,omitempty"`               // Streams a generated (very large) body instead of body
+	HeaderQuirks       *HeaderQuirks      `json:"header_quirks,omitempty" yaml:"header_quirks,omitempty"`       // Exact-case, duplicate, folded and oversized headers for client parser tests
 	ResponseMode       string             `json:"response_mode,omitempty" yaml:"response_mode,omitempty"`       // Response mode: "static", "template", or "script"
 	ScriptBody         string             `json:"script_body,omitempty" yaml:"script_body,omitempty"`           // JavaScript code for script mode
 	RequestValidation  *RequestValidation `json:"request_validation,omitempty" yaml:"request_validation,omitempty"` // Request body validation config
 	UseGlobalCORS      *bool              `json:"use_global_cors,omitempty" yaml:"use_global_cors,omitempty"`   // Whether to use global CORS (nil=use group setting, true=use, false=disable)
+	Draft              bool               `json:"draft,omitempty" yaml:"draft,omitempty"`                       // Unpublished edits: the server keeps serving Published until PublishDrafts
+	Published          *MethodResponse    `json:"published,omitempty" yaml:"published,omitempty"`               // Last published version of a draft (nil = new draft, not served yet)
+	FieldMappings      []FieldMapping     `json:"field_mappings,omitempty" yaml:"field_mappings,omitempty"`     // Declarative JSON field rewrites of the rendered body
+	OpenAPIOperation   string             `json:"openapi_operation,omitempty" yaml:"openapi_operation,omitempty"` // Operation this response was imported from (e.g., "GET /pets/{petId}")
+	Examples           []TrafficExample   `json:"examples,omitempty" yaml:"examples,omitempty"`                 // Real exchanges harvested from traffic (OpenAPI-imported responses), exported as spec examples
+}
+
+// TrafficExample is a real request/response exchange observed for a response imported from OpenAPI
+type TrafficExample struct {
+	CapturedAt          string `json:"captured_at" yaml:"captured_at"` // RFC3339
+	Method              string `json:"method" yaml:"method"`
+	Path                string `json:"path" yaml:"path"` // Request path as sent by the client
+	RequestContentType  string `json:"request_content_type,omitempty" yaml:"request_content_type,omitempty"`
+	RequestBody         string `json:"request_body,omitempty" yaml:"request_body,omitempty"`
+	StatusCode          int    `json:"status_code" yaml:"status_code"`
+	ResponseContentType string `json:"response_content_type,omitempty" yaml:"response_content_type,omitempty"`
+	ResponseBody        string `json:"response_body,omitempty" yaml:"response_body,omitempty"`
 }
 
 // IsEnabled returns whether this response rule is enabled (defaults to true if not set)
@@ -116,14 +263,259 @@ func (r *MethodResponse) IsEnabled() bool {
 	return r.Enabled == nil || *r.Enabled
 }
 
+// Latency distributions for fault injection
+const (
+	LatencyFixed   = "fixed"   // Always latency_ms
+	LatencyUniform = "uniform" // Uniformly between latency_ms and latency_max_ms
+	LatencyNormal  = "normal"  // Normally distributed around latency_ms with latency_stddev_ms (never negative)
+)
+
+// FaultInjection randomly degrades responses to test client resilience. Rates are percentages (0-100)
+// evaluated per request; at most one of reset, error and truncation happens to a request.
+type FaultInjection struct {
+	Enabled         bool    `json:"enabled" yaml:"enabled"`                                         // Whether faults are injected
+	Latency         string  `json:"latency,omitempty" yaml:"latency,omitempty"`                     // Latency distribution: "fixed", "uniform", "normal" (empty = no added latency)
+	LatencyMs       int     `json:"latency_ms,omitempty" yaml:"latency_ms,omitempty"`               // Fixed latency, uniform minimum or normal mean
+	LatencyMaxMs    int     `json:"latency_max_ms,omitempty" yaml:"latency_max_ms,omitempty"`       // Uniform maximum
+	LatencyStdDevMs int     `json:"latency_stddev_ms,omitempty" yaml:"latency_stddev_ms,omitempty"` // Normal standard deviation
+	ErrorRate       float64 `json:"error_rate,omitempty" yaml:"error_rate,omitempty"`               // Percentage of requests answered with error_status
+	ErrorStatus     int     `json:"error_status,omitempty" yaml:"error_status,omitempty"`           // Status of injected errors (default: 503)
+	ErrorBody       string  `json:"error_body,omitempty" yaml:"error_body,omitempty"`               // Body of injected errors (default: status text)
+	ResetRate       float64 `json:"reset_rate,omitempty" yaml:"reset_rate,omitempty"`               // Percentage of connections reset without a response
+	TruncateRate    float64 `json:"truncate_rate,omitempty" yaml:"truncate_rate,omitempty"`         // Percentage of bodies cut off halfway (mock responses only)
+}
+
+// HeaderQuirks emits headers the normal header map cannot express, for testing client header parsing
+type HeaderQuirks struct {
+	Headers     []RawHeader `json:"headers,omitempty" yaml:"headers,omitempty"`           // Sent in order with their exact casing; a repeated name is sent as separate header lines
+	FillerCount int         `json:"filler_count,omitempty" yaml:"filler_count,omitempty"` // Number of extra X-Filler-<n> headers (oversized header counts)
+	FillerSize  int         `json:"filler_size,omitempty" yaml:"filler_size,omitempty"`   // Value length of each filler header in bytes (default: 16)
+	Raw         bool        `json:"raw,omitempty" yaml:"raw,omitempty"`                   // Write the response head by hand (HTTP/1.x only): exact order, and multi-line values become obsolete folded continuation lines
+}
+
+// RawHeader is a single header line
+type RawHeader struct {
+	Name  string `json:"name" yaml:"name"`   // Header name, sent with this exact casing
+	Value string `json:"value" yaml:"value"` // Header value; newlines fold it over continuation lines in raw mode
+}
+
+// BodyGenerator streams a large or highly compressible body without holding it in memory,
+// for testing client size limits and decompression bomb protections.
+type BodyGenerator struct {
+	Kind      string `json:"kind" yaml:"kind"`                                 // "zeros", "random", "repeat", or "json_array"
+	SizeBytes int64  `json:"size_bytes,omitempty" yaml:"size_bytes,omitempty"` // Uncompressed body size (zeros, random, repeat)
+	Pattern   string `json:"pattern,omitempty" yaml:"pattern,omitempty"`       // Repeated text (repeat)
+	Count     int64  `json:"count,omitempty" yaml:"count,omitempty"`           // Number of array elements (json_array)
+	Element   string `json:"element,omitempty" yaml:"element,omitempty"`       // JSON of each element (json_array, default {"id":<index>})
+	Gzip      bool   `json:"gzip,omitempty" yaml:"gzip,omitempty"`             // Compress on the fly and send Content-Encoding: gzip
+}
+
+// DeprecationPolicy stamps Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers on a response.
+// Dates are RFC3339 and compared against the virtual clock, so schedules can be tested ahead of time.
+type DeprecationPolicy struct {
+	AnnounceAt    string `json:"announce_at,omitempty" yaml:"announce_at,omitempty"`       // Headers are only sent from this time on (empty = always)
+	DeprecatedAt  string `json:"deprecated_at,omitempty" yaml:"deprecated_at,omitempty"`   // Deprecation date (may be in the future)
+	SunsetAt      string `json:"sunset_at,omitempty" yaml:"sunset_at,omitempty"`           // Date the resource stops responding
+	Link          string `json:"link,omitempty" yaml:"link,omitempty"`                     // Documentation URL sent as Link rel="deprecation"
+	SunsetLink    string `json:"sunset_link,omitempty" yaml:"sunset_link,omitempty"`       // Sunset policy URL sent as Link rel="sunset"
+	EnforceSunset bool   `json:"enforce_sunset,omitempty" yaml:"enforce_sunset,omitempty"` // Answer 410 Gone once the sunset date has passed
+}
+
+// ResponseVariant overrides a response when the variant header matches its value.
+// Accept-* headers are negotiated by quality value (wildcards and language prefixes match);
+// other headers match case-insensitively. Value "*" is the fallback variant.
+type ResponseVariant struct {
+	Value      string            `json:"value" yaml:"value"`                                 // Header value this variant serves (e.g., "fr", "application/xml", "*")
+	StatusCode int               `json:"status_code,omitempty" yaml:"status_code,omitempty"` // Overrides the response status (0 = inherit)
+	Headers    map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`         // Added to / overriding the response headers
+	Body       string            `json:"body,omitempty" yaml:"body,omitempty"`               // Overrides the response body (empty = inherit)
+}
+
+// Sequence end behaviours
+const (
+	SequenceEndStick = "stick" // Keep serving the last step (default)
+	SequenceEndLoop  = "loop"  // Start again from the first step
+)
+
+// SequenceStep is one call's worth of a sequence response; unset fields inherit from the response
+type SequenceStep struct {
+	StatusCode    int               `json:"status_code,omitempty" yaml:"status_code,omitempty"`       // Overrides the response status (0 = inherit)
+	Headers       map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`               // Added to / overriding the response headers
+	Body          string            `json:"body,omitempty" yaml:"body,omitempty"`                     // Overrides the response body (empty = inherit)
+	ResponseDelay int               `json:"response_delay,omitempty" yaml:"response_delay,omitempty"` // Overrides the response delay (0 = inherit)
+}
+
 // ResponseGroup represents a named group of response rules
 type ResponseGroup struct {
-	ID            string           `json:"id,omitempty" yaml:"id,omitempty"`                               // Unique identifier for this group
-	Name          string           `json:"name" yaml:"name"`                                               // Display name for the group
-	Expanded      *bool            `json:"expanded,omitempty" yaml:"expanded,omitempty"`                   // Whether group is expanded in UI (default: true)
-	Enabled       *bool            `json:"enabled,omitempty" yaml:"enabled,omitempty"`                     // Whether all responses in group are enabled (default: true)
-	UseGlobalCORS *bool            `json:"use_global_cors,omitempty" yaml:"use_global_cors,omitempty"`     // Whether to use global CORS (nil=enabled, true=use, false=disable)
-	Responses     []MethodResponse `json:"responses,omitempty" yaml:"responses,omitempty"`                 // Responses within this group
+	ID            string             `json:"id,omitempty" yaml:"id,omitempty"`                           // Unique identifier for this group
+	Name          string             `json:"name" yaml:"name"`                                           // Display name for the group
+	Expanded      *bool              `json:"expanded,omitempty" yaml:"expanded,omitempty"`               // Whether group is expanded in UI (default: true)
+	Enabled       *bool              `json:"enabled,omitempty" yaml:"enabled,omitempty"`                 // Whether all responses in group are enabled (default: true)
+	UseGlobalCORS *bool              `json:"use_global_cors,omitempty" yaml:"use_global_cors,omitempty"` // Whether to use global CORS (nil=enabled, true=use, false=disable)
+	Defaults      *ResponseDefaults  `json:"defaults,omitempty" yaml:"defaults,omitempty"`               // Defaults inherited by responses in this group (override endpoint defaults)
+	Deprecation   *DeprecationPolicy `json:"deprecation,omitempty" yaml:"deprecation,omitempty"`         // Deprecation policy for responses without their own
+	Responses     []MethodResponse   `json:"responses,omitempty" yaml:"responses,omitempty"`             // Responses within this group
+}
+
+// ResponseDefaults are values inherited by responses that do not set them themselves
+type ResponseDefaults struct {
+	StatusCode    int               `json:"status_code,omitempty" yaml:"status_code,omitempty"`       // Used when a response has no status code
+	Headers       map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`               // Added unless the response sets the same header
+	ResponseDelay int               `json:"response_delay,omitempty" yaml:"response_delay,omitempty"` // Used when a response has no delay
+}
+
+// ApplyResponseDefaults returns a copy of resp with inherited defaults filled in.
+// Layers are ordered most specific first (e.g., group, then endpoint); nil layers are skipped.
+func ApplyResponseDefaults(resp MethodResponse, layers ...*ResponseDefaults) MethodResponse {
+	headers := make(map[string]string, len(resp.Headers))
+	present := make(map[string]bool, len(resp.Headers))
+	for name, value := range resp.Headers {
+		headers[name] = value
+		present[strings.ToLower(name)] = true
+	}
+
+	for _, layer := range layers {
+		if layer == nil {
+			continue
+		}
+		if resp.StatusCode == 0 && layer.StatusCode != 0 {
+			resp.StatusCode = layer.StatusCode
+		}
+		if resp.ResponseDelay == 0 && layer.ResponseDelay != 0 {
+			resp.ResponseDelay = layer.ResponseDelay
+		}
+		for name, value := range layer.Headers {
+			if !present[strings.ToLower(name)] {
+				headers[name] = value
+				present[strings.ToLower(name)] = true
+			}
+		}
+	}
+
+	resp.Headers = headers
+	return resp
+}
+
+// cacheHeaderNames are the headers replaced when a cache preset is applied
+var cacheHeaderNames = []string{"Cache-Control", "Pragma", "Expires"}
+
+// CachePresetHeaders returns the caching headers for a preset.
+// maxAge (seconds) overrides the preset's default max-age; it is ignored by "none" and "no-store".
+func CachePresetHeaders(preset string, maxAge int) (map[string]string, error) {
+	withDefault := func(def int) int {
+		if maxAge > 0 {
+			return maxAge
+		}
+		return def
+	}
+
+	switch preset {
+	case CachePresetNone:
+		return map[string]string{}, nil
+	case CachePresetNoStore:
+		return map[string]string{
+			"Cache-Control": "no-store, no-cache, must-revalidate",
+			"Pragma":        "no-cache",
+			"Expires":       "0",
+		}, nil
+	case CachePresetImmutable:
+		return map[string]string{
+			"Cache-Control": fmt.Sprintf("public, max-age=%d, immutable", withDefault(31536000)),
+		}, nil
+	case CachePresetStaleWhileRevalidate:
+		age := withDefault(60)
+		return map[string]string{
+			"Cache-Control": fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", age, age*5),
+		}, nil
+	case CachePresetPrivate:
+		return map[string]string{
+			"Cache-Control": fmt.Sprintf("private, max-age=%d", withDefault(300)),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache preset: %s", preset)
+	}
+}
+
+// ApplyCacheHeaders replaces the caching headers of a response with the given set
+func ApplyCacheHeaders(resp *MethodResponse, cacheHeaders map[string]string) {
+	headers := make(map[string]string, len(resp.Headers)+len(cacheHeaders))
+	for name, value := range resp.Headers {
+		managed := false
+		for _, cacheName := range cacheHeaderNames {
+			if strings.EqualFold(name, cacheName) {
+				managed = true
+				break
+			}
+		}
+		if !managed {
+			headers[name] = value
+		}
+	}
+	for name, value := range cacheHeaders {
+		headers[name] = value
+	}
+	resp.Headers = headers
+}
+
+// EncodingPresetBody returns a preset's body, whether it is raw bytes (to be stored base64), and its Content-Type
+func EncodingPresetBody(preset string) (body string, raw bool, contentType string, err error) {
+	switch preset {
+	case EncodingPresetUTF8BOM:
+		return "\uFEFF{\"name\":\"Zo\u00eb\",\"city\":\"M\u00fcnchen\"}", false, "application/json; charset=utf-8", nil
+	case EncodingPresetUTF16BOM:
+		text := `{"name":"Zoë","city":"München","emoji":"😀"}`
+		encoded := []byte{0xFF, 0xFE}
+		for _, unit := range utf16.Encode([]rune(text)) {
+			encoded = append(encoded, byte(unit), byte(unit>>8))
+		}
+		return string(encoded), true, "application/json; charset=utf-16", nil
+	case EncodingPresetMixedEncodings:
+		return "UTF-8: caf\u00e9 na\u00efve\n" +
+			"Latin-1: caf\xe9 na\xefve\n" +
+			"Windows-1252: \x93smart quotes\x94 \x96 \x80 5\n", true, "text/plain; charset=utf-8", nil
+	case EncodingPresetLoneSurrogates:
+		return `{"high":"\ud800","low":"\udc00","reversed":"\udc00\ud800","embedded":"a\ud83db","valid":"\ud83d\ude00"}`, false, "application/json; charset=utf-8", nil
+	case EncodingPresetRTLOverride:
+		return "{\"file\":\"invoice\u202egpj.exe\",\"user\":\"admin\u202e\u2066 \u2069\",\"mixed\":\"abc \u05e9\u05dc\u05d5\u05dd 123 \u0645\u0631\u062d\u0628\u0627\",\"isolates\":\"\u2067RTL\u2069 \u2068auto\u2069 \u200fmark\u200e\"}", false, "application/json; charset=utf-8", nil
+	case EncodingPresetEmoji:
+		return "{\"family\":\"👨\u200d👩\u200d👧\u200d👦\",\"skin\":\"👍🏻👍🏼👍🏽👍🏾👍🏿\",\"flags\":\"🇺🇸🇯🇵🏳\ufe0f\u200d🌈🏴\U000e0067\U000e0062\U000e0073\U000e0063\U000e0074\U000e007f\"," +
+			"\"keycap\":\"1\ufe0f\u20e3#\ufe0f\u20e3\",\"zalgo\":\"Z\u0351\u036b\u0343a\u0310\u0352l\u0346\u0344g\u0310\u0313o\u0346\u0315\",\"text\":\"🚀 Deploy 🔥 done ✅ 💯\"}", false, "application/json; charset=utf-8", nil
+	case EncodingPresetInvalidUTF8:
+		return "overlong slash: \xc0\xaf\n" +
+			"overlong NUL: \xc0\x80\n" +
+			"truncated euro: \xe2\x82\n" +
+			"stray continuation: \x80\xbf\n" +
+			"encoded surrogate: \xed\xa0\x80\n" +
+			"beyond U+10FFFF: \xf4\x90\x80\x80\n" +
+			"invalid bytes: \xfe\xff\n", true, "text/plain; charset=utf-8", nil
+	default:
+		return "", false, "", fmt.Errorf("unknown encoding preset: %s", preset)
+	}
+}
+
+// ApplyEncodingPreset replaces a response's body with an encoding preset and serves it statically
+func ApplyEncodingPreset(resp *MethodResponse, preset string) error {
+	body, raw, contentType, err := EncodingPresetBody(preset)
+	if err != nil {
+		return err
+	}
+	resp.Body = body
+	resp.BodyBase64 = raw
+	if raw {
+		resp.Body = base64.StdEncoding.EncodeToString([]byte(body))
+	}
+	resp.ResponseMode = ResponseModeStatic
+	resp.Generator = nil
+
+	headers := make(map[string]string, len(resp.Headers)+1)
+	for name, value := range resp.Headers {
+		if !strings.EqualFold(name, "Content-Type") {
+			headers[name] = value
+		}
+	}
+	headers["Content-Type"] = contentType
+	resp.Headers = headers
+	return nil
 }
 
 // IsExpanded returns whether this group is expanded (defaults to true if not set)
@@ -152,6 +544,21 @@ type HeaderManipulation struct {
 	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"` // For expression mode (JS)
 }
 
+// FieldMapping is one declarative rewrite of a JSON body. Paths are dot-separated field names;
+// a numeric segment indexes an array and "*" applies the rest of the path to every element.
+type FieldMapping struct {
+	Op    string      `json:"op" yaml:"op"`                           // "rename", "remove", "set" or "move"
+	Path  string      `json:"path" yaml:"path"`                       // Field to change, e.g. "data.user_name" or "items.*.id"
+	To    string      `json:"to,omitempty" yaml:"to,omitempty"`       // rename: new field name; move: destination path
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"` // set: the value to set
+}
+
+// URLRewriteRule replaces a URL prefix in proxied response bodies
+type URLRewriteRule struct {
+	From string `json:"from" yaml:"from"` // URL prefix to replace, e.g. "https://cdn.internal.example.com"
+	To   string `json:"to" yaml:"to"`     // Replacement; "{origin}" is the scheme and host the client used
+}
+
 // StatusTranslation defines status code mapping (for proxy endpoints)
 type StatusTranslation struct {
 	FromPattern string `json:"from_pattern" yaml:"from_pattern"` // e.g., "5xx", "404", "2xx"
@@ -161,8 +568,22 @@ type StatusTranslation struct {
 // ProxyConfig contains reverse proxy configuration
 type ProxyConfig struct {
 	BackendURL       string                `json:"backend_url" yaml:"backend_url"`
+	Environments     map[string]string     `json:"environments,omitempty" yaml:"environments,omitempty"` // Backend URL per environment name (e.g., dev, stage, prod); the active environment picks one
 	TimeoutSeconds   int                   `json:"timeout_seconds" yaml:"timeout_seconds"` // Default: 30
 
+	// Load balancing across a pool of backends, used instead of backend_url when set
+	Backends        []ProxyBackend `json:"backends,omitempty" yaml:"backends,omitempty"`
+	BalanceStrategy string         `json:"balance_strategy,omitempty" yaml:"balance_strategy,omitempty"` // "round_robin" (default), "weighted" or "random"
+
+	// Connection pool to the backend (kept per endpoint and reused across requests)
+	Transport *ProxyTransportConfig `json:"transport,omitempty" yaml:"transport,omitempty"`
+
+	// TLS to HTTPS backends (custom CA, client certificate, verification)
+	TLS *ProxyTLSConfig `json:"tls,omitempty" yaml:"tls,omitempty"`
+
+	// Circuit breaker: after repeated backend failures, answer with a fallback for a while
+	CircuitBreaker *CircuitBreakerConfig `json:"circuit_breaker,omitempty" yaml:"circuit_breaker,omitempty"`
+
 	// Path translation uses endpoint's TranslationMode, TranslatePattern, TranslateReplace
 
 	// Header manipulation
@@ -174,7 +595,20 @@ type ProxyConfig struct {
 	StatusTranslation []StatusTranslation `json:"status_translation,omitempty" yaml:"status_translation,omitempty"`
 
 	// Body transformation
-	BodyTransform string `json:"body_transform,omitempty" yaml:"body_transform,omitempty"` // JS script
+	FieldMappings []FieldMapping `json:"field_mappings,omitempty" yaml:"field_mappings,omitempty"` // Declarative JSON field rewrites, applied before body_transform
+	BodyTransform string         `json:"body_transform,omitempty" yaml:"body_transform,omitempty"` // JS script
+
+	// URL rewriting in HTML, JSON, CSS, JavaScript and XML response bodies, so links to the backend
+	// lead back through the mock server (like the Location rewrite of redirects)
+	RewriteBodyURLs bool             `json:"rewrite_body_urls,omitempty" yaml:"rewrite_body_urls,omitempty"` // Rewrite absolute backend URLs to the origin the client used
+	BodyURLRewrites []URLRewriteRule `json:"body_url_rewrites,omitempty" yaml:"body_url_rewrites,omitempty"` // Further URL prefixes to rewrite, e.g. the backend's public hostname
+
+	// Response assertions (JS script run against each backend response; result is recorded on the log entry)
+	AssertionScript string `json:"assertion_script,omitempty" yaml:"assertion_script,omitempty"`
+
+	// Streaming: backend bodies are copied to the client as they arrive unless field_mappings,
+	// body_transform or assertion_script need the whole body; the log keeps the first LogBodyLimit bytes
+	LogBodyLimit int `json:"log_body_limit,omitempty" yaml:"log_body_limit,omitempty"` // Bytes of a streamed body kept in the request log (default: 1 MB)
 
 	// Health check
 	HealthCheckEnabled  bool   `json:"health_check_enabled" yaml:"health_check_enabled"`
@@ -182,6 +616,86 @@ type ProxyConfig struct {
 	HealthCheckPath     string `json:"health_check_path,omitempty" yaml:"health_check_path,omitempty"` // Default: "/"
 }
 
+// CircuitBreakerConfig opens a proxy endpoint's circuit after consecutive backend failures. While
+// open, requests get the fallback response without reaching the backend; once open_seconds have
+// passed, probe requests go through (half-open) and close the circuit again if they succeed.
+type CircuitBreakerConfig struct {
+	Enabled          bool              `json:"enabled" yaml:"enabled"`
+	FailureThreshold int               `json:"failure_threshold,omitempty" yaml:"failure_threshold,omitempty"` // Consecutive failures that open the circuit (default: 5)
+	FailureStatuses  []string          `json:"failure_statuses,omitempty" yaml:"failure_statuses,omitempty"`   // Backend statuses counted as failures, e.g. "5xx", "429" (default: 5xx); unreachable backends always count
+	OpenSeconds      int               `json:"open_seconds,omitempty" yaml:"open_seconds,omitempty"`           // Time the circuit stays open before a probe (default: 30)
+	HalfOpenProbes   int               `json:"half_open_probes,omitempty" yaml:"half_open_probes,omitempty"`   // Successful probes in a row that close the circuit (default: 1)
+	FallbackStatus   int               `json:"fallback_status,omitempty" yaml:"fallback_status,omitempty"`     // Status of the fallback response (default: 503)
+	FallbackHeaders  map[string]string `json:"fallback_headers,omitempty" yaml:"fallback_headers,omitempty"`
+	FallbackBody     string            `json:"fallback_body,omitempty" yaml:"fallback_body,omitempty"`
+}
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"    // Requests reach the backend
+	CircuitOpen     = "open"      // Requests get the fallback response
+	CircuitHalfOpen = "half_open" // One probe request at a time reaches the backend
+)
+
+// ProxyBackend is one member of a proxy endpoint's backend pool
+type ProxyBackend struct {
+	URL    string `json:"url" yaml:"url"`
+	Weight int    `json:"weight,omitempty" yaml:"weight,omitempty"` // Share of requests under the weighted strategy (default: 1)
+}
+
+// Strategies for spreading requests over a proxy's backend pool
+const (
+	ProxyBalanceRoundRobin = "round_robin" // Each healthy backend in turn
+	ProxyBalanceWeighted   = "weighted"    // In turn, in proportion to the backends' weights
+	ProxyBalanceRandom     = "random"      // A healthy backend picked at random
+)
+
+// HTTP/2 modes for connections to a proxy backend
+const (
+	ProxyHTTP2Auto = "auto" // Negotiate HTTP/2 with HTTPS backends (ALPN), HTTP/1.1 otherwise
+	ProxyHTTP2Off  = "off"  // HTTP/1.1 only
+	ProxyHTTP2H2C  = "h2c"  // Cleartext HTTP/2 with prior knowledge to http:// backends
+)
+
+// ProxyTransportConfig tunes the connections a proxy or container endpoint keeps to its backend
+type ProxyTransportConfig struct {
+	MaxIdleConns               int    `json:"max_idle_conns,omitempty" yaml:"max_idle_conns,omitempty"`                               // Idle keep-alive connections kept per backend host (default: 100)
+	MaxConnsPerHost            int    `json:"max_conns_per_host,omitempty" yaml:"max_conns_per_host,omitempty"`                       // Connections per backend host, requests beyond wait (default: unlimited)
+	IdleConnTimeoutSeconds     int    `json:"idle_conn_timeout_seconds,omitempty" yaml:"idle_conn_timeout_seconds,omitempty"`         // Close connections idle this long (default: 90)
+	DisableKeepAlives          bool   `json:"disable_keep_alives,omitempty" yaml:"disable_keep_alives,omitempty"`                     // Open a new connection for every request
+	HTTP2                      string `json:"http2,omitempty" yaml:"http2,omitempty"`                                                 // "auto" (default), "off", or "h2c"
+	TLSMinVersion              string `json:"tls_min_version,omitempty" yaml:"tls_min_version,omitempty"`                             // Lowest TLS version offered to HTTPS backends: "1.0" to "1.3" (default: 1.2)
+	TLSHandshakeTimeoutSeconds int    `json:"tls_handshake_timeout_seconds,omitempty" yaml:"tls_handshake_timeout_seconds,omitempty"` // Default: 10
+}
+
+// ProxyTLSConfig sets how a proxy or container endpoint verifies and authenticates to HTTPS backends
+type ProxyTLSConfig struct {
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty" yaml:"insecure_skip_verify,omitempty"` // Accept any backend certificate (for testing only)
+	CACertPath         string `json:"ca_cert_path,omitempty" yaml:"ca_cert_path,omitempty"`                 // PEM CA bundle trusted in addition to the system roots (private PKI)
+	ClientCertPath     string `json:"client_cert_path,omitempty" yaml:"client_cert_path,omitempty"`         // PEM client certificate presented to the backend (mTLS)
+	ClientKeyPath      string `json:"client_key_path,omitempty" yaml:"client_key_path,omitempty"`           // PEM private key of client_cert_path
+	ServerName         string `json:"server_name,omitempty" yaml:"server_name,omitempty"`                   // Name expected in the backend certificate (default: the backend host)
+}
+
+// ResolveBackendURL returns the backend URL for an environment, or backend_url if the proxy
+// defines none for it (or no environment is active). A proxy with only a backend pool resolves
+// to its first backend.
+func (c *ProxyConfig) ResolveBackendURL(environment string) string {
+	if backendURL := c.Environments[environment]; environment != "" && backendURL != "" {
+		return backendURL
+	}
+	if c.BackendURL == "" && len(c.Backends) > 0 {
+		return c.Backends[0].URL
+	}
+	return c.BackendURL
+}
+
+// UsesBackendPool reports whether requests are spread over the backend pool: the proxy has one,
+// and the environment does not name a backend of its own
+func (c *ProxyConfig) UsesBackendPool(environment string) bool {
+	return len(c.Backends) > 0 && (environment == "" || c.Environments[environment] == "")
+}
+
 // DefaultContainerInboundHeaders returns the default inbound header manipulation rules for container endpoints.
 // These rules ensure proper proxying to containers by:
 // - Dropping hop-by-hop headers that should not be forwarded
@@ -246,7 +760,7 @@ type ContainerConfig struct {
 	ContainerPort int      `json:"container_port" yaml:"container_port"`
 	ExposedPorts  []string `json:"exposed_ports,omitempty" yaml:"exposed_ports,omitempty"` // Ports detected from image inspection (e.g., ["80/tcp", "443/tcp"])
 	PullOnStartup bool     `json:"pull_on_startup" yaml:"pull_on_startup"`                 // Default: true
-	RestartPolicy string   `json:"restart_policy,omitempty" yaml:"restart_policy,omitempty"` // "no", "always", "unless-stopped", "on-failure"
+	RestartPolicy string   `json:"restart_policy,omitempty" yaml:"restart_policy,omitempty"` // "no", "always", "unless-stopped", "on-failure" or "on-failure:<max-retries>"
 
 	// Port mapping (Mockelot forwards to container on this port)
 	// The endpoint's PathPrefix determines routing, container receives on ContainerPort
@@ -264,10 +778,97 @@ type ContainerConfig struct {
 	// Startup behavior
 	RestartOnServerStart bool `json:"restart_on_server_start,omitempty" yaml:"restart_on_server_start,omitempty"` // Restart container if already running when server starts
 
+	// Readiness check startup waits for before the endpoint is marked ready; requests get 503 until
+	// then (nil: ready as soon as the container runs)
+	Readiness *ContainerReadiness `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+
+	// Resource limits of the main container (nil is unlimited)
+	Resources *ContainerResources `json:"resources,omitempty" yaml:"resources,omitempty"`
+
+	// Docker/Podman host the container runs on: the name of one of the configured container hosts,
+	// "local" for this machine, or empty for the default container host
+	ContainerHost string `json:"container_host,omitempty" yaml:"container_host,omitempty"`
+
+	// Login for pulling ImageName from a private registry; without one the global registry
+	// credentials and the docker login of this machine (~/.docker/config.json) are used
+	RegistryAuth *RegistryCredential `json:"registry_auth,omitempty" yaml:"registry_auth,omitempty"`
+
+	// Build the image from a local Dockerfile instead of pulling ImageName, which names the built
+	// image when set (default: the container name)
+	Build *ContainerBuild `json:"build,omitempty" yaml:"build,omitempty"`
+
+	// Multi-container group: sidecars (database, cache, ...) start first, in depends_on order, on a
+	// network shared with the main container, where every container is reachable by its name
+	Sidecars     []SidecarContainer `json:"sidecars,omitempty" yaml:"sidecars,omitempty"`
+	NetworkAlias string             `json:"network_alias,omitempty" yaml:"network_alias,omitempty"` // Host name of the main container on the group network (default: "app")
+
 	// Runtime state (not persisted)
 	ContainerID string `json:"-" yaml:"-"` // Set when container is running
 }
 
+// ContainerResources caps the CPU, memory and processes of a container (zero values are unlimited)
+type ContainerResources struct {
+	CPUShares int64   `json:"cpu_shares,omitempty" yaml:"cpu_shares,omitempty"` // Relative CPU weight (Docker default 1024)
+	CPUs      float64 `json:"cpus,omitempty" yaml:"cpus,omitempty"`             // CPU quota in cores (e.g., 0.5)
+	MemoryMB  int64   `json:"memory_mb,omitempty" yaml:"memory_mb,omitempty"`   // Hard memory limit; the container is killed when it uses more
+	PidsLimit int64   `json:"pids_limit,omitempty" yaml:"pids_limit,omitempty"` // Maximum number of processes
+}
+
+// ContainerReadiness tells when a running container can serve requests, for images that need
+// time after starting (databases, JVM applications, ...). A container that is not ready within
+// the timeout fails to start.
+type ContainerReadiness struct {
+	Strategy       string `json:"strategy" yaml:"strategy"`                                   // "log", "port", "healthcheck" or "delay"
+	LogPattern     string `json:"log_pattern,omitempty" yaml:"log_pattern,omitempty"`         // log: regular expression a line of the container output must match
+	Port           int    `json:"port,omitempty" yaml:"port,omitempty"`                       // port: container port that must accept connections (default: container_port)
+	DelaySeconds   int    `json:"delay_seconds,omitempty" yaml:"delay_seconds,omitempty"`     // delay: time to wait after the container started
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"` // Startup fails when the container is not ready by then (default: 60)
+}
+
+// ContainerHost is a Docker/Podman daemon container endpoints can run on, typically a remote
+// machine with more CPU and memory than the one Mockelot runs on
+type ContainerHost struct {
+	Name        string `json:"name" yaml:"name"`                                     // Referenced by ContainerConfig.ContainerHost
+	Host        string `json:"host" yaml:"host"`                                     // Daemon address: "tcp://build-box:2376", "ssh://user@build-box", "unix:///run/podman/podman.sock"
+	Runtime     string `json:"runtime,omitempty" yaml:"runtime,omitempty"`           // "docker" (default) or "podman"
+	TLSCACert   string `json:"tls_ca_cert,omitempty" yaml:"tls_ca_cert,omitempty"`   // tcp:// with TLS: CA certificate file
+	TLSCert     string `json:"tls_cert,omitempty" yaml:"tls_cert,omitempty"`         // tcp:// with TLS: client certificate file
+	TLSKey      string `json:"tls_key,omitempty" yaml:"tls_key,omitempty"`           // tcp:// with TLS: client key file
+	PublishHost string `json:"publish_host,omitempty" yaml:"publish_host,omitempty"` // Address published container ports are reached on (default: the host name of Host)
+}
+
+// RegistryCredential is a login for a private container registry (ECR, GCR, Harbor, ...)
+type RegistryCredential struct {
+	Registry string `json:"registry,omitempty" yaml:"registry,omitempty"` // Registry host (e.g., "ghcr.io"); optional on an endpoint, where it defaults to the image's registry
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"` // Password or access token
+}
+
+// ContainerBuild builds a container endpoint's image from a Dockerfile before the container starts
+type ContainerBuild struct {
+	ContextPath string           `json:"context_path" yaml:"context_path"`                 // Build context directory (e.g., the service's source tree)
+	Dockerfile  string           `json:"dockerfile,omitempty" yaml:"dockerfile,omitempty"` // Relative to the context (default: "Dockerfile")
+	Args        []EnvironmentVar `json:"args,omitempty" yaml:"args,omitempty"`             // Build arguments; values may be JS expressions
+	Target      string           `json:"target,omitempty" yaml:"target,omitempty"`         // Multi-stage build target (default: last stage)
+	NoCache     bool             `json:"no_cache,omitempty" yaml:"no_cache,omitempty"`
+}
+
+// SidecarContainer is an extra container started with a container endpoint. It is not routed to;
+// the main container reaches it by Name on the group network.
+type SidecarContainer struct {
+	Name          string           `json:"name" yaml:"name"` // Host name on the group network
+	ImageName     string           `json:"image_name" yaml:"image_name"`
+	Command       []string         `json:"command,omitempty" yaml:"command,omitempty"` // Overrides the image command
+	Environment   []EnvironmentVar `json:"environment,omitempty" yaml:"environment,omitempty"`
+	Volumes       []VolumeMapping  `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+	DependsOn     []string         `json:"depends_on,omitempty" yaml:"depends_on,omitempty"` // Sidecars started before this one
+	PullOnStartup bool             `json:"pull_on_startup,omitempty" yaml:"pull_on_startup,omitempty"`
+
+	// Readiness check containers depending on this one wait for ("port" is not supported, sidecar
+	// ports are not published)
+	Readiness *ContainerReadiness `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+}
+
 // HealthStatus represents health check state
 type HealthStatus struct {
 	EndpointID   string `json:"endpoint_id"`
@@ -289,7 +890,7 @@ type ContainerStatus struct {
 // ContainerStartProgress represents a startup progress event
 type ContainerStartProgress struct {
 	EndpointID string `json:"endpoint_id"`
-	Stage      string `json:"stage"`    // "pulling", "creating", "starting", "ready", "error"
+	Stage      string `json:"stage"`    // "pulling" or "building", "creating", "starting", "ready", "error"
 	Message    string `json:"message"`
 	Progress   int    `json:"progress"` // 0-100 percentage
 }
@@ -309,6 +910,58 @@ type ContainerStats struct {
 	LastCheck       string  `json:"last_check"`        // ISO8601/RFC3339 formatted timestamp
 }
 
+// Listener is a named extra port that serves only the endpoints assigned to it, so one instance
+// can expose differently secured APIs side by side (e.g., a partner API over mTLS and a plain
+// internal API)
+type Listener struct {
+	Name         string `json:"name" yaml:"name"`
+	Port         int    `json:"port" yaml:"port"`
+	TLS          bool   `json:"tls,omitempty" yaml:"tls,omitempty"`                       // Serve HTTPS instead of plain HTTP
+	CertPath     string `json:"cert_path,omitempty" yaml:"cert_path,omitempty"`           // PEM server certificate (default: the HTTPS server certificate)
+	KeyPath      string `json:"key_path,omitempty" yaml:"key_path,omitempty"`             // PEM private key of cert_path
+	ClientCAPath string `json:"client_ca_path,omitempty" yaml:"client_ca_path,omitempty"` // Require client certificates issued by this PEM CA (mTLS)
+}
+
+// ClientThrottleRule shapes the traffic of individual clients: every client IP the rule matches gets
+// its own token bucket of max_rps requests per second (burst at once). Requests over the limit wait
+// penalty_delay_ms and are then refused, without affecting other clients.
+type ClientThrottleRule struct {
+	ID             string  `json:"id" yaml:"id"`
+	Name           string  `json:"name,omitempty" yaml:"name,omitempty"`
+	Enabled        bool    `json:"enabled" yaml:"enabled"`
+	Source         string  `json:"source,omitempty" yaml:"source,omitempty"`                     // Client IP or CIDR (empty or "*" = every client)
+	EndpointID     string  `json:"endpoint_id,omitempty" yaml:"endpoint_id,omitempty"`           // Only requests to this endpoint (empty = all endpoints)
+	MaxRPS         float64 `json:"max_rps" yaml:"max_rps"`                                       // Sustained requests per second per client
+	Burst          int     `json:"burst,omitempty" yaml:"burst,omitempty"`                       // Requests a client can make at once (default: max_rps rounded up)
+	PenaltyDelayMs int     `json:"penalty_delay_ms,omitempty" yaml:"penalty_delay_ms,omitempty"` // Wait before answering a throttled request
+	Status         int     `json:"status,omitempty" yaml:"status,omitempty"`                     // Status of throttled requests (default 429)
+}
+
+// CircuitBreakerStatus is the live state of a proxy endpoint's circuit breaker
+type CircuitBreakerStatus struct {
+	EndpointID          string `json:"endpoint_id"`
+	EndpointName        string `json:"endpoint_name,omitempty"`
+	State               string `json:"state"`                  // "closed", "open" or "half_open"
+	ConsecutiveFailures int    `json:"consecutive_failures"`   // Failures since the last success
+	OpenedAt            string `json:"opened_at,omitempty"`    // RFC3339 time the circuit last opened
+	RetryAt             string `json:"retry_at,omitempty"`     // RFC3339 time an open circuit lets a probe through
+	TimesOpened         int64  `json:"times_opened"`           // How often the circuit has opened
+	ShortCircuited      int64  `json:"short_circuited"`        // Requests answered with the fallback
+	LastFailure         string `json:"last_failure,omitempty"` // Reason of the latest failure
+}
+
+// ClientThrottleStats counts the requests of one client under one throttle rule
+type ClientThrottleStats struct {
+	RuleID        string  `json:"rule_id"`
+	RuleName      string  `json:"rule_name,omitempty"`
+	ClientIP      string  `json:"client_ip"`
+	Allowed       int64   `json:"allowed"`                  // Requests let through
+	Throttled     int64   `json:"throttled"`                // Requests refused
+	Tokens        float64 `json:"tokens"`                   // Requests the client can make right now
+	LastSeen      string  `json:"last_seen,omitempty"`      // RFC3339 time of the client's latest request
+	LastThrottled string  `json:"last_throttled,omitempty"` // RFC3339 time of the latest refused request
+}
+
 // Endpoint represents a top-level container for response rules with path prefix and translation
 type Endpoint struct {
 	ID               string         `json:"id" yaml:"id"`                                                   // Unique identifier
@@ -320,15 +973,36 @@ type Endpoint struct {
 	Enabled          *bool          `json:"enabled,omitempty" yaml:"enabled,omitempty"`                     // Whether endpoint is enabled (default: true)
 	IsSystem         bool           `json:"is_system,omitempty" yaml:"is_system,omitempty"`                 // System endpoint (cannot be deleted)
 	DisplayOrder     int            `json:"display_order,omitempty" yaml:"display_order,omitempty"`         // Order for request matching (lower = higher priority)
+	ListenPort       int            `json:"listen_port,omitempty" yaml:"listen_port,omitempty"`             // Dedicated HTTP port (0 = shared server port)
+	Listener         string         `json:"listener,omitempty" yaml:"listener,omitempty"`                   // Named listener serving this endpoint (empty = shared server ports)
+
+	// Defaults inherited by all responses of a mock endpoint
+	Defaults *ResponseDefaults `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+
+	// Chaos mode for every request to the endpoint (responses can override it)
+	FaultInjection *FaultInjection `json:"fault_injection,omitempty" yaml:"fault_injection,omitempty"`
+
+	// Transfer rate cap in KB/s for every response body of the endpoint (0 = unlimited; mock responses can override it)
+	BandwidthKBps int `json:"bandwidth_kbps,omitempty" yaml:"bandwidth_kbps,omitempty"`
+
+	// Authentication simulation: requests without valid credentials are refused with 401/403
+	Auth *EndpointAuth `json:"auth,omitempty" yaml:"auth,omitempty"`
+
+	// API version routing (mock endpoints): versions map to their own item sets
+	Versioning *VersionRouting `json:"versioning,omitempty" yaml:"versioning,omitempty"`
 
 	// Domain filtering (for SOCKS5 proxy)
 	DomainFilter *DomainFilter `json:"domain_filter,omitempty" yaml:"domain_filter,omitempty"` // Domain filter for SOCKS5 intercepted domains
 
+	// Recorded snapshot (mock endpoints): ID of the proxy/container endpoint this mock replaces in offline mode
+	SnapshotOf string `json:"snapshot_of,omitempty" yaml:"snapshot_of,omitempty"`
+
 	// Endpoint type and type-specific configurations
 	Type            string           `json:"type" yaml:"type"`                                         // "mock", "proxy", "container"
 	Items           []ResponseItem   `json:"items,omitempty" yaml:"items,omitempty"`                   // For mock type only
 	ProxyConfig     *ProxyConfig     `json:"proxy_config,omitempty" yaml:"proxy_config,omitempty"`     // For proxy type
 	ContainerConfig *ContainerConfig `json:"container_config,omitempty" yaml:"container_config,omitempty"` // For container type
+	PluginConfig    map[string]interface{} `json:"plugin_config,omitempty" yaml:"plugin_config,omitempty"` // For endpoint types provided by plugins
 }
 
 // IsEnabled returns whether this endpoint is enabled (defaults to true if not set)
@@ -336,6 +1010,74 @@ func (e *Endpoint) IsEnabled() bool {
 	return e.Enabled == nil || *e.Enabled
 }
 
+// EndpointAuth checks the credentials of requests to an endpoint. A request is let through when
+// any configured method accepts it; OPTIONS preflights are never challenged.
+type EndpointAuth struct {
+	Enabled          bool            `json:"enabled" yaml:"enabled"`
+	APIKeys          []string        `json:"api_keys,omitempty" yaml:"api_keys,omitempty"`                   // Accepted static API keys
+	APIKeyHeader     string          `json:"api_key_header,omitempty" yaml:"api_key_header,omitempty"`       // Header carrying the API key (default X-API-Key)
+	APIKeyQuery      string          `json:"api_key_query,omitempty" yaml:"api_key_query,omitempty"`         // Query parameter carrying the API key (empty = header only)
+	BasicUsers       []BasicAuthUser `json:"basic_users,omitempty" yaml:"basic_users,omitempty"`             // Accepted Basic auth users
+	BearerTokens     []string        `json:"bearer_tokens,omitempty" yaml:"bearer_tokens,omitempty"`         // Accepted opaque Bearer tokens
+	JWT              *JWTAuth        `json:"jwt,omitempty" yaml:"jwt,omitempty"`                             // Bearer tokens verified as JWTs
+	Realm            string          `json:"realm,omitempty" yaml:"realm,omitempty"`                         // Realm in WWW-Authenticate challenges (default "mockelot")
+	UnauthorizedBody string          `json:"unauthorized_body,omitempty" yaml:"unauthorized_body,omitempty"` // Body of 401 responses ("{reason}" is replaced by the failure)
+	ForbiddenBody    string          `json:"forbidden_body,omitempty" yaml:"forbidden_body,omitempty"`       // Body of 403 responses ("{reason}" is replaced by the failure)
+	ContentType      string          `json:"content_type,omitempty" yaml:"content_type,omitempty"`           // Content type of refusals (default application/json)
+}
+
+// BasicAuthUser is a username and password accepted by Basic auth
+type BasicAuthUser struct {
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+}
+
+// JWTAuth verifies Bearer JWTs: the signature against a secret or public keys, then the claims.
+// Invalid or expired tokens get a 401; valid tokens missing required claims or scopes get a 403.
+type JWTAuth struct {
+	Secret           string            `json:"secret,omitempty" yaml:"secret,omitempty"`                         // HMAC secret (HS256/HS384/HS512)
+	PublicKey        string            `json:"public_key,omitempty" yaml:"public_key,omitempty"`                 // PEM public key or certificate (RS*, PS*, ES*, EdDSA)
+	JWKS             string            `json:"jwks,omitempty" yaml:"jwks,omitempty"`                             // Inline JWKS document
+	JWKSURL          string            `json:"jwks_url,omitempty" yaml:"jwks_url,omitempty"`                     // URL the JWKS document is fetched from (cached)
+	Key              string            `json:"key,omitempty" yaml:"key,omitempty"`                               // Name of a key in jwt_keys
+	Algorithms       []string          `json:"algorithms,omitempty" yaml:"algorithms,omitempty"`                 // Accepted algorithms (empty = any the keys support)
+	Issuer           string            `json:"issuer,omitempty" yaml:"issuer,omitempty"`                         // Required "iss" claim
+	Audience         string            `json:"audience,omitempty" yaml:"audience,omitempty"`                     // Required "aud" value
+	RequiredClaims   map[string]string `json:"required_claims,omitempty" yaml:"required_claims,omitempty"`       // Claims that must have these values (403 otherwise)
+	RequiredScopes   []string          `json:"required_scopes,omitempty" yaml:"required_scopes,omitempty"`       // Scopes that must be in "scope"/"scp" (403 otherwise)
+	ClockSkewSeconds int               `json:"clock_skew_seconds,omitempty" yaml:"clock_skew_seconds,omitempty"` // Leeway for "exp" and "nbf"
+}
+
+// Auth outcomes
+const (
+	AuthAllowed      = "allowed"
+	AuthUnauthorized = "unauthorized"
+	AuthForbidden    = "forbidden"
+)
+
+// AuthOutcome records how a request fared against its endpoint's auth config
+type AuthOutcome struct {
+	Result    string `json:"result"`              // "allowed", "unauthorized" or "forbidden"
+	Method    string `json:"method,omitempty"`    // Method that decided: "api_key", "basic", "bearer" or "jwt"
+	Principal string `json:"principal,omitempty"` // Who was authenticated (username, JWT subject, masked key)
+	Reason    string `json:"reason,omitempty"`    // Why the request was refused
+}
+
+// VersionRouting selects a per-version item set for a mock endpoint.
+// Items of the resolved version are matched first, then the endpoint's shared items.
+type VersionRouting struct {
+	Source   string       `json:"source" yaml:"source"`                       // "path", "header", or "query"
+	Name     string       `json:"name,omitempty" yaml:"name,omitempty"`       // Header or query parameter name (header/query sources)
+	Default  string       `json:"default,omitempty" yaml:"default,omitempty"` // Version used when the request specifies none (or an unknown one)
+	Versions []APIVersion `json:"versions" yaml:"versions"`                   // Configured versions
+}
+
+// APIVersion is one version of a versioned mock endpoint
+type APIVersion struct {
+	Version string         `json:"version" yaml:"version"`                 // Version name as it appears in the request (e.g., "v1", "2")
+	Items   []ResponseItem `json:"items,omitempty" yaml:"items,omitempty"` // Responses and groups for this version
+}
+
 // CORSHeader represents a single CORS header with JavaScript expression
 type CORSHeader struct {
 	Name       string `json:"name" yaml:"name"`               // Header name (e.g., "Access-Control-Allow-Origin")
@@ -357,6 +1099,45 @@ type CACertInfo struct {
 	Generated string `json:"generated,omitempty"` // When CA was generated (ISO8601/RFC3339 format)
 }
 
+// Certificate roles reported by certificate inspection
+const (
+	CertRoleCA     = "ca"     // CA that signs the server certificate (and SOCKS5 interception certificates)
+	CertRoleServer = "server" // Certificate presented by the HTTPS server
+)
+
+// Certificate export formats
+const (
+	CertFormatPEM = "pem" // Base64 with BEGIN/END markers; a chain is concatenated into one file
+	CertFormatDER = "der" // Binary; one certificate per file
+)
+
+// Kinds of deliberately mismatched certificates for certificate-pinning negative tests
+const (
+	CertMismatchUntrustedCA = "untrusted-ca" // Signed by a throwaway CA
+	CertMismatchNewKey      = "new-key"      // Signed by the configured CA with a fresh key
+	CertMismatchWrongHost   = "wrong-host"   // Signed by the configured CA for a different hostname
+	CertMismatchExpired     = "expired"      // Signed by the configured CA, already expired
+)
+
+// CertificateDetails describes a CA or server certificate, including how close it is to expiry
+type CertificateDetails struct {
+	Role              string   `json:"role"`                         // CertRoleCA or CertRoleServer
+	Subject           string   `json:"subject,omitempty"`            // Distinguished name
+	Issuer            string   `json:"issuer,omitempty"`             // Distinguished name of the signer
+	SerialNumber      string   `json:"serial_number,omitempty"`      // Hex
+	DNSNames          []string `json:"dns_names,omitempty"`          // Subject alternative names
+	IPAddresses       []string `json:"ip_addresses,omitempty"`       // Subject alternative IPs
+	NotBefore         string   `json:"not_before,omitempty"`         // RFC3339
+	NotAfter          string   `json:"not_after,omitempty"`          // RFC3339
+	DaysRemaining     int      `json:"days_remaining"`               // Whole days until expiry (negative once expired)
+	Expiring          bool     `json:"expiring"`                     // Expires within the warning window
+	Expired           bool     `json:"expired"`                      // Past its expiry date
+	SHA1Fingerprint   string   `json:"sha1_fingerprint,omitempty"`   // Colon-separated hex
+	SHA256Fingerprint string   `json:"sha256_fingerprint,omitempty"` // Colon-separated hex
+	PublicKeyPin      string   `json:"public_key_pin,omitempty"`     // "sha256/<base64>" of the public key, as used by pinning clients
+	Error             string   `json:"error,omitempty"`              // Why the certificate could not be read
+}
+
 // CertPaths contains file paths for user-provided certificates
 type CertPaths struct {
 	CACertPath       string `json:"ca_cert_path,omitempty"`
@@ -366,6 +1147,36 @@ type CertPaths struct {
 	ServerBundlePath string `json:"server_bundle_path,omitempty"`
 }
 
+// ClientAuth modes of the HTTPS listener (mTLS)
+const (
+	ClientAuthNone    = ""        // Client certificates are not asked for
+	ClientAuthRequest = "request" // Ask for a client certificate, accept the connection without one and do not verify it
+	ClientAuthRequire = "require" // Refuse the handshake unless the client presents a certificate issued by the client CA
+)
+
+// ClientAuthConfig asks HTTPS clients for certificates. The certificate a client presents is
+// shown in the request log and to templates/scripts as request.clientCert.
+type ClientAuthConfig struct {
+	Mode       string `json:"mode,omitempty" yaml:"mode,omitempty"`                 // "", "request" or "require"
+	CACertPath string `json:"ca_cert_path,omitempty" yaml:"ca_cert_path,omitempty"` // PEM CA bundle client certificates are verified against (required for "require")
+}
+
+// ClientCertInfo describes the certificate a TLS client authenticated with
+type ClientCertInfo struct {
+	Subject        string   `json:"subject"`                   // Distinguished name, e.g. "CN=client,O=Acme"
+	CommonName     string   `json:"common_name,omitempty"`     // Subject common name
+	Issuer         string   `json:"issuer"`                    // Issuer distinguished name
+	SerialNumber   string   `json:"serial_number"`             // Serial number (hex)
+	DNSNames       []string `json:"dns_names,omitempty"`       // DNS subject alternative names
+	EmailAddresses []string `json:"email_addresses,omitempty"` // Email subject alternative names
+	IPAddresses    []string `json:"ip_addresses,omitempty"`    // IP subject alternative names
+	URIs           []string `json:"uris,omitempty"`            // URI subject alternative names (e.g. SPIFFE IDs)
+	NotBefore      string   `json:"not_before"`                // Validity start (RFC3339)
+	NotAfter       string   `json:"not_after"`                 // Validity end (RFC3339)
+	Fingerprint    string   `json:"fingerprint"`               // SHA-256 of the DER certificate (hex)
+	Verified       bool     `json:"verified"`                  // The chain was verified against the client CA
+}
+
 // DomainConfig represents a single domain in the takeover list
 type DomainConfig struct {
 	ID          string `json:"id" yaml:"id"`                                     // Unique identifier
@@ -381,20 +1192,439 @@ type DomainTakeoverConfig struct {
 
 // SOCKS5Config contains SOCKS5 proxy server configuration
 type SOCKS5Config struct {
-	Enabled        bool   `json:"enabled" yaml:"enabled"`                           // Whether SOCKS5 proxy is enabled
-	Port           int    `json:"port" yaml:"port"`                                 // SOCKS5 server port (default: 1080)
-	Authentication bool   `json:"authentication" yaml:"authentication"`             // Whether authentication is required
-	Username       string `json:"username,omitempty" yaml:"username,omitempty"`     // Username for authentication
-	Password       string `json:"password,omitempty" yaml:"password,omitempty"`     // Password for authentication
-	TrackRequests  bool   `json:"track_requests" yaml:"track_requests"`             // Whether to log SOCKS5 requests to a dedicated endpoint
+	Enabled        bool         `json:"enabled" yaml:"enabled"`                               // Whether SOCKS5 proxy is enabled
+	Port           int          `json:"port" yaml:"port"`                                     // SOCKS5 server port (default: 1080)
+	Authentication bool         `json:"authentication" yaml:"authentication"`                 // Whether authentication is required
+	Username       string       `json:"username,omitempty" yaml:"username,omitempty"`         // Username for authentication
+	Password       string       `json:"password,omitempty" yaml:"password,omitempty"`         // Password for authentication
+	TrackRequests  bool         `json:"track_requests" yaml:"track_requests"`                 // Whether to log SOCKS5 requests to a dedicated endpoint
+	BypassRules    []BypassRule `json:"bypass_rules,omitempty" yaml:"bypass_rules,omitempty"` // Destinations always tunneled directly, never intercepted or mocked
+
+	// Tunnel every destination outside the takeover list to the real server, plain HTTP included,
+	// instead of answering plain HTTP with the mocks. Only taken-over domains reach the endpoints.
+	TransparentPassThrough bool `json:"transparent_pass_through,omitempty" yaml:"transparent_pass_through,omitempty"`
+
+	// Access control for shared instances: clients outside AllowedClients are disconnected, and with
+	// authentication on, any of Users may log in besides Username/Password. Logs record the username.
+	AllowedClients []string     `json:"allowed_clients,omitempty" yaml:"allowed_clients,omitempty"` // Client IPs or CIDR ranges (empty = any client)
+	Users          []SOCKS5User `json:"users,omitempty" yaml:"users,omitempty"`                     // Additional credentials, e.g. one per tester
+}
+
+// SOCKS5User is one set of credentials accepted by the SOCKS5 proxy
+type SOCKS5User struct {
+	Username    string `json:"username" yaml:"username"`
+	Password    string `json:"password" yaml:"password"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"` // Who the credentials belong to
+}
+
+// HTTPProxyConfig contains the settings of the HTTP forward proxy, which serves clients that can
+// only be pointed at an HTTP proxy. CONNECT tunnels are handled like SOCKS5 connections (domain
+// takeover, TLS interception and the bypass rules of socks5_config).
+type HTTPProxyConfig struct {
+	Enabled        bool   `json:"enabled" yaml:"enabled"`                       // Whether the HTTP proxy is enabled
+	Port           int    `json:"port" yaml:"port"`                             // HTTP proxy port (default: 3128)
+	Authentication bool   `json:"authentication" yaml:"authentication"`         // Whether clients must send Proxy-Authorization (Basic)
+	Username       string `json:"username,omitempty" yaml:"username,omitempty"` // Username for authentication
+	Password       string `json:"password,omitempty" yaml:"password,omitempty"` // Password for authentication
+}
+
+// BypassRule selects destinations that are tunneled straight to the real server regardless of the
+// domain takeover list (e.g. corporate SSO). Set fields must all match; ports alone match any host.
+type BypassRule struct {
+	ID          string `json:"id" yaml:"id"`                                       // Unique identifier
+	Enabled     bool   `json:"enabled" yaml:"enabled"`                             // Whether this rule is applied
+	Domain      string `json:"domain,omitempty" yaml:"domain,omitempty"`           // Exact host, or "*.example.com" for any subdomain
+	CIDR        string `json:"cidr,omitempty" yaml:"cidr,omitempty"`               // Destination address range, e.g. "10.0.0.0/8" (domains are resolved)
+	Ports       []int  `json:"ports,omitempty" yaml:"ports,omitempty"`             // Destination ports (empty = any port)
+	Description string `json:"description,omitempty" yaml:"description,omitempty"` // Why the destination is bypassed
+}
+
+// BypassRuleStats counts the connections a bypass rule has tunneled since the proxy started
+type BypassRuleStats struct {
+	RuleID        string `json:"rule_id"`
+	Connections   int64  `json:"connections"`            // Connections tunneled by the rule
+	BytesSent     int64  `json:"bytes_sent"`             // Bytes from clients to destinations
+	BytesReceived int64  `json:"bytes_received"`         // Bytes from destinations to clients
+	LastMatched   string `json:"last_matched,omitempty"` // RFC3339 time of the most recent connection
+	LastTarget    string `json:"last_target,omitempty"`  // host:port of the most recent connection
+}
+
+// Default gRPC listener port
+const DefaultGRPCPort = 50051
+
+// GRPCConfig configures the gRPC mock listener. Services are described by .proto files and
+// each method is answered by the first enabled matching method response.
+type GRPCConfig struct {
+	Enabled     bool                 `json:"enabled" yaml:"enabled"`                               // Whether the gRPC listener runs
+	Port        int                  `json:"port,omitempty" yaml:"port,omitempty"`                 // Listener port (default: 50051)
+	ProtoFiles  []string             `json:"proto_files,omitempty" yaml:"proto_files,omitempty"`   // .proto files describing the mocked services
+	ImportPaths []string             `json:"import_paths,omitempty" yaml:"import_paths,omitempty"` // Directories searched for imports (the proto file's directory is always searched)
+	Reflection  *bool                `json:"reflection,omitempty" yaml:"reflection,omitempty"`     // Serve the reflection API for grpcurl and similar tools (default: true)
+	Methods     []GRPCMethodResponse `json:"methods,omitempty" yaml:"methods,omitempty"`           // Responses per method
+}
+
+// ReflectionEnabled returns whether server reflection is served (defaults to true)
+func (c *GRPCConfig) ReflectionEnabled() bool {
+	return c.Reflection == nil || *c.Reflection
+}
+
+// Default DNS listener port (53 usually needs elevated privileges)
+const DefaultDNSPort = 1053
+
+// DNS record types served by the DNS mock
+const (
+	DNSRecordA     = "A"
+	DNSRecordAAAA  = "AAAA"
+	DNSRecordCNAME = "CNAME"
+)
+
+// DNSConfig configures the DNS mock. A device using it as its resolver gets the configured records,
+// the mock server's address for every taken-over domain, and upstream answers for everything else.
+type DNSConfig struct {
+	Enabled       bool        `json:"enabled" yaml:"enabled"`                                     // Whether the DNS listener runs
+	Port          int         `json:"port,omitempty" yaml:"port,omitempty"`                       // UDP listener port (default: 1053)
+	MockAddress   string      `json:"mock_address,omitempty" yaml:"mock_address,omitempty"`       // IPv4 answered for taken-over domains and empty A records (default: this machine's LAN address)
+	MockAddressV6 string      `json:"mock_address_v6,omitempty" yaml:"mock_address_v6,omitempty"` // IPv6 answered for taken-over domains and empty AAAA records (default: none)
+	Upstream      string      `json:"upstream,omitempty" yaml:"upstream,omitempty"`               // Resolver for other names, "host:port" (empty = NXDOMAIN)
+	TTL           int         `json:"ttl,omitempty" yaml:"ttl,omitempty"`                         // TTL of mock answers in seconds (default: 60)
+	Takeover      *bool       `json:"takeover,omitempty" yaml:"takeover,omitempty"`               // Resolve taken-over domains to the mock server (default: true)
+	Records       []DNSRecord `json:"records,omitempty" yaml:"records,omitempty"`                 // Explicit records, checked before the takeover list
+}
+
+// TakeoverEnabled returns whether taken-over domains resolve to the mock server (defaults to true)
+func (c *DNSConfig) TakeoverEnabled() bool {
+	return c.Takeover == nil || *c.Takeover
+}
+
+// DNSRecord is one answer of the DNS mock
+type DNSRecord struct {
+	ID      string `json:"id" yaml:"id"`                           // Unique identifier
+	Enabled bool   `json:"enabled" yaml:"enabled"`                 // Whether this record is served
+	Name    string `json:"name" yaml:"name"`                       // Exact name, or "*.example.com" for any subdomain
+	Type    string `json:"type" yaml:"type"`                       // "A", "AAAA", or "CNAME"
+	Value   string `json:"value,omitempty" yaml:"value,omitempty"` // Address or CNAME target (empty A/AAAA = the mock address)
+}
+
+// GRPCMethodResponse answers calls to one gRPC method. The body is the response message in
+// protobuf JSON form; for server-streaming methods a JSON array sends one message per element.
+type GRPCMethodResponse struct {
+	ID            string            `json:"id,omitempty" yaml:"id,omitempty"`                         // Unique identifier
+	Method        string            `json:"method" yaml:"method"`                                     // Full method name: "package.Service/Method"
+	Enabled       *bool             `json:"enabled,omitempty" yaml:"enabled,omitempty"`               // Whether this response is enabled (default: true)
+	ResponseMode  string            `json:"response_mode,omitempty" yaml:"response_mode,omitempty"`   // "static", "template", or "script"
+	Body          string            `json:"body,omitempty" yaml:"body,omitempty"`                     // Response message as protobuf JSON (static and template modes)
+	ScriptBody    string            `json:"script_body,omitempty" yaml:"script_body,omitempty"`       // JavaScript for script mode (response.body, response.status = gRPC code)
+	StatusCode    int               `json:"status_code,omitempty" yaml:"status_code,omitempty"`       // gRPC status code (0 = OK)
+	StatusMessage string            `json:"status_message,omitempty" yaml:"status_message,omitempty"` // Status message for non-OK codes
+	Metadata      map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`             // Response header metadata
+	Delay         int               `json:"delay,omitempty" yaml:"delay,omitempty"`                   // Delay in milliseconds before responding
+}
+
+// IsEnabled returns whether this method response is enabled (defaults to true if not set)
+func (r *GRPCMethodResponse) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// GRPCMethodInfo describes a method found in the loaded .proto files
+type GRPCMethodInfo struct {
+	Method          string `json:"method"`           // Full method name: "package.Service/Method"
+	InputType       string `json:"input_type"`       // Request message type
+	OutputType      string `json:"output_type"`      // Response message type
+	ClientStreaming bool   `json:"client_streaming"` // Client sends a stream of messages
+	ServerStreaming bool   `json:"server_streaming"` // Server sends a stream of messages
+	ExampleBody     string `json:"example_body"`     // Response message skeleton in protobuf JSON form
+}
+
+// GRPCRequestInfo contains gRPC-specific request information for logging
+type GRPCRequestInfo struct {
+	Service    string `json:"service"`     // Full service name
+	Method     string `json:"method"`      // Method name
+	StatusCode int    `json:"status_code"` // gRPC status code returned
+	Status     string `json:"status"`      // gRPC status name (e.g., "OK", "NOT_FOUND")
+	Messages   int    `json:"messages"`    // Response messages sent
 }
 
 // SOCKS5RequestInfo contains SOCKS5-specific request information for logging
 type SOCKS5RequestInfo struct {
 	TargetHost    string `json:"target_host"`              // Target host (domain or IP)
 	TargetPort    int    `json:"target_port"`              // Target port
-	Protocol      string `json:"protocol"`                 // "HTTP", "HTTPS", or "PASS-THROUGH"
+	Protocol      string `json:"protocol"`                 // "HTTP", "HTTPS", "PASS-THROUGH", or "BYPASS"
 	IsIntercepted bool   `json:"is_intercepted"`           // true if domain was in takeover list and intercepted
+	ViaHTTPProxy  bool   `json:"via_http_proxy,omitempty"` // true if the client came through the HTTP proxy rather than SOCKS5
+	ProxyUser     string `json:"proxy_user,omitempty"`     // Username the client logged in to the proxy with (empty without authentication)
+
+	// Pass-through and bypass tunnels: traffic summary, filled in when the tunnel closes
+	BytesSent     int64  `json:"bytes_sent,omitempty"`     // Bytes from the client to the destination
+	BytesReceived int64  `json:"bytes_received,omitempty"` // Bytes from the destination to the client
+	DurationMs    *int64 `json:"duration_ms,omitempty"`    // How long the tunnel was open (nil while open)
+	Error         string `json:"error,omitempty"`          // Why the destination could not be reached
+}
+
+// Request log traffic filters
+const (
+	TrafficIntercepted = "intercepted" // HTTPS requests decrypted by SOCKS5 TLS interception
+	TrafficDirect      = "direct"      // Requests sent straight to a listener (not through the SOCKS5 proxy)
+)
+
+// VirtualClock shifts or freezes the time seen by time-dependent mock features (e.g., deprecation schedules)
+type VirtualClock struct {
+	OffsetSeconds int64  `json:"offset_seconds,omitempty" yaml:"offset_seconds,omitempty"` // Added to the real time
+	FrozenAt      string `json:"frozen_at,omitempty" yaml:"frozen_at,omitempty"`           // If set (RFC3339), time stands still at this instant
+}
+
+// Now returns the current virtual time (real time when the clock is nil)
+func (c *VirtualClock) Now() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	if c.FrozenAt != "" {
+		if frozen, err := time.Parse(time.RFC3339, c.FrozenAt); err == nil {
+			return frozen
+		}
+	}
+	return time.Now().Add(time.Duration(c.OffsetSeconds) * time.Second)
+}
+
+// Server limit defaults, used when a ServerLimits field is zero
+const (
+	DefaultMaxHeaderBytes       = 1 << 20  // 1 MB
+	DefaultMaxBodyBytes         = 10 << 20 // 10 MB
+	DefaultReadHeaderTimeoutSec = 5
+	DefaultReadTimeoutSec       = 10
+	DefaultWriteTimeoutSec      = 10
+	DefaultIdleTimeoutSec       = 60
+)
+
+// ServerLimits bounds request sizes and connection lifetimes so oversized or slow clients cannot wedge the server.
+// Zero means "use the default"; a negative timeout or body size disables the limit. The header size cannot
+// be unlimited, so a negative header size is rejected.
+type ServerLimits struct {
+	MaxHeaderBytes       int   `json:"max_header_bytes,omitempty" yaml:"max_header_bytes,omitempty"`               // Max size of request line + headers
+	MaxBodyBytes         int64 `json:"max_body_bytes,omitempty" yaml:"max_body_bytes,omitempty"`                   // Max request body size (larger bodies get 413)
+	ReadHeaderTimeoutSec int   `json:"read_header_timeout_sec,omitempty" yaml:"read_header_timeout_sec,omitempty"` // Time allowed to read request headers (slow-loris protection)
+	ReadTimeoutSec       int   `json:"read_timeout_sec,omitempty" yaml:"read_timeout_sec,omitempty"`               // Time allowed to read the whole request
+	WriteTimeoutSec      int   `json:"write_timeout_sec,omitempty" yaml:"write_timeout_sec,omitempty"`             // Time allowed to write the response
+	IdleTimeoutSec       int   `json:"idle_timeout_sec,omitempty" yaml:"idle_timeout_sec,omitempty"`               // Keep-alive idle time before a connection is closed
+	MaxConnections       int   `json:"max_connections,omitempty" yaml:"max_connections,omitempty"`                 // Max concurrent connections per listener (0 = unlimited)
+}
+
+// WithDefaults returns a copy of the limits with zero fields replaced by defaults (safe on nil)
+func (l *ServerLimits) WithDefaults() ServerLimits {
+	var resolved ServerLimits
+	if l != nil {
+		resolved = *l
+	}
+	if resolved.MaxHeaderBytes == 0 {
+		resolved.MaxHeaderBytes = DefaultMaxHeaderBytes
+	}
+	if resolved.MaxBodyBytes == 0 {
+		resolved.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if resolved.ReadHeaderTimeoutSec == 0 {
+		resolved.ReadHeaderTimeoutSec = DefaultReadHeaderTimeoutSec
+	}
+	if resolved.ReadTimeoutSec == 0 {
+		resolved.ReadTimeoutSec = DefaultReadTimeoutSec
+	}
+	if resolved.WriteTimeoutSec == 0 {
+		resolved.WriteTimeoutSec = DefaultWriteTimeoutSec
+	}
+	if resolved.IdleTimeoutSec == 0 {
+		resolved.IdleTimeoutSec = DefaultIdleTimeoutSec
+	}
+	return resolved
+}
+
+// Merge resolution strategies for endpoints, groups and responses that differ between two configs
+const (
+	MergeStrategyBase  = "base"  // Keep the base file's version
+	MergeStrategyOther = "other" // Take the other file's version
+	MergeStrategyBoth  = "both"  // Keep the base version and add the other as a copy
+)
+
+// MergeConflict describes an entry present in both configs with different content
+type MergeConflict struct {
+	Kind       string `json:"kind"`           // "endpoint", "group" or "response"
+	Endpoint   string `json:"endpoint"`       // Endpoint name
+	Name       string `json:"name,omitempty"` // Group name or response "METHODS path" (empty for endpoint conflicts)
+	BaseID     string `json:"base_id"`        // ID in the base config
+	OtherID    string `json:"other_id"`       // ID in the other config
+	Resolution string `json:"resolution"`     // What the merge did: "kept base", "took other", "kept both"
+}
+
+// MergeReport summarizes a config merge
+type MergeReport struct {
+	Strategy       string          `json:"strategy"`
+	EndpointsAdded []string        `json:"endpoints_added,omitempty"` // Endpoints only in the other config
+	ItemsAdded     int             `json:"items_added"`               // Groups/responses only in the other config, added to matching endpoints
+	Unchanged      int             `json:"unchanged"`                 // Entries identical in both configs
+	Conflicts      []MergeConflict `json:"conflicts,omitempty"`
+}
+
+// BundleReport summarizes the export or import of a config bundle
+type BundleReport struct {
+	Files    []string `json:"files"`              // Files in the bundle, by their path in it
+	Warnings []string `json:"warnings,omitempty"` // References that were not bundled and settings tied to the exporting machine
+}
+
+// CrawlOptions configures a backend crawl that snapshots a proxy endpoint into mock responses
+type CrawlOptions struct {
+	SeedPaths   []string          `json:"seed_paths"`             // Backend paths to start from (default: the backend URL path)
+	MaxPages    int               `json:"max_pages,omitempty"`    // Max requests to send (default: 100)
+	MaxDepth    int               `json:"max_depth,omitempty"`    // Max link hops from a seed (default: 3)
+	FollowLinks *bool             `json:"follow_links,omitempty"` // Follow links and HATEOAS refs found in responses (default: true)
+	Headers     map[string]string `json:"headers,omitempty"`      // Extra request headers (e.g., Authorization)
+}
+
+// CrawlResult summarizes a backend crawl
+type CrawlResult struct {
+	EndpointID   string   `json:"endpoint_id"`      // Mock endpoint created from the crawl
+	PagesFetched int      `json:"pages_fetched"`    // Backend requests sent
+	Responses    int      `json:"responses"`        // Mock responses created
+	Errors       []string `json:"errors,omitempty"` // Requests that failed
+}
+
+// OfflineEndpointStatus describes how a proxy/container endpoint is served in offline mode
+type OfflineEndpointStatus struct {
+	EndpointID   string `json:"endpoint_id"`           // Proxy or container endpoint
+	EndpointName string `json:"endpoint_name"`         // Endpoint display name
+	SnapshotID   string `json:"snapshot_id,omitempty"` // Mock endpoint serving its recorded responses (empty = none, requests get 503)
+	Responses    int    `json:"responses"`             // Responses in the snapshot
+	Recorded     int    `json:"recorded"`              // Responses added from the request log by this call
+}
+
+// OfflineModeStatus is the result of switching offline mode
+type OfflineModeStatus struct {
+	Enabled   bool                    `json:"enabled"`
+	Endpoints []OfflineEndpointStatus `json:"endpoints,omitempty"`
+}
+
+// ResponsePerfStats is the template/script execution cost of one response since the server started
+type ResponsePerfStats struct {
+	ResponseID   string   `json:"response_id"`
+	EndpointID   string   `json:"endpoint_id,omitempty"`
+	EndpointName string   `json:"endpoint_name,omitempty"`
+	PathPattern  string   `json:"path_pattern,omitempty"`
+	Methods      []string `json:"methods,omitempty"`
+	Mode         string   `json:"mode"`                 // Response mode ("template" or "script"; "static" = delay expression only)
+	Executions   int64    `json:"executions"`           // Times the template/script ran
+	Errors       int64    `json:"errors"`               // Executions that failed
+	ErrorRate    float64  `json:"error_rate"`           // Errors / executions (0-1)
+	TotalMs      float64  `json:"total_ms"`             // Cumulative execution time
+	AvgMs        float64  `json:"avg_ms"`               // Mean execution time
+	MaxMs        float64  `json:"max_ms"`               // Slowest execution
+	LastError    string   `json:"last_error,omitempty"` // Most recent failure
+}
+
+// Traffic counter kinds
+const (
+	TrafficKindTunnel   = "tunnel"   // SOCKS5 tunnel, keyed by destination host:port
+	TrafficKindEndpoint = "endpoint" // Proxy or container endpoint, keyed by endpoint ID
+)
+
+// TrafficStats is the traffic of one SOCKS5 tunnel destination or proxy endpoint since the server started
+type TrafficStats struct {
+	Kind        string  `json:"kind"`                  // "tunnel" or "endpoint"
+	Key         string  `json:"key"`                   // Destination host:port, or endpoint ID
+	Name        string  `json:"name,omitempty"`        // Endpoint name (tunnels: the destination)
+	Connections int64   `json:"connections"`           // Tunnels opened, or requests proxied
+	BytesIn     int64   `json:"bytes_in"`              // Bytes from clients (tunnels: wire bytes; endpoints: bodies)
+	BytesOut    int64   `json:"bytes_out"`             // Bytes to clients
+	InRate      float64 `json:"in_rate"`               // Bytes/second from clients over the last sample
+	OutRate     float64 `json:"out_rate"`              // Bytes/second to clients over the last sample
+	LastActive  string  `json:"last_active,omitempty"` // RFC3339 time of the most recent traffic
+}
+
+// Scheduled action types
+const (
+	ScheduleActionDisableResponse = "disable_response" // Stop serving a response
+	ScheduleActionEnableResponse  = "enable_response"  // Serve a response (even if disabled in the config)
+	ScheduleActionDisableEndpoint = "disable_endpoint" // Stop routing requests to an endpoint
+	ScheduleActionEnableEndpoint  = "enable_endpoint"  // Route requests to an endpoint (even if disabled in the config)
+	ScheduleActionEndpointError   = "endpoint_error"   // Answer every request to an endpoint with an error
+	ScheduleActionEndpointRecover = "endpoint_recover" // End an endpoint's error mode
+)
+
+// Scheduled action states
+const (
+	ScheduleStatePending   = "pending"
+	ScheduleStateFired     = "fired"
+	ScheduleStateCancelled = "cancelled"
+)
+
+// ScheduledAction changes what the server serves after a delay, without editing the config.
+// Effects last until the server stops or the action is cancelled.
+type ScheduledAction struct {
+	ID         string `json:"id,omitempty" yaml:"id,omitempty"`                   // Unique identifier
+	Name       string `json:"name,omitempty" yaml:"name,omitempty"`               // Display name
+	After      string `json:"after" yaml:"after"`                                 // Delay after server start (or after scheduling), e.g. "30s", "2m"
+	Action     string `json:"action" yaml:"action"`                               // One of the ScheduleAction* types
+	ResponseID string `json:"response_id,omitempty" yaml:"response_id,omitempty"` // Target of response actions
+	EndpointID string `json:"endpoint_id,omitempty" yaml:"endpoint_id,omitempty"` // Target of endpoint actions
+	StatusCode int    `json:"status_code,omitempty" yaml:"status_code,omitempty"` // endpoint_error status (default: 503)
+	Body       string `json:"body,omitempty" yaml:"body,omitempty"`               // endpoint_error body
+}
+
+// ScheduledActionStatus is a scheduled action with its due time and state
+type ScheduledActionStatus struct {
+	Action  ScheduledAction `json:"action"`
+	DueAt   string          `json:"due_at"`             // RFC3339
+	FiredAt string          `json:"fired_at,omitempty"` // RFC3339
+	State   string          `json:"state"`              // "pending", "fired", or "cancelled"
+}
+
+// Macro step actions beyond the ScheduleAction* types
+const (
+	MacroActionOfflineMode = "offline_mode" // Turn offline mode on or off (enabled)
+	MacroActionEnvironment = "environment"  // Switch the active environment (value; empty = base URLs)
+)
+
+// MacroStep is one recorded state change. Scheduler steps are applied as runtime overrides like ScheduleAction.
+type MacroStep struct {
+	Delay      string `json:"delay,omitempty" yaml:"delay,omitempty"`             // Wait after the previous step (or the macro start), e.g. "1.5s"
+	Action     string `json:"action" yaml:"action"`                               // A ScheduleAction* type or a MacroAction* type
+	After      string `json:"after,omitempty" yaml:"after,omitempty"`             // Scheduler delay of scheduled steps
+	ResponseID string `json:"response_id,omitempty" yaml:"response_id,omitempty"` // Target of response actions
+	EndpointID string `json:"endpoint_id,omitempty" yaml:"endpoint_id,omitempty"` // Target of endpoint actions
+	StatusCode int    `json:"status_code,omitempty" yaml:"status_code,omitempty"` // endpoint_error status
+	Body       string `json:"body,omitempty" yaml:"body,omitempty"`               // endpoint_error body
+	Enabled    bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`         // offline_mode state
+	Value      string `json:"value,omitempty" yaml:"value,omitempty"`             // environment name
+}
+
+// Macro is a named sequence of recorded state changes that can be played back with its original timing
+type Macro struct {
+	Name       string      `json:"name" yaml:"name"`
+	RecordedAt string      `json:"recorded_at,omitempty" yaml:"recorded_at,omitempty"` // RFC3339
+	Steps      []MacroStep `json:"steps" yaml:"steps"`
+}
+
+// ScriptModule is a named JavaScript helper that script-mode responses load with require("name").
+// The code runs as a CommonJS module: it assigns what it exports to module.exports or exports.
+type ScriptModule struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Code        string `json:"code" yaml:"code"`
+}
+
+// ScriptFetchConfig controls http.fetch in script-mode responses. Relative URLs call this server
+// and are always allowed; other hosts must be listed in AllowedHosts.
+type ScriptFetchConfig struct {
+	Enabled          bool     `json:"enabled" yaml:"enabled"`
+	AllowedHosts     []string `json:"allowed_hosts,omitempty" yaml:"allowed_hosts,omitempty"`           // "api.example.com", "api.example.com:8443" or "*.example.com"
+	TimeoutMs        int      `json:"timeout_ms,omitempty" yaml:"timeout_ms,omitempty"`                 // Per request (default 3000, at most 5000)
+	MaxResponseBytes int      `json:"max_response_bytes,omitempty" yaml:"max_response_bytes,omitempty"` // Larger responses fail the fetch (default 1 MB)
+}
+
+// JWTSigningKey is a named key that templates and scripts sign and verify JWTs with, and that
+// endpoint auth can verify tokens against
+type JWTSigningKey struct {
+	Name       string `json:"name" yaml:"name"`
+	Algorithm  string `json:"algorithm" yaml:"algorithm"`                         // HS256/384/512, RS256/384/512, PS256/384/512, ES256/384/512 or EdDSA
+	Secret     string `json:"secret,omitempty" yaml:"secret,omitempty"`           // HMAC secret (HS*)
+	PrivateKey string `json:"private_key,omitempty" yaml:"private_key,omitempty"` // PEM private key (other algorithms)
+	PublicKey  string `json:"public_key,omitempty" yaml:"public_key,omitempty"`   // PEM public key for verify-only keys (derived from private_key when empty)
+	KeyID      string `json:"kid,omitempty" yaml:"kid,omitempty"`                 // "kid" header of signed tokens
 }
 
 // UserConfig stores all configuration (server settings + user content) in a single file
@@ -413,11 +1643,51 @@ type UserConfig struct {
 	CertMode               string    `json:"cert_mode,omitempty" yaml:"cert_mode,omitempty"`                               // Certificate mode
 	CertPaths              CertPaths `json:"cert_paths,omitempty" yaml:"cert_paths,omitempty"`                             // Certificate paths
 	CertNames              []string  `json:"cert_names,omitempty" yaml:"cert_names,omitempty"`                             // Certificate names
+	ClientAuth             *ClientAuthConfig `json:"client_auth,omitempty" yaml:"client_auth,omitempty"`           // Client certificates (mTLS)
+	Limits                 *ServerLimits `json:"limits,omitempty" yaml:"limits,omitempty"`                     // Request size and timeout limits
+	VirtualClock           *VirtualClock `json:"virtual_clock,omitempty" yaml:"virtual_clock,omitempty"`       // Virtual clock
+	OfflineMode            bool          `json:"offline_mode,omitempty" yaml:"offline_mode,omitempty"`         // Serve recorded snapshots instead of backends
+	ActiveEnvironment      string        `json:"active_environment,omitempty" yaml:"active_environment,omitempty"` // Environment whose backend URLs proxy endpoints use
+	Variables              map[string]string            `json:"variables,omitempty" yaml:"variables,omitempty"`           // Values of ${NAME} references
+	Profiles               map[string]map[string]string `json:"profiles,omitempty" yaml:"profiles,omitempty"`             // Named variable sets (e.g., dev, staging)
+	ActiveProfile          string                       `json:"active_profile,omitempty" yaml:"active_profile,omitempty"` // Profile whose variables override variables
 
 	// Shared Settings
 	CORS           CORSConfig              `json:"cors,omitempty" yaml:"cors,omitempty"`           // Global CORS configuration
 	SOCKS5Config   *SOCKS5Config           `json:"socks5_config,omitempty" yaml:"socks5_config,omitempty"` // SOCKS5 proxy configuration
+	HTTPProxy      *HTTPProxyConfig        `json:"http_proxy,omitempty" yaml:"http_proxy,omitempty"`       // HTTP forward proxy configuration
 	DomainTakeover *DomainTakeoverConfig   `json:"domain_takeover,omitempty" yaml:"domain_takeover,omitempty"` // Domain takeover configuration
+	GRPC           *GRPCConfig             `json:"grpc,omitempty" yaml:"grpc,omitempty"`           // gRPC mock listener
+	DNS            *DNSConfig              `json:"dns,omitempty" yaml:"dns,omitempty"`             // DNS mock listener
+
+	// Scheduled Actions
+	ScheduledActions []ScheduledAction `json:"scheduled_actions,omitempty" yaml:"scheduled_actions,omitempty"` // Timed response/endpoint changes
+	Macros           []Macro           `json:"macros,omitempty" yaml:"macros,omitempty"`                       // Recorded state change sequences
+
+	// Script Modules
+	ScriptModules []ScriptModule `json:"script_modules,omitempty" yaml:"script_modules,omitempty"` // Shared JavaScript helpers loaded with require()
+
+	// Script Fetch
+	ScriptFetch *ScriptFetchConfig `json:"script_fetch,omitempty" yaml:"script_fetch,omitempty"` // Outbound http.fetch for script-mode responses
+
+	// Named Listeners
+	Listeners []Listener `json:"listeners,omitempty" yaml:"listeners,omitempty"` // Extra ports, each serving the endpoints assigned to it
+
+	// Client Throttling
+	ClientThrottles []ClientThrottleRule `json:"client_throttles,omitempty" yaml:"client_throttles,omitempty"` // Per-client-IP rate limits
+
+	// JWT Keys
+	JWTKeys []JWTSigningKey `json:"jwt_keys,omitempty" yaml:"jwt_keys,omitempty"` // Named keys for the jwt helpers of templates and scripts
+
+	// Container Registries
+	RegistryCredentials []RegistryCredential `json:"registry_credentials,omitempty" yaml:"registry_credentials,omitempty"` // Logins for private container registries, by registry host
+
+	// Container Hosts
+	ContainerHosts       []ContainerHost `json:"container_hosts,omitempty" yaml:"container_hosts,omitempty"`               // Remote Docker/Podman daemons for container endpoints
+	DefaultContainerHost string          `json:"default_container_host,omitempty" yaml:"default_container_host,omitempty"` // Host of container endpoints without one (empty: this machine)
+
+	// Marketplace
+	MarketplaceSources []MarketplaceSource `json:"marketplace_sources,omitempty" yaml:"marketplace_sources,omitempty"` // Endpoint bundle registries
 
 	// UI State
 	SelectedEndpointId string `json:"selected_endpoint_id,omitempty" yaml:"selected_endpoint_id,omitempty"` // Selected endpoint
@@ -472,16 +1742,66 @@ type AppConfig struct {
 	CertMode            string    `json:"cert_mode,omitempty" yaml:"cert_mode,omitempty"`                               // Certificate mode: "auto", "ca-provided", "cert-provided"
 	CertPaths           CertPaths `json:"cert_paths,omitempty" yaml:"cert_paths,omitempty"`                             // Paths to user-provided certificates
 	CertNames           []string  `json:"cert_names,omitempty" yaml:"cert_names,omitempty"`                             // Custom DNS names and IP addresses for certificate (CN/SAN)
+	ClientAuth          *ClientAuthConfig `json:"client_auth,omitempty" yaml:"client_auth,omitempty"`           // Client certificate request/verification of the HTTPS listener (nil = off)
+
+	// Request Limits
+	Limits *ServerLimits `json:"limits,omitempty" yaml:"limits,omitempty"` // Header/body size limits and connection timeouts (nil = defaults)
+
+	// Virtual Clock
+	VirtualClock *VirtualClock `json:"virtual_clock,omitempty" yaml:"virtual_clock,omitempty"` // Time source for deprecation schedules (nil = real time)
+
+	// Offline Mode
+	OfflineMode bool `json:"offline_mode,omitempty" yaml:"offline_mode,omitempty"` // Proxy/container endpoints serve their recorded snapshot endpoints instead of contacting backends
+
+	// Active Environment
+	ActiveEnvironment string `json:"active_environment,omitempty" yaml:"active_environment,omitempty"` // Environment whose backend URLs proxy endpoints use (empty = backend_url)
+
+	// Variables and Profiles
+	Variables     map[string]string            `json:"variables,omitempty" yaml:"variables,omitempty"`           // Values of ${NAME} references in backend URLs, headers, bodies and container environments (undefined names fall back to the OS environment)
+	Profiles      map[string]map[string]string `json:"profiles,omitempty" yaml:"profiles,omitempty"`             // Named variable sets (e.g., dev, staging) that override variables
+	ActiveProfile string                       `json:"active_profile,omitempty" yaml:"active_profile,omitempty"` // Profile whose variables are used (empty = variables only)
 
 	// CORS Configuration
 	CORS CORSConfig `json:"cors,omitempty" yaml:"cors,omitempty"` // Global CORS configuration
 
 	// SOCKS5 Proxy Configuration
 	SOCKS5Config     *SOCKS5Config           `json:"socks5_config,omitempty" yaml:"socks5_config,omitempty"`           // SOCKS5 proxy server settings
+	HTTPProxy        *HTTPProxyConfig        `json:"http_proxy,omitempty" yaml:"http_proxy,omitempty"`                 // HTTP forward proxy (CONNECT and absolute-URI requests)
 	DomainTakeover   *DomainTakeoverConfig   `json:"domain_takeover,omitempty" yaml:"domain_takeover,omitempty"`       // Domain interception configuration
 
+	// gRPC Mock Listener
+	GRPC *GRPCConfig `json:"grpc,omitempty" yaml:"grpc,omitempty"` // gRPC listener, proto files and method responses
+
+	// DNS Mock Listener
+	DNS *DNSConfig `json:"dns,omitempty" yaml:"dns,omitempty"` // DNS records and takeover resolution
+
+	// Scheduled Actions
+	ScheduledActions []ScheduledAction `json:"scheduled_actions,omitempty" yaml:"scheduled_actions,omitempty"` // Changes applied at server start + delay
+	Macros           []Macro           `json:"macros,omitempty" yaml:"macros,omitempty"`                       // Recorded state change sequences, played with RunMacro
+
+	// Script Modules
+	ScriptModules []ScriptModule `json:"script_modules,omitempty" yaml:"script_modules,omitempty"` // Shared JavaScript helpers that script-mode responses load with require("name")
+
+	// Script Fetch
+	ScriptFetch *ScriptFetchConfig `json:"script_fetch,omitempty" yaml:"script_fetch,omitempty"` // Outbound http.fetch for script-mode responses
+
+	// Named Listeners
+	Listeners []Listener `json:"listeners,omitempty" yaml:"listeners,omitempty"` // Extra ports, each serving the endpoints assigned to it
+
+	// Client Throttling
+	ClientThrottles []ClientThrottleRule `json:"client_throttles,omitempty" yaml:"client_throttles,omitempty"` // Per-client-IP rate limits
+
+	// JWT Keys
+	JWTKeys []JWTSigningKey `json:"jwt_keys,omitempty" yaml:"jwt_keys,omitempty"` // Named keys for the jwt helpers of templates and scripts
+
 	// Container Configuration
-	ContainerLogLineLimit int `json:"container_log_line_limit,omitempty" yaml:"container_log_line_limit,omitempty"` // Max number of log lines to retrieve (default 5000)
+	ContainerLogLineLimit int                  `json:"container_log_line_limit,omitempty" yaml:"container_log_line_limit,omitempty"` // Max number of log lines to retrieve (default 5000)
+	RegistryCredentials   []RegistryCredential `json:"registry_credentials,omitempty" yaml:"registry_credentials,omitempty"`         // Logins for private container registries, by registry host
+	ContainerHosts        []ContainerHost      `json:"container_hosts,omitempty" yaml:"container_hosts,omitempty"`                   // Remote Docker/Podman daemons for container endpoints
+	DefaultContainerHost  string               `json:"default_container_host,omitempty" yaml:"default_container_host,omitempty"`     // Host of container endpoints without one (empty: this machine)
+
+	// Marketplace Configuration
+	MarketplaceSources []MarketplaceSource `json:"marketplace_sources,omitempty" yaml:"marketplace_sources,omitempty"` // Registries to browse for endpoint bundles
 
 	// Selected Endpoint
 	SelectedEndpointId string `json:"selected_endpoint_id,omitempty" yaml:"selected_endpoint_id,omitempty"` // Currently selected endpoint ID
@@ -499,9 +1819,16 @@ type ServerSettings struct {
 	CertMode               *string                `json:"cert_mode,omitempty"`
 	CertPaths              *CertPaths             `json:"cert_paths,omitempty"`       // Pointer to distinguish "not provided" from "empty struct"
 	CertNames              []string               `json:"cert_names,omitempty"`       // Slice can be nil to mean "not provided"
+	ClientAuth             *ClientAuthConfig      `json:"client_auth,omitempty"`
 	CORS                   *CORSConfig            `json:"cors,omitempty"`             // Pointer to distinguish "not provided" from "empty struct"
+	Limits                 *ServerLimits          `json:"limits,omitempty"`
 	SOCKS5Config           *SOCKS5Config          `json:"socks5_config,omitempty"`
+	HTTPProxy              *HTTPProxyConfig       `json:"http_proxy,omitempty"`
 	DomainTakeover         *DomainTakeoverConfig  `json:"domain_takeover,omitempty"`
+	MarketplaceSources     []MarketplaceSource    `json:"marketplace_sources,omitempty"` // Slice can be nil to mean "not provided"
+	RegistryCredentials    []RegistryCredential   `json:"registry_credentials,omitempty"` // Slice can be nil to mean "not provided"
+	ContainerHosts         []ContainerHost        `json:"container_hosts,omitempty"`      // Slice can be nil to mean "not provided"
+	DefaultContainerHost   *string                `json:"default_container_host,omitempty"`
 }
 
 // GetAllResponses returns all enabled responses in priority order (flattened from items and legacy responses)
@@ -550,22 +1877,73 @@ type RequestLogSummary struct {
 	ResponseFailed   bool   `json:"response_failed,omitempty"`       // (R) badge - response generation failed (script error, etc.)
 	TargetHost       string `json:"target_host,omitempty"`           // For SOCKS5 logs: target host (domain or IP)
 	TargetPort       int    `json:"target_port,omitempty"`           // For SOCKS5 logs: target port
+	ViaSOCKS5        bool   `json:"via_socks5,omitempty"`            // Request arrived through the SOCKS5 proxy
+	Intercepted      bool   `json:"intercepted,omitempty"`           // Request was decrypted by SOCKS5 TLS interception
+	ProxyUser        string `json:"proxy_user,omitempty"`            // For proxy logs: username the client logged in with
+	BytesSent        int64  `json:"bytes_sent,omitempty"`            // For pass-through tunnels: bytes from client to destination
+	BytesReceived    int64  `json:"bytes_received,omitempty"`        // For pass-through tunnels: bytes from destination to client
+	AssertionStatus  string `json:"assertion_status,omitempty"`      // "passed" or "failed" when an assertion script ran
+}
+
+// RequestLogFilter selects request logs for SearchRequestLogs; empty fields match every log
+type RequestLogFilter struct {
+	Method       string             `json:"method,omitempty"`        // HTTP method (case-insensitive)
+	PathRegex    string             `json:"path_regex,omitempty"`    // Regular expression searched in the request path
+	StatusMin    int                `json:"status_min,omitempty"`    // Lowest client status code, inclusive (logs without a response never match a status range)
+	StatusMax    int                `json:"status_max,omitempty"`    // Highest client status code, inclusive
+	EndpointID   string             `json:"endpoint_id,omitempty"`   // Endpoint that handled the request
+	ProxyUser    string             `json:"proxy_user,omitempty"`    // Username the client logged in to the SOCKS5 or HTTP proxy with
+	Since        string             `json:"since,omitempty"`         // Only logs at or after this time (RFC3339)
+	Until        string             `json:"until,omitempty"`         // Only logs before this time (RFC3339)
+	BodyContains string             `json:"body_contains,omitempty"` // Case-insensitive substring of the request or response body
+	Headers      []LogHeaderMatcher `json:"headers,omitempty"`       // Header matchers, all of which must match
+	Descending   bool               `json:"descending,omitempty"`    // Newest logs first
+	Offset       int                `json:"offset,omitempty"`        // Number of matching logs to skip
+	Limit        int                `json:"limit,omitempty"`         // Page size (default: 100, max: 1000)
 }
 
+// LogHeaderMatcher matches one request (or response) header of a logged request
+type LogHeaderMatcher struct {
+	Name     string `json:"name"`               // Header name (case-insensitive)
+	Pattern  string `json:"pattern,omitempty"`  // Regular expression searched in the header's values (empty = header is present)
+	Response bool   `json:"response,omitempty"` // Match the response headers sent to the client instead of the request headers
+}
+
+// RequestLogPage is one page of request log search results
+type RequestLogPage struct {
+	Total  int                 `json:"total"`  // Number of logs matching the filter
+	Offset int                 `json:"offset"` // Offset of the first log in this page
+	Logs   []RequestLogSummary `json:"logs"`   // Summaries of the logs in this page
+}
+
+// RequestLogTimeFormat is the format of request log timestamps: RFC3339 with milliseconds, so
+// transaction durations can be measured from them
+const RequestLogTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
 // RequestLog represents a detailed log of an incoming HTTP request and response
 // with dual-sided tracking for proxy/container endpoints (client↔server and server↔backend)
 type RequestLog struct {
 	ID         string `json:"id"`                    // Unique request identifier
-	Timestamp  string `json:"timestamp"`             // Time request was received (ISO8601/RFC3339 format)
+	Timestamp  string `json:"timestamp"`             // Time request was received (RequestLogTimeFormat)
 	EndpointID string `json:"endpoint_id,omitempty"` // ID of endpoint that handled this request
 
 	// Failure indicators
 	ValidationFailed bool `json:"validation_failed,omitempty"` // (V) badge - request matched path but failed validation
 	ResponseFailed   bool `json:"response_failed,omitempty"`   // (R) badge - response generation failed (script error, etc.)
+	BodyTruncated    bool `json:"body_truncated,omitempty"`    // Response was streamed and its logged body cut at the proxy's log_body_limit
 
 	// SOCKS5 proxy information (only set for SOCKS5 proxy endpoint logs)
 	SOCKS5Info *SOCKS5RequestInfo `json:"socks5_info,omitempty"`
 
+	// gRPC call information (only set for gRPC listener logs)
+	GRPCInfo *GRPCRequestInfo `json:"grpc_info,omitempty"`
+
+	// Assertion outcome (only set for proxy endpoints with an assertion script)
+	Assertion *AssertionResult `json:"assertion,omitempty"`
+
+	// Which rule handled the request and why
+	Match *MatchInfo `json:"match,omitempty"`
+
 	// Client side: Client → Server
 	ClientRequest struct {
 		Method      string              `json:"method"`                 // HTTP method (GET, POST, etc.)
@@ -577,6 +1955,7 @@ type RequestLog struct {
 		Protocol    string              `json:"protocol,omitempty"`     // HTTP protocol version (HTTP/1.1, HTTP/2)
 		SourceIP    string              `json:"source_ip"`              // Client IP address
 		UserAgent   string              `json:"user_agent,omitempty"`   // Client user agent
+		ClientCert  *ClientCertInfo     `json:"client_cert,omitempty"`  // TLS client certificate (mTLS), nil if none was presented
 	} `json:"client_request"`
 
 	// Client side: Server → Client
@@ -610,6 +1989,185 @@ type RequestLog struct {
 	} `json:"backend_response,omitempty"`
 }
 
+// CommandSnippets are shell commands reproducing a logged request outside the app
+type CommandSnippets struct {
+	Curl          string `json:"curl"`                     // curl command for the client request
+	HTTPie        string `json:"httpie"`                   // HTTPie command for the client request
+	BackendCurl   string `json:"backend_curl,omitempty"`   // curl command for the request sent to the backend (proxied requests)
+	BackendHTTPie string `json:"backend_httpie,omitempty"` // HTTPie command for the request sent to the backend
+}
+
+// ReplayResult is the outcome of re-sending a logged client request, next to the original response
+type ReplayResult struct {
+	LogID           string              `json:"log_id"`                     // Replayed request log
+	Method          string              `json:"method"`                     // Method sent
+	URL             string              `json:"url"`                        // URL the request was sent to
+	StatusCode      int                 `json:"status_code,omitempty"`      // New response status (0 if the request failed)
+	StatusText      string              `json:"status_text,omitempty"`      // New response status text
+	Headers         map[string][]string `json:"headers,omitempty"`          // New response headers
+	Body            string              `json:"body,omitempty"`             // New response body
+	RTTMs           int64               `json:"rtt_ms"`                     // Round-trip time of the replay (ms)
+	Error           string              `json:"error,omitempty"`            // Why the request could not be sent or read
+	OriginalStatus  *int                `json:"original_status,omitempty"`  // Status the original request received (nil if none)
+	OriginalHeaders map[string][]string `json:"original_headers,omitempty"` // Headers of the original response
+	OriginalBody    string              `json:"original_body,omitempty"`    // Body of the original response
+	StatusMatches   bool                `json:"status_matches"`             // New status equals the original status
+	BodyMatches     bool                `json:"body_matches"`               // New body equals the original body
+}
+
+// MatchInfo records which endpoint, group and response handled a request, with the matching steps
+type MatchInfo struct {
+	EndpointID     string       `json:"endpoint_id,omitempty"`     // Matched endpoint
+	EndpointName   string       `json:"endpoint_name,omitempty"`   // Matched endpoint display name
+	GroupID        string       `json:"group_id,omitempty"`        // Group of the matched response (empty for top-level responses)
+	GroupName      string       `json:"group_name,omitempty"`      // Group display name
+	ResponseID     string       `json:"response_id,omitempty"`     // Matched response rule (empty for proxy/container endpoints)
+	PathPattern    string       `json:"path_pattern,omitempty"`    // Path pattern of the matched response
+	TranslatedPath string       `json:"translated_path,omitempty"` // Path after endpoint translation, as matched against responses
+	SequenceStep   int          `json:"sequence_step,omitempty"`   // Step served by a sequence response (1-based)
+	SequenceLength int          `json:"sequence_length,omitempty"` // Steps in that sequence
+	Fault          string       `json:"fault,omitempty"`           // Fault injected into the response (e.g., "latency 120ms", "error 503", "reset", "truncated")
+	Throttle       string       `json:"throttle,omitempty"`        // Client throttle rule that refused the request
+	Circuit        string       `json:"circuit,omitempty"`         // Circuit breaker state that answered with the fallback response
+	Auth           *AuthOutcome `json:"auth,omitempty"`            // Outcome of the endpoint's authentication check
+	Explanation    []string     `json:"explanation,omitempty"`     // Matching steps in order (prefix, translation, candidates, validation)
+}
+
+// PatternError describes a regex pattern in the config that does not compile
+type PatternError struct {
+	EndpointID   string `json:"endpoint_id,omitempty"`   // Endpoint containing the pattern (empty for global settings)
+	EndpointName string `json:"endpoint_name,omitempty"` // Endpoint display name
+	ResponseID   string `json:"response_id,omitempty"`   // Response containing the pattern (empty for endpoint-level fields)
+	Field        string `json:"field"`                   // Field name (e.g., "path_prefix", "path_pattern", "domain_takeover")
+	Pattern      string `json:"pattern"`                 // The offending pattern
+	Error        string `json:"error"`                   // Compiler error message
+}
+
+// String formats the error for logs and error messages
+func (e PatternError) String() string {
+	location := e.Field
+	if e.EndpointName != "" {
+		location = fmt.Sprintf("endpoint %q %s", e.EndpointName, e.Field)
+	}
+	return fmt.Sprintf("%s %q: %s", location, e.Pattern, e.Error)
+}
+
+// ScriptWarning describes a script, template or expression in the config that does not compile
+type ScriptWarning struct {
+	EndpointID   string `json:"endpoint_id,omitempty"`   // Endpoint containing the script (empty for global settings)
+	EndpointName string `json:"endpoint_name,omitempty"` // Endpoint display name
+	ResponseID   string `json:"response_id,omitempty"`   // Response containing the script (empty for endpoint-level fields)
+	Field        string `json:"field"`                   // Field name (e.g., "script_body", "body", "headers.X-Id", "cors.script")
+	Error        string `json:"error"`                   // Compiler error message
+}
+
+// String formats the warning for logs
+func (w ScriptWarning) String() string {
+	location := w.Field
+	if w.ResponseID != "" {
+		location = fmt.Sprintf("response %s %s", w.ResponseID, location)
+	}
+	if w.EndpointName != "" {
+		location = fmt.Sprintf("endpoint %q %s", w.EndpointName, location)
+	}
+	return fmt.Sprintf("%s: %s", location, w.Error)
+}
+
+// ProxyBackendStatus is the health and traffic of one backend in a proxy's pool
+type ProxyBackendStatus struct {
+	URL         string `json:"url"`
+	Weight      int    `json:"weight"`
+	Healthy     bool   `json:"healthy"`                // In the rotation
+	Requests    int64  `json:"requests"`               // Requests sent to the backend
+	Failures    int64  `json:"failures"`               // Requests that could not reach it
+	LastError   string `json:"last_error,omitempty"`   // From the last failed request or health check
+	LastFailure string `json:"last_failure,omitempty"` // RFC3339
+	LastCheck   string `json:"last_check,omitempty"`   // RFC3339 time of the last health check
+}
+
+// BackendSLA summarizes availability and latency of a proxy backend over a time window
+type BackendSLA struct {
+	EndpointID         string  `json:"endpoint_id"`
+	EndpointName       string  `json:"endpoint_name,omitempty"`
+	BackendURL         string  `json:"backend_url,omitempty"`
+	Window             string  `json:"window"`               // Window length (e.g., "1h0m0s")
+	From               string  `json:"from"`                 // Window start (RFC3339)
+	To                 string  `json:"to"`                   // Window end (RFC3339)
+	HealthChecks       int     `json:"health_checks"`        // Health checks performed in the window
+	HealthyChecks      int     `json:"healthy_checks"`       // Health checks that passed
+	UptimePercent      float64 `json:"uptime_percent"`       // Healthy checks / checks (0 if no checks ran)
+	Requests           int     `json:"requests"`             // Proxied requests in the window
+	SuccessfulRequests int     `json:"successful_requests"`  // Backend answered with status < 500
+	FailedRequests     int     `json:"failed_requests"`      // Backend errors, timeouts or 5xx
+	SuccessRatePercent float64 `json:"success_rate_percent"` // Successful / total requests
+	AvgLatencyMs       int64   `json:"avg_latency_ms"`
+	P50LatencyMs       int64   `json:"p50_latency_ms"`
+	P95LatencyMs       int64   `json:"p95_latency_ms"`
+	P99LatencyMs       int64   `json:"p99_latency_ms"`
+	MaxLatencyMs       int64   `json:"max_latency_ms"`
+}
+
+// AssertionResult is the outcome of a proxy assertion script
+type AssertionResult struct {
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"` // Failure reasons, or a pass summary
+}
+
+// Status returns "passed" or "failed" for summaries
+func (r *AssertionResult) Status() string {
+	if r == nil {
+		return ""
+	}
+	if r.Passed {
+		return "passed"
+	}
+	return "failed"
+}
+
+// Transaction types for grouped request logs
+const (
+	TransactionTypeHeader = "header" // Grouped by a shared correlation header value
+	TransactionTypeSOCKS  = "socks"  // Grouped by SOCKS5 client connection
+)
+
+// Transaction groups request logs that belong to one logical client flow
+type Transaction struct {
+	ID           string   `json:"id"`            // Stable identifier for the group
+	Type         string   `json:"type"`          // "header" or "socks"
+	Key          string   `json:"key"`           // Correlation header name, or SOCKS5 client address
+	Value        string   `json:"value"`         // Correlation header value, or SOCKS5 target host:port
+	RequestIDs   []string `json:"request_ids"`   // Request log IDs in arrival order
+	RequestCount int      `json:"request_count"` // Number of requests in the group
+	ErrorCount   int      `json:"error_count"`   // Requests with status >= 400 or validation/response failures
+	StartTime    string   `json:"start_time"`    // Timestamp of the first request (RequestLogTimeFormat)
+	EndTime      string   `json:"end_time"`      // Timestamp of the last request (RequestLogTimeFormat)
+	DurationMs   int64    `json:"duration_ms"`   // Wall time from first request to last completion
+	TotalRTTMs   int64    `json:"total_rtt_ms"`  // Sum of client round-trip times
+	MaxRTTMs     int64    `json:"max_rtt_ms"`    // Slowest client round-trip time
+}
+
+// RetryAfterReport checks whether clients waited as long as the Retry-After headers they were sent
+type RetryAfterReport struct {
+	Advertised int                   `json:"advertised"` // Responses that carried Retry-After
+	Honored    int                   `json:"honored"`    // Followed by the client's next request only after the wait
+	Violated   int                   `json:"violated"`   // Followed by at least one request before the wait was over
+	NoRetry    int                   `json:"no_retry"`   // The client did not come back (yet)
+	Violations []RetryAfterViolation `json:"violations"` // One per violated Retry-After, in log order
+}
+
+// RetryAfterViolation is a client that came back before the Retry-After it was given had elapsed
+type RetryAfterViolation struct {
+	ClientIP      string `json:"client_ip"`
+	EndpointID    string `json:"endpoint_id,omitempty"`
+	Path          string `json:"path"`           // Path of the response that advertised the wait
+	AdvisedLogID  string `json:"advised_log_id"` // Request log of that response
+	StatusCode    int    `json:"status_code"`    // Its status (usually 429 or 503)
+	RetryAfterMs  int64  `json:"retry_after_ms"` // The advertised wait
+	RetryLogID    string `json:"retry_log_id"`   // Request log of the client's first early request
+	GapMs         int64  `json:"gap_ms"`         // Time between the advertising response and that request
+	EarlyRequests int    `json:"early_requests"` // Requests the client made before the wait was over
+}
+
 // DockerImageInfo contains metadata extracted from Docker image inspection
 type DockerImageInfo struct {
 	ImageName    string            `json:"image_name"`              // Full image name with tag
@@ -631,7 +2189,181 @@ type RecentFile struct {
 	Exists       bool      `json:"exists"`         // Whether file currently exists on disk
 }
 
+// ConfigBackup is a copy of a config taken when it was saved
+type ConfigBackup struct {
+	ID         string    `json:"id"`          // Backup file name (identifies the backup within its config)
+	ConfigPath string    `json:"config_path"` // Config file the backup was taken of
+	Created    time.Time `json:"created"`     // When the config was saved
+	Size       int64     `json:"size"`        // Size in bytes
+}
+
+// UndoStatus describes the undo history of endpoint and response edits
+type UndoStatus struct {
+	CanUndo   bool   `json:"can_undo"`
+	CanRedo   bool   `json:"can_redo"`
+	UndoLabel string `json:"undo_label,omitempty"` // Edit the next undo reverts (e.g., "Update endpoint")
+	RedoLabel string `json:"redo_label,omitempty"` // Edit the next redo applies again
+	UndoSteps int    `json:"undo_steps"`
+	RedoSteps int    `json:"redo_steps"`
+}
+
+// RecoveryInfo describes unsaved changes kept by autosave from an earlier session
+type RecoveryInfo struct {
+	ID         string    `json:"id"`                    // Recovery file name
+	Workspace  string    `json:"workspace"`             // Name of the workspace the changes were made in
+	ConfigPath string    `json:"config_path,omitempty"` // Config file the changes belong to (empty if never saved)
+	Saved      time.Time `json:"saved"`                 // When the changes were last autosaved
+	Endpoints  int       `json:"endpoints"`             // Number of endpoints in the recovered config
+}
+
 // RecentFiles contains the list of recent configuration files
 type RecentFiles struct {
 	Files []RecentFile `json:"files"`
-}
\ No newline at end of file
+}
+
+// Config storage backends
+const (
+	StorageBackendFile = "file" // Local file (default)
+	StorageBackendGit  = "git"  // Local file committed to the Git repository containing it
+	StorageBackendHTTP = "http" // Local file mirrored to an HTTP server (GET/PUT)
+	StorageBackendS3   = "s3"   // Local file mirrored to an S3-compatible bucket
+)
+
+// StorageSettings selects where configs are persisted. Stored in ~/.mockelot/storage.json
+// (not in the config file itself).
+type StorageSettings struct {
+	Backend string       `json:"backend"`        // "file", "git", "http", or "s3"
+	Git     *GitStorage  `json:"git,omitempty"`  // Git backend options
+	HTTP    *HTTPStorage `json:"http,omitempty"` // HTTP backend options
+	S3      *S3Storage   `json:"s3,omitempty"`   // S3 backend options
+}
+
+// WorkspaceInfo describes one of the mock server configurations open at the same time. Each
+// workspace has its own ports, endpoints, containers and request logs; the bindings act on the
+// active one, while the others keep serving in the background.
+type WorkspaceInfo struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Active     bool   `json:"active"`                // The workspace the bindings act on
+	Running    bool   `json:"running"`               // Its server is running
+	Port       int    `json:"port"`                  // HTTP port of its server
+	ConfigPath string `json:"config_path,omitempty"` // File the workspace was loaded from or saved to
+	Dirty      bool   `json:"dirty"`                 // Has unsaved changes
+}
+
+// DefaultAdminAPIPort is the admin API port used when none is configured
+const DefaultAdminAPIPort = 9091
+
+// AdminAPISettings configures the admin API, which exposes the app's bindings over HTTP for automation.
+// Settings are app-wide (saved in ~/.mockelot/admin-api.json), not part of a config file.
+type AdminAPISettings struct {
+	Enabled   bool   `json:"enabled"`             // Whether the admin listener runs
+	Port      int    `json:"port,omitempty"`      // Loopback TCP port (default: 9091)
+	Token     string `json:"token,omitempty"`     // Bearer token required on every call (generated when empty)
+	Profiling bool   `json:"profiling,omitempty"` // Serve net/http/pprof under /debug/pprof/ on the admin port
+}
+
+// RuntimeSample is one reading of the app's goroutine count and memory use
+type RuntimeSample struct {
+	Time        string `json:"time"`         // RFC3339 time of the reading
+	Goroutines  int    `json:"goroutines"`   // Live goroutines
+	HeapAlloc   uint64 `json:"heap_alloc"`   // Bytes of allocated heap objects
+	HeapObjects uint64 `json:"heap_objects"` // Allocated heap objects
+	Sys         uint64 `json:"sys"`          // Bytes obtained from the OS
+	NumGC       uint32 `json:"num_gc"`       // Completed GC cycles
+}
+
+// RuntimeWarning reports a metric that grew at every sample over the leak detection window
+type RuntimeWarning struct {
+	Time    string `json:"time"`    // RFC3339 time the growth was detected
+	Metric  string `json:"metric"`  // "goroutines" or "heap"
+	From    uint64 `json:"from"`    // Value at the start of the window
+	To      uint64 `json:"to"`      // Value at the end of the window
+	Window  string `json:"window"`  // Length of the window (e.g. "5m0s")
+	Message string `json:"message"` // Human-readable summary
+}
+
+// GoroutineGroup is a set of goroutines with the same stack
+type GoroutineGroup struct {
+	Count    int      `json:"count"`    // Goroutines with this stack
+	Function string   `json:"function"` // Innermost Mockelot function on the stack (or the goroutine's entry function)
+	Stack    []string `json:"stack"`    // Function names, innermost first
+}
+
+// RuntimeHealth is the leak detector's report
+type RuntimeHealth struct {
+	Current       RuntimeSample    `json:"current"`        // Reading taken for this report
+	Samples       []RuntimeSample  `json:"samples"`        // Periodic readings, oldest first
+	Warnings      []RuntimeWarning `json:"warnings"`       // Growth detected since the app started, oldest first
+	TopGoroutines []GoroutineGroup `json:"top_goroutines"` // Largest groups of goroutines sharing a stack
+	Profiling     bool             `json:"profiling"`      // Whether pprof is served on the admin port
+}
+
+// AppLogEntry is one entry of the application log
+type AppLogEntry struct {
+	Seq       int64             `json:"seq"`              // Increasing sequence number
+	Timestamp string            `json:"timestamp"`        // RFC3339 time with nanoseconds
+	Level     string            `json:"level"`            // DEBUG, INFO, WARN or ERROR
+	Subsystem string            `json:"subsystem"`        // app, server, proxy, container, socks5, dns or storage
+	Message   string            `json:"message"`          // Log message
+	Fields    map[string]string `json:"fields,omitempty"` // Structured context (e.g. endpoint_id)
+}
+
+// LogSettings configures the application log (persisted in ~/.mockelot/logging.json)
+type LogSettings struct {
+	Level      string `json:"level,omitempty"`       // Minimum level: debug, info, warn or error (default: info)
+	File       string `json:"file,omitempty"`        // Also append entries to this file as JSON lines
+	MaxSizeMB  int    `json:"max_size_mb,omitempty"` // Rotate the file at this size (default: 10)
+	MaxBackups int    `json:"max_backups,omitempty"` // Rotated files to keep (default: 5)
+}
+
+// GitStorage commits the config file to the Git repository that contains it on every save
+type GitStorage struct {
+	CommitMessage string `json:"commit_message,omitempty"` // Default commit message; "{file}" is replaced by the file name (default: "Update {file}")
+	PullOnLoad    bool   `json:"pull_on_load,omitempty"`   // Run "git pull --ff-only" before loading
+	Push          bool   `json:"push,omitempty"`           // Push after committing
+}
+
+// HTTPStorage mirrors configs to an HTTP server: saves PUT and loads GET <base_url>/<file name>
+type HTTPStorage struct {
+	BaseURL string            `json:"base_url"`          // Collection URL (e.g., "https://configs.example.com/mocks")
+	Headers map[string]string `json:"headers,omitempty"` // Extra request headers (e.g., Authorization)
+}
+
+// S3Storage mirrors configs to an S3-compatible bucket as <prefix><file name>
+type S3Storage struct {
+	Endpoint        string `json:"endpoint,omitempty"`      // Service URL (default: https://s3.<region>.amazonaws.com); set for MinIO etc.
+	Region          string `json:"region"`                  // Signing region (e.g., "us-east-1")
+	Bucket          string `json:"bucket"`                  // Bucket name
+	Prefix          string `json:"prefix,omitempty"`        // Key prefix (e.g., "mocks/")
+	AccessKeyID     string `json:"access_key_id"`           // Access key
+	SecretAccessKey string `json:"secret_access_key"`       // Secret key
+	SessionToken    string `json:"session_token,omitempty"` // Temporary credentials token
+}
+
+// MarketplaceSource is a registry of community endpoint bundles
+type MarketplaceSource struct {
+	Name string `json:"name" yaml:"name"`                     // Display name (unique within the config)
+	Type string `json:"type" yaml:"type"`                     // "http" or "git"
+	URL  string `json:"url" yaml:"url"`                       // Index URL (http) or repository URL (git)
+	Ref  string `json:"ref,omitempty" yaml:"ref,omitempty"`   // Branch or tag to check out (git only, default: remote HEAD)
+}
+
+// MarketplaceBundle describes an installable endpoint bundle listed in a registry index
+type MarketplaceBundle struct {
+	ID          string   `json:"id"`                    // Bundle identifier (unique within its source)
+	Name        string   `json:"name"`                  // Display name (e.g., "Stripe")
+	Description string   `json:"description,omitempty"` // Short description
+	Version     string   `json:"version,omitempty"`     // Bundle version
+	Tags        []string `json:"tags,omitempty"`        // Search tags (e.g., "payments")
+	Path        string   `json:"path"`                  // Bundle file location, relative to the index or absolute URL
+	SHA256      string   `json:"sha256"`                // Expected hex-encoded SHA-256 of the bundle file
+	Source      string   `json:"source"`                // Name of the MarketplaceSource that lists this bundle
+}
+
+// DockerImageSpec contains generated artifacts for running a config in the headless server image
+type DockerImageSpec struct {
+	ConfigPath string `json:"config_path"` // Config file bundled into the image
+	Dockerfile string `json:"dockerfile"`  // Dockerfile contents
+	Compose    string `json:"compose"`     // docker-compose snippet
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"mockelot/models"
+	"sort"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -107,6 +108,8 @@ func convertOperation(op OperationInfo) []models.MethodResponse {
 			Body:         body,
 			ResponseMode: responseMode,
 			ScriptBody:   scriptBody,
+
+			OpenAPIOperation: operationName(op),
 		}
 
 		// Add request validation for POST/PUT/PATCH methods
@@ -133,6 +136,14 @@ func convertOperation(op OperationInfo) []models.MethodResponse {
 			}
 		}
 
+		// Attach the request schemas for schema validation
+		if schemaValidation := generateSchemaValidation(op); schemaValidation != nil {
+			if methodResponse.RequestValidation == nil {
+				methodResponse.RequestValidation = &models.RequestValidation{Mode: models.ValidationModeNone}
+			}
+			methodResponse.RequestValidation.Schema = schemaValidation
+		}
+
 		responses = append(responses, methodResponse)
 	}
 
@@ -145,6 +156,11 @@ func convertOperation(op OperationInfo) []models.MethodResponse {
 	return responses
 }
 
+// operationName identifies an operation as "METHOD /path" (the spec's path syntax)
+func operationName(op OperationInfo) string {
+	return strings.ToUpper(op.Method) + " " + op.Path
+}
+
 // parseStatusCode converts OpenAPI status code string to int
 func parseStatusCode(statusStr string) int {
 	// Handle "default" or wildcard patterns
@@ -447,15 +463,16 @@ func generateSecurityResponses(op OperationInfo) []models.MethodResponse {
 	// Generate 401 Unauthorized response
 	enabled401 := false
 	response401 := models.MethodResponse{
-		ID:           uuid.New().String(),
-		Enabled:      &enabled401,
-		PathPattern:  pathPattern,
-		Methods:      []string{op.Method},
-		StatusCode:   401,
-		StatusText:   "Unauthorized - Missing or invalid authentication",
-		Headers:      map[string]string{"Content-Type": "application/json"},
-		Body:         `{"error": "Unauthorized", "message": "Authentication required"}`,
-		ResponseMode: models.ResponseModeStatic,
+		ID:               uuid.New().String(),
+		Enabled:          &enabled401,
+		PathPattern:      pathPattern,
+		Methods:          []string{op.Method},
+		StatusCode:       401,
+		StatusText:       "Unauthorized - Missing or invalid authentication",
+		Headers:          map[string]string{"Content-Type": "application/json"},
+		Body:             `{"error": "Unauthorized", "message": "Authentication required"}`,
+		ResponseMode:     models.ResponseModeStatic,
+		OpenAPIOperation: operationName(op),
 		RequestValidation: &models.RequestValidation{
 			Mode:   models.ValidationModeScript,
 			Script: generateAuthValidationScript(op),
@@ -466,15 +483,16 @@ func generateSecurityResponses(op OperationInfo) []models.MethodResponse {
 	// Generate 403 Forbidden response
 	enabled403 := false
 	response403 := models.MethodResponse{
-		ID:          uuid.New().String(),
-		Enabled:     &enabled403,
-		PathPattern: pathPattern,
-		Methods:     []string{op.Method},
-		StatusCode:  403,
-		StatusText:  "Forbidden - Insufficient permissions",
-		Headers:     map[string]string{"Content-Type": "application/json"},
-		Body:        `{"error": "Forbidden", "message": "Insufficient permissions"}`,
-		ResponseMode: models.ResponseModeStatic,
+		ID:               uuid.New().String(),
+		Enabled:          &enabled403,
+		PathPattern:      pathPattern,
+		Methods:          []string{op.Method},
+		StatusCode:       403,
+		StatusText:       "Forbidden - Insufficient permissions",
+		Headers:          map[string]string{"Content-Type": "application/json"},
+		Body:             `{"error": "Forbidden", "message": "Insufficient permissions"}`,
+		ResponseMode:     models.ResponseModeStatic,
+		OpenAPIOperation: operationName(op),
 	}
 	responses = append(responses, response403)
 
@@ -550,17 +568,18 @@ func generateResponseBody(op OperationInfo, response *openapi3.Response) (string
 
 	// Try to find application/json content first
 	var mediaType *openapi3.MediaType
-	for contentType, mt := range response.Content {
-		if strings.Contains(contentType, "application/json") {
-			mediaType = mt
+	var contentType string
+	for ct, mt := range response.Content {
+		if strings.Contains(ct, "application/json") {
+			mediaType, contentType = mt, ct
 			break
 		}
 	}
 
 	// If no JSON, use the first available content type
 	if mediaType == nil {
-		for _, mt := range response.Content {
-			mediaType = mt
+		for ct, mt := range response.Content {
+			mediaType, contentType = mt, ct
 			break
 		}
 	}
@@ -576,16 +595,40 @@ func generateResponseBody(op OperationInfo, response *openapi3.Response) (string
 		return exampleJSON, models.ResponseModeStatic, ""
 	}
 
+	// Then named examples (the first by name)
+	if len(mediaType.Examples) > 0 {
+		names := make([]string, 0, len(mediaType.Examples))
+		for name := range mediaType.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if example := mediaType.Examples[names[0]]; example != nil && example.Value != nil && example.Value.Value != nil {
+			exampleJSON, _ := convertToJSON(example.Value.Value)
+			return exampleJSON, models.ResponseModeStatic, ""
+		}
+	}
+
 	// Check schema.example
 	if mediaType.Schema != nil && mediaType.Schema.Value != nil && mediaType.Schema.Value.Example != nil {
 		exampleJSON, _ := convertToJSON(mediaType.Schema.Value.Example)
 		return exampleJSON, models.ResponseModeStatic, ""
 	}
 
-	// No example - generate script from schema
+	// No example - synthesize one from the schema. The randomized script is kept as well, so
+	// switching the response to script mode returns fresh fake data on every call.
 	if mediaType.Schema != nil && mediaType.Schema.Value != nil {
+		var pathParams []string
+		for _, param := range op.Parameters {
+			if param.Value != nil && param.Value.In == openapi3.ParameterInPath {
+				pathParams = append(pathParams, param.Value.Name)
+			}
+		}
+		body, templated := GenerateExampleBody(mediaType.Schema, contentType, pathParams)
 		script := GenerateMockScript(mediaType.Schema, op)
-		return "", models.ResponseModeScript, script
+		if templated {
+			return body, models.ResponseModeTemplate, script
+		}
+		return body, models.ResponseModeStatic, script
 	}
 
 	// No schema either - return empty response
@@ -0,0 +1,366 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Faker-style value pools for example bodies (picked in turn so list items differ)
+var (
+	exampleFirstNames = []string{"Alice", "Bob", "Carol", "David", "Emma", "Frank"}
+	exampleLastNames  = []string{"Johnson", "Smith", "Garcia", "Chen", "Müller", "Okafor"}
+	exampleCities     = []string{"Springfield", "Riverside", "Fairview", "Madison", "Georgetown"}
+	exampleCountries  = []string{"US", "GB", "DE", "FR", "JP"}
+	exampleStreets    = []string{"123 Main St", "42 Oak Avenue", "7 Elm Road", "900 Pine Lane"}
+	exampleWords      = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot"}
+	exampleSentences  = []string{
+		"A sample description generated from the API schema.",
+		"Another example value for this field.",
+		"Placeholder text for demonstration purposes.",
+	}
+)
+
+// exampleContext holds state while synthesizing an example body
+type exampleContext struct {
+	pathParams map[string]string        // Lower-cased path parameter name -> parameter name
+	visiting   map[*openapi3.Schema]int // Schemas on the current path (circular reference detection)
+	maxDepth   int                      // Below this depth only required properties are included
+	counter    int                      // Advances with every generated value so repeated fields vary
+	templated  bool                     // Whether the body uses template expressions
+}
+
+// GenerateExampleBody synthesizes an example body from a schema, respecting examples, defaults,
+// enums, formats and required fields. String properties named after a path parameter echo the
+// request's value and date-time fields use the current time, in which case the body is a response
+// template and templated is true. Non-JSON content types get plain string bodies unquoted.
+func GenerateExampleBody(schema *openapi3.SchemaRef, contentType string, pathParams []string) (body string, templated bool) {
+	if schema == nil || schema.Value == nil {
+		return "", false
+	}
+
+	ctx := &exampleContext{
+		pathParams: make(map[string]string, len(pathParams)),
+		visiting:   make(map[*openapi3.Schema]int),
+		maxDepth:   4,
+	}
+	for _, name := range pathParams {
+		ctx.pathParams[strings.ToLower(name)] = name
+	}
+
+	value := ctx.generate(schema.Value, "", 0)
+	if s, ok := value.(string); ok && !strings.Contains(contentType, "json") {
+		return s, ctx.templated
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", false
+	}
+	return string(data), ctx.templated
+}
+
+// generate produces an example value for a schema; name is the property name (for faker-style hints)
+func (ctx *exampleContext) generate(schema *openapi3.Schema, name string, depth int) interface{} {
+	// Priority 1: explicit example, default or enum
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if schema.Default != nil {
+		return schema.Default
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+
+	// Circular references: stop the second time a schema appears on the current path
+	if ctx.visiting[schema] > 0 {
+		return nil
+	}
+	ctx.visiting[schema]++
+	defer func() { ctx.visiting[schema]-- }()
+
+	// Priority 2: composition
+	if len(schema.AllOf) > 0 {
+		merged := map[string]interface{}{}
+		for _, part := range schema.AllOf {
+			if part.Value == nil {
+				continue
+			}
+			if object, ok := ctx.generate(part.Value, name, depth).(map[string]interface{}); ok {
+				for key, value := range object {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	}
+	for _, variants := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf} {
+		if len(variants) > 0 && variants[0].Value != nil {
+			return ctx.generate(variants[0].Value, name, depth)
+		}
+	}
+
+	// Priority 3: type (inferred from properties/items when missing)
+	typ := ""
+	if types := schema.Type.Slice(); len(types) > 0 {
+		typ = types[0]
+	} else if len(schema.Properties) > 0 {
+		typ = openapi3.TypeObject
+	} else if schema.Items != nil {
+		typ = openapi3.TypeArray
+	}
+
+	ctx.counter++
+	switch typ {
+	case openapi3.TypeObject:
+		return ctx.generateObject(schema, depth)
+	case openapi3.TypeArray:
+		return ctx.generateArray(schema, name, depth)
+	case openapi3.TypeString:
+		return ctx.generateString(schema, name)
+	case openapi3.TypeInteger:
+		return ctx.generateInteger(schema, name)
+	case openapi3.TypeNumber:
+		return ctx.generateNumber(schema, name)
+	case openapi3.TypeBoolean:
+		return true
+	default:
+		return nil
+	}
+}
+
+// generateObject includes every property near the top of the body and only required ones deeper down
+func (ctx *exampleContext) generateObject(schema *openapi3.Schema, depth int) interface{} {
+	object := map[string]interface{}{}
+
+	names := make([]string, 0, len(schema.Properties))
+	for propName := range schema.Properties {
+		names = append(names, propName)
+	}
+	sort.Strings(names)
+
+	for _, propName := range names {
+		propRef := schema.Properties[propName]
+		if propRef == nil || propRef.Value == nil {
+			continue
+		}
+		required := contains(schema.Required, propName)
+		if !required && depth >= ctx.maxDepth {
+			continue
+		}
+		value := ctx.generate(propRef.Value, propName, depth+1)
+		if value == nil && !required && !propRef.Value.Nullable {
+			continue // Circular reference
+		}
+		object[propName] = value
+	}
+
+	// Required fields without a property schema still need a value
+	for _, propName := range schema.Required {
+		if _, exists := object[propName]; !exists {
+			object[propName] = ctx.pick(exampleWords)
+		}
+	}
+
+	// Free-form maps get one sample entry
+	if len(schema.Properties) == 0 && schema.AdditionalProperties.Schema != nil && schema.AdditionalProperties.Schema.Value != nil {
+		object["key"] = ctx.generate(schema.AdditionalProperties.Schema.Value, "key", depth+1)
+	}
+
+	return object
+}
+
+// generateArray returns two items (within minItems/maxItems) so list responses look like lists
+func (ctx *exampleContext) generateArray(schema *openapi3.Schema, name string, depth int) interface{} {
+	count := 2
+	if int(schema.MinItems) > count {
+		count = int(schema.MinItems)
+	}
+	if schema.MaxItems != nil && int(*schema.MaxItems) < count {
+		count = int(*schema.MaxItems)
+	}
+
+	items := make([]interface{}, 0, count)
+	if schema.Items == nil || schema.Items.Value == nil {
+		return items
+	}
+	// List items describe other resources, so they do not echo path parameters
+	pathParams := ctx.pathParams
+	ctx.pathParams = nil
+	defer func() { ctx.pathParams = pathParams }()
+
+	for i := 0; i < count; i++ {
+		item := ctx.generate(schema.Items.Value, singular(name), depth+1)
+		if item == nil {
+			break // Circular reference
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// generateString picks a value by format, then by property name, then a generic word
+func (ctx *exampleContext) generateString(schema *openapi3.Schema, name string) interface{} {
+	lower := strings.ToLower(name)
+
+	// Echo path parameters back (e.g., GET /users/{id} returns the requested id)
+	if param, ok := ctx.pathParams[lower]; ok && param != "" {
+		ctx.templated = true
+		return "{{index .PathParams `" + param + "`}}"
+	}
+
+	n := ctx.counter
+	switch schema.Format {
+	case "date-time":
+		ctx.templated = true
+		return "{{now}}"
+	case "date":
+		return fmt.Sprintf("2024-01-%02d", n%28+1)
+	case "time":
+		return fmt.Sprintf("%02d:30:00", n%24)
+	case "email":
+		return ctx.email()
+	case "uuid":
+		return fmt.Sprintf("3fa85f64-5717-4562-b3fc-%012x", n)
+	case "uri", "url":
+		return fmt.Sprintf("https://example.com/resources/%d", n)
+	case "hostname":
+		return "api.example.com"
+	case "ipv4":
+		return fmt.Sprintf("192.0.2.%d", n%254+1)
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", n)
+	case "byte":
+		return "ZXhhbXBsZQ=="
+	case "password":
+		return "********"
+	}
+
+	var value string
+	switch {
+	case strings.Contains(lower, "email"):
+		value = ctx.email()
+	case lower == "firstname" || lower == "first_name" || lower == "givenname" || lower == "given_name":
+		value = ctx.pick(exampleFirstNames)
+	case lower == "lastname" || lower == "last_name" || lower == "surname" || lower == "familyname" || lower == "family_name":
+		value = ctx.pick(exampleLastNames)
+	case lower == "name" || lower == "fullname" || lower == "full_name" || lower == "username" || lower == "displayname":
+		value = ctx.pick(exampleFirstNames) + " " + ctx.pick(exampleLastNames)
+		if lower == "username" {
+			value = strings.ToLower(strings.ReplaceAll(value, " ", "."))
+		}
+	case strings.Contains(lower, "phone"):
+		value = fmt.Sprintf("+1-555-01%02d", n%100)
+	case strings.Contains(lower, "city"):
+		value = ctx.pick(exampleCities)
+	case strings.Contains(lower, "country"):
+		value = ctx.pick(exampleCountries)
+	case strings.Contains(lower, "street") || strings.Contains(lower, "address"):
+		value = ctx.pick(exampleStreets)
+	case strings.Contains(lower, "zip") || strings.Contains(lower, "postal"):
+		value = fmt.Sprintf("%05d", 10000+n*137%89999)
+	case strings.Contains(lower, "url") || strings.Contains(lower, "website") || strings.Contains(lower, "link"):
+		value = fmt.Sprintf("https://example.com/resources/%d", n)
+	case strings.Contains(lower, "description") || strings.Contains(lower, "summary") || strings.Contains(lower, "comment"):
+		value = ctx.pick(exampleSentences)
+	case lower == "title":
+		value = "Example " + ctx.pick(exampleWords)
+	case lower == "id" || strings.HasSuffix(lower, "_id") || strings.HasSuffix(name, "Id"):
+		value = fmt.Sprintf("%s-%d", strings.TrimSuffix(strings.TrimSuffix(lower, "_id"), "id"), 1000+n)
+		value = strings.TrimPrefix(value, "-")
+	default:
+		value = ctx.pick(exampleWords)
+	}
+
+	// Respect length constraints
+	for uint64(len(value)) < schema.MinLength {
+		value += "x"
+	}
+	if schema.MaxLength != nil && uint64(len(value)) > *schema.MaxLength {
+		value = value[:*schema.MaxLength]
+	}
+	return value
+}
+
+// generateInteger returns a value inside the schema's bounds, guided by the property name
+func (ctx *exampleContext) generateInteger(schema *openapi3.Schema, name string) interface{} {
+	lower := strings.ToLower(name)
+	value := float64(ctx.counter)
+	switch {
+	case lower == "id" || strings.HasSuffix(lower, "_id") || strings.HasSuffix(name, "Id"):
+		value = float64(1000 + ctx.counter)
+	case strings.Contains(lower, "page"):
+		value = 1
+	case lower == "age":
+		value = 30
+	case strings.Contains(lower, "year"):
+		value = 2024
+	case strings.Contains(lower, "count") || strings.Contains(lower, "total") || strings.Contains(lower, "quantity"):
+		value = 10
+	}
+	return int64(clampNumber(schema, value, true))
+}
+
+// generateNumber returns a price-like value inside the schema's bounds
+func (ctx *exampleContext) generateNumber(schema *openapi3.Schema, name string) interface{} {
+	value := 19.99 + float64(ctx.counter%10)
+	lower := strings.ToLower(name)
+	if strings.HasPrefix(lower, "lat") {
+		value = 40.7128
+	} else if strings.HasPrefix(lower, "lon") || strings.HasPrefix(lower, "lng") {
+		value = -74.006
+	}
+	return clampNumber(schema, value, false)
+}
+
+// clampNumber moves a value inside minimum/maximum (honoring exclusive bounds)
+func clampNumber(schema *openapi3.Schema, value float64, integer bool) float64 {
+	step := 0.01
+	if integer {
+		step = 1
+	}
+	if schema.Min != nil && (value < *schema.Min || (schema.ExclusiveMin && value <= *schema.Min)) {
+		value = *schema.Min
+		if integer {
+			value = math.Ceil(value)
+		}
+		if schema.ExclusiveMin {
+			value += step
+		}
+	}
+	if schema.Max != nil && (value > *schema.Max || (schema.ExclusiveMax && value >= *schema.Max)) {
+		value = *schema.Max
+		if integer {
+			value = math.Floor(value)
+		}
+		if schema.ExclusiveMax {
+			value -= step
+		}
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		value = math.Ceil(value / *schema.MultipleOf) * *schema.MultipleOf
+	}
+	return value
+}
+
+func (ctx *exampleContext) email() string {
+	return strings.ToLower(ctx.pick(exampleFirstNames)) + "@example.com"
+}
+
+// pick returns the next value from a pool
+func (ctx *exampleContext) pick(pool []string) string {
+	return pool[ctx.counter%len(pool)]
+}
+
+// singular turns a list property name into an item name (e.g., "users" -> "user") for name hints
+func singular(name string) string {
+	if strings.HasSuffix(name, "ies") {
+		return strings.TrimSuffix(name, "ies") + "y"
+	}
+	return strings.TrimSuffix(name, "s")
+}
@@ -0,0 +1,378 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mockelot/logger"
+	"mockelot/models"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+var openapiLog = logger.For(logger.SubsystemApp)
+
+// colonParamRegex matches :param path segments
+var colonParamRegex = regexp.MustCompile(`^:([A-Za-z0-9_]+)$`)
+
+// braceParamRegex matches {param} path segments
+var braceParamRegex = regexp.MustCompile(`^\{([A-Za-z0-9_]+)\}$`)
+
+// ExportSpec converts a mock endpoint's responses into an OpenAPI 3 document (format "json" or "yaml")
+// Regex and wildcard path patterns cannot be expressed as OpenAPI paths and are skipped
+func ExportSpec(endpoint *models.Endpoint, format string) ([]byte, error) {
+	spec := BuildSpec(endpoint)
+
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+	}
+	if format == "json" {
+		return data, nil
+	}
+	return jsonToYAML(data)
+}
+
+// BuildSpec builds an OpenAPI 3 document describing a mock endpoint's responses
+func BuildSpec(endpoint *models.Endpoint) *openapi3.T {
+	spec := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info: &openapi3.Info{
+			Title:   endpoint.Name,
+			Version: "1.0.0",
+		},
+		Paths: openapi3.NewPathsWithCapacity(0),
+	}
+	if spec.Info.Title == "" {
+		spec.Info.Title = "Mockelot API"
+	}
+
+	// Strip mode serves response paths below the prefix
+	if endpoint.TranslationMode == models.TranslationModeStrip && endpoint.PathPrefix != "" && endpoint.PathPrefix != "/" {
+		spec.Servers = openapi3.Servers{{URL: strings.TrimSuffix(endpoint.PathPrefix, "/")}}
+	}
+
+	var skipped []string
+	tags := make(map[string]bool)
+
+	addResponse := func(resp *models.MethodResponse, tag string) {
+		// Drafts are served from their published version
+		if resp.Draft {
+			if resp.Published == nil {
+				return
+			}
+			resp = resp.Published
+		}
+		if !resp.IsEnabled() {
+			return
+		}
+
+		path, params, ok := convertMockPath(resp.PathPattern)
+		if !ok {
+			skipped = append(skipped, resp.PathPattern)
+			return
+		}
+
+		pathItem := spec.Paths.Value(path)
+		if pathItem == nil {
+			pathItem = &openapi3.PathItem{}
+			for _, name := range params {
+				pathItem.Parameters = append(pathItem.Parameters, &openapi3.ParameterRef{
+					Value: openapi3.NewPathParameter(name).WithSchema(openapi3.NewStringSchema()),
+				})
+			}
+			spec.Paths.Set(path, pathItem)
+		}
+
+		for _, method := range resp.Methods {
+			method = strings.ToUpper(method)
+			operation := pathItem.GetOperation(method)
+			if operation == nil {
+				operation = openapi3.NewOperation()
+				operation.Responses = openapi3.NewResponsesWithCapacity(0)
+				if tag != "" {
+					operation.Tags = []string{tag}
+					tags[tag] = true
+				}
+				pathItem.SetOperation(method, operation)
+			}
+
+			// The first matching response wins, so earlier responses keep their status code
+			status := strconv.Itoa(resp.StatusCode)
+			if operation.Responses.Value(status) != nil {
+				continue
+			}
+			operation.Responses.Set(status, &openapi3.ResponseRef{Value: exportResponse(resp)})
+			addRequestExamples(operation, method, resp.Examples)
+		}
+	}
+
+	for _, item := range endpoint.Items {
+		if item.Type == "response" && item.Response != nil {
+			addResponse(item.Response, "")
+		} else if item.Type == "group" && item.Group != nil {
+			if item.Group.Enabled != nil && !*item.Group.Enabled {
+				continue
+			}
+			for i := range item.Group.Responses {
+				addResponse(&item.Group.Responses[i], item.Group.Name)
+			}
+		}
+	}
+
+	for _, tag := range sortedTagNames(tags) {
+		spec.Tags = append(spec.Tags, &openapi3.Tag{Name: tag})
+	}
+
+	if len(skipped) > 0 {
+		openapiLog.Info("OpenAPI export: skipped %d response(s) with regex or wildcard paths: %s", len(skipped), strings.Join(skipped, ", "))
+	}
+
+	return spec
+}
+
+// convertMockPath converts a mock path pattern to an OpenAPI path and its parameter names
+// Returns ok=false for regex and wildcard patterns
+func convertMockPath(pattern string) (string, []string, bool) {
+	if pattern == "" || strings.HasPrefix(pattern, "^") || strings.Contains(pattern, "(?") || strings.Contains(pattern, "*") {
+		return "", nil, false
+	}
+
+	var params []string
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if m := colonParamRegex.FindStringSubmatch(segment); m != nil {
+			segments[i] = "{" + m[1] + "}"
+			params = append(params, m[1])
+		} else if m := braceParamRegex.FindStringSubmatch(segment); m != nil {
+			params = append(params, m[1])
+		}
+	}
+
+	path := strings.Join(segments, "/")
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path, params, true
+}
+
+// exportResponse describes one mock response: headers, content type and example body
+func exportResponse(resp *models.MethodResponse) *openapi3.Response {
+	description := resp.StatusText
+	if description == "" {
+		description = http.StatusText(resp.StatusCode)
+	}
+	if description == "" {
+		description = "Response"
+	}
+
+	mode := resp.ResponseMode
+	if mode == "" {
+		mode = models.ResponseModeStatic
+	}
+	switch mode {
+	case models.ResponseModeTemplate:
+		description += " (generated from a template)"
+	case models.ResponseModeScript:
+		description += " (generated by a script)"
+	}
+	response := openapi3.NewResponse().WithDescription(description)
+
+	contentType := ""
+	for name, value := range resp.Headers {
+		if strings.EqualFold(name, "Content-Type") {
+			contentType = value
+			continue
+		}
+		if response.Headers == nil {
+			response.Headers = openapi3.Headers{}
+		}
+		header := &openapi3.Header{Parameter: openapi3.Parameter{Schema: openapi3.NewStringSchema().NewRef()}}
+		if !strings.Contains(value, "{{") {
+			header.Example = value
+		}
+		response.Headers[name] = &openapi3.HeaderRef{Value: header}
+	}
+
+	var example interface{}
+	switch mode {
+	case models.ResponseModeStatic:
+		if resp.Body == "" {
+			break
+		}
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(resp.Body), &parsed); err == nil {
+			example = parsed
+			if contentType == "" {
+				contentType = "application/json"
+			}
+		} else {
+			example = resp.Body
+			if contentType == "" {
+				contentType = "text/plain"
+			}
+		}
+	case models.ResponseModeTemplate:
+		if contentType == "" && resp.Body != "" {
+			contentType = "text/plain"
+		}
+	case models.ResponseModeScript:
+		if contentType == "" {
+			contentType = "application/json"
+		}
+	}
+
+	if contentType != "" {
+		// Parameters such as charset are not part of the media type key
+		mediaTypeKey := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+		mediaType := openapi3.NewMediaType()
+		if example != nil {
+			mediaType.Example = example
+			mediaType.Schema = inferSchema(example).NewRef()
+		}
+		response.Content = openapi3.Content{mediaTypeKey: mediaType}
+	}
+
+	// Exchanges harvested from traffic become named examples
+	for i, example := range resp.Examples {
+		if example.ResponseBody == "" {
+			continue
+		}
+		if response.Content == nil {
+			response.Content = openapi3.Content{}
+		}
+		addExample(response.Content, example.ResponseContentType, fmt.Sprintf("observed%d", i+1), exampleSummary(example), example.ResponseBody)
+	}
+
+	return response
+}
+
+// addRequestExamples documents the request bodies of harvested exchanges on an operation
+func addRequestExamples(operation *openapi3.Operation, method string, examples []models.TrafficExample) {
+	for i, example := range examples {
+		if example.RequestBody == "" || !strings.EqualFold(example.Method, method) {
+			continue
+		}
+		if operation.RequestBody == nil {
+			operation.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithContent(openapi3.Content{})}
+		}
+		requestBody := operation.RequestBody.Value
+		if requestBody.Content == nil {
+			requestBody.Content = openapi3.Content{}
+		}
+		addExample(requestBody.Content, example.RequestContentType, fmt.Sprintf("observed%d", i+1), exampleSummary(example), example.RequestBody)
+	}
+}
+
+// addExample adds a named example to the media type of contentType, creating it (with a schema
+// inferred from JSON bodies) if needed. A single configured example is moved into the named
+// examples, since OpenAPI does not allow both.
+func addExample(content openapi3.Content, contentType, name, summary, body string) {
+	mediaTypeKey := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	if mediaTypeKey == "" {
+		mediaTypeKey = "text/plain"
+	}
+
+	var value interface{} = body
+	var parsed interface{}
+	if strings.Contains(mediaTypeKey, "json") && json.Unmarshal([]byte(body), &parsed) == nil {
+		value = parsed
+	}
+
+	mediaType := content.Get(mediaTypeKey)
+	if mediaType == nil {
+		mediaType = openapi3.NewMediaType()
+		content[mediaTypeKey] = mediaType
+	}
+	if mediaType.Schema == nil && parsed != nil {
+		mediaType.Schema = inferSchema(parsed).NewRef()
+	}
+	if mediaType.Examples == nil {
+		mediaType.Examples = openapi3.Examples{}
+	}
+	if mediaType.Example != nil {
+		mediaType.Examples["configured"] = &openapi3.ExampleRef{Value: openapi3.NewExample(mediaType.Example)}
+		mediaType.Example = nil
+	}
+
+	example := openapi3.NewExample(value)
+	example.Summary = summary
+	mediaType.Examples[name] = &openapi3.ExampleRef{Value: example}
+}
+
+// exampleSummary describes where a harvested example came from
+func exampleSummary(example models.TrafficExample) string {
+	return fmt.Sprintf("Observed %s %s (%d) at %s", example.Method, example.Path, example.StatusCode, example.CapturedAt)
+}
+
+// inferSchema derives a schema from an example value
+func inferSchema(value interface{}) *openapi3.Schema {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		schema := openapi3.NewObjectSchema()
+		for key, field := range v {
+			schema.WithProperty(key, inferSchema(field))
+		}
+		return schema
+	case []interface{}:
+		items := openapi3.NewSchema()
+		if len(v) > 0 {
+			items = inferSchema(v[0])
+		}
+		return openapi3.NewArraySchema().WithItems(items)
+	case float64:
+		if v == float64(int64(v)) {
+			return openapi3.NewIntegerSchema()
+		}
+		return openapi3.NewFloat64Schema()
+	case bool:
+		return openapi3.NewBoolSchema()
+	case nil:
+		return openapi3.NewSchema().WithNullable()
+	default:
+		return openapi3.NewStringSchema()
+	}
+}
+
+// jsonToYAML re-encodes JSON as block-style YAML, keeping key order
+func jsonToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to convert OpenAPI spec to YAML: %w", err)
+	}
+	clearStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to convert OpenAPI spec to YAML: %w", err)
+	}
+	encoder.Close()
+	return buf.Bytes(), nil
+}
+
+// clearStyle drops the flow and quoting styles JSON input gives every node (the encoder still
+// quotes strings that would otherwise read back as another type)
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
+
+func sortedTagNames(tags map[string]bool) []string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
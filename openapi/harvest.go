@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+const (
+	// MaxExamplesPerResponse is how many harvested examples a response keeps (the newest ones)
+	MaxExamplesPerResponse = 3
+
+	// maxExampleBodySize keeps large payloads out of the config; bigger exchanges are not harvested
+	maxExampleBodySize = 64 * 1024
+)
+
+// HarvestExample records a logged exchange as an example of the OpenAPI-imported response it
+// exercised: the mock response that served it, or, for proxy and container endpoints, the
+// imported response of their snapshot mock endpoint with the same operation and status.
+// Returns true if an example was added.
+func HarvestExample(endpoints []models.Endpoint, log *models.RequestLog) bool {
+	if log.ClientResponse.StatusCode == nil || log.ResponseFailed || log.ValidationFailed || log.BodyTruncated {
+		return false
+	}
+	if len(log.ClientRequest.Body) > maxExampleBodySize || len(log.ClientResponse.Body) > maxExampleBodySize {
+		return false
+	}
+
+	resp := harvestTarget(endpoints, log)
+	if resp == nil {
+		return false
+	}
+
+	example := models.TrafficExample{
+		CapturedAt:          log.Timestamp,
+		Method:              log.ClientRequest.Method,
+		Path:                log.ClientRequest.Path,
+		RequestContentType:  headerValue(log.ClientRequest.Headers, "Content-Type"),
+		RequestBody:         log.ClientRequest.Body,
+		StatusCode:          *log.ClientResponse.StatusCode,
+		ResponseContentType: headerValue(log.ClientResponse.Headers, "Content-Type"),
+		ResponseBody:        log.ClientResponse.Body,
+	}
+	if example.CapturedAt == "" {
+		example.CapturedAt = time.Now().Format(time.RFC3339)
+	}
+
+	// The same exchange seen again adds nothing to the documentation
+	for _, existing := range resp.Examples {
+		if existing.RequestBody == example.RequestBody && existing.ResponseBody == example.ResponseBody && existing.StatusCode == example.StatusCode {
+			return false
+		}
+	}
+
+	examples := append(append([]models.TrafficExample{}, resp.Examples...), example)
+	if len(examples) > MaxExamplesPerResponse {
+		examples = examples[len(examples)-MaxExamplesPerResponse:]
+	}
+	resp.Examples = examples
+	return true
+}
+
+// harvestTarget finds the imported response a logged exchange belongs to
+func harvestTarget(endpoints []models.Endpoint, log *models.RequestLog) *models.MethodResponse {
+	// Mocked traffic names the response that served it
+	if log.Match != nil && log.Match.ResponseID != "" {
+		for i := range endpoints {
+			if resp := findResponse(endpoints[i].Items, log.Match.ResponseID); resp != nil {
+				if resp.OpenAPIOperation == "" {
+					return nil
+				}
+				return resp
+			}
+		}
+		return nil
+	}
+
+	// Proxied traffic is matched against the operations of the proxy's snapshot endpoint
+	path := log.ClientRequest.Path
+	if log.Match != nil && log.Match.TranslatedPath != "" {
+		path = log.Match.TranslatedPath
+	}
+	method := strings.ToUpper(log.ClientRequest.Method)
+	status := *log.ClientResponse.StatusCode
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		if endpoint.Type != models.EndpointTypeMock || endpoint.SnapshotOf == "" || endpoint.SnapshotOf != log.EndpointID {
+			continue
+		}
+		var target *models.MethodResponse
+		forEachResponse(endpoint.Items, func(resp *models.MethodResponse) {
+			if target != nil || resp.StatusCode != status {
+				return
+			}
+			opMethod, opPath, ok := strings.Cut(resp.OpenAPIOperation, " ")
+			if ok && opMethod == method && operationPathMatches(opPath, path) {
+				target = resp
+			}
+		})
+		if target != nil {
+			return target
+		}
+	}
+	return nil
+}
+
+// findResponse returns the response with the given ID among items and their groups
+func findResponse(items []models.ResponseItem, id string) *models.MethodResponse {
+	var found *models.MethodResponse
+	forEachResponse(items, func(resp *models.MethodResponse) {
+		if found == nil && resp.ID == id {
+			found = resp
+		}
+	})
+	return found
+}
+
+// forEachResponse calls fn for every response among items and their groups, in order
+func forEachResponse(items []models.ResponseItem, fn func(resp *models.MethodResponse)) {
+	for i := range items {
+		item := &items[i]
+		if item.Type == "response" && item.Response != nil {
+			fn(item.Response)
+		} else if item.Type == "group" && item.Group != nil {
+			for j := range item.Group.Responses {
+				fn(&item.Group.Responses[j])
+			}
+		}
+	}
+}
+
+// operationPathMatches reports whether a request path fits an OpenAPI path template
+func operationPathMatches(template, path string) bool {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateSegments) != len(pathSegments) {
+		return false
+	}
+	for i, segment := range templateSegments {
+		if braceParamRegex.MatchString(segment) {
+			if pathSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func headerValue(headers map[string][]string, name string) string {
+	for key, values := range headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+
+	"mockelot/models"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// generateSchemaValidation captures an operation's request body and parameter schemas so requests
+// can be validated against them. Violations are logged but not rejected until the user opts in.
+// Returns nil when the operation declares neither.
+func generateSchemaValidation(op OperationInfo) *models.SchemaValidation {
+	validation := &models.SchemaValidation{Enabled: true}
+
+	if shouldValidateRequest(op.Method) && op.Operation.RequestBody != nil && op.Operation.RequestBody.Value != nil {
+		requestBody := op.Operation.RequestBody.Value
+		validation.BodyRequired = requestBody.Required
+		for contentType, mediaType := range requestBody.Content {
+			if strings.Contains(contentType, "application/json") && mediaType != nil && mediaType.Schema != nil {
+				validation.Body = schemaJSON(mediaType.Schema)
+				break
+			}
+		}
+	}
+
+	for _, paramRef := range op.Parameters {
+		if paramRef == nil || paramRef.Value == nil {
+			continue
+		}
+		param := paramRef.Value
+		switch param.In {
+		case models.ParameterInQuery, models.ParameterInPath, models.ParameterInHeader:
+		default:
+			continue // Cookie parameters are not validated
+		}
+		parameter := models.ParameterSchema{
+			Name:     param.Name,
+			In:       param.In,
+			Required: param.Required,
+		}
+		if param.Schema != nil {
+			parameter.Schema = schemaJSON(param.Schema)
+		}
+		validation.Parameters = append(validation.Parameters, parameter)
+	}
+
+	if validation.Body == "" && !validation.BodyRequired && len(validation.Parameters) == 0 {
+		return nil
+	}
+	return validation
+}
+
+// schemaJSON encodes a schema with every $ref inlined, so it can be validated without the spec
+func schemaJSON(ref *openapi3.SchemaRef) string {
+	schema := inlineSchema(ref, make(map[*openapi3.Schema]bool))
+	if schema == nil {
+		return ""
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// inlineSchema copies a schema, replacing references with the schemas they point to. A schema that
+// refers back to itself is cut off with an empty schema (which accepts anything) at the repeat.
+func inlineSchema(ref *openapi3.SchemaRef, visiting map[*openapi3.Schema]bool) *openapi3.Schema {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	if visiting[ref.Value] {
+		return &openapi3.Schema{}
+	}
+	visiting[ref.Value] = true
+	defer delete(visiting, ref.Value)
+
+	schema := *ref.Value
+	inline := func(child *openapi3.SchemaRef) *openapi3.SchemaRef {
+		if child == nil {
+			return nil
+		}
+		return &openapi3.SchemaRef{Value: inlineSchema(child, visiting)}
+	}
+	inlineAll := func(children openapi3.SchemaRefs) openapi3.SchemaRefs {
+		if children == nil {
+			return nil
+		}
+		inlined := make(openapi3.SchemaRefs, len(children))
+		for i, child := range children {
+			inlined[i] = inline(child)
+		}
+		return inlined
+	}
+
+	schema.OneOf = inlineAll(schema.OneOf)
+	schema.AnyOf = inlineAll(schema.AnyOf)
+	schema.AllOf = inlineAll(schema.AllOf)
+	schema.Not = inline(schema.Not)
+	schema.Items = inline(schema.Items)
+	schema.AdditionalProperties.Schema = inline(schema.AdditionalProperties.Schema)
+	if schema.Properties != nil {
+		properties := make(openapi3.Schemas, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = inline(property)
+		}
+		schema.Properties = properties
+	}
+	return &schema
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"mockelot/models"
+)
+
+// autosaveInterval is how often unsaved changes are written to the recovery files
+const autosaveInterval = 5 * time.Second
+
+// recoveryFile is the content of a recovery file: the unsaved config of one workspace
+type recoveryFile struct {
+	Workspace  string             `yaml:"workspace"`
+	ConfigPath string             `yaml:"config_path,omitempty"`
+	Saved      time.Time          `yaml:"saved"`
+	Config     *models.UserConfig `yaml:"config"`
+}
+
+// recoveryDir returns ~/.mockelot/recovery, which holds one file per workspace with unsaved changes
+func recoveryDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine home directory: %v", err)
+	}
+	return filepath.Join(homeDir, ".mockelot", "recovery"), nil
+}
+
+// recoveryPath returns the path of a recovery file, rejecting IDs that are not plain file names
+func recoveryPath(id string) (string, error) {
+	if id == "" || filepath.Base(id) != id || filepath.Ext(id) != ".yaml" {
+		return "", fmt.Errorf("invalid recovery ID: %s", id)
+	}
+	dir, err := recoveryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, id), nil
+}
+
+// startAutosave writes unsaved changes to the recovery files every few seconds until shutdown
+func (a *App) startAutosave() {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.autosaveCancel = cancel
+	go func() {
+		ticker := time.NewTicker(autosaveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.autosave()
+			}
+		}
+	}()
+}
+
+// stopAutosave stops the autosave loop and writes the unsaved changes one last time, so changes
+// that were not saved before quitting are offered on the next start
+func (a *App) stopAutosave() {
+	if a.autosaveCancel == nil {
+		return
+	}
+	a.autosaveCancel()
+	a.autosaveCancel = nil
+	a.autosave()
+}
+
+// autosave writes a recovery file for each workspace with unsaved changes and removes the file of
+// each workspace without. Files are only rewritten when the changes changed.
+func (a *App) autosave() {
+	a.recoveryMutex.Lock()
+	defer a.recoveryMutex.Unlock()
+
+	dir, err := recoveryDir()
+	if err != nil {
+		appLog.Warn("Autosave skipped: %v", err)
+		return
+	}
+
+	a.workspaceMutex.RLock()
+	workspaces := append([]*workspace(nil), a.workspaces...)
+	active := a.activeWorkspace
+	a.workspaceMutex.RUnlock()
+
+	open := make(map[string]bool)
+	for _, ws := range workspaces {
+		id := ws.id + ".yaml"
+		open[id] = true
+		recovery := a.recoveryOf(ws, ws == active)
+		path := filepath.Join(dir, id)
+
+		if recovery == nil {
+			if _, written := a.recoverySums[id]; written {
+				os.Remove(path)
+				delete(a.recoverySums, id)
+			}
+			continue
+		}
+
+		data, err := yaml.Marshal(recovery.Config)
+		if err != nil {
+			appLog.Warn("Autosave of workspace %s failed: %v", ws.name, err)
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if a.recoverySums[id] == sum {
+			continue
+		}
+
+		recovery.Saved = time.Now()
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(recovery); err != nil {
+			appLog.Warn("Autosave of workspace %s failed: %v", ws.name, err)
+			continue
+		}
+		encoder.Close()
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			appLog.Warn("Autosave skipped: could not create %s: %v", dir, err)
+			return
+		}
+		// Write to a temporary file first, so a crash while writing keeps the previous copy
+		tmpPath := path + ".tmp"
+		if err := os.WriteFile(tmpPath, buf.Bytes(), 0600); err != nil {
+			appLog.Warn("Autosave of workspace %s failed: %v", ws.name, err)
+			continue
+		}
+		if err := os.Rename(tmpPath, path); err != nil {
+			appLog.Warn("Autosave of workspace %s failed: %v", ws.name, err)
+			continue
+		}
+		a.recoverySums[id] = sum
+		appLog.Debug("Autosaved unsaved changes of workspace %s", ws.name)
+	}
+
+	// Closed workspaces discarded their changes
+	for id := range a.recoverySums {
+		if !open[id] {
+			os.Remove(filepath.Join(dir, id))
+			delete(a.recoverySums, id)
+		}
+	}
+}
+
+// recoveryOf returns the unsaved changes of a workspace, or nil if it has none. A config that was
+// never saved counts as changed once it has an endpoint of its own.
+func (a *App) recoveryOf(ws *workspace, active bool) *recoveryFile {
+	var cfg, saved *models.AppConfig
+	var path string
+	if active {
+		a.configMutex.RLock()
+		defer a.configMutex.RUnlock()
+		cfg, saved, path = a.config, a.savedConfig, a.currentConfigPath
+	} else {
+		ws.mutex.Lock()
+		defer ws.mutex.Unlock()
+		cfg, saved, path = ws.state.config, ws.state.savedConfig, ws.state.currentConfigPath
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	if saved != nil {
+		if a.configsEqual(cfg, saved) {
+			return nil
+		}
+	} else {
+		hasEndpoint := false
+		for _, endpoint := range cfg.Endpoints {
+			if !endpoint.IsSystem {
+				hasEndpoint = true
+				break
+			}
+		}
+		if !hasEndpoint {
+			return nil
+		}
+	}
+
+	return &recoveryFile{
+		Workspace:  ws.name,
+		ConfigPath: path,
+		Config:     appConfigToUserConfig(cfg),
+	}
+}
+
+// readRecovery reads a recovery file
+func readRecovery(path string) (*recoveryFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("recovery file %s does not exist", filepath.Base(path))
+		}
+		return nil, fmt.Errorf("could not read recovery file: %v", err)
+	}
+	var recovery recoveryFile
+	if err := yaml.Unmarshal(data, &recovery); err != nil {
+		return nil, fmt.Errorf("could not decode recovery file: %v", err)
+	}
+	if recovery.Config == nil {
+		return nil, fmt.Errorf("recovery file %s holds no config", filepath.Base(path))
+	}
+	return &recovery, nil
+}
+
+// GetRecoveries returns the unsaved changes left by an earlier session, newest first, so they
+// can be restored after a crash. Changes to a config file that was saved after them are
+// outdated; they are removed and not returned.
+func (a *App) GetRecoveries() ([]models.RecoveryInfo, error) {
+	dir, err := recoveryDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []models.RecoveryInfo{}, nil
+		}
+		return nil, fmt.Errorf("could not read recovery files: %v", err)
+	}
+
+	// Files of the open workspaces belong to this session
+	open := make(map[string]bool)
+	a.workspaceMutex.RLock()
+	for _, ws := range a.workspaces {
+		open[ws.id+".yaml"] = true
+	}
+	a.workspaceMutex.RUnlock()
+
+	recoveries := []models.RecoveryInfo{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" || open[entry.Name()] {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		recovery, err := readRecovery(path)
+		if err != nil {
+			appLog.Warn("Skipping recovery file %s: %v", entry.Name(), err)
+			continue
+		}
+		if recovery.ConfigPath != "" {
+			if info, err := os.Stat(recovery.ConfigPath); err == nil && info.ModTime().After(recovery.Saved) {
+				appLog.Info("Removing outdated recovery file %s: %s was saved after it", entry.Name(), recovery.ConfigPath)
+				os.Remove(path)
+				continue
+			}
+		}
+		recoveries = append(recoveries, models.RecoveryInfo{
+			ID:         entry.Name(),
+			Workspace:  recovery.Workspace,
+			ConfigPath: recovery.ConfigPath,
+			Saved:      recovery.Saved,
+			Endpoints:  len(recovery.Config.Endpoints),
+		})
+	}
+	sort.Slice(recoveries, func(i, j int) bool {
+		return recoveries[i].Saved.After(recoveries[j].Saved)
+	})
+	return recoveries, nil
+}
+
+// RestoreRecovery loads unsaved changes from an earlier session into the active workspace. The
+// config file they belong to is loaded first, so the restored changes show as unsaved and can be
+// undone. The recovery file is removed.
+func (a *App) RestoreRecovery(id string) (*models.AppConfig, error) {
+	path, err := recoveryPath(id)
+	if err != nil {
+		return nil, err
+	}
+	recovery, err := readRecovery(path)
+	if err != nil {
+		return nil, err
+	}
+
+	configPath := recovery.ConfigPath
+	if configPath != "" {
+		if _, err := a.LoadConfigFromPath(configPath); err != nil {
+			appLog.Warn("Could not load %s before restoring unsaved changes: %v", configPath, err)
+			configPath = ""
+		}
+	}
+
+	edit := a.snapshotEdit("Restore unsaved changes")
+	if err := a.loadUnsavedConfig(recovery.Config, configPath); err != nil {
+		return nil, err
+	}
+	a.commitEdit(edit)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		appLog.Warn("Could not remove recovery file %s: %v", id, err)
+	}
+	appLog.Info("Restored unsaved changes of workspace %s from %s", recovery.Workspace, recovery.Saved.Format(time.RFC3339))
+	return a.config, nil
+}
+
+// DiscardRecovery removes unsaved changes left by an earlier session
+func (a *App) DiscardRecovery(id string) error {
+	path, err := recoveryPath(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove recovery file: %v", err)
+	}
+	return nil
+}
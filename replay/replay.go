@@ -0,0 +1,124 @@
+package replay
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+// Header added to replayed requests so they can be told apart in the request log
+const ReplayHeader = "X-Mockelot-Replay"
+
+const (
+	requestTimeout = 30 * time.Second
+	maxBodySize    = 10 * 1024 * 1024
+)
+
+// skipHeaders are managed by the HTTP client and not copied from the logged request
+var skipHeaders = map[string]bool{
+	"Host":              true,
+	"Content-Length":    true,
+	"Connection":        true,
+	"Transfer-Encoding": true,
+	"Accept-Encoding":   true,
+}
+
+// Replayer re-sends logged client requests
+type Replayer struct {
+	client *http.Client
+}
+
+// NewReplayer creates a replayer that does not follow redirects, so the raw response is compared
+func NewReplayer() *Replayer {
+	return &Replayer{
+		client: &http.Client{
+			Timeout: requestTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Replay sends a logged client request to targetBaseURL (scheme://host[:port][/prefix]), keeping its
+// method, path, query, headers and body, and compares the new response with the logged one
+func (r *Replayer) Replay(log *models.RequestLog, targetBaseURL string) models.ReplayResult {
+	result := models.ReplayResult{
+		LogID:           log.ID,
+		Method:          log.ClientRequest.Method,
+		OriginalStatus:  log.ClientResponse.StatusCode,
+		OriginalHeaders: log.ClientResponse.Headers,
+		OriginalBody:    log.ClientResponse.Body,
+	}
+
+	target, err := targetURL(log.ClientRequest.FullURL, targetBaseURL)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.URL = target
+
+	var body io.Reader
+	if log.ClientRequest.Body != "" {
+		body = strings.NewReader(log.ClientRequest.Body)
+	}
+	req, err := http.NewRequest(log.ClientRequest.Method, target, body)
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid request: %v", err)
+		return result
+	}
+	for name, values := range log.ClientRequest.Headers {
+		if skipHeaders[http.CanonicalHeaderKey(name)] {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	req.Header.Set(ReplayHeader, log.ID)
+
+	started := time.Now()
+	resp, err := r.client.Do(req)
+	if err != nil {
+		result.RTTMs = time.Since(started).Milliseconds()
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	result.RTTMs = time.Since(started).Milliseconds()
+	if err != nil {
+		result.Error = fmt.Sprintf("reading response: %v", err)
+	}
+
+	result.StatusCode = resp.StatusCode
+	result.StatusText = http.StatusText(resp.StatusCode)
+	result.Headers = resp.Header
+	result.Body = string(respBody)
+	result.StatusMatches = log.ClientResponse.StatusCode != nil && *log.ClientResponse.StatusCode == resp.StatusCode
+	result.BodyMatches = result.Body == log.ClientResponse.Body
+	return result
+}
+
+// targetURL moves a logged URL's path and query onto the target base URL
+func targetURL(loggedURL, targetBaseURL string) (string, error) {
+	base, err := url.Parse(targetBaseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return "", fmt.Errorf("invalid target URL %q: expected scheme://host[:port]", targetBaseURL)
+	}
+	logged, err := url.Parse(loggedURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid logged URL %q: %v", loggedURL, err)
+	}
+
+	base.RawPath = strings.TrimSuffix(base.EscapedPath(), "/") + logged.EscapedPath()
+	base.Path = strings.TrimSuffix(base.Path, "/") + logged.Path
+	base.RawQuery = logged.RawQuery
+	return base.String(), nil
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"mockelot/logger"
+	"mockelot/models"
+	"mockelot/server"
+)
+
+// runServe implements `mockelot serve`: it runs the HTTP/HTTPS/SOCKS5 servers and container
+// endpoints from a saved config file without the desktop UI. Returns the process exit code.
+func runServe(args []string) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	configPath := flags.String("config", "", "Path to the YAML config file or config directory (required)")
+	port := flags.Int("port", 0, "Override the HTTP port from the config")
+	profile := flags.String("profile", "", "Use the variables of this profile (overrides active_profile in the config)")
+	logFile := flags.String("log-file", "", "Append request logs to this file instead of stdout")
+	logFormat := flags.String("log-format", "text", "Request log format: text or json")
+	logLevel := flags.String("log-level", "info", "Application log level: debug, info, warn or error")
+	noContainers := flags.Bool("no-containers", false, "Do not start container endpoints")
+	adminPort := flags.Int("admin-port", 0, "Serve the admin API on this loopback port")
+	adminToken := flags.String("admin-token", os.Getenv("MOCKELOT_ADMIN_TOKEN"), "Admin API token (default: $MOCKELOT_ADMIN_TOKEN, or a random token that is logged)")
+	pprofEnabled := flags.Bool("pprof", false, "Serve net/http/pprof on the admin port (requires --admin-port)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *configPath == "" {
+		fmt.Fprintln(os.Stderr, "usage: mockelot serve --config config.yaml [--port 8080] [--log-file requests.log] [--log-format text|json] [--log-level info] [--no-containers] [--admin-port 9091 [--admin-token TOKEN] [--pprof]]")
+		return 2
+	}
+	level, err := logger.ParseLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	logger.Default.SetMinLevel(level)
+	if *pprofEnabled && *adminPort == 0 {
+		fmt.Fprintln(os.Stderr, "--pprof requires --admin-port")
+		return 2
+	}
+	if *logFormat != "text" && *logFormat != "json" {
+		fmt.Fprintf(os.Stderr, "invalid --log-format %q (expected text or json)\n", *logFormat)
+		return 2
+	}
+
+	userCfg, err := readUserConfigFile(*configPath)
+	if err != nil {
+		appLog.Error("Failed to load config %s: %v", *configPath, err)
+		return 1
+	}
+
+	// Build the runtime config the same way the desktop app does, including system endpoints
+	headless := NewApp()
+	headless.config = userConfigToAppConfig(userCfg, nil)
+	headless.currentConfigPath = *configPath
+	if *port != 0 {
+		headless.config.Port = *port
+	}
+	if *profile != "" {
+		headless.config.ActiveProfile = *profile
+	}
+	headless.ensureDisplayOrder()
+	headless.ensureDomainTakeoverEndpoints()
+	headless.ensureSOCKS5ProxyEndpoint()
+	headless.ensureRejectionsEndpoint()
+	cfg := headless.config
+
+	if err := server.ValidateServerLimits(cfg.Limits); err != nil {
+		appLog.Error("Invalid limits in %s: %v", *configPath, err)
+		return 1
+	}
+	if errs := server.ValidatePatterns(cfg); len(errs) > 0 {
+		for _, pe := range errs {
+			appLog.Error("Invalid pattern in %s: %s", *configPath, pe.String())
+		}
+		return 1
+	}
+	for _, w := range server.ValidateScripts(cfg) {
+		appLog.Warn("Script warning: %s", w.String())
+	}
+
+	out := io.Writer(os.Stdout)
+	if *logFile != "" {
+		file, err := os.OpenFile(*logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			appLog.Error("Failed to open log file %s: %v", *logFile, err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+	}
+	logger := &headlessLogger{out: out, json: *logFormat == "json"}
+
+	if *adminPort != 0 {
+		// The admin API drives the app the way the desktop UI does, so the server runs through the
+		// app, which keeps the request logs for it and passes logs and events on to the stream
+		headless.headlessLog = logger
+		if err := headless.StartServer(cfg.Port); err != nil {
+			appLog.Error("Failed to start server: %v", err)
+			return 1
+		}
+	} else {
+		proxyHandler := server.NewProxyHandler(logger)
+		containerHandler := server.NewContainerHandler(logger, logger, proxyHandler, headless, headless)
+		headless.server = server.NewHTTPServer(cfg, logger, logger, logger, containerHandler, proxyHandler)
+		if err := headless.server.Start(); err != nil {
+			appLog.Error("Failed to start server: %v", err)
+			return 1
+		}
+	}
+	appLog.Info("Serving %s (%d endpoints) on port %d", *configPath, len(cfg.Endpoints), cfg.Port)
+
+	if !*noContainers {
+		if err := headless.server.StartContainers(); err != nil {
+			appLog.Error("Error starting containers: %v", err)
+		}
+	}
+
+	if *adminPort != 0 {
+		if err := startHeadlessAdminAPI(headless, *adminPort, *adminToken, *pprofEnabled); err != nil {
+			appLog.Error("Failed to start admin API: %v", err)
+			headless.server.Stop()
+			return 1
+		}
+	}
+
+	headless.leakWatcher.Start()
+	defer headless.leakWatcher.Stop()
+
+	// Run until interrupted
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	sig := <-signals
+	appLog.Info("Received %s, shutting down", sig)
+
+	headless.stopAdminAPI()
+	if headless.server == nil {
+		// Already stopped over the admin API
+		return 0
+	}
+	if err := headless.server.Stop(); err != nil {
+		appLog.Error("Error stopping server: %v", err)
+		return 1
+	}
+	return 0
+}
+
+// startHeadlessAdminAPI serves the admin API for a headless app, generating a token if none is given
+func startHeadlessAdminAPI(headless *App, port int, token string, profiling bool) error {
+	if token == "" {
+		generated, err := generateAdminToken()
+		if err != nil {
+			return err
+		}
+		token = generated
+		appLog.Info("Admin API token: %s", token)
+	}
+
+	headless.adminMutex.Lock()
+	defer headless.adminMutex.Unlock()
+	headless.adminSettings = models.AdminAPISettings{Enabled: true, Port: port, Token: token, Profiling: profiling}
+	return headless.startAdminAPI()
+}
+
+// headlessLogger writes request logs, script errors and container events to a stream
+type headlessLogger struct {
+	mutex sync.Mutex
+	out   io.Writer
+	json  bool
+}
+
+// LogRequest implements server.RequestLogger (pending proxy requests are written once completed)
+func (l *headlessLogger) LogRequest(entry models.RequestLog) {
+	if entry.ClientResponse.StatusCode == nil && !entry.ValidationFailed && !entry.ResponseFailed {
+		return
+	}
+	l.writeRequest(entry)
+}
+
+// UpdateRequestLog implements server.RequestLogger
+func (l *headlessLogger) UpdateRequestLog(entry models.RequestLog) {
+	l.writeRequest(entry)
+}
+
+// LogScriptError implements server.ScriptErrorLogger
+func (l *headlessLogger) LogScriptError(responseID, path, method, errorMsg string) {
+	appLog.Error("Script error in response %s (%s %s): %s", responseID, method, path, errorMsg)
+}
+
+// SendEvent implements server.EventSender
+func (l *headlessLogger) SendEvent(source string, data interface{}) {
+	if progress, ok := data.(models.ContainerStartProgress); ok {
+		appLog.Info("[%s] %s: %s", source, progress.EndpointID, progress.Message)
+	}
+}
+
+func (l *headlessLogger) writeRequest(entry models.RequestLog) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.json {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			appLog.Error("Failed to encode request log: %v", err)
+			return
+		}
+		fmt.Fprintln(l.out, string(data))
+		return
+	}
+
+	status := "-"
+	if entry.ClientResponse.StatusCode != nil {
+		status = fmt.Sprintf("%d", *entry.ClientResponse.StatusCode)
+	}
+	rtt := "-"
+	if entry.ClientResponse.RTTMs != nil {
+		rtt = fmt.Sprintf("%dms", *entry.ClientResponse.RTTMs)
+	}
+	flags := ""
+	if entry.ValidationFailed {
+		flags += " [validation failed]"
+	}
+	if entry.ResponseFailed {
+		flags += " [response failed]"
+	}
+	if entry.Assertion != nil && !entry.Assertion.Passed {
+		flags += " [assertion failed: " + entry.Assertion.Message + "]"
+	}
+	if info := entry.SOCKS5Info; info != nil && entry.ClientRequest.Method != "CONNECT" {
+		if info.IsIntercepted {
+			flags += fmt.Sprintf(" [intercepted %s:%d]", info.TargetHost, info.TargetPort)
+		} else {
+			flags += fmt.Sprintf(" [via SOCKS5 %s:%d]", info.TargetHost, info.TargetPort)
+		}
+	}
+	fmt.Fprintf(l.out, "%s %s %s %s %s %s%s\n", entry.Timestamp, entry.ClientRequest.SourceIP,
+		entry.ClientRequest.Method, entry.ClientRequest.FullURL, status, rtt, flags)
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+// maxDocsExampleSize keeps huge bodies from bloating the docs page
+const maxDocsExampleSize = 4096
+
+// docsPage is the data behind the API docs page
+type docsPage struct {
+	Generated string
+	Endpoints []docsEndpoint
+}
+
+// docsEndpoint is one active endpoint on the docs page
+type docsEndpoint struct {
+	Name       string
+	Type       string
+	PathPrefix string
+	Serving    string // Where the endpoint is reachable, when not on the shared ports
+	Note       string // What serves requests, for endpoints without response rules
+	Operations []docsOperation
+}
+
+// docsOperation is one response rule of a mock endpoint
+type docsOperation struct {
+	Version     string
+	Group       string
+	Methods     string
+	Path        string
+	Translated  bool // Path is matched after translation, not as sent by clients
+	Status      int
+	ContentType string
+	Mode        string
+	Example     string
+	ExampleNote string
+}
+
+var docsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Mock API</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, sans-serif; margin: 2rem auto; max-width: 72rem; padding: 0 1rem; color: #1f2937; }
+h1 { margin-bottom: 0.25rem; }
+.generated { color: #6b7280; font-size: 0.875rem; }
+nav ul { columns: 2; padding-left: 1.25rem; }
+section { border-top: 1px solid #e5e7eb; margin-top: 2rem; padding-top: 1rem; }
+.type { background: #e0e7ff; border-radius: 0.25rem; font-size: 0.75rem; margin-left: 0.5rem; padding: 0.1rem 0.4rem; text-transform: uppercase; vertical-align: middle; }
+.meta { color: #4b5563; }
+table { border-collapse: collapse; margin-top: 0.75rem; width: 100%; }
+th, td { border-bottom: 1px solid #f3f4f6; padding: 0.4rem 0.5rem; text-align: left; vertical-align: top; }
+th { background: #f9fafb; font-size: 0.8rem; }
+code { font-family: ui-monospace, Menlo, monospace; font-size: 0.85rem; }
+.method { font-weight: 600; }
+.note { color: #6b7280; font-style: italic; }
+details pre { background: #f9fafb; max-height: 20rem; overflow: auto; padding: 0.5rem; white-space: pre-wrap; word-break: break-all; }
+</style>
+</head>
+<body>
+<h1>Mock API</h1>
+<div class="generated">Generated from the live configuration at {{.Generated}}</div>
+{{if not .Endpoints}}<p class="note">No endpoints are active.</p>{{else}}
+<nav><ul>{{range $i, $e := .Endpoints}}<li><a href="#endpoint-{{$i}}">{{$e.Name}}</a> <code>{{$e.PathPrefix}}</code></li>{{end}}</ul></nav>
+{{end}}
+{{range $i, $e := .Endpoints}}
+<section id="endpoint-{{$i}}">
+<h2>{{$e.Name}}<span class="type">{{$e.Type}}</span></h2>
+<div class="meta">Path prefix <code>{{$e.PathPrefix}}</code>{{if $e.Serving}} &middot; {{$e.Serving}}{{end}}</div>
+{{if $e.Note}}<p class="note">{{$e.Note}}</p>{{end}}
+{{if $e.Operations}}
+<table>
+<tr><th>Method</th><th>Path</th><th>Status</th><th>Content type</th><th>Example response</th></tr>
+{{range $e.Operations}}
+<tr>
+<td class="method">{{.Methods}}</td>
+<td>{{if .Version}}<span class="type">{{.Version}}</span> {{end}}<code>{{.Path}}</code>{{if .Translated}} <span class="note">(after translation)</span>{{end}}{{if .Group}}<div class="note">{{.Group}}</div>{{end}}</td>
+<td>{{.Status}}</td>
+<td><code>{{.ContentType}}</code></td>
+<td>{{if .Example}}<details><summary>{{.Mode}}</summary><pre>{{.Example}}</pre></details>{{end}}{{if .ExampleNote}}<span class="note">{{.ExampleNote}}</span>{{end}}</td>
+</tr>
+{{end}}
+</table>
+{{end}}
+</section>
+{{end}}
+</body>
+</html>
+`))
+
+// serveAPIDocs renders the browsable documentation of the active endpoints
+func (h *ResponseHandler) serveAPIDocs(w http.ResponseWriter) {
+	page := h.buildDocsPage()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := docsTemplate.Execute(w, page); err != nil {
+		serverLog.Error("Failed to render API docs: %v", err)
+	}
+}
+
+// buildDocsPage collects the enabled endpoints and the responses they currently serve
+func (h *ResponseHandler) buildDocsPage() docsPage {
+	page := docsPage{Generated: time.Now().Format(time.RFC1123)}
+
+	h.configMutex.RLock()
+	defer h.configMutex.RUnlock()
+
+	endpoints := make([]*models.Endpoint, 0, len(h.config.Endpoints))
+	for i := range h.config.Endpoints {
+		endpoint := &h.config.Endpoints[i]
+		if !endpoint.IsSystem && h.scheduler.endpointEnabled(endpoint) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	sort.SliceStable(endpoints, func(i, j int) bool { return endpoints[i].DisplayOrder < endpoints[j].DisplayOrder })
+
+	for _, endpoint := range endpoints {
+		entry := docsEndpoint{
+			Name:       endpoint.Name,
+			Type:       endpoint.Type,
+			PathPrefix: endpoint.PathPrefix,
+		}
+		if endpoint.Listener != "" {
+			entry.Serving = "listener " + endpoint.Listener
+		} else if endpoint.ListenPort != 0 {
+			entry.Serving = "port " + strconv.Itoa(endpoint.ListenPort)
+		}
+
+		switch endpoint.Type {
+		case models.EndpointTypeMock:
+			entry.Operations = h.docsOperations(endpoint, "", endpoint.Items)
+			if endpoint.Versioning != nil {
+				for _, version := range endpoint.Versioning.Versions {
+					entry.Operations = append(entry.Operations, h.docsOperations(endpoint, version.Version, version.Items)...)
+				}
+			}
+			if len(entry.Operations) == 0 {
+				entry.Note = "No responses are enabled."
+			}
+		case models.EndpointTypeProxy:
+			entry.Note = "Requests under this prefix are forwarded to a backend service."
+		case models.EndpointTypeContainer:
+			entry.Note = "Requests under this prefix are served by a container."
+		default:
+			entry.Note = "Requests under this prefix are served by the " + endpoint.Type + " endpoint type."
+		}
+		page.Endpoints = append(page.Endpoints, entry)
+	}
+	return page
+}
+
+// docsOperations lists the enabled responses among a mock endpoint's items (caller holds configMutex)
+func (h *ResponseHandler) docsOperations(endpoint *models.Endpoint, version string, items []models.ResponseItem) []docsOperation {
+	var operations []docsOperation
+	for _, item := range h.scheduler.scheduledItems(publishedItems(items)) {
+		switch {
+		case item.Type == "response" && item.Response != nil:
+			if item.Response.IsEnabled() {
+				operations = append(operations, docsOperationFor(endpoint, version, "", *item.Response))
+			}
+		case item.Type == "group" && item.Group != nil && item.Group.IsEnabled():
+			for _, resp := range item.Group.Responses {
+				if resp.IsEnabled() {
+					effective := models.ApplyResponseDefaults(resp, item.Group.Defaults)
+					operations = append(operations, docsOperationFor(endpoint, version, item.Group.Name, effective))
+				}
+			}
+		}
+	}
+	return operations
+}
+
+// docsOperationFor describes one response rule
+func docsOperationFor(endpoint *models.Endpoint, version, group string, resp models.MethodResponse) docsOperation {
+	op := docsOperation{
+		Version: version,
+		Group:   group,
+		Methods: strings.Join(resp.Methods, ", "),
+		Status:  resp.StatusCode,
+		Mode:    "static",
+	}
+	op.Path, op.Translated = docsPath(endpoint, version, resp.PathPattern)
+	for name, value := range resp.Headers {
+		if strings.EqualFold(name, "Content-Type") {
+			op.ContentType = value
+		}
+	}
+
+	switch {
+	case len(resp.Sequence) > 0:
+		op.ExampleNote = "Sequence of " + strconv.Itoa(len(resp.Sequence)) + " responses"
+	case resp.EventStream != "":
+		op.ExampleNote = "Server-Sent Events stream"
+	case resp.Generator != nil:
+		op.ExampleNote = "Generated body"
+	case resp.ResponseMode == models.ResponseModeScript:
+		op.ExampleNote = "Computed by a script"
+	case resp.BodyBase64:
+		op.ExampleNote = "Binary body"
+	default:
+		if resp.ResponseMode == models.ResponseModeTemplate {
+			op.Mode = "template"
+		}
+		op.Example = resp.Body
+	}
+
+	// A real exchange captured from traffic beats a template or a missing body
+	if len(resp.Examples) > 0 && (op.Example == "" || op.Mode == "template") {
+		example := resp.Examples[len(resp.Examples)-1]
+		op.Mode = "captured example"
+		op.Example = example.ResponseBody
+		op.ExampleNote = ""
+		if op.ContentType == "" {
+			op.ContentType = example.ResponseContentType
+		}
+	}
+	if len(op.Example) > maxDocsExampleSize {
+		op.Example = strings.ToValidUTF8(op.Example[:maxDocsExampleSize], "") + "\n…"
+	}
+	return op
+}
+
+// docsPath returns the path clients call for a response pattern, and whether the pattern only
+// makes sense after the endpoint's path translation (regex prefixes and translate mode)
+func docsPath(endpoint *models.Endpoint, version, pattern string) (string, bool) {
+	if endpoint.Versioning != nil && endpoint.Versioning.Source == models.VersionSourcePath && version != "" {
+		pattern = "/" + version + pattern
+	}
+	switch endpoint.TranslationMode {
+	case models.TranslationModeStrip:
+		if strings.HasPrefix(endpoint.PathPrefix, "^") || strings.HasPrefix(pattern, "^") {
+			return pattern, true
+		}
+		return strings.TrimSuffix(endpoint.PathPrefix, "/") + pattern, false
+	case models.TranslationModeTranslate:
+		return pattern, true
+	}
+	return pattern, false
+}
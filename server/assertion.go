@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"mockelot/models"
+)
+
+// RunAssertionScript evaluates a proxy assertion script against a backend response.
+//
+// The script sees a read-only `response` object (status, statusText, headers, body, json),
+// an `assert(condition, message)` helper and `jsonpath(expr)` for querying the JSON body.
+// The assertion fails if any assert() fails, the script returns false, or it throws.
+func RunAssertionScript(script string, statusCode int, headers map[string][]string, body string) *models.AssertionResult {
+	vm := goja.New()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resultChan := make(chan *models.AssertionResult, 1)
+	go func() {
+		resultChan <- runAssertionScript(vm, script, statusCode, headers, body)
+	}()
+
+	select {
+	case result := <-resultChan:
+		return result
+	case <-ctx.Done():
+		vm.Interrupt("assertion script timeout")
+		return &models.AssertionResult{Passed: false, Message: "assertion script timeout (5s limit)"}
+	}
+}
+
+func runAssertionScript(vm *goja.Runtime, script string, statusCode int, headers map[string][]string, body string) *models.AssertionResult {
+	// Headers are exposed with lower-cased names and their first value
+	headerMap := make(map[string]interface{}, len(headers))
+	for name, values := range headers {
+		if len(values) > 0 {
+			headerMap[strings.ToLower(name)] = values[0]
+		}
+	}
+
+	var parsedJSON interface{}
+	if err := json.Unmarshal([]byte(body), &parsedJSON); err != nil {
+		parsedJSON = nil
+	}
+
+	vm.Set("response", map[string]interface{}{
+		"status":     statusCode,
+		"statusText": http.StatusText(statusCode),
+		"headers":    headerMap,
+		"body":       body,
+		"json":       parsedJSON,
+	})
+
+	var failures []string
+	passedCount := 0
+	vm.Set("assert", func(condition bool, message string) bool {
+		if condition {
+			passedCount++
+		} else {
+			if message == "" {
+				message = "assertion failed"
+			}
+			failures = append(failures, message)
+		}
+		return condition
+	})
+
+	vm.Set("jsonpath", func(expr string) interface{} {
+		value, ok := evalJSONPath(parsedJSON, expr)
+		if !ok {
+			return nil
+		}
+		return value
+	})
+
+	vm.Set("console", map[string]interface{}{
+		"log": func(args ...interface{}) {},
+	})
+
+	value, err := vm.RunString(script)
+	if err != nil {
+		if jsErr, ok := err.(*goja.Exception); ok {
+			return &models.AssertionResult{Passed: false, Message: fmt.Sprintf("script error: %s", jsErr.Value().String())}
+		}
+		return &models.AssertionResult{Passed: false, Message: fmt.Sprintf("script error: %v", err)}
+	}
+
+	if len(failures) > 0 {
+		return &models.AssertionResult{Passed: false, Message: strings.Join(failures, "; ")}
+	}
+
+	// A script may also signal failure by returning false
+	if value != nil && !goja.IsUndefined(value) && !goja.IsNull(value) {
+		if result, ok := value.Export().(bool); ok && !result {
+			return &models.AssertionResult{Passed: false, Message: "script returned false"}
+		}
+	}
+
+	return &models.AssertionResult{Passed: true, Message: fmt.Sprintf("%d assertion(s) passed", passedCount)}
+}
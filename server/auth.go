@@ -0,0 +1,371 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+const (
+	defaultAPIKeyHeader = "X-API-Key"
+	defaultAuthRealm    = "mockelot"
+)
+
+// authenticate checks a request against its endpoint's auth config and records the outcome in the
+// match info. Refused requests are answered with a 401 or 403 and logged; it returns false for them.
+func (h *ResponseHandler) authenticate(w http.ResponseWriter, r *http.Request, endpoint *models.Endpoint, bodyBytes []byte) bool {
+	auth := endpoint.Auth
+	if auth == nil || !auth.Enabled {
+		return true
+	}
+	// Browsers send CORS preflights without credentials
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		explain(r, "Auth skipped for CORS preflight")
+		return true
+	}
+
+	outcome := h.checkAuth(auth, r)
+	updateMatchInfo(r, func(info *models.MatchInfo) {
+		info.Auth = &outcome
+	})
+	if outcome.Result == models.AuthAllowed {
+		explain(r, "Auth: %s accepted %s", outcome.Method, outcome.Principal)
+		return true
+	}
+	explain(r, "Auth: %s (%s)", outcome.Result, outcome.Reason)
+	h.refuseAuth(w, r, endpoint.ID, auth, outcome, bodyBytes)
+	return false
+}
+
+// checkAuth tries the credentials of a request against each configured method. Without any
+// credentials the request is unauthorized; a valid JWT lacking required claims is forbidden.
+func (h *ResponseHandler) checkAuth(auth *models.EndpointAuth, r *http.Request) models.AuthOutcome {
+	var failures []models.AuthOutcome
+
+	if len(auth.APIKeys) > 0 {
+		if key := requestAPIKey(auth, r); key != "" {
+			if containsSecret(auth.APIKeys, key) {
+				return models.AuthOutcome{Result: models.AuthAllowed, Method: "api_key", Principal: maskSecret(key)}
+			}
+			failures = append(failures, models.AuthOutcome{Result: models.AuthUnauthorized, Method: "api_key", Reason: "unknown API key"})
+		}
+	}
+
+	scheme, credentials, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	credentials = strings.TrimSpace(credentials)
+	switch {
+	case strings.EqualFold(scheme, "Basic") && len(auth.BasicUsers) > 0:
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			failures = append(failures, models.AuthOutcome{Result: models.AuthUnauthorized, Method: "basic", Reason: "malformed Basic credentials"})
+			break
+		}
+		for _, user := range auth.BasicUsers {
+			if secretEqual(user.Username, username) && secretEqual(user.Password, password) {
+				return models.AuthOutcome{Result: models.AuthAllowed, Method: "basic", Principal: username}
+			}
+		}
+		failures = append(failures, models.AuthOutcome{Result: models.AuthUnauthorized, Method: "basic", Principal: username, Reason: "wrong username or password"})
+	case strings.EqualFold(scheme, "Bearer") && (len(auth.BearerTokens) > 0 || auth.JWT != nil):
+		if containsSecret(auth.BearerTokens, credentials) {
+			return models.AuthOutcome{Result: models.AuthAllowed, Method: "bearer", Principal: maskSecret(credentials)}
+		}
+		if auth.JWT == nil {
+			failures = append(failures, models.AuthOutcome{Result: models.AuthUnauthorized, Method: "bearer", Reason: "unknown bearer token"})
+			break
+		}
+		outcome := h.checkJWT(auth.JWT, credentials)
+		if outcome.Result == models.AuthAllowed {
+			return outcome
+		}
+		failures = append(failures, outcome)
+	}
+
+	if len(failures) == 0 {
+		return models.AuthOutcome{Result: models.AuthUnauthorized, Reason: "no credentials"}
+	}
+	for _, failure := range failures {
+		if failure.Result == models.AuthForbidden {
+			return failure
+		}
+	}
+	return failures[0]
+}
+
+// checkJWT verifies a bearer JWT's algorithm, signature and claims
+func (h *ResponseHandler) checkJWT(cfg *models.JWTAuth, raw string) models.AuthOutcome {
+	refuse := func(result, principal, reason string) models.AuthOutcome {
+		return models.AuthOutcome{Result: result, Method: "jwt", Principal: principal, Reason: reason}
+	}
+
+	token, err := decodeJWT(raw)
+	if err != nil {
+		return refuse(models.AuthUnauthorized, "", err.Error())
+	}
+	if alg := token.alg(); len(cfg.Algorithms) > 0 && !slices.Contains(cfg.Algorithms, alg) {
+		return refuse(models.AuthUnauthorized, "", fmt.Sprintf("algorithm %s not accepted", alg))
+	}
+
+	keys, err := h.jwtVerificationKeys(cfg, token.kid())
+	if err != nil {
+		return refuse(models.AuthUnauthorized, "", err.Error())
+	}
+	if err := verifyJWTWithKeys(token, keys); err != nil {
+		return refuse(models.AuthUnauthorized, "", err.Error())
+	}
+
+	subject, _ := token.claims["sub"].(string)
+	if result, reason := checkJWTClaims(cfg, token.claims, h.now()); result != models.AuthAllowed {
+		return refuse(result, subject, reason)
+	}
+	return models.AuthOutcome{Result: models.AuthAllowed, Method: "jwt", Principal: subject}
+}
+
+// jwtVerificationKeys collects the keys a JWT config trusts: its secret, public key and key sets
+func (h *ResponseHandler) jwtVerificationKeys(cfg *models.JWTAuth, kid string) ([]jwtKey, error) {
+	var keys []jwtKey
+	if cfg.Secret != "" {
+		keys = append(keys, jwtKey{key: []byte(cfg.Secret)})
+	}
+	if cfg.Key != "" {
+		h.configMutex.RLock()
+		named, err := findJWTKey(h.config.JWTKeys, cfg.Key)
+		h.configMutex.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		key, err := namedJWTKey(named)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if cfg.PublicKey != "" {
+		key, err := parsePublicKeyPEM(cfg.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, jwtKey{key: key})
+	}
+	if cfg.JWKS != "" {
+		set, err := parseJWKS([]byte(cfg.JWKS))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, set...)
+	}
+	if cfg.JWKSURL != "" {
+		set, err := h.jwks.keys(cfg.JWKSURL, kid)
+		if err != nil && len(keys) == 0 {
+			return nil, fmt.Errorf("JWKS unavailable: %v", err)
+		}
+		keys = append(keys, set...)
+	}
+	return keys, nil
+}
+
+// checkJWTClaims checks the time window, issuer and audience of a token (401 when wrong), then the
+// required claims and scopes (403 when missing). Times are read from the virtual clock.
+func checkJWTClaims(cfg *models.JWTAuth, claims map[string]interface{}, now time.Time) (string, string) {
+	skew := time.Duration(cfg.ClockSkewSeconds) * time.Second
+	if exp, ok := claims["exp"].(float64); ok && now.After(time.Unix(int64(exp), 0).Add(skew)) {
+		return models.AuthUnauthorized, "token expired"
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(skew).Before(time.Unix(int64(nbf), 0)) {
+		return models.AuthUnauthorized, "token not valid yet"
+	}
+	if cfg.Issuer != "" && claims["iss"] != cfg.Issuer {
+		return models.AuthUnauthorized, fmt.Sprintf("issuer %v not accepted", claims["iss"])
+	}
+	if cfg.Audience != "" && !slices.Contains(claimStrings(claims["aud"]), cfg.Audience) {
+		return models.AuthUnauthorized, "audience does not include " + cfg.Audience
+	}
+
+	for name, want := range cfg.RequiredClaims {
+		value, ok := claims[name]
+		if !ok {
+			return models.AuthForbidden, "missing claim " + name
+		}
+		if want != "" && fmt.Sprint(value) != want && !slices.Contains(claimStrings(value), want) {
+			return models.AuthForbidden, fmt.Sprintf("claim %s is not %s", name, want)
+		}
+	}
+	if len(cfg.RequiredScopes) > 0 {
+		granted := claimStrings(claims["scope"])
+		granted = append(granted, claimStrings(claims["scp"])...)
+		for _, scope := range cfg.RequiredScopes {
+			if !slices.Contains(granted, scope) {
+				return models.AuthForbidden, "missing scope " + scope
+			}
+		}
+	}
+	return models.AuthAllowed, ""
+}
+
+// claimStrings returns the values of a claim as strings: the words of a space-separated string,
+// the members of an array, or the claim itself
+func claimStrings(value interface{}) []string {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, member := range value {
+			values = append(values, fmt.Sprint(member))
+		}
+		return values
+	}
+	return []string{fmt.Sprint(value)}
+}
+
+// refuseAuth answers a request that failed authentication and logs it
+func (h *ResponseHandler) refuseAuth(w http.ResponseWriter, r *http.Request, endpointID string, auth *models.EndpointAuth, outcome models.AuthOutcome, bodyBytes []byte) {
+	startTime := time.Now()
+	status := http.StatusUnauthorized
+	body := auth.UnauthorizedBody
+	if outcome.Result == models.AuthForbidden {
+		status = http.StatusForbidden
+		body = auth.ForbiddenBody
+	}
+	if body == "" {
+		encoded, _ := json.Marshal(map[string]string{"error": outcome.Result, "message": outcome.Reason})
+		body = string(encoded)
+	} else {
+		body = strings.ReplaceAll(body, "{reason}", outcome.Reason)
+	}
+
+	for _, challenge := range authChallenges(auth, outcome) {
+		w.Header().Add("WWW-Authenticate", challenge)
+	}
+	contentType := auth.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+
+	requestLog := buildRequestLog(r, bodyBytes, endpointID)
+	delayMs := int64(0)
+	rttMs := time.Since(startTime).Milliseconds()
+	requestLog.ClientResponse.StatusCode = &status
+	requestLog.ClientResponse.StatusText = http.StatusText(status)
+	requestLog.ClientResponse.Headers = w.Header().Clone()
+	requestLog.ClientResponse.Body = body
+	requestLog.ClientResponse.DelayMs = &delayMs
+	requestLog.ClientResponse.RTTMs = &rttMs
+	h.requestLogger.LogRequest(requestLog)
+}
+
+// authChallenges returns the WWW-Authenticate values of a refusal: one per challengeable method
+// on a 401, and RFC 6750's insufficient_scope for a JWT that is valid but not allowed
+func authChallenges(auth *models.EndpointAuth, outcome models.AuthOutcome) []string {
+	realm := auth.Realm
+	if realm == "" {
+		realm = defaultAuthRealm
+	}
+	bearer := fmt.Sprintf("Bearer realm=%q", realm)
+	if outcome.Result == models.AuthForbidden {
+		return []string{bearer + `, error="insufficient_scope"`}
+	}
+
+	var challenges []string
+	if len(auth.BasicUsers) > 0 {
+		challenges = append(challenges, fmt.Sprintf("Basic realm=%q", realm))
+	}
+	if len(auth.BearerTokens) > 0 || auth.JWT != nil {
+		if outcome.Method == "bearer" || outcome.Method == "jwt" {
+			bearer += `, error="invalid_token"`
+		}
+		challenges = append(challenges, bearer)
+	}
+	return challenges
+}
+
+// requestAPIKey returns the API key of a request, from the configured header or query parameter
+func requestAPIKey(auth *models.EndpointAuth, r *http.Request) string {
+	header := auth.APIKeyHeader
+	if header == "" {
+		header = defaultAPIKeyHeader
+	}
+	if key := r.Header.Get(header); key != "" {
+		return key
+	}
+	if auth.APIKeyQuery != "" {
+		return r.URL.Query().Get(auth.APIKeyQuery)
+	}
+	return ""
+}
+
+// containsSecret reports whether value is one of the secrets, comparing in constant time
+func containsSecret(secrets []string, value string) bool {
+	found := false
+	for _, secret := range secrets {
+		if secretEqual(secret, value) {
+			found = true
+		}
+	}
+	return found
+}
+
+func secretEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// maskSecret shows only the end of a key or token in logs
+func maskSecret(secret string) string {
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
+// ValidateEndpointAuth checks an endpoint's auth config: it needs at least one method, and its
+// keys and algorithms must parse
+func ValidateEndpointAuth(auth *models.EndpointAuth) error {
+	if auth == nil || !auth.Enabled {
+		return nil
+	}
+	if len(auth.APIKeys) == 0 && len(auth.BasicUsers) == 0 && len(auth.BearerTokens) == 0 && auth.JWT == nil {
+		return fmt.Errorf("auth is enabled but no API keys, users, tokens or JWT settings are configured")
+	}
+	for _, user := range auth.BasicUsers {
+		if user.Username == "" || strings.Contains(user.Username, ":") {
+			return fmt.Errorf("invalid Basic auth username %q", user.Username)
+		}
+	}
+	jwt := auth.JWT
+	if jwt == nil {
+		return nil
+	}
+	if jwt.Secret == "" && jwt.Key == "" && jwt.PublicKey == "" && jwt.JWKS == "" && jwt.JWKSURL == "" {
+		return fmt.Errorf("JWT auth needs a secret, a named key, a public key or a JWKS")
+	}
+	for _, alg := range jwt.Algorithms {
+		if !isJWTAlgorithm(alg) {
+			return fmt.Errorf("unsupported JWT algorithm %q", alg)
+		}
+	}
+	if jwt.PublicKey != "" {
+		if _, err := parsePublicKeyPEM(jwt.PublicKey); err != nil {
+			return fmt.Errorf("JWT public key: %w", err)
+		}
+	}
+	if jwt.JWKS != "" {
+		if _, err := parseJWKS([]byte(jwt.JWKS)); err != nil {
+			return err
+		}
+	}
+	if jwt.ClockSkewSeconds < 0 {
+		return fmt.Errorf("JWT clock skew cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"mockelot/models"
+)
+
+// bandwidthTick is how much of the body a throttled response sends at a time
+const bandwidthTick = 100 * time.Millisecond
+
+// resolveBandwidth returns the bandwidth cap in KB/s of a response: its own, else its endpoint's (0 = unlimited)
+func resolveBandwidth(endpoint *models.Endpoint, resp *models.MethodResponse) int {
+	if resp != nil && resp.BandwidthKBps > 0 {
+		return resp.BandwidthKBps
+	}
+	if endpoint != nil && endpoint.BandwidthKBps > 0 {
+		return endpoint.BandwidthKBps
+	}
+	return 0
+}
+
+// limitBandwidth wraps w so the response body goes out at no more than kbps kilobytes per second.
+// Slow bodies outlive the server's write timeout, so the deadline is lifted. A cap of 0 returns w.
+func limitBandwidth(w http.ResponseWriter, r *http.Request, kbps int) http.ResponseWriter {
+	if kbps <= 0 {
+		return w
+	}
+	explain(r, "Bandwidth capped at %d KB/s", kbps)
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	bytesPerSecond := kbps * 1024
+	return &bandwidthWriter{
+		ResponseWriter: w,
+		ctx:            r.Context(),
+		bytesPerSecond: bytesPerSecond,
+		chunkSize:      max(bytesPerSecond/int(time.Second/bandwidthTick), 1),
+	}
+}
+
+// bandwidthWriter paces body writes: each chunk is flushed to the client, then the writer waits
+// until the bytes sent so far fit the rate
+type bandwidthWriter struct {
+	http.ResponseWriter
+	ctx            context.Context
+	bytesPerSecond int
+	chunkSize      int
+	started        time.Time
+	sent           int64
+}
+
+func (b *bandwidthWriter) Write(data []byte) (int, error) {
+	if b.started.IsZero() {
+		b.started = time.Now()
+	}
+	written := 0
+	for len(data) > 0 {
+		chunk := data[:min(len(data), b.chunkSize)]
+		n, err := b.ResponseWriter.Write(chunk)
+		written += n
+		b.sent += int64(n)
+		if err != nil {
+			return written, err
+		}
+		data = data[n:]
+		http.NewResponseController(b.ResponseWriter).Flush()
+
+		due := b.started.Add(time.Duration(b.sent * int64(time.Second) / int64(b.bytesPerSecond)))
+		if wait := time.Until(due); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-b.ctx.Done():
+				timer.Stop()
+				return written, b.ctx.Err()
+			}
+		}
+	}
+	return written, nil
+}
+
+// Flush lets streamed responses reach the client as they are paced
+func (b *bandwidthWriter) Flush() {
+	http.NewResponseController(b.ResponseWriter).Flush()
+}
+
+// Hijack hands WebSocket upgrades the raw connection; upgraded traffic is not paced
+func (b *bandwidthWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(b.ResponseWriter).Hijack()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController (hijacking, deadlines)
+func (b *bandwidthWriter) Unwrap() http.ResponseWriter {
+	return b.ResponseWriter
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"mockelot/models"
+)
+
+// bypassResolveTimeout bounds the DNS lookup made to test a domain against CIDR rules
+const bypassResolveTimeout = 2 * time.Second
+
+// matchBypassRule returns the first enabled bypass rule matching a destination, or nil
+func matchBypassRule(rules []models.BypassRule, host string, port int) *models.BypassRule {
+	var addrs []net.IP
+	resolved := false
+
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Enabled || (rule.Domain == "" && rule.CIDR == "" && len(rule.Ports) == 0) {
+			continue
+		}
+		if len(rule.Ports) > 0 && !slices.Contains(rule.Ports, port) {
+			continue
+		}
+		if rule.Domain != "" && !matchBypassDomain(rule.Domain, host) {
+			continue
+		}
+		if rule.CIDR != "" {
+			_, network, err := net.ParseCIDR(rule.CIDR)
+			if err != nil {
+				continue
+			}
+			// Domain destinations are resolved once, and only if a CIDR rule needs them
+			if !resolved {
+				addrs = resolveBypassHost(host)
+				resolved = true
+			}
+			if !slices.ContainsFunc(addrs, network.Contains) {
+				continue
+			}
+		}
+		return rule
+	}
+	return nil
+}
+
+// matchBypassDomain matches a host against an exact domain or a "*.example.com" wildcard
+func matchBypassDomain(pattern, host string) bool {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+	return host == pattern
+}
+
+// resolveBypassHost returns the destination's addresses (the host itself if it is an IP)
+func resolveBypassHost(host string) []net.IP {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), bypassResolveTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil
+	}
+	return addrs
+}
+
+// ValidateBypassRules checks that every bypass rule's CIDR parses
+func ValidateBypassRules(socks5Config *models.SOCKS5Config) []models.PatternError {
+	if socks5Config == nil {
+		return nil
+	}
+
+	var errs []models.PatternError
+	for _, rule := range socks5Config.BypassRules {
+		if rule.CIDR == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(rule.CIDR); err != nil {
+			errs = append(errs, models.PatternError{
+				Field:   "bypass_rules",
+				Pattern: rule.CIDR,
+				Error:   err.Error(),
+			})
+		}
+	}
+	return errs
+}
+
+// bypassCounter accumulates the traffic tunneled by one bypass rule
+type bypassCounter struct {
+	connections   int64
+	bytesSent     int64
+	bytesReceived int64
+	lastMatched   time.Time
+	lastTarget    string
+}
+
+// BypassStats counts the connections each bypass rule has tunneled
+type BypassStats struct {
+	mutex sync.Mutex
+	rules map[string]*bypassCounter // Rule ID -> counters
+}
+
+// NewBypassStats creates empty bypass counters
+func NewBypassStats() *BypassStats {
+	return &BypassStats{rules: make(map[string]*bypassCounter)}
+}
+
+// recordConnection counts a connection the rule matched
+func (b *BypassStats) recordConnection(ruleID, target string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	counter := b.counter(ruleID)
+	counter.connections++
+	counter.lastMatched = time.Now()
+	counter.lastTarget = target
+}
+
+// recordBytes adds the bytes a finished connection carried
+func (b *BypassStats) recordBytes(ruleID string, sent, received int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	counter := b.counter(ruleID)
+	counter.bytesSent += sent
+	counter.bytesReceived += received
+}
+
+// counter returns a rule's counters, creating them on first use (caller holds the mutex)
+func (b *BypassStats) counter(ruleID string) *bypassCounter {
+	counter := b.rules[ruleID]
+	if counter == nil {
+		counter = &bypassCounter{}
+		b.rules[ruleID] = counter
+	}
+	return counter
+}
+
+// Snapshot returns the counters of every rule that has matched
+func (b *BypassStats) Snapshot() []models.BypassRuleStats {
+	stats := []models.BypassRuleStats{}
+	if b == nil {
+		return stats
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for id, counter := range b.rules {
+		stats = append(stats, models.BypassRuleStats{
+			RuleID:        id,
+			Connections:   counter.connections,
+			BytesSent:     counter.bytesSent,
+			BytesReceived: counter.bytesReceived,
+			LastMatched:   counter.lastMatched.Format(time.RFC3339),
+			LastTarget:    counter.lastTarget,
+		})
+	}
+	return stats
+}
+
+// Reset clears every rule's counters
+func (b *BypassStats) Reset() {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	b.rules = make(map[string]*bypassCounter)
+	b.mutex.Unlock()
+}
+
+// bypassDescription formats a rule for logs
+func bypassDescription(rule *models.BypassRule) string {
+	var parts []string
+	if rule.Domain != "" {
+		parts = append(parts, rule.Domain)
+	}
+	if rule.CIDR != "" {
+		parts = append(parts, rule.CIDR)
+	}
+	if len(rule.Ports) > 0 {
+		parts = append(parts, fmt.Sprintf("ports %v", rule.Ports))
+	}
+	return strings.Join(parts, " ")
+}
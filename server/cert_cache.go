@@ -4,14 +4,13 @@ import (
 	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
-	"log"
 	"sync"
 	"time"
 )
 
 // CertCache provides thread-safe caching of dynamically generated TLS certificates
-// for SOCKS5 TLS interception. Certificates are generated on-demand for each domain
-// and cached to improve performance.
+// for SOCKS5 TLS interception and SNI-based HTTPS serving. Certificates are generated
+// on-demand for each domain and cached to improve performance.
 type CertCache struct {
 	mu          sync.RWMutex
 	certs       map[string]*cachedCert
@@ -70,7 +69,7 @@ func (c *CertCache) GetOrCreate(domain string) (*tls.Certificate, error) {
 
 	// Generate certificate for domain
 	// The domain is used as the DNS SAN (Subject Alternative Name)
-	certPEM, keyPEM, err := c.certManager.GenerateServerCert(
+	certPEM, keyPEM, err := IssueServerCert(
 		c.caCert,
 		c.caKey,
 		[]string{domain},
@@ -92,7 +91,7 @@ func (c *CertCache) GetOrCreate(domain string) (*tls.Certificate, error) {
 		createdAt: time.Now(),
 	}
 
-	log.Printf("CertCache: Generated certificate for domain: %s (cache size: %d)", domain, len(c.certs))
+	serverLog.Debug("CertCache: Generated certificate for domain: %s (cache size: %d)", domain, len(c.certs))
 
 	return &tlsCert, nil
 }
@@ -112,7 +111,7 @@ func (c *CertCache) evictOldest() {
 
 	if oldestKey != "" {
 		delete(c.certs, oldestKey)
-		log.Printf("CertCache: Evicted oldest certificate for domain: %s", oldestKey)
+		serverLog.Debug("CertCache: Evicted oldest certificate for domain: %s", oldestKey)
 	}
 }
 
@@ -121,7 +120,17 @@ func (c *CertCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.certs = make(map[string]*cachedCert)
-	log.Printf("CertCache: Cleared all cached certificates")
+	serverLog.Info("CertCache: Cleared all cached certificates")
+}
+
+// Rotate switches to a new signing CA and drops the certificates signed by the old one
+func (c *CertCache) Rotate(caCert *x509.Certificate, caKey *rsa.PrivateKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.caCert = caCert
+	c.caKey = caKey
+	c.certs = make(map[string]*cachedCert)
+	serverLog.Info("CertCache: Rotated CA, cleared cached certificates")
 }
 
 // Size returns the current number of cached certificates
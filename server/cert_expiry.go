@@ -0,0 +1,193 @@
+package server
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+const (
+	// CertExpiryWarningDays is how many days before expiry a certificate is reported as expiring
+	CertExpiryWarningDays = 30
+
+	// certExpiryCheckInterval is how often a running HTTPS server re-checks certificate expiry
+	certExpiryCheckInterval = 12 * time.Hour
+)
+
+// DescribeCertificate reports a certificate's names, validity window and fingerprints
+func DescribeCertificate(role string, cert *x509.Certificate) models.CertificateDetails {
+	details := models.CertificateDetails{
+		Role:         role,
+		Subject:      cert.Subject.String(),
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: fmt.Sprintf("%X", cert.SerialNumber),
+		DNSNames:     cert.DNSNames,
+		NotBefore:    cert.NotBefore.Format(time.RFC3339),
+		NotAfter:     cert.NotAfter.Format(time.RFC3339),
+	}
+	sha1Sum := sha1.Sum(cert.Raw)
+	sha256Sum := sha256.Sum256(cert.Raw)
+	details.SHA1Fingerprint = fingerprint(sha1Sum[:])
+	details.SHA256Fingerprint = fingerprint(sha256Sum[:])
+	spkiSum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	details.PublicKeyPin = "sha256/" + base64.StdEncoding.EncodeToString(spkiSum[:])
+	for _, ip := range cert.IPAddresses {
+		details.IPAddresses = append(details.IPAddresses, ip.String())
+	}
+
+	remaining := time.Until(cert.NotAfter)
+	details.DaysRemaining = int(remaining.Hours() / 24)
+	details.Expired = remaining <= 0
+	details.Expiring = !details.Expired && remaining <= CertExpiryWarningDays*24*time.Hour
+	return details
+}
+
+// fingerprint formats a digest as colon-separated uppercase hex
+func fingerprint(digest []byte) string {
+	parts := make([]string, len(digest))
+	for i, b := range digest {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// InspectCertificates describes the CA used by the configured certificate mode and, when HTTPS is
+// serving, the active server certificate. The caller guards config.
+func InspectCertificates(config *models.AppConfig, serving *x509.Certificate) []models.CertificateDetails {
+	certificates := []models.CertificateDetails{}
+
+	caCert, err := configuredCA(config)
+	switch {
+	case err != nil:
+		certificates = append(certificates, models.CertificateDetails{Role: models.CertRoleCA, Error: err.Error()})
+	case caCert != nil:
+		certificates = append(certificates, DescribeCertificate(models.CertRoleCA, caCert))
+	}
+
+	if serving != nil {
+		certificates = append(certificates, DescribeCertificate(models.CertRoleServer, serving))
+	}
+	return certificates
+}
+
+// configuredCA loads the CA certificate for the configured certificate mode. Returns nil when
+// there is none yet (auto mode before the first HTTPS start, or cert-provided mode without a bundle).
+func configuredCA(config *models.AppConfig) (*x509.Certificate, error) {
+	switch config.CertMode {
+	case models.CertModeCAProvided:
+		if config.CertPaths.CACertPath == "" {
+			return nil, fmt.Errorf("no CA certificate path configured")
+		}
+		return readCertificateFile(config.CertPaths.CACertPath)
+
+	case models.CertModeCertProvided:
+		if config.CertPaths.ServerBundlePath == "" {
+			return nil, nil
+		}
+		return readCertificateFile(config.CertPaths.ServerBundlePath)
+
+	default:
+		certManager, err := NewCertificateManager()
+		if err != nil {
+			return nil, err
+		}
+		if !certManager.CAExists() {
+			return nil, nil
+		}
+		caCert, _, err := certManager.LoadCA()
+		return caCert, err
+	}
+}
+
+// readCertificateFile parses the first certificate in a PEM file
+func readCertificateFile(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// CertificateDetails describes the configured CA and the certificate HTTPS is currently serving
+func (s *HTTPServer) CertificateDetails() []models.CertificateDetails {
+	var serving *x509.Certificate
+	if cert := s.servingCert.Load(); cert != nil {
+		serving = cert.Leaf
+		if serving == nil && len(cert.Certificate) > 0 {
+			serving, _ = x509.ParseCertificate(cert.Certificate[0])
+		}
+	}
+
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+	return InspectCertificates(s.config, serving)
+}
+
+// checkCertificateExpiry logs and reports a "cert:expiring" event for certificates that are
+// expired or within CertExpiryWarningDays of expiring
+func (s *HTTPServer) checkCertificateExpiry() {
+	var expiring []models.CertificateDetails
+	for _, cert := range s.CertificateDetails() {
+		if !cert.Expiring && !cert.Expired {
+			continue
+		}
+		if cert.Expired {
+			serverLog.Warn("%s certificate %q expired on %s", cert.Role, cert.Subject, cert.NotAfter)
+		} else {
+			serverLog.Warn("%s certificate %q expires in %d days (%s)", cert.Role, cert.Subject, cert.DaysRemaining, cert.NotAfter)
+		}
+		expiring = append(expiring, cert)
+	}
+
+	if len(expiring) > 0 && s.eventSender != nil {
+		s.eventSender.SendEvent("cert:expiring", map[string]interface{}{
+			"certificates": expiring,
+			"warning_days": CertExpiryWarningDays,
+		})
+	}
+}
+
+// startCertExpiryWatch checks certificate expiry now and periodically while HTTPS is serving
+func (s *HTTPServer) startCertExpiryWatch() {
+	s.stopCertExpiryWatch()
+	stop := make(chan struct{})
+	s.certWatchStop = stop
+
+	go func() {
+		s.checkCertificateExpiry()
+		ticker := time.NewTicker(certExpiryCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.checkCertificateExpiry()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// stopCertExpiryWatch ends periodic expiry checks
+func (s *HTTPServer) stopCertExpiryWatch() {
+	if s.certWatchStop != nil {
+		close(s.certWatchStop)
+		s.certWatchStop = nil
+	}
+}
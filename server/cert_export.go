@@ -0,0 +1,186 @@
+package server
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"mockelot/models"
+)
+
+// ServingChain returns the certificates HTTPS presents to clients, leaf first. With includeCA the
+// configured CA is appended when the served chain does not already end with it.
+func (s *HTTPServer) ServingChain(includeCA bool) ([]*x509.Certificate, error) {
+	cert := s.servingCert.Load()
+	if cert == nil {
+		return nil, fmt.Errorf("HTTPS server is not running")
+	}
+
+	chain := make([]*x509.Certificate, 0, len(cert.Certificate)+1)
+	for _, der := range cert.Certificate {
+		parsed, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse served certificate: %w", err)
+		}
+		chain = append(chain, parsed)
+	}
+
+	if includeCA {
+		s.configMutex.RLock()
+		caCert, err := configuredCA(s.config)
+		s.configMutex.RUnlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA certificate: %w", err)
+		}
+		if caCert != nil && !chain[len(chain)-1].Equal(caCert) {
+			chain = append(chain, caCert)
+		}
+	}
+	return chain, nil
+}
+
+// EncodeCertificates encodes certificates for export. PEM concatenates the chain into one file;
+// DER holds a single certificate, so each certificate is returned as its own file.
+func EncodeCertificates(certs []*x509.Certificate, format string) ([][]byte, error) {
+	switch format {
+	case models.CertFormatPEM, "":
+		var buf bytes.Buffer
+		for _, cert := range certs {
+			pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+		}
+		return [][]byte{buf.Bytes()}, nil
+
+	case models.CertFormatDER:
+		files := make([][]byte, len(certs))
+		for i, cert := range certs {
+			files[i] = cert.Raw
+		}
+		return files, nil
+
+	default:
+		return nil, fmt.Errorf("unknown certificate format: %s", format)
+	}
+}
+
+// GenerateMismatchedCert creates a certificate for the given hostnames that a client pinning the
+// served certificate or CA must reject:
+//   - untrusted-ca: signed by a throwaway CA the client has never seen
+//   - new-key: signed by the configured CA, but with a fresh key (fails public key pins)
+//   - wrong-host: signed by the configured CA for "mismatch.invalid" instead of the hostnames
+//   - expired: signed by the configured CA, valid only in the past
+//
+// The configured CA is not needed for untrusted-ca. Returns PEM-encoded certificate and key; the
+// certificate is not saved or served.
+func GenerateMismatchedCert(config *models.AppConfig, kind string, hostnames []string) ([]byte, []byte, error) {
+	if len(hostnames) == 0 {
+		return nil, nil, fmt.Errorf("at least one hostname is required")
+	}
+	dnsNames, ipAddresses := ParseCertNames(hostnames)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject: pkix.Name{
+			CommonName:   hostnames[0],
+			Organization: []string{"Mockelot"},
+		},
+		NotBefore:   time.Now(),
+		NotAfter:    time.Now().AddDate(1, 0, 0),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:    dnsNames,
+		IPAddresses: ipAddresses,
+	}
+
+	var caCert *x509.Certificate
+	var caKey *rsa.PrivateKey
+	var err error
+
+	switch kind {
+	case models.CertMismatchUntrustedCA:
+		caCert, caKey, err = throwawayCA()
+	case models.CertMismatchNewKey:
+		caCert, caKey, err = configuredCAKeyPair(config)
+	case models.CertMismatchWrongHost:
+		caCert, caKey, err = configuredCAKeyPair(config)
+		template.Subject.CommonName = "mismatch.invalid"
+		template.DNSNames = []string{"mismatch.invalid"}
+		template.IPAddresses = nil
+	case models.CertMismatchExpired:
+		caCert, caKey, err = configuredCAKeyPair(config)
+		template.NotBefore = time.Now().AddDate(0, 0, -30)
+		template.NotAfter = time.Now().AddDate(0, 0, -1)
+	default:
+		return nil, nil, fmt.Errorf("unknown mismatch kind: %s", kind)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate private key: %w", err)
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM, nil
+}
+
+// configuredCAKeyPair loads the CA certificate and key that sign server certificates in the
+// configured certificate mode
+func configuredCAKeyPair(config *models.AppConfig) (*x509.Certificate, *rsa.PrivateKey, error) {
+	switch config.CertMode {
+	case models.CertModeCAProvided:
+		return LoadUserCACert(config.CertPaths.CACertPath, config.CertPaths.CAKeyPath)
+	case models.CertModeCertProvided:
+		return nil, nil, fmt.Errorf("cert-provided mode has no CA key to sign with; use the untrusted-ca kind")
+	default:
+		certManager, err := NewCertificateManager()
+		if err != nil {
+			return nil, nil, err
+		}
+		if !certManager.CAExists() {
+			return nil, nil, fmt.Errorf("CA certificate does not exist - please start HTTPS server first")
+		}
+		return certManager.LoadCA()
+	}
+}
+
+// throwawayCA creates an in-memory CA that is never saved or trusted
+func throwawayCA() (*x509.Certificate, *rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA private key: %w", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject: pkix.Name{
+			CommonName:   "Mockelot Untrusted CA",
+			Organization: []string{"Mockelot"},
+		},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+	return cert, key, nil
+}
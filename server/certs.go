@@ -267,6 +267,29 @@ func (cm *CertificateManager) GetCACertPEM() ([]byte, error) {
 // Returns PEM-encoded certificate and private key
 // If dnsNames or ipAddresses are empty, defaults will be used
 func (cm *CertificateManager) GenerateServerCert(caCert *x509.Certificate, caPrivKey *rsa.PrivateKey, dnsNames []string, ipAddresses []net.IP) ([]byte, []byte, error) {
+	serverCertPEM, serverKeyPEM, err := IssueServerCert(caCert, caPrivKey, dnsNames, ipAddresses)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Save to disk for reference
+	serverCertPath := filepath.Join(cm.certDir, serverCertFile)
+	if err := os.WriteFile(serverCertPath, serverCertPEM, 0600); err != nil {
+		return nil, nil, fmt.Errorf("failed to write server certificate: %w", err)
+	}
+
+	serverKeyPath := filepath.Join(cm.certDir, serverKeyFile)
+	if err := os.WriteFile(serverKeyPath, serverKeyPEM, 0600); err != nil {
+		return nil, nil, fmt.Errorf("failed to write server private key: %w", err)
+	}
+
+	return serverCertPEM, serverKeyPEM, nil
+}
+
+// IssueServerCert creates a server certificate signed by the CA without saving it, for
+// certificates minted per domain. Serial numbers are random, so certificates issued in the
+// same second are still told apart by clients.
+func IssueServerCert(caCert *x509.Certificate, caPrivKey *rsa.PrivateKey, dnsNames []string, ipAddresses []net.IP) ([]byte, []byte, error) {
 	// Generate server private key
 	serverPrivKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
@@ -290,9 +313,14 @@ func (cm *CertificateManager) GenerateServerCert(caCert *x509.Certificate, caPri
 		cn = dnsNames[0]
 	}
 
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
 	// Create server certificate template
 	serverTemplate := &x509.Certificate{
-		SerialNumber: big.NewInt(time.Now().Unix()),
+		SerialNumber: serialNumber,
 		Subject: pkix.Name{
 			CommonName:   cn,
 			Organization: []string{"Mockelot"},
@@ -322,17 +350,6 @@ func (cm *CertificateManager) GenerateServerCert(caCert *x509.Certificate, caPri
 		Bytes: x509.MarshalPKCS1PrivateKey(serverPrivKey),
 	})
 
-	// Save to disk for reference
-	serverCertPath := filepath.Join(cm.certDir, serverCertFile)
-	if err := os.WriteFile(serverCertPath, serverCertPEM, 0600); err != nil {
-		return nil, nil, fmt.Errorf("failed to write server certificate: %w", err)
-	}
-
-	serverKeyPath := filepath.Join(cm.certDir, serverKeyFile)
-	if err := os.WriteFile(serverKeyPath, serverKeyPEM, 0600); err != nil {
-		return nil, nil, fmt.Errorf("failed to write server private key: %w", err)
-	}
-
 	return serverCertPEM, serverKeyPEM, nil
 }
 
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+// Fault kinds chosen for a request (at most one per request)
+const (
+	faultError    = "error"
+	faultReset    = "reset"
+	faultTruncate = "truncate"
+)
+
+// faultPlan is the outcome of rolling a fault injection config for one request
+type faultPlan struct {
+	latencyMs int
+	kind      string
+	status    int    // Injected error status
+	body      string // Injected error body
+}
+
+// resolveFaultInjection returns the fault config in effect for a response: the response's own
+// (a disabled one switches chaos off for that response), else the endpoint's. Nil means no faults.
+func resolveFaultInjection(endpoint *models.Endpoint, resp *models.MethodResponse) *models.FaultInjection {
+	if resp != nil && resp.FaultInjection != nil {
+		if resp.FaultInjection.Enabled {
+			return resp.FaultInjection
+		}
+		return nil
+	}
+	if endpoint != nil && endpoint.FaultInjection != nil && endpoint.FaultInjection.Enabled {
+		return endpoint.FaultInjection
+	}
+	return nil
+}
+
+// planFault decides the latency and fault for one request and records them in the match info
+func planFault(f *models.FaultInjection, r *http.Request, allowTruncate bool) faultPlan {
+	var plan faultPlan
+	if f == nil {
+		return plan
+	}
+
+	plan.latencyMs = faultLatencyMs(f)
+
+	roll := rand.Float64() * 100
+	switch {
+	case roll < f.ResetRate:
+		plan.kind = faultReset
+	case roll < f.ResetRate+f.ErrorRate:
+		plan.kind = faultError
+		plan.status = f.ErrorStatus
+		if plan.status == 0 {
+			plan.status = http.StatusServiceUnavailable
+		}
+		plan.body = f.ErrorBody
+		if plan.body == "" {
+			plan.body = http.StatusText(plan.status)
+		}
+	case allowTruncate && roll < f.ResetRate+f.ErrorRate+f.TruncateRate:
+		plan.kind = faultTruncate
+	}
+
+	var parts []string
+	if plan.latencyMs > 0 {
+		parts = append(parts, fmt.Sprintf("latency %dms", plan.latencyMs))
+	}
+	switch plan.kind {
+	case faultError:
+		parts = append(parts, fmt.Sprintf("error %d", plan.status))
+	case faultReset:
+		parts = append(parts, "reset")
+	case faultTruncate:
+		parts = append(parts, "truncated")
+	}
+	if len(parts) > 0 {
+		description := strings.Join(parts, ", ")
+		updateMatchInfo(r, func(info *models.MatchInfo) {
+			info.Fault = description
+		})
+		explain(r, "Fault injection: %s", description)
+	}
+	return plan
+}
+
+// faultLatencyMs draws the added latency from the configured distribution
+func faultLatencyMs(f *models.FaultInjection) int {
+	switch f.Latency {
+	case models.LatencyFixed:
+		return max(f.LatencyMs, 0)
+	case models.LatencyUniform:
+		if f.LatencyMaxMs <= f.LatencyMs {
+			return max(f.LatencyMs, 0)
+		}
+		return f.LatencyMs + rand.IntN(f.LatencyMaxMs-f.LatencyMs+1)
+	case models.LatencyNormal:
+		return max(int(rand.NormFloat64()*float64(f.LatencyStdDevMs)+float64(f.LatencyMs)), 0)
+	}
+	return 0
+}
+
+// resetConnection drops the client connection without a response. HTTP/1 connections are closed
+// with an RST; HTTP/2 streams (which cannot be hijacked) are reset by aborting the handler.
+func resetConnection(w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	netConn := conn
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		netConn = tlsConn.NetConn()
+	}
+	if tcpConn, ok := netConn.(*net.TCPConn); ok {
+		tcpConn.SetLinger(0)
+	}
+	conn.Close()
+}
+
+// writeTruncated announces the full body length but sends only its first half, so the client sees
+// the connection end mid-body. Returns what was sent.
+func writeTruncated(w http.ResponseWriter, status int, body string) string {
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	sent := body[:len(body)/2]
+	w.Write([]byte(sent))
+	return sent
+}
+
+// logFaultReset records a request whose connection was reset by fault injection
+func (h *ResponseHandler) logFaultReset(r *http.Request, bodyBytes []byte, endpointID string) {
+	requestLog := buildRequestLog(r, bodyBytes, endpointID)
+	requestLog.ResponseFailed = true
+	requestLog.ClientResponse.StatusCode = nil // No HTTP response
+	requestLog.ClientResponse.Body = "Connection reset by fault injection"
+	h.requestLogger.LogRequest(requestLog)
+}
+
+// injectEndpointFault applies an endpoint's fault injection to a proxy/container request before it
+// is forwarded. Returns true when the request was answered (error) or dropped (reset).
+func (h *ResponseHandler) injectEndpointFault(w http.ResponseWriter, r *http.Request, endpoint *models.Endpoint, bodyBytes []byte) bool {
+	plan := planFault(resolveFaultInjection(endpoint, nil), r, false)
+	if plan.latencyMs > 0 {
+		time.Sleep(time.Duration(plan.latencyMs) * time.Millisecond)
+	}
+
+	switch plan.kind {
+	case faultReset:
+		h.logFaultReset(r, bodyBytes, endpoint.ID)
+		resetConnection(w)
+		return true
+	case faultError:
+		startTime := time.Now()
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(plan.status)
+		w.Write([]byte(plan.body))
+
+		requestLog := buildRequestLog(r, bodyBytes, endpoint.ID)
+		rttMs := time.Since(startTime).Milliseconds()
+		requestLog.ClientResponse.StatusCode = &plan.status
+		requestLog.ClientResponse.StatusText = http.StatusText(plan.status)
+		requestLog.ClientResponse.Headers = map[string][]string{"Content-Type": {"text/plain; charset=utf-8"}}
+		requestLog.ClientResponse.Body = plan.body
+		requestLog.ClientResponse.RTTMs = &rttMs
+		h.requestLogger.LogRequest(requestLog)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	"mockelot/models"
+)
+
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitOpenTime         = 30 * time.Second
+)
+
+// statusPatternRegex matches the status patterns of proxies: an exact code or a class like "5xx"
+var statusPatternRegex = regexp.MustCompile(`^[1-5](xx|[0-9]{2})$`)
+
+// circuitState is the live state of one endpoint's circuit breaker
+type circuitState struct {
+	state          string
+	failures       int // Consecutive failures
+	openedAt       time.Time
+	probing        bool // A half-open probe is in flight
+	probeSuccesses int
+	timesOpened    int64
+	shortCircuited int64
+	lastFailure    string
+}
+
+// circuitAllow decides whether a request may reach the backend. An open circuit whose open time
+// has passed turns half-open and lets one probe through at a time. When the request is refused,
+// it returns how long until the next probe.
+func (p *ProxyHandler) circuitAllow(endpointID string, breaker *models.CircuitBreakerConfig) (allowed, probe bool, retryAfter time.Duration, state string) {
+	p.circuitMutex.Lock()
+	defer p.circuitMutex.Unlock()
+	circuit := p.circuit(endpointID)
+
+	if circuit.state == models.CircuitOpen {
+		retryAt := circuit.openedAt.Add(circuitOpenTime(breaker))
+		if wait := time.Until(retryAt); wait > 0 {
+			circuit.shortCircuited++
+			return false, false, wait, circuit.state
+		}
+		circuit.state = models.CircuitHalfOpen
+		circuit.probeSuccesses = 0
+		proxyLog.Info("Circuit of endpoint %s is half-open, probing the backend", endpointID)
+	}
+	if circuit.state == models.CircuitHalfOpen {
+		if circuit.probing {
+			circuit.shortCircuited++
+			return false, false, 0, circuit.state
+		}
+		circuit.probing = true
+		return true, true, 0, circuit.state
+	}
+	return true, false, 0, circuit.state
+}
+
+// circuitRecord counts the outcome of a request that reached the backend (failure is empty on
+// success). Enough consecutive failures open the circuit; a failed probe opens it again at once.
+func (p *ProxyHandler) circuitRecord(endpointID string, breaker *models.CircuitBreakerConfig, probe bool, failure string) {
+	p.circuitMutex.Lock()
+	defer p.circuitMutex.Unlock()
+	circuit := p.circuit(endpointID)
+	if probe {
+		circuit.probing = false
+	}
+
+	if failure == "" {
+		circuit.failures = 0
+		if circuit.state == models.CircuitHalfOpen && probe {
+			circuit.probeSuccesses++
+			if circuit.probeSuccesses >= circuitHalfOpenProbes(breaker) {
+				circuit.state = models.CircuitClosed
+				proxyLog.Info("Circuit of endpoint %s closed, the backend recovered", endpointID)
+			}
+		}
+		return
+	}
+
+	circuit.failures++
+	circuit.lastFailure = failure
+	threshold := breaker.FailureThreshold
+	if threshold <= 0 {
+		threshold = defaultCircuitFailureThreshold
+	}
+	if (circuit.state == models.CircuitHalfOpen && probe) || (circuit.state == models.CircuitClosed && circuit.failures >= threshold) {
+		circuit.state = models.CircuitOpen
+		circuit.openedAt = time.Now()
+		circuit.timesOpened++
+		proxyLog.Warn("Circuit of endpoint %s opened after %d failures (last: %s)", endpointID, circuit.failures, failure)
+	}
+}
+
+// circuitRelease ends a probe whose outcome says nothing about the backend (the client went away)
+func (p *ProxyHandler) circuitRelease(endpointID string, probe bool) {
+	if !probe {
+		return
+	}
+	p.circuitMutex.Lock()
+	defer p.circuitMutex.Unlock()
+	p.circuit(endpointID).probing = false
+}
+
+// circuitFailure returns why a backend exchange counts as a failure, or "" if it does not
+func (p *ProxyHandler) circuitFailure(breaker *models.CircuitBreakerConfig, resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	statuses := breaker.FailureStatuses
+	if len(statuses) == 0 {
+		statuses = []string{"5xx"}
+	}
+	for _, pattern := range statuses {
+		if p.matchesStatusPattern(resp.StatusCode, pattern) {
+			return fmt.Sprintf("status %d", resp.StatusCode)
+		}
+	}
+	return ""
+}
+
+// serveCircuitFallback answers a request refused by the circuit breaker with the fallback response
+func (p *ProxyHandler) serveCircuitFallback(w http.ResponseWriter, r *http.Request, endpoint *models.Endpoint, breaker *models.CircuitBreakerConfig, retryAfter time.Duration, state string) {
+	startTime := time.Now()
+	status := breaker.FallbackStatus
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+	updateMatchInfo(r, func(info *models.MatchInfo) {
+		info.Circuit = state
+	})
+	explain(r, "Circuit breaker %s, answered with the fallback response", state)
+
+	var requestBody []byte
+	if r.Body != nil {
+		requestBody, _ = io.ReadAll(r.Body)
+	}
+
+	for name, value := range breaker.FallbackHeaders {
+		w.Header().Set(name, value)
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	body := breaker.FallbackBody
+	if body == "" {
+		body = http.StatusText(status)
+	}
+	if advertisesRetryAfter(status) && retryAfter > 0 && w.Header().Get("Retry-After") == "" {
+		w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+	p.trafficMeter().RecordEndpoint(endpoint, int64(len(requestBody)), int64(len(body)))
+
+	if p.logger == nil {
+		return
+	}
+	requestLog := buildRequestLog(r, requestBody, endpoint.ID)
+	delayMs := int64(0)
+	rttMs := time.Since(startTime).Milliseconds()
+	requestLog.ClientResponse.StatusCode = &status
+	requestLog.ClientResponse.StatusText = http.StatusText(status)
+	requestLog.ClientResponse.Headers = w.Header().Clone()
+	requestLog.ClientResponse.Body = body
+	requestLog.ClientResponse.DelayMs = &delayMs
+	requestLog.ClientResponse.RTTMs = &rttMs
+	p.logger.LogRequest(requestLog)
+}
+
+// CircuitStatus returns the circuit breaker state of every proxy endpoint that has one enabled
+func (p *ProxyHandler) CircuitStatus(endpoints []models.Endpoint) []models.CircuitBreakerStatus {
+	statuses := []models.CircuitBreakerStatus{}
+	p.circuitMutex.Lock()
+	defer p.circuitMutex.Unlock()
+	for _, endpoint := range endpoints {
+		cfg := endpoint.ProxyConfig
+		if endpoint.Type != models.EndpointTypeProxy || cfg == nil || cfg.CircuitBreaker == nil || !cfg.CircuitBreaker.Enabled {
+			continue
+		}
+		circuit := p.circuit(endpoint.ID)
+		status := models.CircuitBreakerStatus{
+			EndpointID:          endpoint.ID,
+			EndpointName:        endpoint.Name,
+			State:               circuit.state,
+			ConsecutiveFailures: circuit.failures,
+			TimesOpened:         circuit.timesOpened,
+			ShortCircuited:      circuit.shortCircuited,
+			LastFailure:         circuit.lastFailure,
+		}
+		if !circuit.openedAt.IsZero() {
+			status.OpenedAt = circuit.openedAt.Format(time.RFC3339)
+		}
+		if circuit.state == models.CircuitOpen {
+			status.RetryAt = circuit.openedAt.Add(circuitOpenTime(cfg.CircuitBreaker)).Format(time.RFC3339)
+		}
+		statuses = append(statuses, status)
+	}
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].EndpointName < statuses[j].EndpointName })
+	return statuses
+}
+
+// ResetCircuit closes an endpoint's circuit and clears its counters (every circuit if endpointID is empty)
+func (p *ProxyHandler) ResetCircuit(endpointID string) {
+	p.circuitMutex.Lock()
+	defer p.circuitMutex.Unlock()
+	if endpointID == "" {
+		p.circuits = make(map[string]*circuitState)
+		return
+	}
+	delete(p.circuits, endpointID)
+}
+
+// circuit returns the circuit of an endpoint, closed on first use (caller holds circuitMutex)
+func (p *ProxyHandler) circuit(endpointID string) *circuitState {
+	circuit := p.circuits[endpointID]
+	if circuit == nil {
+		circuit = &circuitState{state: models.CircuitClosed}
+		p.circuits[endpointID] = circuit
+	}
+	return circuit
+}
+
+func circuitOpenTime(breaker *models.CircuitBreakerConfig) time.Duration {
+	if breaker.OpenSeconds > 0 {
+		return time.Duration(breaker.OpenSeconds) * time.Second
+	}
+	return defaultCircuitOpenTime
+}
+
+func circuitHalfOpenProbes(breaker *models.CircuitBreakerConfig) int {
+	if breaker.HalfOpenProbes > 0 {
+		return breaker.HalfOpenProbes
+	}
+	return 1
+}
+
+// ValidateCircuitBreaker checks the circuit breaker settings of a proxy endpoint
+func ValidateCircuitBreaker(breaker *models.CircuitBreakerConfig) error {
+	if breaker == nil {
+		return nil
+	}
+	if breaker.FailureThreshold < 0 || breaker.OpenSeconds < 0 || breaker.HalfOpenProbes < 0 {
+		return fmt.Errorf("circuit breaker thresholds and times cannot be negative")
+	}
+	if breaker.FallbackStatus != 0 && (breaker.FallbackStatus < 100 || breaker.FallbackStatus > 599) {
+		return fmt.Errorf("invalid fallback status %d", breaker.FallbackStatus)
+	}
+	for _, pattern := range breaker.FailureStatuses {
+		if !statusPatternRegex.MatchString(pattern) {
+			return fmt.Errorf("invalid failure status %q (use a code such as 503 or a class such as 5xx)", pattern)
+		}
+	}
+	return nil
+}
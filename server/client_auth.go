@@ -0,0 +1,151 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"mockelot/models"
+)
+
+// clientAuthPolicy is how the HTTPS listener asks clients for certificates
+type clientAuthPolicy struct {
+	mode tls.ClientAuthType
+	cas  *x509.CertPool // Also advertised to clients in request mode, so they can pick a certificate
+}
+
+// buildClientAuthPolicy turns the client_auth settings into a policy; nil means certificates are not asked for
+func buildClientAuthPolicy(cfg *models.ClientAuthConfig) (*clientAuthPolicy, error) {
+	if cfg == nil || cfg.Mode == models.ClientAuthNone {
+		return nil, nil
+	}
+
+	policy := &clientAuthPolicy{}
+	if cfg.CACertPath != "" {
+		pool, err := loadClientCAs(cfg.CACertPath)
+		if err != nil {
+			return nil, err
+		}
+		policy.cas = pool
+	}
+
+	switch cfg.Mode {
+	case models.ClientAuthRequest:
+		policy.mode = tls.RequestClientCert
+	case models.ClientAuthRequire:
+		if policy.cas == nil {
+			return nil, fmt.Errorf("client auth mode \"require\" needs a client CA (ca_cert_path)")
+		}
+		policy.mode = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("unknown client auth mode %q (use request or require)", cfg.Mode)
+	}
+	return policy, nil
+}
+
+// loadClientCAs reads a PEM bundle of the CAs client certificates are verified against
+func loadClientCAs(path string) (*x509.CertPool, error) {
+	caPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in client CA %s", path)
+	}
+	return pool, nil
+}
+
+// ValidateClientAuth checks the client certificate settings of the HTTPS listener, including that the CA loads
+func ValidateClientAuth(cfg *models.ClientAuthConfig) error {
+	_, err := buildClientAuthPolicy(cfg)
+	return err
+}
+
+// reloadClientAuth swaps in the client certificate policy of the current config
+func (s *HTTPServer) reloadClientAuth() error {
+	s.configMutex.RLock()
+	cfg := s.config.ClientAuth
+	s.configMutex.RUnlock()
+
+	policy, err := buildClientAuthPolicy(cfg)
+	if err != nil {
+		return err
+	}
+	s.clientAuth.Store(policy)
+	return nil
+}
+
+// clientAuthConfig returns the TLS settings of one HTTPS handshake: base (the server's TLSConfig)
+// with the current client certificate policy, or nil to use base as it is
+func (s *HTTPServer) clientAuthConfig(base *tls.Config) (*tls.Config, error) {
+	policy := s.clientAuth.Load()
+	if policy == nil {
+		return nil, nil
+	}
+	config := base.Clone()
+	config.GetConfigForClient = nil
+	config.ClientAuth = policy.mode
+	config.ClientCAs = policy.cas
+	// ServeTLS adds HTTP/1.1 to the copy of TLSConfig it serves with, not to TLSConfig itself
+	if !slices.Contains(config.NextProtos, "http/1.1") {
+		config.NextProtos = append(config.NextProtos, "http/1.1")
+	}
+	return config, nil
+}
+
+// clientCertInfo describes the certificate the client of a TLS request presented, nil if none
+func clientCertInfo(r *http.Request) *models.ClientCertInfo {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	cert := r.TLS.PeerCertificates[0]
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	info := &models.ClientCertInfo{
+		Subject:        cert.Subject.String(),
+		CommonName:     cert.Subject.CommonName,
+		Issuer:         cert.Issuer.String(),
+		SerialNumber:   fmt.Sprintf("%X", cert.SerialNumber),
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		NotBefore:      cert.NotBefore.UTC().Format(time.RFC3339),
+		NotAfter:       cert.NotAfter.UTC().Format(time.RFC3339),
+		Fingerprint:    hex.EncodeToString(fingerprint[:]),
+		Verified:       len(r.TLS.VerifiedChains) > 0,
+	}
+	for _, ip := range cert.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		info.URIs = append(info.URIs, uri.String())
+	}
+	return info
+}
+
+// clientCertMap exposes a client certificate to scripts as request.clientCert (null without one)
+func clientCertMap(info *models.ClientCertInfo) map[string]interface{} {
+	if info == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"subject":        info.Subject,
+		"commonName":     info.CommonName,
+		"issuer":         info.Issuer,
+		"serialNumber":   info.SerialNumber,
+		"dnsNames":       info.DNSNames,
+		"emailAddresses": info.EmailAddresses,
+		"ipAddresses":    info.IPAddresses,
+		"uris":           info.URIs,
+		"notBefore":      info.NotBefore,
+		"notAfter":       info.NotAfter,
+		"fingerprint":    info.Fingerprint,
+		"verified":       info.Verified,
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -31,12 +31,18 @@ type ContainerHandler struct {
 	logger         RequestLogger
 	eventSender    EventSender // For progress and status events
 	proxyHandler   *ProxyHandler // For header manipulation
+	credentials    RegistryCredentialSource // Global registry logins for image pulls (may be nil)
+	hosts          ContainerHostSource      // Remote Docker/Podman hosts (may be nil)
+	hostsMutex     sync.Mutex               // Mutex for remote runtimes map
+	remoteRuntimes map[string]connectedHost // Connections to remote hosts, by host name
 	healthStatus   map[string]*models.HealthStatus
 	containerStatus map[string]*models.ContainerStatus // Track container running state
 	containerStats  map[string]*models.ContainerStats  // Track container resource usage
+	notReady        map[string]bool                    // Endpoints whose container runs but has not passed its readiness check
 	healthMutex    sync.RWMutex
 	statusMutex    sync.RWMutex // Mutex for container status map
 	statsMutex     sync.RWMutex // Mutex for container stats map
+	readyMutex     sync.RWMutex // Mutex for not ready map
 	stopStatusPoll chan struct{} // Channel to signal status polling goroutine to stop
 	stopStatsPoll  chan struct{} // Channel to signal stats polling goroutine to stop
 }
@@ -59,31 +65,39 @@ func sanitizeContainerName(endpointName string) string {
 }
 
 // NewContainerHandler creates a new container handler
-func NewContainerHandler(logger RequestLogger, eventSender EventSender, proxyHandler *ProxyHandler) *ContainerHandler {
+func NewContainerHandler(logger RequestLogger, eventSender EventSender, proxyHandler *ProxyHandler, credentials RegistryCredentialSource, hosts ContainerHostSource) *ContainerHandler {
 	// Detect runtime instead of hardcoding Docker
 	containerRuntime, err := runtime.DetectRuntime()
 	if err != nil {
-		log.Printf("Warning: Failed to detect container runtime: %v. Container endpoints will not be available.", err)
+		containerLog.Warn("Failed to detect container runtime: %v. Only container endpoints on remote hosts will be available.", err)
 		return &ContainerHandler{
 			logger:          logger,
 			eventSender:     eventSender,
 			proxyHandler:    proxyHandler,
+			credentials:     credentials,
+			hosts:           hosts,
+			remoteRuntimes:  make(map[string]connectedHost),
 			healthStatus:    make(map[string]*models.HealthStatus),
 			containerStatus: make(map[string]*models.ContainerStatus),
 			containerStats:  make(map[string]*models.ContainerStats),
+			notReady:        make(map[string]bool),
 		}
 	}
 
-	log.Printf("Using container runtime: %s", containerRuntime.Name())
+	containerLog.Info("Using container runtime: %s", containerRuntime.Name())
 
 	return &ContainerHandler{
 		runtime:         containerRuntime,
 		logger:          logger,
 		eventSender:     eventSender,
 		proxyHandler:    proxyHandler,
+		credentials:     credentials,
+		hosts:           hosts,
+		remoteRuntimes:  make(map[string]connectedHost),
 		healthStatus:    make(map[string]*models.HealthStatus),
 		containerStatus: make(map[string]*models.ContainerStatus),
 		containerStats:  make(map[string]*models.ContainerStats),
+		notReady:        make(map[string]bool),
 		stopStatusPoll:  make(chan struct{}),
 		stopStatsPoll:   make(chan struct{}),
 	}
@@ -91,14 +105,15 @@ func NewContainerHandler(logger RequestLogger, eventSender EventSender, proxyHan
 
 // StartContainer pulls image, creates and starts a container
 func (c *ContainerHandler) StartContainer(ctx context.Context, endpoint *models.Endpoint) error {
-	if c.runtime == nil {
-		return fmt.Errorf("container runtime not available")
-	}
-
 	cfg := endpoint.ContainerConfig
 	if cfg == nil {
 		return fmt.Errorf("container configuration missing")
 	}
+	rt, err := c.runtimeFor(endpoint)
+	if err != nil {
+		c.emitProgress(endpoint.ID, "error", err.Error(), 0)
+		return err
+	}
 
 	// Generate container name from endpoint name
 	containerName := sanitizeContainerName(endpoint.Name)
@@ -109,30 +124,45 @@ func (c *ContainerHandler) StartContainer(ctx context.Context, endpoint *models.
 	// Cleanup on error or cancellation
 	defer func() {
 		if cleanupNeeded && containerID != "" {
-			log.Printf("Cleaning up partial container: %s (%s)", containerName, containerID[:12])
+			containerLog.Info("Cleaning up partial container: %s (%s)", containerName, containerID[:12])
 			c.emitProgress(endpoint.ID, "error", "Cleaning up partial container...", 0)
 			cleanupCtx := context.Background() // Use fresh context for cleanup
-			c.runtime.StopContainer(cleanupCtx, containerID, 5)
-			c.runtime.RemoveContainer(cleanupCtx, containerID, true)
+			rt.StopContainer(cleanupCtx, containerID, 5)
+			rt.RemoveContainer(cleanupCtx, containerID, true)
 			cfg.ContainerID = ""
 		}
+		if cleanupNeeded && len(cfg.Sidecars) > 0 {
+			c.removeContainerGroup(context.Background(), rt, endpoint)
+		}
 	}()
 
 	// Check for existing container with same name and remove it
-	existingID, err := c.runtime.FindContainerByName(context.Background(), containerName)
+	existingID, err := rt.FindContainerByName(context.Background(), containerName)
 	if err == nil {
-		log.Printf("Found existing container %s (%s), removing...", containerName, existingID[:12])
-		c.runtime.StopContainer(context.Background(), existingID, 5)
-		c.runtime.RemoveContainer(context.Background(), existingID, true)
+		containerLog.Info("Found existing container %s (%s), removing...", containerName, existingID[:12])
+		rt.StopContainer(context.Background(), existingID, 5)
+		rt.RemoveContainer(context.Background(), existingID, true)
 	}
 
 	// Emit start event
 	c.emitProgress(endpoint.ID, "pulling", "Initializing container startup...", 0)
 
-	// Pull image if requested
-	if cfg.PullOnStartup {
+	// Build the image from its Dockerfile, or pull it if requested
+	imageName := containerImageName(endpoint)
+	if cfg.Build != nil {
+		if err := c.buildImage(ctx, rt, endpoint); err != nil {
+			c.emitProgress(endpoint.ID, "error", "Build failed: "+err.Error(), 0)
+			return fmt.Errorf("failed to build image: %w", err)
+		}
+		c.emitProgress(endpoint.ID, "building", "Image built successfully", 40)
+	} else if cfg.PullOnStartup {
 		c.emitProgress(endpoint.ID, "pulling", "Pulling container image: "+cfg.ImageName, 10)
-		reader, err := c.runtime.PullImage(ctx, cfg.ImageName)
+		registryAuth, err := c.registryAuth(cfg.ImageName, cfg.RegistryAuth)
+		if err != nil {
+			c.emitProgress(endpoint.ID, "error", err.Error(), 0)
+			return err
+		}
+		reader, err := rt.PullImage(ctx, cfg.ImageName, registryAuth)
 		if err != nil {
 			c.emitProgress(endpoint.ID, "error", "Failed to pull image: "+err.Error(), 0)
 			return fmt.Errorf("failed to pull image: %w", err)
@@ -157,6 +187,15 @@ func (c *ContainerHandler) StartContainer(ctx context.Context, endpoint *models.
 	default:
 	}
 
+	// Start the sidecars of a multi-container group before the main container
+	if len(cfg.Sidecars) > 0 {
+		cleanupNeeded = true // Remove sidecars that started if a later step fails
+		if err := c.startSidecars(ctx, rt, endpoint); err != nil {
+			c.emitProgress(endpoint.ID, "error", err.Error(), 0)
+			return err
+		}
+	}
+
 	// Prepare environment variables
 	c.emitProgress(endpoint.ID, "creating", "Preparing container configuration...", 50)
 	env, err := c.prepareEnvironment(cfg.Environment)
@@ -171,18 +210,33 @@ func (c *ContainerHandler) StartContainer(ctx context.Context, endpoint *models.
 	// Create runtime-agnostic container config
 	createConfig := &runtime.ContainerCreateConfig{
 		Name:         containerName,
-		Image:        cfg.ImageName,
+		Image:        imageName,
 		Env:          env,
 		ExposedPorts: []string{fmt.Sprintf("%d/tcp", cfg.ContainerPort)},
 		PortBindings: map[string]string{
 			fmt.Sprintf("%d/tcp", cfg.ContainerPort): "0", // Random host port
 		},
-		Mounts: mounts,
+		Mounts:        mounts,
+		Resources:     containerResourceLimits(cfg),
+		RestartPolicy: cfg.RestartPolicy,
+		HostNetwork:   cfg.HostNetworking,
+		DockerSocket:  cfg.DockerSocketAccess,
+	}
+	if checkPort := readinessCheckPort(cfg); cfg.Readiness != nil && cfg.Readiness.Strategy == readinessPort && checkPort != cfg.ContainerPort && !cfg.HostNetworking {
+		// Publish the port the readiness check connects to
+		port := fmt.Sprintf("%d/tcp", checkPort)
+		createConfig.ExposedPorts = append(createConfig.ExposedPorts, port)
+		createConfig.PortBindings[port] = "0"
+	}
+	c.emitPrivilegedWarnings(endpoint)
+	if len(cfg.Sidecars) > 0 {
+		createConfig.Network = containerGroupNetwork(endpoint.Name)
+		createConfig.Aliases = []string{networkAlias(cfg)}
 	}
 
 	// Create container
 	c.emitProgress(endpoint.ID, "creating", "Creating container...", 60)
-	createdContainerID, err := c.runtime.CreateContainer(ctx, createConfig)
+	createdContainerID, err := rt.CreateContainer(ctx, createConfig)
 	if err != nil {
 		c.emitProgress(endpoint.ID, "error", "Failed to create container: "+err.Error(), 0)
 		return fmt.Errorf("failed to create container: %w", err)
@@ -202,7 +256,7 @@ func (c *ContainerHandler) StartContainer(ctx context.Context, endpoint *models.
 
 	// Start container
 	c.emitProgress(endpoint.ID, "starting", "Starting container...", 75)
-	if err := c.runtime.StartContainer(ctx, containerID); err != nil {
+	if err := rt.StartContainer(ctx, containerID); err != nil {
 		c.emitProgress(endpoint.ID, "error", "Failed to start container: "+err.Error(), 0)
 		return fmt.Errorf("failed to start container: %w", err)
 	}
@@ -215,6 +269,22 @@ func (c *ContainerHandler) StartContainer(ctx context.Context, endpoint *models.
 	default:
 	}
 
+	// Wait until the application in the container can serve requests
+	if cfg.Readiness != nil {
+		c.setNotReady(endpoint.ID, true)
+		defer c.setNotReady(endpoint.ID, false)
+		port := readinessCheckPort(cfg)
+		c.emitProgress(endpoint.ID, "waiting", "Waiting for "+readinessDescription(cfg.Readiness, port)+"...", 85)
+		if err := c.waitUntilReady(ctx, rt, cfg, cfg.Readiness, containerID, port); err != nil {
+			if ctx.Err() != nil {
+				c.emitProgress(endpoint.ID, "error", "Startup cancelled by user", 0)
+				return ctx.Err()
+			}
+			c.emitProgress(endpoint.ID, "error", "Container not ready: "+err.Error(), 0)
+			return fmt.Errorf("container not ready: %w", err)
+		}
+	}
+
 	c.emitProgress(endpoint.ID, "ready", "Container ready", 100)
 
 	// Startup successful, disable cleanup
@@ -230,23 +300,31 @@ func (c *ContainerHandler) StartContainer(ctx context.Context, endpoint *models.
 
 // StopContainer stops and removes a container
 func (c *ContainerHandler) StopContainer(ctx context.Context, endpoint *models.Endpoint) error {
-	if c.runtime == nil {
-		return nil
-	}
-
 	if endpoint.ContainerConfig == nil {
 		return nil
 	}
+	rt, err := c.runtimeFor(endpoint)
+	if err != nil {
+		if endpoint.ContainerConfig.ContainerID == "" {
+			return nil // Never started on a reachable runtime, nothing to stop
+		}
+		return err
+	}
 
 	var containerID string
 	containerName := sanitizeContainerName(endpoint.Name)
 
+	// Sidecars go after the main container that uses them
+	if len(endpoint.ContainerConfig.Sidecars) > 0 {
+		defer c.removeContainerGroup(ctx, rt, endpoint)
+	}
+
 	// Try to get container ID from config
 	if endpoint.ContainerConfig.ContainerID != "" {
 		containerID = endpoint.ContainerConfig.ContainerID
 	} else {
 		// Try to find by name
-		foundID, err := c.runtime.FindContainerByName(ctx, containerName)
+		foundID, err := rt.FindContainerByName(ctx, containerName)
 		if err != nil {
 			// Container not found, nothing to stop
 			return nil
@@ -255,13 +333,13 @@ func (c *ContainerHandler) StopContainer(ctx context.Context, endpoint *models.E
 	}
 
 	timeout := 10
-	if err := c.runtime.StopContainer(ctx, containerID, timeout); err != nil {
-		log.Printf("Error stopping container: %v", err)
+	if err := rt.StopContainer(ctx, containerID, timeout); err != nil {
+		containerLog.Error("Error stopping container: %v", err)
 	}
 
 	// Remove container
-	if err := c.runtime.RemoveContainer(ctx, containerID, true); err != nil {
-		log.Printf("Error removing container: %v", err)
+	if err := rt.RemoveContainer(ctx, containerID, true); err != nil {
+		containerLog.Error("Error removing container: %v", err)
 		return err
 	}
 
@@ -273,13 +351,16 @@ func (c *ContainerHandler) StopContainer(ctx context.Context, endpoint *models.E
 	return nil
 }
 
-// prepareEnvironment evaluates JS expressions and builds environment variable list
+// prepareEnvironment resolves config variables, evaluates JS expressions and builds environment variable list
 func (c *ContainerHandler) prepareEnvironment(envVars []models.EnvironmentVar) ([]string, error) {
 	vm := goja.New()
 	var result []string
 
 	for _, envVar := range envVars {
 		value := envVar.Value
+		if c.proxyHandler != nil {
+			value = c.proxyHandler.expandVariables(value) // Config variables (${NAME})
+		}
 
 		if envVar.Expression != "" {
 			// Evaluate JS expression
@@ -333,28 +414,31 @@ func (rc *responseCapture) Write(b []byte) (int, error) {
 
 // ServeHTTP proxies requests to the running container
 func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoint *models.Endpoint, translatedPath string) {
-	if c.runtime == nil {
-		http.Error(w, "Container runtime not available", http.StatusServiceUnavailable)
-		return
-	}
-
 	cfg := endpoint.ContainerConfig
 	if cfg == nil || cfg.ContainerID == "" {
 		http.Error(w, "Container not running", http.StatusServiceUnavailable)
 		return
 	}
+	if c.isNotReady(endpoint.ID) {
+		http.Error(w, "Container starting", http.StatusServiceUnavailable)
+		return
+	}
+	rt, err := c.runtimeFor(endpoint)
+	if err != nil {
+		http.Error(w, "Container runtime not available", http.StatusServiceUnavailable)
+		return
+	}
 
 	// Get container info
-	info, err := c.runtime.InspectContainer(context.Background(), cfg.ContainerID)
+	info, err := rt.InspectContainer(context.Background(), cfg.ContainerID)
 	if err != nil {
 		http.Error(w, "Container inspection failed", http.StatusServiceUnavailable)
 		c.logErrorRequest(endpoint, r, 503, "Container inspection failed: "+err.Error())
 		return
 	}
 
-	portKey := fmt.Sprintf("%d/tcp", cfg.ContainerPort)
-	hostPort, ok := info.Ports[portKey]
-	if !ok || hostPort == "" {
+	address, ok := containerAddress(rt, cfg, info)
+	if !ok {
 		http.Error(w, "Container port not bound", http.StatusServiceUnavailable)
 		c.logErrorRequest(endpoint, r, 503, "Container port not bound")
 		return
@@ -400,7 +484,7 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 	clientFullURL := clientScheme + "://" + r.Host + r.URL.RequestURI()
 
 	// Build container URL (backend URL)
-	containerURL := fmt.Sprintf("http://127.0.0.1:%s%s", hostPort, translatedPath)
+	containerURL := fmt.Sprintf("http://%s%s", address, translatedPath)
 	if r.URL.RawQuery != "" {
 		containerURL += "?" + r.URL.RawQuery
 	}
@@ -440,8 +524,14 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 		}
 	}
 
+	// A body the proxy works on must come in a coding it can decode
+	if cfg.ProxyConfig.RewriteBodyURLs || len(cfg.ProxyConfig.BodyURLRewrites) > 0 {
+		limitAcceptEncoding(backendReq.Header)
+	}
+
 	// Apply inbound header manipulation using shared ProxyHandler
 	// This handles hop-by-hop header filtering, Host header setting, and X-Forwarded-* headers
+	_, hostPort, _ := net.SplitHostPort(address)
 	customContext := map[string]interface{}{
 		"hostPort": hostPort,
 	}
@@ -458,7 +548,8 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 	// Execute backend request and measure timing
 	// Note: Don't follow redirects - pass them through to the client
 	client := &http.Client{
-		Timeout: 30 * time.Second,
+		Transport: c.proxyHandler.backendTransport(endpoint.ID, &cfg.ProxyConfig),
+		Timeout:   30 * time.Second,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse // Don't follow redirects, return redirect response to client
 		},
@@ -469,10 +560,10 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 
 	if err != nil {
 		// Log detailed error information for debugging
-		log.Printf("Container request failed for endpoint '%s' (ID: %s): %v",
+		containerLog.Error("Container request failed for endpoint '%s' (ID: %s): %v",
 			endpoint.Name, endpoint.ID, err)
-		log.Printf("  Backend URL: %s", containerURL)
-		log.Printf("  Container ID: %s", cfg.ContainerID[:12])
+		containerLog.Info("  Backend URL: %s", containerURL)
+		containerLog.Info("  Container ID: %s", cfg.ContainerID[:12])
 
 		// Log to transaction log so it appears in UI
 		c.logErrorRequest(endpoint, r, 502, fmt.Sprintf("Container request failed: %v", err))
@@ -485,7 +576,7 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 	// Read backend response body
 	backendBodyBytes, err := io.ReadAll(backendResp.Body)
 	if err != nil {
-		log.Printf("Failed to read container response body for endpoint '%s': %v", endpoint.Name, err)
+		containerLog.Error("Failed to read container response body for endpoint '%s': %v", endpoint.Name, err)
 		c.logErrorRequest(endpoint, r, 502, fmt.Sprintf("Failed to read container response: %v", err))
 		http.Error(w, "Failed to read container response", http.StatusBadGateway)
 		return
@@ -506,7 +597,22 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 
 	backendStatusCode := backendResp.StatusCode
 	backendStatusText := http.StatusText(backendResp.StatusCode)
-	backendRespBody := string(backendBodyBytes)
+	encoding := backendResp.Header.Get("Content-Encoding")
+	backendRespBody := readableBody(backendBodyBytes, encoding, false)
+
+	// Rewrite backend URLs in the (decoded) body so links lead back through the mock server
+	clientBodyBytes := backendBodyBytes
+	clientRespBody := backendRespBody
+	bodyRewritten := false
+	if rewritesBodyURLs(&cfg.ProxyConfig, backendResp.Header) {
+		if plain, err := decodeContentEncoding(backendBodyBytes, encoding); err == nil {
+			if rewritten, changed := rewriteBodyURLs(plain, bodyURLRewrites(&cfg.ProxyConfig, containerURL, endpoint, r)); changed {
+				if encoded, err := encodeContentEncoding(rewritten, encoding); err == nil {
+					clientBodyBytes, clientRespBody, bodyRewritten = encoded, string(rewritten), true
+				}
+			}
+		}
+	}
 
 	// Copy backend response headers to client response
 	for name, values := range backendResp.Header {
@@ -514,6 +620,10 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 			w.Header().Add(name, value)
 		}
 	}
+	if bodyRewritten {
+		// The backend's length no longer matches the body
+		w.Header().Del("Content-Length")
+	}
 
 	// Rewrite redirect Location headers to route back through our proxy
 	if backendStatusCode >= 300 && backendStatusCode < 400 {
@@ -521,7 +631,7 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 			rewrittenLocation := c.rewriteRedirectLocation(location, containerURL, r.URL.Path, translatedPath, endpoint, r)
 			if rewrittenLocation != location {
 				w.Header().Set("Location", rewrittenLocation)
-				log.Printf("Container redirect rewrite: %s -> %s", location, rewrittenLocation)
+				containerLog.Debug("Container redirect rewrite: %s -> %s", location, rewrittenLocation)
 			}
 		}
 	}
@@ -539,7 +649,7 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 
 	// Write response to client
 	w.WriteHeader(backendStatusCode)
-	w.Write(backendBodyBytes)
+	w.Write(clientBodyBytes)
 
 	// Capture client completion time
 	clientCompletionTime := time.Now()
@@ -551,7 +661,7 @@ func (c *ContainerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, end
 	// Log request with full details (both client and backend sides)
 	c.logRequest(requestID, endpoint, r,
 		clientFullURL, requestHeaders, requestBody, queryParams,
-		backendStatusCode, finalRespHeaders, backendRespBody, clientDelayMs, clientRTTMs,
+		backendStatusCode, finalRespHeaders, clientRespBody, clientDelayMs, clientRTTMs,
 		backendFullURL, translatedPath, backendQueryParams, backendReqHeaders,
 		backendStatusCode, backendStatusText, backendRespHeaders, backendRespBody, backendDelayMs, backendRTTMs)
 }
@@ -676,17 +786,17 @@ func (c *ContainerHandler) healthCheckLoop(endpoint *models.Endpoint) {
 
 // performHealthCheck checks container state and optionally performs HTTP health check
 func (c *ContainerHandler) performHealthCheck(endpoint *models.Endpoint) (bool, string) {
-	if c.runtime == nil {
-		return false, "Container runtime not available"
-	}
-
 	cfg := endpoint.ContainerConfig
 	if cfg == nil || cfg.ContainerID == "" {
 		return false, "Container not configured"
 	}
+	rt, err := c.runtimeFor(endpoint)
+	if err != nil {
+		return false, err.Error()
+	}
 
 	// Check container state
-	info, err := c.runtime.InspectContainer(context.Background(), cfg.ContainerID)
+	info, err := rt.InspectContainer(context.Background(), cfg.ContainerID)
 	if err != nil {
 		return false, err.Error()
 	}
@@ -697,13 +807,12 @@ func (c *ContainerHandler) performHealthCheck(endpoint *models.Endpoint) (bool,
 
 	// HTTP health check if path specified
 	if cfg.ProxyConfig.HealthCheckPath != "" {
-		portKey := fmt.Sprintf("%d/tcp", cfg.ContainerPort)
-		hostPort, ok := info.Ports[portKey]
-		if !ok || hostPort == "" {
+		address, ok := containerAddress(rt, cfg, info)
+		if !ok {
 			return false, "Container port not bound"
 		}
 
-		healthURL := fmt.Sprintf("http://127.0.0.1:%s%s", hostPort, cfg.ProxyConfig.HealthCheckPath)
+		healthURL := fmt.Sprintf("http://%s%s", address, cfg.ProxyConfig.HealthCheckPath)
 		client := &http.Client{Timeout: 5 * time.Second}
 		resp, err := client.Get(healthURL)
 		if err != nil {
@@ -721,6 +830,24 @@ func (c *ContainerHandler) performHealthCheck(endpoint *models.Endpoint) (bool,
 	return true, ""
 }
 
+// setNotReady marks a started container as waiting for its readiness check (or ready again)
+func (c *ContainerHandler) setNotReady(endpointID string, notReady bool) {
+	c.readyMutex.Lock()
+	defer c.readyMutex.Unlock()
+	if notReady {
+		c.notReady[endpointID] = true
+	} else {
+		delete(c.notReady, endpointID)
+	}
+}
+
+// isNotReady reports whether an endpoint's container is still waiting for its readiness check
+func (c *ContainerHandler) isNotReady(endpointID string) bool {
+	c.readyMutex.RLock()
+	defer c.readyMutex.RUnlock()
+	return c.notReady[endpointID]
+}
+
 // GetHealthStatus returns the health status for an endpoint
 func (c *ContainerHandler) GetHealthStatus(endpointID string) *models.HealthStatus {
 	c.healthMutex.RLock()
@@ -739,8 +866,10 @@ func (c *ContainerHandler) logRequest(requestID string, endpoint *models.Endpoin
 		// Create RequestLog with new nested structure
 		requestLog := models.RequestLog{
 			ID:         requestID,
-			Timestamp:  time.Now().Format(time.RFC3339),
+			Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
 			EndpointID: endpoint.ID,
+			Match:      matchInfoSnapshot(r),
+			SOCKS5Info: socks5Info(r),
 		}
 
 		// Populate client request
@@ -752,6 +881,7 @@ func (c *ContainerHandler) logRequest(requestID string, endpoint *models.Endpoin
 		requestLog.ClientRequest.Body = clientReqBody
 		requestLog.ClientRequest.Protocol = r.Proto
 		requestLog.ClientRequest.SourceIP = r.RemoteAddr
+		requestLog.ClientRequest.ClientCert = clientCertInfo(r)
 		requestLog.ClientRequest.UserAgent = r.Header.Get("User-Agent")
 
 		// Populate client response
@@ -840,8 +970,10 @@ func (c *ContainerHandler) logErrorRequest(endpoint *models.Endpoint, r *http.Re
 	// Create RequestLog with error response
 	requestLog := models.RequestLog{
 		ID:         fmt.Sprintf("%d", time.Now().UnixNano()),
-		Timestamp:  time.Now().Format(time.RFC3339),
+		Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
 		EndpointID: endpoint.ID,
+		Match:      matchInfoSnapshot(r),
+		SOCKS5Info: socks5Info(r),
 	}
 
 	// Populate client request
@@ -853,6 +985,7 @@ func (c *ContainerHandler) logErrorRequest(endpoint *models.Endpoint, r *http.Re
 	requestLog.ClientRequest.Body = requestBody
 	requestLog.ClientRequest.Protocol = r.Proto
 	requestLog.ClientRequest.SourceIP = r.RemoteAddr
+	requestLog.ClientRequest.ClientCert = clientCertInfo(r)
 	requestLog.ClientRequest.UserAgent = r.Header.Get("User-Agent")
 
 	// Populate client response with error
@@ -876,8 +1009,10 @@ func (c *ContainerHandler) logPendingRequest(requestID string, endpoint *models.
 		// Create RequestLog with pending status
 		requestLog := models.RequestLog{
 			ID:         requestID,
-			Timestamp:  time.Now().Format(time.RFC3339),
+			Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
 			EndpointID: endpoint.ID,
+			Match:      matchInfoSnapshot(r),
+			SOCKS5Info: socks5Info(r),
 		}
 
 		// Populate client request (we have this data immediately)
@@ -889,6 +1024,7 @@ func (c *ContainerHandler) logPendingRequest(requestID string, endpoint *models.
 		requestLog.ClientRequest.Body = clientReqBody
 		requestLog.ClientRequest.Protocol = r.Proto
 		requestLog.ClientRequest.SourceIP = r.RemoteAddr
+		requestLog.ClientRequest.ClientCert = clientCertInfo(r)
 		requestLog.ClientRequest.UserAgent = r.Header.Get("User-Agent")
 
 		// Client response is empty (pending)
@@ -910,7 +1046,7 @@ func (c *ContainerHandler) logPendingRequest(requestID string, endpoint *models.
 // emitProgress emits a container startup progress event to the frontend
 func (c *ContainerHandler) emitProgress(endpointID, stage, message string, progress int) {
 	if c.eventSender == nil {
-		log.Printf("WARNING: eventSender is nil, cannot emit progress event")
+		containerLog.Warn("eventSender is nil, cannot emit progress event")
 		return
 	}
 
@@ -943,7 +1079,7 @@ func (c *ContainerHandler) streamPullProgress(ctx context.Context, reader io.Rea
 			break
 		} else if err != nil {
 			// Not all runtimes return valid JSON, just log and continue
-			log.Printf("Pull progress parse warning: %v", err)
+			containerLog.Warn("Pull progress parse warning: %v", err)
 			continue
 		}
 
@@ -1031,7 +1167,7 @@ func (c *ContainerHandler) updateContainerStatus(endpointID string, containerID
 	if c.eventSender != nil {
 		c.eventSender.SendEvent("ctr:status", c.containerStatus[endpointID])
 	} else {
-		log.Printf("WARNING: eventSender is nil, cannot emit container status event for %s", endpointID)
+		containerLog.Warn("eventSender is nil, cannot emit container status event for %s", endpointID)
 	}
 }
 
@@ -1096,21 +1232,21 @@ func (c *ContainerHandler) pollAllContainerStatuses(endpoints []*models.Endpoint
 
 // pollContainerStatus checks and updates container status
 func (c *ContainerHandler) pollContainerStatus(endpoint *models.Endpoint) {
-	if c.runtime == nil {
-		log.Println("WARNING: Container runtime is nil during status poll")
-		return
-	}
-
 	cfg := endpoint.ContainerConfig
 	if cfg == nil {
 		c.updateContainerStatus(endpoint.ID, "", false, "not started", false)
 		return
 	}
+	rt, err := c.runtimeFor(endpoint)
+	if err != nil {
+		containerLog.Debug("Container status poll for %s: %v", endpoint.Name, err)
+		return
+	}
 
 	// If ContainerID is not set, try to find container by name (fallback for pre-existing containers)
 	if cfg.ContainerID == "" {
 		containerName := sanitizeContainerName(endpoint.Name)
-		foundID, err := c.runtime.FindContainerByName(context.Background(), containerName)
+		foundID, err := rt.FindContainerByName(context.Background(), containerName)
 		if err != nil {
 			// Container doesn't exist by name either
 			// Check if container was explicitly deleted (status already "gone")
@@ -1129,7 +1265,7 @@ func (c *ContainerHandler) pollContainerStatus(endpoint *models.Endpoint) {
 	}
 
 	// Inspect container to get current state
-	info, err := c.runtime.InspectContainer(context.Background(), cfg.ContainerID)
+	info, err := rt.InspectContainer(context.Background(), cfg.ContainerID)
 	if err != nil {
 		// Container doesn't exist (gone)
 		c.updateContainerStatus(endpoint.ID, cfg.ContainerID, false, "gone", true)
@@ -1173,18 +1309,18 @@ func (c *ContainerHandler) pollAllContainerStats(endpoints []*models.Endpoint) {
 
 // pollContainerStats collects and updates container stats
 func (c *ContainerHandler) pollContainerStats(endpoint *models.Endpoint) {
-	if c.runtime == nil {
-		return
-	}
-
 	cfg := endpoint.ContainerConfig
 	if cfg == nil || cfg.ContainerID == "" {
 		// No stats available for non-running containers
 		return
 	}
+	rt, err := c.runtimeFor(endpoint)
+	if err != nil {
+		return
+	}
 
 	// Get container stats from runtime
-	stats, err := c.runtime.GetContainerStats(context.Background(), cfg.ContainerID)
+	stats, err := rt.GetContainerStats(context.Background(), cfg.ContainerID)
 	if err != nil {
 		// Container might be stopped or removed, skip stats collection
 		return
@@ -1223,22 +1359,23 @@ func (c *ContainerHandler) GetContainerStats(endpointID string) *models.Containe
 }
 
 // GetContainerLogs retrieves container stdout/stderr logs
-func (c *ContainerHandler) GetContainerLogs(ctx context.Context, endpointID string, tail int) (string, error) {
-	if c.runtime == nil {
-		return "", fmt.Errorf("container runtime not available")
+func (c *ContainerHandler) GetContainerLogs(ctx context.Context, endpoint *models.Endpoint, tail int) (string, error) {
+	rt, err := c.runtimeFor(endpoint)
+	if err != nil {
+		return "", err
 	}
 
 	// Get container status to find container ID
 	c.statusMutex.RLock()
-	status := c.containerStatus[endpointID]
+	status := c.containerStatus[endpoint.ID]
 	c.statusMutex.RUnlock()
 
 	if status == nil || status.ContainerID == "" {
-		return "", fmt.Errorf("container not found for endpoint %s", endpointID)
+		return "", fmt.Errorf("container not found for endpoint %s", endpoint.ID)
 	}
 
 	// Retrieve logs from runtime
-	return c.runtime.GetContainerLogs(ctx, status.ContainerID, tail)
+	return rt.GetContainerLogs(ctx, status.ContainerID, tail)
 }
 
 // StopPolling stops all container polling goroutines
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mockelot/models"
+	"mockelot/server/runtime"
+)
+
+// buildStepPattern finds the "Step 3/7 : RUN ..." lines of the build output
+var buildStepPattern = regexp.MustCompile(`^Step (\d+)/(\d+)`)
+
+// containerImageName is the image the main container runs: ImageName, or for a built image
+// without one, a name derived from the endpoint
+func containerImageName(endpoint *models.Endpoint) string {
+	cfg := endpoint.ContainerConfig
+	if cfg.ImageName == "" && cfg.Build != nil {
+		return sanitizeContainerName(endpoint.Name) + ":latest"
+	}
+	return cfg.ImageName
+}
+
+// ValidateContainerBuild checks the Dockerfile build settings of a container endpoint
+func ValidateContainerBuild(cfg *models.ContainerConfig) error {
+	if cfg == nil || cfg.Build == nil {
+		return nil
+	}
+	if strings.TrimSpace(cfg.Build.ContextPath) == "" {
+		return fmt.Errorf("building an image needs a context directory (build.context_path)")
+	}
+	return nil
+}
+
+// buildImage builds the endpoint's image from its Dockerfile, reporting the build steps as
+// "building" progress in the 10-40% range
+func (c *ContainerHandler) buildImage(ctx context.Context, rt runtime.ContainerRuntime, endpoint *models.Endpoint) error {
+	build := endpoint.ContainerConfig.Build
+	dockerfile := build.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	// Build arguments are evaluated like environment variables
+	argList, err := c.prepareEnvironment(build.Args)
+	if err != nil {
+		return fmt.Errorf("failed to prepare build arguments: %w", err)
+	}
+	args := make(map[string]string, len(argList))
+	for _, arg := range argList {
+		name, value, _ := strings.Cut(arg, "=")
+		args[name] = value
+	}
+
+	imageName := containerImageName(endpoint)
+	c.emitProgress(endpoint.ID, "building", fmt.Sprintf("Building %s from %s...", imageName, build.ContextPath), 10)
+	reader, err := rt.BuildImage(ctx, &runtime.ImageBuildConfig{
+		ContextDir: runtime.TranslatePath(build.ContextPath),
+		Dockerfile: dockerfile,
+		Tag:        imageName,
+		Args:       args,
+		Target:     build.Target,
+		NoCache:    build.NoCache,
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return c.streamBuildProgress(ctx, reader, endpoint.ID)
+}
+
+// streamBuildProgress parses Docker/Podman build output, emits progress updates and returns the
+// error the build failed with
+func (c *ContainerHandler) streamBuildProgress(ctx context.Context, reader io.Reader, endpointID string) error {
+	decoder := json.NewDecoder(reader)
+	for {
+		// Check for cancellation
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var event struct {
+			Stream      string `json:"stream"`
+			Error       string `json:"error"`
+			ErrorDetail struct {
+				Message string `json:"message"`
+			} `json:"errorDetail"`
+		}
+		if err := decoder.Decode(&event); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read build output: %w", err)
+		}
+
+		if event.Error != "" {
+			if event.ErrorDetail.Message != "" {
+				return fmt.Errorf("%s", event.ErrorDetail.Message)
+			}
+			return fmt.Errorf("%s", event.Error)
+		}
+
+		line := strings.TrimSpace(event.Stream)
+		if line == "" {
+			continue
+		}
+		containerLog.Debug("Build: %s", line)
+
+		// Progress moves with the Dockerfile steps (10-40% range)
+		if match := buildStepPattern.FindStringSubmatch(line); match != nil {
+			step, _ := strconv.Atoi(match[1])
+			total, _ := strconv.Atoi(match[2])
+			if total > 0 {
+				c.emitProgress(endpointID, "building", line, 10+30*step/total)
+			}
+		}
+	}
+}
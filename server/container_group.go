@@ -0,0 +1,218 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"mockelot/models"
+	"mockelot/server/runtime"
+)
+
+// defaultNetworkAlias is the host name of a group's main container when none is configured
+const defaultNetworkAlias = "app"
+
+// containerHostnamePattern is what a container name on the group network may look like
+var containerHostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// containerGroupNetwork names the network shared by an endpoint's containers
+func containerGroupNetwork(endpointName string) string {
+	return sanitizeContainerName(endpointName) + "-net"
+}
+
+// sidecarContainerName names a sidecar container after its endpoint, e.g. "mockelot-shop-db"
+func sidecarContainerName(endpointName, sidecarName string) string {
+	return sanitizeContainerName(endpointName + "-" + sidecarName)
+}
+
+// networkAlias is the host name of the main container on the group network
+func networkAlias(cfg *models.ContainerConfig) string {
+	if cfg.NetworkAlias != "" {
+		return cfg.NetworkAlias
+	}
+	return defaultNetworkAlias
+}
+
+// sidecarStartOrder sorts sidecars so every sidecar comes after the ones it depends on, keeping the
+// configured order otherwise
+func sidecarStartOrder(sidecars []models.SidecarContainer) ([]models.SidecarContainer, error) {
+	byName := make(map[string]int, len(sidecars))
+	for i, sidecar := range sidecars {
+		byName[sidecar.Name] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(sidecars))
+	ordered := make([]models.SidecarContainer, 0, len(sidecars))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("sidecar %q depends on itself through depends_on", sidecars[i].Name)
+		}
+		state[i] = visiting
+		for _, dependency := range sidecars[i].DependsOn {
+			j, ok := byName[dependency]
+			if !ok {
+				return fmt.Errorf("sidecar %q depends on unknown sidecar %q", sidecars[i].Name, dependency)
+			}
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		state[i] = done
+		ordered = append(ordered, sidecars[i])
+		return nil
+	}
+
+	for i := range sidecars {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// ValidateContainerGroup checks the sidecars of a container endpoint: every sidecar needs an image
+// and a unique host name, and depends_on must name other sidecars without cycles
+func ValidateContainerGroup(cfg *models.ContainerConfig) error {
+	if cfg == nil || len(cfg.Sidecars) == 0 {
+		return nil
+	}
+	if cfg.HostNetworking {
+		return fmt.Errorf("host networking cannot be used with sidecars, which reach the main container on the group network")
+	}
+
+	alias := networkAlias(cfg)
+	if !containerHostnamePattern.MatchString(alias) {
+		return fmt.Errorf("invalid network alias %q", alias)
+	}
+	seen := map[string]bool{alias: true}
+	for _, sidecar := range cfg.Sidecars {
+		if !containerHostnamePattern.MatchString(sidecar.Name) {
+			return fmt.Errorf("invalid sidecar name %q (use letters, digits, '_', '.' and '-')", sidecar.Name)
+		}
+		if seen[sidecar.Name] {
+			return fmt.Errorf("container name %q is used more than once in the group", sidecar.Name)
+		}
+		seen[sidecar.Name] = true
+		if sidecar.ImageName == "" {
+			return fmt.Errorf("sidecar %q needs an image", sidecar.Name)
+		}
+	}
+
+	_, err := sidecarStartOrder(cfg.Sidecars)
+	return err
+}
+
+// startSidecars creates the group network and starts the sidecars in dependency order. Progress is
+// reported in the 40-50% range, between pulling the main image and creating the main container.
+func (c *ContainerHandler) startSidecars(ctx context.Context, rt runtime.ContainerRuntime, endpoint *models.Endpoint) error {
+	cfg := endpoint.ContainerConfig
+	sidecars, err := sidecarStartOrder(cfg.Sidecars)
+	if err != nil {
+		return err
+	}
+
+	networkName := containerGroupNetwork(endpoint.Name)
+	if err := rt.CreateNetwork(ctx, networkName); err != nil {
+		return fmt.Errorf("failed to create network %s: %w", networkName, err)
+	}
+
+	for i, sidecar := range sidecars {
+		progress := 40 + 10*i/len(sidecars)
+		containerName := sidecarContainerName(endpoint.Name, sidecar.Name)
+
+		// Replace a sidecar left over from an earlier run
+		if existingID, err := rt.FindContainerByName(context.Background(), containerName); err == nil {
+			rt.StopContainer(context.Background(), existingID, 5)
+			rt.RemoveContainer(context.Background(), existingID, true)
+		}
+
+		if sidecar.PullOnStartup {
+			c.emitProgress(endpoint.ID, "pulling", "Pulling sidecar image: "+sidecar.ImageName, progress)
+			// The endpoint's login only applies to sidecars when it names their registry
+			var endpointCred *models.RegistryCredential
+			if cfg.RegistryAuth != nil && cfg.RegistryAuth.Registry != "" {
+				endpointCred = cfg.RegistryAuth
+			}
+			registryAuth, err := c.registryAuth(sidecar.ImageName, endpointCred)
+			if err != nil {
+				return err
+			}
+			reader, err := rt.PullImage(ctx, sidecar.ImageName, registryAuth)
+			if err != nil {
+				return fmt.Errorf("failed to pull image of sidecar %s: %w", sidecar.Name, err)
+			}
+			err = c.streamPullProgress(ctx, reader, endpoint.ID)
+			reader.Close()
+			if err != nil {
+				return fmt.Errorf("failed to pull image of sidecar %s: %w", sidecar.Name, err)
+			}
+		}
+
+		env, err := c.prepareEnvironment(sidecar.Environment)
+		if err != nil {
+			return fmt.Errorf("failed to prepare environment of sidecar %s: %w", sidecar.Name, err)
+		}
+
+		c.emitProgress(endpoint.ID, "starting", fmt.Sprintf("Starting sidecar %s (%d/%d)...", sidecar.Name, i+1, len(sidecars)), progress)
+		containerID, err := rt.CreateContainer(ctx, &runtime.ContainerCreateConfig{
+			Name:    containerName,
+			Image:   sidecar.ImageName,
+			Env:     env,
+			Mounts:  c.prepareMounts(sidecar.Volumes),
+			Cmd:     sidecar.Command,
+			Network: networkName,
+			Aliases: []string{sidecar.Name},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create sidecar %s: %w", sidecar.Name, err)
+		}
+		if err := rt.StartContainer(ctx, containerID); err != nil {
+			return fmt.Errorf("failed to start sidecar %s: %w", sidecar.Name, err)
+		}
+		containerLog.Info("Started sidecar %s (%s) for %s", sidecar.Name, containerID[:12], endpoint.Name)
+
+		// Containers depending on the sidecar start once it is ready
+		if sidecar.Readiness != nil {
+			c.emitProgress(endpoint.ID, "starting", fmt.Sprintf("Waiting for sidecar %s: %s...", sidecar.Name, readinessDescription(sidecar.Readiness, 0)), progress)
+			if err := c.waitUntilReady(ctx, rt, cfg, sidecar.Readiness, containerID, 0); err != nil {
+				return fmt.Errorf("sidecar %s is not ready: %w", sidecar.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// removeContainerGroup removes an endpoint's sidecars (dependents first) and the group network
+func (c *ContainerHandler) removeContainerGroup(ctx context.Context, rt runtime.ContainerRuntime, endpoint *models.Endpoint) {
+	sidecars, err := sidecarStartOrder(endpoint.ContainerConfig.Sidecars)
+	if err != nil {
+		sidecars = endpoint.ContainerConfig.Sidecars
+	}
+	for i := len(sidecars) - 1; i >= 0; i-- {
+		containerName := sidecarContainerName(endpoint.Name, sidecars[i].Name)
+		containerID, err := rt.FindContainerByName(ctx, containerName)
+		if err != nil {
+			continue
+		}
+		if err := rt.StopContainer(ctx, containerID, 10); err != nil {
+			containerLog.Error("Error stopping sidecar %s: %v", containerName, err)
+		}
+		if err := rt.RemoveContainer(ctx, containerID, true); err != nil {
+			containerLog.Error("Error removing sidecar %s: %v", containerName, err)
+		}
+	}
+
+	if err := rt.RemoveNetwork(ctx, containerGroupNetwork(endpoint.Name)); err != nil {
+		containerLog.Debug("Network %s not removed: %v", containerGroupNetwork(endpoint.Name), err)
+	}
+}
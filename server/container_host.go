@@ -0,0 +1,120 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"mockelot/models"
+	"mockelot/server/runtime"
+)
+
+// LocalContainerHost names the Docker/Podman daemon of this machine in ContainerConfig.ContainerHost
+const LocalContainerHost = "local"
+
+// ContainerHostSource supplies the configured container hosts; they are read whenever an endpoint's
+// runtime is looked up so changes apply without restarting the server
+type ContainerHostSource interface {
+	ContainerHosts() []models.ContainerHost
+	DefaultContainerHost() string
+}
+
+// connectedHost is a remote daemon connection and the settings it was made with
+type connectedHost struct {
+	config  models.ContainerHost
+	runtime runtime.ContainerRuntime
+}
+
+// DaemonConfig converts a container host to the runtime's daemon settings
+func DaemonConfig(host models.ContainerHost) *runtime.DaemonConfig {
+	return &runtime.DaemonConfig{
+		Host:        host.Host,
+		Runtime:     host.Runtime,
+		TLSCACert:   host.TLSCACert,
+		TLSCert:     host.TLSCert,
+		TLSKey:      host.TLSKey,
+		PublishHost: host.PublishHost,
+	}
+}
+
+// ResolveContainerHost returns the configured host an endpoint's containers run on, or nil for
+// this machine
+func ResolveContainerHost(cfg *models.ContainerConfig, hosts []models.ContainerHost, defaultHost string) (*models.ContainerHost, error) {
+	name := defaultHost
+	if cfg != nil && cfg.ContainerHost != "" {
+		name = cfg.ContainerHost
+	}
+	if name == "" || name == LocalContainerHost {
+		return nil, nil
+	}
+	for i := range hosts {
+		if hosts[i].Name == name {
+			return &hosts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("container host %q is not configured", name)
+}
+
+// runtimeFor returns the runtime of the daemon an endpoint's containers run on, connecting to
+// remote hosts on first use (and again after their settings change)
+func (c *ContainerHandler) runtimeFor(endpoint *models.Endpoint) (runtime.ContainerRuntime, error) {
+	var hosts []models.ContainerHost
+	var defaultHost string
+	if c.hosts != nil {
+		hosts = c.hosts.ContainerHosts()
+		defaultHost = c.hosts.DefaultContainerHost()
+	}
+	host, err := ResolveContainerHost(endpoint.ContainerConfig, hosts, defaultHost)
+	if err != nil {
+		return nil, err
+	}
+	if host == nil {
+		if c.runtime == nil {
+			return nil, fmt.Errorf("container runtime not available")
+		}
+		return c.runtime, nil
+	}
+
+	c.hostsMutex.Lock()
+	defer c.hostsMutex.Unlock()
+	if connected, ok := c.remoteRuntimes[host.Name]; ok && connected.config == *host {
+		return connected.runtime, nil
+	}
+	remoteRuntime, err := runtime.ConnectRuntime(DaemonConfig(*host))
+	if err != nil {
+		return nil, fmt.Errorf("container host %s: %w", host.Name, err)
+	}
+	containerLog.Info("Connected to container host %s (%s)", host.Name, host.Host)
+	c.remoteRuntimes[host.Name] = connectedHost{config: *host, runtime: remoteRuntime}
+	return remoteRuntime, nil
+}
+
+// ValidateContainerHosts checks the container hosts and that the default names one of them
+func ValidateContainerHosts(hosts []models.ContainerHost, defaultHost string) error {
+	seen := make(map[string]bool)
+	for _, host := range hosts {
+		name := strings.TrimSpace(host.Name)
+		if name == "" {
+			return fmt.Errorf("container hosts need a name")
+		}
+		if name == LocalContainerHost {
+			return fmt.Errorf("container host name %q is reserved for this machine", LocalContainerHost)
+		}
+		if seen[name] {
+			return fmt.Errorf("container host %q is defined more than once", name)
+		}
+		seen[name] = true
+		if err := runtime.ValidateDaemonHost(host.Host); err != nil {
+			return fmt.Errorf("container host %s: %w", name, err)
+		}
+		if (host.TLSCert == "") != (host.TLSKey == "") {
+			return fmt.Errorf("container host %s: TLS needs both a client certificate and a key", name)
+		}
+		if host.Runtime != "" && host.Runtime != "docker" && host.Runtime != "podman" {
+			return fmt.Errorf("container host %s: unknown runtime %q (use docker or podman)", name, host.Runtime)
+		}
+	}
+	if defaultHost != "" && defaultHost != LocalContainerHost && !seen[defaultHost] {
+		return fmt.Errorf("default container host %q is not configured", defaultHost)
+	}
+	return nil
+}
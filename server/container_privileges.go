@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net"
+	"strconv"
+
+	"mockelot/models"
+	"mockelot/server/runtime"
+)
+
+// privilegedWarnings describes the privileged options a container endpoint enables
+func privilegedWarnings(cfg *models.ContainerConfig) []string {
+	var warnings []string
+	if cfg.HostNetworking {
+		warnings = append(warnings, "Host networking is enabled: the container shares this machine's network stack and can reach every local service")
+	}
+	if cfg.DockerSocketAccess {
+		warnings = append(warnings, "Docker socket access is enabled: the container can control Docker/Podman and gains root-equivalent access to this machine")
+	}
+	return warnings
+}
+
+// emitPrivilegedWarnings logs the privileged options of a starting container and reports them
+// to the frontend as "ctr:warning" events
+func (c *ContainerHandler) emitPrivilegedWarnings(endpoint *models.Endpoint) {
+	for _, warning := range privilegedWarnings(endpoint.ContainerConfig) {
+		containerLog.Warn("Endpoint %s: %s", endpoint.Name, warning)
+		if c.eventSender != nil {
+			c.eventSender.SendEvent("ctr:warning", map[string]interface{}{
+				"endpoint_id": endpoint.ID,
+				"message":     warning,
+			})
+		}
+	}
+}
+
+// containerAddress returns the host:port the container's ContainerPort is reachable on from this
+// machine: the port itself with host networking, otherwise its published host port, on the
+// runtime's publish host
+func containerAddress(rt runtime.ContainerRuntime, cfg *models.ContainerConfig, info *runtime.ContainerInfo) (string, bool) {
+	hostPort := strconv.Itoa(cfg.ContainerPort)
+	if !cfg.HostNetworking {
+		var ok bool
+		hostPort, ok = info.Ports[hostPort+"/tcp"]
+		if !ok || hostPort == "" {
+			return "", false
+		}
+	}
+	return net.JoinHostPort(rt.PublishHost(), hostPort), true
+}
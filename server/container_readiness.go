@@ -0,0 +1,253 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"time"
+
+	"mockelot/models"
+	"mockelot/server/runtime"
+)
+
+// Readiness strategies
+const (
+	readinessLog         = "log"
+	readinessPort        = "port"
+	readinessHealthcheck = "healthcheck"
+	readinessDelay       = "delay"
+)
+
+// defaultReadinessTimeout is how long startup waits for a container without a configured timeout
+const defaultReadinessTimeout = 60 * time.Second
+
+// readinessPollInterval is how often the port and healthcheck strategies check the container
+const readinessPollInterval = 500 * time.Millisecond
+
+// ValidateContainerReadiness checks the readiness strategies of a container endpoint and its sidecars
+func ValidateContainerReadiness(cfg *models.ContainerConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if err := validateReadiness(cfg.Readiness, false); err != nil {
+		return err
+	}
+	for _, sidecar := range cfg.Sidecars {
+		if err := validateReadiness(sidecar.Readiness, true); err != nil {
+			return fmt.Errorf("sidecar %s: %w", sidecar.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateReadiness(readiness *models.ContainerReadiness, sidecar bool) error {
+	if readiness == nil {
+		return nil
+	}
+	if readiness.TimeoutSeconds < 0 {
+		return fmt.Errorf("readiness timeout cannot be negative")
+	}
+	switch readiness.Strategy {
+	case readinessLog:
+		if readiness.LogPattern == "" {
+			return fmt.Errorf("the log readiness strategy needs a log_pattern")
+		}
+		if _, err := regexp.Compile(readiness.LogPattern); err != nil {
+			return fmt.Errorf("invalid readiness log_pattern: %w", err)
+		}
+	case readinessPort:
+		if sidecar {
+			return fmt.Errorf("the port readiness strategy is not supported for sidecars, use log or healthcheck")
+		}
+		if readiness.Port < 0 || readiness.Port > 65535 {
+			return fmt.Errorf("invalid readiness port %d", readiness.Port)
+		}
+	case readinessHealthcheck:
+	case readinessDelay:
+		if readiness.DelaySeconds <= 0 {
+			return fmt.Errorf("the delay readiness strategy needs delay_seconds")
+		}
+	default:
+		return fmt.Errorf("unknown readiness strategy %q (use log, port, healthcheck or delay)", readiness.Strategy)
+	}
+	return nil
+}
+
+// readinessCheckPort is the container port the port strategy checks
+func readinessCheckPort(cfg *models.ContainerConfig) int {
+	if cfg.Readiness != nil && cfg.Readiness.Port > 0 {
+		return cfg.Readiness.Port
+	}
+	return cfg.ContainerPort
+}
+
+// readinessDescription says what a container is waiting for, for progress messages
+func readinessDescription(readiness *models.ContainerReadiness, port int) string {
+	switch readiness.Strategy {
+	case readinessLog:
+		return fmt.Sprintf("log line matching %q", readiness.LogPattern)
+	case readinessPort:
+		return fmt.Sprintf("port %d to accept connections", port)
+	case readinessHealthcheck:
+		return "image healthcheck to pass"
+	default:
+		return fmt.Sprintf("%d seconds", readiness.DelaySeconds)
+	}
+}
+
+// waitUntilReady blocks until a started container passes its readiness check. port is the container
+// port of the port strategy (only the main container has published ports).
+func (c *ContainerHandler) waitUntilReady(ctx context.Context, rt runtime.ContainerRuntime, cfg *models.ContainerConfig, readiness *models.ContainerReadiness, containerID string, port int) error {
+	timeout := defaultReadinessTimeout
+	if readiness.TimeoutSeconds > 0 {
+		timeout = time.Duration(readiness.TimeoutSeconds) * time.Second
+	}
+	if readiness.Strategy == readinessDelay {
+		// The delay is the whole wait, the timeout does not apply
+		timeout = time.Duration(readiness.DelaySeconds)*time.Second + 2*readinessPollInterval
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var err error
+	switch readiness.Strategy {
+	case readinessLog:
+		err = waitForLogLine(waitCtx, rt, containerID, readiness.LogPattern)
+	case readinessPort:
+		err = waitForPort(waitCtx, rt, cfg, containerID, port)
+	case readinessHealthcheck:
+		err = waitForHealthcheck(waitCtx, rt, containerID)
+	case readinessDelay:
+		err = waitForDelay(waitCtx, rt, containerID, time.Duration(readiness.DelaySeconds)*time.Second)
+	default:
+		err = fmt.Errorf("unknown readiness strategy %q", readiness.Strategy)
+	}
+
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("not ready after %s (waiting for %s)", timeout, readinessDescription(readiness, port))
+	}
+	return err
+}
+
+// waitForLogLine follows the container output until a line matches pattern
+func waitForLogLine(ctx context.Context, rt runtime.ContainerRuntime, containerID, pattern string) error {
+	matcher, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid log pattern: %w", err)
+	}
+	logs, err := rt.FollowContainerLogs(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("failed to read container logs: %w", err)
+	}
+	defer logs.Close()
+
+	// Close the stream on timeout so the scanner stops waiting for output
+	go func() {
+		<-ctx.Done()
+		logs.Close()
+	}()
+
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if matcher.MatchString(scanner.Text()) {
+			return nil
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := scanner.Err(); err != nil && err != io.ErrClosedPipe {
+		return fmt.Errorf("failed to read container logs: %w", err)
+	}
+	// The log stream ends when the container exits
+	return containerExitedError(rt, containerID)
+}
+
+// waitForPort waits until the container port accepts connections. Docker's userland proxy accepts
+// connections on a published port even while nothing listens in the container and closes them
+// right away, so a connection only counts once it stays open or the server sends data.
+func waitForPort(ctx context.Context, rt runtime.ContainerRuntime, cfg *models.ContainerConfig, containerID string, port int) error {
+	portCfg := *cfg
+	portCfg.ContainerPort = port
+	return pollContainer(ctx, rt, containerID, func(info *runtime.ContainerInfo) (bool, error) {
+		address, ok := containerAddress(rt, &portCfg, info)
+		if !ok {
+			return false, fmt.Errorf("container port %d is not published", port)
+		}
+		conn, err := net.DialTimeout("tcp", address, time.Second)
+		if err != nil {
+			return false, nil
+		}
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+		if _, err := conn.Read(make([]byte, 1)); err != nil {
+			var netErr net.Error
+			return errors.As(err, &netErr) && netErr.Timeout(), nil
+		}
+		return true, nil
+	})
+}
+
+// waitForHealthcheck waits until the HEALTHCHECK of the image reports healthy
+func waitForHealthcheck(ctx context.Context, rt runtime.ContainerRuntime, containerID string) error {
+	return pollContainer(ctx, rt, containerID, func(info *runtime.ContainerInfo) (bool, error) {
+		switch info.Health {
+		case "":
+			return false, fmt.Errorf("the image has no HEALTHCHECK, choose another readiness strategy")
+		case "healthy":
+			return true, nil
+		case "unhealthy":
+			return false, fmt.Errorf("container healthcheck reports unhealthy")
+		}
+		return false, nil
+	})
+}
+
+// waitForDelay waits a fixed time, failing early if the container exits
+func waitForDelay(ctx context.Context, rt runtime.ContainerRuntime, containerID string, delay time.Duration) error {
+	deadline := time.Now().Add(delay)
+	return pollContainer(ctx, rt, containerID, func(info *runtime.ContainerInfo) (bool, error) {
+		return !time.Now().Before(deadline), nil
+	})
+}
+
+// pollContainer inspects the container until check reports ready or fails, or the container exits
+func pollContainer(ctx context.Context, rt runtime.ContainerRuntime, containerID string, check func(info *runtime.ContainerInfo) (bool, error)) error {
+	ticker := time.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+	for {
+		info, err := rt.InspectContainer(ctx, containerID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to inspect container: %w", err)
+		}
+		if !info.Running {
+			return fmt.Errorf("container exited before it was ready (status: %s)", info.Status)
+		}
+		if ready, err := check(info); ready || err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// containerExitedError describes a container that stopped while startup waited for it
+func containerExitedError(rt runtime.ContainerRuntime, containerID string) error {
+	info, err := rt.InspectContainer(context.Background(), containerID)
+	if err != nil || info.Running {
+		return fmt.Errorf("container log stream ended before it was ready")
+	}
+	return fmt.Errorf("container exited before it was ready (status: %s)", info.Status)
+}
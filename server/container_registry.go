@@ -0,0 +1,67 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"mockelot/models"
+	"mockelot/server/runtime"
+)
+
+// RegistryCredentialSource supplies the global registry credentials; they are read at every pull so
+// changes apply without restarting the server
+type RegistryCredentialSource interface {
+	RegistryCredentials() []models.RegistryCredential
+}
+
+// ResolveRegistryAuth picks the login for pulling an image: endpointCred (when it names the image's
+// registry, or names none), then the global credentials for that registry, then the docker login of
+// this machine including credential helpers. Returns "" to pull anonymously.
+func ResolveRegistryAuth(imageName string, endpointCred *models.RegistryCredential, global []models.RegistryCredential) (string, error) {
+	host := runtime.RegistryHost(imageName)
+
+	if endpointCred != nil && (endpointCred.Registry == "" || runtime.SameRegistry(endpointCred.Registry, host)) {
+		return runtime.EncodeRegistryAuth(&runtime.RegistryLogin{Username: endpointCred.Username, Password: endpointCred.Password}, host)
+	}
+	for _, cred := range global {
+		if runtime.SameRegistry(cred.Registry, host) {
+			return runtime.EncodeRegistryAuth(&runtime.RegistryLogin{Username: cred.Username, Password: cred.Password}, host)
+		}
+	}
+
+	login, err := runtime.DockerConfigLogin(host)
+	if err != nil {
+		return "", err
+	}
+	return runtime.EncodeRegistryAuth(login, host)
+}
+
+// registryAuth resolves the login for pulling an image of a container endpoint
+func (c *ContainerHandler) registryAuth(imageName string, endpointCred *models.RegistryCredential) (string, error) {
+	var global []models.RegistryCredential
+	if c.credentials != nil {
+		global = c.credentials.RegistryCredentials()
+	}
+	auth, err := ResolveRegistryAuth(imageName, endpointCred, global)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry credentials for %s: %w", imageName, err)
+	}
+	return auth, nil
+}
+
+// ValidateRegistryCredentials checks that every global registry login names a registry, once
+func ValidateRegistryCredentials(creds []models.RegistryCredential) error {
+	seen := make(map[string]bool)
+	for _, cred := range creds {
+		registry := strings.TrimSpace(cred.Registry)
+		if registry == "" {
+			return fmt.Errorf("registry credentials need a registry host (e.g. ghcr.io)")
+		}
+		key := runtime.NormalizeRegistry(registry)
+		if seen[key] {
+			return fmt.Errorf("registry %s has more than one login", registry)
+		}
+		seen[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"fmt"
+
+	"mockelot/models"
+	"mockelot/server/runtime"
+)
+
+// ValidateContainerResources checks the restart policy and resource limits of a container endpoint
+func ValidateContainerResources(cfg *models.ContainerConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if _, ok := runtime.ParseRestartPolicy(cfg.RestartPolicy); !ok {
+		return fmt.Errorf("invalid restart policy %q (use no, always, unless-stopped, on-failure or on-failure:<max-retries>)", cfg.RestartPolicy)
+	}
+	if res := cfg.Resources; res != nil {
+		if res.CPUShares < 0 || res.CPUs < 0 || res.MemoryMB < 0 || res.PidsLimit < 0 {
+			return fmt.Errorf("resource limits cannot be negative")
+		}
+		if res.CPUs > 0 && res.CPUs < 0.01 {
+			return fmt.Errorf("cpus must be at least 0.01")
+		}
+		if res.MemoryMB > 0 && res.MemoryMB < 6 {
+			return fmt.Errorf("memory limit must be at least 6 MB")
+		}
+	}
+	return nil
+}
+
+// containerResourceLimits converts the endpoint's resource limits for the runtime
+func containerResourceLimits(cfg *models.ContainerConfig) runtime.ResourceLimits {
+	if cfg.Resources == nil {
+		return runtime.ResourceLimits{}
+	}
+	return runtime.ResourceLimits{
+		CPUShares:   cfg.Resources.CPUShares,
+		CPUs:        cfg.Resources.CPUs,
+		MemoryBytes: cfg.Resources.MemoryMB * 1024 * 1024,
+		PidsLimit:   cfg.Resources.PidsLimit,
+	}
+}
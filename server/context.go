@@ -2,27 +2,39 @@ package server
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+
+	"mockelot/models"
 )
 
 // RequestContext represents the data available to templates and scripts
 type RequestContext struct {
-	Method      string                 `json:"method"`
-	Path        string                 `json:"path"`
-	PathParams  map[string]string      `json:"pathParams"`
-	QueryParams map[string][]string    `json:"queryParams"`
-	Headers     map[string][]string    `json:"headers"`
-	Body        RequestBody            `json:"body"`
-	Vars        map[string]interface{} `json:"vars"` // Extracted variables from request validation
+	Method      string                    `json:"method"`
+	Path        string                    `json:"path"`
+	Protocol    string                    `json:"protocol"` // Negotiated protocol: HTTP/1.0, HTTP/1.1, HTTP/2 or HTTP/3
+	PathParams  map[string]string         `json:"pathParams"`
+	QueryParams map[string][]string       `json:"queryParams"`
+	Headers     map[string][]string       `json:"headers"`
+	Body        RequestBody               `json:"body"`
+	Vars        map[string]interface{}    `json:"vars"`       // Extracted variables from request validation
+	ClientCert  *models.ClientCertInfo    `json:"clientCert"` // TLS client certificate (mTLS), nil if none was presented
+	History     []HistoryEntry            `json:"-"`          // Previous requests to the same endpoint (script mode only)
+	Events      *EventBus                 `json:"-"`          // Event bus for events.emit/events.on (script mode only)
+	Modules     []models.ScriptModule     `json:"-"`          // Modules available to require() (script mode only)
+	Fetch       *models.ScriptFetchConfig `json:"-"`          // http.fetch settings (script mode only)
+	BaseURL     string                    `json:"-"`          // scheme://host the request was sent to; relative http.fetch URLs resolve against it
+	LocalAddr   string                    `json:"-"`          // Listener address the request arrived on; relative http.fetch calls connect to it
+	JWT         *jwtKeyring               `json:"-"`          // Keys of the jwt helpers (template and script modes)
 }
 
 // RequestBody contains parsed body data in various formats
 type RequestBody struct {
-	Raw  string                 `json:"raw"`
-	JSON interface{}            `json:"json,omitempty"`
-	Form map[string][]string    `json:"form,omitempty"`
+	Raw  string              `json:"raw"`
+	JSON interface{}         `json:"json,omitempty"`
+	Form map[string][]string `json:"form,omitempty"`
 }
 
 // BuildRequestContext creates a RequestContext from an HTTP request
@@ -30,12 +42,23 @@ func BuildRequestContext(r *http.Request, bodyBytes []byte, pathParams map[strin
 	ctx := &RequestContext{
 		Method:      r.Method,
 		Path:        r.URL.Path,
+		Protocol:    requestProtocol(r),
 		PathParams:  pathParams,
 		QueryParams: r.URL.Query(),
 		Headers:     r.Header,
 		Body: RequestBody{
 			Raw: string(bodyBytes),
 		},
+		ClientCert: clientCertInfo(r),
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	ctx.BaseURL = scheme + "://" + r.Host
+	if addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+		ctx.LocalAddr = addr.String()
 	}
 
 	// Ensure PathParams is not nil
@@ -80,10 +103,12 @@ func (ctx *RequestContext) ToMap() map[string]interface{} {
 	return map[string]interface{}{
 		"method":      ctx.Method,
 		"path":        ctx.Path,
+		"protocol":    ctx.Protocol,
 		"pathParams":  ctx.PathParams,
 		"queryParams": ctx.QueryParams,
 		"headers":     ctx.Headers,
 		"vars":        vars,
+		"clientCert":  clientCertMap(ctx.ClientCert),
 		"body": map[string]interface{}{
 			"raw":  ctx.Body.Raw,
 			"json": ctx.Body.JSON,
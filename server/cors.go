@@ -2,7 +2,6 @@ package server
 
 import (
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 	"sync"
@@ -59,7 +58,7 @@ func (cp *CORSProcessor) ProcessCORS(r *http.Request) map[string]string {
 		for _, headerExpr := range config.HeaderExpressions {
 			value, err := cp.evaluateHeaderExpression(headerExpr.Expression, reqContext)
 			if err != nil {
-				log.Printf("CORS header expression error for '%s': %v", headerExpr.Name, err)
+				serverLog.Error("CORS header expression error for '%s': %v", headerExpr.Name, err)
 				continue
 			}
 			if value != "" {
@@ -71,7 +70,7 @@ func (cp *CORSProcessor) ProcessCORS(r *http.Request) map[string]string {
 		// Execute custom script
 		scriptHeaders, err := cp.evaluateScript(config.Script, reqContext)
 		if err != nil {
-			log.Printf("CORS script execution error: %v", err)
+			serverLog.Error("CORS script execution error: %v", err)
 			// Return empty headers on error
 			return headers
 		}
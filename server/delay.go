@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// EvaluateDelayExpression evaluates a response delay expression and returns the delay in milliseconds.
+//
+// The expression sees the same read-only `request` object as script mode, plus `query(name)`,
+// `header(name)`, `bodySize` (request body length in bytes) and `delay` (the static response_delay).
+// A null/undefined result keeps the static delay. On error the static delay is returned with the error.
+func EvaluateDelayExpression(expression string, reqContext *RequestContext, defaultDelay int) (int, error) {
+	vm := goja.New()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	type delayResult struct {
+		delay int
+		err   error
+	}
+	resultChan := make(chan delayResult, 1)
+	go func() {
+		delay, err := runDelayExpression(vm, expression, reqContext, defaultDelay)
+		resultChan <- delayResult{delay: delay, err: err}
+	}()
+
+	select {
+	case result := <-resultChan:
+		return result.delay, result.err
+	case <-ctx.Done():
+		vm.Interrupt("delay expression timeout")
+		return defaultDelay, fmt.Errorf("delay expression timeout (1s limit)")
+	}
+}
+
+func runDelayExpression(vm *goja.Runtime, expression string, reqContext *RequestContext, defaultDelay int) (int, error) {
+	vm.Set("request", reqContext.ToMap())
+	vm.Set("delay", defaultDelay)
+	vm.Set("bodySize", len(reqContext.Body.Raw))
+	vm.Set("query", func(name string) string {
+		return reqContext.GetQueryParam(name)
+	})
+	vm.Set("header", func(name string) string {
+		return reqContext.GetHeader(http.CanonicalHeaderKey(name))
+	})
+
+	value, err := vm.RunString(fmt.Sprintf("(function() { return (%s); })()", expression))
+	if err != nil {
+		if jsErr, ok := err.(*goja.Exception); ok {
+			return defaultDelay, fmt.Errorf("delay expression error: %s", jsErr.Value().String())
+		}
+		return defaultDelay, fmt.Errorf("delay expression error: %v", err)
+	}
+
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return defaultDelay, nil
+	}
+
+	ms := value.ToFloat()
+	if math.IsNaN(ms) || math.IsInf(ms, 0) {
+		return defaultDelay, fmt.Errorf("delay expression returned %q, expected a number of milliseconds", value.String())
+	}
+	if ms < 0 {
+		ms = 0
+	}
+	return int(ms), nil
+}
+
+// ValidateDelayExpression checks a delay expression for syntax errors
+func ValidateDelayExpression(expression string) error {
+	if _, err := goja.Compile("", fmt.Sprintf("(function() { return (%s); })", expression), false); err != nil {
+		return fmt.Errorf("syntax error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"mockelot/models"
+)
+
+// now returns the current time according to the configured virtual clock
+func (h *ResponseHandler) now() time.Time {
+	h.configMutex.RLock()
+	defer h.configMutex.RUnlock()
+	return h.config.VirtualClock.Now()
+}
+
+// applyDeprecation returns the response with Deprecation/Sunset/Link headers stamped according to
+// its policy (or the group's), evaluated at the given time. Once the sunset has passed and the
+// policy enforces it, the returned response is a static 410 Gone.
+func applyDeprecation(resp *models.MethodResponse, group *models.ResponseGroup, now time.Time) *models.MethodResponse {
+	policy := resp.Deprecation
+	if policy == nil && group != nil {
+		policy = group.Deprecation
+	}
+	if policy == nil {
+		return resp
+	}
+
+	if announceAt, ok := parsePolicyTime(policy.AnnounceAt, "announce_at"); ok && now.Before(announceAt) {
+		return resp
+	}
+
+	headers := make(map[string]string, len(resp.Headers)+3)
+	for name, value := range resp.Headers {
+		headers[name] = value
+	}
+
+	if deprecatedAt, ok := parsePolicyTime(policy.DeprecatedAt, "deprecated_at"); ok {
+		setHeader(headers, "Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+	}
+	sunsetAt, hasSunset := parsePolicyTime(policy.SunsetAt, "sunset_at")
+	if hasSunset {
+		setHeader(headers, "Sunset", sunsetAt.UTC().Format(http.TimeFormat))
+	}
+
+	var links []string
+	if policy.Link != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="deprecation"; type="text/html"`, policy.Link))
+	}
+	if policy.SunsetLink != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="sunset"; type="text/html"`, policy.SunsetLink))
+	}
+	for _, link := range links {
+		if existing := getHeader(headers, "Link"); existing != "" {
+			setHeader(headers, "Link", existing+", "+link)
+		} else {
+			setHeader(headers, "Link", link)
+		}
+	}
+
+	stamped := *resp
+	stamped.Headers = headers
+
+	if policy.EnforceSunset && hasSunset && !now.Before(sunsetAt) {
+		stamped.ResponseMode = models.ResponseModeStatic
+		stamped.StatusCode = http.StatusGone
+		stamped.Body = fmt.Sprintf("This resource was retired on %s", sunsetAt.UTC().Format(http.TimeFormat))
+		stamped.DelayExpression = ""
+	}
+
+	return &stamped
+}
+
+// parsePolicyTime parses an RFC3339 policy date; invalid dates are logged and ignored
+func parsePolicyTime(value, field string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		serverLog.Warn("Invalid deprecation %s %q: %v", field, value, err)
+		return time.Time{}, false
+	}
+	return t, true
+}
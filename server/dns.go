@@ -0,0 +1,294 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"mockelot/models"
+)
+
+const (
+	dnsDefaultTTL      = 60
+	dnsMaxPacketSize   = 4096
+	dnsUpstreamTimeout = 3 * time.Second
+	dnsMaxCNAMEChain   = 8
+)
+
+// DNSServer answers DNS queries over UDP from the configured records and the domain takeover list,
+// forwarding everything else to an upstream resolver
+type DNSServer struct {
+	config         *models.DNSConfig
+	domainTakeover *models.DomainTakeoverConfig
+	configMutex    sync.RWMutex
+	conn           net.PacketConn
+	done           chan struct{}
+}
+
+// NewDNSServer creates a DNS server for the given config and takeover list
+func NewDNSServer(config *models.DNSConfig, domainTakeover *models.DomainTakeoverConfig) *DNSServer {
+	return &DNSServer{
+		config:         config,
+		domainTakeover: domainTakeover,
+	}
+}
+
+// Start begins answering queries
+func (d *DNSServer) Start() error {
+	port := d.config.Port
+	if port == 0 {
+		port = models.DefaultDNSPort
+	}
+
+	conn, err := net.ListenPacket("udp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on UDP port %d: %v", port, err)
+	}
+	d.conn = conn
+	d.done = make(chan struct{})
+
+	dnsLog.Info("DNS server listening on UDP port %d", port)
+	go d.serve()
+	return nil
+}
+
+// Stop closes the listener and waits for the serve loop to exit
+func (d *DNSServer) Stop() {
+	if d.conn == nil {
+		return
+	}
+	d.conn.Close()
+	<-d.done
+	dnsLog.Info("DNS server stopped")
+}
+
+// UpdateConfig replaces the records and takeover list (port changes require a restart)
+func (d *DNSServer) UpdateConfig(config *models.DNSConfig, domainTakeover *models.DomainTakeoverConfig) {
+	if config == nil {
+		return
+	}
+	d.configMutex.Lock()
+	defer d.configMutex.Unlock()
+	d.config = config
+	d.domainTakeover = domainTakeover
+}
+
+func (d *DNSServer) serve() {
+	defer close(d.done)
+	buf := make([]byte, dnsMaxPacketSize)
+	for {
+		n, addr, err := d.conn.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				dnsLog.Error("DNS server error: %v", err)
+			}
+			return
+		}
+		query := append([]byte(nil), buf[:n]...)
+		go d.handleQuery(query, addr)
+	}
+}
+
+// handleQuery answers one query packet
+func (d *DNSServer) handleQuery(query []byte, addr net.Addr) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return
+	}
+	question, err := parser.Question()
+	if err != nil {
+		return
+	}
+
+	d.configMutex.RLock()
+	config := d.config
+	domainTakeover := d.domainTakeover
+	d.configMutex.RUnlock()
+
+	answers, found := resolveMockDNS(config, domainTakeover, question)
+	if !found && config.Upstream != "" {
+		reply, err := forwardDNS(config.Upstream, query)
+		if err == nil {
+			d.conn.WriteTo(reply, addr)
+			return
+		}
+		dnsLog.Error("DNS upstream %s failed for %s: %v", config.Upstream, question.Name, err)
+	}
+
+	rcode := dnsmessage.RCodeSuccess
+	if !found {
+		rcode = dnsmessage.RCodeNameError
+	}
+	reply, err := buildDNSReply(header, question, answers, rcode)
+	if err != nil {
+		dnsLog.Error("DNS reply for %s failed: %v", question.Name, err)
+		return
+	}
+	d.conn.WriteTo(reply, addr)
+}
+
+// resolveMockDNS answers a question from the configured records, then the takeover list.
+// found is false when the name is not mocked (and should go upstream).
+func resolveMockDNS(config *models.DNSConfig, domainTakeover *models.DomainTakeoverConfig, question dnsmessage.Question) (answers []dnsmessage.Resource, found bool) {
+	ttl := uint32(config.TTL)
+	if ttl == 0 {
+		ttl = dnsDefaultTTL
+	}
+	return resolveMockName(config, domainTakeover, question.Name, question.Type, ttl, dnsMaxCNAMEChain)
+}
+
+// resolveMockName answers a name, following CNAME records to mocked targets up to depth links
+func resolveMockName(config *models.DNSConfig, domainTakeover *models.DomainTakeoverConfig, qname dnsmessage.Name, qtype dnsmessage.Type, ttl uint32, depth int) (answers []dnsmessage.Resource, found bool) {
+	name := strings.ToLower(strings.TrimSuffix(qname.String(), "."))
+	header := dnsmessage.ResourceHeader{Name: qname, Class: dnsmessage.ClassINET, TTL: ttl}
+
+	for _, record := range config.Records {
+		if !record.Enabled || !dnsNameMatches(record.Name, name) {
+			continue
+		}
+		found = true
+		resource, ok := dnsRecordResource(config, record, qtype, header)
+		if !ok {
+			continue
+		}
+		answers = append(answers, resource)
+
+		// Address queries also get the CNAME target's addresses when the target is mocked too
+		if cname, isCNAME := resource.Body.(*dnsmessage.CNAMEResource); isCNAME && qtype != dnsmessage.TypeCNAME && depth > 0 {
+			chained, _ := resolveMockName(config, domainTakeover, cname.CNAME, qtype, ttl, depth-1)
+			answers = append(answers, chained...)
+		}
+	}
+	if found {
+		return answers, true
+	}
+
+	if config.TakeoverEnabled() && domainTakenOver(domainTakeover, name) {
+		switch qtype {
+		case dnsmessage.TypeA:
+			if ip := dnsMockAddress(config, false); ip != nil {
+				answers = append(answers, dnsAddressResource(header, ip))
+			}
+		case dnsmessage.TypeAAAA:
+			if ip := dnsMockAddress(config, true); ip != nil {
+				answers = append(answers, dnsAddressResource(header, ip))
+			}
+		}
+		return answers, true
+	}
+
+	return nil, false
+}
+
+// dnsRecordResource converts a configured record to an answer for the question type (ok=false if it does not apply)
+func dnsRecordResource(config *models.DNSConfig, record models.DNSRecord, qtype dnsmessage.Type, header dnsmessage.ResourceHeader) (dnsmessage.Resource, bool) {
+	switch strings.ToUpper(record.Type) {
+	case models.DNSRecordA, models.DNSRecordAAAA:
+		v6 := strings.EqualFold(record.Type, models.DNSRecordAAAA)
+		if (v6 && qtype != dnsmessage.TypeAAAA) || (!v6 && qtype != dnsmessage.TypeA) {
+			return dnsmessage.Resource{}, false
+		}
+		ip := net.ParseIP(record.Value)
+		if record.Value == "" {
+			ip = dnsMockAddress(config, v6)
+		}
+		if ip == nil || (ip.To4() == nil) != v6 {
+			return dnsmessage.Resource{}, false
+		}
+		return dnsAddressResource(header, ip), true
+
+	case models.DNSRecordCNAME:
+		if qtype != dnsmessage.TypeCNAME && qtype != dnsmessage.TypeA && qtype != dnsmessage.TypeAAAA {
+			return dnsmessage.Resource{}, false
+		}
+		target, err := dnsmessage.NewName(dnsFQDN(record.Value))
+		if err != nil {
+			return dnsmessage.Resource{}, false
+		}
+		return dnsmessage.Resource{Header: header, Body: &dnsmessage.CNAMEResource{CNAME: target}}, true
+	}
+	return dnsmessage.Resource{}, false
+}
+
+func dnsAddressResource(header dnsmessage.ResourceHeader, ip net.IP) dnsmessage.Resource {
+	if ip4 := ip.To4(); ip4 != nil {
+		var a dnsmessage.AResource
+		copy(a.A[:], ip4)
+		return dnsmessage.Resource{Header: header, Body: &a}
+	}
+	var aaaa dnsmessage.AAAAResource
+	copy(aaaa.AAAA[:], ip.To16())
+	return dnsmessage.Resource{Header: header, Body: &aaaa}
+}
+
+// dnsMockAddress returns the address taken-over names resolve to (nil if none for the family)
+func dnsMockAddress(config *models.DNSConfig, v6 bool) net.IP {
+	if v6 {
+		return net.ParseIP(config.MockAddressV6)
+	}
+	if config.MockAddress != "" {
+		return net.ParseIP(config.MockAddress)
+	}
+	return getDefaultGatewayIP()
+}
+
+// dnsNameMatches matches a record name (exact, or "*.example.com" for any subdomain) against a query name
+func dnsNameMatches(pattern, name string) bool {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(name, "."+suffix)
+	}
+	return pattern == name
+}
+
+func dnsFQDN(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// buildDNSReply packs a response to the question with the given answers
+func buildDNSReply(query dnsmessage.Header, question dnsmessage.Question, answers []dnsmessage.Resource, rcode dnsmessage.RCode) ([]byte, error) {
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 query.ID,
+			Response:           true,
+			Authoritative:      true,
+			RecursionDesired:   query.RecursionDesired,
+			RecursionAvailable: true,
+			RCode:              rcode,
+		},
+		Questions: []dnsmessage.Question{question},
+		Answers:   answers,
+	}
+	return msg.Pack()
+}
+
+// forwardDNS relays a query packet to the upstream resolver and returns its reply
+func forwardDNS(upstream string, query []byte) ([]byte, error) {
+	if _, _, err := net.SplitHostPort(upstream); err != nil {
+		upstream = net.JoinHostPort(upstream, "53")
+	}
+	conn, err := net.DialTimeout("udp", upstream, dnsUpstreamTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(dnsUpstreamTimeout))
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, dnsMaxPacketSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
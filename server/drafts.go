@@ -0,0 +1,59 @@
+package server
+
+import (
+	"mockelot/models"
+)
+
+// publishedItems returns the items as they are served: draft responses are replaced by their last
+// published version, and drafts that were never published are left out. Items without drafts are
+// returned unchanged, so the common case does not allocate.
+func publishedItems(items []models.ResponseItem) []models.ResponseItem {
+	if !itemsHaveDrafts(items) {
+		return items
+	}
+
+	result := make([]models.ResponseItem, 0, len(items))
+	for _, item := range items {
+		switch {
+		case item.Type == "response" && item.Response != nil && item.Response.Draft:
+			if item.Response.Published != nil {
+				result = append(result, models.ResponseItem{Type: "response", Response: item.Response.Published})
+			}
+		case item.Type == "group" && item.Group != nil && responsesHaveDrafts(item.Group.Responses):
+			group := *item.Group
+			group.Responses = make([]models.MethodResponse, 0, len(item.Group.Responses))
+			for _, resp := range item.Group.Responses {
+				if !resp.Draft {
+					group.Responses = append(group.Responses, resp)
+				} else if resp.Published != nil {
+					group.Responses = append(group.Responses, *resp.Published)
+				}
+			}
+			result = append(result, models.ResponseItem{Type: "group", Group: &group})
+		default:
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func itemsHaveDrafts(items []models.ResponseItem) bool {
+	for _, item := range items {
+		if item.Response != nil && item.Response.Draft {
+			return true
+		}
+		if item.Group != nil && responsesHaveDrafts(item.Group.Responses) {
+			return true
+		}
+	}
+	return false
+}
+
+func responsesHaveDrafts(responses []models.MethodResponse) bool {
+	for i := range responses {
+		if responses[i].Draft {
+			return true
+		}
+	}
+	return false
+}
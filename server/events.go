@@ -0,0 +1,185 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	eventSubscriberBuffer = 64        // Events queued per subscriber before new ones are dropped
+	eventStreamLogLimit   = 64 * 1024 // Bytes of a streamed response kept in its request log
+	eventStreamKeepAlive  = 15 * time.Second
+)
+
+// AllEvents subscribes to every event name
+const AllEvents = "*"
+
+// BusEvent is a named event published by a script (or the app) on the event bus
+type BusEvent struct {
+	Name    string
+	Payload interface{}
+}
+
+// EventBus delivers events published by response scripts to open event-stream responses,
+// shared by all listeners
+type EventBus struct {
+	mutex       sync.RWMutex
+	subscribers map[string]map[chan BusEvent]struct{} // Event name (or AllEvents) -> subscriber channels
+}
+
+// NewEventBus creates an event bus without subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[string]map[chan BusEvent]struct{})}
+}
+
+// Publish sends an event to every subscriber of its name and returns how many received it.
+// Subscribers that are not keeping up miss the event rather than blocking the publisher.
+func (b *EventBus) Publish(name string, payload interface{}) int {
+	if b == nil || name == "" {
+		return 0
+	}
+	event := BusEvent{Name: name, Payload: payload}
+
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	delivered := 0
+	for _, key := range []string{name, AllEvents} {
+		for subscriber := range b.subscribers[key] {
+			select {
+			case subscriber <- event:
+				delivered++
+			default:
+				serverLog.Warn("Event bus: dropped %q event for a slow subscriber", name)
+			}
+		}
+	}
+	return delivered
+}
+
+// Subscribe registers for events with the given name (AllEvents for every event).
+// The returned function unsubscribes.
+func (b *EventBus) Subscribe(name string) (<-chan BusEvent, func()) {
+	subscriber := make(chan BusEvent, eventSubscriberBuffer)
+	if b == nil {
+		return subscriber, func() {}
+	}
+
+	b.mutex.Lock()
+	if b.subscribers[name] == nil {
+		b.subscribers[name] = make(map[chan BusEvent]struct{})
+	}
+	b.subscribers[name][subscriber] = struct{}{}
+	b.mutex.Unlock()
+
+	return subscriber, func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		delete(b.subscribers[name], subscriber)
+		if len(b.subscribers[name]) == 0 {
+			delete(b.subscribers, name)
+		}
+	}
+}
+
+// eventsToJS builds the script `events` object: emit(name, payload) publishes on the bus and
+// on(name) turns the current response into an event stream of that name
+func eventsToJS(vm *goja.Runtime, bus *EventBus, result *ScriptResponse) *goja.Object {
+	events := vm.NewObject()
+	events.Set("emit", func(name string, payload goja.Value) int {
+		var exported interface{}
+		if payload != nil && !goja.IsUndefined(payload) {
+			exported = payload.Export()
+		}
+		return bus.Publish(name, exported)
+	})
+	events.Set("on", func(name string) {
+		result.EventStream = name
+	})
+	return events
+}
+
+// streamEvents holds the response open as a Server-Sent Events stream, writing the body first and
+// then every event published under the given name until the client disconnects. Returns the
+// streamed text (truncated) for the request log.
+func streamEvents(w http.ResponseWriter, r *http.Request, bus *EventBus, name string, status int, body string) string {
+	events, unsubscribe := bus.Subscribe(name)
+	defer unsubscribe()
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/event-stream")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Del("Content-Length")
+
+	// Streams outlive the server's write timeout
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.WriteHeader(status)
+
+	var transcript strings.Builder
+	write := func(text string) bool {
+		if transcript.Len() < eventStreamLogLimit {
+			transcript.WriteString(text)
+		}
+		if _, err := w.Write([]byte(text)); err != nil {
+			return false
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return true
+	}
+
+	if body != "" && !write(body) {
+		return transcript.String()
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return transcript.String()
+		case event := <-events:
+			if !write(formatServerSentEvent(event)) {
+				return transcript.String()
+			}
+		case <-keepAlive.C:
+			if _, err := w.Write([]byte(": keep-alive\n\n")); err != nil {
+				return transcript.String()
+			}
+			if flusher, ok := w.(http.Flusher); ok {
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// formatServerSentEvent renders an event in text/event-stream format. String payloads are sent
+// as-is, anything else as JSON.
+func formatServerSentEvent(event BusEvent) string {
+	data, ok := event.Payload.(string)
+	if !ok {
+		encoded, err := json.Marshal(event.Payload)
+		if err != nil {
+			encoded = []byte(fmt.Sprintf("%q", fmt.Sprint(event.Payload)))
+		}
+		data = string(encoded)
+	}
+
+	var b strings.Builder
+	b.WriteString("event: " + event.Name + "\n")
+	for _, line := range strings.Split(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
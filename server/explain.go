@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"mockelot/models"
+)
+
+type matchInfoKey struct{}
+
+// matchRecord is a request's match record. Log entries can be built from other goroutines (proxy
+// responses, container requests) while matching steps are still recorded, so access is guarded.
+type matchRecord struct {
+	mutex sync.Mutex
+	info  models.MatchInfo
+}
+
+// withMatchInfo attaches a match record to the request so every log entry built from it can explain the match
+func withMatchInfo(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), matchInfoKey{}, &matchRecord{}))
+}
+
+// updateMatchInfo changes the request's match record (no-op if the request was not routed by HandleRequest)
+func updateMatchInfo(r *http.Request, update func(info *models.MatchInfo)) {
+	record, _ := r.Context().Value(matchInfoKey{}).(*matchRecord)
+	if record == nil {
+		return
+	}
+	record.mutex.Lock()
+	defer record.mutex.Unlock()
+	update(&record.info)
+}
+
+// matchInfoSnapshot returns a copy of the match record for a log entry, so later steps do not change logged entries
+func matchInfoSnapshot(r *http.Request) *models.MatchInfo {
+	record, _ := r.Context().Value(matchInfoKey{}).(*matchRecord)
+	if record == nil {
+		return nil
+	}
+	record.mutex.Lock()
+	defer record.mutex.Unlock()
+	snapshot := record.info
+	snapshot.Explanation = append([]string(nil), record.info.Explanation...)
+	return &snapshot
+}
+
+// explain appends a matching step to the request's match record
+func explain(r *http.Request, format string, args ...interface{}) {
+	step := fmt.Sprintf(format, args...)
+	updateMatchInfo(r, func(info *models.MatchInfo) {
+		info.Explanation = append(info.Explanation, step)
+	})
+}
+
+// explainEndpoint records the matched endpoint and the path translation applied
+func explainEndpoint(r *http.Request, endpoint *models.Endpoint, requestPath, translatedPath string) {
+	updateMatchInfo(r, func(info *models.MatchInfo) {
+		info.EndpointID = endpoint.ID
+		info.EndpointName = endpoint.Name
+		info.TranslatedPath = translatedPath
+	})
+
+	explain(r, "Endpoint %q (%s) matched prefix %s", endpoint.Name, endpoint.Type, endpoint.PathPrefix)
+	if translatedPath != requestPath {
+		explain(r, "Path translated %s -> %s (%s)", requestPath, translatedPath, endpoint.TranslationMode)
+	} else {
+		explain(r, "Path not translated (%s)", translationModeName(endpoint.TranslationMode))
+	}
+}
+
+// explainResponse records the response that will be served
+func explainResponse(r *http.Request, resp *models.MethodResponse, group *models.ResponseGroup, pathParams map[string]string) {
+	where := ""
+	if group != nil {
+		where = fmt.Sprintf(" in group %q", group.Name)
+	}
+	updateMatchInfo(r, func(info *models.MatchInfo) {
+		info.ResponseID = resp.ID
+		info.PathPattern = resp.PathPattern
+		if group != nil {
+			info.GroupID = group.ID
+			info.GroupName = group.Name
+		}
+	})
+	explain(r, "Matched response %s %s%s%s", strings.Join(resp.Methods, ","), resp.PathPattern, where, formatPathParams(pathParams))
+}
+
+// explainMatchOutcome records the result of matching a request against response rules
+func explainMatchOutcome(r *http.Request, resp *models.MethodResponse, group *models.ResponseGroup, pathParams map[string]string, translatedPath string) {
+	if resp == nil {
+		explain(r, "No response matched %s %s", r.Method, translatedPath)
+		return
+	}
+	explainResponse(r, resp, group, pathParams)
+}
+
+// explainValidationFailure records a candidate response skipped because request validation failed
+func explainValidationFailure(r *http.Request, resp *models.MethodResponse, reason string) {
+	explain(r, "Skipped response %s %s: validation failed: %s", strings.Join(resp.Methods, ","), resp.PathPattern, reason)
+}
+
+func translationModeName(mode string) string {
+	if mode == "" {
+		return models.TranslationModeNone
+	}
+	return mode
+}
+
+func formatPathParams(params map[string]string) string {
+	if len(params) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + params[name]
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Word lists for the faker template functions
+var (
+	fakerFirstNames = []string{
+		"Ada", "Alan", "Alice", "Amara", "Ben", "Carlos", "Chen", "Chloe", "Daniel", "Diego",
+		"Elena", "Emma", "Fatima", "Grace", "Hana", "Hugo", "Isla", "Ivan", "James", "Jin",
+		"Kai", "Leila", "Liam", "Lucia", "Maya", "Mohammed", "Nina", "Noah", "Olivia", "Omar",
+		"Priya", "Rafael", "Sara", "Sofia", "Tariq", "Theo", "Uma", "Victor", "Yuki", "Zoe",
+	}
+	fakerLastNames = []string{
+		"Adams", "Ahmed", "Brown", "Costa", "Dubois", "Garcia", "Hansen", "Ito", "Johnson", "Kim",
+		"Kowalski", "Lee", "Lopez", "Martin", "Müller", "Nakamura", "Nguyen", "Novak", "O'Brien", "Patel",
+		"Rossi", "Santos", "Schmidt", "Silva", "Smith", "Tanaka", "Taylor", "Walker", "Wang", "Williams",
+	}
+	fakerDomains = []string{"example.com", "example.org", "example.net", "mail.test", "inbox.test"}
+	fakerStreets = []string{
+		"Main St", "Oak Ave", "Maple Dr", "Cedar Ln", "Park Rd", "Elm St", "Lake View", "Hill St",
+		"River Rd", "Station Rd", "Church St", "Mill Ln", "Sunset Blvd", "High St", "King St",
+	}
+	fakerCities = []string{
+		"Springfield", "Riverside", "Fairview", "Georgetown", "Franklin", "Greenville", "Madison",
+		"Clinton", "Salem", "Ashland", "Bristol", "Dover", "Milton", "Newport", "Oxford",
+	}
+	fakerCountries = []string{
+		"United States", "Canada", "United Kingdom", "Germany", "France", "Spain", "Italy",
+		"Netherlands", "Japan", "Australia", "Brazil", "India", "Mexico", "Sweden", "Ireland",
+	}
+	fakerCompanies = []string{
+		"Acme", "Globex", "Initech", "Umbrella", "Stark", "Wayne", "Hooli", "Vandelay", "Soylent", "Tyrell",
+	}
+	fakerCompanySuffixes = []string{"Inc", "LLC", "Ltd", "Group", "Corp", "Labs"}
+	fakerLoremWords      = strings.Fields(`lorem ipsum dolor sit amet consectetur adipiscing elit sed do
+		eiusmod tempor incididunt ut labore et dolore magna aliqua enim ad minim veniam quis nostrud
+		exercitation ullamco laboris nisi aliquip ex ea commodo consequat duis aute irure in
+		reprehenderit voluptate velit esse cillum eu fugiat nulla pariatur excepteur sint occaecat
+		cupidatat non proident sunt culpa qui officia deserunt mollit anim id est laborum`)
+)
+
+// faker generates random data for one template execution. Its RNG starts from a random seed;
+// {{seed ...}} makes the rest of the template deterministic.
+type faker struct {
+	rng *rand.Rand
+}
+
+func newFaker() *faker {
+	return &faker{rng: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))}
+}
+
+// funcs returns the faker template functions bound to this faker's RNG
+func (f *faker) funcs() template.FuncMap {
+	return template.FuncMap{
+		"seed":           f.seed,
+		"uuid":           f.uuid,
+		"firstName":      func() string { return f.pick(fakerFirstNames) },
+		"lastName":       func() string { return f.pick(fakerLastNames) },
+		"name":           f.name,
+		"email":          f.email,
+		"username":       f.username,
+		"phone":          f.phone,
+		"company":        f.company,
+		"street":         f.street,
+		"city":           func() string { return f.pick(fakerCities) },
+		"country":        func() string { return f.pick(fakerCountries) },
+		"zip":            func() string { return fmt.Sprintf("%05d", f.rng.IntN(100000)) },
+		"address":        f.address,
+		"lorem":          f.lorem,
+		"loremSentence":  f.sentence,
+		"loremParagraph": f.paragraph,
+		"randomInt":      f.randomInt,
+		"randomFloat":    f.randomFloat,
+		"randomBool":     func() bool { return f.rng.IntN(2) == 1 },
+		"randomChoice":   f.randomChoice,
+		"randomString":   f.randomString,
+		"dateOffset":     dateOffset,
+		"randomDate":     f.randomDate,
+	}
+}
+
+// seed restarts the RNG from a number or string (e.g. {{seed .PathParams.id}} for stable data per ID)
+func (f *faker) seed(value interface{}) string {
+	var seed uint64
+	switch v := value.(type) {
+	case int:
+		seed = uint64(v)
+	case int64:
+		seed = uint64(v)
+	case float64:
+		seed = uint64(v)
+	default:
+		hash := fnv.New64a()
+		fmt.Fprint(hash, v)
+		seed = hash.Sum64()
+	}
+	f.rng = rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	return ""
+}
+
+func (f *faker) pick(values []string) string {
+	return values[f.rng.IntN(len(values))]
+}
+
+// uuid returns a version 4 UUID drawn from the RNG, so seeded templates produce stable IDs
+func (f *faker) uuid() string {
+	var id uuid.UUID
+	for i := range id {
+		id[i] = byte(f.rng.UintN(256))
+	}
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return id.String()
+}
+
+func (f *faker) name() string {
+	return f.pick(fakerFirstNames) + " " + f.pick(fakerLastNames)
+}
+
+func (f *faker) username() string {
+	first := strings.ToLower(f.pick(fakerFirstNames))
+	last := strings.ToLower(strings.NewReplacer("'", "", "ü", "u").Replace(f.pick(fakerLastNames)))
+	switch f.rng.IntN(3) {
+	case 0:
+		return first + "." + last
+	case 1:
+		return first + last[:1] + strconv.Itoa(f.rng.IntN(100))
+	default:
+		return first + "_" + last
+	}
+}
+
+func (f *faker) email() string {
+	return f.username() + "@" + f.pick(fakerDomains)
+}
+
+func (f *faker) phone() string {
+	return fmt.Sprintf("+1-%03d-555-%04d", 200+f.rng.IntN(800), f.rng.IntN(10000))
+}
+
+func (f *faker) company() string {
+	return f.pick(fakerCompanies) + " " + f.pick(fakerCompanySuffixes)
+}
+
+func (f *faker) street() string {
+	return fmt.Sprintf("%d %s", 1+f.rng.IntN(9999), f.pick(fakerStreets))
+}
+
+func (f *faker) address() string {
+	return fmt.Sprintf("%s, %s %05d, %s", f.street(), f.pick(fakerCities), f.rng.IntN(100000), f.pick(fakerCountries))
+}
+
+// lorem returns count lorem ipsum words (default 5)
+func (f *faker) lorem(count ...int) string {
+	n := 5
+	if len(count) > 0 && count[0] > 0 {
+		n = count[0]
+	}
+	words := make([]string, n)
+	for i := range words {
+		words[i] = f.pick(fakerLoremWords)
+	}
+	return strings.Join(words, " ")
+}
+
+func (f *faker) sentence() string {
+	words := f.lorem(6 + f.rng.IntN(8))
+	return strings.ToUpper(words[:1]) + words[1:] + "."
+}
+
+func (f *faker) paragraph() string {
+	sentences := make([]string, 3+f.rng.IntN(4))
+	for i := range sentences {
+		sentences[i] = f.sentence()
+	}
+	return strings.Join(sentences, " ")
+}
+
+// randomInt returns an integer in [min, max]
+func (f *faker) randomInt(min, max int) int {
+	if max < min {
+		min, max = max, min
+	}
+	return min + f.rng.IntN(max-min+1)
+}
+
+// randomFloat returns a number in [min, max) rounded to two decimals
+func (f *faker) randomFloat(min, max float64) float64 {
+	value := min + f.rng.Float64()*(max-min)
+	return float64(int64(value*100)) / 100
+}
+
+// randomChoice returns one of its arguments, or one element when given a single slice
+func (f *faker) randomChoice(choices ...interface{}) interface{} {
+	if len(choices) == 1 {
+		if list, ok := choices[0].([]interface{}); ok {
+			choices = list
+		} else if list, ok := choices[0].([]string); ok {
+			choices = make([]interface{}, len(list))
+			for i, v := range list {
+				choices[i] = v
+			}
+		}
+	}
+	if len(choices) == 0 {
+		return ""
+	}
+	return choices[f.rng.IntN(len(choices))]
+}
+
+// randomString returns length random letters and digits
+func (f *faker) randomString(length int) string {
+	const chars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = chars[f.rng.IntN(len(chars))]
+	}
+	return string(b)
+}
+
+// randomDate returns a random time between two offsets from now (see dateOffset), formatted
+// with an optional Go layout (default RFC3339)
+func (f *faker) randomDate(from, to string, layout ...string) (string, error) {
+	start, err := parseDateOffset(from)
+	if err != nil {
+		return "", err
+	}
+	end, err := parseDateOffset(to)
+	if err != nil {
+		return "", err
+	}
+	if end < start {
+		start, end = end, start
+	}
+	offset := start
+	if end > start {
+		offset += time.Duration(f.rng.Int64N(int64(end - start)))
+	}
+	return formatDate(time.Now().Add(offset), layout), nil
+}
+
+// dateOffset returns now shifted by an offset such as "-3d", "+2h30m" or "1w", formatted with an
+// optional Go layout (default RFC3339)
+func dateOffset(offset string, layout ...string) (string, error) {
+	d, err := parseDateOffset(offset)
+	if err != nil {
+		return "", err
+	}
+	return formatDate(time.Now().Add(d), layout), nil
+}
+
+// parseDateOffset parses a Go duration that may also use d (days) and w (weeks) units
+func parseDateOffset(offset string) (time.Duration, error) {
+	s := strings.TrimSpace(offset)
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+		s = s[1:]
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+
+	var total time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+			i++
+		}
+		j := i
+		for j < len(s) && !(s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+			j++
+		}
+		if i == 0 || i == j {
+			return 0, fmt.Errorf("invalid date offset %q", offset)
+		}
+		value, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid date offset %q", offset)
+		}
+		switch unit := s[i:j]; unit {
+		case "w":
+			total += time.Duration(value * float64(7*24*time.Hour))
+		case "d":
+			total += time.Duration(value * float64(24*time.Hour))
+		default:
+			d, err := time.ParseDuration(s[:j])
+			if err != nil {
+				return 0, fmt.Errorf("invalid date offset %q", offset)
+			}
+			total += d
+		}
+		s = s[j:]
+	}
+	return sign * total, nil
+}
+
+func formatDate(t time.Time, layout []string) string {
+	if len(layout) > 0 && layout[0] != "" {
+		return t.Format(layout[0])
+	}
+	return t.Format(time.RFC3339)
+}
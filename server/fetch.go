@@ -0,0 +1,254 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"mockelot/models"
+)
+
+const (
+	defaultFetchTimeoutMs   = 3000
+	maxFetchTimeoutMs       = 5000 // The whole script has 5s
+	defaultFetchMaxResponse = 1024 * 1024
+	maxFetchRedirects       = 10
+
+	// fetchDepthHeader counts nested fetches so scripts calling their own endpoint cannot loop forever
+	fetchDepthHeader = "X-Mockelot-Fetch-Depth"
+	maxFetchDepth    = 3
+)
+
+// setupFetch defines http.fetch(url, options) in a script runtime. The call is synchronous and
+// returns {status, statusText, ok, headers, body, text(), json()}; it throws if fetching is
+// disabled, the host is not allowed, or the request fails.
+func setupFetch(vm *goja.Runtime, reqContext *RequestContext) error {
+	depth, _ := strconv.Atoi(http.Header(reqContext.Headers).Get(fetchDepthHeader))
+
+	fetch := func(call goja.FunctionCall) goja.Value {
+		cfg := reqContext.Fetch
+		if cfg == nil || !cfg.Enabled {
+			panic(vm.NewGoError(fmt.Errorf("http.fetch is disabled (enable script_fetch in the configuration)")))
+		}
+		if depth >= maxFetchDepth {
+			panic(vm.NewGoError(fmt.Errorf("http.fetch: nested fetch depth limit (%d) reached", maxFetchDepth)))
+		}
+
+		var options map[string]interface{}
+		if arg := call.Argument(1); !goja.IsUndefined(arg) && !goja.IsNull(arg) {
+			options, _ = arg.Export().(map[string]interface{})
+		}
+
+		resp, err := doFetch(cfg, reqContext, call.Argument(0).String(), options, depth)
+		if err != nil {
+			panic(vm.NewGoError(fmt.Errorf("http.fetch: %v", err)))
+		}
+		return fetchResponseToJS(vm, resp)
+	}
+
+	httpObj := vm.NewObject()
+	if err := httpObj.Set("fetch", fetch); err != nil {
+		return err
+	}
+	return vm.Set("http", httpObj)
+}
+
+// fetchResult is a completed outbound request
+type fetchResult struct {
+	status  int
+	headers http.Header
+	body    []byte
+}
+
+// doFetch performs one http.fetch call
+func doFetch(cfg *models.ScriptFetchConfig, reqContext *RequestContext, rawURL string, options map[string]interface{}, depth int) (*fetchResult, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %v", rawURL, err)
+	}
+
+	// Relative URLs call this server, through the listener the script's request arrived on
+	local := !target.IsAbs()
+	if local {
+		base, err := url.Parse(reqContext.BaseURL)
+		if err != nil || reqContext.BaseURL == "" {
+			return nil, fmt.Errorf("relative URL %q cannot be resolved", rawURL)
+		}
+		target = base.ResolveReference(target)
+	} else {
+		if target.Scheme != "http" && target.Scheme != "https" {
+			return nil, fmt.Errorf("unsupported scheme %q", target.Scheme)
+		}
+		if !fetchHostAllowed(cfg.AllowedHosts, target) {
+			return nil, fmt.Errorf("host %s is not in allowed_hosts", target.Host)
+		}
+	}
+
+	method := http.MethodGet
+	if m, ok := options["method"].(string); ok && m != "" {
+		method = strings.ToUpper(m)
+	}
+
+	timeoutMs := cfg.TimeoutMs
+	switch t := options["timeout"].(type) {
+	case int64:
+		timeoutMs = int(t)
+	case float64:
+		timeoutMs = int(t)
+	}
+	if timeoutMs <= 0 {
+		timeoutMs = defaultFetchTimeoutMs
+	}
+	if timeoutMs > maxFetchTimeoutMs {
+		timeoutMs = maxFetchTimeoutMs
+	}
+
+	// An object body is sent as JSON
+	var body io.Reader
+	jsonBody := false
+	switch b := options["body"].(type) {
+	case nil:
+	case string:
+		body = strings.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode body: %v", err)
+		}
+		body = bytes.NewReader(data)
+		jsonBody = true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if headers, ok := options["headers"].(map[string]interface{}); ok {
+		for name, value := range headers {
+			req.Header.Set(name, fmt.Sprint(value))
+		}
+	}
+	if jsonBody && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(fetchDepthHeader, strconv.Itoa(depth+1))
+
+	client := &http.Client{
+		Timeout: time.Duration(timeoutMs) * time.Millisecond,
+		CheckRedirect: func(next *http.Request, via []*http.Request) error {
+			if len(via) >= maxFetchRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxFetchRedirects)
+			}
+			// A redirect must not reach a host the script could not fetch directly; local
+			// fetches always dial this server's listener, wherever they are redirected
+			if !local && !fetchHostAllowed(cfg.AllowedHosts, next.URL) {
+				return fmt.Errorf("redirect to host %s is not in allowed_hosts", next.URL.Host)
+			}
+			return nil
+		},
+	}
+	if local && reqContext.LocalAddr != "" {
+		transport := localFetchTransport(reqContext.LocalAddr)
+		defer transport.CloseIdleConnections()
+		client.Transport = transport
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	maxBytes := cfg.MaxResponseBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultFetchMaxResponse
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxBytes)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBytes {
+		return nil, fmt.Errorf("response from %s exceeds %d bytes", target.Host, maxBytes)
+	}
+	return &fetchResult{status: resp.StatusCode, headers: resp.Header, body: data}, nil
+}
+
+// localFetchTransport connects to this server's listener whatever the URL's host resolves to,
+// so virtual hosts work, and accepts the server's own (often self-signed) certificate
+func localFetchTransport(localAddr string) *http.Transport {
+	dialer := &net.Dialer{}
+	return &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, localAddr)
+		},
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+}
+
+// fetchHostAllowed matches a URL's host against allowed_hosts entries: a host name (any port),
+// host:port, or a "*.example.com" wildcard
+func fetchHostAllowed(allowed []string, target *url.URL) bool {
+	hostname := target.Hostname()
+	for _, pattern := range allowed {
+		if pattern == "*" {
+			return true
+		}
+		if strings.Contains(pattern, ":") && !strings.HasPrefix(pattern, "[") {
+			if strings.EqualFold(pattern, target.Host) || strings.EqualFold(pattern, hostname+":"+fetchPort(target)) {
+				return true
+			}
+			continue
+		}
+		if matchBypassDomain(strings.Trim(pattern, "[]"), hostname) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchPort returns a URL's port, defaulting by scheme
+func fetchPort(target *url.URL) string {
+	if port := target.Port(); port != "" {
+		return port
+	}
+	if target.Scheme == "https" {
+		return "443"
+	}
+	return "80"
+}
+
+// fetchResponseToJS builds the object http.fetch returns
+func fetchResponseToJS(vm *goja.Runtime, resp *fetchResult) goja.Value {
+	headers := make(map[string]interface{})
+	for name, values := range resp.headers {
+		headers[strings.ToLower(name)] = strings.Join(values, ", ")
+	}
+	body := string(resp.body)
+
+	obj := vm.NewObject()
+	obj.Set("status", resp.status)
+	obj.Set("statusText", http.StatusText(resp.status))
+	obj.Set("ok", resp.status >= 200 && resp.status < 300)
+	obj.Set("headers", headers)
+	obj.Set("body", body)
+	obj.Set("text", func() string { return body })
+	obj.Set("json", func() goja.Value {
+		var data interface{}
+		if err := json.Unmarshal(resp.body, &data); err != nil {
+			panic(vm.NewGoError(fmt.Errorf("http.fetch: response is not JSON: %v", err)))
+		}
+		return vm.ToValue(data)
+	})
+	return obj
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mockelot/models"
+)
+
+// ApplyFieldMappings rewrites a JSON body with declarative field mapping rules, in order. Bodies
+// that are not JSON are returned unchanged, as are rules whose source field does not exist.
+func ApplyFieldMappings(body []byte, rules []models.FieldMapping) ([]byte, bool) {
+	if len(rules) == 0 {
+		return body, false
+	}
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return body, false
+	}
+
+	changed := false
+	for _, rule := range rules {
+		if ValidateFieldMapping(rule) != nil {
+			continue // Reported with the script warnings when the config is loaded
+		}
+		path := splitFieldPath(rule.Path)
+		switch rule.Op {
+		case models.FieldMappingRename:
+			changed = renameField(doc, path, rule.To) || changed
+		case models.FieldMappingRemove:
+			changed = removeField(doc, path) || changed
+		case models.FieldMappingSet:
+			var ok bool
+			doc, ok = setField(doc, path, rule.Value)
+			changed = ok || changed
+		case models.FieldMappingMove:
+			values := collectFields(doc, path)
+			if len(values) == 0 {
+				continue
+			}
+			removeField(doc, path)
+			// A wildcard source moved to a single destination becomes an array of the values
+			var value interface{} = values[0]
+			if strings.Contains(rule.Path, "*") {
+				value = values
+			}
+			doc, _ = setField(doc, splitFieldPath(rule.To), value)
+			changed = true
+		}
+	}
+	if !changed {
+		return body, false
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return body, false
+	}
+	return out, true
+}
+
+// ValidateFieldMapping checks that a rule has a known operation and the fields it needs
+func ValidateFieldMapping(rule models.FieldMapping) error {
+	if rule.Path == "" {
+		return fmt.Errorf("%s rule needs a path", rule.Op)
+	}
+	switch rule.Op {
+	case models.FieldMappingRemove, models.FieldMappingSet:
+	case models.FieldMappingRename:
+		if rule.To == "" || strings.Contains(rule.To, ".") {
+			return fmt.Errorf("rename of %s needs a new field name (without dots) in to", rule.Path)
+		}
+	case models.FieldMappingMove:
+		if rule.To == "" || strings.Contains(rule.To, "*") {
+			return fmt.Errorf("move of %s needs a destination path (without *) in to", rule.Path)
+		}
+	default:
+		return fmt.Errorf("unknown field mapping operation %q (use rename, remove, set or move)", rule.Op)
+	}
+	return nil
+}
+
+func splitFieldPath(path string) []string {
+	return strings.Split(strings.Trim(path, "."), ".")
+}
+
+// forEachParent calls fn with every container holding the last segment of path
+func forEachParent(node interface{}, path []string, fn func(parent interface{}, key string)) {
+	if len(path) == 1 {
+		fn(node, path[0])
+		return
+	}
+	for _, child := range fieldChildren(node, path[0]) {
+		forEachParent(child, path[1:], fn)
+	}
+}
+
+// fieldChildren returns the values a path segment selects in a node
+func fieldChildren(node interface{}, segment string) []interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if child, ok := n[segment]; ok {
+			return []interface{}{child}
+		}
+	case []interface{}:
+		if segment == "*" {
+			return n
+		}
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(n) {
+			return []interface{}{n[index]}
+		}
+	}
+	return nil
+}
+
+func renameField(doc interface{}, path []string, to string) bool {
+	changed := false
+	forEachParent(doc, path, func(parent interface{}, key string) {
+		if obj, ok := parent.(map[string]interface{}); ok {
+			if value, exists := obj[key]; exists {
+				delete(obj, key)
+				obj[to] = value
+				changed = true
+			}
+		}
+	})
+	return changed
+}
+
+func removeField(doc interface{}, path []string) bool {
+	changed := false
+	forEachParent(doc, path, func(parent interface{}, key string) {
+		if obj, ok := parent.(map[string]interface{}); ok {
+			if _, exists := obj[key]; exists {
+				delete(obj, key)
+				changed = true
+			}
+		}
+	})
+	return changed
+}
+
+// collectFields returns the values a path selects, in document order
+func collectFields(doc interface{}, path []string) []interface{} {
+	var values []interface{}
+	forEachParent(doc, path, func(parent interface{}, key string) {
+		values = append(values, fieldChildren(parent, key)...)
+	})
+	return values
+}
+
+// setField sets the value at path, creating missing objects along the way. Wildcards set the
+// field in every array element. Returns the (possibly new) root and whether anything was set.
+func setField(node interface{}, path []string, value interface{}) (interface{}, bool) {
+	if len(path) == 0 {
+		return value, true
+	}
+	segment := path[0]
+	switch n := node.(type) {
+	case []interface{}:
+		changed := false
+		if segment == "*" {
+			for i := range n {
+				var ok bool
+				n[i], ok = setField(n[i], path[1:], value)
+				changed = ok || changed
+			}
+		} else if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(n) {
+			n[index], changed = setField(n[index], path[1:], value)
+		}
+		return n, changed
+	case map[string]interface{}:
+		if segment == "*" {
+			return n, false
+		}
+		child, ok := setField(n[segment], path[1:], value)
+		if ok {
+			n[segment] = child
+		}
+		return n, ok
+	case nil:
+		if segment == "*" {
+			return nil, false
+		}
+		obj := make(map[string]interface{})
+		child, ok := setField(nil, path[1:], value)
+		if ok {
+			obj[segment] = child
+		}
+		return obj, ok
+	default:
+		// A scalar is in the way
+		return node, false
+	}
+}
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mockelot/models"
+)
+
+const generatorChunkSize = 64 * 1024
+
+// generatorReader returns a reader producing a generator's uncompressed body on demand
+func generatorReader(gen *models.BodyGenerator) (io.Reader, error) {
+	switch gen.Kind {
+	case models.GeneratorZeros:
+		return io.LimitReader(zeroReader{}, gen.SizeBytes), nil
+	case models.GeneratorRandom:
+		return io.LimitReader(rand.New(rand.NewSource(time.Now().UnixNano())), gen.SizeBytes), nil
+	case models.GeneratorRepeat:
+		if gen.Pattern == "" {
+			return nil, fmt.Errorf("repeat generator needs a pattern")
+		}
+		return io.LimitReader(&repeatReader{pattern: []byte(gen.Pattern)}, gen.SizeBytes), nil
+	case models.GeneratorJSONArray:
+		return &jsonArrayReader{count: gen.Count, element: gen.Element}, nil
+	default:
+		return nil, fmt.Errorf("unknown body generator %q", gen.Kind)
+	}
+}
+
+// generatedLength returns the uncompressed body length when it is known up front (-1 otherwise)
+func generatedLength(gen *models.BodyGenerator) int64 {
+	switch gen.Kind {
+	case models.GeneratorZeros, models.GeneratorRandom, models.GeneratorRepeat:
+		return gen.SizeBytes
+	case models.GeneratorJSONArray:
+		if gen.Element == "" {
+			return -1 // Default elements embed their index
+		}
+		if gen.Count <= 0 {
+			return 2
+		}
+		return 2 + gen.Count*int64(len(gen.Element)) + gen.Count - 1
+	}
+	return -1
+}
+
+// streamGenerated writes a generated body (gzip-compressed on the fly if configured) without buffering it.
+// Returns a summary of what was sent for the request log.
+func streamGenerated(w http.ResponseWriter, gen *models.BodyGenerator, status int) string {
+	reader, err := generatorReader(gen)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err.Error()
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		if gen.Kind == models.GeneratorJSONArray {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "application/octet-stream")
+		}
+	}
+	if gen.Gzip {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Del("Content-Length")
+	} else if length := generatedLength(gen); length >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	}
+
+	// Large bodies outlive the server's write timeout
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.WriteHeader(status)
+
+	wire := &countingWriter{w: w}
+	var dest io.Writer = wire
+	var gz *gzip.Writer
+	if gen.Gzip {
+		gz, _ = gzip.NewWriterLevel(wire, gzip.BestCompression)
+		dest = gz
+	}
+
+	generated, copyErr := io.CopyBuffer(dest, reader, make([]byte, generatorChunkSize))
+	if gz != nil && copyErr == nil {
+		copyErr = gz.Close()
+	}
+
+	summary := fmt.Sprintf("[generated %s body: %d bytes", gen.Kind, generated)
+	if gen.Gzip {
+		summary += fmt.Sprintf(", %d bytes gzip on the wire", wire.n)
+	}
+	if copyErr != nil {
+		summary += ", client disconnected"
+	}
+	return summary + "]"
+}
+
+// zeroReader yields an endless stream of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// repeatReader yields a pattern over and over
+type repeatReader struct {
+	pattern []byte
+	offset  int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		copied := copy(p[n:], r.pattern[r.offset:])
+		n += copied
+		r.offset = (r.offset + copied) % len(r.pattern)
+	}
+	return n, nil
+}
+
+// jsonArrayReader yields a JSON array of count elements, rendering one element at a time
+type jsonArrayReader struct {
+	count   int64
+	element string
+	next    int64 // Index of the next element to render
+	started bool
+	done    bool
+	pending []byte
+}
+
+func (r *jsonArrayReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.pending) == 0 && !r.fill() {
+			break
+		}
+		copied := copy(p[n:], r.pending)
+		r.pending = r.pending[copied:]
+		n += copied
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// fill renders the next piece of the array; returns false once the array is complete
+func (r *jsonArrayReader) fill() bool {
+	switch {
+	case r.done:
+		return false
+	case !r.started:
+		r.started = true
+		r.pending = append(r.pending[:0], '[')
+	case r.next >= r.count:
+		r.done = true
+		r.pending = append(r.pending[:0], ']')
+	default:
+		r.pending = r.pending[:0]
+		if r.next > 0 {
+			r.pending = append(r.pending, ',')
+		}
+		if r.element != "" {
+			r.pending = append(r.pending, r.element...)
+		} else {
+			r.pending = append(r.pending, `{"id":`...)
+			r.pending = strconv.AppendInt(r.pending, r.next, 10)
+			r.pending = append(r.pending, '}')
+		}
+		r.next++
+	}
+	return true
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
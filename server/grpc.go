@@ -0,0 +1,485 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/bufbuild/protocompile/linker"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"mockelot/models"
+)
+
+// GRPCServer serves mock responses for the services described by .proto files
+type GRPCServer struct {
+	config            *models.GRPCConfig
+	configMutex       sync.RWMutex
+	files             linker.Files
+	methods           map[string]protoreflect.MethodDescriptor // "/package.Service/Method" -> descriptor
+	requestLogger     RequestLogger
+	scriptErrorLogger ScriptErrorLogger
+	events            *EventBus
+	server            *grpc.Server
+	listener          net.Listener
+}
+
+// NewGRPCServer compiles the configured .proto files and prepares the listener
+func NewGRPCServer(config *models.GRPCConfig, requestLogger RequestLogger, scriptErrorLogger ScriptErrorLogger, events *EventBus) (*GRPCServer, error) {
+	files, err := compileProtoFiles(config)
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make(map[string]protoreflect.MethodDescriptor)
+	for _, file := range files {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			service := services.Get(i)
+			for j := 0; j < service.Methods().Len(); j++ {
+				method := service.Methods().Get(j)
+				methods["/"+string(service.FullName())+"/"+string(method.Name())] = method
+			}
+		}
+	}
+
+	return &GRPCServer{
+		config:            config,
+		files:             files,
+		methods:           methods,
+		requestLogger:     requestLogger,
+		scriptErrorLogger: scriptErrorLogger,
+		events:            events,
+	}, nil
+}
+
+// Start begins accepting gRPC connections
+func (g *GRPCServer) Start() error {
+	port := g.config.Port
+	if port == 0 {
+		port = models.DefaultGRPCPort
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %v", port, err)
+	}
+
+	g.server = grpc.NewServer(grpc.UnknownServiceHandler(g.handleStream))
+	if g.config.ReflectionEnabled() {
+		options := reflection.ServerOptions{
+			Services:           g,
+			DescriptorResolver: g.files.AsResolver(),
+		}
+		reflectionv1.RegisterServerReflectionServer(g.server, reflection.NewServerV1(options))
+		reflectionv1alpha.RegisterServerReflectionServer(g.server, reflection.NewServer(options))
+	}
+	g.listener = listener
+
+	serverLog.Info("gRPC server listening on port %d (%d methods)", port, len(g.methods))
+	go func() {
+		if err := g.server.Serve(listener); err != nil {
+			serverLog.Error("gRPC server error: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop stops the listener, letting in-flight calls finish for up to five seconds
+func (g *GRPCServer) Stop() {
+	if g.server == nil {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		g.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		g.server.Stop()
+	}
+	serverLog.Info("gRPC server stopped")
+}
+
+// UpdateConfig replaces the method responses (proto and port changes require a restart)
+func (g *GRPCServer) UpdateConfig(config *models.GRPCConfig) {
+	if config == nil {
+		return
+	}
+	g.configMutex.Lock()
+	defer g.configMutex.Unlock()
+	g.config = config
+}
+
+// GetServiceInfo implements reflection.ServiceInfoProvider for the services in the loaded protos
+func (g *GRPCServer) GetServiceInfo() map[string]grpc.ServiceInfo {
+	info := make(map[string]grpc.ServiceInfo)
+	for _, file := range g.files {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			service := services.Get(i)
+			serviceInfo := grpc.ServiceInfo{Metadata: file.Path()}
+			for j := 0; j < service.Methods().Len(); j++ {
+				method := service.Methods().Get(j)
+				serviceInfo.Methods = append(serviceInfo.Methods, grpc.MethodInfo{
+					Name:           string(method.Name()),
+					IsClientStream: method.IsStreamingClient(),
+					IsServerStream: method.IsStreamingServer(),
+				})
+			}
+			info[string(service.FullName())] = serviceInfo
+		}
+	}
+	return info
+}
+
+// handleStream answers every call (unary and streaming) from the configured method responses
+func (g *GRPCServer) handleStream(_ interface{}, stream grpc.ServerStream) error {
+	startTime := time.Now()
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+	method, known := g.methods[fullMethod]
+
+	incoming, _ := metadata.FromIncomingContext(stream.Context())
+	requestLog := buildGRPCRequestLog(stream.Context(), fullMethod, incoming)
+
+	if !known {
+		err := status.Errorf(codes.Unimplemented, "unknown method %s", fullMethod)
+		g.logCall(requestLog, err, nil, startTime)
+		return err
+	}
+
+	// Read the request message(s)
+	var requests []proto.Message
+	for {
+		request := dynamicpb.NewMessage(method.Input())
+		if err := stream.RecvMsg(request); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		requests = append(requests, request)
+		if !method.IsStreamingClient() {
+			break
+		}
+	}
+	requestJSON, requestValue := encodeMessages(requests, method.IsStreamingClient())
+	requestLog.ClientRequest.Body = requestJSON
+
+	resp := g.findMethodResponse(fullMethod)
+	if resp == nil {
+		err := status.Errorf(codes.Unimplemented, "no mock response configured for %s", fullMethod)
+		g.logCall(requestLog, err, nil, startTime)
+		return err
+	}
+
+	code, message, body, responseMetadata, delay, err := g.processMethodResponse(resp, fullMethod, incoming, requestJSON, requestValue)
+	if err != nil {
+		if g.scriptErrorLogger != nil && resp.ID != "" {
+			g.scriptErrorLogger.LogScriptError(resp.ID, fullMethod, "gRPC", err.Error())
+		}
+		requestLog.ResponseFailed = true
+		callErr := status.Errorf(codes.Internal, "mock response failed: %v", err)
+		g.logCall(requestLog, callErr, nil, startTime)
+		return callErr
+	}
+
+	if delay > 0 {
+		time.Sleep(time.Duration(delay) * time.Millisecond)
+	}
+	if len(responseMetadata) > 0 {
+		stream.SetHeader(metadata.New(responseMetadata))
+	}
+	requestLog.ClientResponse.Headers = metadataToHeaders(responseMetadata)
+
+	if codes.Code(code) != codes.OK {
+		callErr := status.Error(codes.Code(code), message)
+		g.logCall(requestLog, callErr, nil, startTime)
+		return callErr
+	}
+
+	responses, err := decodeMessages(body, method.Output(), method.IsStreamingServer())
+	if err != nil {
+		requestLog.ResponseFailed = true
+		callErr := status.Errorf(codes.Internal, "invalid response body for %s: %v", method.Output().FullName(), err)
+		g.logCall(requestLog, callErr, nil, startTime)
+		return callErr
+	}
+	for _, response := range responses {
+		if err := stream.SendMsg(response); err != nil {
+			return err
+		}
+	}
+
+	g.logCall(requestLog, nil, responses, startTime)
+	return nil
+}
+
+// findMethodResponse returns the first enabled response configured for a method
+func (g *GRPCServer) findMethodResponse(fullMethod string) *models.GRPCMethodResponse {
+	g.configMutex.RLock()
+	defer g.configMutex.RUnlock()
+	name := strings.TrimPrefix(fullMethod, "/")
+	for i := range g.config.Methods {
+		resp := &g.config.Methods[i]
+		if resp.IsEnabled() && strings.TrimPrefix(resp.Method, "/") == name {
+			copied := *resp
+			return &copied
+		}
+	}
+	return nil
+}
+
+// processMethodResponse renders a method response in its mode and returns the gRPC code, message,
+// response body (protobuf JSON), response metadata and delay
+func (g *GRPCServer) processMethodResponse(resp *models.GRPCMethodResponse, fullMethod string, incoming metadata.MD, requestJSON string, requestValue interface{}) (int, string, string, map[string]string, int, error) {
+	service, methodName, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	reqContext := &RequestContext{
+		Method:      "POST",
+		Path:        fullMethod,
+		Protocol:    "HTTP/2",
+		PathParams:  map[string]string{"service": service, "method": methodName},
+		QueryParams: map[string][]string{},
+		Headers:     map[string][]string(incoming),
+		Body:        RequestBody{Raw: requestJSON, JSON: requestValue},
+		Events:      g.events,
+	}
+
+	responseMetadata := make(map[string]string, len(resp.Metadata))
+	for key, value := range resp.Metadata {
+		responseMetadata[key] = value
+	}
+
+	switch resp.ResponseMode {
+	case models.ResponseModeTemplate:
+		body, err := ProcessTemplate(resp.Body, reqContext)
+		if err != nil {
+			return 0, "", "", nil, 0, err
+		}
+		return resp.StatusCode, resp.StatusMessage, body, responseMetadata, resp.Delay, nil
+
+	case models.ResponseModeScript:
+		result, err := ProcessScript(resp.ScriptBody, reqContext, &models.MethodResponse{
+			StatusCode:    resp.StatusCode,
+			Headers:       responseMetadata,
+			Body:          resp.Body,
+			ResponseDelay: resp.Delay,
+		})
+		if err != nil {
+			return 0, "", "", nil, 0, err
+		}
+		return result.Status, resp.StatusMessage, result.Body, result.Headers, result.Delay, nil
+
+	default:
+		return resp.StatusCode, resp.StatusMessage, resp.Body, responseMetadata, resp.Delay, nil
+	}
+}
+
+// logCall completes and sends the request log for a call
+func (g *GRPCServer) logCall(requestLog models.RequestLog, callErr error, responses []proto.Message, startTime time.Time) {
+	if g.requestLogger == nil {
+		return
+	}
+
+	callStatus := status.Convert(callErr)
+	requestLog.GRPCInfo.StatusCode = int(callStatus.Code())
+	requestLog.GRPCInfo.Status = grpcCodeName(callStatus.Code())
+	requestLog.GRPCInfo.Messages = len(responses)
+
+	// gRPC always answers HTTP 200; the call status travels in the grpc-status trailer
+	httpStatus := 200
+	rttMs := time.Since(startTime).Milliseconds()
+	requestLog.ClientResponse.StatusCode = &httpStatus
+	requestLog.ClientResponse.StatusText = requestLog.GRPCInfo.Status
+	requestLog.ClientResponse.RTTMs = &rttMs
+	if requestLog.ClientResponse.Headers == nil {
+		requestLog.ClientResponse.Headers = make(map[string][]string)
+	}
+	requestLog.ClientResponse.Headers["grpc-status"] = []string{fmt.Sprintf("%d", callStatus.Code())}
+	if callErr != nil {
+		requestLog.ClientResponse.Headers["grpc-message"] = []string{callStatus.Message()}
+		requestLog.ClientResponse.Body = callStatus.Message()
+	} else {
+		requestLog.ClientResponse.Body, _ = encodeMessages(responses, len(responses) != 1)
+	}
+
+	g.requestLogger.LogRequest(requestLog)
+}
+
+// buildGRPCRequestLog creates the log entry for a call with its client-side request fields
+func buildGRPCRequestLog(ctx context.Context, fullMethod string, incoming metadata.MD) models.RequestLog {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	requestLog := models.RequestLog{
+		ID:        uuid.New().String(),
+		Timestamp: time.Now().Format(models.RequestLogTimeFormat),
+		GRPCInfo:  &models.GRPCRequestInfo{Service: service, Method: method},
+	}
+
+	authority := ""
+	if values := incoming.Get(":authority"); len(values) > 0 {
+		authority = values[0]
+	}
+	requestLog.ClientRequest.Method = "POST"
+	requestLog.ClientRequest.FullURL = "grpc://" + authority + fullMethod
+	requestLog.ClientRequest.Path = fullMethod
+	requestLog.ClientRequest.Headers = map[string][]string(incoming.Copy())
+	requestLog.ClientRequest.Protocol = "gRPC"
+	if values := incoming.Get("user-agent"); len(values) > 0 {
+		requestLog.ClientRequest.UserAgent = values[0]
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		requestLog.ClientRequest.SourceIP = p.Addr.String()
+	}
+	return requestLog
+}
+
+// encodeMessages renders messages as protobuf JSON (an array for streams) and as a generic value for scripts
+func encodeMessages(messages []proto.Message, stream bool) (string, interface{}) {
+	marshal := protojson.MarshalOptions{Multiline: true, Indent: "  "}
+	var parts []string
+	var values []interface{}
+	for _, message := range messages {
+		data, err := marshal.Marshal(message)
+		if err != nil {
+			data = []byte(fmt.Sprintf("%q", err.Error()))
+		}
+		parts = append(parts, string(data))
+		var value interface{}
+		json.Unmarshal(data, &value)
+		values = append(values, value)
+	}
+
+	if !stream && len(parts) == 1 {
+		return parts[0], values[0]
+	}
+	return "[\n" + strings.Join(parts, ",\n") + "\n]", values
+}
+
+// decodeMessages parses a protobuf JSON body into response messages; server-streaming
+// methods accept a JSON array with one element per message
+func decodeMessages(body string, descriptor protoreflect.MessageDescriptor, stream bool) ([]proto.Message, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		body = "{}"
+	}
+
+	var raw []json.RawMessage
+	if stream && strings.HasPrefix(body, "[") {
+		if err := json.Unmarshal([]byte(body), &raw); err != nil {
+			return nil, err
+		}
+	} else {
+		raw = []json.RawMessage{json.RawMessage(body)}
+	}
+
+	messages := make([]proto.Message, 0, len(raw))
+	for _, data := range raw {
+		message := dynamicpb.NewMessage(descriptor)
+		if err := protojson.Unmarshal(data, message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func metadataToHeaders(md map[string]string) map[string][]string {
+	headers := make(map[string][]string, len(md))
+	for key, value := range md {
+		headers[key] = []string{value}
+	}
+	return headers
+}
+
+// grpcCodeName returns the canonical name of a status code (e.g., "NOT_FOUND")
+func grpcCodeName(code codes.Code) string {
+	if code == codes.OK {
+		return "OK"
+	}
+	name := code.String()
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+// compileProtoFiles parses the configured .proto files (well-known types are always available)
+func compileProtoFiles(config *models.GRPCConfig) (linker.Files, error) {
+	if len(config.ProtoFiles) == 0 {
+		return nil, fmt.Errorf("no .proto files configured")
+	}
+
+	importPaths := append([]string(nil), config.ImportPaths...)
+	var names []string
+	for _, path := range config.ProtoFiles {
+		dir, name := filepath.Split(path)
+		if dir == "" {
+			dir = "."
+		}
+		importPaths = append(importPaths, dir)
+		names = append(names, name)
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: importPaths}),
+	}
+	files, err := compiler.Compile(context.Background(), names...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile proto files: %v", err)
+	}
+	return files, nil
+}
+
+// ListGRPCMethods compiles the configured .proto files and describes every method they define
+func ListGRPCMethods(config *models.GRPCConfig) ([]models.GRPCMethodInfo, error) {
+	files, err := compileProtoFiles(config)
+	if err != nil {
+		return nil, err
+	}
+
+	example := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}
+	var methods []models.GRPCMethodInfo
+	for _, file := range files {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			service := services.Get(i)
+			for j := 0; j < service.Methods().Len(); j++ {
+				method := service.Methods().Get(j)
+				body, _ := example.Marshal(dynamicpb.NewMessage(method.Output()))
+				methods = append(methods, models.GRPCMethodInfo{
+					Method:          string(service.FullName()) + "/" + string(method.Name()),
+					InputType:       string(method.Input().FullName()),
+					OutputType:      string(method.Output().FullName()),
+					ClientStreaming: method.IsStreamingClient(),
+					ServerStreaming: method.IsStreamingServer(),
+					ExampleBody:     string(body),
+				})
+			}
+		}
+	}
+	return methods, nil
+}
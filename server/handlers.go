@@ -2,8 +2,9 @@ package server
 
 import (
 	"bytes"
+	"encoding/base64"
+	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"regexp"
 	"strings"
@@ -33,10 +34,19 @@ type ResponseHandler struct {
 	containerHandler  *ContainerHandler
 	overlayHandler    *OverlayHandler
 	regexCache        map[string]*regexp.Regexp // Cache for compiled regexes
+	regexErrors       map[string]error          // Negative cache for patterns that failed to compile
 	regexCacheMutex   sync.RWMutex              // Mutex for regex cache
+	startedAt         time.Time                 // When this handler started serving (reported by health endpoints)
+	history           *RequestHistory           // Previous requests per endpoint, exposed to response scripts
+	events            *EventBus                 // Events published by scripts, streamed by event-stream responses
+	scheduler         *Scheduler                // Fired scheduled actions override enabled states and error modes
+	perfStats         *PerfStats                // Template/script execution cost per response
+	sequences         *SequenceTracker          // Call counts of sequence responses
+	throttle          *ClientThrottle           // Token buckets of client throttle rules
+	jwks              *jwksCache                // Key sets fetched for JWT auth
 }
 
-func NewResponseHandler(config *models.AppConfig, logger RequestLogger, scriptErrorLogger ScriptErrorLogger, proxyHandler *ProxyHandler, containerHandler *ContainerHandler) *ResponseHandler {
+func NewResponseHandler(config *models.AppConfig, logger RequestLogger, scriptErrorLogger ScriptErrorLogger, proxyHandler *ProxyHandler, containerHandler *ContainerHandler, history *RequestHistory, events *EventBus, scheduler *Scheduler, perfStats *PerfStats, sequences *SequenceTracker, throttle *ClientThrottle) *ResponseHandler {
 	overlayHandler := NewOverlayHandler(proxyHandler)
 	return &ResponseHandler{
 		config:            config,
@@ -46,11 +56,21 @@ func NewResponseHandler(config *models.AppConfig, logger RequestLogger, scriptEr
 		proxyHandler:      proxyHandler,
 		containerHandler:  containerHandler,
 		overlayHandler:    overlayHandler,
+		history:           history,
+		events:            events,
+		scheduler:         scheduler,
+		perfStats:         perfStats,
+		sequences:         sequences,
+		throttle:          throttle,
+		jwks:              newJWKSCache(),
 		regexCache:        make(map[string]*regexp.Regexp),
+		regexErrors:       make(map[string]error),
+		startedAt:         time.Now(),
 	}
 }
 
 // compileRegex compiles a regex pattern and caches it
+// Failed compiles are cached too, so a broken pattern is logged once instead of on every request
 func (h *ResponseHandler) compileRegex(pattern string) (*regexp.Regexp, error) {
 	// Check cache first (read lock)
 	h.regexCacheMutex.RLock()
@@ -58,11 +78,19 @@ func (h *ResponseHandler) compileRegex(pattern string) (*regexp.Regexp, error) {
 		h.regexCacheMutex.RUnlock()
 		return re, nil
 	}
+	if err, failed := h.regexErrors[pattern]; failed {
+		h.regexCacheMutex.RUnlock()
+		return nil, err
+	}
 	h.regexCacheMutex.RUnlock()
 
 	// Compile regex (outside lock to avoid blocking readers)
 	re, err := regexp.Compile(pattern)
 	if err != nil {
+		h.regexCacheMutex.Lock()
+		h.regexErrors[pattern] = err
+		h.regexCacheMutex.Unlock()
+		serverLog.Warn("Invalid regex pattern %q: %v (ignored until the pattern is fixed)", pattern, err)
 		return nil, err
 	}
 
@@ -78,13 +106,27 @@ func (h *ResponseHandler) compileRegex(pattern string) (*regexp.Regexp, error) {
 func (h *ResponseHandler) InvalidateRegexCache() {
 	h.regexCacheMutex.Lock()
 	h.regexCache = make(map[string]*regexp.Regexp)
+	h.regexErrors = make(map[string]error)
 	h.regexCacheMutex.Unlock()
 }
 
 func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request) {
-	// Read request body
-	bodyBytes, _ := io.ReadAll(r.Body)
+	// Reserved health/readiness paths are answered by the server itself
+	if h.handleReservedPath(w, r) {
+		return
+	}
+
+	// Read request body (bounded by the configured body size limit)
+	h.configMutex.RLock()
+	maxBodyBytes := h.config.Limits.WithDefaults().MaxBodyBytes
+	h.configMutex.RUnlock()
+	bodyBytes, tooLarge := readLimitedBody(w, r, maxBodyBytes)
+	if tooLarge {
+		http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
 	r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	r = withMatchInfo(r)
 
 	h.configMutex.RLock()
 	requestPath := r.URL.Path
@@ -100,7 +142,12 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 	if len(h.config.Endpoints) > 0 {
 		for i := range h.config.Endpoints {
 			endpoint := &h.config.Endpoints[i]
-			if !endpoint.IsEnabled() {
+			if !h.scheduler.endpointEnabled(endpoint) {
+				continue
+			}
+
+			// Endpoints with their own port or named listener are only served by that listener
+			if !servesOnListener(endpoint, r) {
 				continue
 			}
 
@@ -115,7 +162,6 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 				// Regex matching with capture groups
 				re, err := h.compileRegex(endpoint.PathPrefix)
 				if err != nil {
-					log.Printf("Invalid regex pattern: %s (%v)", endpoint.PathPrefix, err)
 					prefixMatches = false
 				} else {
 					matches := re.FindStringSubmatch(requestPath)
@@ -137,6 +183,12 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 				}
 			}
 
+			// Plugin endpoint types can decline requests beyond the path prefix
+			if prefixMatches && !pluginMatches(endpoint, r) {
+				prefixMatches = false
+				captureGroups = nil
+			}
+
 			if prefixMatches {
 				matchedEndpoint = endpoint
 
@@ -150,7 +202,6 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 						// Regex strip: find what matched and remove it
 						re, err := h.compileRegex(endpoint.PathPrefix)
 						if err != nil {
-							log.Printf("Invalid regex pattern for strip: %s (%v)", endpoint.PathPrefix, err)
 							translatedPath = requestPath
 						} else {
 							matched := re.FindString(requestPath)
@@ -172,7 +223,6 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 					if endpoint.TranslatePattern != "" {
 						re, err := h.compileRegex(endpoint.TranslatePattern)
 						if err != nil {
-							log.Printf("Invalid regex pattern in endpoint %s: %v", endpoint.Name, err)
 							translatedPath = requestPath
 						} else {
 							translatedPath = re.ReplaceAllString(requestPath, endpoint.TranslateReplace)
@@ -198,7 +248,7 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 			if h.overlayHandler != nil && h.overlayHandler.shouldUseOverlay(requestDomain, domainTakeover) {
 				// Use overlay mode - proxy to real server
 				if err := h.overlayHandler.handleOverlay(w, r, requestDomain); err != nil {
-					log.Printf("Overlay mode error: %v", err)
+					serverLog.Error("Overlay mode error: %v", err)
 					http.Error(w, "Overlay mode failed", http.StatusBadGateway)
 				}
 				return
@@ -209,8 +259,47 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
+		explainEndpoint(r, matchedEndpoint, requestPath, translatedPath)
+
+		// Clients over their throttle rule's rate are refused before anything else
+		if decision := h.throttle.check(h.config.ClientThrottles, matchedEndpoint.ID, r); decision != nil {
+			h.configMutex.RUnlock()
+			h.handleThrottled(w, r, matchedEndpoint.ID, decision, bodyBytes)
+			return
+		}
+
+		// Scheduled error mode answers every request to the endpoint
+		if fault := h.scheduler.endpointError(matchedEndpoint.ID); fault != nil {
+			h.configMutex.RUnlock()
+			h.handleScheduledError(w, r, matchedEndpoint, fault, bodyBytes)
+			return
+		}
+
+		// Endpoints requiring credentials refuse requests without valid ones
+		if auth := matchedEndpoint.Auth; auth != nil && auth.Enabled {
+			h.configMutex.RUnlock()
+			if !h.authenticate(w, r, matchedEndpoint, bodyBytes) {
+				return
+			}
+			h.configMutex.RLock()
+		}
+
+		// Offline mode: proxy/container endpoints are answered from their recorded snapshot
+		if h.config.OfflineMode && servesOffline(matchedEndpoint) {
+			snapshot := h.offlineSnapshot(matchedEndpoint.ID)
+			h.configMutex.RUnlock()
+			h.handleOfflineRequest(w, r, snapshot, translatedPath, bodyBytes)
+			return
+		}
+
 		// Dispatch based on endpoint type
 		h.configMutex.RUnlock()
+		if matchedEndpoint.Type != models.EndpointTypeMock && h.injectEndpointFault(w, r, matchedEndpoint, bodyBytes) {
+			return
+		}
+		if matchedEndpoint.Type != models.EndpointTypeMock {
+			w = limitBandwidth(w, r, matchedEndpoint.BandwidthKBps)
+		}
 		switch matchedEndpoint.Type {
 		case models.EndpointTypeMock:
 			h.handleMockRequest(w, r, matchedEndpoint, translatedPath, bodyBytes)
@@ -219,13 +308,20 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 		case models.EndpointTypeContainer:
 			h.handleContainerRequest(w, r, matchedEndpoint, translatedPath)
 		default:
-			http.Error(w, "Unknown endpoint type", http.StatusInternalServerError)
+			h.handlePluginRequest(w, r, matchedEndpoint, translatedPath, captureGroups, bodyBytes)
 		}
 		return
 	} else {
 		// Fallback: No endpoints configured, use legacy Items
 		translatedPath = requestPath
-		items = h.config.Items
+		items = h.scheduler.scheduledItems(publishedItems(h.config.Items))
+		explain(r, "No endpoints configured; matching legacy items")
+
+		if decision := h.throttle.check(h.config.ClientThrottles, "", r); decision != nil {
+			h.configMutex.RUnlock()
+			h.handleThrottled(w, r, "", decision, bodyBytes)
+			return
+		}
 	}
 
 	// Check if this is a CORS preflight that should be handled globally
@@ -246,6 +342,7 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 	var matchedGroup *models.ResponseGroup
 	var pathParams map[string]string
 	var extractedVars map[string]interface{}
+	var rejection *ValidationResult // Schema violation answered with 400
 
 	// Iterate through items to preserve group information
 	for _, item := range items {
@@ -267,7 +364,7 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 			}
 
 			// Check if path matches and extract path parameters (using translated path)
-			if methodMatches {
+			if methodMatches && protocolMatches(resp.Protocols, r) {
 				matchResult := matchPathPatternWithParams(resp.PathPattern, translatedPath)
 				if matchResult.Matches {
 					// Build initial context for validation (without vars yet)
@@ -277,7 +374,13 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 					validationResult := ValidateRequest(resp.RequestValidation, string(bodyBytes), tempContext)
 					if !validationResult.Valid {
 						// Validation failed - log and continue to next response
-						log.Printf("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+						serverLog.Warn("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+						explainValidationFailure(r, resp, validationResult.Error)
+						if validationResult.Reject {
+							// Answered with 400 once the config lock is released
+							rejection = validationResult
+							break
+						}
 
 						// Log validation failure (no HTTP response sent)
 						requestLog := buildRequestLog(r, bodyBytes, endpointID)
@@ -322,7 +425,7 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 				}
 
 				// Check if path matches and extract path parameters (using translated path)
-				if methodMatches {
+				if methodMatches && protocolMatches(resp.Protocols, r) {
 					matchResult := matchPathPatternWithParams(resp.PathPattern, translatedPath)
 					if matchResult.Matches {
 						// Build initial context for validation (without vars yet)
@@ -332,7 +435,13 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 						validationResult := ValidateRequest(resp.RequestValidation, string(bodyBytes), tempContext)
 						if !validationResult.Valid {
 							// Validation failed - log and continue to next response
-							log.Printf("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+							serverLog.Warn("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+							explainValidationFailure(r, resp, validationResult.Error)
+							if validationResult.Reject {
+								// Answered with 400 once the config lock is released
+								rejection = validationResult
+								break
+							}
 
 							// Log validation failure (no HTTP response sent)
 							requestLog := buildRequestLog(r, bodyBytes, endpointID)
@@ -354,20 +463,27 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 				}
 			}
 
-			if matchedResponse != nil {
+			if matchedResponse != nil || rejection != nil {
 				break
 			}
 		}
 
-		if matchedResponse != nil {
+		if matchedResponse != nil || rejection != nil {
 			break
 		}
 	}
 
 	// Fallback to legacy responses if no items matched and no endpoints configured
-	if matchedResponse == nil && len(items) == 0 && len(h.config.Endpoints) == 0 {
+	if matchedResponse == nil && rejection == nil && len(items) == 0 && len(h.config.Endpoints) == 0 {
 		for i := range h.config.Responses {
 			resp := &h.config.Responses[i]
+			// Drafts are served as last published, or not at all
+			if resp.Draft {
+				if resp.Published == nil {
+					continue
+				}
+				resp = resp.Published
+			}
 			// Skip disabled responses
 			if !resp.IsEnabled() {
 				continue
@@ -383,7 +499,7 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 			}
 
 			// Check if path matches and extract path parameters (using translated path)
-			if methodMatches {
+			if methodMatches && protocolMatches(resp.Protocols, r) {
 				matchResult := matchPathPatternWithParams(resp.PathPattern, translatedPath)
 				if matchResult.Matches {
 					// Build initial context for validation (without vars yet)
@@ -393,7 +509,13 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 					validationResult := ValidateRequest(resp.RequestValidation, string(bodyBytes), tempContext)
 					if !validationResult.Valid {
 						// Validation failed - log and continue to next response
-						log.Printf("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+						serverLog.Warn("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+						explainValidationFailure(r, resp, validationResult.Error)
+						if validationResult.Reject {
+							// Answered with 400 once the config lock is released
+							rejection = validationResult
+							break
+						}
 
 						// Log validation failure (no HTTP response sent)
 						requestLog := buildRequestLog(r, bodyBytes, endpointID)
@@ -415,8 +537,21 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 			}
 		}
 	}
+	explainMatchOutcome(r, matchedResponse, matchedGroup, pathParams, translatedPath)
+
+	// Fill in defaults inherited from the group (legacy items have no endpoint)
+	if matchedResponse != nil && matchedGroup != nil && matchedGroup.Defaults != nil {
+		effectiveResponse := models.ApplyResponseDefaults(*matchedResponse, matchedGroup.Defaults)
+		matchedResponse = &effectiveResponse
+	}
 	h.configMutex.RUnlock()
 
+	// Schema violations on a rejecting response are answered with 400
+	if rejection != nil {
+		h.rejectInvalidRequest(w, r, bodyBytes, endpointID, rejection)
+		return
+	}
+
 	// Deep copy headers to avoid reference issues
 	headersCopy := make(map[string][]string, len(r.Header))
 	for key, values := range r.Header {
@@ -438,6 +573,18 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Pick the variant negotiated from the variant header (adds Vary)
+	matchedResponse = selectResponseVariant(matchedResponse, r)
+
+	// Apply the overrides for the request's HTTP protocol version
+	matchedResponse = selectProtocolVariant(matchedResponse, r)
+
+	// Serve the current step of a sequence response
+	matchedResponse = h.sequences.nextStep(matchedResponse, r)
+
+	// Stamp deprecation headers (or retire the response) according to the virtual clock
+	matchedResponse = applyDeprecation(matchedResponse, matchedGroup, h.now())
+
 	// Apply CORS headers if needed
 	if h.shouldApplyCORS(matchedResponse, matchedGroup, r) {
 		corsHeaders := h.corsProcessor.ProcessCORS(r)
@@ -450,7 +597,7 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 	startTime := time.Now()
 
 	// Process response based on mode
-	finalBody, finalHeaders, finalStatus, finalDelay, responseErr := h.processResponse(
+	finalBody, finalHeaders, finalStatus, finalDelay, eventStream, responseErr := h.processResponse(
 		matchedResponse, r, bodyBytes, pathParams, extractedVars,
 	)
 
@@ -468,24 +615,65 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Generated bodies are streamed in place of the configured body
+	generator := matchedResponse.Generator
+	headerQuirks := matchedResponse.HeaderQuirks
+
+	// Apply Range handling (partial content, 416, multipart/byteranges); event streams and generated bodies are never ranged
+	if eventStream == "" && generator == nil {
+		finalStatus, finalHeaders, finalBody = applyRangeMode(matchedResponse.RangeMode, r, finalStatus, finalHeaders, finalBody)
+	}
+
+	// Chaos mode: added latency, then possibly an injected error, a reset or a truncated body
+	fault := planFault(resolveFaultInjection(nil, matchedResponse), r, eventStream == "" && generator == nil)
+	finalDelay += fault.latencyMs
+	if fault.kind == faultError {
+		finalStatus, finalBody, eventStream, generator, headerQuirks = fault.status, fault.body, "", nil, nil
+		finalHeaders = map[string]string{"Content-Type": "text/plain; charset=utf-8"}
+	}
+
 	// Implement response delay
 	if finalDelay > 0 {
 		time.Sleep(time.Duration(finalDelay) * time.Millisecond)
 	}
 
+	if fault.kind == faultReset {
+		h.logFaultReset(r, bodyBytes, endpointID)
+		resetConnection(w)
+		return
+	}
+
 	// Set headers
 	for name, value := range finalHeaders {
 		w.Header().Set(name, value)
 	}
+	rawHead := headerQuirks != nil && headerQuirks.Raw && eventStream == "" && generator == nil && fault.kind != faultTruncate
+	if headerQuirks != nil && !rawHead {
+		applyHeaderQuirks(w, headerQuirks)
+	}
+
+	// Slow-network simulation paces the body at the configured rate
+	w = limitBandwidth(w, r, resolveBandwidth(nil, matchedResponse))
 
 	// Capture time before first byte (right before WriteHeader)
 	firstByteTime := time.Now()
 
-	// Set status code
-	w.WriteHeader(finalStatus)
-
-	// Write response body
-	w.Write([]byte(finalBody))
+	// Set status code and write response body (event streams stay open until the client disconnects)
+	if eventStream != "" {
+		finalBody = streamEvents(w, r, h.events, eventStream, finalStatus, finalBody)
+	} else if generator != nil {
+		finalBody = streamGenerated(w, generator, finalStatus)
+	} else if fault.kind == faultTruncate {
+		finalBody = writeTruncated(w, finalStatus, finalBody)
+	} else if rawHead && writeRawResponse(w, r, finalStatus, headerQuirks, finalBody) {
+		// Written directly to the connection
+	} else {
+		if rawHead {
+			applyHeaderQuirks(w, headerQuirks)
+		}
+		w.WriteHeader(finalStatus)
+		w.Write([]byte(finalBody))
+	}
 
 	// Capture completion time
 	completionTime := time.Now()
@@ -515,8 +703,10 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 	// Log the request with full response details using new nested structure
 	requestLog := models.RequestLog{
 		ID:         uuid.New().String(),
-		Timestamp:  time.Now().Format(time.RFC3339),
+		Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
 		EndpointID: endpointID,
+		Match:      matchInfoSnapshot(r),
+		SOCKS5Info: socks5Info(r),
 	}
 
 	// Populate client request
@@ -528,6 +718,7 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 	requestLog.ClientRequest.Body = string(bodyBytes)
 	requestLog.ClientRequest.Protocol = r.Proto
 	requestLog.ClientRequest.SourceIP = r.RemoteAddr
+	requestLog.ClientRequest.ClientCert = clientCertInfo(r)
 	requestLog.ClientRequest.UserAgent = r.UserAgent()
 
 	// Populate client response
@@ -542,12 +733,17 @@ func (h *ResponseHandler) HandleRequest(w http.ResponseWriter, r *http.Request)
 
 	// Send log to logger
 	h.requestLogger.LogRequest(requestLog)
+
+	// Make this request visible to later scripts on the same endpoint
+	h.history.Record(endpointID, r, bodyBytes, finalStatus)
 }
 
 // handleMockRequest handles mock endpoint requests with script-based responses
 func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Request, endpoint *models.Endpoint, translatedPath string, bodyBytes []byte) {
 	h.configMutex.RLock()
-	items := endpoint.Items
+	// Resolve the API version (if versioned) to pick the item set
+	items, translatedPath := selectVersionItems(endpoint, r, translatedPath)
+	items = h.scheduler.scheduledItems(publishedItems(items))
 
 	// Check if this is a CORS preflight that should be handled globally
 	if r.Method == "OPTIONS" && h.shouldHandleCORSPreflightForItems(r, translatedPath, items) {
@@ -561,6 +757,7 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 	var matchedGroup *models.ResponseGroup
 	var pathParams map[string]string
 	var extractedVars map[string]interface{}
+	var rejection *ValidationResult // Schema violation answered with 400
 
 	// Iterate through items to preserve group information
 	for _, item := range items {
@@ -582,7 +779,7 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 			}
 
 			// Check if path matches and extract path parameters (using translated path)
-			if methodMatches {
+			if methodMatches && protocolMatches(resp.Protocols, r) {
 				matchResult := matchPathPatternWithParams(resp.PathPattern, translatedPath)
 				if matchResult.Matches {
 					// Build initial context for validation (without vars yet)
@@ -592,7 +789,13 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 					validationResult := ValidateRequest(resp.RequestValidation, string(bodyBytes), tempContext)
 					if !validationResult.Valid {
 						// Validation failed - log and continue to next response
-						log.Printf("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+						serverLog.Warn("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+						explainValidationFailure(r, resp, validationResult.Error)
+						if validationResult.Reject {
+							// Answered with 400 once the config lock is released
+							rejection = validationResult
+							break
+						}
 
 						// Log validation failure (no HTTP response sent)
 						requestLog := buildRequestLog(r, bodyBytes, endpoint.ID)
@@ -637,7 +840,7 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 				}
 
 				// Check if path matches and extract path parameters (using translated path)
-				if methodMatches {
+				if methodMatches && protocolMatches(resp.Protocols, r) {
 					matchResult := matchPathPatternWithParams(resp.PathPattern, translatedPath)
 					if matchResult.Matches {
 						// Build initial context for validation (without vars yet)
@@ -647,7 +850,13 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 						validationResult := ValidateRequest(resp.RequestValidation, string(bodyBytes), tempContext)
 						if !validationResult.Valid {
 							// Validation failed - log and continue to next response
-							log.Printf("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+							serverLog.Warn("Validation failed for %s %s (translated: %s): %s", r.Method, r.URL.Path, translatedPath, validationResult.Error)
+							explainValidationFailure(r, resp, validationResult.Error)
+							if validationResult.Reject {
+								// Answered with 400 once the config lock is released
+								rejection = validationResult
+								break
+							}
 
 							// Log validation failure (no HTTP response sent)
 							requestLog := buildRequestLog(r, bodyBytes, endpoint.ID)
@@ -669,17 +878,35 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 				}
 			}
 
-			if matchedResponse != nil {
+			if matchedResponse != nil || rejection != nil {
 				break
 			}
 		}
 
-		if matchedResponse != nil {
+		if matchedResponse != nil || rejection != nil {
 			break
 		}
 	}
+
+	explainMatchOutcome(r, matchedResponse, matchedGroup, pathParams, translatedPath)
+
+	// Fill in defaults inherited from the group and endpoint (copied so config stays untouched)
+	if matchedResponse != nil {
+		var groupDefaults *models.ResponseDefaults
+		if matchedGroup != nil {
+			groupDefaults = matchedGroup.Defaults
+		}
+		effectiveResponse := models.ApplyResponseDefaults(*matchedResponse, groupDefaults, endpoint.Defaults)
+		matchedResponse = &effectiveResponse
+	}
 	h.configMutex.RUnlock()
 
+	// Schema violations on a rejecting response are answered with 400
+	if rejection != nil {
+		h.rejectInvalidRequest(w, r, bodyBytes, endpoint.ID, rejection)
+		return
+	}
+
 	// Deep copy headers to avoid reference issues
 	headersCopy := make(map[string][]string, len(r.Header))
 	for key, values := range r.Header {
@@ -701,6 +928,18 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Pick the variant negotiated from the variant header (adds Vary)
+	matchedResponse = selectResponseVariant(matchedResponse, r)
+
+	// Apply the overrides for the request's HTTP protocol version
+	matchedResponse = selectProtocolVariant(matchedResponse, r)
+
+	// Serve the current step of a sequence response
+	matchedResponse = h.sequences.nextStep(matchedResponse, r)
+
+	// Stamp deprecation headers (or retire the response) according to the virtual clock
+	matchedResponse = applyDeprecation(matchedResponse, matchedGroup, h.now())
+
 	// Apply CORS headers if needed
 	if h.shouldApplyCORS(matchedResponse, matchedGroup, r) {
 		corsHeaders := h.corsProcessor.ProcessCORS(r)
@@ -713,7 +952,7 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 	startTime := time.Now()
 
 	// Process response based on mode
-	finalBody, finalHeaders, finalStatus, finalDelay, responseErr := h.processResponse(
+	finalBody, finalHeaders, finalStatus, finalDelay, eventStream, responseErr := h.processResponse(
 		matchedResponse, r, bodyBytes, pathParams, extractedVars,
 	)
 
@@ -731,24 +970,65 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Generated bodies are streamed in place of the configured body
+	generator := matchedResponse.Generator
+	headerQuirks := matchedResponse.HeaderQuirks
+
+	// Apply Range handling (partial content, 416, multipart/byteranges); event streams and generated bodies are never ranged
+	if eventStream == "" && generator == nil {
+		finalStatus, finalHeaders, finalBody = applyRangeMode(matchedResponse.RangeMode, r, finalStatus, finalHeaders, finalBody)
+	}
+
+	// Chaos mode: added latency, then possibly an injected error, a reset or a truncated body
+	fault := planFault(resolveFaultInjection(endpoint, matchedResponse), r, eventStream == "" && generator == nil)
+	finalDelay += fault.latencyMs
+	if fault.kind == faultError {
+		finalStatus, finalBody, eventStream, generator, headerQuirks = fault.status, fault.body, "", nil, nil
+		finalHeaders = map[string]string{"Content-Type": "text/plain; charset=utf-8"}
+	}
+
 	// Implement response delay
 	if finalDelay > 0 {
 		time.Sleep(time.Duration(finalDelay) * time.Millisecond)
 	}
 
+	if fault.kind == faultReset {
+		h.logFaultReset(r, bodyBytes, endpoint.ID)
+		resetConnection(w)
+		return
+	}
+
 	// Set headers
 	for name, value := range finalHeaders {
 		w.Header().Set(name, value)
 	}
+	rawHead := headerQuirks != nil && headerQuirks.Raw && eventStream == "" && generator == nil && fault.kind != faultTruncate
+	if headerQuirks != nil && !rawHead {
+		applyHeaderQuirks(w, headerQuirks)
+	}
+
+	// Slow-network simulation paces the body at the configured rate
+	w = limitBandwidth(w, r, resolveBandwidth(endpoint, matchedResponse))
 
 	// Capture time before first byte (right before WriteHeader)
 	firstByteTime := time.Now()
 
-	// Set status code
-	w.WriteHeader(finalStatus)
-
-	// Write response body
-	w.Write([]byte(finalBody))
+	// Set status code and write response body (event streams stay open until the client disconnects)
+	if eventStream != "" {
+		finalBody = streamEvents(w, r, h.events, eventStream, finalStatus, finalBody)
+	} else if generator != nil {
+		finalBody = streamGenerated(w, generator, finalStatus)
+	} else if fault.kind == faultTruncate {
+		finalBody = writeTruncated(w, finalStatus, finalBody)
+	} else if rawHead && writeRawResponse(w, r, finalStatus, headerQuirks, finalBody) {
+		// Written directly to the connection
+	} else {
+		if rawHead {
+			applyHeaderQuirks(w, headerQuirks)
+		}
+		w.WriteHeader(finalStatus)
+		w.Write([]byte(finalBody))
+	}
 
 	// Capture completion time
 	completionTime := time.Now()
@@ -778,8 +1058,10 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 	// Log the request with full response details using new nested structure
 	requestLog := models.RequestLog{
 		ID:         uuid.New().String(),
-		Timestamp:  time.Now().Format(time.RFC3339),
+		Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
 		EndpointID: endpoint.ID,
+		Match:      matchInfoSnapshot(r),
+		SOCKS5Info: socks5Info(r),
 	}
 
 	// Populate client request
@@ -791,6 +1073,7 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 	requestLog.ClientRequest.Body = string(bodyBytes)
 	requestLog.ClientRequest.Protocol = r.Proto
 	requestLog.ClientRequest.SourceIP = r.RemoteAddr
+	requestLog.ClientRequest.ClientCert = clientCertInfo(r)
 	requestLog.ClientRequest.UserAgent = r.UserAgent()
 
 	// Populate client response
@@ -805,6 +1088,9 @@ func (h *ResponseHandler) handleMockRequest(w http.ResponseWriter, r *http.Reque
 
 	// Send log to logger
 	h.requestLogger.LogRequest(requestLog)
+
+	// Make this request visible to later scripts on the same endpoint
+	h.history.Record(endpoint.ID, r, bodyBytes, finalStatus)
 }
 
 // handleProxyRequest handles proxy endpoint requests
@@ -862,8 +1148,10 @@ func buildRequestLog(r *http.Request, bodyBytes []byte, endpointID string) model
 	// Create base log
 	requestLog := models.RequestLog{
 		ID:         uuid.New().String(),
-		Timestamp:  time.Now().Format(time.RFC3339),
+		Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
 		EndpointID: endpointID,
+		Match:      matchInfoSnapshot(r),
+		SOCKS5Info: socks5Info(r),
 	}
 
 	// Populate client request
@@ -875,6 +1163,7 @@ func buildRequestLog(r *http.Request, bodyBytes []byte, endpointID string) model
 	requestLog.ClientRequest.Body = string(bodyBytes)
 	requestLog.ClientRequest.Protocol = r.Proto
 	requestLog.ClientRequest.SourceIP = r.RemoteAddr
+	requestLog.ClientRequest.ClientCert = clientCertInfo(r)
 	requestLog.ClientRequest.UserAgent = r.UserAgent()
 
 	return requestLog
@@ -887,18 +1176,57 @@ func (h *ResponseHandler) processResponse(
 	bodyBytes []byte,
 	pathParams map[string]string,
 	extractedVars map[string]interface{},
-) (body string, headers map[string]string, status int, delay int, err error) {
+) (body string, headers map[string]string, status int, delay int, eventStream string, err error) {
 	// Default values from the response configuration
 	body = resp.Body
 	headers = resp.Headers
 	status = resp.StatusCode
 	delay = resp.ResponseDelay
+	eventStream = resp.EventStream
 
 	// Ensure headers is not nil
 	if headers == nil {
 		headers = make(map[string]string)
 	}
 
+	// Config variables (${NAME}) in static and template bodies and headers; script mode is left
+	// alone, since ${...} there is JavaScript
+	if resp.ResponseMode != models.ResponseModeScript {
+		body, headers = h.expandVariables(body, headers)
+	}
+
+	// Track template/script execution cost (including delay expressions) per response
+	if resp.ResponseMode == models.ResponseModeTemplate || resp.ResponseMode == models.ResponseModeScript || resp.DelayExpression != "" {
+		mode := resp.ResponseMode
+		if mode == "" {
+			mode = models.ResponseModeStatic
+		}
+		started := time.Now()
+		defer func() {
+			h.perfStats.Record(resp.ID, mode, time.Since(started), err)
+		}()
+	}
+
+	// Delay expression overrides the static delay (script mode sees the result as response.delay)
+	if resp.DelayExpression != "" {
+		reqContext := BuildRequestContext(r, bodyBytes, pathParams)
+		reqContext.Vars = extractedVars
+
+		evaluated, delayErr := EvaluateDelayExpression(resp.DelayExpression, reqContext, resp.ResponseDelay)
+		if delayErr != nil {
+			serverLog.Error("Delay expression error: %v", delayErr)
+			if h.scriptErrorLogger != nil && resp.ID != "" {
+				h.scriptErrorLogger.LogScriptError(resp.ID, r.URL.Path, r.Method, delayErr.Error())
+			}
+		}
+		if evaluated != resp.ResponseDelay {
+			delayed := *resp
+			delayed.ResponseDelay = evaluated
+			resp = &delayed
+		}
+		delay = evaluated
+	}
+
 	// Determine response mode (default to static)
 	responseMode := resp.ResponseMode
 	if responseMode == "" {
@@ -910,11 +1238,14 @@ func (h *ResponseHandler) processResponse(
 		// Build request context with extracted vars
 		reqContext := BuildRequestContext(r, bodyBytes, pathParams)
 		reqContext.Vars = extractedVars
+		h.configMutex.RLock()
+		reqContext.JWT = newJWTKeyring(h.config.JWTKeys, h.config.VirtualClock.Now())
+		h.configMutex.RUnlock()
 
 		// Process body as template
-		processedBody, templateErr := ProcessTemplate(resp.Body, reqContext)
+		processedBody, templateErr := ProcessTemplate(body, reqContext)
 		if templateErr != nil {
-			log.Printf("Template processing error: %v", templateErr)
+			serverLog.Error("Template processing error: %v", templateErr)
 			// Return error for response failure tracking
 			err = templateErr
 			return
@@ -922,9 +1253,9 @@ func (h *ResponseHandler) processResponse(
 		body = processedBody
 
 		// Also process headers as templates
-		processedHeaders, headerErr := ProcessTemplateHeaders(resp.Headers, reqContext)
+		processedHeaders, headerErr := ProcessTemplateHeaders(headers, reqContext)
 		if headerErr != nil {
-			log.Printf("Template header processing error: %v", headerErr)
+			serverLog.Error("Template header processing error: %v", headerErr)
 			// Return error for response failure tracking
 			err = headerErr
 			return
@@ -932,14 +1263,23 @@ func (h *ResponseHandler) processResponse(
 		headers = processedHeaders
 
 	case models.ResponseModeScript:
-		// Build request context with extracted vars
+		// Build request context with extracted vars and the endpoint's request history
 		reqContext := BuildRequestContext(r, bodyBytes, pathParams)
 		reqContext.Vars = extractedVars
+		if info := matchInfoSnapshot(r); info != nil {
+			reqContext.History = h.history.Recent(info.EndpointID)
+		}
+		reqContext.Events = h.events
+		h.configMutex.RLock()
+		reqContext.Modules = h.config.ScriptModules
+		reqContext.Fetch = h.config.ScriptFetch
+		reqContext.JWT = newJWTKeyring(h.config.JWTKeys, h.config.VirtualClock.Now())
+		h.configMutex.RUnlock()
 
 		// Execute script
 		scriptResp, scriptErr := ProcessScript(resp.ScriptBody, reqContext, resp)
 		if scriptErr != nil {
-			log.Printf("Script execution error: %v", scriptErr)
+			serverLog.Error("Script execution error: %v", scriptErr)
 			// Log error to frontend
 			if h.scriptErrorLogger != nil && resp.ID != "" {
 				h.scriptErrorLogger.LogScriptError(resp.ID, r.URL.Path, r.Method, scriptErr.Error())
@@ -952,14 +1292,54 @@ func (h *ResponseHandler) processResponse(
 		headers = scriptResp.Headers
 		status = scriptResp.Status
 		delay = scriptResp.Delay
+		if scriptResp.EventStream != "" {
+			eventStream = scriptResp.EventStream
+		}
 
 	default:
-		// Static mode - use values as-is (already set above)
+		// Static mode - use values as-is (already set above), decoding raw bodies
+		if resp.BodyBase64 {
+			decoded, decodeErr := base64.StdEncoding.DecodeString(resp.Body) // Raw bytes, no variables
+			if decodeErr != nil {
+				err = fmt.Errorf("invalid base64 body: %v", decodeErr)
+				return
+			}
+			body = string(decoded)
+		}
+	}
+
+	// Declarative field rewrites apply to whatever the mode rendered
+	if len(resp.FieldMappings) > 0 {
+		if mapped, changed := ApplyFieldMappings([]byte(body), resp.FieldMappings); changed {
+			body = string(mapped)
+		}
 	}
 
 	return
 }
 
+// expandVariables replaces the ${NAME} config variable references in a response body and headers.
+// The configured headers map is copied before it is changed.
+func (h *ResponseHandler) expandVariables(body string, headers map[string]string) (string, map[string]string) {
+	hasReference := strings.Contains(body, "${")
+	for _, value := range headers {
+		hasReference = hasReference || strings.Contains(value, "${")
+	}
+	if !hasReference {
+		return body, headers
+	}
+
+	h.configMutex.RLock()
+	variables := ConfigVariables(h.config)
+	h.configMutex.RUnlock()
+
+	expanded := make(map[string]string, len(headers))
+	for name, value := range headers {
+		expanded[name] = variables.Expand(value)
+	}
+	return variables.Expand(body), expanded
+}
+
 // shouldHandleCORSPreflight checks if global CORS should handle an OPTIONS request (legacy, for backward compatibility)
 func (h *ResponseHandler) shouldHandleCORSPreflight(r *http.Request) bool {
 	// Check if global CORS is enabled
@@ -1128,6 +1508,32 @@ func extractDomain(r *http.Request) string {
 	return host
 }
 
+// matchesTakeoverDomain checks if a domain matches an enabled pattern of the domain takeover list
+func (h *ResponseHandler) matchesTakeoverDomain(domain string) bool {
+	// Get domain takeover configuration from config
+	h.configMutex.RLock()
+	domainTakeover := h.config.DomainTakeover
+	h.configMutex.RUnlock()
+
+	if domainTakeover == nil {
+		return false
+	}
+	for _, domainConfig := range domainTakeover.Domains {
+		if !domainConfig.Enabled {
+			continue
+		}
+		// Compile and check regex pattern
+		re, err := h.compileRegex(domainConfig.Pattern)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(domain) {
+			return true
+		}
+	}
+	return false
+}
+
 // matchesDomain checks if the request domain matches the endpoint's domain filter
 func (h *ResponseHandler) matchesDomain(endpoint *models.Endpoint, domain string) bool {
 	// If no domain filter, match any domain
@@ -1135,11 +1541,6 @@ func (h *ResponseHandler) matchesDomain(endpoint *models.Endpoint, domain string
 		return true
 	}
 
-	// Get domain takeover configuration from config
-	h.configMutex.RLock()
-	domainTakeover := h.config.DomainTakeover
-	h.configMutex.RUnlock()
-
 	switch endpoint.DomainFilter.Mode {
 	case models.DomainFilterModeAny:
 		// Match any domain
@@ -1147,31 +1548,13 @@ func (h *ResponseHandler) matchesDomain(endpoint *models.Endpoint, domain string
 
 	case models.DomainFilterModeAll:
 		// Match if domain is in any enabled takeover pattern
-		if domainTakeover == nil {
-			return false
-		}
-		for _, domainConfig := range domainTakeover.Domains {
-			if !domainConfig.Enabled {
-				continue
-			}
-			// Compile and check regex pattern
-			re, err := h.compileRegex(domainConfig.Pattern)
-			if err != nil {
-				log.Printf("Invalid domain pattern %s: %v", domainConfig.Pattern, err)
-				continue
-			}
-			if re.MatchString(domain) {
-				return true
-			}
-		}
-		return false
+		return h.matchesTakeoverDomain(domain)
 
 	case models.DomainFilterModeSpecific:
 		// Match if domain matches any selected pattern
 		for _, pattern := range endpoint.DomainFilter.Patterns {
 			re, err := h.compileRegex(pattern)
 			if err != nil {
-				log.Printf("Invalid domain pattern %s: %v", pattern, err)
 				continue
 			}
 			if re.MatchString(domain) {
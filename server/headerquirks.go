@@ -0,0 +1,111 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mockelot/models"
+)
+
+const defaultFillerSize = 16
+
+// quirkHeaders returns the header lines of a response's header quirks, in the order they are sent
+func quirkHeaders(quirks *models.HeaderQuirks) []models.RawHeader {
+	headers := append([]models.RawHeader(nil), quirks.Headers...)
+	if quirks.FillerCount > 0 {
+		size := quirks.FillerSize
+		if size <= 0 {
+			size = defaultFillerSize
+		}
+		value := strings.Repeat("x", size)
+		for i := 1; i <= quirks.FillerCount; i++ {
+			headers = append(headers, models.RawHeader{Name: fmt.Sprintf("X-Filler-%d", i), Value: value})
+		}
+	}
+	return headers
+}
+
+// applyHeaderQuirks adds quirk headers to the header map without canonicalizing their names.
+// Go writes HTTP/1.x header names as stored, so casing and duplicates survive; multi-line values
+// are flattened to spaces by net/http (use raw mode for folding). HTTP/2 always lowercases names.
+func applyHeaderQuirks(w http.ResponseWriter, quirks *models.HeaderQuirks) {
+	header := w.Header()
+	for _, h := range quirkHeaders(quirks) {
+		header[h.Name] = append(header[h.Name], h.Value)
+	}
+}
+
+// writeRawResponse takes over the connection and writes the status line, headers and body byte for byte:
+// normal headers first, then quirk headers in order with multi-line values folded (obs-fold).
+// The connection is closed afterwards. Returns false (without writing) when the connection cannot be
+// taken over, e.g. on HTTP/2, so the caller can fall back to a normal response.
+func writeRawResponse(w http.ResponseWriter, r *http.Request, status int, quirks *models.HeaderQuirks, body string) bool {
+	if r.ProtoMajor != 1 {
+		serverLog.Warn("Raw header mode needs HTTP/1.x, %s %s uses %s", r.Method, r.URL.Path, r.Proto)
+		return false
+	}
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		serverLog.Warn("Raw header mode unavailable for %s %s: %v", r.Method, r.URL.Path, err)
+		return false
+	}
+	defer conn.Close()
+
+	lines := quirkHeaders(quirks)
+	present := make(map[string]bool)
+	for _, h := range lines {
+		present[strings.ToLower(h.Name)] = true
+	}
+
+	out := bufio.NewWriter(conn)
+	fmt.Fprintf(out, "HTTP/1.1 %d %s\r\n", status, http.StatusText(status))
+
+	names := make([]string, 0, len(w.Header()))
+	for name := range w.Header() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range w.Header()[name] {
+			fmt.Fprintf(out, "%s: %s\r\n", name, value)
+		}
+	}
+	for _, h := range lines {
+		fmt.Fprintf(out, "%s: %s\r\n", h.Name, foldHeaderValue(h.Value))
+	}
+
+	// Framing headers are only added when the quirks don't set their own (possibly wrong) ones
+	if w.Header().Get("Content-Length") == "" && !present["content-length"] && !present["transfer-encoding"] {
+		fmt.Fprintf(out, "Content-Length: %s\r\n", strconv.Itoa(len(body)))
+	}
+	if w.Header().Get("Connection") == "" && !present["connection"] {
+		out.WriteString("Connection: close\r\n")
+	}
+	out.WriteString("\r\n")
+	if r.Method != http.MethodHead {
+		out.WriteString(body)
+	}
+	if err := out.Flush(); err != nil {
+		serverLog.Error("Raw response write for %s %s failed: %v", r.Method, r.URL.Path, err)
+	}
+
+	// Record the quirk headers for the request log
+	applyHeaderQuirks(w, quirks)
+	return true
+}
+
+// foldHeaderValue turns a multi-line value into an obsolete folded header (RFC 7230 obs-fold):
+// each further line goes on a continuation line starting with whitespace
+func foldHeaderValue(value string) string {
+	lines := strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n")
+	for i := 1; i < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], " ") && !strings.HasPrefix(lines[i], "\t") {
+			lines[i] = " " + lines[i]
+		}
+	}
+	return strings.Join(lines, "\r\n")
+}
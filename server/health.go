@@ -0,0 +1,158 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"mockelot/endpointtype"
+	"mockelot/models"
+)
+
+// healthReport is the body returned by the reserved health and readiness paths
+type healthReport struct {
+	Status     string              `json:"status"` // "ok", "ready" or "not_ready"
+	Uptime     string              `json:"uptime"`
+	StartedAt  string              `json:"started_at"`
+	ConfigHash string              `json:"config_hash"` // SHA-256 of the loaded config
+	Endpoints  int                 `json:"endpoints"`
+	Containers []endpointReadiness `json:"containers,omitempty"`
+	Plugins    []endpointReadiness `json:"plugins,omitempty"` // Endpoints of plugin-provided types
+}
+
+// endpointReadiness describes whether a container or plugin endpoint can serve traffic
+type endpointReadiness struct {
+	EndpointID string `json:"endpoint_id"`
+	Name       string `json:"name"`
+	Ready      bool   `json:"ready"`
+	Status     string `json:"status"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// handleReservedPath serves the built-in health, readiness, API docs and PAC file endpoints.
+// Returns true if the request was handled.
+func (h *ResponseHandler) handleReservedPath(w http.ResponseWriter, r *http.Request) bool {
+	switch r.URL.Path {
+	case models.ReservedPathHealth:
+		report := h.buildHealthReport(false)
+		writeHealthReport(w, http.StatusOK, report)
+		return true
+	case models.ReservedPathReady:
+		report := h.buildHealthReport(true)
+		statusCode := http.StatusOK
+		if report.Status != "ready" {
+			statusCode = http.StatusServiceUnavailable
+		}
+		writeHealthReport(w, statusCode, report)
+		return true
+	case models.ReservedPathDocs:
+		h.serveAPIDocs(w)
+		return true
+	case models.ReservedPathPAC:
+		h.servePACFile(w, r)
+		return true
+	}
+	return false
+}
+
+// buildHealthReport collects server status, config hash and (optionally) container and plugin readiness
+func (h *ResponseHandler) buildHealthReport(includeReadiness bool) healthReport {
+	h.configMutex.RLock()
+	configJSON, _ := json.Marshal(h.config)
+	var containerEndpoints, pluginEndpoints []models.Endpoint
+	userEndpoints := 0
+	for _, endpoint := range h.config.Endpoints {
+		if endpoint.IsSystem {
+			continue
+		}
+		userEndpoints++
+		if endpoint.Type == models.EndpointTypeContainer && endpoint.IsEnabled() {
+			containerEndpoints = append(containerEndpoints, endpoint)
+		} else if !endpointtype.IsBuiltin(endpoint.Type) && endpoint.IsEnabled() {
+			pluginEndpoints = append(pluginEndpoints, endpoint)
+		}
+	}
+	h.configMutex.RUnlock()
+
+	sum := sha256.Sum256(configJSON)
+	report := healthReport{
+		Status:     "ok",
+		Uptime:     time.Since(h.startedAt).Round(time.Second).String(),
+		StartedAt:  h.startedAt.Format(time.RFC3339),
+		ConfigHash: hex.EncodeToString(sum[:]),
+		Endpoints:  userEndpoints,
+	}
+
+	if !includeReadiness {
+		return report
+	}
+
+	report.Status = "ready"
+	for _, endpoint := range containerEndpoints {
+		readiness := h.containerReadiness(&endpoint)
+		if !readiness.Ready {
+			report.Status = "not_ready"
+		}
+		report.Containers = append(report.Containers, readiness)
+	}
+	for _, endpoint := range pluginEndpoints {
+		readiness := pluginReadiness(&endpoint)
+		if !readiness.Ready {
+			report.Status = "not_ready"
+		}
+		report.Plugins = append(report.Plugins, readiness)
+	}
+
+	return report
+}
+
+// containerReadiness reports a container endpoint as ready once it is running
+// and, when health checks are enabled, its last health check passed
+func (h *ResponseHandler) containerReadiness(endpoint *models.Endpoint) endpointReadiness {
+	readiness := endpointReadiness{
+		EndpointID: endpoint.ID,
+		Name:       endpoint.Name,
+		Status:     "not started",
+	}
+
+	if h.containerHandler == nil {
+		readiness.Reason = "container runtime not available"
+		return readiness
+	}
+
+	status := h.containerHandler.GetContainerStatus(endpoint.ID)
+	if status == nil {
+		readiness.Reason = "no status reported yet"
+		return readiness
+	}
+	readiness.Status = status.Status
+	if !status.Running {
+		readiness.Reason = "container is not running"
+		return readiness
+	}
+
+	if endpoint.ContainerConfig != nil && endpoint.ContainerConfig.ProxyConfig.HealthCheckEnabled {
+		health := h.containerHandler.GetHealthStatus(endpoint.ID)
+		if health == nil {
+			readiness.Reason = "waiting for first health check"
+			return readiness
+		}
+		if !health.Healthy {
+			readiness.Reason = health.ErrorMessage
+			return readiness
+		}
+	}
+
+	readiness.Ready = true
+	return readiness
+}
+
+// writeHealthReport writes a health report as JSON with caching disabled
+func writeHealthReport(w http.ResponseWriter, statusCode int, report healthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(report)
+}
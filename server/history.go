@@ -0,0 +1,114 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	historyPerEndpoint = 50   // Requests kept per endpoint for the script history API
+	historyBodySnippet = 1024 // Bytes of each request body kept
+)
+
+// HistoryEntry is a completed request as seen by response scripts
+type HistoryEntry struct {
+	Method    string
+	Path      string
+	Query     string
+	Body      string
+	Status    int
+	Timestamp time.Time
+}
+
+// RequestHistory keeps the most recent requests answered by each mock endpoint, shared by all listeners
+type RequestHistory struct {
+	mutex   sync.RWMutex
+	entries map[string][]HistoryEntry // Endpoint ID ("" for legacy items) -> oldest first
+}
+
+// NewRequestHistory creates an empty request history
+func NewRequestHistory() *RequestHistory {
+	return &RequestHistory{entries: make(map[string][]HistoryEntry)}
+}
+
+// Record adds a completed request to an endpoint's history, dropping the oldest beyond the limit
+func (h *RequestHistory) Record(endpointID string, r *http.Request, body []byte, status int) {
+	if h == nil {
+		return
+	}
+	snippet := body
+	if len(snippet) > historyBodySnippet {
+		snippet = snippet[:historyBodySnippet]
+	}
+	entry := HistoryEntry{
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Query:     r.URL.RawQuery,
+		Body:      string(snippet),
+		Status:    status,
+		Timestamp: time.Now(),
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	entries := append(h.entries[endpointID], entry)
+	if len(entries) > historyPerEndpoint {
+		entries = entries[len(entries)-historyPerEndpoint:]
+	}
+	h.entries[endpointID] = entries
+}
+
+// Recent returns a copy of an endpoint's history, oldest first
+func (h *RequestHistory) Recent(endpointID string) []HistoryEntry {
+	if h == nil {
+		return nil
+	}
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	entries := make([]HistoryEntry, len(h.entries[endpointID]))
+	copy(entries, h.entries[endpointID])
+	return entries
+}
+
+// Clear forgets all recorded requests
+func (h *RequestHistory) Clear() {
+	if h == nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.entries = make(map[string][]HistoryEntry)
+}
+
+// historyToJS converts history entries into a frozen array of frozen plain objects
+func historyToJS(vm *goja.Runtime, entries []HistoryEntry) (goja.Value, error) {
+	items := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		obj := vm.NewObject()
+		obj.Set("method", entry.Method)
+		obj.Set("path", entry.Path)
+		obj.Set("query", entry.Query)
+		obj.Set("body", entry.Body)
+		obj.Set("status", entry.Status)
+		obj.Set("timestamp", entry.Timestamp.Format(time.RFC3339Nano))
+		items[i] = obj
+	}
+	history := vm.NewArray(items...)
+
+	freeze, ok := goja.AssertFunction(vm.Get("Object").ToObject(vm).Get("freeze"))
+	if !ok {
+		return history, nil
+	}
+	for _, item := range items {
+		if _, err := freeze(nil, item.(*goja.Object)); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := freeze(nil, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,300 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"sync"
+	"time"
+
+	"mockelot/models"
+)
+
+// defaultHTTPProxyPort is the HTTP proxy port when none is configured
+const defaultHTTPProxyPort = 3128
+
+// HTTPProxyServer is an HTTP forward proxy for clients that cannot use SOCKS5. CONNECT tunnels go
+// through the SOCKS5 tunnel handling (bypass rules, domain takeover, TLS interception and
+// pass-through). Absolute-URI requests (plain HTTP) for taken-over domains are answered by the
+// ResponseHandler; all others are forwarded to the real server.
+type HTTPProxyServer struct {
+	config    *models.HTTPProxyConfig
+	server    *http.Server
+	tunnel    *SOCKS5Server // Shared tunnel handling, never started as a SOCKS5 listener
+	forwarder *httputil.ReverseProxy
+	mu        sync.Mutex
+	running   bool
+}
+
+// NewHTTPProxyServer creates a new HTTP proxy instance
+// Parameters:
+//   - config: HTTP proxy configuration (port, auth)
+//   - socks5Config: Bypass rules and transparent pass-through, shared with the SOCKS5 proxy (may be nil)
+//   - handler, certCache, domainTakeover, logger, bypassStats, traffic: as for NewSOCKS5Server
+func NewHTTPProxyServer(config *models.HTTPProxyConfig, socks5Config *models.SOCKS5Config, handler *ResponseHandler, certCache *CertCache, domainTakeover *models.DomainTakeoverConfig, logger RequestLogger, bypassStats *BypassStats, traffic *TrafficMeter) *HTTPProxyServer {
+	var tlsInterceptor *TLSInterceptor
+	if certCache != nil {
+		tlsInterceptor = NewTLSInterceptor(certCache)
+	}
+
+	// Only the routing settings are shared; the SOCKS5 listener settings do not apply here
+	tunnelConfig := &models.SOCKS5Config{}
+	if socks5Config != nil {
+		tunnelConfig.BypassRules = socks5Config.BypassRules
+		tunnelConfig.TransparentPassThrough = socks5Config.TransparentPassThrough
+	}
+
+	// Plain HTTP is forwarded as is; the request URL is already absolute
+	transport := &http.Transport{
+		Proxy:                 nil, // Never chain to the proxy from the environment (it may be this one)
+		DialContext:           (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 60 * time.Second,
+	}
+
+	return &HTTPProxyServer{
+		config: config,
+		tunnel: &SOCKS5Server{
+			config:          tunnelConfig,
+			responseHandler: handler,
+			tlsInterceptor:  tlsInterceptor,
+			domainTakeover:  domainTakeover,
+			requestLogger:   logger,
+			bypassStats:     bypassStats,
+			traffic:         traffic,
+			viaHTTPProxy:    true,
+		},
+		forwarder: &httputil.ReverseProxy{
+			Rewrite:   func(*httputil.ProxyRequest) {},
+			Transport: transport,
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+				socks5Log.Error("HTTP proxy: failed to forward %s: %v", r.URL, err)
+				http.Error(w, "Bad Gateway", http.StatusBadGateway)
+			},
+		},
+	}
+}
+
+// Start begins listening for proxy requests
+func (p *HTTPProxyServer) Start() error {
+	port := p.config.Port
+	if port == 0 {
+		port = defaultHTTPProxyPort
+	}
+
+	p.mu.Lock()
+	if p.running {
+		p.mu.Unlock()
+		return fmt.Errorf("HTTP proxy already running")
+	}
+	p.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           p,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	listener, err := net.Listen("tcp", p.server.Addr)
+	if err != nil {
+		p.mu.Unlock()
+		return fmt.Errorf("failed to start HTTP proxy: %w", err)
+	}
+	p.running = true
+	p.mu.Unlock()
+
+	socks5Log.Info("HTTP proxy listening on %s", p.server.Addr)
+	if err := p.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Stop shuts down the proxy. Tunnels that are already open run until either side closes them.
+func (p *HTTPProxyServer) Stop() error {
+	p.mu.Lock()
+	if !p.running {
+		p.mu.Unlock()
+		return nil
+	}
+	p.running = false
+	p.mu.Unlock()
+
+	socks5Log.Info("Stopping HTTP proxy...")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.server.Shutdown(ctx); err != nil {
+		socks5Log.Info("HTTP proxy stopped (timeout)")
+		return p.server.Close()
+	}
+	socks5Log.Info("HTTP proxy stopped")
+	return nil
+}
+
+// ServeHTTP handles one proxy request: CONNECT, or a request with an absolute URI
+func (p *HTTPProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proxyUser, ok := p.authorize(r)
+	if !ok {
+		w.Header().Set("Proxy-Authenticate", `Basic realm="Mockelot"`)
+		http.Error(w, "Proxy Authentication Required", http.StatusProxyAuthRequired)
+		return
+	}
+
+	if r.Method == http.MethodConnect {
+		p.handleConnect(w, r, proxyUser)
+		return
+	}
+	if !r.URL.IsAbs() {
+		http.Error(w, "This is a proxy: send CONNECT or absolute-URI requests", http.StatusBadRequest)
+		return
+	}
+	p.handleForward(w, r, proxyUser)
+}
+
+// authorize checks the Proxy-Authorization credentials when authentication is required, and
+// returns the username that logged in (empty without authentication)
+func (p *HTTPProxyServer) authorize(r *http.Request) (string, bool) {
+	if !p.config.Authentication {
+		return "", true
+	}
+	header := r.Header.Get("Proxy-Authorization")
+	if header == "" {
+		return "", false
+	}
+	// Parse the header through an Authorization header, which net/http knows how to decode
+	probe := &http.Request{Header: http.Header{"Authorization": {header}}}
+	username, password, ok := probe.BasicAuth()
+	if !ok || !secretEqual(username, p.config.Username) || !secretEqual(password, p.config.Password) {
+		return "", false
+	}
+	return username, true
+}
+
+// handleConnect opens a tunnel and hands it to the SOCKS5 tunnel handling
+func (p *HTTPProxyServer) handleConnect(w http.ResponseWriter, r *http.Request, proxyUser string) {
+	host, portText, err := net.SplitHostPort(r.Host)
+	port, portErr := strconv.ParseUint(portText, 10, 16)
+	if err != nil || portErr != nil || host == "" {
+		http.Error(w, "CONNECT needs a host:port target", http.StatusBadRequest)
+		return
+	}
+
+	conn, buffered, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		socks5Log.Error("HTTP proxy: cannot take over the CONNECT connection: %v", err)
+		http.Error(w, "CONNECT not supported on this connection", http.StatusInternalServerError)
+		return
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Time{})
+
+	if _, err := conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		return
+	}
+
+	// Bytes the client sent right after the CONNECT (e.g. a TLS ClientHello) are already buffered
+	var tunnelConn net.Conn = conn
+	if buffered.Reader.Buffered() > 0 {
+		tunnelConn = &bufferedConn{Conn: conn, reader: buffered.Reader}
+	}
+
+	socks5Log.Debug("HTTP proxy tunnel established to %s:%d", host, port)
+	tunnelConn = p.tunnel.traffic.meterTunnel(tunnelConn, fmt.Sprintf("%s:%d", host, port))
+	p.tunnel.handleTunnel(tunnelConn, host, uint16(port), proxyUser)
+}
+
+// handleForward serves a plain HTTP request sent with an absolute URI
+func (p *HTTPProxyServer) handleForward(w http.ResponseWriter, r *http.Request, proxyUser string) {
+	host := r.URL.Hostname()
+	port := 80
+	if portText := r.URL.Port(); portText != "" {
+		port, _ = strconv.Atoi(portText)
+	}
+
+	rule := matchBypassRule(p.tunnel.config.BypassRules, host, port)
+	if rule == nil && p.tunnel.shouldIntercept(host) {
+		// Taken-over domain: answer like a request sent through a SOCKS5 tunnel
+		r.RequestURI = ""
+		r.Header.Del("Proxy-Authorization")
+		r.Header.Del("Proxy-Connection")
+		r = withSOCKS5Info(r, &models.SOCKS5RequestInfo{
+			TargetHost:   host,
+			TargetPort:   port,
+			Protocol:     "HTTP",
+			ViaHTTPProxy: true,
+			ProxyUser:    proxyUser,
+		})
+		p.tunnel.responseHandler.HandleRequest(w, r)
+		return
+	}
+
+	protocol := "PASS-THROUGH"
+	if rule != nil {
+		protocol = "BYPASS"
+		if p.tunnel.bypassStats != nil {
+			p.tunnel.bypassStats.recordConnection(rule.ID, fmt.Sprintf("%s:%d", host, port))
+		}
+	}
+	p.logForward(r, host, port, protocol, proxyUser)
+	p.forwarder.ServeHTTP(w, r)
+}
+
+// logForward logs a request forwarded to the real server (metadata only, no bodies)
+func (p *HTTPProxyServer) logForward(r *http.Request, host string, port int, protocol, proxyUser string) {
+	if p.tunnel.requestLogger == nil {
+		return
+	}
+	requestLog := models.RequestLog{
+		ID:         fmt.Sprintf("%d", time.Now().UnixNano()),
+		Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
+		EndpointID: socks5EndpointID,
+		SOCKS5Info: &models.SOCKS5RequestInfo{
+			TargetHost:   host,
+			TargetPort:   port,
+			Protocol:     protocol,
+			ViaHTTPProxy: true,
+			ProxyUser:    proxyUser,
+		},
+	}
+	requestLog.ClientRequest.Method = r.Method
+	requestLog.ClientRequest.FullURL = r.URL.String()
+	requestLog.ClientRequest.Path = r.URL.Path
+	requestLog.ClientRequest.SourceIP = r.RemoteAddr
+	requestLog.ClientRequest.UserAgent = r.UserAgent()
+	p.tunnel.requestLogger.LogRequest(requestLog)
+}
+
+// bufferedConn is a connection whose first bytes were already read into a buffer
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// CloseWrite lets the pass-through signal EOF to the client
+func (c *bufferedConn) CloseWrite() error {
+	if closer, ok := c.Conn.(interface{ CloseWrite() error }); ok {
+		return closer.CloseWrite()
+	}
+	return nil
+}
+
+// ValidateHTTPProxy checks the HTTP proxy settings
+func ValidateHTTPProxy(cfg *models.HTTPProxyConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Port < 0 || cfg.Port > 65535 {
+		return fmt.Errorf("invalid HTTP proxy port %d", cfg.Port)
+	}
+	if cfg.Authentication && cfg.Username == "" {
+		return fmt.Errorf("HTTP proxy authentication needs a username")
+	}
+	return nil
+}
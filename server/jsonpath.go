@@ -0,0 +1,89 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// evalJSONPath evaluates a simple JSONPath expression against decoded JSON.
+// Supported syntax: $, .field, ['field'], ["field"], [index] (negative indexes count from the end).
+// Returns false if any segment does not exist.
+func evalJSONPath(data interface{}, path string) (interface{}, bool) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	current := data
+	for _, seg := range segments {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[seg]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(seg)
+			if err != nil {
+				return nil, false
+			}
+			if index < 0 {
+				index += len(node)
+			}
+			if index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// parseJSONPath splits a JSONPath expression into field names / array indexes
+func parseJSONPath(path string) ([]string, error) {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "$") {
+		path = path[1:]
+	}
+
+	var segments []string
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty field name in JSONPath")
+			}
+			segments = append(segments, path[:end])
+			path = path[end:]
+		case '[':
+			end := strings.Index(path, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated bracket in JSONPath")
+			}
+			inner := strings.TrimSpace(path[1:end])
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				inner = inner[1 : len(inner)-1]
+			}
+			segments = append(segments, inner)
+			path = path[end+1:]
+		default:
+			// Allow a bare leading field name (e.g., "data.items[0]")
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			segments = append(segments, path[:end])
+			path = path[end:]
+		}
+	}
+	return segments, nil
+}
@@ -0,0 +1,591 @@
+package server
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"mockelot/models"
+)
+
+const (
+	jwksCacheTime       = 5 * time.Minute
+	jwksRefetchInterval = 30 * time.Second // A token with an unknown key ID fetches the JWKS again at most this often
+	jwksFetchTimeout    = 5 * time.Second
+	maxJWKSSize         = 1 << 20
+)
+
+// jwtAlgorithms are the signing algorithms tokens can use ("none" is never accepted)
+var jwtAlgorithms = []string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// jwtToken is a decoded compact JWT whose signature has not been checked
+type jwtToken struct {
+	header       map[string]interface{}
+	claims       map[string]interface{}
+	signingInput string
+	signature    []byte
+}
+
+func (t *jwtToken) alg() string {
+	alg, _ := t.header["alg"].(string)
+	return alg
+}
+
+func (t *jwtToken) kid() string {
+	kid, _ := t.header["kid"].(string)
+	return kid
+}
+
+// decodeJWT splits a compact JWT and decodes its header and claims
+func decodeJWT(token string) (*jwtToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is not a JWT")
+	}
+	header, err := decodeJWTSegment(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+	claims, err := decodeJWTSegment(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT claims: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[2], "="))
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT signature: %w", err)
+	}
+	return &jwtToken{header: header, claims: claims, signingInput: parts[0] + "." + parts[1], signature: signature}, nil
+}
+
+func decodeJWTSegment(segment string) (map[string]interface{}, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// jwtHash returns the digest of a signing algorithm (0 for EdDSA, which hashes internally)
+func jwtHash(alg string) (crypto.Hash, error) {
+	if alg == "EdDSA" {
+		return 0, nil
+	}
+	if len(alg) != 5 {
+		return 0, fmt.Errorf("unsupported JWT algorithm %q", alg)
+	}
+	switch alg[2:] {
+	case "256":
+		return crypto.SHA256, nil
+	case "384":
+		return crypto.SHA384, nil
+	case "512":
+		return crypto.SHA512, nil
+	}
+	return 0, fmt.Errorf("unsupported JWT algorithm %q", alg)
+}
+
+// isJWTAlgorithm reports whether alg is a supported signing algorithm
+func isJWTAlgorithm(alg string) bool {
+	for _, known := range jwtAlgorithms {
+		if alg == known {
+			return true
+		}
+	}
+	return false
+}
+
+// jwtKey is a verification key, with the key ID and algorithm a JWKS may pin it to
+type jwtKey struct {
+	kid string
+	alg string
+	key interface{} // []byte (HMAC), *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey
+}
+
+// fits reports whether the key can check a signature made with alg. HMAC secrets only check HS*
+// tokens and public keys never do, so a public key cannot be abused as an HMAC secret.
+func (k jwtKey) fits(alg string) bool {
+	if k.alg != "" && k.alg != alg {
+		return false
+	}
+	switch key := k.key.(type) {
+	case []byte:
+		return strings.HasPrefix(alg, "HS")
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		switch alg {
+		case "ES256":
+			return key.Curve == elliptic.P256()
+		case "ES384":
+			return key.Curve == elliptic.P384()
+		case "ES512":
+			return key.Curve == elliptic.P521()
+		}
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	}
+	return false
+}
+
+// verifyJWTSignature checks a signature made with alg against a key that fits it
+func verifyJWTSignature(alg string, key interface{}, signingInput string, signature []byte) error {
+	hash, err := jwtHash(alg)
+	if err != nil {
+		return err
+	}
+	var digest []byte
+	if hash != 0 {
+		hasher := hash.New()
+		hasher.Write([]byte(signingInput))
+		digest = hasher.Sum(nil)
+	}
+
+	valid := false
+	switch key := key.(type) {
+	case []byte:
+		mac := hmac.New(hash.New, key)
+		mac.Write([]byte(signingInput))
+		valid = hmac.Equal(mac.Sum(nil), signature)
+	case *rsa.PublicKey:
+		if strings.HasPrefix(alg, "PS") {
+			valid = rsa.VerifyPSS(key, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto}) == nil
+		} else {
+			valid = rsa.VerifyPKCS1v15(key, hash, digest, signature) == nil
+		}
+	case *ecdsa.PublicKey:
+		// JWS ECDSA signatures are r and s as fixed-size big-endian integers
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) == 2*size {
+			r := new(big.Int).SetBytes(signature[:size])
+			s := new(big.Int).SetBytes(signature[size:])
+			valid = ecdsa.Verify(key, digest, r, s)
+		}
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, []byte(signingInput), signature)
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+	if !valid {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// verifyJWTWithKeys checks a token's signature against the keys that fit its algorithm. A token
+// with a key ID is only checked against keys with that ID, or keys without one.
+func verifyJWTWithKeys(token *jwtToken, keys []jwtKey) error {
+	alg, kid := token.alg(), token.kid()
+	if !isJWTAlgorithm(alg) {
+		return fmt.Errorf("algorithm %q not accepted", alg)
+	}
+	tried := false
+	for _, key := range keys {
+		if (kid != "" && key.kid != "" && key.kid != kid) || !key.fits(alg) {
+			continue
+		}
+		tried = true
+		if verifyJWTSignature(alg, key.key, token.signingInput, token.signature) == nil {
+			return nil
+		}
+	}
+	if !tried {
+		return fmt.Errorf("no key for %s tokens", alg)
+	}
+	return errors.New("invalid signature")
+}
+
+// signJWT signs claims with a named key. The header gets the key's algorithm and key ID, plus
+// any extra members.
+func signJWT(key models.JWTSigningKey, extraHeader, claims map[string]interface{}) (string, error) {
+	alg := key.Algorithm
+	hash, err := jwtHash(alg)
+	if err != nil {
+		return "", err
+	}
+
+	header := map[string]interface{}{}
+	for name, value := range extraHeader {
+		header[name] = value
+	}
+	header["alg"] = alg
+	if _, ok := header["typ"]; !ok {
+		header["typ"] = "JWT"
+	}
+	if key.KeyID != "" {
+		header["kid"] = key.KeyID
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("claims are not JSON: %w", err)
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	var digest []byte
+	if hash != 0 {
+		hasher := hash.New()
+		hasher.Write([]byte(signingInput))
+		digest = hasher.Sum(nil)
+	}
+
+	var signature []byte
+	if strings.HasPrefix(alg, "HS") {
+		if key.Secret == "" {
+			return "", fmt.Errorf("key %s has no secret", key.Name)
+		}
+		mac := hmac.New(hash.New, []byte(key.Secret))
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	} else {
+		if key.PrivateKey == "" {
+			return "", fmt.Errorf("key %s is verify-only (no private key)", key.Name)
+		}
+		private, err := parsePrivateKeyPEM(key.PrivateKey)
+		if err != nil {
+			return "", err
+		}
+		switch private := private.(type) {
+		case *rsa.PrivateKey:
+			if strings.HasPrefix(alg, "PS") {
+				signature, err = rsa.SignPSS(rand.Reader, private, hash, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+			} else {
+				signature, err = rsa.SignPKCS1v15(rand.Reader, private, hash, digest)
+			}
+		case *ecdsa.PrivateKey:
+			var r, s *big.Int
+			r, s, err = ecdsa.Sign(rand.Reader, private, digest)
+			if err == nil {
+				size := (private.Curve.Params().BitSize + 7) / 8
+				signature = make([]byte, 2*size)
+				r.FillBytes(signature[:size])
+				s.FillBytes(signature[size:])
+			}
+		case ed25519.PrivateKey:
+			signature = ed25519.Sign(private, []byte(signingInput))
+		default:
+			err = fmt.Errorf("unsupported private key type %T", private)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// namedJWTKey returns the verification key of a named key, pinned to its algorithm
+func namedJWTKey(key models.JWTSigningKey) (jwtKey, error) {
+	verification := jwtKey{alg: key.Algorithm}
+	switch {
+	case strings.HasPrefix(key.Algorithm, "HS"):
+		verification.key = []byte(key.Secret)
+	case key.PublicKey != "":
+		public, err := parsePublicKeyPEM(key.PublicKey)
+		if err != nil {
+			return jwtKey{}, err
+		}
+		verification.key = public
+	default:
+		private, err := parsePrivateKeyPEM(key.PrivateKey)
+		if err != nil {
+			return jwtKey{}, err
+		}
+		verification.key = private.Public()
+	}
+	return verification, nil
+}
+
+// findJWTKey returns the named key (the only key when name is empty and just one is configured)
+func findJWTKey(keys []models.JWTSigningKey, name string) (models.JWTSigningKey, error) {
+	if name == "" {
+		if len(keys) == 1 {
+			return keys[0], nil
+		}
+		return models.JWTSigningKey{}, errors.New("name the JWT key to use")
+	}
+	for _, key := range keys {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+	return models.JWTSigningKey{}, fmt.Errorf("unknown JWT key %q", name)
+}
+
+// ValidateJWTKeys checks that key names are unique and that every key can sign or verify with its algorithm
+func ValidateJWTKeys(keys []models.JWTSigningKey) error {
+	names := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key.Name == "" {
+			return errors.New("JWT keys need a name")
+		}
+		if names[key.Name] {
+			return fmt.Errorf("duplicate JWT key name %q", key.Name)
+		}
+		names[key.Name] = true
+		if !isJWTAlgorithm(key.Algorithm) {
+			return fmt.Errorf("JWT key %s: unsupported algorithm %q", key.Name, key.Algorithm)
+		}
+		if strings.HasPrefix(key.Algorithm, "HS") {
+			if key.Secret == "" {
+				return fmt.Errorf("JWT key %s: %s needs a secret", key.Name, key.Algorithm)
+			}
+			continue
+		}
+		if key.PrivateKey == "" && key.PublicKey == "" {
+			return fmt.Errorf("JWT key %s: %s needs a private or public key", key.Name, key.Algorithm)
+		}
+		if key.PrivateKey != "" {
+			if _, err := parsePrivateKeyPEM(key.PrivateKey); err != nil {
+				return fmt.Errorf("JWT key %s: %w", key.Name, err)
+			}
+		}
+		verification, err := namedJWTKey(key)
+		if err != nil {
+			return fmt.Errorf("JWT key %s: %w", key.Name, err)
+		}
+		if !verification.fits(key.Algorithm) {
+			return fmt.Errorf("JWT key %s: the key does not suit %s", key.Name, key.Algorithm)
+		}
+	}
+	return nil
+}
+
+// parsePrivateKeyPEM reads a PEM private key (PKCS#8, PKCS#1 or SEC 1)
+func parsePrivateKeyPEM(text string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(text))
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q (use PRIVATE KEY, RSA PRIVATE KEY or EC PRIVATE KEY)", block.Type)
+}
+
+// parsePublicKeyPEM reads a PEM public key (PKIX or PKCS#1) or the key of a PEM certificate
+func parsePublicKeyPEM(text string) (interface{}, error) {
+	block, _ := pem.Decode([]byte(text))
+	if block == nil {
+		return nil, errors.New("public key is not PEM encoded")
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q (use PUBLIC KEY, RSA PUBLIC KEY or CERTIFICATE)", block.Type)
+}
+
+// parseJWKS reads the signing keys of a JWKS document; encryption keys are skipped
+func parseJWKS(data []byte) ([]jwtKey, error) {
+	var doc struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Alg string `json:"alg"`
+			Use string `json:"use"`
+			Crv string `json:"crv"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+			K   string `json:"k"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	var keys []jwtKey
+	for i, jwk := range doc.Keys {
+		if jwk.Use == "enc" {
+			continue
+		}
+		key, err := jwkPublicKey(jwk.Kty, jwk.Crv, jwk.N, jwk.E, jwk.X, jwk.Y, jwk.K)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %d: %w", i, err)
+		}
+		keys = append(keys, jwtKey{kid: jwk.Kid, alg: jwk.Alg, key: key})
+	}
+	return keys, nil
+}
+
+// jwkPublicKey builds the key described by the members of a JWK
+func jwkPublicKey(kty, crv, n, e, x, y, k string) (interface{}, error) {
+	decode := func(name, value string) ([]byte, error) {
+		raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+		if err != nil || len(raw) == 0 {
+			return nil, fmt.Errorf("invalid %q member", name)
+		}
+		return raw, nil
+	}
+
+	switch kty {
+	case "RSA":
+		modulus, err := decode("n", n)
+		if err != nil {
+			return nil, err
+		}
+		exponent, err := decode("e", e)
+		if err != nil {
+			return nil, err
+		}
+		if len(exponent) > 4 {
+			return nil, errors.New("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(new(big.Int).SetBytes(exponent).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", crv)
+		}
+		xBytes, err := decode("x", x)
+		if err != nil {
+			return nil, err
+		}
+		yBytes, err := decode("y", y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(xBytes), Y: new(big.Int).SetBytes(yBytes)}, nil
+	case "OKP":
+		if crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", crv)
+		}
+		xBytes, err := decode("x", x)
+		if err != nil {
+			return nil, err
+		}
+		if len(xBytes) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(xBytes), nil
+	case "oct":
+		return decode("k", k)
+	}
+	return nil, fmt.Errorf("unsupported key type %q", kty)
+}
+
+// jwksCache holds the key sets fetched from JWKS URLs
+type jwksCache struct {
+	mutex   sync.Mutex
+	entries map[string]*jwksEntry // By URL
+	client  *http.Client
+}
+
+type jwksEntry struct {
+	keys      []jwtKey
+	fetchedAt time.Time
+	err       error
+}
+
+func newJWKSCache() *jwksCache {
+	return &jwksCache{
+		entries: make(map[string]*jwksEntry),
+		client:  &http.Client{Timeout: jwksFetchTimeout},
+	}
+}
+
+// keys returns the key set of a JWKS URL, fetching it when the cached copy is stale or does not
+// have the token's key ID (rotated keys). A failed fetch keeps serving the last good key set.
+func (c *jwksCache) keys(url, kid string) ([]jwtKey, error) {
+	c.mutex.Lock()
+	entry := c.entries[url]
+	c.mutex.Unlock()
+
+	if entry != nil {
+		age := time.Since(entry.fetchedAt)
+		if age < jwksRefetchInterval || (age < jwksCacheTime && (kid == "" || hasKeyID(entry.keys, kid))) {
+			return entry.keys, entry.err
+		}
+	}
+
+	keys, err := c.fetch(url)
+	fresh := &jwksEntry{keys: keys, fetchedAt: time.Now(), err: err}
+	if err != nil {
+		serverLog.Warn("Failed to fetch JWKS from %s: %v", url, err)
+		if entry != nil && len(entry.keys) > 0 {
+			fresh.keys, fresh.err = entry.keys, nil
+		}
+	}
+	c.mutex.Lock()
+	c.entries[url] = fresh
+	c.mutex.Unlock()
+	return fresh.keys, fresh.err
+}
+
+func (c *jwksCache) fetch(url string) ([]jwtKey, error) {
+	resp, err := c.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS URL answered %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return nil, err
+	}
+	return parseJWKS(data)
+}
+
+func hasKeyID(keys []jwtKey, kid string) bool {
+	for _, key := range keys {
+		if key.kid == kid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dop251/goja"
+	"mockelot/models"
+)
+
+// jwtKeyring gives templates and scripts the configured JWT keys, and the virtual clock reading
+// that issued-at and expiry times are based on
+type jwtKeyring struct {
+	keys []models.JWTSigningKey
+	now  time.Time
+}
+
+func newJWTKeyring(keys []models.JWTSigningKey, now time.Time) *jwtKeyring {
+	return &jwtKeyring{keys: keys, now: now}
+}
+
+// sign mints a token with a named key. "iat" is added unless the claims have it; expiresIn
+// (seconds, 0 = none) sets "exp". Extra header members are merged into the JOSE header.
+func (k *jwtKeyring) sign(name string, claims map[string]interface{}, expiresIn int64, header map[string]interface{}) (string, error) {
+	if k == nil || len(k.keys) == 0 {
+		return "", errors.New("no JWT keys are configured (add them under jwt_keys)")
+	}
+	key, err := findJWTKey(k.keys, name)
+	if err != nil {
+		return "", err
+	}
+
+	stamped := make(map[string]interface{}, len(claims)+2)
+	for claim, value := range claims {
+		stamped[claim] = value
+	}
+	if _, ok := stamped["iat"]; !ok {
+		stamped["iat"] = k.now.Unix()
+	}
+	if expiresIn > 0 {
+		stamped["exp"] = k.now.Unix() + expiresIn
+	}
+	return signJWT(key, header, stamped)
+}
+
+// verify checks a token (a "Bearer " prefix is ignored) against a named key, or every key when
+// name is empty, then its "exp" and "nbf". It returns {valid, error, header, claims}; the header
+// and claims are filled in whenever the token decodes, valid or not.
+func (k *jwtKeyring) verify(raw, name string) map[string]interface{} {
+	result := map[string]interface{}{"valid": false, "error": "", "header": nil, "claims": nil}
+	token, err := decodeJWT(stripBearer(raw))
+	if err != nil {
+		result["error"] = err.Error()
+		return result
+	}
+	result["header"], result["claims"] = token.header, token.claims
+
+	var named []models.JWTSigningKey
+	now := time.Now()
+	if k != nil {
+		named, now = k.keys, k.now
+	}
+	if name != "" {
+		key, err := findJWTKey(named, name)
+		if err != nil {
+			result["error"] = err.Error()
+			return result
+		}
+		named = []models.JWTSigningKey{key}
+	}
+	keys := make([]jwtKey, 0, len(named))
+	for _, key := range named {
+		if verification, err := namedJWTKey(key); err == nil {
+			verification.kid = key.KeyID
+			keys = append(keys, verification)
+		}
+	}
+	if err := verifyJWTWithKeys(token, keys); err != nil {
+		result["error"] = err.Error()
+		return result
+	}
+	if _, reason := checkJWTClaims(&models.JWTAuth{}, token.claims, now); reason != "" {
+		result["error"] = reason
+		return result
+	}
+	result["valid"] = true
+	return result
+}
+
+// decodeJWTForScripts reads a token's header and claims without checking it: {header, claims}, plus error
+// when the token does not decode
+func decodeJWTForScripts(raw string) map[string]interface{} {
+	token, err := decodeJWT(stripBearer(raw))
+	if err != nil {
+		return map[string]interface{}{"header": nil, "claims": nil, "error": err.Error()}
+	}
+	return map[string]interface{}{"header": token.header, "claims": token.claims}
+}
+
+func stripBearer(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if scheme, token, ok := strings.Cut(raw, " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return raw
+}
+
+// templateFuncs returns jwtSign, jwtVerify and jwtDecode for template mode. jwtSign takes the
+// claims as a map or a JSON object string, and optionally the lifetime in seconds:
+// {{jwtSign "main" `{"sub":"42"}` 3600}}
+func (k *jwtKeyring) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"jwtSign": func(name string, claims interface{}, expiresIn ...int) (string, error) {
+			claimMap, err := templateClaims(claims)
+			if err != nil {
+				return "", err
+			}
+			lifetime := int64(0)
+			if len(expiresIn) > 0 {
+				lifetime = int64(expiresIn[0])
+			}
+			return k.sign(name, claimMap, lifetime, nil)
+		},
+		"jwtVerify": func(name, token string) map[string]interface{} {
+			return k.verify(token, name)
+		},
+		"jwtDecode": decodeJWTForScripts,
+	}
+}
+
+// templateClaims accepts the claims of jwtSign as a map or a JSON object string
+func templateClaims(claims interface{}) (map[string]interface{}, error) {
+	switch claims := claims.(type) {
+	case map[string]interface{}:
+		return claims, nil
+	case string:
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(claims), &decoded); err != nil {
+			return nil, fmt.Errorf("jwtSign: claims are not a JSON object: %v", err)
+		}
+		return decoded, nil
+	}
+	return nil, fmt.Errorf("jwtSign: claims must be a map or a JSON object string, not %T", claims)
+}
+
+// setupJWT defines jwt.sign(claims, keyName, options), jwt.verify(token, keyName) and
+// jwt.decode(token) in a script runtime. Options are {expiresIn: seconds, header: {...}}; the key
+// name can be left out when a single key is configured. sign throws on errors, verify and decode
+// report them in the returned object.
+func setupJWT(vm *goja.Runtime, keyring *jwtKeyring) error {
+	jwtObj := vm.NewObject()
+	sign := func(call goja.FunctionCall) goja.Value {
+		claims, ok := call.Argument(0).Export().(map[string]interface{})
+		if !ok {
+			panic(vm.NewTypeError("jwt.sign: claims must be an object"))
+		}
+		name := ""
+		if arg := call.Argument(1); !goja.IsUndefined(arg) && !goja.IsNull(arg) {
+			name = arg.String()
+		}
+		var expiresIn int64
+		var header map[string]interface{}
+		if arg := call.Argument(2); !goja.IsUndefined(arg) && !goja.IsNull(arg) {
+			options, _ := arg.Export().(map[string]interface{})
+			switch lifetime := options["expiresIn"].(type) {
+			case int64:
+				expiresIn = lifetime
+			case float64:
+				expiresIn = int64(lifetime)
+			}
+			header, _ = options["header"].(map[string]interface{})
+		}
+
+		token, err := keyring.sign(name, claims, expiresIn, header)
+		if err != nil {
+			panic(vm.NewGoError(fmt.Errorf("jwt.sign: %v", err)))
+		}
+		return vm.ToValue(token)
+	}
+	verify := func(token, name string) map[string]interface{} {
+		return keyring.verify(token, name)
+	}
+
+	if err := jwtObj.Set("sign", sign); err != nil {
+		return err
+	}
+	if err := jwtObj.Set("verify", verify); err != nil {
+		return err
+	}
+	if err := jwtObj.Set("decode", decodeJWTForScripts); err != nil {
+		return err
+	}
+	return vm.Set("jwt", jwtObj)
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/netutil"
+	"mockelot/models"
+)
+
+// currentLimits returns the configured server limits with defaults applied
+func (s *HTTPServer) currentLimits() models.ServerLimits {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+	return s.config.Limits.WithDefaults()
+}
+
+// ValidateServerLimits checks the request limits. Timeouts and the body size can be disabled
+// with a negative value, but the header size cannot: Go's HTTP server always applies one.
+func ValidateServerLimits(limits *models.ServerLimits) error {
+	if limits == nil {
+		return nil
+	}
+	if limits.MaxHeaderBytes < 0 {
+		return fmt.Errorf("invalid max_header_bytes %d: the header size limit cannot be disabled", limits.MaxHeaderBytes)
+	}
+	return nil
+}
+
+// applyServerLimits copies header size and timeout limits onto an http.Server
+func applyServerLimits(srv *http.Server, limits models.ServerLimits) {
+	if limits.MaxHeaderBytes > 0 {
+		srv.MaxHeaderBytes = limits.MaxHeaderBytes
+	}
+	srv.ReadHeaderTimeout = limitDuration(limits.ReadHeaderTimeoutSec)
+	srv.ReadTimeout = limitDuration(limits.ReadTimeoutSec)
+	srv.WriteTimeout = limitDuration(limits.WriteTimeoutSec)
+	srv.IdleTimeout = limitDuration(limits.IdleTimeoutSec)
+}
+
+// limitDuration converts a limit in seconds to a duration (negative disables the timeout)
+func limitDuration(seconds int) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// listenWithLimit opens a TCP listener, capping concurrent connections when maxConns > 0
+func listenWithLimit(addr string, maxConns int) (net.Listener, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if maxConns > 0 {
+		listener = netutil.LimitListener(listener, maxConns)
+	}
+	return listener, nil
+}
+
+// readLimitedBody reads the request body, enforcing maxBytes (negative = unlimited).
+// Returns tooLarge=true when the body exceeds the limit.
+func readLimitedBody(w http.ResponseWriter, r *http.Request, maxBytes int64) (body []byte, tooLarge bool) {
+	if maxBytes > 0 {
+		if r.ContentLength > maxBytes {
+			return nil, true
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return nil, true
+	}
+	return body, false
+}
@@ -0,0 +1,348 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"mockelot/models"
+)
+
+type listenerPortKey struct{}
+type listenerNameKey struct{}
+
+// endpointListener is an extra HTTP listener serving the endpoints configured with its port
+type endpointListener struct {
+	server *http.Server
+	done   chan struct{}
+}
+
+// withListenerPort tags requests with the dedicated endpoint port they arrived on
+func withListenerPort(port int, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), listenerPortKey{}, port)))
+	})
+}
+
+// listenerPort returns the dedicated endpoint port a request arrived on (0 for the shared HTTP/HTTPS/SOCKS5 listeners)
+func listenerPort(r *http.Request) int {
+	port, _ := r.Context().Value(listenerPortKey{}).(int)
+	return port
+}
+
+// withListenerName tags requests with the named listener they arrived on
+func withListenerName(name string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), listenerNameKey{}, name)))
+	})
+}
+
+// listenerName returns the named listener a request arrived on ("" for all other listeners)
+func listenerName(r *http.Request) string {
+	name, _ := r.Context().Value(listenerNameKey{}).(string)
+	return name
+}
+
+// servesOnListener reports whether an endpoint is routed for a request, given the listener it
+// arrived on. Endpoints assigned to a named listener or with their own port are only reachable
+// there, and those listeners serve nothing else.
+func servesOnListener(endpoint *models.Endpoint, r *http.Request) bool {
+	if name := listenerName(r); name != "" || endpoint.Listener != "" {
+		return endpoint.Listener == name
+	}
+	return endpoint.ListenPort == listenerPort(r)
+}
+
+// endpointPorts returns the dedicated ports of enabled endpoints, skipping ports taken by the shared listeners
+func (s *HTTPServer) endpointPorts() []int {
+	s.configMutex.RLock()
+	defer s.configMutex.RUnlock()
+
+	reserved := map[int]bool{s.config.Port: true}
+	if s.config.HTTPSEnabled {
+		reserved[s.config.HTTPSPort] = true
+	}
+	if s.config.GRPC != nil && s.config.GRPC.Enabled {
+		grpcPort := s.config.GRPC.Port
+		if grpcPort == 0 {
+			grpcPort = models.DefaultGRPCPort
+		}
+		reserved[grpcPort] = true
+	}
+	for _, listener := range s.config.Listeners {
+		reserved[listener.Port] = true
+	}
+
+	seen := make(map[int]bool)
+	var ports []int
+	for i := range s.config.Endpoints {
+		endpoint := &s.config.Endpoints[i]
+		port := endpoint.ListenPort
+		if port == 0 || endpoint.Listener != "" || !endpoint.IsEnabled() || seen[port] {
+			continue
+		}
+		if reserved[port] {
+			serverLog.Warn("Endpoint %s: port %d is used by another listener, endpoint is not reachable", endpoint.Name, port)
+			continue
+		}
+		seen[port] = true
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// SyncEndpointListeners starts listeners for newly configured endpoint ports and named listeners,
+// and stops (or, for changed named listeners, restarts) those no longer used
+func (s *HTTPServer) SyncEndpointListeners() {
+	s.syncNamedListeners()
+	ports := s.endpointPorts()
+
+	s.listenersMutex.Lock()
+	defer s.listenersMutex.Unlock()
+
+	wanted := make(map[int]bool, len(ports))
+	for _, port := range ports {
+		wanted[port] = true
+		if _, running := s.endpointListeners[port]; running {
+			continue
+		}
+		listener, err := s.startEndpointListener(port)
+		if err != nil {
+			serverLog.Error("Failed to start endpoint listener on port %d: %v", port, err)
+			continue
+		}
+		s.endpointListeners[port] = listener
+	}
+
+	for port, listener := range s.endpointListeners {
+		if !wanted[port] {
+			stopEndpointListener(port, listener)
+			delete(s.endpointListeners, port)
+		}
+	}
+}
+
+// StopEndpointListeners stops all dedicated endpoint listeners and named listeners
+func (s *HTTPServer) StopEndpointListeners() {
+	s.listenersMutex.Lock()
+	defer s.listenersMutex.Unlock()
+
+	for port, listener := range s.endpointListeners {
+		stopEndpointListener(port, listener)
+		delete(s.endpointListeners, port)
+	}
+	for name, running := range s.namedListeners {
+		stopEndpointListener(running.spec.Port, running.listener)
+		delete(s.namedListeners, name)
+	}
+}
+
+// EndpointListenerPorts returns the dedicated endpoint ports currently listening
+func (s *HTTPServer) EndpointListenerPorts() []int {
+	s.listenersMutex.Lock()
+	defer s.listenersMutex.Unlock()
+
+	ports := make([]int, 0, len(s.endpointListeners))
+	for port := range s.endpointListeners {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// startEndpointListener starts an HTTP listener for the endpoints bound to port
+func (s *HTTPServer) startEndpointListener(port int) (*endpointListener, error) {
+	responseHandler := NewResponseHandler(s.config, s.requestLogger, s.scriptErrorLogger, s.proxyHandler, s.containerHandler, s.history, s.events, s.scheduler, s.perfStats, s.sequences, s.throttle)
+	handler := withListenerPort(port, http.HandlerFunc(responseHandler.HandleRequest))
+
+	s.configMutex.RLock()
+	http2Enabled := s.config.HTTP2Enabled
+	s.configMutex.RUnlock()
+	if http2Enabled {
+		handler = h2c.NewHandler(handler, &http2.Server{})
+	}
+
+	limits := s.currentLimits()
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: handler,
+	}
+	applyServerLimits(srv, limits)
+
+	// Bind synchronously so port conflicts are reported instead of silently dropping the endpoint
+	netListener, err := listenWithLimit(srv.Addr, limits.MaxConnections)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &endpointListener{server: srv, done: make(chan struct{})}
+	go func() {
+		serverLog.Info("Starting endpoint listener on port %d", port)
+		if err := srv.Serve(netListener); err != nil && err != http.ErrServerClosed {
+			serverLog.Error("Endpoint listener on port %d error: %v", port, err)
+		}
+		close(listener.done)
+	}()
+	return listener, nil
+}
+
+// stopEndpointListener shuts a dedicated endpoint listener down and waits for it to exit
+func stopEndpointListener(port int, listener *endpointListener) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := listener.server.Shutdown(ctx); err != nil {
+		serverLog.Error("Endpoint listener on port %d shutdown error: %v", port, err)
+	}
+	<-listener.done
+	serverLog.Info("Endpoint listener on port %d stopped", port)
+}
+
+// runningNamedListener is a started named listener and the settings it was started with
+type runningNamedListener struct {
+	spec     models.Listener
+	listener *endpointListener
+}
+
+// syncNamedListeners starts the configured named listeners, restarts those whose settings changed
+// and stops the removed ones. Listeners without enabled endpoints still run, answering 404.
+func (s *HTTPServer) syncNamedListeners() {
+	s.configMutex.RLock()
+	specs := append([]models.Listener(nil), s.config.Listeners...)
+	s.configMutex.RUnlock()
+
+	s.listenersMutex.Lock()
+	defer s.listenersMutex.Unlock()
+
+	wanted := make(map[string]models.Listener, len(specs))
+	for _, spec := range specs {
+		wanted[spec.Name] = spec
+	}
+
+	for name, running := range s.namedListeners {
+		if spec, ok := wanted[name]; ok && spec == running.spec {
+			continue
+		}
+		stopEndpointListener(running.spec.Port, running.listener)
+		delete(s.namedListeners, name)
+	}
+
+	for _, spec := range specs {
+		if _, running := s.namedListeners[spec.Name]; running {
+			continue
+		}
+		listener, err := s.startNamedListener(spec)
+		if err != nil {
+			serverLog.Error("Failed to start listener %s on port %d: %v", spec.Name, spec.Port, err)
+			continue
+		}
+		s.namedListeners[spec.Name] = &runningNamedListener{spec: spec, listener: listener}
+	}
+}
+
+// ActiveListeners returns the names of the named listeners currently running
+func (s *HTTPServer) ActiveListeners() []string {
+	s.listenersMutex.Lock()
+	defer s.listenersMutex.Unlock()
+
+	names := make([]string, 0, len(s.namedListeners))
+	for name := range s.namedListeners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// startNamedListener starts a named listener serving the endpoints assigned to it
+func (s *HTTPServer) startNamedListener(spec models.Listener) (*endpointListener, error) {
+	responseHandler := NewResponseHandler(s.config, s.requestLogger, s.scriptErrorLogger, s.proxyHandler, s.containerHandler, s.history, s.events, s.scheduler, s.perfStats, s.sequences, s.throttle)
+	var handler http.Handler = withListenerName(spec.Name, http.HandlerFunc(responseHandler.HandleRequest))
+
+	s.configMutex.RLock()
+	http2Enabled := s.config.HTTP2Enabled
+	s.configMutex.RUnlock()
+
+	limits := s.currentLimits()
+	srv := &http.Server{
+		Addr: fmt.Sprintf(":%d", spec.Port),
+	}
+	applyServerLimits(srv, limits)
+
+	if spec.TLS {
+		tlsConfig, err := s.listenerTLSConfig(spec)
+		if err != nil {
+			return nil, err
+		}
+		srv.TLSConfig = tlsConfig
+		if http2Enabled {
+			http2.ConfigureServer(srv, &http2.Server{})
+		} else {
+			srv.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+		}
+	} else if http2Enabled {
+		handler = h2c.NewHandler(handler, &http2.Server{})
+	}
+	srv.Handler = handler
+
+	netListener, err := listenWithLimit(srv.Addr, limits.MaxConnections)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &endpointListener{server: srv, done: make(chan struct{})}
+	go func() {
+		serverLog.Info("Starting listener %s on port %d (tls: %v)", spec.Name, spec.Port, spec.TLS)
+		var err error
+		if spec.TLS {
+			err = srv.ServeTLS(netListener, "", "")
+		} else {
+			err = srv.Serve(netListener)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			serverLog.Error("Listener %s on port %d error: %v", spec.Name, spec.Port, err)
+		}
+		close(listener.done)
+	}()
+	return listener, nil
+}
+
+// listenerTLSConfig builds the TLS settings of a named listener: its own certificate or the HTTPS
+// server's, and client certificate verification when a client CA is set
+func (s *HTTPServer) listenerTLSConfig(spec models.Listener) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if spec.CertPath != "" || spec.KeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(spec.CertPath, spec.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	} else {
+		if s.servingCert.Load() == nil {
+			cert, err := s.buildServingCertificate()
+			if err != nil {
+				return nil, err
+			}
+			s.servingCert.Store(cert)
+		}
+		tlsConfig.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return s.servingCert.Load(), nil
+		}
+	}
+
+	if spec.ClientCAPath != "" {
+		pool, err := loadClientCAs(spec.ClientCAPath)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
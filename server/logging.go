@@ -0,0 +1,12 @@
+package server
+
+import "mockelot/logger"
+
+// Subsystem loggers of the server package
+var (
+	serverLog    = logger.For(logger.SubsystemServer)
+	proxyLog     = logger.For(logger.SubsystemProxy)
+	containerLog = logger.For(logger.SubsystemContainer)
+	socks5Log    = logger.For(logger.SubsystemSOCKS5)
+	dnsLog       = logger.For(logger.SubsystemDNS)
+)
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dop251/goja"
+	"mockelot/models"
+)
+
+// moduleWrapperPrefix turns a module into a function of the CommonJS variables. It ends on the
+// module's first line so error positions match the module source.
+const moduleWrapperPrefix = "(function (module, exports, require) {"
+
+// compiledModule is a module program and the source it was compiled from
+type compiledModule struct {
+	code    string
+	program *goja.Program
+}
+
+var (
+	moduleCache      = make(map[string]compiledModule) // By module name
+	moduleCacheMutex sync.Mutex
+)
+
+// compileModule returns the program of a module, compiling it only when its code changed
+func compileModule(module *models.ScriptModule) (*goja.Program, error) {
+	moduleCacheMutex.Lock()
+	defer moduleCacheMutex.Unlock()
+
+	if cached, ok := moduleCache[module.Name]; ok && cached.code == module.Code {
+		return cached.program, nil
+	}
+	program, err := goja.Compile(module.Name, moduleWrapperPrefix+module.Code+"\n})", false)
+	if err != nil {
+		return nil, err
+	}
+	moduleCache[module.Name] = compiledModule{code: module.Code, program: program}
+	return program, nil
+}
+
+// pruneModuleCache drops the programs of modules that were removed or changed, so the cache only
+// holds the configured modules
+func pruneModuleCache(modules []models.ScriptModule) {
+	current := make(map[string]string, len(modules))
+	for _, module := range modules {
+		current[module.Name] = module.Code
+	}
+
+	moduleCacheMutex.Lock()
+	defer moduleCacheMutex.Unlock()
+	for name, cached := range moduleCache {
+		if code, ok := current[name]; !ok || code != cached.code {
+			delete(moduleCache, name)
+		}
+	}
+}
+
+// setupRequire defines require(name) in a script runtime. Each module runs once per script, so
+// every require of the same name returns the same exports; a module required while it is still
+// loading (a cycle) returns its exports so far.
+func setupRequire(vm *goja.Runtime, modules []models.ScriptModule) error {
+	loaded := make(map[string]*goja.Object)
+
+	require := func(call goja.FunctionCall) goja.Value {
+		name := call.Argument(0).String()
+		if moduleObj, ok := loaded[name]; ok {
+			return moduleObj.Get("exports")
+		}
+
+		var module *models.ScriptModule
+		for i := range modules {
+			if modules[i].Name == name {
+				module = &modules[i]
+				break
+			}
+		}
+		if module == nil {
+			panic(vm.NewGoError(fmt.Errorf("cannot find module %q", name)))
+		}
+
+		program, err := compileModule(module)
+		if err != nil {
+			panic(vm.NewGoError(fmt.Errorf("module %q: %v", name, err)))
+		}
+		wrapper, err := vm.RunProgram(program)
+		if err != nil {
+			panic(err)
+		}
+		fn, ok := goja.AssertFunction(wrapper)
+		if !ok {
+			// The module closed the wrapper function early, e.g. with "}); 1 + (function () {"
+			panic(vm.NewGoError(fmt.Errorf("module %q: code must not close the module wrapper", name)))
+		}
+
+		moduleObj := vm.NewObject()
+		exports := vm.NewObject()
+		moduleObj.Set("exports", exports)
+		loaded[name] = moduleObj
+		if _, err := fn(goja.Undefined(), moduleObj, exports, vm.Get("require")); err != nil {
+			delete(loaded, name)
+			panic(err)
+		}
+		return moduleObj.Get("exports")
+	}
+
+	return vm.Set("require", require)
+}
+
+// ValidateScriptModules checks that module names are set and unique and that every module compiles
+func ValidateScriptModules(modules []models.ScriptModule) []models.ScriptWarning {
+	var warnings []models.ScriptWarning
+	seen := make(map[string]bool)
+	for i, module := range modules {
+		field := "script_modules." + module.Name
+		if module.Name == "" {
+			field = fmt.Sprintf("script_modules[%d]", i)
+			warnings = append(warnings, newScriptWarning(nil, "", field, fmt.Errorf("module name is required")))
+			continue
+		}
+		if seen[module.Name] {
+			warnings = append(warnings, newScriptWarning(nil, "", field, fmt.Errorf("duplicate module name %q", module.Name)))
+			continue
+		}
+		seen[module.Name] = true
+		if err := compileScript(moduleWrapperPrefix + module.Code + "\n})"); err != nil {
+			warnings = append(warnings, newScriptWarning(nil, "", field, err))
+		}
+	}
+	return warnings
+}
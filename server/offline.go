@@ -0,0 +1,36 @@
+package server
+
+import (
+	"net/http"
+
+	"mockelot/models"
+)
+
+// offlineSnapshot returns the mock endpoint recorded for a proxy/container endpoint, or nil if it has none.
+// Snapshots are served even when disabled, since they are normally kept disabled to avoid shadowing the live endpoint.
+// Caller must hold configMutex.
+func (h *ResponseHandler) offlineSnapshot(endpointID string) *models.Endpoint {
+	for i := range h.config.Endpoints {
+		endpoint := &h.config.Endpoints[i]
+		if endpoint.Type == models.EndpointTypeMock && endpoint.SnapshotOf == endpointID {
+			return endpoint
+		}
+	}
+	return nil
+}
+
+// servesOffline reports whether an endpoint type is replaced by its snapshot in offline mode
+func servesOffline(endpoint *models.Endpoint) bool {
+	return endpoint.Type == models.EndpointTypeProxy || endpoint.Type == models.EndpointTypeContainer
+}
+
+// handleOfflineRequest serves a proxy/container request from its recorded snapshot
+func (h *ResponseHandler) handleOfflineRequest(w http.ResponseWriter, r *http.Request, snapshot *models.Endpoint, translatedPath string, bodyBytes []byte) {
+	if snapshot == nil {
+		explain(r, "Offline mode: no snapshot recorded for this endpoint")
+		http.Error(w, "Offline mode: no recorded responses for this endpoint", http.StatusServiceUnavailable)
+		return
+	}
+	explain(r, "Offline mode: served by snapshot %q", snapshot.Name)
+	h.handleMockRequest(w, r, snapshot, translatedPath, bodyBytes)
+}
@@ -1,10 +1,11 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"net/http"
 	"sync"
@@ -99,6 +100,11 @@ func (h *OverlayHandler) handleOverlay(w http.ResponseWriter, r *http.Request, d
 		},
 	}
 
+	// Overlay traffic that came through the SOCKS5 proxy is listed with the SOCKS5 endpoint's logs
+	if socks5Info(r) != nil {
+		proxyEndpoint.ID = socks5EndpointID
+	}
+
 	// 4. Use existing ProxyHandler to execute the request
 	// The ProxyHandler already handles:
 	// - Header manipulation
@@ -156,21 +162,41 @@ func (h *OverlayHandler) resolveRealIP(domain string) (string, error) {
 	}
 	h.cacheMutex.Unlock()
 
-	log.Printf("Resolved %s to %s (cached for %v)", domain, ip, h.cacheExpiry)
+	proxyLog.Debug("Resolved %s to %s (cached for %v)", domain, ip, h.cacheExpiry)
 	return ip, nil
 }
 
 // executeProxyRequest executes a proxy request to the backend server
-// This is a simplified version that directly proxies the request
+// This is a simplified version that directly proxies the request; it is logged through the proxy
+// handler's pending/complete pipeline under the synthetic overlay endpoint
 func (h *OverlayHandler) executeProxyRequest(w http.ResponseWriter, r *http.Request, endpoint *models.Endpoint, originalDomain string) {
 	// Create backend request
 	backendURL := endpoint.ProxyConfig.BackendURL
+	p := h.proxyHandler
+
+	// Read the request body so it can be both forwarded and logged
+	requestBody, _ := io.ReadAll(r.Body)
+
+	// Capture original request headers and query parameters for logging
+	requestHeaders := map[string][]string(r.Header.Clone())
+	queryParams := map[string][]string(r.URL.Query())
+
+	requestID := fmt.Sprintf("%d", time.Now().UnixNano())
+	clientScheme := "http"
+	if r.TLS != nil {
+		clientScheme = "https"
+	}
+	clientFullURL := clientScheme + "://" + r.Host + r.URL.RequestURI()
+
+	// Log request immediately as pending (before waiting for response)
+	p.logPendingRequest(requestID, endpoint, r, clientFullURL, requestHeaders, string(requestBody), queryParams)
+	startTime := time.Now()
 
 	// Create new request to backend
-	backendReq, err := http.NewRequest(r.Method, backendURL, r.Body)
+	backendReq, err := http.NewRequest(r.Method, backendURL, bytes.NewReader(requestBody))
 	if err != nil {
-		log.Printf("Failed to create backend request: %v", err)
-		http.Error(w, "Failed to create backend request", http.StatusInternalServerError)
+		proxyLog.Error("Failed to create backend request: %v", err)
+		h.failRequest(w, r, requestID, endpoint, requestBody, http.StatusInternalServerError, "Failed to create backend request")
 		return
 	}
 
@@ -178,6 +204,7 @@ func (h *OverlayHandler) executeProxyRequest(w http.ResponseWriter, r *http.Requ
 	backendReq.Header = r.Header.Clone()
 
 	// Set Host header to original domain (important for virtual hosting)
+	backendReq.Host = originalDomain
 	backendReq.Header.Set("Host", originalDomain)
 
 	// Set X-Forwarded-* headers
@@ -197,6 +224,11 @@ func (h *OverlayHandler) executeProxyRequest(w http.ResponseWriter, r *http.Requ
 
 	client := &http.Client{
 		Timeout: timeout,
+		// The backend URL uses the resolved IP, so verify the certificate against the original domain
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{ServerName: originalDomain},
+		},
 		// Don't follow redirects - pass them through to client
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
@@ -205,13 +237,23 @@ func (h *OverlayHandler) executeProxyRequest(w http.ResponseWriter, r *http.Requ
 
 	// Execute backend request
 	resp, err := client.Do(backendReq)
+	backendFirstByteTime := time.Now()
 	if err != nil {
-		log.Printf("Backend request failed: %v", err)
-		http.Error(w, "Backend request failed", http.StatusBadGateway)
+		proxyLog.Error("Backend request failed: %v", err)
+		h.failRequest(w, r, requestID, endpoint, requestBody, http.StatusBadGateway, "Backend request failed")
 		return
 	}
 	defer resp.Body.Close()
 
+	// Read response body
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		proxyLog.Error("Failed to read backend response: %v", err)
+		h.failRequest(w, r, requestID, endpoint, requestBody, http.StatusBadGateway, "Failed to read response")
+		return
+	}
+	backendCompletionTime := time.Now()
+
 	// Copy response headers
 	for key, values := range resp.Header {
 		for _, value := range values {
@@ -219,17 +261,40 @@ func (h *OverlayHandler) executeProxyRequest(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	// Write response status
+	// Write response status and body
 	w.WriteHeader(resp.StatusCode)
-
-	// Copy response body
-	if resp.Body != nil {
-		if _, err := io.Copy(w, resp.Body); err != nil {
-			log.Printf("Failed to copy response body: %v", err)
-		}
+	if _, err := w.Write(responseBody); err != nil {
+		proxyLog.Error("Failed to copy response body: %v", err)
 	}
+	completionTime := time.Now()
+
+	proxyLog.Debug("Overlay mode: proxied %s %s to %s (status: %d)", r.Method, r.URL.Path, backendURL, resp.StatusCode)
+
+	// Complete the pending log entry
+	backendDelayMs := backendFirstByteTime.Sub(startTime).Milliseconds()
+	backendRTTMs := backendCompletionTime.Sub(startTime).Milliseconds()
+	p.logProxyRequest(requestID, endpoint, r,
+		clientFullURL, requestHeaders, string(requestBody), queryParams,
+		resp.StatusCode, map[string][]string(resp.Header.Clone()), string(responseBody), backendDelayMs, completionTime.Sub(startTime).Milliseconds(),
+		backendURL, r.Method, backendReq.URL.Path, map[string][]string(backendReq.URL.Query()), map[string][]string(backendReq.Header.Clone()),
+		resp.StatusCode, http.StatusText(resp.StatusCode), map[string][]string(resp.Header.Clone()), string(responseBody), backendDelayMs, backendRTTMs,
+		nil, false)
+}
+
+// failRequest answers an overlay request that could not be proxied and completes its pending log entry
+func (h *OverlayHandler) failRequest(w http.ResponseWriter, r *http.Request, requestID string, endpoint *models.Endpoint, requestBody []byte, status int, message string) {
+	http.Error(w, message, status)
 
-	log.Printf("Overlay mode: proxied %s %s to %s (status: %d)", r.Method, r.URL.Path, backendURL, resp.StatusCode)
+	if h.proxyHandler.logger == nil {
+		return
+	}
+	requestLog := buildRequestLog(r, requestBody, endpoint.ID)
+	requestLog.ID = requestID
+	requestLog.ResponseFailed = true
+	requestLog.ClientResponse.StatusCode = &status
+	requestLog.ClientResponse.StatusText = http.StatusText(status)
+	requestLog.ClientResponse.Body = message
+	h.proxyHandler.logger.UpdateRequestLog(requestLog)
 }
 
 // ClearDNSCache clears the DNS resolution cache
@@ -237,5 +302,5 @@ func (h *OverlayHandler) ClearDNSCache() {
 	h.cacheMutex.Lock()
 	h.dnsCache = make(map[string]*dnsCacheEntry)
 	h.cacheMutex.Unlock()
-	log.Println("DNS cache cleared")
+	proxyLog.Info("DNS cache cleared")
 }
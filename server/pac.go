@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mockelot/models"
+)
+
+// pacContentType is the MIME type browsers expect for proxy auto-config files
+const pacContentType = "application/x-ns-proxy-autoconfig"
+
+// BuildPACFile generates a proxy auto-config (PAC) file that sends the enabled Domain Takeover
+// domains through the running proxies at proxyHost and everything else DIRECT. Without an enabled
+// proxy every host goes DIRECT. Taken-over domains have no DIRECT fallback, so a stopped proxy
+// shows up as a connection error instead of silently reaching the real server.
+func BuildPACFile(domainTakeover *models.DomainTakeoverConfig, socks5 *models.SOCKS5Config, httpProxy *models.HTTPProxyConfig, proxyHost string) string {
+	var b strings.Builder
+	b.WriteString("// Proxy auto-config generated by Mockelot from the Domain Takeover list\n")
+	b.WriteString("function FindProxyForURL(url, host) {\n")
+
+	proxies := pacProxyDirective(socks5, httpProxy, proxyHost)
+	if proxies == "" {
+		b.WriteString("  // No proxy is enabled: enable SOCKS5 or the HTTP proxy to route taken-over domains\n")
+		b.WriteString("  return \"DIRECT\";\n}\n")
+		return b.String()
+	}
+
+	var patterns []string
+	if domainTakeover != nil {
+		for _, domainConfig := range domainTakeover.Domains {
+			// Patterns are regexes; ones that do not compile never match in the proxies either
+			if !domainConfig.Enabled || domainConfig.Pattern == "" {
+				continue
+			}
+			if _, err := regexp.Compile(domainConfig.Pattern); err != nil {
+				continue
+			}
+			patterns = append(patterns, domainConfig.Pattern)
+		}
+	}
+
+	proxyLiteral, _ := json.Marshal(proxies)
+	b.WriteString("  host = host.toLowerCase();\n")
+	b.WriteString("  var domains = [\n")
+	regexps := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		literal, _ := json.Marshal("^(?:" + pattern + ")$")
+		regexps[i] = fmt.Sprintf("    new RegExp(%s, \"i\")", literal)
+	}
+	if len(regexps) > 0 {
+		b.WriteString(strings.Join(regexps, ",\n") + "\n")
+	}
+	b.WriteString("  ];\n")
+	b.WriteString("  for (var i = 0; i < domains.length; i++) {\n")
+	fmt.Fprintf(&b, "    if (domains[i].test(host)) return %s;\n", proxyLiteral)
+	b.WriteString("  }\n")
+	b.WriteString("  return \"DIRECT\";\n}\n")
+	return b.String()
+}
+
+// pacProxyDirective lists the enabled proxies in PAC syntax, HTTP proxy first since every client
+// supports it ("" when none is enabled)
+func pacProxyDirective(socks5 *models.SOCKS5Config, httpProxy *models.HTTPProxyConfig, proxyHost string) string {
+	var directives []string
+	if httpProxy != nil && httpProxy.Enabled {
+		port := httpProxy.Port
+		if port == 0 {
+			port = defaultHTTPProxyPort
+		}
+		directives = append(directives, "PROXY "+net.JoinHostPort(proxyHost, strconv.Itoa(port)))
+	}
+	if socks5 != nil && socks5.Enabled {
+		address := net.JoinHostPort(proxyHost, strconv.Itoa(socks5.Port))
+		directives = append(directives, "SOCKS5 "+address, "SOCKS "+address)
+	}
+	return strings.Join(directives, "; ")
+}
+
+// DefaultPACHost is the address other devices reach this machine on, for PAC files handed out
+// outside an HTTP request (localhost when there is no network)
+func DefaultPACHost() string {
+	if ip := getDefaultGatewayIP(); ip != nil {
+		return ip.String()
+	}
+	return "localhost"
+}
+
+// servePACFile answers the reserved PAC path. The proxies are addressed by the host name the client
+// used to fetch the file, which is an address it can already reach.
+func (h *ResponseHandler) servePACFile(w http.ResponseWriter, r *http.Request) {
+	proxyHost := strings.Trim(r.Host, "[]")
+	if host, _, err := net.SplitHostPort(r.Host); err == nil {
+		proxyHost = host
+	}
+
+	h.configMutex.RLock()
+	pac := BuildPACFile(h.config.DomainTakeover, h.config.SOCKS5Config, h.config.HTTPProxy, proxyHost)
+	h.configMutex.RUnlock()
+
+	w.Header().Set("Content-Type", pacContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write([]byte(pac))
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"mockelot/models"
+)
+
+// PatternValidationError is returned when a config change contains patterns that do not compile
+type PatternValidationError struct {
+	Errors []models.PatternError
+}
+
+func (e *PatternValidationError) Error() string {
+	parts := make([]string, 0, len(e.Errors))
+	for _, pe := range e.Errors {
+		parts = append(parts, pe.String())
+	}
+	return fmt.Sprintf("invalid patterns: %s", strings.Join(parts, "; "))
+}
+
+// ValidatePatterns compiles every regex pattern in the config and returns the ones that fail
+func ValidatePatterns(cfg *models.AppConfig) []models.PatternError {
+	var errs []models.PatternError
+
+	for i := range cfg.Endpoints {
+		errs = append(errs, ValidateEndpointPatterns(&cfg.Endpoints[i])...)
+	}
+
+	// Legacy top-level items and responses (used when no endpoints are configured)
+	errs = append(errs, validateItemPatterns(nil, cfg.Items)...)
+	for i := range cfg.Responses {
+		errs = append(errs, validateResponsePatterns(nil, &cfg.Responses[i])...)
+	}
+
+	errs = append(errs, ValidateDomainPatterns(cfg.DomainTakeover)...)
+	errs = append(errs, ValidateBypassRules(cfg.SOCKS5Config)...)
+
+	return errs
+}
+
+// ValidateEndpointPatterns compiles the regex patterns of a single endpoint and its responses
+func ValidateEndpointPatterns(endpoint *models.Endpoint) []models.PatternError {
+	var errs []models.PatternError
+
+	if strings.HasPrefix(endpoint.PathPrefix, "^") {
+		if err := compilePattern(endpoint.PathPrefix); err != nil {
+			errs = append(errs, newPatternError(endpoint, "", "path_prefix", endpoint.PathPrefix, err))
+		}
+	}
+
+	if endpoint.TranslationMode == models.TranslationModeTranslate && endpoint.TranslatePattern != "" {
+		if err := compilePattern(endpoint.TranslatePattern); err != nil {
+			errs = append(errs, newPatternError(endpoint, "", "translate_pattern", endpoint.TranslatePattern, err))
+		}
+	}
+
+	if endpoint.DomainFilter != nil && endpoint.DomainFilter.Mode == models.DomainFilterModeSpecific {
+		for _, pattern := range endpoint.DomainFilter.Patterns {
+			if err := compilePattern(pattern); err != nil {
+				errs = append(errs, newPatternError(endpoint, "", "domain_filter", pattern, err))
+			}
+		}
+	}
+
+	errs = append(errs, validateItemPatterns(endpoint, endpoint.Items)...)
+	if endpoint.Versioning != nil {
+		for _, version := range endpoint.Versioning.Versions {
+			errs = append(errs, validateItemPatterns(endpoint, version.Items)...)
+		}
+	}
+
+	return errs
+}
+
+// ValidateResponsePatterns compiles the regex patterns of a single response
+func ValidateResponsePatterns(resp *models.MethodResponse) []models.PatternError {
+	return validateResponsePatterns(nil, resp)
+}
+
+// ValidateItemPatterns compiles the regex patterns of standalone and grouped responses
+func ValidateItemPatterns(items []models.ResponseItem) []models.PatternError {
+	return validateItemPatterns(nil, items)
+}
+
+// ValidateDomainPatterns compiles the domain takeover patterns
+func ValidateDomainPatterns(domainTakeover *models.DomainTakeoverConfig) []models.PatternError {
+	if domainTakeover == nil {
+		return nil
+	}
+
+	var errs []models.PatternError
+	for _, domain := range domainTakeover.Domains {
+		if err := compilePattern(domain.Pattern); err != nil {
+			errs = append(errs, models.PatternError{
+				Field:   "domain_takeover",
+				Pattern: domain.Pattern,
+				Error:   err.Error(),
+			})
+		}
+	}
+	return errs
+}
+
+// validateItemPatterns compiles the patterns of standalone and grouped responses
+func validateItemPatterns(endpoint *models.Endpoint, items []models.ResponseItem) []models.PatternError {
+	var errs []models.PatternError
+	for _, item := range items {
+		if item.Type == "response" && item.Response != nil {
+			errs = append(errs, validateResponsePatterns(endpoint, item.Response)...)
+		} else if item.Type == "group" && item.Group != nil {
+			for i := range item.Group.Responses {
+				errs = append(errs, validateResponsePatterns(endpoint, &item.Group.Responses[i])...)
+			}
+		}
+	}
+	return errs
+}
+
+// validateResponsePatterns compiles a response's path pattern and request validation regexes
+func validateResponsePatterns(endpoint *models.Endpoint, resp *models.MethodResponse) []models.PatternError {
+	var errs []models.PatternError
+
+	if isRegexPattern(resp.PathPattern) {
+		if err := compilePattern(resp.PathPattern); err != nil {
+			errs = append(errs, newPatternError(endpoint, resp.ID, "path_pattern", resp.PathPattern, err))
+		}
+	}
+
+	if v := resp.RequestValidation; v != nil {
+		if v.Mode == models.ValidationModeRegex && v.Pattern != "" {
+			if err := compilePattern(v.Pattern); err != nil {
+				errs = append(errs, newPatternError(endpoint, resp.ID, "request_validation.pattern", v.Pattern, err))
+			}
+		}
+		for _, hv := range v.Headers {
+			if hv.Mode == models.HeaderValidationModeRegex && hv.Pattern != "" {
+				if err := compilePattern(hv.Pattern); err != nil {
+					errs = append(errs, newPatternError(endpoint, resp.ID, "request_validation.headers."+hv.Name, hv.Pattern, err))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// isRegexPattern reports whether a response path pattern is treated as a regex (same rule as the matcher)
+func isRegexPattern(pattern string) bool {
+	return strings.HasPrefix(pattern, "^") || strings.HasPrefix(pattern, "(?")
+}
+
+func compilePattern(pattern string) error {
+	_, err := regexp.Compile(pattern)
+	return err
+}
+
+func newPatternError(endpoint *models.Endpoint, responseID, field, pattern string, err error) models.PatternError {
+	pe := models.PatternError{
+		ResponseID: responseID,
+		Field:      field,
+		Pattern:    pattern,
+		Error:      err.Error(),
+	}
+	if endpoint != nil {
+		pe.EndpointID = endpoint.ID
+		pe.EndpointName = endpoint.Name
+	}
+	return pe
+}
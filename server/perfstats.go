@@ -0,0 +1,100 @@
+package server
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"mockelot/models"
+)
+
+// responsePerf accumulates execution cost for one response
+type responsePerf struct {
+	mode       string
+	executions int64
+	errors     int64
+	total      time.Duration
+	max        time.Duration
+	lastError  string
+}
+
+// PerfStats tracks how long template and script responses take to generate, shared by all listeners
+type PerfStats struct {
+	mutex     sync.Mutex
+	responses map[string]*responsePerf // Response ID -> accumulated cost
+}
+
+// NewPerfStats creates empty execution statistics
+func NewPerfStats() *PerfStats {
+	return &PerfStats{responses: make(map[string]*responsePerf)}
+}
+
+// Record adds one execution of a response's template or script
+func (p *PerfStats) Record(responseID, mode string, elapsed time.Duration, err error) {
+	if p == nil || responseID == "" {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	perf := p.responses[responseID]
+	if perf == nil {
+		perf = &responsePerf{}
+		p.responses[responseID] = perf
+	}
+	perf.mode = mode
+	perf.executions++
+	perf.total += elapsed
+	if elapsed > perf.max {
+		perf.max = elapsed
+	}
+	if err != nil {
+		perf.errors++
+		perf.lastError = err.Error()
+	}
+}
+
+// Snapshot returns the statistics of every executed response, most total time first
+func (p *PerfStats) Snapshot() []models.ResponsePerfStats {
+	stats := []models.ResponsePerfStats{}
+	if p == nil {
+		return stats
+	}
+	p.mutex.Lock()
+	for id, perf := range p.responses {
+		stats = append(stats, models.ResponsePerfStats{
+			ResponseID: id,
+			Mode:       perf.mode,
+			Executions: perf.executions,
+			Errors:     perf.errors,
+			ErrorRate:  float64(perf.errors) / float64(perf.executions),
+			TotalMs:    durationMs(perf.total),
+			AvgMs:      durationMs(perf.total) / float64(perf.executions),
+			MaxMs:      durationMs(perf.max),
+			LastError:  perf.lastError,
+		})
+	}
+	p.mutex.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].TotalMs != stats[j].TotalMs {
+			return stats[i].TotalMs > stats[j].TotalMs
+		}
+		return stats[i].ResponseID < stats[j].ResponseID
+	})
+	return stats
+}
+
+// Reset clears all statistics
+func (p *PerfStats) Reset() {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.responses = make(map[string]*responsePerf)
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"mockelot/endpointtype"
+	"mockelot/models"
+)
+
+// pluginMatches asks the plugin of a non built-in endpoint whether it accepts a request that
+// matched its path prefix. Built-in endpoints (and endpoints of unregistered types) always accept.
+func pluginMatches(endpoint *models.Endpoint, r *http.Request) bool {
+	plugin, ok := endpointtype.Lookup(endpoint.Type)
+	if !ok {
+		return true
+	}
+	return plugin.Match(endpoint, r)
+}
+
+// handlePluginRequest serves a request to an endpoint whose type is provided by a plugin
+func (h *ResponseHandler) handlePluginRequest(w http.ResponseWriter, r *http.Request, endpoint *models.Endpoint, translatedPath string, captureGroups []string, bodyBytes []byte) {
+	plugin, ok := endpointtype.Lookup(endpoint.Type)
+	if !ok {
+		http.Error(w, "Unknown endpoint type", http.StatusInternalServerError)
+		return
+	}
+
+	startTime := time.Now()
+	recorder := &pluginResponseRecorder{ResponseWriter: w, startTime: startTime}
+	plugin.Serve(recorder, r, endpointtype.Request{
+		Endpoint:      endpoint,
+		Path:          translatedPath,
+		CaptureGroups: captureGroups,
+		Body:          bodyBytes,
+	})
+
+	requestLog := buildRequestLog(r, bodyBytes, endpoint.ID)
+	rttMs := time.Since(startTime).Milliseconds()
+	if recorder.statusCode != 0 {
+		statusCode := recorder.statusCode
+		requestLog.ClientResponse.StatusCode = &statusCode
+		requestLog.ClientResponse.StatusText = http.StatusText(statusCode)
+		requestLog.ClientResponse.Headers = recorder.Header().Clone()
+		requestLog.ClientResponse.Body = recorder.body.String()
+		requestLog.ClientResponse.DelayMs = &recorder.delayMs
+		requestLog.ClientResponse.RTTMs = &rttMs
+	}
+	h.requestLogger.LogRequest(requestLog)
+}
+
+// pluginResponseRecorder passes a plugin's response through while keeping a copy for the request log
+type pluginResponseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	delayMs    int64
+	startTime  time.Time
+	body       bytes.Buffer
+}
+
+func (p *pluginResponseRecorder) WriteHeader(statusCode int) {
+	if p.statusCode == 0 {
+		p.statusCode = statusCode
+		p.delayMs = time.Since(p.startTime).Milliseconds()
+	}
+	p.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (p *pluginResponseRecorder) Write(data []byte) (int, error) {
+	if p.statusCode == 0 {
+		p.WriteHeader(http.StatusOK)
+	}
+	p.body.Write(data)
+	return p.ResponseWriter.Write(data)
+}
+
+// Flush lets plugins stream responses
+func (p *pluginResponseRecorder) Flush() {
+	if flusher, ok := p.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController (hijacking, deadlines)
+func (p *pluginResponseRecorder) Unwrap() http.ResponseWriter {
+	return p.ResponseWriter
+}
+
+// pluginLifecycle starts and stops the endpoints of plugin types as they come and go
+type pluginLifecycle struct {
+	mutex   sync.Mutex
+	running map[string]runningPluginEndpoint // Keyed by endpoint ID
+}
+
+// runningPluginEndpoint is a started endpoint and the plugin that started it
+type runningPluginEndpoint struct {
+	plugin   endpointtype.Plugin
+	endpoint models.Endpoint
+}
+
+func newPluginLifecycle() *pluginLifecycle {
+	return &pluginLifecycle{running: make(map[string]runningPluginEndpoint)}
+}
+
+// sync starts the enabled plugin endpoints not running yet and stops the ones that were removed,
+// disabled or changed (changed endpoints are then started again with their new settings)
+func (p *pluginLifecycle) sync(endpoints []models.Endpoint) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	wanted := make(map[string]models.Endpoint)
+	for _, endpoint := range endpoints {
+		if _, ok := endpointtype.Lookup(endpoint.Type); ok && endpoint.IsEnabled() {
+			wanted[endpoint.ID] = endpoint
+		}
+	}
+
+	for id, running := range p.running {
+		if endpoint, ok := wanted[id]; ok && reflect.DeepEqual(endpoint, running.endpoint) {
+			continue
+		}
+		if err := running.plugin.Stop(context.Background(), &running.endpoint); err != nil {
+			serverLog.Error("Failed to stop %s endpoint %s: %v", running.endpoint.Type, running.endpoint.Name, err)
+		}
+		delete(p.running, id)
+	}
+
+	for id, endpoint := range wanted {
+		if _, ok := p.running[id]; ok {
+			continue
+		}
+		plugin, _ := endpointtype.Lookup(endpoint.Type)
+		if err := plugin.Start(context.Background(), &endpoint); err != nil {
+			serverLog.Error("Failed to start %s endpoint %s: %v", endpoint.Type, endpoint.Name, err)
+			continue
+		}
+		p.running[id] = runningPluginEndpoint{plugin: plugin, endpoint: endpoint}
+	}
+}
+
+// stopAll stops every running plugin endpoint
+func (p *pluginLifecycle) stopAll() {
+	p.sync(nil)
+}
+
+// pluginReadiness reports the health of an enabled plugin endpoint
+func pluginReadiness(endpoint *models.Endpoint) endpointReadiness {
+	readiness := endpointReadiness{
+		EndpointID: endpoint.ID,
+		Name:       endpoint.Name,
+		Status:     "unknown endpoint type",
+		Reason:     "no plugin registered for type " + endpoint.Type,
+	}
+	plugin, ok := endpointtype.Lookup(endpoint.Type)
+	if !ok {
+		return readiness
+	}
+	health := plugin.Health(endpoint)
+	readiness.Ready = health.Ready
+	readiness.Status = health.Status
+	readiness.Reason = health.Reason
+	return readiness
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"mockelot/models"
+)
+
+// requestProtocol returns the negotiated protocol of a request: "HTTP/1.0", "HTTP/1.1", "HTTP/2" or "HTTP/3"
+func requestProtocol(r *http.Request) string {
+	switch r.ProtoMajor {
+	case 2:
+		return "HTTP/2"
+	case 3:
+		return "HTTP/3"
+	}
+	return r.Proto
+}
+
+// normalizeProtocol maps the accepted spellings of a protocol ("http/2.0", "h2", "h2c", "2", "1.1") to requestProtocol's form
+func normalizeProtocol(protocol string) string {
+	p := strings.ToUpper(strings.TrimSpace(protocol))
+	p = strings.TrimPrefix(p, "HTTP/")
+	switch p {
+	case "2", "2.0", "H2", "H2C":
+		return "HTTP/2"
+	case "3", "3.0", "H3":
+		return "HTTP/3"
+	case "1.0", "1.1":
+		return "HTTP/" + p
+	}
+	return strings.ToUpper(strings.TrimSpace(protocol))
+}
+
+// protocolMatches reports whether a request's protocol is one of a response's protocols (empty = any)
+func protocolMatches(protocols []string, r *http.Request) bool {
+	if len(protocols) == 0 {
+		return true
+	}
+	actual := requestProtocol(r)
+	for _, protocol := range protocols {
+		if normalizeProtocol(protocol) == actual {
+			return true
+		}
+	}
+	return false
+}
+
+// selectProtocolVariant returns a copy of the response with the overrides of the variant for the request's
+// protocol applied ("*" is the fallback); responses without protocol variants are returned unchanged
+func selectProtocolVariant(resp *models.MethodResponse, r *http.Request) *models.MethodResponse {
+	if len(resp.ProtocolVariants) == 0 {
+		return resp
+	}
+
+	actual := requestProtocol(r)
+	var variant *models.ResponseVariant
+	for i := range resp.ProtocolVariants {
+		candidate := &resp.ProtocolVariants[i]
+		if candidate.Value == "*" {
+			if variant == nil {
+				variant = candidate
+			}
+			continue
+		}
+		if normalizeProtocol(candidate.Value) == actual {
+			variant = candidate
+			break
+		}
+	}
+	if variant == nil {
+		return resp
+	}
+
+	selected := *resp
+	if variant.StatusCode != 0 {
+		selected.StatusCode = variant.StatusCode
+	}
+	if variant.Body != "" {
+		selected.Body = variant.Body
+		selected.BodyBase64 = false
+	}
+	if len(variant.Headers) > 0 {
+		headers := make(map[string]string, len(resp.Headers)+len(variant.Headers))
+		for name, value := range resp.Headers {
+			headers[name] = value
+		}
+		for name, value := range variant.Headers {
+			setHeader(headers, name, value)
+		}
+		selected.Headers = headers
+	}
+	return &selected
+}
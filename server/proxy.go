@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"strings"
@@ -24,8 +23,19 @@ type ProxyHandler struct {
 	logger          RequestLogger
 	healthStatus    map[string]*models.HealthStatus
 	healthMutex     sync.RWMutex
-	expressionCache map[string]*goja.Program // Cache for compiled JS expressions
-	cacheMutex      sync.RWMutex             // Mutex for expression cache
+	expressionCache map[string]*goja.Program     // Cache for compiled JS expressions
+	cacheMutex      sync.RWMutex                 // Mutex for expression cache
+	sla             *slaTracker                  // Health check and request outcomes for SLA reports
+	environment     string                       // Active environment selecting each proxy's backend URL
+	variables       Variables                    // Values of ${NAME} references in backend URLs, headers and container environments
+	envMutex        sync.RWMutex                 // Mutex for environment and variables
+	traffic         *TrafficMeter                // Bytes carried per endpoint (nil until a server attaches one)
+	transports      map[string]*backendTransport // Backend connection pools, by endpoint ID
+	transportMutex  sync.Mutex                   // Mutex for transports
+	pools           map[string]*backendPool      // Backend pool health and turns, by endpoint ID
+	poolMutex       sync.Mutex                   // Mutex for pools
+	circuits        map[string]*circuitState     // Circuit breaker states, by endpoint ID
+	circuitMutex    sync.Mutex                   // Mutex for circuits
 }
 
 // NewProxyHandler creates a new proxy handler
@@ -34,9 +44,59 @@ func NewProxyHandler(logger RequestLogger) *ProxyHandler {
 		logger:          logger,
 		healthStatus:    make(map[string]*models.HealthStatus),
 		expressionCache: make(map[string]*goja.Program),
+		sla:             newSLATracker(),
+		transports:      make(map[string]*backendTransport),
+		pools:           make(map[string]*backendPool),
+		circuits:        make(map[string]*circuitState),
 	}
 }
 
+// SetActiveEnvironment selects the environment whose backend URLs all proxy endpoints use
+// (empty = each proxy's backend_url)
+func (p *ProxyHandler) SetActiveEnvironment(environment string) {
+	p.envMutex.Lock()
+	defer p.envMutex.Unlock()
+	p.environment = environment
+}
+
+// SetVariables sets the values ${NAME} references resolve to (the config's variables and active profile)
+func (p *ProxyHandler) SetVariables(variables Variables) {
+	p.envMutex.Lock()
+	defer p.envMutex.Unlock()
+	p.variables = variables
+}
+
+// expandVariables replaces the ${NAME} references in a configured value
+func (p *ProxyHandler) expandVariables(value string) string {
+	if !strings.Contains(value, "${") {
+		return value
+	}
+	p.envMutex.RLock()
+	defer p.envMutex.RUnlock()
+	return p.variables.Expand(value)
+}
+
+// SetTrafficMeter attaches the byte counters that proxied requests are recorded in
+func (p *ProxyHandler) SetTrafficMeter(traffic *TrafficMeter) {
+	p.envMutex.Lock()
+	defer p.envMutex.Unlock()
+	p.traffic = traffic
+}
+
+// trafficMeter returns the attached byte counters (may be nil)
+func (p *ProxyHandler) trafficMeter() *TrafficMeter {
+	p.envMutex.RLock()
+	defer p.envMutex.RUnlock()
+	return p.traffic
+}
+
+// backendURL returns a proxy's backend URL for the active environment
+func (p *ProxyHandler) backendURL(cfg *models.ProxyConfig) string {
+	p.envMutex.RLock()
+	defer p.envMutex.RUnlock()
+	return p.variables.Expand(cfg.ResolveBackendURL(p.environment))
+}
+
 // ServeHTTP handles a proxy request
 func (p *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoint *models.Endpoint, translatedPath string, captureGroups []string) {
 	cfg := endpoint.ProxyConfig
@@ -51,8 +111,30 @@ func (p *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoin
 		return
 	}
 
+	// An open circuit answers with the fallback response without calling the backend
+	breaker := cfg.CircuitBreaker
+	if breaker != nil && !breaker.Enabled {
+		breaker = nil
+	}
+	probe, probeDone := false, false
+	if breaker != nil {
+		allowed, isProbe, retryAfter, state := p.circuitAllow(endpoint.ID, breaker)
+		if !allowed {
+			p.serveCircuitFallback(w, r, endpoint, breaker, retryAfter, state)
+			return
+		}
+		probe = isProbe
+	}
+	defer func() {
+		// A probe that never reached the backend must not keep the circuit half-open forever
+		if probe && !probeDone {
+			p.circuitRelease(endpoint.ID, true)
+		}
+	}()
+
 	// Build backend URL with capture group substitution
-	backendURLStr := p.substituteCaptureGroups(cfg.BackendURL, captureGroups)
+	baseURL := p.pickBackend(endpoint.ID, cfg, nil)
+	backendURLStr := p.substituteCaptureGroups(baseURL, captureGroups)
 	backendURL, err := url.Parse(backendURLStr)
 	if err != nil {
 		http.Error(w, "Invalid backend URL", http.StatusInternalServerError)
@@ -113,7 +195,7 @@ func (p *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoin
 	}
 
 	// Log the backend URL being proxied to
-	log.Printf("Proxy request: %s %s", r.Method, backendURL.String())
+	proxyLog.Debug("Proxy request: %s %s", r.Method, backendURL.String())
 
 	// Copy headers
 	for name, values := range r.Header {
@@ -122,6 +204,11 @@ func (p *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoin
 		}
 	}
 
+	// A body the proxy works on must come in a coding it can decode
+	if !streamsProxyBody(cfg) || cfg.RewriteBodyURLs || len(cfg.BodyURLRewrites) > 0 {
+		limitAcceptEncoding(proxyReq.Header)
+	}
+
 	// Apply inbound header manipulation
 	p.applyHeaderManipulation(proxyReq.Header, cfg.InboundHeaders, r)
 
@@ -152,40 +239,90 @@ func (p *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoin
 	if timeout == 0 {
 		timeout = 30 * time.Second
 	}
-	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	// The timeout covers the whole exchange, except for streamed bodies, which can take as long as
+	// they need once the backend has answered
+	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
+	timeoutTimer := time.AfterFunc(timeout, cancel)
+	defer timeoutTimer.Stop()
 	proxyReq = proxyReq.WithContext(ctx)
 
 	// Execute backend request and measure timing
 	// Note: Don't follow redirects - pass them through to the client
 	client := &http.Client{
-		Timeout: timeout,
+		Transport: p.backendTransport(endpoint.ID, cfg),
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse // Don't follow redirects, return redirect response to client
 		},
 	}
 	backendStartTime := time.Now()
 	resp, err := client.Do(proxyReq)
+
+	// A pool sends requests that could not reach their backend to the next one
+	if p.usesBackendPool(cfg) {
+		tried := map[string]bool{baseURL: true}
+		for r.Context().Err() == nil { // A client that went away says nothing about the backend
+			p.recordBackendResult(endpoint.ID, baseURL, err)
+			if err == nil || ctx.Err() != nil {
+				break
+			}
+			next := p.pickBackend(endpoint.ID, cfg, tried)
+			if next == "" {
+				break
+			}
+			nextURL, parseErr := url.Parse(p.substituteCaptureGroups(next, captureGroups))
+			if parseErr != nil {
+				tried[next] = true
+				continue
+			}
+			proxyLog.Warn("Backend %s failed (%v), retrying %s %s on %s", baseURL, err, r.Method, r.URL.Path, next)
+			nextURL.Path = translatedPath
+			nextURL.RawQuery = r.URL.RawQuery
+			retryReq := proxyReq.Clone(ctx)
+			retryReq.URL = nextURL
+			retryReq.Host = nextURL.Host
+			retryReq.Body = io.NopCloser(strings.NewReader(requestBody))
+			baseURL, backendURLStr, backendFullURL = next, nextURL.String(), nextURL.String()
+			tried[next] = true
+			resp, err = client.Do(retryReq)
+		}
+	}
 	backendFirstByteTime := time.Now() // Response headers received
 
+	if breaker != nil && r.Context().Err() == nil { // A client that went away says nothing about the backend
+		p.circuitRecord(endpoint.ID, breaker, probe, p.circuitFailure(breaker, resp, err))
+		probeDone = true
+	}
+
 	if err != nil {
+		p.sla.recordRequest(endpoint.ID, false, backendFirstByteTime.Sub(backendStartTime).Milliseconds())
 		http.Error(w, "Backend request failed", http.StatusBadGateway)
 		// Note: For error cases, we don't have complete timing data
 		return
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		http.Error(w, "Failed to read response", http.StatusBadGateway)
-		return
+	// Bodies nothing needs to read are streamed; the others are read in full first
+	rewriteURLs := rewritesBodyURLs(cfg, resp.Header)
+	streaming := streamsProxyBody(cfg) && !rewriteURLs
+	var bodyBytes []byte
+	if streaming {
+		timeoutTimer.Stop()
+	} else {
+		bodyBytes, err = io.ReadAll(resp.Body)
+		if err != nil {
+			http.Error(w, "Failed to read response", http.StatusBadGateway)
+			return
+		}
 	}
-	backendCompletionTime := time.Now() // Full response received
+	backendCompletionTime := time.Now() // Full response received (headers only when streaming)
 
 	// Calculate backend timing metrics
 	backendDelayMs := backendFirstByteTime.Sub(backendStartTime).Milliseconds()
 	backendRTTMs := backendCompletionTime.Sub(backendStartTime).Milliseconds()
+	if !streaming {
+		p.sla.recordRequest(endpoint.ID, resp.StatusCode < slaSuccessCutoff, backendRTTMs)
+	}
 
 	// Capture backend response headers for logging
 	backendRespHeaders := make(map[string][]string, len(resp.Header))
@@ -195,18 +332,59 @@ func (p *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoin
 		backendRespHeaders[name] = valuesCopy
 	}
 
+	// Work on the decoded body. The client gets the backend's bytes unless the body is changed,
+	// and a changed body is compressed again with the backend's coding.
+	encoding := resp.Header.Get("Content-Encoding")
+	wireBody := bodyBytes
+	decoded := false
+	if !streaming {
+		plain, err := decodeContentEncoding(bodyBytes, encoding)
+		decoded = err == nil
+		if decoded {
+			bodyBytes = plain
+		} else {
+			proxyLog.Warn("Passing %s %s through unchanged, its body cannot be decoded: %v", r.Method, r.URL.Path, err)
+			bodyBytes = []byte(readableBody(wireBody, encoding, false))
+		}
+	}
+
 	// Save original backend response body before transformation
 	originalBackendBody := string(bodyBytes)
 	backendStatusCode := resp.StatusCode
 	backendStatusText := http.StatusText(resp.StatusCode)
 
-	// Apply body transformation
-	if cfg.BodyTransform != "" {
+	// Run assertion script against the untransformed backend response
+	var assertion *models.AssertionResult
+	if cfg.AssertionScript != "" {
+		assertion = RunAssertionScript(cfg.AssertionScript, backendStatusCode, backendRespHeaders, originalBackendBody)
+		if !assertion.Passed {
+			proxyLog.Warn("Proxy assertion failed for %s %s: %s", r.Method, r.URL.Path, assertion.Message)
+		}
+	}
+
+	// Rewrite backend URLs, then apply field mappings and the body transformation
+	bodyRewritten := false
+	if decoded && rewriteURLs {
+		bodyBytes, bodyRewritten = rewriteBodyURLs(bodyBytes, bodyURLRewrites(cfg, backendURLStr, endpoint, r))
+	}
+	if decoded && len(cfg.FieldMappings) > 0 {
+		var mapped bool
+		bodyBytes, mapped = ApplyFieldMappings(bodyBytes, cfg.FieldMappings)
+		bodyRewritten = bodyRewritten || mapped
+	}
+	if decoded && cfg.BodyTransform != "" {
 		bodyBytes, err = p.transformBody(bodyBytes, resp.Header.Get("Content-Type"), cfg.BodyTransform)
 		if err != nil {
 			http.Error(w, "Body transformation failed", http.StatusInternalServerError)
 			return
 		}
+		bodyRewritten = true
+	}
+	if bodyRewritten {
+		if wireBody, err = encodeContentEncoding(bodyBytes, encoding); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			return
+		}
 	}
 
 	// Apply status code translation
@@ -221,14 +399,18 @@ func (p *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoin
 			w.Header().Add(name, value)
 		}
 	}
+	if bodyRewritten {
+		// The backend's length no longer matches the body
+		w.Header().Del("Content-Length")
+	}
 
 	// Rewrite redirect Location headers to route back through our proxy
 	if statusCode >= 300 && statusCode < 400 {
 		if location := resp.Header.Get("Location"); location != "" {
-			rewrittenLocation := p.rewriteRedirectLocation(location, cfg.BackendURL, r.URL.Path, translatedPath, endpoint, r)
+			rewrittenLocation := p.rewriteRedirectLocation(location, baseURL, r.URL.Path, translatedPath, endpoint, r)
 			if rewrittenLocation != location {
 				w.Header().Set("Location", rewrittenLocation)
-				log.Printf("Redirect rewrite: %s -> %s", location, rewrittenLocation)
+				proxyLog.Debug("Redirect rewrite: %s -> %s", location, rewrittenLocation)
 			}
 		}
 	}
@@ -249,10 +431,24 @@ func (p *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoin
 
 	// Write response
 	w.WriteHeader(statusCode)
-	w.Write(bodyBytes)
+	bodySent := int64(len(wireBody))
+	bodyTruncated := false
+	if streaming {
+		var loggedBody string
+		bodySent, loggedBody, bodyTruncated = copyProxyBody(w, resp.Body, cfg.LogBodyLimit)
+		loggedBody = readableBody([]byte(loggedBody), encoding, bodyTruncated)
+		bodyBytes = []byte(loggedBody)
+		originalBackendBody = loggedBody
+
+		backendRTTMs = time.Since(backendStartTime).Milliseconds()
+		p.sla.recordRequest(endpoint.ID, resp.StatusCode < slaSuccessCutoff, backendRTTMs)
+	} else {
+		w.Write(wireBody)
+	}
 
 	// Capture client completion time
 	clientCompletionTime := time.Now()
+	p.trafficMeter().RecordEndpoint(endpoint, int64(len(requestBody)), bodySent)
 
 	// Calculate client timing metrics
 	clientDelayMs := clientFirstByteTime.Sub(clientStartTime).Milliseconds()
@@ -264,7 +460,7 @@ func (p *ProxyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request, endpoin
 		clientFullURL, requestHeaders, requestBody, queryParams,
 		statusCode, finalRespHeaders, string(bodyBytes), clientDelayMs, clientRTTMs,
 		backendFullURL, r.Method, translatedPath, backendQueryParams, backendReqHeaders,
-		backendStatusCode, backendStatusText, backendRespHeaders, originalBackendBody, backendDelayMs, backendRTTMs)
+		backendStatusCode, backendStatusText, backendRespHeaders, originalBackendBody, backendDelayMs, backendRTTMs, assertion, bodyTruncated)
 }
 
 // compileExpression compiles a JS expression and caches it
@@ -343,19 +539,19 @@ func (p *ProxyHandler) applyHeaderManipulationWithContext(headers http.Header, m
 		case models.HeaderModeDrop:
 			headers.Del(manip.Name)
 		case models.HeaderModeReplace:
-			headers.Set(manip.Name, manip.Value)
+			headers.Set(manip.Name, p.expandVariables(manip.Value))
 		case models.HeaderModeExpression:
 			// Use cached compiled expression for performance
 			program, err := p.compileExpression(manip.Expression)
 			if err != nil {
-				log.Printf("Failed to compile header expression for %s: %v", manip.Name, err)
+				proxyLog.Error("Failed to compile header expression for %s: %v", manip.Name, err)
 				continue
 			}
 			result, err := vm.RunProgram(program)
 			if err == nil {
 				headers.Set(manip.Name, result.String())
 			} else {
-				log.Printf("Failed to evaluate header expression for %s: %v", manip.Name, err)
+				proxyLog.Error("Failed to evaluate header expression for %s: %v", manip.Name, err)
 			}
 		}
 	}
@@ -542,7 +738,8 @@ func (p *ProxyHandler) handleWebSocket(w http.ResponseWriter, r *http.Request, e
 	defer clientConn.Close()
 
 	// Connect to backend WebSocket with capture group substitution
-	backendURL := p.substituteCaptureGroups(endpoint.ProxyConfig.BackendURL, captureGroups)
+	baseURL := p.pickBackend(endpoint.ID, endpoint.ProxyConfig, nil)
+	backendURL := p.substituteCaptureGroups(baseURL, captureGroups)
 	backendURL = strings.Replace(backendURL, "http://", "ws://", 1)
 	backendURL = strings.Replace(backendURL, "https://", "wss://", 1)
 	backendURL += translatedPath
@@ -551,14 +748,19 @@ func (p *ProxyHandler) handleWebSocket(w http.ResponseWriter, r *http.Request, e
 	}
 
 	backendConn, _, err := websocket.DefaultDialer.Dial(backendURL, nil)
+	if p.usesBackendPool(endpoint.ProxyConfig) {
+		p.recordBackendResult(endpoint.ID, baseURL, err)
+	}
 	if err != nil {
 		clientConn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "Backend connection failed"))
 		return
 	}
 	defer backendConn.Close()
 
-	// Bidirectional forwarding
+	// Bidirectional forwarding (message bytes count toward the endpoint's traffic)
 	errChan := make(chan error, 2)
+	traffic := p.trafficMeter()
+	traffic.RecordEndpoint(endpoint, 0, 0)
 
 	// Client -> Backend
 	go func() {
@@ -572,6 +774,7 @@ func (p *ProxyHandler) handleWebSocket(w http.ResponseWriter, r *http.Request, e
 				errChan <- err
 				return
 			}
+			traffic.recordEndpointBytes(endpoint, int64(len(msg)), 0)
 		}
 	}()
 
@@ -587,6 +790,7 @@ func (p *ProxyHandler) handleWebSocket(w http.ResponseWriter, r *http.Request, e
 				errChan <- err
 				return
 			}
+			traffic.recordEndpointBytes(endpoint, 0, int64(len(msg)))
 		}
 	}()
 
@@ -620,7 +824,9 @@ func (p *ProxyHandler) healthCheckLoop(endpoint *models.Endpoint) {
 	defer ticker.Stop()
 
 	for range ticker.C {
+		checkStart := time.Now()
 		healthy, errMsg := p.performHealthCheck(endpoint)
+		p.sla.recordHealth(endpoint.ID, healthy, time.Since(checkStart).Milliseconds())
 
 		p.healthMutex.Lock()
 		p.healthStatus[endpoint.ID] = &models.HealthStatus{
@@ -633,17 +839,39 @@ func (p *ProxyHandler) healthCheckLoop(endpoint *models.Endpoint) {
 	}
 }
 
-// performHealthCheck performs a single health check
+// performHealthCheck performs a single health check. A pool checks every backend and is healthy
+// while any of them is.
 func (p *ProxyHandler) performHealthCheck(endpoint *models.Endpoint) (bool, string) {
+	cfg := endpoint.ProxyConfig
+	if !p.usesBackendPool(cfg) {
+		return p.checkBackend(endpoint, p.backendURL(cfg))
+	}
+
+	anyHealthy := false
+	var failures []string
+	for _, backend := range p.poolBackends(cfg) {
+		healthy, errMsg := p.checkBackend(endpoint, backend.URL)
+		p.recordBackendCheck(endpoint.ID, backend.URL, healthy, errMsg)
+		if healthy {
+			anyHealthy = true
+		} else {
+			failures = append(failures, backend.URL+": "+errMsg)
+		}
+	}
+	return anyHealthy, strings.Join(failures, "; ")
+}
+
+// checkBackend requests the health check path of one backend
+func (p *ProxyHandler) checkBackend(endpoint *models.Endpoint, backendURL string) (bool, string) {
 	cfg := endpoint.ProxyConfig
 	healthPath := cfg.HealthCheckPath
 	if healthPath == "" {
 		healthPath = "/"
 	}
 
-	healthURL := cfg.BackendURL + healthPath
+	healthURL := backendURL + healthPath
 
-	client := &http.Client{Timeout: 5 * time.Second}
+	client := &http.Client{Transport: p.backendTransport(endpoint.ID, cfg), Timeout: 5 * time.Second}
 	resp, err := client.Get(healthURL)
 	if err != nil {
 		return false, err.Error()
@@ -672,13 +900,18 @@ func (p *ProxyHandler) logProxyRequest(requestID string, endpoint *models.Endpoi
 	clientFullURL string, clientReqHeaders map[string][]string, clientReqBody string, clientQueryParams map[string][]string,
 	clientStatusCode int, clientRespHeaders map[string][]string, clientRespBody string, clientDelayMs int64, clientRTTMs int64,
 	backendFullURL string, backendMethod string, backendPath string, backendQueryParams map[string][]string, backendReqHeaders map[string][]string,
-	backendStatusCode int, backendStatusText string, backendRespHeaders map[string][]string, backendRespBody string, backendDelayMs int64, backendRTTMs int64) {
+	backendStatusCode int, backendStatusText string, backendRespHeaders map[string][]string, backendRespBody string, backendDelayMs int64, backendRTTMs int64,
+	assertion *models.AssertionResult, bodyTruncated bool) {
 	if p.logger != nil {
 		// Create RequestLog with new nested structure
 		requestLog := models.RequestLog{
-			ID:         requestID,
-			Timestamp:  time.Now().Format(time.RFC3339),
-			EndpointID: endpoint.ID,
+			ID:            requestID,
+			Timestamp:     time.Now().Format(models.RequestLogTimeFormat),
+			EndpointID:    endpoint.ID,
+			Match:         matchInfoSnapshot(r),
+			SOCKS5Info:    socks5Info(r),
+			Assertion:     assertion,
+			BodyTruncated: bodyTruncated,
 		}
 
 		// Populate client request
@@ -690,6 +923,7 @@ func (p *ProxyHandler) logProxyRequest(requestID string, endpoint *models.Endpoi
 		requestLog.ClientRequest.Body = clientReqBody
 		requestLog.ClientRequest.Protocol = r.Proto
 		requestLog.ClientRequest.SourceIP = r.RemoteAddr
+		requestLog.ClientRequest.ClientCert = clientCertInfo(r)
 		requestLog.ClientRequest.UserAgent = r.Header.Get("User-Agent")
 
 		// Populate client response
@@ -745,8 +979,10 @@ func (p *ProxyHandler) logPendingRequest(requestID string, endpoint *models.Endp
 		// Create RequestLog with pending status
 		requestLog := models.RequestLog{
 			ID:         requestID,
-			Timestamp:  time.Now().Format(time.RFC3339),
+			Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
 			EndpointID: endpoint.ID,
+			Match:      matchInfoSnapshot(r),
+			SOCKS5Info: socks5Info(r),
 		}
 
 		// Populate client request (we have this data immediately)
@@ -758,6 +994,7 @@ func (p *ProxyHandler) logPendingRequest(requestID string, endpoint *models.Endp
 		requestLog.ClientRequest.Body = clientReqBody
 		requestLog.ClientRequest.Protocol = r.Proto
 		requestLog.ClientRequest.SourceIP = r.RemoteAddr
+		requestLog.ClientRequest.ClientCert = clientCertInfo(r)
 		requestLog.ClientRequest.UserAgent = r.Header.Get("User-Agent")
 
 		// Client response is empty (pending)
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+// backendDownTime is how long a backend that could not be reached is left out of the rotation,
+// unless a health check brings it back sooner
+const backendDownTime = 10 * time.Second
+
+// backendState is the health and traffic of one backend in a pool
+type backendState struct {
+	healthy     bool
+	downUntil   time.Time // Set when a request failed: the backend gets another try after this
+	requests    int64
+	failures    int64
+	lastError   string
+	lastFailure time.Time
+	lastCheck   time.Time
+}
+
+// available reports whether a backend is in the rotation
+func (s *backendState) available(now time.Time) bool {
+	return s.healthy || (!s.downUntil.IsZero() && now.After(s.downUntil))
+}
+
+// backendPool tracks the backends of one proxy endpoint
+type backendPool struct {
+	states map[string]*backendState // By backend URL
+	next   uint64                   // Turn counter of the round-robin and weighted strategies
+}
+
+// usesBackendPool reports whether a proxy spreads requests over its backend pool in the active environment
+func (p *ProxyHandler) usesBackendPool(cfg *models.ProxyConfig) bool {
+	p.envMutex.RLock()
+	defer p.envMutex.RUnlock()
+	return cfg.UsesBackendPool(p.environment)
+}
+
+// pickBackend returns the base URL the next request of an endpoint goes to. Pools skip backends
+// that are down, and the ones in exclude (already tried for this request); when every backend is
+// down they are all tried anyway. Returns "" when every backend has been tried.
+func (p *ProxyHandler) pickBackend(endpointID string, cfg *models.ProxyConfig, exclude map[string]bool) string {
+	if !p.usesBackendPool(cfg) {
+		if backendURL := p.backendURL(cfg); !exclude[backendURL] {
+			return backendURL
+		}
+		return ""
+	}
+
+	p.poolMutex.Lock()
+	defer p.poolMutex.Unlock()
+	pool := p.pool(endpointID)
+	now := time.Now()
+
+	var candidates, fallback []models.ProxyBackend
+	for _, backend := range p.poolBackends(cfg) {
+		if backend.URL == "" || exclude[backend.URL] {
+			continue
+		}
+		fallback = append(fallback, backend)
+		if pool.state(backend.URL).available(now) {
+			candidates = append(candidates, backend)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = fallback
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	var chosen models.ProxyBackend
+	switch cfg.BalanceStrategy {
+	case models.ProxyBalanceRandom:
+		chosen = candidates[rand.IntN(len(candidates))]
+	case models.ProxyBalanceWeighted:
+		total := 0
+		for _, backend := range candidates {
+			total += backendWeight(backend)
+		}
+		turn := int(pool.next % uint64(total))
+		for _, backend := range candidates {
+			if turn < backendWeight(backend) {
+				chosen = backend
+				break
+			}
+			turn -= backendWeight(backend)
+		}
+		pool.next++
+	default:
+		chosen = candidates[pool.next%uint64(len(candidates))]
+		pool.next++
+	}
+	pool.state(chosen.URL).requests++
+	return chosen.URL
+}
+
+// poolBackends returns the backend pool of a proxy with the variables in the URLs resolved
+func (p *ProxyHandler) poolBackends(cfg *models.ProxyConfig) []models.ProxyBackend {
+	backends := make([]models.ProxyBackend, len(cfg.Backends))
+	for i, backend := range cfg.Backends {
+		backend.URL = p.expandVariables(backend.URL)
+		backends[i] = backend
+	}
+	return backends
+}
+
+// recordBackendResult updates a pool backend after a request: one that could not be reached is
+// taken out of the rotation for backendDownTime, one that answered is back in it
+func (p *ProxyHandler) recordBackendResult(endpointID, backendURL string, err error) {
+	p.poolMutex.Lock()
+	defer p.poolMutex.Unlock()
+	state := p.pool(endpointID).state(backendURL)
+	if err == nil {
+		state.healthy = true
+		state.downUntil = time.Time{}
+		return
+	}
+	now := time.Now()
+	state.healthy = false
+	state.downUntil = now.Add(backendDownTime)
+	state.failures++
+	state.lastError = err.Error()
+	state.lastFailure = now
+}
+
+// recordBackendCheck stores the outcome of a pool backend's health check. A failed check keeps
+// the backend out of the rotation until a check passes.
+func (p *ProxyHandler) recordBackendCheck(endpointID, backendURL string, healthy bool, errMsg string) {
+	p.poolMutex.Lock()
+	defer p.poolMutex.Unlock()
+	state := p.pool(endpointID).state(backendURL)
+	state.healthy = healthy
+	state.downUntil = time.Time{}
+	state.lastCheck = time.Now()
+	if !healthy {
+		state.lastError = errMsg
+	}
+}
+
+// BackendPoolStatus returns the health and traffic of each backend in an endpoint's pool
+func (p *ProxyHandler) BackendPoolStatus(endpointID string, cfg *models.ProxyConfig) []models.ProxyBackendStatus {
+	statuses := []models.ProxyBackendStatus{}
+	if cfg == nil {
+		return statuses
+	}
+
+	p.poolMutex.Lock()
+	defer p.poolMutex.Unlock()
+	pool := p.pool(endpointID)
+	now := time.Now()
+	for _, backend := range p.poolBackends(cfg) {
+		state := pool.state(backend.URL)
+		status := models.ProxyBackendStatus{
+			URL:       backend.URL,
+			Weight:    backendWeight(backend),
+			Healthy:   state.available(now),
+			Requests:  state.requests,
+			Failures:  state.failures,
+			LastError: state.lastError,
+		}
+		if !state.lastFailure.IsZero() {
+			status.LastFailure = state.lastFailure.Format(time.RFC3339)
+		}
+		if !state.lastCheck.IsZero() {
+			status.LastCheck = state.lastCheck.Format(time.RFC3339)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// pool returns the pool of an endpoint, creating it on first use (caller holds poolMutex)
+func (p *ProxyHandler) pool(endpointID string) *backendPool {
+	pool := p.pools[endpointID]
+	if pool == nil {
+		pool = &backendPool{states: make(map[string]*backendState)}
+		p.pools[endpointID] = pool
+	}
+	return pool
+}
+
+// state returns the state of a backend; backends start out healthy
+func (b *backendPool) state(backendURL string) *backendState {
+	state := b.states[backendURL]
+	if state == nil {
+		state = &backendState{healthy: true}
+		b.states[backendURL] = state
+	}
+	return state
+}
+
+// backendWeight returns a backend's weight (at least 1)
+func backendWeight(backend models.ProxyBackend) int {
+	if backend.Weight > 0 {
+		return backend.Weight
+	}
+	return 1
+}
+
+// ValidateProxyBackends checks the backend pool and balancing strategy of a proxy endpoint
+func ValidateProxyBackends(cfg *models.ProxyConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	switch cfg.BalanceStrategy {
+	case "", models.ProxyBalanceRoundRobin, models.ProxyBalanceWeighted, models.ProxyBalanceRandom:
+	default:
+		return fmt.Errorf("unknown balance strategy %q (use round_robin, weighted or random)", cfg.BalanceStrategy)
+	}
+	seen := make(map[string]bool, len(cfg.Backends))
+	for _, backend := range cfg.Backends {
+		// URLs with variables can only be checked once they are resolved
+		parsed, err := url.Parse(backend.URL)
+		if !strings.Contains(backend.URL, "${") && (err != nil || parsed.Scheme == "" || parsed.Host == "") {
+			return fmt.Errorf("backend %q is not an absolute URL", backend.URL)
+		}
+		if seen[backend.URL] {
+			return fmt.Errorf("backend %s is listed twice", backend.URL)
+		}
+		seen[backend.URL] = true
+		if backend.Weight < 0 {
+			return fmt.Errorf("backend %s has a negative weight", backend.URL)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// limitAcceptEncoding keeps only the codings a proxy can decode (gzip and deflate) in the
+// Accept-Encoding sent to a backend whose body it has to read. When none is left the header is
+// removed, and the transport asks for gzip itself and hands back the decoded body.
+func limitAcceptEncoding(header http.Header) {
+	var kept []string
+	for _, part := range strings.Split(strings.Join(header.Values("Accept-Encoding"), ","), ",") {
+		part = strings.TrimSpace(part)
+		name, params, _ := strings.Cut(part, ";")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "gzip", "x-gzip", "deflate":
+			if !zeroQuality(params) {
+				kept = append(kept, part)
+			}
+		}
+	}
+	if len(kept) == 0 {
+		header.Del("Accept-Encoding")
+		return
+	}
+	header.Set("Accept-Encoding", strings.Join(kept, ", "))
+}
+
+// zeroQuality reports whether Accept-Encoding parameters refuse a coding ("q=0")
+func zeroQuality(params string) bool {
+	for _, param := range strings.Split(params, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "q") {
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			return err == nil && q == 0
+		}
+	}
+	return false
+}
+
+// decodeContentEncoding undoes a response's Content-Encoding. A body that ends early (a streamed
+// body cut for the log) decodes as far as it goes, along with the error.
+func decodeContentEncoding(body []byte, encoding string) ([]byte, error) {
+	var reader io.ReadCloser
+	var err error
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		reader, err = gzip.NewReader(bytes.NewReader(body))
+	case "deflate":
+		// "deflate" is zlib-wrapped, but some servers send raw deflate data
+		reader, err = zlib.NewReader(bytes.NewReader(body))
+		if err != nil {
+			reader, err = flate.NewReader(bytes.NewReader(body)), nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// encodeContentEncoding compresses a rewritten body again with the backend's coding
+func encodeContentEncoding(body []byte, encoding string) ([]byte, error) {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		writer = gzip.NewWriter(&buf)
+	case "deflate":
+		writer = zlib.NewWriter(&buf)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readableBody returns the readable form of a (possibly truncated) body for the request log:
+// decoded when its coding is known, otherwise a placeholder instead of compressed bytes
+func readableBody(body []byte, encoding string, truncated bool) string {
+	decoded, err := decodeContentEncoding(body, encoding)
+	if err == nil || (truncated && len(decoded) > 0) {
+		return string(decoded)
+	}
+	return fmt.Sprintf("[%s-encoded body: %d bytes]", encoding, len(body))
+}
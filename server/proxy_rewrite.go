@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"mockelot/models"
+)
+
+// bodyURLRewriteTypes are the content types whose bodies are searched for backend URLs
+var bodyURLRewriteTypes = []string{"text/html", "application/xhtml+xml", "json", "text/css", "javascript", "xml"}
+
+// rewritesBodyURLs reports whether a backend response body is searched for URLs to rewrite:
+// rewriting must be configured, and the body must be text of a known type
+func rewritesBodyURLs(cfg *models.ProxyConfig, header http.Header) bool {
+	if !cfg.RewriteBodyURLs && len(cfg.BodyURLRewrites) == 0 {
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, candidate := range bodyURLRewriteTypes {
+		if strings.Contains(contentType, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// bodyURLRewrites lists the URL prefixes to replace in a response body. With rewrite_body_urls
+// the backend's origin maps to the origin the client used, plus the endpoint's prefix when it is
+// stripped before forwarding. The "{origin}" placeholder in custom rules is the client's origin.
+func bodyURLRewrites(cfg *models.ProxyConfig, backendBaseURL string, endpoint *models.Endpoint, r *http.Request) []models.URLRewriteRule {
+	origin := "http://" + r.Host
+	if r.TLS != nil {
+		origin = "https://" + r.Host
+	}
+
+	rules := make([]models.URLRewriteRule, 0, len(cfg.BodyURLRewrites)+2)
+	for _, rule := range cfg.BodyURLRewrites {
+		if rule.From != "" {
+			rules = append(rules, models.URLRewriteRule{From: rule.From, To: strings.ReplaceAll(rule.To, "{origin}", origin)})
+		}
+	}
+
+	if cfg.RewriteBodyURLs {
+		if backendURL, err := url.Parse(backendBaseURL); err == nil && backendURL.Host != "" {
+			prefix := ""
+			if endpoint.TranslationMode == models.TranslationModeStrip && !strings.HasPrefix(endpoint.PathPrefix, "^") {
+				prefix = strings.TrimSuffix(endpoint.PathPrefix, "/")
+			}
+			rules = append(rules,
+				models.URLRewriteRule{From: backendURL.Scheme + "://" + backendURL.Host, To: origin + prefix},
+				// Scheme-relative links keep whichever scheme the page was loaded with
+				models.URLRewriteRule{From: "//" + backendURL.Host, To: "//" + r.Host + prefix},
+			)
+		}
+	}
+	return rules
+}
+
+// rewriteBodyURLs replaces URL prefixes in a body, including their JSON-escaped form
+// ("https:\/\/host"). A prefix ending in a host or path segment only matches where the URL does
+// not go on with more of it, so "http://api" leaves "http://api2" alone. Longer prefixes win.
+func rewriteBodyURLs(body []byte, rules []models.URLRewriteRule) ([]byte, bool) {
+	if len(rules) == 0 || len(body) == 0 {
+		return body, false
+	}
+
+	type replacement struct {
+		from, to []byte
+	}
+	var replacements []replacement
+	seen := make(map[string]bool, len(rules)*2)
+	var firstBytes [256]bool
+	add := func(from, to string) {
+		if from == "" || seen[from] {
+			return
+		}
+		seen[from] = true
+		replacements = append(replacements, replacement{from: []byte(from), to: []byte(to)})
+		firstBytes[from[0]] = true
+	}
+	for _, rule := range rules {
+		add(rule.From, rule.To)
+		add(strings.ReplaceAll(rule.From, "/", `\/`), strings.ReplaceAll(rule.To, "/", `\/`))
+	}
+	sort.SliceStable(replacements, func(i, j int) bool { return len(replacements[i].from) > len(replacements[j].from) })
+
+	var out bytes.Buffer
+	changed := false
+	last := 0
+	for i := 0; i < len(body); i++ {
+		if !firstBytes[body[i]] {
+			continue
+		}
+		for _, candidate := range replacements {
+			if !bytes.HasPrefix(body[i:], candidate.from) {
+				continue
+			}
+			end := i + len(candidate.from)
+			if end < len(body) && urlContinues(candidate.from[len(candidate.from)-1]) && urlContinues(body[end]) {
+				continue
+			}
+			if !changed {
+				out.Grow(len(body))
+				changed = true
+			}
+			out.Write(body[last:i])
+			out.Write(candidate.to)
+			last = end
+			i = end - 1
+			break
+		}
+	}
+	if !changed {
+		return body, false
+	}
+	out.Write(body[last:])
+	return out.Bytes(), true
+}
+
+// urlContinues reports whether c can be part of a host name, port or path segment
+func urlContinues(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("._~:-", c) >= 0
+}
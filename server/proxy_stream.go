@@ -0,0 +1,58 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"mockelot/models"
+)
+
+// defaultProxyLogBodyLimit is how much of a streamed response body the request log keeps
+const defaultProxyLogBodyLimit = 1024 * 1024
+
+// streamsProxyBody reports whether a proxy copies backend bodies to the client as they arrive.
+// Field mappings, body transforms and assertions need the whole body, so they turn streaming off.
+func streamsProxyBody(cfg *models.ProxyConfig) bool {
+	return len(cfg.FieldMappings) == 0 && cfg.BodyTransform == "" && cfg.AssertionScript == ""
+}
+
+// copyProxyBody streams a backend body to the client, flushing after every read so downloads
+// and Server-Sent Events reach the client without delay. Returns the bytes sent and the start
+// of the body for the request log (truncated at limit bytes).
+func copyProxyBody(w http.ResponseWriter, body io.Reader, limit int) (int64, string, bool) {
+	if limit <= 0 {
+		limit = defaultProxyLogBodyLimit
+	}
+
+	// Streams outlive the server's write timeout
+	controller := http.NewResponseController(w)
+	controller.SetWriteDeadline(time.Time{})
+
+	var sent int64
+	var captured bytes.Buffer
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := body.Read(buf)
+		if n > 0 {
+			if room := limit - captured.Len(); room > 0 {
+				captured.Write(buf[:min(n, room)])
+			}
+			written, writeErr := w.Write(buf[:n])
+			sent += int64(written)
+			if writeErr != nil {
+				proxyLog.Debug("Client went away while streaming: %v", writeErr)
+				break
+			}
+			controller.Flush()
+		}
+		if readErr != nil {
+			if readErr != io.EOF {
+				proxyLog.Warn("Backend stream ended early: %v", readErr)
+			}
+			break
+		}
+	}
+	return sent, captured.String(), sent > int64(captured.Len())
+}
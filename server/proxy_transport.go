@@ -0,0 +1,264 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"golang.org/x/net/http2"
+	"mockelot/models"
+)
+
+const (
+	defaultProxyMaxIdleConns        = 100
+	defaultProxyIdleConnTimeout     = 90 * time.Second
+	defaultProxyTLSHandshakeTimeout = 10 * time.Second
+)
+
+// tlsVersions maps tls_min_version values to crypto/tls versions
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// transportSettings are the endpoint settings a backend transport is built from
+type transportSettings struct {
+	transport models.ProxyTransportConfig
+	tls       models.ProxyTLSConfig
+}
+
+// backendTransport is the shared transport of one endpoint and the settings it was built with
+type backendTransport struct {
+	settings  transportSettings
+	transport http.RoundTripper
+}
+
+// ValidateProxyTransport checks the backend connection and TLS settings of a proxy or container endpoint
+func ValidateProxyTransport(cfg *models.ProxyConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.TLS != nil {
+		if _, err := backendTLSConfig(*cfg.TLS); err != nil {
+			return err
+		}
+	}
+	if cfg.Transport == nil {
+		return nil
+	}
+	return validateTransportSettings(cfg.Transport)
+}
+
+// validateTransportSettings checks connection pool limits, the HTTP/2 mode and the TLS version
+func validateTransportSettings(cfg *models.ProxyTransportConfig) error {
+	if cfg.MaxIdleConns < 0 || cfg.MaxConnsPerHost < 0 || cfg.IdleConnTimeoutSeconds < 0 || cfg.TLSHandshakeTimeoutSeconds < 0 {
+		return fmt.Errorf("transport limits and timeouts cannot be negative")
+	}
+	switch cfg.HTTP2 {
+	case "", models.ProxyHTTP2Auto, models.ProxyHTTP2Off, models.ProxyHTTP2H2C:
+	default:
+		return fmt.Errorf("unknown http2 mode %q (use auto, off or h2c)", cfg.HTTP2)
+	}
+	if _, ok := tlsVersions[cfg.TLSMinVersion]; cfg.TLSMinVersion != "" && !ok {
+		return fmt.Errorf("unknown tls_min_version %q (use 1.0, 1.1, 1.2 or 1.3)", cfg.TLSMinVersion)
+	}
+	return nil
+}
+
+// backendTransport returns the connection pool of an endpoint, building it on first use and
+// again when its settings change (the old pool's idle connections are closed). Certificate files
+// are read when the pool is built; if they cannot be loaded, requests fail until they can.
+func (p *ProxyHandler) backendTransport(endpointID string, cfg *models.ProxyConfig) http.RoundTripper {
+	var settings transportSettings
+	if cfg != nil && cfg.Transport != nil {
+		settings.transport = *cfg.Transport
+	}
+	if cfg != nil && cfg.TLS != nil {
+		settings.tls = *cfg.TLS
+	}
+
+	p.transportMutex.Lock()
+	defer p.transportMutex.Unlock()
+	if existing, ok := p.transports[endpointID]; ok {
+		if existing.settings == settings {
+			return existing.transport
+		}
+		closeIdleConnections(existing.transport)
+	}
+	transport, err := newBackendTransport(settings)
+	if err != nil {
+		proxyLog.Error("Backend transport for endpoint %s: %v", endpointID, err)
+		delete(p.transports, endpointID)
+		return failedTransport{err: err}
+	}
+	p.transports[endpointID] = &backendTransport{settings: settings, transport: transport}
+	return transport
+}
+
+// SyncTransports closes the connection pools of endpoints that no longer exist, and forgets the
+// health of their backends and their circuit breakers
+func (p *ProxyHandler) SyncTransports(endpoints []models.Endpoint) {
+	ids := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		ids[endpoint.ID] = true
+	}
+
+	p.transportMutex.Lock()
+	defer p.transportMutex.Unlock()
+	for id, existing := range p.transports {
+		if !ids[id] {
+			closeIdleConnections(existing.transport)
+			delete(p.transports, id)
+		}
+	}
+
+	p.poolMutex.Lock()
+	defer p.poolMutex.Unlock()
+	for id := range p.pools {
+		if !ids[id] {
+			delete(p.pools, id)
+		}
+	}
+
+	p.circuitMutex.Lock()
+	defer p.circuitMutex.Unlock()
+	for id := range p.circuits {
+		if !ids[id] {
+			delete(p.circuits, id)
+		}
+	}
+}
+
+// newBackendTransport builds a pooled transport from an endpoint's settings
+func newBackendTransport(all transportSettings) (http.RoundTripper, error) {
+	settings := all.transport
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	tlsConfig, err := backendTLSConfig(all.tls)
+	if err != nil {
+		return nil, err
+	}
+	if version, ok := tlsVersions[settings.TLSMinVersion]; ok {
+		tlsConfig.MinVersion = version
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     settings.HTTP2 != models.ProxyHTTP2Off,
+		MaxIdleConnsPerHost:   defaultProxyMaxIdleConns,
+		MaxConnsPerHost:       settings.MaxConnsPerHost,
+		IdleConnTimeout:       defaultProxyIdleConnTimeout,
+		TLSHandshakeTimeout:   defaultProxyTLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+		DisableKeepAlives:     settings.DisableKeepAlives,
+	}
+	if settings.MaxIdleConns > 0 {
+		transport.MaxIdleConnsPerHost = settings.MaxIdleConns
+	}
+	if settings.IdleConnTimeoutSeconds > 0 {
+		transport.IdleConnTimeout = time.Duration(settings.IdleConnTimeoutSeconds) * time.Second
+	}
+	if settings.TLSHandshakeTimeoutSeconds > 0 {
+		transport.TLSHandshakeTimeout = time.Duration(settings.TLSHandshakeTimeoutSeconds) * time.Second
+	}
+	if settings.HTTP2 == models.ProxyHTTP2Off {
+		// A non-nil empty map turns off HTTP/2 negotiation
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+	if settings.HTTP2 != models.ProxyHTTP2H2C {
+		return transport, nil
+	}
+
+	// h2c speaks HTTP/2 over plain TCP to http:// backends; https:// backends still use TLS
+	h2c := &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, addr)
+		},
+		IdleConnTimeout: transport.IdleConnTimeout,
+	}
+	return &h2cRoundTripper{h2c: h2c, tls: transport}, nil
+}
+
+// backendTLSConfig builds the client TLS settings for HTTPS backends: extra trusted CAs, a client
+// certificate, and the expected server name
+func backendTLSConfig(cfg models.ProxyTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		ServerName:         cfg.ServerName,
+	}
+
+	if cfg.CACertPath != "" {
+		caPEM, err := os.ReadFile(cfg.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backend CA: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in backend CA %s", cfg.CACertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCertPath != "" || cfg.ClientKeyPath != "" {
+		if cfg.ClientCertPath == "" || cfg.ClientKeyPath == "" {
+			return nil, fmt.Errorf("client_cert_path and client_key_path must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertPath, cfg.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// failedTransport fails every request with the error that kept a backend transport from being built
+type failedTransport struct {
+	err error
+}
+
+func (t failedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
+
+// h2cRoundTripper sends http:// requests over cleartext HTTP/2 and the others over the regular transport
+type h2cRoundTripper struct {
+	h2c *http2.Transport
+	tls *http.Transport
+}
+
+func (t *h2cRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme == "http" {
+		return t.h2c.RoundTrip(req)
+	}
+	return t.tls.RoundTrip(req)
+}
+
+func (t *h2cRoundTripper) CloseIdleConnections() {
+	t.h2c.CloseIdleConnections()
+	t.tls.CloseIdleConnections()
+}
+
+// closeIdleConnections closes the idle connections of a transport being replaced; connections in
+// use finish their requests first
+func closeIdleConnections(transport http.RoundTripper) {
+	if closer, ok := transport.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
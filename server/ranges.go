@@ -0,0 +1,186 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"mockelot/models"
+)
+
+const maxRanges = 32 // More ranges than this in one request are treated as unsatisfiable
+
+// byteRange is an inclusive byte range resolved against a body length
+type byteRange struct {
+	start, end int64
+}
+
+// applyRangeMode applies a response's Range handling to a generated 200 response.
+// The returned headers map is a copy; the input map is never modified.
+func applyRangeMode(mode string, r *http.Request, status int, headers map[string]string, body string) (int, map[string]string, string) {
+	if mode == "" || mode == models.RangeModeIgnore || status != http.StatusOK {
+		return status, headers, body
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return status, headers, body
+	}
+
+	out := make(map[string]string, len(headers)+2)
+	for name, value := range headers {
+		out[name] = value
+	}
+	size := int64(len(body))
+	rangeHeader := r.Header.Get("Range")
+
+	switch mode {
+	case models.RangeModeReject:
+		setHeader(out, "Accept-Ranges", "none")
+		if rangeHeader == "" {
+			return status, out, body
+		}
+		setHeader(out, "Content-Range", fmt.Sprintf("bytes */%d", size))
+		return http.StatusRequestedRangeNotSatisfiable, out, ""
+
+	case models.RangeModeHonor:
+		setHeader(out, "Accept-Ranges", "bytes")
+		if rangeHeader == "" || !ifRangeMatches(r.Header.Get("If-Range"), out) {
+			return status, out, body
+		}
+
+		ranges, ok := parseRangeHeader(rangeHeader, size)
+		if !ok {
+			setHeader(out, "Content-Range", fmt.Sprintf("bytes */%d", size))
+			return http.StatusRequestedRangeNotSatisfiable, out, ""
+		}
+
+		if len(ranges) == 1 {
+			rg := ranges[0]
+			setHeader(out, "Content-Range", fmt.Sprintf("bytes %d-%d/%d", rg.start, rg.end, size))
+			return http.StatusPartialContent, out, body[rg.start : rg.end+1]
+		}
+
+		// Multiple ranges: multipart/byteranges with one part per range
+		contentType := getHeader(out, "Content-Type")
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		for _, rg := range ranges {
+			partHeader := textproto.MIMEHeader{}
+			if contentType != "" {
+				partHeader.Set("Content-Type", contentType)
+			}
+			partHeader.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", rg.start, rg.end, size))
+			part, err := mw.CreatePart(partHeader)
+			if err != nil {
+				return status, out, body
+			}
+			part.Write([]byte(body[rg.start : rg.end+1]))
+		}
+		mw.Close()
+		setHeader(out, "Content-Type", "multipart/byteranges; boundary="+mw.Boundary())
+		return http.StatusPartialContent, out, buf.String()
+	}
+
+	return status, headers, body
+}
+
+// parseRangeHeader parses a "bytes=" Range header against a body size.
+// Unsatisfiable ranges are dropped; ok is false when none remain or the header is malformed.
+func parseRangeHeader(header string, size int64) ([]byteRange, bool) {
+	spec, found := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !found {
+		return nil, false
+	}
+
+	var ranges []byteRange
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		startStr, endStr, found := strings.Cut(part, "-")
+		if !found {
+			return nil, false
+		}
+		startStr, endStr = strings.TrimSpace(startStr), strings.TrimSpace(endStr)
+
+		var rg byteRange
+		if startStr == "" {
+			// Suffix range: last N bytes
+			n, err := strconv.ParseInt(endStr, 10, 64)
+			if err != nil || n < 0 {
+				return nil, false
+			}
+			if n == 0 || size == 0 {
+				continue
+			}
+			if n > size {
+				n = size
+			}
+			rg = byteRange{start: size - n, end: size - 1}
+		} else {
+			start, err := strconv.ParseInt(startStr, 10, 64)
+			if err != nil || start < 0 {
+				return nil, false
+			}
+			if start >= size {
+				continue
+			}
+			end := size - 1
+			if endStr != "" {
+				end, err = strconv.ParseInt(endStr, 10, 64)
+				if err != nil || end < start {
+					return nil, false
+				}
+				if end >= size {
+					end = size - 1
+				}
+			}
+			rg = byteRange{start: start, end: end}
+		}
+		ranges = append(ranges, rg)
+	}
+
+	if len(ranges) == 0 || len(ranges) > maxRanges {
+		return nil, false
+	}
+	return ranges, true
+}
+
+// ifRangeMatches reports whether an If-Range precondition allows a partial response.
+// An absent If-Range always matches; otherwise it must equal the response's ETag or Last-Modified.
+func ifRangeMatches(ifRange string, headers map[string]string) bool {
+	if ifRange == "" {
+		return true
+	}
+	if etag := getHeader(headers, "ETag"); etag != "" && ifRange == etag {
+		return true
+	}
+	if lastModified := getHeader(headers, "Last-Modified"); lastModified != "" && ifRange == lastModified {
+		return true
+	}
+	return false
+}
+
+// getHeader looks up a configured response header case-insensitively
+func getHeader(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// setHeader sets a response header, replacing any existing key that differs only in case
+func setHeader(headers map[string]string, name, value string) {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			delete(headers, key)
+		}
+	}
+	headers[name] = value
+}
@@ -0,0 +1,19 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// advertisesRetryAfter reports whether a generated status should carry Retry-After
+func advertisesRetryAfter(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// retryAfterSeconds formats a wait as a Retry-After value: whole seconds, rounded up (at least 1)
+// so a client that waits as told is not refused again
+func retryAfterSeconds(wait time.Duration) string {
+	return strconv.Itoa(max(1, int(math.Ceil(wait.Seconds()))))
+}
@@ -0,0 +1,156 @@
+package runtime
+
+import (
+	"archive/tar"
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImageBuildConfig contains image build parameters
+type ImageBuildConfig struct {
+	ContextDir string            // Build context directory on this machine
+	Dockerfile string            // Relative to ContextDir (e.g., "Dockerfile")
+	Tag        string            // Name of the built image (e.g., "mockelot-myendpoint:latest")
+	Args       map[string]string // Build arguments (ARG values)
+	Target     string            // Multi-stage build target, empty for the last stage
+	NoCache    bool
+}
+
+// buildArgs converts build arguments to the form the Docker API expects
+func (c *ImageBuildConfig) buildArgs() map[string]*string {
+	args := make(map[string]*string, len(c.Args))
+	for name, value := range c.Args {
+		args[name] = &value
+	}
+	return args
+}
+
+// tarBuildContext streams the build context directory as a tar archive, leaving out the paths
+// matched by its .dockerignore (the Dockerfile and .dockerignore are always sent)
+func tarBuildContext(contextDir, dockerfile string) (io.ReadCloser, error) {
+	if _, err := os.Stat(filepath.Join(contextDir, dockerfile)); err != nil {
+		return nil, err
+	}
+	excludes := readDockerignore(contextDir)
+
+	reader, writer := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(writer)
+		err := filepath.Walk(contextDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(contextDir, path)
+			if err != nil || rel == "." {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if rel != filepath.ToSlash(dockerfile) && rel != ".dockerignore" && ignored(rel, excludes) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			link := ""
+			if info.Mode()&os.ModeSymlink != 0 {
+				if link, err = os.Readlink(path); err != nil {
+					return err
+				}
+			}
+			header, err := tar.FileInfoHeader(info, link)
+			if err != nil {
+				return err
+			}
+			header.Name = rel
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			_, err = io.Copy(tw, file)
+			return err
+		})
+		if err == nil {
+			err = tw.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+	return reader, nil
+}
+
+// readDockerignore reads the exclude patterns of a build context (none without a .dockerignore)
+func readDockerignore(contextDir string) []string {
+	file, err := os.Open(filepath.Join(contextDir, ".dockerignore"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, strings.TrimPrefix(filepath.ToSlash(filepath.Clean(line)), "/"))
+	}
+	return patterns
+}
+
+// ignored reports whether a context path matches the .dockerignore patterns. Later patterns win,
+// and "!" patterns include paths again. A pattern also matches everything below a matched directory.
+func ignored(path string, patterns []string) bool {
+	excluded := false
+	for _, pattern := range patterns {
+		negate := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+		if patternMatches(pattern, path) {
+			excluded = !negate
+		}
+	}
+	return excluded
+}
+
+// patternMatches matches a path or one of its parent directories against a .dockerignore pattern
+// ("**" matches any number of directories)
+func patternMatches(pattern, path string) bool {
+	for candidate := path; candidate != "."; candidate = filepath.ToSlash(filepath.Dir(candidate)) {
+		if globMatch(strings.Split(pattern, "/"), strings.Split(candidate, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch matches path segments against pattern segments
+func globMatch(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if globMatch(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := filepath.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return globMatch(pattern[1:], segments[1:])
+}
@@ -2,12 +2,15 @@ package runtime
 
 import (
 	"fmt"
-	"log"
 	"os"
 	goruntime "runtime"
 	"strings"
+
+	"mockelot/logger"
 )
 
+var runtimeLog = logger.For(logger.SubsystemContainer)
+
 // DetectRuntime detects and initializes the best available container runtime
 func DetectRuntime() (ContainerRuntime, error) {
 	// Environment variable override: CONTAINER_RUNTIME=docker|podman
@@ -15,16 +18,26 @@ func DetectRuntime() (ContainerRuntime, error) {
 		return initializeSpecificRuntime(envRuntime)
 	}
 
+	// DOCKER_HOST pointing to a remote daemon (tcp:// or ssh://)
+	if remote := remoteDaemonFromEnv(); remote != nil {
+		remoteRuntime, err := ConnectRuntime(remote)
+		if err != nil {
+			return nil, err
+		}
+		runtimeLog.Info("Container runtime: remote daemon %s", remote.Host)
+		return remoteRuntime, nil
+	}
+
 	// Auto-detect: try Docker first, fallback to Podman
 	dockerRuntime := NewDockerRuntime()
 	if err := dockerRuntime.Initialize(); err == nil {
-		log.Printf("Container runtime: Docker detected")
+		runtimeLog.Info("Container runtime: Docker detected")
 		return dockerRuntime, nil
 	}
 
 	podmanRuntime := NewPodmanRuntime()
 	if err := podmanRuntime.Initialize(); err == nil {
-		log.Printf("Container runtime: Podman detected")
+		runtimeLog.Info("Container runtime: Podman detected")
 		return podmanRuntime, nil
 	}
 
@@ -6,15 +6,18 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 )
 
 type DockerRuntime struct {
-	client *client.Client
+	client      *client.Client
+	publishHost string // Where published ports are reachable; empty for this machine
 }
 
 func NewDockerRuntime() *DockerRuntime {
@@ -43,6 +46,13 @@ func (d *DockerRuntime) Name() string {
 	return "docker"
 }
 
+func (d *DockerRuntime) PublishHost() string {
+	if d.publishHost == "" {
+		return localPublishHost
+	}
+	return d.publishHost
+}
+
 func (d *DockerRuntime) IsAvailable() bool {
 	if d.client == nil {
 		return false
@@ -52,8 +62,29 @@ func (d *DockerRuntime) IsAvailable() bool {
 	return err == nil
 }
 
-func (d *DockerRuntime) PullImage(ctx context.Context, imageName string) (io.ReadCloser, error) {
-	return d.client.ImagePull(ctx, imageName, image.PullOptions{})
+func (d *DockerRuntime) PullImage(ctx context.Context, imageName string, registryAuth string) (io.ReadCloser, error) {
+	return d.client.ImagePull(ctx, imageName, image.PullOptions{RegistryAuth: registryAuth})
+}
+
+func (d *DockerRuntime) BuildImage(ctx context.Context, config *ImageBuildConfig) (io.ReadCloser, error) {
+	buildContext, err := tarBuildContext(config.ContextDir, config.Dockerfile)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.ImageBuild(ctx, buildContext, types.ImageBuildOptions{
+		Tags:        []string{config.Tag},
+		Dockerfile:  config.Dockerfile,
+		BuildArgs:   config.buildArgs(),
+		Target:      config.Target,
+		NoCache:     config.NoCache,
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		buildContext.Close()
+		return nil, err
+	}
+	return resp.Body, nil
 }
 
 func (d *DockerRuntime) CreateContainer(ctx context.Context, config *ContainerCreateConfig) (string, error) {
@@ -81,14 +112,33 @@ func (d *DockerRuntime) CreateContainer(ctx context.Context, config *ContainerCr
 		Image:        config.Image,
 		Env:          config.Env,
 		ExposedPorts: portSet,
+		Cmd:          config.Cmd,
 	}
 
+	restartPolicy, ok := ParseRestartPolicy(config.RestartPolicy)
+	if !ok {
+		return "", fmt.Errorf("invalid restart policy %q", config.RestartPolicy)
+	}
 	hostConfig := &container.HostConfig{
-		Mounts:       mounts,
-		PortBindings: portBindings,
+		Mounts:        mounts,
+		PortBindings:  portBindings,
+		RestartPolicy: restartPolicy,
+		Resources:     config.Resources.hostResources(),
+	}
+
+	applyHostAccess(config, hostConfig, d.client.DaemonHost())
+
+	var networkingConfig *network.NetworkingConfig
+	if config.Network != "" && !config.HostNetwork {
+		hostConfig.NetworkMode = container.NetworkMode(config.Network)
+		networkingConfig = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				config.Network: {Aliases: config.Aliases},
+			},
+		}
 	}
 
-	resp, err := d.client.ContainerCreate(ctx, containerConfig, hostConfig, nil, nil, config.Name)
+	resp, err := d.client.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, nil, config.Name)
 	if err != nil {
 		return "", err
 	}
@@ -120,6 +170,9 @@ func (d *DockerRuntime) InspectContainer(ctx context.Context, containerID string
 		Status:  inspect.State.Status,
 		Ports:   make(map[string]string),
 	}
+	if inspect.State.Health != nil {
+		info.Health = inspect.State.Health.Status
+	}
 
 	// Extract port mappings
 	for portKey, bindings := range inspect.NetworkSettings.Ports {
@@ -235,3 +288,27 @@ func (d *DockerRuntime) GetContainerLogs(ctx context.Context, containerID string
 
 	return string(logBytes), nil
 }
+
+func (d *DockerRuntime) FollowContainerLogs(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	logs, err := d.client.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return demuxLogs(logs), nil
+}
+
+func (d *DockerRuntime) CreateNetwork(ctx context.Context, name string) error {
+	if _, err := d.client.NetworkInspect(ctx, name, network.InspectOptions{}); err == nil {
+		return nil
+	}
+	_, err := d.client.NetworkCreate(ctx, name, network.CreateOptions{Driver: "bridge"})
+	return err
+}
+
+func (d *DockerRuntime) RemoveNetwork(ctx context.Context, name string) error {
+	return d.client.NetworkRemove(ctx, name)
+}
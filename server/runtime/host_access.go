@@ -0,0 +1,33 @@
+package runtime
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+)
+
+// dockerSocketPath is where containers expect the Docker API socket, and where the daemon listens
+// on its host when the client reaches it over TCP or a named pipe (Docker Desktop)
+const dockerSocketPath = "/var/run/docker.sock"
+
+// applyHostAccess gives a container the host's network stack and/or the runtime's API socket.
+// daemonHost is the client's daemon address (e.g., "unix:///run/podman/podman.sock").
+func applyHostAccess(config *ContainerCreateConfig, hostConfig *container.HostConfig, daemonHost string) {
+	if config.HostNetwork {
+		// Ports are reachable on the host directly, there is nothing to publish
+		hostConfig.NetworkMode = container.NetworkMode("host")
+		hostConfig.PortBindings = nil
+	}
+	if config.DockerSocket {
+		source := dockerSocketPath
+		if strings.HasPrefix(daemonHost, "unix://") {
+			source = strings.TrimPrefix(daemonHost, "unix://")
+		}
+		hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+			Type:   mount.TypeBind,
+			Source: source,
+			Target: dockerSocketPath,
+		})
+	}
+}
@@ -16,8 +16,16 @@ type ContainerRuntime interface {
 	// IsAvailable checks if runtime is installed and accessible
 	IsAvailable() bool
 
-	// PullImage pulls a container image
-	PullImage(ctx context.Context, imageName string) (io.ReadCloser, error)
+	// PublishHost returns the address published container ports are reachable on from this machine
+	// (127.0.0.1 for a local daemon, the remote host's name otherwise)
+	PublishHost() string
+
+	// PullImage pulls a container image; registryAuth is an encoded login from EncodeRegistryAuth
+	// ("" pulls anonymously)
+	PullImage(ctx context.Context, imageName string, registryAuth string) (io.ReadCloser, error)
+
+	// BuildImage builds an image from a local Dockerfile; the returned stream carries the build output
+	BuildImage(ctx context.Context, config *ImageBuildConfig) (io.ReadCloser, error)
 
 	// CreateContainer creates a container with given config
 	CreateContainer(ctx context.Context, config *ContainerCreateConfig) (containerID string, err error)
@@ -45,16 +53,33 @@ type ContainerRuntime interface {
 
 	// GetContainerLogs gets container stdout/stderr logs
 	GetContainerLogs(ctx context.Context, containerID string, tail int) (string, error)
+
+	// FollowContainerLogs streams the container's stdout/stderr as plain text from its start until
+	// the container exits or ctx is cancelled
+	FollowContainerLogs(ctx context.Context, containerID string) (io.ReadCloser, error)
+
+	// CreateNetwork creates a bridge network (an existing network with the same name is reused)
+	CreateNetwork(ctx context.Context, name string) error
+
+	// RemoveNetwork removes a network
+	RemoveNetwork(ctx context.Context, name string) error
 }
 
 // ContainerCreateConfig contains container creation parameters
 type ContainerCreateConfig struct {
-	Name         string            // Container name (e.g., "mockelot-myendpoint")
-	Image        string
-	Env          []string
-	ExposedPorts []string          // e.g., "8080/tcp"
-	PortBindings map[string]string // containerPort -> hostPort (e.g., "8080/tcp" -> "0")
-	Mounts       []Mount
+	Name          string // Container name (e.g., "mockelot-myendpoint")
+	Image         string
+	Env           []string
+	ExposedPorts  []string          // e.g., "8080/tcp"
+	PortBindings  map[string]string // containerPort -> hostPort (e.g., "8080/tcp" -> "0")
+	Mounts        []Mount
+	Cmd           []string // Overrides the image command when set
+	Network       string   // Network to attach to instead of the default bridge
+	Aliases       []string // Host names of the container on Network
+	Resources     ResourceLimits
+	RestartPolicy string // "no", "always", "unless-stopped", "on-failure[:max-retries]" (empty is "no")
+	HostNetwork   bool   // Share the host's network stack (PortBindings and Network are ignored)
+	DockerSocket  bool   // Mount the runtime's API socket at /var/run/docker.sock
 }
 
 // Mount represents a volume mount
@@ -69,6 +94,7 @@ type ContainerInfo struct {
 	ID      string
 	Running bool
 	Status  string
+	Health  string            // Status of the image's HEALTHCHECK: "starting", "healthy", "unhealthy" ("" without one)
 	Ports   map[string]string // containerPort -> hostPort
 }
 
@@ -0,0 +1,30 @@
+package runtime
+
+import (
+	"io"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// demuxLogs converts a multiplexed log stream (8-byte frame headers before every stdout/stderr
+// chunk, as sent for containers without a TTY) to plain text
+func demuxLogs(logs io.ReadCloser) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(writer, writer, logs)
+		logs.Close()
+		writer.CloseWithError(err)
+	}()
+	return &demuxedLogs{PipeReader: reader, logs: logs}
+}
+
+// demuxedLogs closes the underlying log stream along with the pipe, so the copy goroutine ends
+type demuxedLogs struct {
+	*io.PipeReader
+	logs io.ReadCloser
+}
+
+func (d *demuxedLogs) Close() error {
+	d.PipeReader.Close()
+	return d.logs.Close()
+}
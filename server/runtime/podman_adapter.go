@@ -8,15 +8,18 @@ import (
 	"os"
 	"strings"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 )
 
 type PodmanRuntime struct {
-	client *client.Client
+	client      *client.Client
+	publishHost string // Where published ports are reachable; empty for this machine
 }
 
 func NewPodmanRuntime() *PodmanRuntime {
@@ -51,6 +54,13 @@ func (p *PodmanRuntime) Name() string {
 	return "podman"
 }
 
+func (p *PodmanRuntime) PublishHost() string {
+	if p.publishHost == "" {
+		return localPublishHost
+	}
+	return p.publishHost
+}
+
 func (p *PodmanRuntime) IsAvailable() bool {
 	if p.client == nil {
 		return false
@@ -60,8 +70,29 @@ func (p *PodmanRuntime) IsAvailable() bool {
 	return err == nil
 }
 
-func (p *PodmanRuntime) PullImage(ctx context.Context, imageName string) (io.ReadCloser, error) {
-	return p.client.ImagePull(ctx, imageName, image.PullOptions{})
+func (p *PodmanRuntime) PullImage(ctx context.Context, imageName string, registryAuth string) (io.ReadCloser, error) {
+	return p.client.ImagePull(ctx, imageName, image.PullOptions{RegistryAuth: registryAuth})
+}
+
+func (p *PodmanRuntime) BuildImage(ctx context.Context, config *ImageBuildConfig) (io.ReadCloser, error) {
+	buildContext, err := tarBuildContext(config.ContextDir, config.Dockerfile)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.ImageBuild(ctx, buildContext, types.ImageBuildOptions{
+		Tags:        []string{config.Tag},
+		Dockerfile:  config.Dockerfile,
+		BuildArgs:   config.buildArgs(),
+		Target:      config.Target,
+		NoCache:     config.NoCache,
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		buildContext.Close()
+		return nil, err
+	}
+	return resp.Body, nil
 }
 
 func (p *PodmanRuntime) CreateContainer(ctx context.Context, config *ContainerCreateConfig) (string, error) {
@@ -89,14 +120,33 @@ func (p *PodmanRuntime) CreateContainer(ctx context.Context, config *ContainerCr
 		Image:        config.Image,
 		Env:          config.Env,
 		ExposedPorts: portSet,
+		Cmd:          config.Cmd,
 	}
 
+	restartPolicy, ok := ParseRestartPolicy(config.RestartPolicy)
+	if !ok {
+		return "", fmt.Errorf("invalid restart policy %q", config.RestartPolicy)
+	}
 	hostConfig := &container.HostConfig{
-		Mounts:       mounts,
-		PortBindings: portBindings,
+		Mounts:        mounts,
+		PortBindings:  portBindings,
+		RestartPolicy: restartPolicy,
+		Resources:     config.Resources.hostResources(),
+	}
+
+	applyHostAccess(config, hostConfig, p.client.DaemonHost())
+
+	var networkingConfig *network.NetworkingConfig
+	if config.Network != "" && !config.HostNetwork {
+		hostConfig.NetworkMode = container.NetworkMode(config.Network)
+		networkingConfig = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				config.Network: {Aliases: config.Aliases},
+			},
+		}
 	}
 
-	resp, err := p.client.ContainerCreate(ctx, containerConfig, hostConfig, nil, nil, config.Name)
+	resp, err := p.client.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, nil, config.Name)
 	if err != nil {
 		return "", err
 	}
@@ -128,6 +178,9 @@ func (p *PodmanRuntime) InspectContainer(ctx context.Context, containerID string
 		Status:  inspect.State.Status,
 		Ports:   make(map[string]string),
 	}
+	if inspect.State.Health != nil {
+		info.Health = inspect.State.Health.Status
+	}
 
 	// Extract port mappings
 	for portKey, bindings := range inspect.NetworkSettings.Ports {
@@ -244,6 +297,30 @@ func (p *PodmanRuntime) GetContainerLogs(ctx context.Context, containerID string
 	return string(logBytes), nil
 }
 
+func (p *PodmanRuntime) FollowContainerLogs(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	logs, err := p.client.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return demuxLogs(logs), nil
+}
+
+func (p *PodmanRuntime) CreateNetwork(ctx context.Context, name string) error {
+	if _, err := p.client.NetworkInspect(ctx, name, network.InspectOptions{}); err == nil {
+		return nil
+	}
+	_, err := p.client.NetworkCreate(ctx, name, network.CreateOptions{Driver: "bridge"})
+	return err
+}
+
+func (p *PodmanRuntime) RemoveNetwork(ctx context.Context, name string) error {
+	return p.client.NetworkRemove(ctx, name)
+}
+
 // getPodmanSocketPath returns the Podman socket path based on OS
 func getPodmanSocketPath() string {
 	// Linux: unix:///run/user/{UID}/podman/podman.sock
@@ -0,0 +1,175 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types/registry"
+)
+
+// dockerHubHost is the registry of images without a registry host (e.g., "nginx:latest")
+const dockerHubHost = "docker.io"
+
+// dockerHubConfigKey is the key Docker Hub logins are stored under in config.json
+const dockerHubConfigKey = "https://index.docker.io/v1/"
+
+// RegistryLogin is a username/password (or identity token) for one registry
+type RegistryLogin struct {
+	Username      string
+	Password      string
+	IdentityToken string // OAuth refresh token stored by some credential helpers
+}
+
+// EncodeRegistryAuth encodes a login for the RegistryAuth option of image pulls ("" for nil,
+// which pulls anonymously)
+func EncodeRegistryAuth(login *RegistryLogin, host string) (string, error) {
+	if login == nil {
+		return "", nil
+	}
+	serverAddress := host
+	if host == dockerHubHost {
+		serverAddress = dockerHubConfigKey
+	}
+	return registry.EncodeAuthConfig(registry.AuthConfig{
+		Username:      login.Username,
+		Password:      login.Password,
+		IdentityToken: login.IdentityToken,
+		ServerAddress: serverAddress,
+	})
+}
+
+// RegistryHost returns the registry host of an image reference: the first path component when it
+// looks like a host (has a "." or ":", or is "localhost"), otherwise Docker Hub
+func RegistryHost(imageName string) string {
+	first, _, found := strings.Cut(imageName, "/")
+	if !found {
+		return dockerHubHost
+	}
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		if first == "index.docker.io" || first == "registry-1.docker.io" {
+			return dockerHubHost
+		}
+		return strings.ToLower(first)
+	}
+	return dockerHubHost
+}
+
+// SameRegistry reports whether a configured registry ("ghcr.io", "https://ghcr.io/v2/") names host
+func SameRegistry(configured, host string) bool {
+	return NormalizeRegistry(configured) == NormalizeRegistry(host)
+}
+
+// NormalizeRegistry strips the scheme and path of a registry address and maps Docker Hub aliases
+// to docker.io (e.g., "https://index.docker.io/v1/" -> "docker.io")
+func NormalizeRegistry(address string) string {
+	address = strings.ToLower(strings.TrimSpace(address))
+	address = strings.TrimPrefix(strings.TrimPrefix(address, "https://"), "http://")
+	address, _, _ = strings.Cut(address, "/")
+	switch address {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return dockerHubHost
+	}
+	return address
+}
+
+// dockerConfigFile is the subset of ~/.docker/config.json holding registry logins
+type dockerConfigFile struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"` // base64 "username:password"
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredHelpers map[string]string `json:"credHelpers"` // Registry host -> helper suffix (docker-credential-<suffix>)
+	CredsStore  string            `json:"credsStore"`  // Default helper for all registries
+}
+
+// DockerConfigLogin looks up the login for a registry the way the docker CLI does: the registry's
+// credential helper, then the auths of config.json, then the default credential store
+// ($DOCKER_CONFIG/config.json, or ~/.docker/config.json). Returns nil without a login.
+func DockerConfigLogin(host string) (*RegistryLogin, error) {
+	configDir := os.Getenv("DOCKER_CONFIG")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		configDir = filepath.Join(home, ".docker")
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+	if err != nil {
+		return nil, nil // No docker login on this machine
+	}
+	var config dockerConfigFile
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config: %w", err)
+	}
+
+	host = NormalizeRegistry(host)
+	for configured, helper := range config.CredHelpers {
+		if NormalizeRegistry(configured) == host {
+			return credentialHelperLogin(helper, host)
+		}
+	}
+
+	for configured, auth := range config.Auths {
+		if NormalizeRegistry(configured) != host {
+			continue
+		}
+		if auth.IdentityToken != "" {
+			return &RegistryLogin{IdentityToken: auth.IdentityToken}, nil
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth for %s in docker config: %w", configured, err)
+			}
+			username, password, _ := strings.Cut(string(decoded), ":")
+			return &RegistryLogin{Username: username, Password: password}, nil
+		}
+	}
+
+	if config.CredsStore != "" {
+		return credentialHelperLogin(config.CredsStore, host)
+	}
+	return nil, nil
+}
+
+// credentialHelperLogin asks a docker credential helper (docker-credential-<helper> get) for the
+// login of a registry. Returns nil when the helper has none.
+func credentialHelperLogin(helper, host string) (*RegistryLogin, error) {
+	serverURL := host
+	if host == dockerHubHost {
+		serverURL = dockerHubConfigKey
+	}
+
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(serverURL)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(stdout.String() + stderr.String())
+		if strings.Contains(output, "credentials not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("credential helper docker-credential-%s failed: %v %s", helper, err, output)
+	}
+
+	var creds struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return nil, fmt.Errorf("invalid output from docker-credential-%s: %w", helper, err)
+	}
+	// Helpers return "<token>" as the username of identity tokens
+	if creds.Username == "<token>" {
+		return &RegistryLogin{IdentityToken: creds.Secret}, nil
+	}
+	return &RegistryLogin{Username: creds.Username, Password: creds.Secret}, nil
+}
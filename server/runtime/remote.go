@@ -0,0 +1,121 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/client"
+)
+
+// localPublishHost is where ports published by a daemon on this machine are reachable
+const localPublishHost = "127.0.0.1"
+
+// DaemonConfig locates a Docker/Podman daemon, on this machine or a remote host
+type DaemonConfig struct {
+	Host        string // "unix:///var/run/docker.sock", "tcp://build-box:2376" or "ssh://user@build-box"
+	Runtime     string // "docker" (default) or "podman"
+	TLSCACert   string // CA certificate file of a tcp:// daemon with TLS
+	TLSCert     string // Client certificate file
+	TLSKey      string // Client key file
+	PublishHost string // Address published container ports are reached on (default: the daemon's host name)
+}
+
+// ValidateDaemonHost checks the scheme of a daemon address
+func ValidateDaemonHost(host string) error {
+	u, err := url.Parse(host)
+	if err != nil {
+		return fmt.Errorf("invalid daemon address %q: %w", host, err)
+	}
+	switch u.Scheme {
+	case "unix", "npipe":
+		return nil
+	case "tcp", "ssh":
+		if u.Hostname() == "" {
+			return fmt.Errorf("daemon address %q has no host name", host)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported daemon address %q (use unix://, npipe://, tcp:// or ssh://)", host)
+}
+
+// NewDaemonClient creates an API client for the daemon of config. ssh:// daemons are reached
+// through "docker system dial-stdio" (or "podman system dial-stdio") on the remote host.
+func NewDaemonClient(config *DaemonConfig) (*client.Client, error) {
+	if err := ValidateDaemonHost(config.Host); err != nil {
+		return nil, err
+	}
+	opts := []client.Opt{client.WithAPIVersionNegotiation()}
+
+	u, _ := url.Parse(config.Host)
+	if u.Scheme == "ssh" {
+		// The host is only used for the Host header; every connection runs over ssh
+		opts = append(opts, client.WithHost("http://"+u.Hostname()), client.WithDialContext(sshDialer(u, config.Runtime)))
+	} else {
+		opts = append(opts, client.WithHost(config.Host))
+	}
+	if config.TLSCert != "" || config.TLSCACert != "" {
+		opts = append(opts, client.WithTLSClientConfig(config.TLSCACert, config.TLSCert, config.TLSKey))
+	}
+	return client.NewClientWithOpts(opts...)
+}
+
+// ConnectRuntime connects to the daemon of config and checks that it responds
+func ConnectRuntime(config *DaemonConfig) (ContainerRuntime, error) {
+	daemonClient, err := NewDaemonClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	if _, err := daemonClient.Ping(ctx); err != nil {
+		daemonClient.Close()
+		return nil, fmt.Errorf("container daemon at %s not responding: %w", config.Host, err)
+	}
+
+	publishHost := config.PublishHost
+	if publishHost == "" {
+		publishHost = daemonPublishHost(config.Host)
+	}
+	if strings.EqualFold(config.Runtime, "podman") {
+		return &PodmanRuntime{client: daemonClient, publishHost: publishHost}, nil
+	}
+	return &DockerRuntime{client: daemonClient, publishHost: publishHost}, nil
+}
+
+// daemonPublishHost is where a daemon publishes container ports: its host name for tcp:// and
+// ssh:// daemons, otherwise this machine
+func daemonPublishHost(host string) string {
+	u, err := url.Parse(host)
+	if err != nil || (u.Scheme != "tcp" && u.Scheme != "ssh") {
+		return localPublishHost
+	}
+	return u.Hostname()
+}
+
+// remoteDaemonFromEnv returns the daemon of DOCKER_HOST when it is not a local socket, with the
+// TLS certificates of DOCKER_CERT_PATH when DOCKER_TLS_VERIFY is set (nil otherwise)
+func remoteDaemonFromEnv() *DaemonConfig {
+	host := os.Getenv("DOCKER_HOST")
+	if !strings.HasPrefix(host, "tcp://") && !strings.HasPrefix(host, "ssh://") {
+		return nil
+	}
+	config := &DaemonConfig{Host: host}
+	if os.Getenv("DOCKER_TLS_VERIFY") != "" {
+		certPath := os.Getenv("DOCKER_CERT_PATH")
+		if certPath == "" {
+			if home, err := os.UserHomeDir(); err == nil {
+				certPath = filepath.Join(home, ".docker")
+			}
+		}
+		config.TLSCACert = filepath.Join(certPath, "ca.pem")
+		config.TLSCert = filepath.Join(certPath, "cert.pem")
+		config.TLSKey = filepath.Join(certPath, "key.pem")
+	}
+	return config
+}
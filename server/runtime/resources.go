@@ -0,0 +1,59 @@
+package runtime
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// cpuPeriod is the CFS scheduler period CPU quotas are expressed in (100ms, the Docker default)
+const cpuPeriod = 100000
+
+// ResourceLimits caps the resources a container may use (zero values are unlimited)
+type ResourceLimits struct {
+	CPUShares   int64   // Relative CPU weight against other containers (Docker default 1024)
+	CPUs        float64 // CPU quota in cores (e.g., 0.5 for half a core)
+	MemoryBytes int64   // Hard memory limit
+	PidsLimit   int64   // Maximum number of processes
+}
+
+// hostResources converts the limits to the resources of a container's host config
+func (r ResourceLimits) hostResources() container.Resources {
+	resources := container.Resources{
+		CPUShares: r.CPUShares,
+		Memory:    r.MemoryBytes,
+	}
+	if r.CPUs > 0 {
+		resources.CPUPeriod = cpuPeriod
+		resources.CPUQuota = int64(r.CPUs * cpuPeriod)
+	}
+	if r.PidsLimit > 0 {
+		pidsLimit := r.PidsLimit
+		resources.PidsLimit = &pidsLimit
+	}
+	return resources
+}
+
+// ParseRestartPolicy parses a restart policy ("no", "always", "unless-stopped", "on-failure" or
+// "on-failure:<max-retries>"); ok is false for unknown policies. An empty policy is "no".
+func ParseRestartPolicy(policy string) (restartPolicy container.RestartPolicy, ok bool) {
+	name, retries, hasRetries := strings.Cut(strings.TrimSpace(policy), ":")
+	switch name {
+	case "":
+		return container.RestartPolicy{Name: container.RestartPolicyDisabled}, !hasRetries
+	case "no", "always", "unless-stopped":
+		return container.RestartPolicy{Name: container.RestartPolicyMode(name)}, !hasRetries
+	case "on-failure":
+		restartPolicy.Name = container.RestartPolicyOnFailure
+		if hasRetries {
+			maxRetries, err := strconv.Atoi(retries)
+			if err != nil || maxRetries < 0 {
+				return restartPolicy, false
+			}
+			restartPolicy.MaximumRetryCount = maxRetries
+		}
+		return restartPolicy, true
+	}
+	return restartPolicy, false
+}
@@ -0,0 +1,82 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// sshDialer connects to the daemon of an ssh:// host by running "<runtime> system dial-stdio" there,
+// the way the docker CLI does. Authentication uses the ssh agent and config of this machine.
+func sshDialer(u *url.URL, runtimeName string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if runtimeName == "" {
+		runtimeName = "docker"
+	}
+	args := []string{"-o", "BatchMode=yes"}
+	if u.User != nil {
+		args = append(args, "-l", u.User.Username())
+	}
+	if u.Port() != "" {
+		args = append(args, "-p", u.Port())
+	}
+	args = append(args, "--", u.Hostname(), runtimeName, "system", "dial-stdio")
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		// Not bound to ctx: the connection outlives the dial
+		cmd := exec.Command("ssh", args...)
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+		return &commandConn{cmd: cmd, stdin: stdin, stdout: stdout, remote: u.Host}, nil
+	}
+}
+
+// commandConn is a connection over the stdin and stdout of a command
+type commandConn struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	stdout    io.ReadCloser
+	remote    string
+	closeOnce sync.Once
+}
+
+func (c *commandConn) Read(p []byte) (int, error)  { return c.stdout.Read(p) }
+func (c *commandConn) Write(p []byte) (int, error) { return c.stdin.Write(p) }
+
+// Close ends the command; its exit status is of no interest once the connection is closed
+func (c *commandConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.stdin.Close()
+		if c.cmd.Process != nil {
+			c.cmd.Process.Kill()
+		}
+		c.cmd.Wait()
+	})
+	return nil
+}
+
+func (c *commandConn) LocalAddr() net.Addr  { return commandAddr("ssh") }
+func (c *commandConn) RemoteAddr() net.Addr { return commandAddr(c.remote) }
+
+// Deadlines are not supported by pipes; requests are bounded by their contexts instead
+func (c *commandConn) SetDeadline(t time.Time) error      { return nil }
+func (c *commandConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *commandConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// commandAddr names the end of a command connection
+type commandAddr string
+
+func (a commandAddr) Network() string { return "ssh" }
+func (a commandAddr) String() string  { return string(a) }
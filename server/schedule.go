@@ -0,0 +1,356 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"mockelot/models"
+)
+
+// scheduledEntry is one action on the schedule
+type scheduledEntry struct {
+	action  models.ScheduledAction
+	dueAt   time.Time
+	firedAt time.Time
+	state   string
+	timer   *time.Timer
+}
+
+// Scheduler fires timed actions that disable/enable responses and endpoints or put endpoints into
+// error mode. Effects are runtime overrides: the config is never modified.
+type Scheduler struct {
+	mutex       sync.RWMutex
+	entries     []*scheduledEntry
+	fired       []*scheduledEntry // Fired (and not cancelled) entries in firing order; later ones win
+	eventSender EventSender
+}
+
+// NewScheduler creates an empty schedule; changes are reported to the event sender (may be nil)
+func NewScheduler(eventSender EventSender) *Scheduler {
+	return &Scheduler{eventSender: eventSender}
+}
+
+// ValidateScheduledAction checks an action's delay, type and target
+func ValidateScheduledAction(action models.ScheduledAction) error {
+	if _, err := parseScheduleDelay(action.After); err != nil {
+		return err
+	}
+	switch action.Action {
+	case models.ScheduleActionDisableResponse, models.ScheduleActionEnableResponse:
+		if action.ResponseID == "" {
+			return fmt.Errorf("%s requires response_id", action.Action)
+		}
+	case models.ScheduleActionDisableEndpoint, models.ScheduleActionEnableEndpoint,
+		models.ScheduleActionEndpointError, models.ScheduleActionEndpointRecover:
+		if action.EndpointID == "" {
+			return fmt.Errorf("%s requires endpoint_id", action.Action)
+		}
+	default:
+		return fmt.Errorf("unknown scheduled action %q", action.Action)
+	}
+	return nil
+}
+
+// parseScheduleDelay parses a Go duration ("30s", "1m30s"); empty means immediately
+func parseScheduleDelay(after string) (time.Duration, error) {
+	if after == "" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(after)
+	if err != nil {
+		return 0, fmt.Errorf("invalid delay %q: %v", after, err)
+	}
+	if delay < 0 {
+		return 0, fmt.Errorf("invalid delay %q: must not be negative", after)
+	}
+	return delay, nil
+}
+
+// Add schedules an action relative to now. Invalid actions are rejected.
+func (s *Scheduler) Add(action models.ScheduledAction) (models.ScheduledActionStatus, error) {
+	if err := ValidateScheduledAction(action); err != nil {
+		return models.ScheduledActionStatus{}, err
+	}
+	if action.ID == "" {
+		action.ID = uuid.New().String()
+	}
+	delay, _ := parseScheduleDelay(action.After)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, entry := range s.entries {
+		if entry.action.ID == action.ID && entry.state == models.ScheduleStatePending {
+			return models.ScheduledActionStatus{}, fmt.Errorf("action %s is already scheduled", action.ID)
+		}
+	}
+
+	entry := &scheduledEntry{action: action, dueAt: time.Now().Add(delay), state: models.ScheduleStatePending}
+	entry.timer = time.AfterFunc(delay, func() { s.fire(entry) })
+	s.entries = append(s.entries, entry)
+	return entry.status(), nil
+}
+
+// Start schedules the config's actions relative to now (server start); invalid ones are logged and skipped
+func (s *Scheduler) Start(actions []models.ScheduledAction) {
+	for _, action := range actions {
+		if _, err := s.Add(action); err != nil {
+			serverLog.Warn("Skipping scheduled action %q: %v", action.Name, err)
+		}
+	}
+}
+
+// Stop cancels all pending timers and clears the schedule and its effects
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, entry := range s.entries {
+		entry.timer.Stop()
+		entry.state = models.ScheduleStateCancelled
+	}
+	s.entries = nil
+	s.fired = nil
+}
+
+// Cancel cancels a pending action, or reverts the effect of one that already fired
+func (s *Scheduler) Cancel(id string) error {
+	s.mutex.Lock()
+	var cancelled *scheduledEntry
+	for _, entry := range s.entries {
+		if entry.action.ID == id && entry.state != models.ScheduleStateCancelled {
+			cancelled = entry
+			break
+		}
+	}
+	if cancelled == nil {
+		s.mutex.Unlock()
+		return fmt.Errorf("scheduled action not found: %s", id)
+	}
+
+	if cancelled.state == models.ScheduleStatePending {
+		cancelled.timer.Stop()
+	} else {
+		for i, entry := range s.fired {
+			if entry == cancelled {
+				s.fired = append(s.fired[:i], s.fired[i+1:]...)
+				break
+			}
+		}
+	}
+	cancelled.state = models.ScheduleStateCancelled
+	status := cancelled.status()
+	s.mutex.Unlock()
+
+	s.notify(status)
+	return nil
+}
+
+// Status returns the schedule ordered as added
+func (s *Scheduler) Status() []models.ScheduledActionStatus {
+	if s == nil {
+		return []models.ScheduledActionStatus{}
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	statuses := make([]models.ScheduledActionStatus, 0, len(s.entries))
+	for _, entry := range s.entries {
+		statuses = append(statuses, entry.status())
+	}
+	return statuses
+}
+
+func (s *Scheduler) fire(entry *scheduledEntry) {
+	s.mutex.Lock()
+	if entry.state != models.ScheduleStatePending {
+		s.mutex.Unlock()
+		return
+	}
+	entry.state = models.ScheduleStateFired
+	entry.firedAt = time.Now()
+	s.fired = append(s.fired, entry)
+	status := entry.status()
+	s.mutex.Unlock()
+
+	target := entry.action.ResponseID
+	if target == "" {
+		target = entry.action.EndpointID
+	}
+	serverLog.Info("Scheduled action fired: %s %s (%s)", entry.action.Action, target, entry.action.Name)
+	s.notify(status)
+}
+
+func (s *Scheduler) notify(status models.ScheduledActionStatus) {
+	if s.eventSender == nil {
+		return
+	}
+	s.eventSender.SendEvent("schedule:updated", map[string]interface{}{
+		"id":     status.Action.ID,
+		"name":   status.Action.Name,
+		"action": status.Action.Action,
+		"state":  status.State,
+	})
+}
+
+func (e *scheduledEntry) status() models.ScheduledActionStatus {
+	status := models.ScheduledActionStatus{
+		Action: e.action,
+		DueAt:  e.dueAt.Format(time.RFC3339),
+		State:  e.state,
+	}
+	if !e.firedAt.IsZero() {
+		status.FiredAt = e.firedAt.Format(time.RFC3339)
+	}
+	return status
+}
+
+// endpointEnabled returns whether an endpoint receives requests, honouring fired enable/disable actions
+func (s *Scheduler) endpointEnabled(endpoint *models.Endpoint) bool {
+	enabled := endpoint.IsEnabled()
+	if s == nil {
+		return enabled
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, entry := range s.fired {
+		if entry.action.EndpointID != endpoint.ID {
+			continue
+		}
+		switch entry.action.Action {
+		case models.ScheduleActionDisableEndpoint:
+			enabled = false
+		case models.ScheduleActionEnableEndpoint:
+			enabled = true
+		}
+	}
+	return enabled
+}
+
+// endpointError returns the endpoint_error action in effect for an endpoint, or nil
+func (s *Scheduler) endpointError(endpointID string) *models.ScheduledAction {
+	if s == nil {
+		return nil
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var fault *models.ScheduledAction
+	for _, entry := range s.fired {
+		if entry.action.EndpointID != endpointID {
+			continue
+		}
+		switch entry.action.Action {
+		case models.ScheduleActionEndpointError:
+			action := entry.action
+			fault = &action
+		case models.ScheduleActionEndpointRecover:
+			fault = nil
+		}
+	}
+	return fault
+}
+
+// endpointRecovery returns when the earliest pending endpoint_recover action for an endpoint is due
+func (s *Scheduler) endpointRecovery(endpointID string) (time.Time, bool) {
+	if s == nil {
+		return time.Time{}, false
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var due time.Time
+	for _, entry := range s.entries {
+		if entry.action.EndpointID != endpointID || entry.action.Action != models.ScheduleActionEndpointRecover || entry.state != models.ScheduleStatePending {
+			continue
+		}
+		if due.IsZero() || entry.dueAt.Before(due) {
+			due = entry.dueAt
+		}
+	}
+	return due, !due.IsZero()
+}
+
+// responseOverrides returns the enabled state forced on responses by fired actions
+func (s *Scheduler) responseOverrides() map[string]bool {
+	if s == nil {
+		return nil
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var overrides map[string]bool
+	for _, entry := range s.fired {
+		switch entry.action.Action {
+		case models.ScheduleActionDisableResponse, models.ScheduleActionEnableResponse:
+			if overrides == nil {
+				overrides = make(map[string]bool)
+			}
+			overrides[entry.action.ResponseID] = entry.action.Action == models.ScheduleActionEnableResponse
+		}
+	}
+	return overrides
+}
+
+// scheduledItems returns the items with scheduled response enable/disable overrides applied.
+// Items are returned unchanged when nothing is overridden.
+func (s *Scheduler) scheduledItems(items []models.ResponseItem) []models.ResponseItem {
+	overrides := s.responseOverrides()
+	if len(overrides) == 0 {
+		return items
+	}
+
+	override := func(resp models.MethodResponse) models.MethodResponse {
+		if enabled, ok := overrides[resp.ID]; ok {
+			resp.Enabled = &enabled
+		}
+		return resp
+	}
+
+	result := make([]models.ResponseItem, 0, len(items))
+	for _, item := range items {
+		switch {
+		case item.Type == "response" && item.Response != nil:
+			resp := override(*item.Response)
+			result = append(result, models.ResponseItem{Type: "response", Response: &resp})
+		case item.Type == "group" && item.Group != nil:
+			group := *item.Group
+			group.Responses = make([]models.MethodResponse, len(item.Group.Responses))
+			for i, resp := range item.Group.Responses {
+				group.Responses[i] = override(resp)
+			}
+			result = append(result, models.ResponseItem{Type: "group", Group: &group})
+		default:
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// handleScheduledError answers a request to an endpoint in scheduled error mode
+func (h *ResponseHandler) handleScheduledError(w http.ResponseWriter, r *http.Request, endpoint *models.Endpoint, fault *models.ScheduledAction, bodyBytes []byte) {
+	status := fault.StatusCode
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+	body := fault.Body
+	if body == "" {
+		body = http.StatusText(status)
+	}
+	explain(r, "Endpoint %q is in error mode (scheduled action %q)", endpoint.Name, fault.Name)
+
+	startTime := time.Now()
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	// A scheduled recovery tells clients when to come back
+	if recovery, ok := h.scheduler.endpointRecovery(endpoint.ID); ok && advertisesRetryAfter(status) {
+		w.Header().Set("Retry-After", retryAfterSeconds(time.Until(recovery)))
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+
+	requestLog := buildRequestLog(r, bodyBytes, endpoint.ID)
+	rttMs := time.Since(startTime).Milliseconds()
+	requestLog.ClientResponse.StatusCode = &status
+	requestLog.ClientResponse.StatusText = http.StatusText(status)
+	requestLog.ClientResponse.Headers = w.Header().Clone()
+	requestLog.ClientResponse.Body = body
+	requestLog.ClientResponse.RTTMs = &rttMs
+	h.requestLogger.LogRequest(requestLog)
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"mockelot/models"
+)
+
+// compiledSchemas caches parsed schemas by their JSON source
+var compiledSchemas sync.Map
+
+// compileSchema parses a schema stored as JSON
+func compileSchema(source string) (*openapi3.Schema, error) {
+	if cached, ok := compiledSchemas.Load(source); ok {
+		return cached.(*openapi3.Schema), nil
+	}
+	schema := &openapi3.Schema{}
+	if err := json.Unmarshal([]byte(source), schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	compiledSchemas.Store(source, schema)
+	return schema, nil
+}
+
+// validateSchema checks the request's body and parameters against their schemas and reports every violation
+func validateSchema(validation *models.SchemaValidation, body string, reqContext *RequestContext) *ValidationResult {
+	var violations []models.SchemaViolation
+
+	for _, param := range validation.Parameters {
+		violations = append(violations, validateParameter(param, reqContext)...)
+	}
+
+	if validation.Body != "" || validation.BodyRequired {
+		violations = append(violations, validateBody(validation, body)...)
+	}
+
+	if len(violations) == 0 {
+		return &ValidationResult{Valid: true, Vars: make(map[string]interface{})}
+	}
+
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.String()
+	}
+	return &ValidationResult{
+		Valid:      false,
+		Error:      "schema validation failed: " + strings.Join(messages, "; "),
+		Violations: violations,
+		Reject:     validation.Reject,
+	}
+}
+
+// validateBody checks a JSON request body against the body schema
+func validateBody(validation *models.SchemaValidation, body string) []models.SchemaViolation {
+	if strings.TrimSpace(body) == "" {
+		if validation.BodyRequired {
+			return []models.SchemaViolation{{In: "body", Message: "request body is required"}}
+		}
+		return nil
+	}
+	if validation.Body == "" {
+		return nil
+	}
+
+	schema, err := compileSchema(validation.Body)
+	if err != nil {
+		return []models.SchemaViolation{{In: "body", Message: err.Error()}}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return []models.SchemaViolation{{In: "body", Message: fmt.Sprintf("body is not valid JSON: %v", err)}}
+	}
+
+	return schemaViolations(schema.VisitJSON(value, openapi3.MultiErrors(), openapi3.VisitAsRequest(), openapi3.EnableFormatValidation()), "body", "")
+}
+
+// validateParameter checks one query, path or header parameter
+func validateParameter(param models.ParameterSchema, reqContext *RequestContext) []models.SchemaViolation {
+	var values []string
+	if reqContext != nil {
+		switch param.In {
+		case models.ParameterInQuery:
+			values = reqContext.QueryParams[param.Name]
+		case models.ParameterInPath:
+			if value, ok := reqContext.PathParams[param.Name]; ok {
+				values = []string{value}
+			}
+		case models.ParameterInHeader:
+			values = http.Header(reqContext.Headers).Values(param.Name)
+		}
+	}
+
+	if len(values) == 0 {
+		if param.Required {
+			return []models.SchemaViolation{{In: param.In, Name: param.Name, Message: "is required"}}
+		}
+		return nil
+	}
+	if param.Schema == "" {
+		return nil
+	}
+
+	schema, err := compileSchema(param.Schema)
+	if err != nil {
+		return []models.SchemaViolation{{In: param.In, Name: param.Name, Message: err.Error()}}
+	}
+
+	return schemaViolations(schema.VisitJSON(coerceParameter(schema, values), openapi3.MultiErrors(), openapi3.EnableFormatValidation()), param.In, param.Name)
+}
+
+// coerceParameter converts raw parameter strings to the type the schema expects, so "5" validates
+// as an integer. Values that do not convert are left as strings and fail validation.
+func coerceParameter(schema *openapi3.Schema, values []string) interface{} {
+	if schema.Type.Is(openapi3.TypeArray) {
+		// Arrays arrive as repeated parameters or a single comma-separated value
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		items := make([]interface{}, len(values))
+		for i, value := range values {
+			itemSchema := &openapi3.Schema{}
+			if schema.Items != nil && schema.Items.Value != nil {
+				itemSchema = schema.Items.Value
+			}
+			items[i] = coerceParameter(itemSchema, []string{value})
+		}
+		return items
+	}
+
+	value := values[0]
+	switch {
+	case schema.Type.Is(openapi3.TypeInteger), schema.Type.Is(openapi3.TypeNumber):
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case schema.Type.Is(openapi3.TypeBoolean):
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+	return value
+}
+
+// schemaViolations flattens a schema validation error into violations
+func schemaViolations(err error, in, name string) []models.SchemaViolation {
+	if err == nil {
+		return nil
+	}
+
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		var violations []models.SchemaViolation
+		for _, e := range multi {
+			violations = append(violations, schemaViolations(e, in, name)...)
+		}
+		return violations
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		location := name
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+			location += "/" + strings.Join(pointer, "/")
+		}
+		if in == "body" && location == "" {
+			location = "/"
+		}
+		return []models.SchemaViolation{{In: in, Name: location, Message: schemaErr.Reason}}
+	}
+
+	return []models.SchemaViolation{{In: in, Name: name, Message: err.Error()}}
+}
+
+// rejectInvalidRequest answers a request that violates its schema with 400 and a structured error body
+func (h *ResponseHandler) rejectInvalidRequest(w http.ResponseWriter, r *http.Request, bodyBytes []byte, endpointID string, result *ValidationResult) {
+	startTime := time.Now()
+	errorBody, _ := json.MarshalIndent(map[string]interface{}{
+		"error":      "Request validation failed",
+		"violations": result.Violations,
+	}, "", "  ")
+
+	status := http.StatusBadRequest
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(errorBody)
+
+	requestLog := buildRequestLog(r, bodyBytes, endpointID)
+	rttMs := time.Since(startTime).Milliseconds()
+	requestLog.ValidationFailed = true
+	requestLog.ClientResponse.StatusCode = &status
+	requestLog.ClientResponse.StatusText = http.StatusText(status)
+	requestLog.ClientResponse.Headers = map[string][]string{"Content-Type": {"application/json"}}
+	requestLog.ClientResponse.Body = string(errorBody)
+	requestLog.ClientResponse.RTTMs = &rttMs
+	h.requestLogger.LogRequest(requestLog)
+}
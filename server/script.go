@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -16,6 +15,8 @@ type ScriptResponse struct {
 	Headers map[string]string `json:"headers"`
 	Body    string            `json:"body"`
 	Delay   int               `json:"delay"`
+
+	EventStream string `json:"-"` // Event name the response streams (set by events.on)
 }
 
 // ScriptError represents an error that occurred during script execution
@@ -35,8 +36,13 @@ func (e *ScriptError) Error() string {
 // ProcessScript executes a JavaScript script with access to request context
 // and returns the modified response
 func ProcessScript(scriptBody string, reqContext *RequestContext, originalResponse *models.MethodResponse) (*ScriptResponse, error) {
-	// Create a new JavaScript runtime
-	vm := goja.New()
+	script, err := compileResponseScript(originalResponse.ID, scriptBody)
+	if err != nil {
+		return nil, &ScriptError{Message: err.Error()}
+	}
+
+	// Borrow a JavaScript runtime from the script's pool
+	vm := script.acquireVM()
 
 	// Set up timeout context (5 second limit)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -47,7 +53,7 @@ func ProcessScript(scriptBody string, reqContext *RequestContext, originalRespon
 	errChan := make(chan error, 1)
 
 	go func() {
-		result, err := runScript(vm, scriptBody, reqContext, originalResponse)
+		result, err := runScript(vm, script.program, reqContext, originalResponse)
 		if err != nil {
 			errChan <- err
 		} else {
@@ -58,16 +64,19 @@ func ProcessScript(scriptBody string, reqContext *RequestContext, originalRespon
 	// Wait for result or timeout
 	select {
 	case result := <-resultChan:
+		script.releaseVM(vm)
 		return result, nil
 	case err := <-errChan:
+		script.releaseVM(vm)
 		return nil, err
 	case <-ctx.Done():
+		// The interrupted runtime may still be unwinding, so it is not returned to the pool
 		vm.Interrupt("script execution timeout")
 		return nil, &ScriptError{Message: "script execution timeout (5s limit)"}
 	}
 }
 
-func runScript(vm *goja.Runtime, scriptBody string, reqContext *RequestContext, originalResponse *models.MethodResponse) (*ScriptResponse, error) {
+func runScript(vm *goja.Runtime, program *goja.Program, reqContext *RequestContext, originalResponse *models.MethodResponse) (*ScriptResponse, error) {
 	// Prepare headers for response (convert from original or use empty map)
 	originalHeaders := make(map[string]interface{})
 	if originalResponse.Headers != nil {
@@ -96,6 +105,35 @@ func runScript(vm *goja.Runtime, scriptBody string, reqContext *RequestContext,
 		return nil, &ScriptError{Message: fmt.Sprintf("failed to set request object: %v", err)}
 	}
 
+	// Set up history of previous requests to this endpoint (read-only, oldest first)
+	history, historyErr := historyToJS(vm, reqContext.History)
+	if historyErr == nil {
+		historyErr = vm.Set("history", history)
+	}
+	if historyErr != nil {
+		return nil, &ScriptError{Message: fmt.Sprintf("failed to set history object: %v", historyErr)}
+	}
+
+	// Set up event bus (events.emit publishes, events.on streams this response)
+	if err := vm.Set("events", eventsToJS(vm, reqContext.Events, result)); err != nil {
+		return nil, &ScriptError{Message: fmt.Sprintf("failed to set events object: %v", err)}
+	}
+
+	// Set up require() for the shared script modules
+	if err := setupRequire(vm, reqContext.Modules); err != nil {
+		return nil, &ScriptError{Message: fmt.Sprintf("failed to set require function: %v", err)}
+	}
+
+	// Set up http.fetch for outbound calls (disabled unless configured)
+	if err := setupFetch(vm, reqContext); err != nil {
+		return nil, &ScriptError{Message: fmt.Sprintf("failed to set http object: %v", err)}
+	}
+
+	// Set up jwt.sign/verify/decode with the configured keys
+	if err := setupJWT(vm, reqContext.JWT); err != nil {
+		return nil, &ScriptError{Message: fmt.Sprintf("failed to set jwt object: %v", err)}
+	}
+
 	// Set up response object (writable) as plain JavaScript object for Goja compatibility
 	responseObj := map[string]interface{}{
 		"status":  originalResponse.StatusCode,
@@ -107,60 +145,8 @@ func runScript(vm *goja.Runtime, scriptBody string, reqContext *RequestContext,
 		return nil, &ScriptError{Message: fmt.Sprintf("failed to set response object: %v", err)}
 	}
 
-	// Add console.log for debugging
-	console := map[string]interface{}{
-		"log": func(args ...interface{}) {
-			// In production, you might want to collect these logs
-			// For now, we'll just ignore them
-		},
-		"error": func(args ...interface{}) {
-			// Same as log
-		},
-		"warn": func(args ...interface{}) {
-			// Same as log
-		},
-	}
-	if err := vm.Set("console", console); err != nil {
-		return nil, &ScriptError{Message: fmt.Sprintf("failed to set console object: %v", err)}
-	}
-
-	// Add JSON utility (overrides native, but with proper Go interop)
-	jsonUtil := map[string]interface{}{
-		"stringify": func(v interface{}, args ...interface{}) string {
-			var indent string
-			if len(args) >= 2 {
-				if spaces, ok := args[1].(int64); ok {
-					for i := int64(0); i < spaces; i++ {
-						indent += " "
-					}
-				}
-			}
-			var b []byte
-			var err error
-			if indent != "" {
-				b, err = json.MarshalIndent(v, "", indent)
-			} else {
-				b, err = json.Marshal(v)
-			}
-			if err != nil {
-				return ""
-			}
-			return string(b)
-		},
-		"parse": func(s string) interface{} {
-			var v interface{}
-			if err := json.Unmarshal([]byte(s), &v); err != nil {
-				return nil
-			}
-			return v
-		},
-	}
-	if err := vm.Set("JSON", jsonUtil); err != nil {
-		return nil, &ScriptError{Message: fmt.Sprintf("failed to set JSON object: %v", err)}
-	}
-
 	// Execute the script
-	_, err := vm.RunString(scriptBody)
+	_, err := vm.RunProgram(program)
 	if err != nil {
 		if jsErr, ok := err.(*goja.Exception); ok {
 			return nil, &ScriptError{Message: jsErr.String()}
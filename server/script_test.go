@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"mockelot/models"
+)
+
+const benchmarkScript = `
+var items = [];
+for (var i = 0; i < 20; i++) {
+	items.push({ id: i, path: request.path, name: "item-" + i });
+}
+response.headers["Content-Type"] = "application/json";
+response.body = JSON.stringify({ count: items.length, items: items });
+`
+
+// BenchmarkProcessScript compares running a response script from the compiled program cache
+// with compiling it on every run, which is what every request did before the cache
+func BenchmarkProcessScript(b *testing.B) {
+	req := httptest.NewRequest("GET", "/users/42?verbose=true", nil)
+	reqContext := BuildRequestContext(req, nil, map[string]string{"id": "42"})
+	resp := &models.MethodResponse{
+		ID:           "benchmark-response",
+		StatusCode:   200,
+		ResponseMode: models.ResponseModeScript,
+		ScriptBody:   benchmarkScript,
+	}
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := ProcessScript(benchmarkScript, reqContext, resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			scriptCacheMutex.Lock()
+			delete(scriptCache, resp.ID)
+			scriptCacheMutex.Unlock()
+			if _, err := ProcessScript(benchmarkScript, reqContext, resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/dop251/goja"
+	"mockelot/models"
+)
+
+// ValidateScripts compiles every script, template and JavaScript expression in the config and
+// returns the ones that fail, so broken configs are reported on load instead of on first request
+func ValidateScripts(cfg *models.AppConfig) []models.ScriptWarning {
+	var warnings []models.ScriptWarning
+
+	for i := range cfg.Endpoints {
+		warnings = append(warnings, ValidateEndpointScripts(&cfg.Endpoints[i])...)
+	}
+
+	// Legacy top-level items and responses (used when no endpoints are configured)
+	warnings = append(warnings, validateItemScripts(nil, cfg.Items)...)
+	for i := range cfg.Responses {
+		warnings = append(warnings, validateResponseScripts(nil, &cfg.Responses[i])...)
+	}
+
+	if cors := cfg.CORS; cors.Enabled {
+		if cors.Mode == models.CORSModeScript {
+			if err := compileScript(cors.Script); err != nil {
+				warnings = append(warnings, newScriptWarning(nil, "", "cors.script", err))
+			}
+		} else {
+			for _, header := range cors.HeaderExpressions {
+				if err := compileScript(header.Expression); err != nil {
+					warnings = append(warnings, newScriptWarning(nil, "", "cors.header_expressions."+header.Name, err))
+				}
+			}
+		}
+	}
+
+	warnings = append(warnings, ValidateScriptModules(cfg.ScriptModules)...)
+
+	if cfg.GRPC != nil {
+		for _, method := range cfg.GRPC.Methods {
+			field := "grpc.methods." + method.Method
+			switch method.ResponseMode {
+			case models.ResponseModeTemplate:
+				if err := parseTemplate(method.Body); err != nil {
+					warnings = append(warnings, newScriptWarning(nil, method.ID, field+".body", err))
+				}
+			case models.ResponseModeScript:
+				if err := compileScript(wrapResponseScript(method.ScriptBody)); err != nil {
+					warnings = append(warnings, newScriptWarning(nil, method.ID, field+".script_body", err))
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// ValidateEndpointScripts compiles the scripts and templates of a single endpoint and its responses
+func ValidateEndpointScripts(endpoint *models.Endpoint) []models.ScriptWarning {
+	var warnings []models.ScriptWarning
+
+	if endpoint.ProxyConfig != nil {
+		warnings = append(warnings, validateProxyScripts(endpoint, "proxy_config", endpoint.ProxyConfig)...)
+	}
+	if endpoint.ContainerConfig != nil {
+		warnings = append(warnings, validateProxyScripts(endpoint, "container_config.proxy_config", &endpoint.ContainerConfig.ProxyConfig)...)
+		for _, env := range endpoint.ContainerConfig.Environment {
+			if env.Expression == "" {
+				continue
+			}
+			if err := compileScript(env.Expression); err != nil {
+				warnings = append(warnings, newScriptWarning(endpoint, "", "container_config.environment."+env.Name, err))
+			}
+		}
+	}
+
+	warnings = append(warnings, validateItemScripts(endpoint, endpoint.Items)...)
+	if endpoint.Versioning != nil {
+		for _, version := range endpoint.Versioning.Versions {
+			warnings = append(warnings, validateItemScripts(endpoint, version.Items)...)
+		}
+	}
+
+	return warnings
+}
+
+// validateProxyScripts compiles a proxy's header expressions, body transform and assertion script
+func validateProxyScripts(endpoint *models.Endpoint, prefix string, proxy *models.ProxyConfig) []models.ScriptWarning {
+	var warnings []models.ScriptWarning
+
+	checkHeaders := func(direction string, headers []models.HeaderManipulation) {
+		for _, header := range headers {
+			if header.Mode != models.HeaderModeExpression {
+				continue
+			}
+			if err := compileScript(header.Expression); err != nil {
+				warnings = append(warnings, newScriptWarning(endpoint, "", prefix+"."+direction+"."+header.Name, err))
+			}
+		}
+	}
+	checkHeaders("inbound_headers", proxy.InboundHeaders)
+	checkHeaders("outbound_headers", proxy.OutboundHeaders)
+
+	for i, rule := range proxy.FieldMappings {
+		if err := ValidateFieldMapping(rule); err != nil {
+			warnings = append(warnings, newScriptWarning(endpoint, "", fmt.Sprintf("%s.field_mappings[%d]", prefix, i), err))
+		}
+	}
+	if proxy.BodyTransform != "" {
+		if err := compileScript(proxy.BodyTransform); err != nil {
+			warnings = append(warnings, newScriptWarning(endpoint, "", prefix+".body_transform", err))
+		}
+	}
+	if proxy.AssertionScript != "" {
+		if err := compileScript(proxy.AssertionScript); err != nil {
+			warnings = append(warnings, newScriptWarning(endpoint, "", prefix+".assertion_script", err))
+		}
+	}
+
+	return warnings
+}
+
+// validateItemScripts compiles the scripts of standalone and grouped responses
+func validateItemScripts(endpoint *models.Endpoint, items []models.ResponseItem) []models.ScriptWarning {
+	var warnings []models.ScriptWarning
+	for _, item := range items {
+		if item.Type == "response" && item.Response != nil {
+			warnings = append(warnings, validateResponseScripts(endpoint, item.Response)...)
+		} else if item.Type == "group" && item.Group != nil {
+			for i := range item.Group.Responses {
+				warnings = append(warnings, validateResponseScripts(endpoint, &item.Group.Responses[i])...)
+			}
+		}
+	}
+	return warnings
+}
+
+// validateResponseScripts compiles a response's script or templates (including variants and
+// sequence steps), its delay expression and its request validation scripts
+func validateResponseScripts(endpoint *models.Endpoint, resp *models.MethodResponse) []models.ScriptWarning {
+	var warnings []models.ScriptWarning
+	add := func(field string, err error) {
+		warnings = append(warnings, newScriptWarning(endpoint, resp.ID, field, err))
+	}
+
+	switch resp.ResponseMode {
+	case models.ResponseModeScript:
+		if err := compileScript(wrapResponseScript(resp.ScriptBody)); err != nil {
+			add("script_body", err)
+		}
+	case models.ResponseModeTemplate:
+		checkTemplates := func(prefix, body string, headers map[string]string) {
+			if err := parseTemplate(body); err != nil {
+				add(prefix+"body", err)
+			}
+			for _, name := range sortedKeys(headers) {
+				if !strings.Contains(headers[name], "{{") {
+					continue
+				}
+				if err := parseTemplate(headers[name]); err != nil {
+					add(prefix+"headers."+name, err)
+				}
+			}
+		}
+		checkTemplates("", resp.Body, resp.Headers)
+		for i, variant := range resp.Variants {
+			checkTemplates(fmt.Sprintf("variants[%d].", i), variant.Body, variant.Headers)
+		}
+		for i, step := range resp.Sequence {
+			checkTemplates(fmt.Sprintf("sequence[%d].", i), step.Body, step.Headers)
+		}
+	}
+
+	for i, rule := range resp.FieldMappings {
+		if err := ValidateFieldMapping(rule); err != nil {
+			add(fmt.Sprintf("field_mappings[%d]", i), err)
+		}
+	}
+
+	if resp.DelayExpression != "" {
+		if err := ValidateDelayExpression(resp.DelayExpression); err != nil {
+			add("delay_expression", err)
+		}
+	}
+
+	if v := resp.RequestValidation; v != nil {
+		if v.Mode == models.ValidationModeScript && v.Script != "" {
+			if err := compileScript(v.Script); err != nil {
+				add("request_validation.script", err)
+			}
+		}
+		for _, hv := range v.Headers {
+			if hv.Mode == models.HeaderValidationModeScript && hv.Expression != "" {
+				if err := compileScript(hv.Expression); err != nil {
+					add("request_validation.headers."+hv.Name, err)
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// compileScript compiles JavaScript the way the runtime runs it, without executing it
+func compileScript(source string) error {
+	_, err := goja.Compile("", source, false)
+	return err
+}
+
+// parseTemplate parses a response template with the same functions available at runtime
+func parseTemplate(body string) error {
+	_, err := template.New("response").Funcs(templateFuncs).Funcs(newFaker().funcs()).Parse(body)
+	return err
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func newScriptWarning(endpoint *models.Endpoint, responseID, field string, err error) models.ScriptWarning {
+	warning := models.ScriptWarning{
+		ResponseID: responseID,
+		Field:      field,
+		Error:      err.Error(),
+	}
+	if endpoint != nil {
+		warning.EndpointID = endpoint.ID
+		warning.EndpointName = endpoint.Name
+	}
+	return warning
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/dop251/goja"
+)
+
+// scriptWrapperPrefix runs a response script as a function body, so its variables are local and
+// a pooled runtime can run it again. It ends on the script's first line so error positions
+// match the script source.
+const scriptWrapperPrefix = "(function () {"
+
+// maxCachedScripts bounds the compiled script cache; scripts of responses without an ID are
+// cached by their source, which changes with every edit
+const maxCachedScripts = 1024
+
+// compiledScript is a response script program, the source it was compiled from and the
+// runtimes that have run it. Runtimes are pooled per script, so changes a script makes to
+// builtins or prototypes are only ever seen by later runs of that same script.
+type compiledScript struct {
+	code     string
+	program  *goja.Program
+	runtimes *sync.Pool
+}
+
+var (
+	scriptCache      = make(map[string]compiledScript) // By response ID (or source for responses without one)
+	scriptCacheMutex sync.RWMutex
+)
+
+// scriptVMGlobals are the globals a pooled runtime keeps between scripts; everything else a
+// script (or the per-request setup) defines is removed before the runtime is reused
+var scriptVMGlobals = map[string]bool{"console": true, "JSON": true}
+
+// compileResponseScript returns the compiled form of a response script, compiling it (with a
+// new runtime pool) only when the response's script changed
+func compileResponseScript(responseID, code string) (compiledScript, error) {
+	key := responseID
+	if key == "" {
+		key = code
+	}
+
+	scriptCacheMutex.RLock()
+	cached, ok := scriptCache[key]
+	scriptCacheMutex.RUnlock()
+	if ok && cached.code == code {
+		return cached, nil
+	}
+
+	program, err := goja.Compile("", wrapResponseScript(code), false)
+	if err != nil {
+		return compiledScript{}, err
+	}
+	compiled := compiledScript{
+		code:     code,
+		program:  program,
+		runtimes: &sync.Pool{New: func() interface{} { return newScriptVM() }},
+	}
+
+	scriptCacheMutex.Lock()
+	if len(scriptCache) >= maxCachedScripts {
+		scriptCache = make(map[string]compiledScript)
+	}
+	scriptCache[key] = compiled
+	scriptCacheMutex.Unlock()
+	return compiled, nil
+}
+
+// wrapResponseScript returns the source actually compiled for a response script
+func wrapResponseScript(code string) string {
+	return scriptWrapperPrefix + code + "\n})()"
+}
+
+// acquireVM takes a runtime from the script's pool
+func (s compiledScript) acquireVM() *goja.Runtime {
+	return s.runtimes.Get().(*goja.Runtime)
+}
+
+// releaseVM clears what a run left in a runtime's global scope, resets its interrupt state and
+// returns it to the script's pool. Runtimes whose globals cannot be cleared are dropped.
+func (s compiledScript) releaseVM(vm *goja.Runtime) {
+	global := vm.GlobalObject()
+	for _, key := range global.Keys() {
+		if scriptVMGlobals[key] {
+			continue
+		}
+		if err := global.Delete(key); err != nil || global.Get(key) != nil {
+			return
+		}
+	}
+	vm.ClearInterrupt()
+	s.runtimes.Put(vm)
+}
+
+// newScriptVM creates a runtime with the globals every script shares
+func newScriptVM() *goja.Runtime {
+	vm := goja.New()
+
+	// Add console.log for debugging
+	console := map[string]interface{}{
+		"log": func(args ...interface{}) {
+			// In production, you might want to collect these logs
+			// For now, we'll just ignore them
+		},
+		"error": func(args ...interface{}) {
+			// Same as log
+		},
+		"warn": func(args ...interface{}) {
+			// Same as log
+		},
+	}
+	vm.Set("console", console)
+
+	// Add JSON utility (overrides native, but with proper Go interop)
+	jsonUtil := map[string]interface{}{
+		"stringify": func(v interface{}, args ...interface{}) string {
+			var indent string
+			if len(args) >= 2 {
+				if spaces, ok := args[1].(int64); ok {
+					for i := int64(0); i < spaces; i++ {
+						indent += " "
+					}
+				}
+			}
+			var b []byte
+			var err error
+			if indent != "" {
+				b, err = json.MarshalIndent(v, "", indent)
+			} else {
+				b, err = json.Marshal(v)
+			}
+			if err != nil {
+				return ""
+			}
+			return string(b)
+		},
+		"parse": func(s string) interface{} {
+			var v interface{}
+			if err := json.Unmarshal([]byte(s), &v); err != nil {
+				return nil
+			}
+			return v
+		},
+	}
+	vm.Set("JSON", jsonUtil)
+
+	return vm
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"mockelot/models"
+)
+
+// SequenceTracker counts calls to sequence responses, shared by all listeners
+type SequenceTracker struct {
+	mutex sync.Mutex
+	calls map[string]int // Response key -> calls served so far
+}
+
+// NewSequenceTracker creates a tracker with every sequence at its first step
+func NewSequenceTracker() *SequenceTracker {
+	return &SequenceTracker{calls: make(map[string]int)}
+}
+
+// Reset moves one response's sequence (or every sequence, if responseID is empty) back to its first step
+func (t *SequenceTracker) Reset(responseID string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if responseID == "" {
+		t.calls = make(map[string]int)
+		return
+	}
+	delete(t.calls, responseID)
+}
+
+// advance returns the 0-based step for this call and moves the sequence on
+func (t *SequenceTracker) advance(key string, length int, loop bool) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	call := t.calls[key]
+	t.calls[key] = call + 1
+	if loop {
+		return call % length
+	}
+	if call >= length {
+		return length - 1
+	}
+	return call
+}
+
+// nextStep returns the response to serve for this call of a sequence response: a copy with the
+// current step's overrides applied. Responses without a sequence are returned unchanged.
+func (t *SequenceTracker) nextStep(resp *models.MethodResponse, r *http.Request) *models.MethodResponse {
+	if t == nil || len(resp.Sequence) == 0 {
+		return resp
+	}
+
+	key := resp.ID
+	if key == "" {
+		key = strings.Join(resp.Methods, ",") + " " + resp.PathPattern
+	}
+	index := t.advance(key, len(resp.Sequence), resp.SequenceEnd == models.SequenceEndLoop)
+	step := resp.Sequence[index]
+
+	selected := *resp
+	if step.StatusCode != 0 {
+		selected.StatusCode = step.StatusCode
+		selected.StatusText = ""
+	}
+	if step.Body != "" {
+		selected.Body = step.Body
+		selected.BodyBase64 = false
+	}
+	if step.ResponseDelay != 0 {
+		selected.ResponseDelay = step.ResponseDelay
+	}
+	if len(step.Headers) > 0 {
+		headers := make(map[string]string, len(resp.Headers)+len(step.Headers))
+		for name, value := range resp.Headers {
+			headers[name] = value
+		}
+		for name, value := range step.Headers {
+			setHeader(headers, name, value)
+		}
+		selected.Headers = headers
+	}
+
+	updateMatchInfo(r, func(info *models.MatchInfo) {
+		info.SequenceStep = index + 1
+		info.SequenceLength = len(resp.Sequence)
+	})
+	explain(r, "Sequence step %d of %d", index+1, len(resp.Sequence))
+	return &selected
+}
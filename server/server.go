@@ -6,9 +6,10 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
-	"log"
 	"net/http"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/net/http2"
@@ -20,27 +21,52 @@ type HTTPServer struct {
 	httpServer        *http.Server
 	httpsServer       *http.Server
 	socks5Server      *SOCKS5Server
+	httpProxyServer   *HTTPProxyServer
+	grpcServer        *GRPCServer
+	dnsServer         *DNSServer
 	config            *models.AppConfig
 	configMutex       sync.RWMutex
 	requestLogger     RequestLogger
 	scriptErrorLogger ScriptErrorLogger
 	httpStopChan      chan struct{}
 	httpsStopChan     chan struct{}
+	endpointListeners map[int]*endpointListener        // Dedicated listeners of endpoints with their own port
+	namedListeners    map[string]*runningNamedListener // Named listeners (config listeners), by name
+	listenersMutex    sync.Mutex
 	certManager       *CertificateManager
-	certCache         *CertCache // Certificate cache for SOCKS5 TLS interception
+	certCache         *CertCache                       // Certificate cache for SOCKS5 and HTTP proxy TLS interception
+	servingCert       atomic.Pointer[tls.Certificate]  // HTTPS certificate, swapped by ReloadCertificates
+	clientAuth        atomic.Pointer[clientAuthPolicy] // HTTPS client certificate policy, swapped by ReloadCertificates
+	sniCerts          atomic.Pointer[CertCache]        // HTTPS certificates minted per taken-over domain, replaced by ReloadCertificates
+	certWatchStop     chan struct{}                    // Stops periodic certificate expiry checks
 	proxyHandler      *ProxyHandler
 	containerHandler  *ContainerHandler
 	startupCtx        context.Context    // Context for container startup
 	startupCancel     context.CancelFunc // Cancel function for startup
+	history           *RequestHistory    // Request history shared by all listeners (script history API)
+	events            *EventBus          // Event bus shared by all listeners (script events API)
+	scheduler         *Scheduler         // Scheduled actions, started with the server
+	perfStats         *PerfStats         // Template/script execution cost per response
+	sequences         *SequenceTracker   // Sequence response positions
+	bypassStats       *BypassStats       // Connections tunneled by SOCKS5 bypass rules
+	traffic           *TrafficMeter      // Bytes carried per SOCKS5 tunnel and proxy endpoint
+	throttle          *ClientThrottle    // Token buckets of client throttle rules
+	eventSender       EventSender        // Frontend notifications (certificate expiry warnings)
+	plugins           *pluginLifecycle   // Running endpoints of plugin-provided types
+	invalidPatterns   string             // Invalid patterns last reported by UpdateConfig
 }
 
 func NewHTTPServer(config *models.AppConfig, requestLogger RequestLogger, scriptErrorLogger ScriptErrorLogger, eventSender EventSender, containerHandler *ContainerHandler, proxyHandler *ProxyHandler) *HTTPServer {
 	certManager, err := NewCertificateManager()
 	if err != nil {
-		log.Printf("Warning: Failed to initialize certificate manager: %v", err)
+		serverLog.Warn("Failed to initialize certificate manager: %v", err)
 	}
 
 	// Proxy handler is passed in (shared with container handler)
+	traffic := NewTrafficMeter(eventSender)
+	if proxyHandler != nil {
+		proxyHandler.SetTrafficMeter(traffic)
+	}
 
 	return &HTTPServer{
 		config:            config,
@@ -48,9 +74,21 @@ func NewHTTPServer(config *models.AppConfig, requestLogger RequestLogger, script
 		scriptErrorLogger: scriptErrorLogger,
 		httpStopChan:      make(chan struct{}),
 		httpsStopChan:     make(chan struct{}),
+		endpointListeners: make(map[int]*endpointListener),
+		namedListeners:    make(map[string]*runningNamedListener),
 		certManager:       certManager,
 		proxyHandler:      proxyHandler,
 		containerHandler:  containerHandler,
+		history:           NewRequestHistory(),
+		events:            NewEventBus(),
+		scheduler:         NewScheduler(eventSender),
+		perfStats:         NewPerfStats(),
+		sequences:         NewSequenceTracker(),
+		bypassStats:       NewBypassStats(),
+		traffic:           traffic,
+		throttle:          NewClientThrottle(),
+		eventSender:       eventSender,
+		plugins:           newPluginLifecycle(),
 	}
 }
 
@@ -71,7 +109,7 @@ func (s *HTTPServer) StartHTTP() error {
 		handler = HTTPSRedirectHandler(httpsPort)
 	} else {
 		// Use normal response handler
-		responseHandler := NewResponseHandler(s.config, s.requestLogger, s.scriptErrorLogger, s.proxyHandler, s.containerHandler)
+		responseHandler := NewResponseHandler(s.config, s.requestLogger, s.scriptErrorLogger, s.proxyHandler, s.containerHandler, s.history, s.events, s.scheduler, s.perfStats, s.sequences, s.throttle)
 		handler = http.HandlerFunc(responseHandler.HandleRequest)
 	}
 
@@ -86,18 +124,22 @@ func (s *HTTPServer) StartHTTP() error {
 	}
 
 	// Create HTTP server
+	limits := s.currentLimits()
 	s.httpServer = &http.Server{
-		Addr:         fmt.Sprintf(":%d", port),
-		Handler:      handler,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: handler,
 	}
+	applyServerLimits(s.httpServer, limits)
 
 	// Start server in a goroutine
 	go func() {
-		log.Printf("Starting HTTP server on port %d", port)
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Printf("HTTP server error: %v", err)
+		serverLog.Info("Starting HTTP server on port %d", port)
+		listener, err := listenWithLimit(s.httpServer.Addr, limits.MaxConnections)
+		if err == nil {
+			err = s.httpServer.Serve(listener)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			serverLog.Error("HTTP server error: %v", err)
 		}
 		s.httpStopChan <- struct{}{}
 	}()
@@ -107,13 +149,118 @@ func (s *HTTPServer) StartHTTP() error {
 
 // StartHTTPS starts the HTTPS server with TLS configuration
 func (s *HTTPServer) StartHTTPS() error {
+	cert, err := s.buildServingCertificate()
+	if err != nil {
+		return err
+	}
+	s.servingCert.Store(cert)
+	s.reloadSNICerts()
+	if err := s.reloadClientAuth(); err != nil {
+		return err
+	}
+
+	s.configMutex.RLock()
+	httpsPort := s.config.HTTPSPort
+	s.configMutex.RUnlock()
+
+	// Create response handler
+	responseHandler := NewResponseHandler(s.config, s.requestLogger, s.scriptErrorLogger, s.proxyHandler, s.containerHandler, s.history, s.events, s.scheduler, s.perfStats, s.sequences, s.throttle)
+
+	// The certificate is looked up per handshake so it can be rotated without a restart, and so
+	// taken-over domains get one matching their name
+	tlsConfig := &tls.Config{
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return s.httpsCertificate(hello, responseHandler)
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+
+	// Create HTTPS server
+	limits := s.currentLimits()
+	s.httpsServer = &http.Server{
+		Addr:      fmt.Sprintf(":%d", httpsPort),
+		Handler:   http.HandlerFunc(responseHandler.HandleRequest),
+		TLSConfig: tlsConfig,
+	}
+	applyServerLimits(s.httpsServer, limits)
+
+	// Client certificates are also asked for per handshake, so client_auth changes need no restart
+	httpsServer := s.httpsServer
+	tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return s.clientAuthConfig(httpsServer.TLSConfig)
+	}
+
+	// Configure HTTP/2 support
+	s.configMutex.RLock()
+	http2Enabled := s.config.HTTP2Enabled
+	s.configMutex.RUnlock()
+
+	if http2Enabled {
+		// Enable HTTP/2 (default behavior, but explicit for clarity)
+		http2.ConfigureServer(s.httpsServer, &http2.Server{})
+	} else {
+		// Disable HTTP/2 by setting TLSNextProto to empty map
+		s.httpsServer.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+	}
+
+	// Start server in a goroutine
+	go func() {
+		serverLog.Info("Starting HTTPS server on port %d", httpsPort)
+		// Use ServeTLS with empty strings since we provided TLSConfig
+		listener, err := listenWithLimit(s.httpsServer.Addr, limits.MaxConnections)
+		if err == nil {
+			err = s.httpsServer.ServeTLS(listener, "", "")
+		}
+		if err != nil && err != http.ErrServerClosed {
+			serverLog.Error("HTTPS server error: %v", err)
+		}
+		s.httpsStopChan <- struct{}{}
+	}()
+
+	s.startCertExpiryWatch()
+	return nil
+}
+
+// initInterceptionCerts creates the certificate cache that SOCKS5 and the HTTP proxy intercept TLS
+// with, when HTTPS is enabled in auto certificate mode. The cache stays nil otherwise, which turns
+// interception off.
+func (s *HTTPServer) initInterceptionCerts(httpsEnabled bool) {
+	s.configMutex.RLock()
+	certMode := s.config.CertMode
+	s.configMutex.RUnlock()
+
+	if !httpsEnabled || s.certManager == nil || (certMode != "" && certMode != models.CertModeAuto) {
+		return
+	}
+
+	// Load or generate CA certificate for signing domain certs
+	var caCert *x509.Certificate
+	var caKey *rsa.PrivateKey
+	var err error
+
+	if s.certManager.CAExists() {
+		caCert, caKey, err = s.certManager.LoadCA()
+	} else {
+		caCert, caKey, err = s.certManager.GenerateCA()
+	}
+
+	if err != nil {
+		serverLog.Warn("Failed to load/generate CA for TLS interception: %v", err)
+		return
+	}
+	// Create certificate cache with capacity for 100 domain certs
+	s.certCache = NewCertCache(s.certManager, caCert, caKey, 100)
+	serverLog.Info("TLS interception enabled (certificate cache initialized)")
+}
+
+// buildServingCertificate creates the HTTPS server certificate for the configured certificate mode
+func (s *HTTPServer) buildServingCertificate() (*tls.Certificate, error) {
 	if s.certManager == nil {
-		return fmt.Errorf("certificate manager not initialized")
+		return nil, fmt.Errorf("certificate manager not initialized")
 	}
 
 	// Thread-safe config access
 	s.configMutex.RLock()
-	httpsPort := s.config.HTTPSPort
 	certMode := s.config.CertMode
 	certPaths := s.config.CertPaths
 	certNames := s.config.CertNames
@@ -147,104 +294,64 @@ func (s *HTTPServer) StartHTTPS() error {
 		if s.certManager.CAExists() {
 			caCert, caPrivKey, err = s.certManager.LoadCA()
 			if err != nil {
-				log.Printf("Failed to load existing CA, generating new one: %v", err)
+				serverLog.Warn("Failed to load existing CA, generating new one: %v", err)
 				caCert, caPrivKey, err = s.certManager.GenerateCA()
 				if err != nil {
-					return fmt.Errorf("failed to generate CA: %w", err)
+					return nil, fmt.Errorf("failed to generate CA: %w", err)
 				}
 			}
 		} else {
 			caCert, caPrivKey, err = s.certManager.GenerateCA()
 			if err != nil {
-				return fmt.Errorf("failed to generate CA: %w", err)
+				return nil, fmt.Errorf("failed to generate CA: %w", err)
 			}
 		}
 
 		// Generate server certificate with custom or default names
 		certPEM, keyPEM, err = s.certManager.GenerateServerCert(caCert, caPrivKey, dnsNames, ipAddresses)
 		if err != nil {
-			return fmt.Errorf("failed to generate server certificate: %w", err)
+			return nil, fmt.Errorf("failed to generate server certificate: %w", err)
 		}
 
 	case models.CertModeCAProvided:
 		// User provides CA cert + key, we generate server cert
 		if certPaths.CACertPath == "" || certPaths.CAKeyPath == "" {
-			return fmt.Errorf("CA certificate and key paths are required for ca-provided mode")
+			return nil, fmt.Errorf("CA certificate and key paths are required for ca-provided mode")
 		}
 
 		caCert, caPrivKey, err := LoadUserCACert(certPaths.CACertPath, certPaths.CAKeyPath)
 		if err != nil {
-			return fmt.Errorf("failed to load user CA certificate: %w", err)
+			return nil, fmt.Errorf("failed to load user CA certificate: %w", err)
 		}
 
 		// Generate server certificate using user's CA with custom or default names
 		certPEM, keyPEM, err = s.certManager.GenerateServerCert(caCert, caPrivKey, dnsNames, ipAddresses)
 		if err != nil {
-			return fmt.Errorf("failed to generate server certificate with user CA: %w", err)
+			return nil, fmt.Errorf("failed to generate server certificate with user CA: %w", err)
 		}
 
 	case models.CertModeCertProvided:
 		// User provides server cert + key + optional bundle
 		if certPaths.ServerCertPath == "" || certPaths.ServerKeyPath == "" {
-			return fmt.Errorf("server certificate and key paths are required for cert-provided mode")
+			return nil, fmt.Errorf("server certificate and key paths are required for cert-provided mode")
 		}
 
 		certPEM, keyPEM, err = LoadUserServerCert(certPaths.ServerCertPath, certPaths.ServerKeyPath, certPaths.ServerBundlePath)
 		if err != nil {
-			return fmt.Errorf("failed to load user server certificate: %w", err)
+			return nil, fmt.Errorf("failed to load user server certificate: %w", err)
 		}
 
 	default:
-		return fmt.Errorf("unknown certificate mode: %s", certMode)
+		return nil, fmt.Errorf("unknown certificate mode: %s", certMode)
 	}
 
-	// Create TLS config from PEM-encoded cert and key
+	// Parse the PEM-encoded cert and key
 	cert, err := tls.X509KeyPair(certPEM, keyPEM)
 	if err != nil {
-		return fmt.Errorf("failed to load TLS certificate: %w", err)
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
 	}
 
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		MinVersion:   tls.VersionTLS12,
-	}
-
-	// Create response handler
-	responseHandler := NewResponseHandler(s.config, s.requestLogger, s.scriptErrorLogger, s.proxyHandler, s.containerHandler)
-
-	// Create HTTPS server
-	s.httpsServer = &http.Server{
-		Addr:         fmt.Sprintf(":%d", httpsPort),
-		Handler:      http.HandlerFunc(responseHandler.HandleRequest),
-		TLSConfig:    tlsConfig,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-	}
-
-	// Configure HTTP/2 support
-	s.configMutex.RLock()
-	http2Enabled := s.config.HTTP2Enabled
-	s.configMutex.RUnlock()
-
-	if http2Enabled {
-		// Enable HTTP/2 (default behavior, but explicit for clarity)
-		http2.ConfigureServer(s.httpsServer, &http2.Server{})
-	} else {
-		// Disable HTTP/2 by setting TLSNextProto to empty map
-		s.httpsServer.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
-	}
-
-	// Start server in a goroutine
-	go func() {
-		log.Printf("Starting HTTPS server on port %d", httpsPort)
-		// Use ListenAndServeTLS with empty strings since we provided TLSConfig
-		if err := s.httpsServer.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
-			log.Printf("HTTPS server error: %v", err)
-		}
-		s.httpsStopChan <- struct{}{}
-	}()
-
-	return nil
+	return &cert, nil
 }
 
 // Start starts both HTTP and HTTPS servers based on configuration
@@ -252,18 +359,27 @@ func (s *HTTPServer) Start() error {
 	s.configMutex.RLock()
 	httpsEnabled := s.config.HTTPSEnabled
 	endpoints := s.config.Endpoints
+	environment := s.config.ActiveEnvironment
+	variables := ConfigVariables(s.config)
+	profileErr := ValidateProfile(s.config)
 	s.configMutex.RUnlock()
 
+	if profileErr != nil {
+		return profileErr
+	}
+
 	// Create cancellable context for container startup (will be used when frontend calls StartContainers)
 	s.startupCtx, s.startupCancel = context.WithCancel(context.Background())
 
-	log.Printf("Server started. Waiting for frontend to signal readiness before starting containers...")
+	serverLog.Info("Server started. Waiting for frontend to signal readiness before starting containers...")
 
 	// Note: Containers will be started by explicit call to StartContainers() from frontend
 	// This prevents race condition where backend emits progress events before frontend is ready
 
 	// Start health checks for proxy endpoints
 	if s.proxyHandler != nil {
+		s.proxyHandler.SetActiveEnvironment(environment)
+		s.proxyHandler.SetVariables(variables)
 		var proxyEndpoints []*models.Endpoint
 		for i := range endpoints {
 			if endpoints[i].Type == models.EndpointTypeProxy {
@@ -281,52 +397,69 @@ func (s *HTTPServer) Start() error {
 	// Start HTTPS server if enabled
 	if httpsEnabled {
 		if err := s.StartHTTPS(); err != nil {
-			log.Printf("Failed to start HTTPS server: %v", err)
+			serverLog.Error("Failed to start HTTPS server: %v", err)
 			// Don't fail completely if HTTPS fails, HTTP is still running
 		}
 	}
 
+	// Start listeners for endpoints bound to their own port
+	s.SyncEndpointListeners()
+
+	// Start endpoints of plugin-provided types
+	s.plugins.sync(endpoints)
+
 	// Start SOCKS5 proxy if enabled
 	s.configMutex.RLock()
 	socks5Config := s.config.SOCKS5Config
+	httpProxyConfig := s.config.HTTPProxy
 	domainTakeover := s.config.DomainTakeover
-	certMode := s.config.CertMode
 	s.configMutex.RUnlock()
 
-	if socks5Config != nil && socks5Config.Enabled {
-		responseHandler := NewResponseHandler(s.config, s.requestLogger, s.scriptErrorLogger, s.proxyHandler, s.containerHandler)
-
-		// Initialize certificate cache for TLS interception if HTTPS is enabled
-		// This allows SOCKS5 to intercept HTTPS connections for domains in the takeover list
-		if httpsEnabled && s.certManager != nil && (certMode == "" || certMode == models.CertModeAuto) {
-			// Load or generate CA certificate for signing domain certs
-			var caCert *x509.Certificate
-			var caKey *rsa.PrivateKey
-			var err error
-
-			if s.certManager.CAExists() {
-				caCert, caKey, err = s.certManager.LoadCA()
-			} else {
-				caCert, caKey, err = s.certManager.GenerateCA()
-			}
+	// Both proxies intercept TLS for taken-over domains with certificates signed by the CA
+	if (socks5Config != nil && socks5Config.Enabled) || (httpProxyConfig != nil && httpProxyConfig.Enabled) {
+		s.initInterceptionCerts(httpsEnabled)
+	}
 
-			if err != nil {
-				log.Printf("Warning: Failed to load/generate CA for SOCKS5 TLS interception: %v", err)
-			} else {
-				// Create certificate cache with capacity for 100 domain certs
-				s.certCache = NewCertCache(s.certManager, caCert, caKey, 100)
-				log.Printf("SOCKS5 TLS interception enabled (certificate cache initialized)")
+	if socks5Config != nil && socks5Config.Enabled {
+		responseHandler := NewResponseHandler(s.config, s.requestLogger, s.scriptErrorLogger, s.proxyHandler, s.containerHandler, s.history, s.events, s.scheduler, s.perfStats, s.sequences, s.throttle)
+		s.socks5Server = NewSOCKS5Server(socks5Config, responseHandler, s.certCache, domainTakeover, s.requestLogger, s.bypassStats, s.traffic)
+		go func() {
+			if err := s.socks5Server.Start(); err != nil {
+				serverLog.Error("Failed to start SOCKS5 server: %v", err)
 			}
-		}
+		}()
+	}
 
-		s.socks5Server = NewSOCKS5Server(socks5Config, responseHandler, s.certCache, domainTakeover, s.requestLogger)
+	// Start HTTP forward proxy if enabled (it shares the SOCKS5 bypass rules and pass-through mode)
+	if httpProxyConfig != nil && httpProxyConfig.Enabled {
+		responseHandler := NewResponseHandler(s.config, s.requestLogger, s.scriptErrorLogger, s.proxyHandler, s.containerHandler, s.history, s.events, s.scheduler, s.perfStats, s.sequences, s.throttle)
+		s.httpProxyServer = NewHTTPProxyServer(httpProxyConfig, socks5Config, responseHandler, s.certCache, domainTakeover, s.requestLogger, s.bypassStats, s.traffic)
 		go func() {
-			if err := s.socks5Server.Start(); err != nil {
-				log.Printf("Failed to start SOCKS5 server: %v", err)
+			if err := s.httpProxyServer.Start(); err != nil {
+				serverLog.Error("Failed to start HTTP proxy: %v", err)
 			}
 		}()
 	}
 
+	// Schedule the config's timed actions relative to server start
+	s.configMutex.RLock()
+	scheduledActions := s.config.ScheduledActions
+	s.configMutex.RUnlock()
+	s.scheduler.Start(scheduledActions)
+
+	// Report traffic counters and bandwidth periodically
+	s.traffic.Start()
+
+	// Start gRPC listener if enabled (failures don't stop the HTTP server)
+	if err := s.StartGRPC(); err != nil {
+		serverLog.Error("Failed to start gRPC server: %v", err)
+	}
+
+	// Start DNS listener if enabled (failures don't stop the HTTP server)
+	if err := s.StartDNS(); err != nil {
+		serverLog.Error("Failed to start DNS server: %v", err)
+	}
+
 	// Start monitoring for any container endpoints in config
 	// This will detect and track any containers already running from previous sessions
 	s.EnsureContainerMonitoring()
@@ -344,12 +477,12 @@ func (s *HTTPServer) StopHTTP() error {
 	defer cancel()
 
 	if err := s.httpServer.Shutdown(ctx); err != nil {
-		log.Printf("HTTP server shutdown error: %v", err)
+		serverLog.Error("HTTP server shutdown error: %v", err)
 		return err
 	}
 
 	<-s.httpStopChan
-	log.Println("HTTP server stopped")
+	serverLog.Info("HTTP server stopped")
 	return nil
 }
 
@@ -363,12 +496,13 @@ func (s *HTTPServer) StopHTTPS() error {
 	defer cancel()
 
 	if err := s.httpsServer.Shutdown(ctx); err != nil {
-		log.Printf("HTTPS server shutdown error: %v", err)
+		serverLog.Error("HTTPS server shutdown error: %v", err)
 		return err
 	}
 
 	<-s.httpsStopChan
-	log.Println("HTTPS server stopped")
+	s.stopCertExpiryWatch()
+	serverLog.Info("HTTPS server stopped")
 	return nil
 }
 
@@ -376,7 +510,7 @@ func (s *HTTPServer) StopHTTPS() error {
 // This is called when server starts or config is loaded to monitor any already-running containers
 func (s *HTTPServer) EnsureContainerMonitoring() {
 	if s.containerHandler == nil {
-		log.Printf("WARNING: Container handler is nil")
+		serverLog.Warn("Container handler is nil")
 		return
 	}
 
@@ -410,7 +544,7 @@ func (s *HTTPServer) StartContainers() error {
 	s.configMutex.RUnlock()
 
 	if s.containerHandler == nil {
-		log.Printf("WARNING: Container handler not available")
+		serverLog.Warn("Container handler not available")
 		return nil
 	}
 
@@ -427,7 +561,7 @@ func (s *HTTPServer) StartContainers() error {
 					// Restart the container
 					// Stop first
 					if err := s.containerHandler.StopContainer(s.startupCtx, endpoint); err != nil {
-						log.Printf("Failed to stop container for endpoint %s: %v", endpoint.Name, err)
+						serverLog.Error("Failed to stop container for endpoint %s: %v", endpoint.Name, err)
 						// Check if cancelled
 						if s.startupCtx.Err() != nil {
 							return fmt.Errorf("startup cancelled: %w", s.startupCtx.Err())
@@ -438,7 +572,7 @@ func (s *HTTPServer) StartContainers() error {
 
 					// Then start
 					if err := s.containerHandler.StartContainer(s.startupCtx, endpoint); err != nil {
-						log.Printf("Failed to start container for endpoint %s: %v", endpoint.Name, err)
+						serverLog.Error("Failed to start container for endpoint %s: %v", endpoint.Name, err)
 						// Check if cancelled
 						if s.startupCtx.Err() != nil {
 							return fmt.Errorf("startup cancelled: %w", s.startupCtx.Err())
@@ -450,7 +584,7 @@ func (s *HTTPServer) StartContainers() error {
 			} else {
 				// Container is not running, start it normally
 				if err := s.containerHandler.StartContainer(s.startupCtx, endpoint); err != nil {
-					log.Printf("Failed to start container for endpoint %s: %v", endpoint.Name, err)
+					serverLog.Error("Failed to start container for endpoint %s: %v", endpoint.Name, err)
 					// Check if cancelled
 					if s.startupCtx.Err() != nil {
 						return fmt.Errorf("startup cancelled: %w", s.startupCtx.Err())
@@ -476,13 +610,26 @@ func (s *HTTPServer) StartContainers() error {
 func (s *HTTPServer) Stop() error {
 	var httpErr, httpsErr error
 
-	// Stop SOCKS5 server if running
+	// Stop SOCKS5 server and HTTP proxy if running
 	if s.socks5Server != nil {
 		if err := s.socks5Server.Stop(); err != nil {
-			log.Printf("Error stopping SOCKS5 server: %v", err)
+			serverLog.Error("Error stopping SOCKS5 server: %v", err)
+		}
+	}
+	if s.httpProxyServer != nil {
+		if err := s.httpProxyServer.Stop(); err != nil {
+			serverLog.Error("Error stopping HTTP proxy: %v", err)
 		}
 	}
 
+	// Stop gRPC and DNS servers if running
+	s.StopGRPC()
+	s.StopDNS()
+
+	// Drop the schedule and its effects
+	s.scheduler.Stop()
+	s.traffic.Stop()
+
 	// Stop containers before stopping servers
 	if s.containerHandler != nil {
 		// Stop polling goroutines first
@@ -496,12 +643,15 @@ func (s *HTTPServer) Stop() error {
 			endpoint := &endpoints[i]
 			if endpoint.Type == models.EndpointTypeContainer {
 				if err := s.containerHandler.StopContainer(context.Background(), endpoint); err != nil {
-					log.Printf("Error stopping container for endpoint %s: %v", endpoint.Name, err)
+					serverLog.Error("Error stopping container for endpoint %s: %v", endpoint.Name, err)
 				}
 			}
 		}
 	}
 
+	s.StopEndpointListeners()
+	s.plugins.stopAll()
+
 	// Stop HTTP server if running
 	if s.httpServer != nil {
 		httpErr = s.StopHTTP()
@@ -520,16 +670,45 @@ func (s *HTTPServer) Stop() error {
 		return httpsErr
 	}
 
-	log.Println("All servers stopped")
+	serverLog.Info("All servers stopped")
+	return nil
+}
+
+// ReloadCertificates rebuilds the HTTPS certificate from the current CA and certificate settings and
+// swaps it in without restarting: in-flight requests and WebSockets keep their connections, and new
+// handshakes get the new certificate. Certificates for SOCKS5 interception are re-signed on demand.
+func (s *HTTPServer) ReloadCertificates() error {
+	if s.servingCert.Load() != nil {
+		cert, err := s.buildServingCertificate()
+		if err != nil {
+			return err
+		}
+		s.servingCert.Store(cert)
+		serverLog.Info("HTTPS certificate reloaded")
+		s.reloadSNICerts()
+		if err := s.reloadClientAuth(); err != nil {
+			return err
+		}
+	}
+
+	if s.certCache != nil && s.certManager != nil && s.certManager.CAExists() {
+		caCert, caKey, err := s.certManager.LoadCA()
+		if err != nil {
+			return fmt.Errorf("failed to load CA for SOCKS5 interception: %w", err)
+		}
+		s.certCache.Rotate(caCert, caKey)
+	}
+
+	s.checkCertificateExpiry()
 	return nil
 }
 
-// RestartHTTPS restarts the HTTPS server (used after CA regeneration)
+// RestartHTTPS restarts the HTTPS server
 func (s *HTTPServer) RestartHTTPS() error {
 	// Stop HTTPS server if running
 	if s.httpsServer != nil {
 		if err := s.StopHTTPS(); err != nil {
-			log.Printf("Error stopping HTTPS server: %v", err)
+			serverLog.Error("Error stopping HTTPS server: %v", err)
 		}
 		// Reset the stop channel
 		s.httpsStopChan = make(chan struct{})
@@ -539,10 +718,170 @@ func (s *HTTPServer) RestartHTTPS() error {
 	return s.StartHTTPS()
 }
 
-func (s *HTTPServer) UpdateConfig(newConfig *models.AppConfig) {
+// StartGRPC starts the gRPC listener when it is enabled in the config
+func (s *HTTPServer) StartGRPC() error {
+	s.configMutex.RLock()
+	grpcConfig := s.config.GRPC
+	s.configMutex.RUnlock()
+
+	if grpcConfig == nil || !grpcConfig.Enabled {
+		return nil
+	}
+
+	grpcServer, err := NewGRPCServer(grpcConfig, s.requestLogger, s.scriptErrorLogger, s.events)
+	if err != nil {
+		return err
+	}
+	if err := grpcServer.Start(); err != nil {
+		return err
+	}
+	s.grpcServer = grpcServer
+	return nil
+}
+
+// StopGRPC stops the gRPC listener if it is running
+func (s *HTTPServer) StopGRPC() {
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+		s.grpcServer = nil
+	}
+}
+
+// RestartGRPC reloads the .proto files and restarts the gRPC listener
+func (s *HTTPServer) RestartGRPC() error {
+	s.StopGRPC()
+	return s.StartGRPC()
+}
+
+// StartDNS starts the DNS listener when it is enabled in the config
+func (s *HTTPServer) StartDNS() error {
+	s.configMutex.RLock()
+	dnsConfig := s.config.DNS
+	domainTakeover := s.config.DomainTakeover
+	s.configMutex.RUnlock()
+
+	if dnsConfig == nil || !dnsConfig.Enabled {
+		return nil
+	}
+
+	dnsServer := NewDNSServer(dnsConfig, domainTakeover)
+	if err := dnsServer.Start(); err != nil {
+		return err
+	}
+	s.dnsServer = dnsServer
+	return nil
+}
+
+// StopDNS stops the DNS listener if it is running
+func (s *HTTPServer) StopDNS() {
+	if s.dnsServer != nil {
+		s.dnsServer.Stop()
+		s.dnsServer = nil
+	}
+}
+
+// RestartDNS restarts the DNS listener (needed for port and enabled changes)
+func (s *HTTPServer) RestartDNS() error {
+	s.StopDNS()
+	return s.StartDNS()
+}
+
+// UpdateConfig makes newConfig the served config. A config with patterns that do not compile is
+// refused: the server keeps serving the previous config and the patterns are returned as a
+// *PatternValidationError.
+func (s *HTTPServer) UpdateConfig(newConfig *models.AppConfig) error {
+	patternErrs := ValidatePatterns(newConfig)
+
 	s.configMutex.Lock()
-	defer s.configMutex.Unlock()
+	s.reportInvalidPatterns(patternErrs)
+	if len(patternErrs) > 0 {
+		s.configMutex.Unlock()
+		return &PatternValidationError{Errors: patternErrs}
+	}
 	s.config = newConfig
+	if s.proxyHandler != nil {
+		s.proxyHandler.SetActiveEnvironment(newConfig.ActiveEnvironment)
+		s.proxyHandler.SetVariables(ConfigVariables(newConfig))
+		s.proxyHandler.SyncTransports(newConfig.Endpoints)
+	}
+	if s.grpcServer != nil {
+		s.grpcServer.UpdateConfig(newConfig.GRPC)
+	}
+	if s.dnsServer != nil {
+		s.dnsServer.UpdateConfig(newConfig.DNS, newConfig.DomainTakeover)
+	}
+	s.configMutex.Unlock()
+
+	pruneModuleCache(newConfig.ScriptModules)
+
+	// Endpoint ports may have been added, changed or removed
+	s.SyncEndpointListeners()
+	s.plugins.sync(newConfig.Endpoints)
+	return nil
+}
+
+// reportInvalidPatterns logs the invalid patterns of a refused config once, not on every update (caller holds configMutex)
+func (s *HTTPServer) reportInvalidPatterns(errs []models.PatternError) {
+	parts := make([]string, 0, len(errs))
+	for _, pe := range errs {
+		parts = append(parts, pe.String())
+	}
+	reported := strings.Join(parts, "\n")
+	if reported == s.invalidPatterns {
+		return
+	}
+	s.invalidPatterns = reported
+	for _, part := range parts {
+		serverLog.Warn("Config refused, invalid pattern: %s", part)
+	}
+}
+
+// ClearRequestHistory forgets the requests exposed to response scripts through the history API
+func (s *HTTPServer) ClearRequestHistory() {
+	s.history.Clear()
+}
+
+// PublishEvent publishes an event on the script event bus and returns how many streams received it
+func (s *HTTPServer) PublishEvent(name string, payload interface{}) int {
+	return s.events.Publish(name, payload)
+}
+
+// ResetSequences moves sequence responses back to their first step (all of them if responseID is empty)
+func (s *HTTPServer) ResetSequences(responseID string) {
+	s.sequences.Reset(responseID)
+}
+
+// PerfStats returns the template/script execution statistics
+func (s *HTTPServer) PerfStats() *PerfStats {
+	return s.perfStats
+}
+
+// BypassStats returns the connection counters of the SOCKS5 bypass rules
+func (s *HTTPServer) BypassStats() *BypassStats {
+	return s.bypassStats
+}
+
+// Traffic returns the byte counters of SOCKS5 tunnels and proxy endpoints
+func (s *HTTPServer) Traffic() *TrafficMeter {
+	return s.traffic
+}
+
+// ClientThrottle returns the token buckets and counters of client throttle rules
+func (s *HTTPServer) ClientThrottle() *ClientThrottle {
+	return s.throttle
+}
+
+// ClientThrottleStats returns the counters of every client the throttle rules track
+func (s *HTTPServer) ClientThrottleStats() []models.ClientThrottleStats {
+	s.configMutex.RLock()
+	rules := s.config.ClientThrottles
+	s.configMutex.RUnlock()
+	return s.throttle.Snapshot(rules)
+}
+
+// Scheduler returns the schedule of timed actions
+func (s *HTTPServer) Scheduler() *Scheduler {
+	return s.scheduler
 }
 
 // GetProxyHealthStatus returns the health status for a proxy endpoint
@@ -582,7 +921,20 @@ func (s *HTTPServer) GetContainerLogs(ctx context.Context, endpointID string, ta
 	if s.containerHandler == nil {
 		return "", fmt.Errorf("container handler not available")
 	}
-	return s.containerHandler.GetContainerLogs(ctx, endpointID, tail)
+
+	s.configMutex.RLock()
+	var endpoint *models.Endpoint
+	for i := range s.config.Endpoints {
+		if s.config.Endpoints[i].ID == endpointID {
+			endpoint = &s.config.Endpoints[i]
+			break
+		}
+	}
+	s.configMutex.RUnlock()
+	if endpoint == nil {
+		return "", fmt.Errorf("endpoint not found: %s", endpointID)
+	}
+	return s.containerHandler.GetContainerLogs(ctx, endpoint, tail)
 }
 
 // StartSingleContainer starts a single container endpoint
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"mockelot/models"
+)
+
+const (
+	slaRetention     = 7 * 24 * time.Hour // Samples older than this are discarded
+	maxSLASamples    = 50000              // Per endpoint and sample kind
+	slaSuccessCutoff = 500                // Backend status codes below this count as successful
+)
+
+// slaSample is a single health check or proxied request outcome
+type slaSample struct {
+	at        time.Time
+	ok        bool
+	latencyMs int64
+}
+
+// slaTracker keeps recent health check and request outcomes per proxy endpoint
+type slaTracker struct {
+	mutex    sync.Mutex
+	health   map[string][]slaSample
+	requests map[string][]slaSample
+}
+
+func newSLATracker() *slaTracker {
+	return &slaTracker{
+		health:   make(map[string][]slaSample),
+		requests: make(map[string][]slaSample),
+	}
+}
+
+// recordHealth records a health check result
+func (t *slaTracker) recordHealth(endpointID string, healthy bool, latencyMs int64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.health[endpointID] = appendSLASample(t.health[endpointID], slaSample{at: time.Now(), ok: healthy, latencyMs: latencyMs})
+}
+
+// recordRequest records a proxied request outcome
+func (t *slaTracker) recordRequest(endpointID string, ok bool, latencyMs int64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.requests[endpointID] = appendSLASample(t.requests[endpointID], slaSample{at: time.Now(), ok: ok, latencyMs: latencyMs})
+}
+
+// appendSLASample appends a sample, dropping expired samples and enforcing the size cap. Dropped
+// samples are sliced off rather than copied; append moves the remaining samples to a new array
+// once the capacity runs out, so recording stays amortized O(1).
+func appendSLASample(samples []slaSample, sample slaSample) []slaSample {
+	samples = append(samples, sample)
+
+	cutoff := time.Now().Add(-slaRetention)
+	drop := 0
+	for drop < len(samples) && samples[drop].at.Before(cutoff) {
+		drop++
+	}
+	if over := len(samples) - drop - maxSLASamples; over > 0 {
+		drop += over
+	}
+	return samples[drop:]
+}
+
+// report computes availability and latency stats for an endpoint over the window ending now
+func (t *slaTracker) report(endpointID string, window time.Duration) *models.BackendSLA {
+	now := time.Now()
+	from := now.Add(-window)
+
+	t.mutex.Lock()
+	health := samplesSince(t.health[endpointID], from)
+	requests := samplesSince(t.requests[endpointID], from)
+	t.mutex.Unlock()
+
+	report := &models.BackendSLA{
+		EndpointID: endpointID,
+		Window:     window.String(),
+		From:       from.Format(time.RFC3339),
+		To:         now.Format(time.RFC3339),
+	}
+
+	for _, s := range health {
+		report.HealthChecks++
+		if s.ok {
+			report.HealthyChecks++
+		}
+	}
+	if report.HealthChecks > 0 {
+		report.UptimePercent = percent(report.HealthyChecks, report.HealthChecks)
+	}
+
+	latencies := make([]int64, 0, len(requests))
+	var total int64
+	for _, s := range requests {
+		report.Requests++
+		if s.ok {
+			report.SuccessfulRequests++
+		}
+		latencies = append(latencies, s.latencyMs)
+		total += s.latencyMs
+	}
+	report.FailedRequests = report.Requests - report.SuccessfulRequests
+
+	if report.Requests > 0 {
+		report.SuccessRatePercent = percent(report.SuccessfulRequests, report.Requests)
+
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		report.AvgLatencyMs = total / int64(len(latencies))
+		report.P50LatencyMs = percentile(latencies, 50)
+		report.P95LatencyMs = percentile(latencies, 95)
+		report.P99LatencyMs = percentile(latencies, 99)
+		report.MaxLatencyMs = latencies[len(latencies)-1]
+	}
+
+	return report
+}
+
+// samplesSince returns a copy of the samples at or after from
+func samplesSince(samples []slaSample, from time.Time) []slaSample {
+	start := sort.Search(len(samples), func(i int) bool { return !samples[i].at.Before(from) })
+	return append([]slaSample(nil), samples[start:]...)
+}
+
+// percent returns part/whole as a percentage rounded to two decimals
+func percent(part, whole int) float64 {
+	return float64(part*10000/whole) / 100
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// GetBackendSLA returns availability and latency stats for a proxy endpoint over a time window
+func (p *ProxyHandler) GetBackendSLA(endpointID string, window time.Duration) *models.BackendSLA {
+	return p.sla.report(endpointID, window)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"crypto/tls"
+	"strings"
+
+	"mockelot/models"
+)
+
+// sniCacheSize is how many per-domain certificates the HTTPS listener keeps
+const sniCacheSize = 100
+
+// reloadSNICerts starts a new cache of per-domain certificates, signed by the CA of the configured
+// certificate mode. Cert-provided mode has no CA key, so every domain gets the provided certificate.
+func (s *HTTPServer) reloadSNICerts() {
+	s.configMutex.RLock()
+	certMode := s.config.CertMode
+	caCert, caKey, err := configuredCAKeyPair(s.config)
+	s.configMutex.RUnlock()
+
+	if certMode == models.CertModeCertProvided {
+		s.sniCerts.Store(nil)
+		return
+	}
+	if err != nil {
+		serverLog.Warn("Per-domain HTTPS certificates disabled, serving one certificate to every domain: %v", err)
+		s.sniCerts.Store(nil)
+		return
+	}
+	s.sniCerts.Store(NewCertCache(s.certManager, caCert, caKey, sniCacheSize))
+}
+
+// httpsCertificate picks the certificate of an HTTPS handshake. A server name (SNI) in the domain
+// takeover list gets a certificate minted for that name, so every intercepted domain sees a
+// matching certificate; other names, and clients that send none, get the serving certificate.
+func (s *HTTPServer) httpsCertificate(hello *tls.ClientHelloInfo, handler *ResponseHandler) (*tls.Certificate, error) {
+	serverName := strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))
+	certs := s.sniCerts.Load()
+	if serverName == "" || certs == nil || !handler.matchesTakeoverDomain(serverName) {
+		return s.servingCert.Load(), nil
+	}
+
+	cert, err := certs.GetOrCreate(serverName)
+	if err != nil {
+		serverLog.Warn("Failed to create a certificate for %s, serving the default one: %v", serverName, err)
+		return s.servingCert.Load(), nil
+	}
+	return cert, nil
+}
@@ -4,12 +4,13 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/binary"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +18,9 @@ import (
 	"mockelot/models"
 )
 
+// socks5EndpointID is the system endpoint that lists SOCKS5 connection logs
+const socks5EndpointID = "system-socks5-proxy"
+
 // SOCKS5 Protocol Constants
 const (
 	socks5Version = 0x05
@@ -54,6 +58,9 @@ type SOCKS5Server struct {
 	tlsInterceptor  *TLSInterceptor             // TLS interception for HTTPS connections
 	domainTakeover  *models.DomainTakeoverConfig // Domain takeover config for intercept decisions
 	requestLogger   RequestLogger                // For logging SOCKS5 requests (observational)
+	bypassStats     *BypassStats                 // Connections tunneled by each bypass rule
+	traffic         *TrafficMeter                // Bytes carried per tunnel destination
+	viaHTTPProxy    bool                         // Tunnels of the HTTP proxy's CONNECT requests (marked so in logs)
 	ctx             context.Context
 	cancel          context.CancelFunc
 	wg              sync.WaitGroup
@@ -68,13 +75,15 @@ type SOCKS5Server struct {
 //   - certCache: Certificate cache for TLS interception (nil disables TLS interception)
 //   - domainTakeover: Domain takeover config to determine which domains to intercept
 //   - logger: RequestLogger for logging SOCKS5 requests (observational only)
-func NewSOCKS5Server(config *models.SOCKS5Config, handler *ResponseHandler, certCache *CertCache, domainTakeover *models.DomainTakeoverConfig, logger RequestLogger) *SOCKS5Server {
+//   - bypassStats: Counters for connections tunneled by bypass rules
+//   - traffic: Byte counters per tunnel destination
+func NewSOCKS5Server(config *models.SOCKS5Config, handler *ResponseHandler, certCache *CertCache, domainTakeover *models.DomainTakeoverConfig, logger RequestLogger, bypassStats *BypassStats, traffic *TrafficMeter) *SOCKS5Server {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	var tlsInterceptor *TLSInterceptor
 	if certCache != nil {
 		tlsInterceptor = NewTLSInterceptor(certCache)
-		log.Println("SOCKS5 TLS interception enabled")
+		socks5Log.Info("SOCKS5 TLS interception enabled")
 	}
 
 	return &SOCKS5Server{
@@ -83,6 +92,8 @@ func NewSOCKS5Server(config *models.SOCKS5Config, handler *ResponseHandler, cert
 		tlsInterceptor:  tlsInterceptor,
 		domainTakeover:  domainTakeover,
 		requestLogger:   logger,
+		bypassStats:     bypassStats,
+		traffic:         traffic,
 		ctx:             ctx,
 		cancel:          cancel,
 	}
@@ -107,7 +118,7 @@ func (s *SOCKS5Server) Start() error {
 	s.running = true
 	s.mu.Unlock()
 
-	log.Printf("SOCKS5 server listening on %s", addr)
+	socks5Log.Info("SOCKS5 server listening on %s", addr)
 
 	// Accept connections
 	for {
@@ -117,7 +128,7 @@ func (s *SOCKS5Server) Start() error {
 			case <-s.ctx.Done():
 				return nil
 			default:
-				log.Printf("SOCKS5 accept error: %v", err)
+				socks5Log.Error("SOCKS5 accept error: %v", err)
 				continue
 			}
 		}
@@ -140,7 +151,7 @@ func (s *SOCKS5Server) Stop() error {
 	s.running = false
 	s.mu.Unlock()
 
-	log.Println("Stopping SOCKS5 server...")
+	socks5Log.Info("Stopping SOCKS5 server...")
 	s.cancel()
 
 	if s.listener != nil {
@@ -156,9 +167,9 @@ func (s *SOCKS5Server) Stop() error {
 
 	select {
 	case <-done:
-		log.Println("SOCKS5 server stopped")
+		socks5Log.Info("SOCKS5 server stopped")
 	case <-time.After(5 * time.Second):
-		log.Println("SOCKS5 server stopped (timeout)")
+		socks5Log.Info("SOCKS5 server stopped (timeout)")
 	}
 
 	return nil
@@ -168,20 +179,27 @@ func (s *SOCKS5Server) Stop() error {
 func (s *SOCKS5Server) handleConnection(conn net.Conn) {
 	defer conn.Close()
 
+	// Clients outside the allow-list are dropped before the handshake
+	if !s.clientAllowed(conn.RemoteAddr()) {
+		socks5Log.Warn("SOCKS5 connection from %s refused: not in allowed_clients", conn.RemoteAddr())
+		return
+	}
+
 	// Set read deadline for handshake
 	conn.SetReadDeadline(time.Now().Add(30 * time.Second))
 
 	// 1. Version identification/method selection
 	authMethod, err := s.handleHandshake(conn)
 	if err != nil {
-		log.Printf("SOCKS5 handshake failed: %v", err)
+		socks5Log.Error("SOCKS5 handshake failed: %v", err)
 		return
 	}
 
 	// 2. Authentication (if required)
+	var proxyUser string
 	if authMethod == authMethodUserPassword {
-		if err := s.handleAuthentication(conn); err != nil {
-			log.Printf("SOCKS5 authentication failed: %v", err)
+		if proxyUser, err = s.handleAuthentication(conn); err != nil {
+			socks5Log.Error("SOCKS5 authentication failed from %s: %v", conn.RemoteAddr(), err)
 			return
 		}
 	}
@@ -189,17 +207,18 @@ func (s *SOCKS5Server) handleConnection(conn net.Conn) {
 	// 3. Request (CONNECT command)
 	targetAddr, targetPort, err := s.handleRequest(conn)
 	if err != nil {
-		log.Printf("SOCKS5 request failed: %v", err)
+		socks5Log.Error("SOCKS5 request failed: %v", err)
 		return
 	}
 
 	// Reset read deadline after handshake
 	conn.SetReadDeadline(time.Time{})
 
-	log.Printf("SOCKS5 connection established to %s:%d", targetAddr, targetPort)
+	socks5Log.Debug("SOCKS5 connection established to %s:%d", targetAddr, targetPort)
 
-	// 4. Tunnel HTTP traffic
-	s.handleTunnel(conn, targetAddr, targetPort)
+	// 4. Tunnel HTTP traffic (bytes count toward the destination)
+	conn = s.traffic.meterTunnel(conn, fmt.Sprintf("%s:%d", targetAddr, targetPort))
+	s.handleTunnel(conn, targetAddr, targetPort, proxyUser)
 }
 
 // handleHandshake performs SOCKS5 version identification and method selection
@@ -267,7 +286,8 @@ func (s *SOCKS5Server) handleHandshake(conn net.Conn) (byte, error) {
 }
 
 // handleAuthentication performs username/password authentication
-func (s *SOCKS5Server) handleAuthentication(conn net.Conn) error {
+// Returns the username the client logged in with.
+func (s *SOCKS5Server) handleAuthentication(conn net.Conn) (string, error) {
 	// Read authentication request
 	// +----+------+----------+------+----------+
 	// |VER | ULEN |  UNAME   | PLEN |  PASSWD  |
@@ -277,35 +297,35 @@ func (s *SOCKS5Server) handleAuthentication(conn net.Conn) error {
 
 	buf := make([]byte, 2)
 	if _, err := io.ReadFull(conn, buf); err != nil {
-		return fmt.Errorf("read auth version: %w", err)
+		return "", fmt.Errorf("read auth version: %w", err)
 	}
 
 	version := buf[0]
 	if version != 0x01 {
-		return fmt.Errorf("unsupported auth version: %d", version)
+		return "", fmt.Errorf("unsupported auth version: %d", version)
 	}
 
 	// Read username
 	uLen := buf[1]
 	username := make([]byte, uLen)
 	if _, err := io.ReadFull(conn, username); err != nil {
-		return fmt.Errorf("read username: %w", err)
+		return "", fmt.Errorf("read username: %w", err)
 	}
 
 	// Read password length
 	if _, err := io.ReadFull(conn, buf[:1]); err != nil {
-		return fmt.Errorf("read password length: %w", err)
+		return "", fmt.Errorf("read password length: %w", err)
 	}
 	pLen := buf[0]
 
 	// Read password
 	password := make([]byte, pLen)
 	if _, err := io.ReadFull(conn, password); err != nil {
-		return fmt.Errorf("read password: %w", err)
+		return "", fmt.Errorf("read password: %w", err)
 	}
 
 	// Verify credentials
-	success := string(username) == s.config.Username && string(password) == s.config.Password
+	success := s.credentialsValid(string(username), string(password))
 
 	// Send authentication response
 	// +----+--------+
@@ -319,14 +339,14 @@ func (s *SOCKS5Server) handleAuthentication(conn net.Conn) error {
 	}
 
 	if _, err := conn.Write([]byte{0x01, status}); err != nil {
-		return fmt.Errorf("write auth response: %w", err)
+		return "", fmt.Errorf("write auth response: %w", err)
 	}
 
 	if !success {
-		return fmt.Errorf("authentication failed")
+		return "", fmt.Errorf("authentication failed for user %q", username)
 	}
 
-	return nil
+	return string(username), nil
 }
 
 // handleRequest processes the SOCKS5 request (CONNECT command)
@@ -436,11 +456,16 @@ func (s *SOCKS5Server) sendReply(conn net.Conn, rep byte) error {
 // shouldIntercept checks if a domain should be intercepted based on domain takeover config
 // Returns true if the domain matches any enabled domain in the takeover list
 func (s *SOCKS5Server) shouldIntercept(domain string) bool {
-	if s.domainTakeover == nil {
+	return domainTakenOver(s.domainTakeover, domain)
+}
+
+// domainTakenOver reports whether a domain is in the enabled part of the takeover list
+func domainTakenOver(domainTakeover *models.DomainTakeoverConfig, domain string) bool {
+	if domainTakeover == nil {
 		return false
 	}
 
-	for _, domainConfig := range s.domainTakeover.Domains {
+	for _, domainConfig := range domainTakeover.Domains {
 		if !domainConfig.Enabled {
 			continue
 		}
@@ -456,60 +481,78 @@ func (s *SOCKS5Server) shouldIntercept(domain string) bool {
 }
 
 // handleTunnel processes HTTP/HTTPS requests through the SOCKS5 tunnel
+// Destinations matching a bypass rule are always passed through to the real server.
 // For HTTPS (port 443):
 //   - If domain is in takeover list: TLS intercept → ResponseHandler
 //   - If domain NOT in takeover list: Pass-through to real server
-func (s *SOCKS5Server) handleTunnel(conn net.Conn, targetAddr string, targetPort uint16) {
+// With transparent pass-through, domains outside the takeover list are passed through on every port.
+func (s *SOCKS5Server) handleTunnel(conn net.Conn, targetAddr string, targetPort uint16, proxyUser string) {
+	if rule := matchBypassRule(s.config.BypassRules, targetAddr, int(targetPort)); rule != nil {
+		socks5Log.Debug("SOCKS5 bypass: %s:%d matches rule %s", targetAddr, targetPort, bypassDescription(rule))
+		s.handlePassthrough(conn, targetAddr, targetPort, rule, proxyUser)
+		return
+	}
+
 	isHTTPS := targetPort == 443
+	intercept := s.shouldIntercept(targetAddr)
+
+	// Transparent pass-through: only taken-over domains are served locally, on any port
+	if !intercept && s.config.TransparentPassThrough {
+		s.handlePassthrough(conn, targetAddr, targetPort, nil, proxyUser)
+		return
+	}
 
 	// For HTTPS connections, decide: intercept or pass-through
 	if isHTTPS {
-		if s.shouldIntercept(targetAddr) && s.tlsInterceptor != nil {
+		if intercept && s.tlsInterceptor != nil {
 			// Domain is in takeover list - TLS intercept and handle with ResponseHandler
-			s.handleInterceptedHTTPS(conn, targetAddr, targetPort)
+			s.handleInterceptedHTTPS(conn, targetAddr, targetPort, proxyUser)
 		} else {
 			// Domain NOT in takeover list - pass-through to real server
-			s.handlePassthrough(conn, targetAddr, targetPort)
+			s.handlePassthrough(conn, targetAddr, targetPort, nil, proxyUser)
 		}
 		return
 	}
 
 	// For HTTP connections, handle directly with ResponseHandler
-	s.handleHTTP(conn, targetAddr, targetPort)
+	s.handleHTTP(conn, targetAddr, targetPort, proxyUser)
 }
 
 // handleInterceptedHTTPS performs TLS interception for domains in the takeover list
 // Performs TLS handshake with client, then reads decrypted HTTP requests
-func (s *SOCKS5Server) handleInterceptedHTTPS(conn net.Conn, targetAddr string, targetPort uint16) {
+func (s *SOCKS5Server) handleInterceptedHTTPS(conn net.Conn, targetAddr string, targetPort uint16, proxyUser string) {
 	// Perform TLS handshake with the client
 	tlsConn, err := s.tlsInterceptor.Intercept(conn, targetAddr)
 	if err != nil {
-		log.Printf("SOCKS5 TLS interception failed for %s: %v", targetAddr, err)
+		socks5Log.Error("SOCKS5 TLS interception failed for %s: %v", targetAddr, err)
 		// Fall back to pass-through on TLS error
 		// Note: Connection may be in bad state, so this might fail
 		return
 	}
 	defer tlsConn.Close()
 
-	log.Printf("SOCKS5 TLS intercepted: %s:%d", targetAddr, targetPort)
+	socks5Log.Debug("SOCKS5 TLS intercepted: %s:%d", targetAddr, targetPort)
 
 	// Log intercepted HTTPS connection (connection-level only)
-	// Individual HTTP requests are logged by the overlay endpoint handler
+	// Individual HTTP requests are logged by the endpoint or overlay handler that serves them
 	if s.requestLogger != nil {
 		requestLog := models.RequestLog{
 			ID:         fmt.Sprintf("%d", time.Now().UnixNano()),
-			Timestamp:  time.Now().Format(time.RFC3339),
-			EndpointID: "system-socks5-proxy",
+			Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
+			EndpointID: socks5EndpointID,
 			SOCKS5Info: &models.SOCKS5RequestInfo{
 				TargetHost:    targetAddr,
 				TargetPort:    int(targetPort),
 				Protocol:      "HTTPS",
 				IsIntercepted: true,
+				ViaHTTPProxy:  s.viaHTTPProxy,
+				ProxyUser:     proxyUser,
 			},
 		}
 		requestLog.ClientRequest.Method = "CONNECT"
 		requestLog.ClientRequest.FullURL = fmt.Sprintf("https://%s:%d", targetAddr, targetPort)
 		requestLog.ClientRequest.Path = fmt.Sprintf("%s:%d", targetAddr, targetPort)
+		requestLog.ClientRequest.SourceIP = conn.RemoteAddr().String()
 		s.requestLogger.LogRequest(requestLog)
 	}
 
@@ -521,7 +564,7 @@ func (s *SOCKS5Server) handleInterceptedHTTPS(conn net.Conn, targetAddr string,
 		req, err := http.ReadRequest(reader)
 		if err != nil {
 			if err != io.EOF && !strings.Contains(err.Error(), "use of closed network connection") {
-				log.Printf("SOCKS5 read request error (intercepted): %v", err)
+				socks5Log.Error("SOCKS5 read request error (intercepted): %v", err)
 			}
 			return
 		}
@@ -530,20 +573,39 @@ func (s *SOCKS5Server) handleInterceptedHTTPS(conn net.Conn, targetAddr string,
 		req.URL.Scheme = "https"
 		req.URL.Host = fmt.Sprintf("%s:%d", targetAddr, targetPort)
 
+		// Tag with the SOCKS5 client address so logs can be grouped by connection
+		req.RemoteAddr = conn.RemoteAddr().String()
+
+		// The request arrived over TLS, so handlers and logs see it as HTTPS
+		if state, ok := tlsConn.(*tls.Conn); ok {
+			connState := state.ConnectionState()
+			req.TLS = &connState
+		}
+
 		// Ensure Host header is set
 		if req.Host == "" {
 			req.Host = targetAddr
 		}
 
+		// Mark the request as intercepted so its log entries record the original destination
+		req = withSOCKS5Info(req, &models.SOCKS5RequestInfo{
+			TargetHost:    targetAddr,
+			TargetPort:    int(targetPort),
+			Protocol:      "HTTPS",
+			IsIntercepted: true,
+			ViaHTTPProxy:  s.viaHTTPProxy,
+			ProxyUser:     proxyUser,
+		})
+
 		// Create a response recorder to capture the response
 		rec := newResponseRecorder()
 
-		// Pass request to ResponseHandler
+		// Pass request to ResponseHandler (which logs it like any other request)
 		s.responseHandler.HandleRequest(rec, req)
 
 		// Write response back through TLS tunnel
 		if err := s.writeResponse(tlsConn, rec); err != nil {
-			log.Printf("SOCKS5 write response error (intercepted): %v", err)
+			socks5Log.Error("SOCKS5 write response error (intercepted): %v", err)
 			return
 		}
 
@@ -555,46 +617,43 @@ func (s *SOCKS5Server) handleInterceptedHTTPS(conn net.Conn, targetAddr string,
 }
 
 // handlePassthrough connects to the real server and forwards raw bytes
-// Used for domains NOT in the takeover list (Option A - pass-through mode)
-func (s *SOCKS5Server) handlePassthrough(conn net.Conn, targetAddr string, targetPort uint16) {
+// Used for domains NOT in the takeover list (Option A - pass-through mode) and for destinations
+// matching a bypass rule (rule is nil otherwise)
+func (s *SOCKS5Server) handlePassthrough(conn net.Conn, targetAddr string, targetPort uint16, rule *models.BypassRule, proxyUser string) {
 	// Connect to the real destination
-	destAddr := fmt.Sprintf("%s:%d", targetAddr, targetPort)
+	destAddr := net.JoinHostPort(targetAddr, strconv.Itoa(int(targetPort)))
+	if rule != nil && s.bypassStats != nil {
+		s.bypassStats.recordConnection(rule.ID, destAddr)
+	}
+
+	protocol := "PASS-THROUGH"
+	if rule != nil {
+		protocol = "BYPASS"
+	} else {
+		socks5Log.Debug("SOCKS5 pass-through: %s (not in takeover list)", destAddr)
+	}
+
+	// Log the tunnel as it opens; the entry gets its traffic summary when the tunnel closes
+	start := time.Now()
+	requestLog := s.logPassthrough(conn, targetAddr, targetPort, protocol, proxyUser)
+
 	destConn, err := net.DialTimeout("tcp", destAddr, 30*time.Second)
 	if err != nil {
-		log.Printf("SOCKS5 pass-through: failed to connect to %s: %v", destAddr, err)
+		socks5Log.Error("SOCKS5 pass-through: failed to connect to %s: %v", destAddr, err)
+		s.finishPassthroughLog(requestLog, start, 0, 0, err)
 		return
 	}
 	defer destConn.Close()
 
-	log.Printf("SOCKS5 pass-through: %s (not in takeover list)", destAddr)
-
-	// Log pass-through connection (metadata only, no bodies)
-	if s.requestLogger != nil {
-		requestLog := models.RequestLog{
-			ID:         fmt.Sprintf("%d", time.Now().UnixNano()),
-			Timestamp:  time.Now().Format(time.RFC3339),
-			EndpointID: "system-socks5-proxy",
-			SOCKS5Info: &models.SOCKS5RequestInfo{
-				TargetHost:    targetAddr,
-				TargetPort:    int(targetPort),
-				Protocol:      "PASS-THROUGH",
-				IsIntercepted: false,
-			},
-		}
-		requestLog.ClientRequest.Method = "CONNECT"
-		requestLog.ClientRequest.FullURL = fmt.Sprintf("%s:%d", targetAddr, targetPort)
-		requestLog.ClientRequest.Path = fmt.Sprintf("%s:%d", targetAddr, targetPort)
-		s.requestLogger.LogRequest(requestLog)
-	}
-
 	// Set up bidirectional copy
 	var wg sync.WaitGroup
+	var sent, received int64
 	wg.Add(2)
 
 	// Client → Destination
 	go func() {
 		defer wg.Done()
-		io.Copy(destConn, conn)
+		sent, _ = io.Copy(destConn, conn)
 		// Signal EOF to destination
 		if tcpConn, ok := destConn.(*net.TCPConn); ok {
 			tcpConn.CloseWrite()
@@ -604,18 +663,71 @@ func (s *SOCKS5Server) handlePassthrough(conn net.Conn, targetAddr string, targe
 	// Destination → Client
 	go func() {
 		defer wg.Done()
-		io.Copy(conn, destConn)
+		received, _ = io.Copy(conn, destConn)
 		// Signal EOF to client
-		if tcpConn, ok := conn.(*net.TCPConn); ok {
-			tcpConn.CloseWrite()
+		if closer, ok := conn.(interface{ CloseWrite() error }); ok {
+			closer.CloseWrite()
 		}
 	}()
 
 	wg.Wait()
+
+	s.finishPassthroughLog(requestLog, start, sent, received, nil)
+	if rule != nil && s.bypassStats != nil {
+		s.bypassStats.recordBytes(rule.ID, sent, received)
+	}
+}
+
+// logPassthrough logs a pass-through tunnel when it opens (metadata only, no bodies).
+// Returns nil when there is no request logger.
+func (s *SOCKS5Server) logPassthrough(conn net.Conn, targetAddr string, targetPort uint16, protocol, proxyUser string) *models.RequestLog {
+	if s.requestLogger == nil {
+		return nil
+	}
+	requestLog := &models.RequestLog{
+		ID:         fmt.Sprintf("%d", time.Now().UnixNano()),
+		Timestamp:  time.Now().Format(models.RequestLogTimeFormat),
+		EndpointID: socks5EndpointID,
+		SOCKS5Info: &models.SOCKS5RequestInfo{
+			TargetHost:    targetAddr,
+			TargetPort:    int(targetPort),
+			Protocol:      protocol,
+			IsIntercepted: false,
+			ViaHTTPProxy:  s.viaHTTPProxy,
+			ProxyUser:     proxyUser,
+		},
+	}
+	requestLog.ClientRequest.Method = "CONNECT"
+	requestLog.ClientRequest.FullURL = fmt.Sprintf("%s:%d", targetAddr, targetPort)
+	requestLog.ClientRequest.Path = fmt.Sprintf("%s:%d", targetAddr, targetPort)
+	requestLog.ClientRequest.SourceIP = conn.RemoteAddr().String()
+	s.requestLogger.LogRequest(*requestLog)
+	return requestLog
+}
+
+// finishPassthroughLog completes a pass-through log entry with the bytes each way, how long the
+// tunnel was open (also shown as the entry's round-trip time) and the dial error, if any
+func (s *SOCKS5Server) finishPassthroughLog(requestLog *models.RequestLog, start time.Time, sent, received int64, dialErr error) {
+	if requestLog == nil {
+		return
+	}
+	duration := time.Since(start).Milliseconds()
+
+	// The logged entry shares the original SOCKS5Info, so the summary goes into a copy
+	info := *requestLog.SOCKS5Info
+	info.BytesSent = sent
+	info.BytesReceived = received
+	info.DurationMs = &duration
+	if dialErr != nil {
+		info.Error = dialErr.Error()
+	}
+	requestLog.SOCKS5Info = &info
+	requestLog.ClientResponse.RTTMs = &duration
+	s.requestLogger.UpdateRequestLog(*requestLog)
 }
 
 // handleHTTP processes HTTP (non-HTTPS) requests through the SOCKS5 tunnel
-func (s *SOCKS5Server) handleHTTP(conn net.Conn, targetAddr string, targetPort uint16) {
+func (s *SOCKS5Server) handleHTTP(conn net.Conn, targetAddr string, targetPort uint16, proxyUser string) {
 	reader := bufio.NewReader(conn)
 
 	for {
@@ -623,7 +735,7 @@ func (s *SOCKS5Server) handleHTTP(conn net.Conn, targetAddr string, targetPort u
 		req, err := http.ReadRequest(reader)
 		if err != nil {
 			if err != io.EOF && !strings.Contains(err.Error(), "use of closed network connection") {
-				log.Printf("SOCKS5 read request error: %v", err)
+				socks5Log.Error("SOCKS5 read request error: %v", err)
 			}
 			return
 		}
@@ -632,39 +744,32 @@ func (s *SOCKS5Server) handleHTTP(conn net.Conn, targetAddr string, targetPort u
 		req.URL.Scheme = "http"
 		req.URL.Host = fmt.Sprintf("%s:%d", targetAddr, targetPort)
 
+		// Tag with the SOCKS5 client address so logs can be grouped by connection
+		req.RemoteAddr = conn.RemoteAddr().String()
+
 		// Ensure Host header is set
 		if req.Host == "" {
 			req.Host = targetAddr
 		}
 
+		// Record the original destination in the request's log entries
+		req = withSOCKS5Info(req, &models.SOCKS5RequestInfo{
+			TargetHost:   targetAddr,
+			TargetPort:   int(targetPort),
+			Protocol:     "HTTP",
+			ViaHTTPProxy: s.viaHTTPProxy,
+			ProxyUser:    proxyUser,
+		})
+
 		// Create a response recorder to capture the response
 		rec := newResponseRecorder()
 
-		// Pass request to ResponseHandler
+		// Pass request to ResponseHandler (which logs it like any other request)
 		s.responseHandler.HandleRequest(rec, req)
 
-		// Log HTTP request (plain HTTP through SOCKS5)
-		if s.requestLogger != nil {
-			requestLog := models.RequestLog{
-				ID:         fmt.Sprintf("%d", time.Now().UnixNano()),
-				Timestamp:  time.Now().Format(time.RFC3339),
-				EndpointID: "system-socks5-proxy",
-				SOCKS5Info: &models.SOCKS5RequestInfo{
-					TargetHost:    targetAddr,
-					TargetPort:    int(targetPort),
-					Protocol:      "HTTP",
-					IsIntercepted: false,
-				},
-			}
-			requestLog.ClientRequest.Method = req.Method
-			requestLog.ClientRequest.FullURL = req.URL.String()
-			requestLog.ClientRequest.Path = req.URL.Path
-			s.requestLogger.LogRequest(requestLog)
-		}
-
 		// Write response back through tunnel
 		if err := s.writeResponse(conn, rec); err != nil {
-			log.Printf("SOCKS5 write response error: %v", err)
+			socks5Log.Error("SOCKS5 write response error: %v", err)
 			return
 		}
 
@@ -754,3 +859,20 @@ func (r *responseRecorder) Write(data []byte) (int, error) {
 func (r *responseRecorder) WriteHeader(statusCode int) {
 	r.statusCode = statusCode
 }
+
+type socks5InfoKey struct{}
+
+// withSOCKS5Info tags a request that arrived through the SOCKS5 proxy with its original destination
+func withSOCKS5Info(r *http.Request, info *models.SOCKS5RequestInfo) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), socks5InfoKey{}, info))
+}
+
+// socks5Info returns a copy of the request's SOCKS5 destination for a log entry (nil for direct requests)
+func socks5Info(r *http.Request) *models.SOCKS5RequestInfo {
+	info, _ := r.Context().Value(socks5InfoKey{}).(*models.SOCKS5RequestInfo)
+	if info == nil {
+		return nil
+	}
+	snapshot := *info
+	return &snapshot
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"fmt"
+	"net"
+
+	"mockelot/models"
+)
+
+// clientAllowed reports whether a client address is in the allow-list (an empty list allows everyone)
+func (s *SOCKS5Server) clientAllowed(addr net.Addr) bool {
+	if len(s.config.AllowedClients) == 0 {
+		return true
+	}
+	clientIP := addr.String()
+	if host, _, err := net.SplitHostPort(clientIP); err == nil {
+		clientIP = host
+	}
+	for _, source := range s.config.AllowedClients {
+		if throttleSourceMatches(source, clientIP) {
+			return true
+		}
+	}
+	return false
+}
+
+// credentialsValid checks a login against the single username/password pair and the users list
+func (s *SOCKS5Server) credentialsValid(username, password string) bool {
+	if s.config.Username != "" && secretEqual(username, s.config.Username) && secretEqual(password, s.config.Password) {
+		return true
+	}
+	for _, user := range s.config.Users {
+		if secretEqual(username, user.Username) && secretEqual(password, user.Password) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSOCKS5Access checks the client allow-list (IPs or CIDRs) and that every user has a
+// unique, non-empty username
+func ValidateSOCKS5Access(socks5Config *models.SOCKS5Config) error {
+	if socks5Config == nil {
+		return nil
+	}
+
+	for _, source := range socks5Config.AllowedClients {
+		if net.ParseIP(source) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(source); err != nil {
+			return fmt.Errorf("invalid SOCKS5 allowed client %q: not an IP address or CIDR range", source)
+		}
+	}
+
+	seen := map[string]bool{socks5Config.Username: socks5Config.Username != ""}
+	for _, user := range socks5Config.Users {
+		switch {
+		case user.Username == "":
+			return fmt.Errorf("SOCKS5 user has an empty username")
+		case seen[user.Username]:
+			return fmt.Errorf("SOCKS5 username %q is used more than once", user.Username)
+		}
+		seen[user.Username] = true
+	}
+	return nil
+}
@@ -65,9 +65,11 @@ var templateFuncs = template.FuncMap{
 	},
 }
 
-// ProcessTemplate processes a template string with the request context
+// ProcessTemplate processes a template string with the request context. Each call gets its own
+// faker, so random data differs between requests unless the template calls seed. The jwt helpers
+// use the context's keys.
 func ProcessTemplate(templateBody string, context *RequestContext) (string, error) {
-	tmpl, err := template.New("response").Funcs(templateFuncs).Parse(templateBody)
+	tmpl, err := template.New("response").Funcs(templateFuncs).Funcs(newFaker().funcs()).Funcs(context.JWT.templateFuncs()).Parse(templateBody)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"mockelot/models"
+)
+
+// maxThrottleClients caps the tracked clients; the least recently seen are forgotten first
+const maxThrottleClients = 4096
+
+// throttleBucket is the token bucket of one client under one rule
+type throttleBucket struct {
+	ruleID        string
+	ruleName      string
+	clientIP      string
+	tokens        float64
+	updated       time.Time
+	allowed       int64
+	throttled     int64
+	lastThrottled time.Time
+}
+
+// ClientThrottle keeps the token buckets and counters of client throttle rules, shared by all listeners
+type ClientThrottle struct {
+	mutex   sync.Mutex
+	buckets map[string]*throttleBucket // Rule ID + "|" + client IP -> bucket
+}
+
+// NewClientThrottle creates empty client throttle state
+func NewClientThrottle() *ClientThrottle {
+	return &ClientThrottle{buckets: make(map[string]*throttleBucket)}
+}
+
+// throttleDecision is the outcome of a throttled request
+type throttleDecision struct {
+	rule       models.ClientThrottleRule
+	retryAfter time.Duration // Until the client may make its next request
+}
+
+// check takes a token from the bucket of the first enabled rule matching the request's client
+// and endpoint. Returns nil when the request may proceed.
+func (t *ClientThrottle) check(rules []models.ClientThrottleRule, endpointID string, r *http.Request) *throttleDecision {
+	if t == nil || len(rules) == 0 {
+		return nil
+	}
+	clientIP := requestClientIP(r)
+	for _, rule := range rules {
+		if !rule.Enabled || rule.MaxRPS <= 0 {
+			continue
+		}
+		if rule.EndpointID != "" && rule.EndpointID != endpointID {
+			continue
+		}
+		if !throttleSourceMatches(rule.Source, clientIP) {
+			continue
+		}
+		if retryAfter, ok := t.take(rule, clientIP); !ok {
+			return &throttleDecision{rule: rule, retryAfter: retryAfter}
+		}
+		return nil // Only the first matching rule applies
+	}
+	return nil
+}
+
+// take refills a client's bucket and takes a token. When the bucket is empty it returns how long
+// until the next token.
+func (t *ClientThrottle) take(rule models.ClientThrottleRule, clientIP string) (time.Duration, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := time.Now()
+	burst := throttleBurst(rule)
+	key := rule.ID + "|" + clientIP
+	bucket := t.buckets[key]
+	if bucket == nil {
+		if len(t.buckets) >= maxThrottleClients {
+			t.evictOldest()
+		}
+		bucket = &throttleBucket{ruleID: rule.ID, clientIP: clientIP, tokens: burst, updated: now}
+		t.buckets[key] = bucket
+	}
+	bucket.ruleName = rule.Name
+	bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*rule.MaxRPS)
+	bucket.updated = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		bucket.allowed++
+		return 0, true
+	}
+	bucket.throttled++
+	bucket.lastThrottled = now
+	return time.Duration((1 - bucket.tokens) / rule.MaxRPS * float64(time.Second)), false
+}
+
+// evictOldest forgets the least recently seen client (caller holds the mutex)
+func (t *ClientThrottle) evictOldest() {
+	var oldestKey string
+	var oldest time.Time
+	for key, bucket := range t.buckets {
+		if oldestKey == "" || bucket.updated.Before(oldest) {
+			oldestKey, oldest = key, bucket.updated
+		}
+	}
+	delete(t.buckets, oldestKey)
+}
+
+// Snapshot returns the counters of every tracked client, most throttled first
+func (t *ClientThrottle) Snapshot(rules []models.ClientThrottleRule) []models.ClientThrottleStats {
+	stats := []models.ClientThrottleStats{}
+	if t == nil {
+		return stats
+	}
+	rulesByID := make(map[string]models.ClientThrottleRule, len(rules))
+	for _, rule := range rules {
+		rulesByID[rule.ID] = rule
+	}
+
+	now := time.Now()
+	t.mutex.Lock()
+	for _, bucket := range t.buckets {
+		entry := models.ClientThrottleStats{
+			RuleID:    bucket.ruleID,
+			RuleName:  bucket.ruleName,
+			ClientIP:  bucket.clientIP,
+			Allowed:   bucket.allowed,
+			Throttled: bucket.throttled,
+			Tokens:    bucket.tokens,
+			LastSeen:  bucket.updated.Format(time.RFC3339),
+		}
+		// Show the bucket as refilled up to now under the rule's current settings
+		if rule, ok := rulesByID[bucket.ruleID]; ok && rule.MaxRPS > 0 {
+			entry.Tokens = math.Min(throttleBurst(rule), bucket.tokens+now.Sub(bucket.updated).Seconds()*rule.MaxRPS)
+		}
+		if !bucket.lastThrottled.IsZero() {
+			entry.LastThrottled = bucket.lastThrottled.Format(time.RFC3339)
+		}
+		stats = append(stats, entry)
+	}
+	t.mutex.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Throttled != stats[j].Throttled {
+			return stats[i].Throttled > stats[j].Throttled
+		}
+		if stats[i].RuleID != stats[j].RuleID {
+			return stats[i].RuleID < stats[j].RuleID
+		}
+		return stats[i].ClientIP < stats[j].ClientIP
+	})
+	return stats
+}
+
+// Reset forgets every client, so all of them start with a full bucket
+func (t *ClientThrottle) Reset() {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	t.buckets = make(map[string]*throttleBucket)
+	t.mutex.Unlock()
+}
+
+// throttleBurst returns the bucket size of a rule
+func throttleBurst(rule models.ClientThrottleRule) float64 {
+	if rule.Burst > 0 {
+		return float64(rule.Burst)
+	}
+	return math.Max(1, math.Ceil(rule.MaxRPS))
+}
+
+// throttleSourceMatches reports whether a client IP falls under a rule's source (IP or CIDR)
+func throttleSourceMatches(source, clientIP string) bool {
+	if source == "" || source == "*" {
+		return true
+	}
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	if _, network, err := net.ParseCIDR(source); err == nil {
+		return network.Contains(ip)
+	}
+	if sourceIP := net.ParseIP(source); sourceIP != nil {
+		return sourceIP.Equal(ip)
+	}
+	return false
+}
+
+// requestClientIP returns the IP address of the client connection
+func requestClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// handleThrottled refuses a request over its client's rate, after the rule's penalty delay
+func (h *ResponseHandler) handleThrottled(w http.ResponseWriter, r *http.Request, endpointID string, decision *throttleDecision, bodyBytes []byte) {
+	rule := decision.rule
+	status := rule.Status
+	if status == 0 {
+		status = http.StatusTooManyRequests
+	}
+	ruleName := rule.Name
+	if ruleName == "" {
+		ruleName = rule.ID
+	}
+	description := ruleName + ": " + requestClientIP(r) + " over " + strconv.FormatFloat(rule.MaxRPS, 'f', -1, 64) + " req/s"
+	updateMatchInfo(r, func(info *models.MatchInfo) {
+		info.Throttle = description
+	})
+	explain(r, "Client throttle %s", description)
+
+	startTime := time.Now()
+	if rule.PenaltyDelayMs > 0 {
+		time.Sleep(time.Duration(rule.PenaltyDelayMs) * time.Millisecond)
+	}
+
+	body := http.StatusText(status)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if advertisesRetryAfter(status) {
+		w.Header().Set("Retry-After", retryAfterSeconds(decision.retryAfter))
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+
+	requestLog := buildRequestLog(r, bodyBytes, endpointID)
+	delayMs := int64(rule.PenaltyDelayMs)
+	rttMs := time.Since(startTime).Milliseconds()
+	requestLog.ClientResponse.StatusCode = &status
+	requestLog.ClientResponse.StatusText = http.StatusText(status)
+	requestLog.ClientResponse.Headers = w.Header().Clone()
+	requestLog.ClientResponse.Body = body
+	requestLog.ClientResponse.DelayMs = &delayMs
+	requestLog.ClientResponse.RTTMs = &rttMs
+	h.requestLogger.LogRequest(requestLog)
+}
@@ -3,7 +3,6 @@ package server
 import (
 	"crypto/tls"
 	"fmt"
-	"log"
 	"net"
 )
 
@@ -64,7 +63,7 @@ func (t *TLSInterceptor) Intercept(conn net.Conn, targetDomain string) (net.Conn
 
 	// Log successful handshake
 	state := tlsConn.ConnectionState()
-	log.Printf("TLS interception established for %s (TLS %s, cipher: %s)",
+	socks5Log.Debug("TLS interception established for %s (TLS %s, cipher: %s)",
 		targetDomain,
 		tlsVersionString(state.Version),
 		tls.CipherSuiteName(state.CipherSuite))
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mockelot/models"
+)
+
+// trafficSampleInterval is how often bandwidth is sampled and reported to the frontend
+const trafficSampleInterval = 5 * time.Second
+
+// trafficCounter accumulates the traffic of one SOCKS5 tunnel destination or proxy endpoint
+type trafficCounter struct {
+	name        string
+	connections atomic.Int64
+	bytesIn     atomic.Int64
+	bytesOut    atomic.Int64
+	lastActive  atomic.Int64 // Unix nanoseconds
+
+	// Bandwidth over the last sample interval (guarded by TrafficMeter.mutex)
+	sampledIn  int64
+	sampledOut int64
+	inRate     float64
+	outRate    float64
+}
+
+// TrafficMeter counts the bytes carried by each SOCKS5 tunnel destination and proxy endpoint and
+// reports them, with the current bandwidth, every few seconds
+type TrafficMeter struct {
+	mutex       sync.Mutex
+	counters    map[string]*trafficCounter // kind + "|" + key -> counter
+	eventSender EventSender
+	stop        chan struct{}
+}
+
+// NewTrafficMeter creates empty traffic counters; samples are reported to the event sender (may be nil)
+func NewTrafficMeter(eventSender EventSender) *TrafficMeter {
+	return &TrafficMeter{
+		counters:    make(map[string]*trafficCounter),
+		eventSender: eventSender,
+	}
+}
+
+// counter returns the counter for a tunnel destination or endpoint, creating it on first use
+func (t *TrafficMeter) counter(kind, key, name string) *trafficCounter {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	id := kind + "|" + key
+	counter := t.counters[id]
+	if counter == nil {
+		counter = &trafficCounter{}
+		t.counters[id] = counter
+	}
+	if name != "" {
+		counter.name = name
+	}
+	return counter
+}
+
+// RecordEndpoint counts one request (or WebSocket connection) to an endpoint and its bytes
+// (in = from the client, out = to the client)
+func (t *TrafficMeter) RecordEndpoint(endpoint *models.Endpoint, in, out int64) {
+	if t == nil || endpoint == nil {
+		return
+	}
+	counter := t.counter(models.TrafficKindEndpoint, endpoint.ID, endpoint.Name)
+	counter.connections.Add(1)
+	counter.add(in, out)
+}
+
+// recordEndpointBytes adds bytes to an endpoint without counting a request (WebSocket messages)
+func (t *TrafficMeter) recordEndpointBytes(endpoint *models.Endpoint, in, out int64) {
+	if t == nil || endpoint == nil {
+		return
+	}
+	t.counter(models.TrafficKindEndpoint, endpoint.ID, endpoint.Name).add(in, out)
+}
+
+// meterTunnel wraps a SOCKS5 client connection so its bytes count toward the destination
+func (t *TrafficMeter) meterTunnel(conn net.Conn, destination string) net.Conn {
+	if t == nil {
+		return conn
+	}
+	counter := t.counter(models.TrafficKindTunnel, destination, destination)
+	counter.connections.Add(1)
+	counter.lastActive.Store(time.Now().UnixNano())
+	return &meteredConn{Conn: conn, counter: counter}
+}
+
+func (c *trafficCounter) add(in, out int64) {
+	c.bytesIn.Add(in)
+	c.bytesOut.Add(out)
+	c.lastActive.Store(time.Now().UnixNano())
+}
+
+// Snapshot returns every counter, most total bytes first
+func (t *TrafficMeter) Snapshot() []models.TrafficStats {
+	stats := []models.TrafficStats{}
+	if t == nil {
+		return stats
+	}
+	t.mutex.Lock()
+	for id, counter := range t.counters {
+		kind, key := splitTrafficID(id)
+		entry := models.TrafficStats{
+			Kind:        kind,
+			Key:         key,
+			Name:        counter.name,
+			Connections: counter.connections.Load(),
+			BytesIn:     counter.bytesIn.Load(),
+			BytesOut:    counter.bytesOut.Load(),
+			InRate:      counter.inRate,
+			OutRate:     counter.outRate,
+		}
+		if lastActive := counter.lastActive.Load(); lastActive != 0 {
+			entry.LastActive = time.Unix(0, lastActive).Format(time.RFC3339)
+		}
+		stats = append(stats, entry)
+	}
+	t.mutex.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		ti, tj := stats[i].BytesIn+stats[i].BytesOut, stats[j].BytesIn+stats[j].BytesOut
+		if ti != tj {
+			return ti > tj
+		}
+		return stats[i].Key < stats[j].Key
+	})
+	return stats
+}
+
+// Reset clears every counter
+func (t *TrafficMeter) Reset() {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	t.counters = make(map[string]*trafficCounter)
+	t.mutex.Unlock()
+}
+
+// Start samples bandwidth periodically and reports counters that changed as "traffic:stats" events
+func (t *TrafficMeter) Start() {
+	t.mutex.Lock()
+	if t.stop != nil {
+		t.mutex.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	t.stop = stop
+	t.mutex.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(trafficSampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if t.sample() && t.eventSender != nil {
+					t.eventSender.SendEvent("traffic:stats", map[string]interface{}{
+						"stats": t.Snapshot(),
+					})
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends periodic sampling
+func (t *TrafficMeter) Stop() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.stop != nil {
+		close(t.stop)
+		t.stop = nil
+	}
+}
+
+// sample computes each counter's bandwidth since the previous sample. Returns whether any
+// counter carried traffic or stopped carrying it (so idle periods are not reported repeatedly).
+func (t *TrafficMeter) sample() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	changed := false
+	seconds := trafficSampleInterval.Seconds()
+	for _, counter := range t.counters {
+		in, out := counter.bytesIn.Load(), counter.bytesOut.Load()
+		inRate := float64(in-counter.sampledIn) / seconds
+		outRate := float64(out-counter.sampledOut) / seconds
+		if inRate != counter.inRate || outRate != counter.outRate || inRate > 0 || outRate > 0 {
+			changed = true
+		}
+		counter.sampledIn, counter.sampledOut = in, out
+		counter.inRate, counter.outRate = inRate, outRate
+	}
+	return changed
+}
+
+// splitTrafficID splits a counter ID into its kind and key
+func splitTrafficID(id string) (string, string) {
+	kind, key, _ := strings.Cut(id, "|")
+	return kind, key
+}
+
+// meteredConn counts the bytes read from (in) and written to (out) a client connection
+type meteredConn struct {
+	net.Conn
+	counter *trafficCounter
+}
+
+func (c *meteredConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.counter.add(int64(n), 0)
+	}
+	return n, err
+}
+
+func (c *meteredConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.counter.add(0, int64(n))
+	}
+	return n, err
+}
+
+// CloseWrite half-closes the underlying TCP connection, if it supports it
+func (c *meteredConn) CloseWrite() error {
+	if closer, ok := c.Conn.(interface{ CloseWrite() error }); ok {
+		return closer.CloseWrite()
+	}
+	return nil
+}
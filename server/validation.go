@@ -14,9 +14,11 @@ import (
 
 // ValidationResult contains the result of request validation
 type ValidationResult struct {
-	Valid bool                   `json:"valid"`           // Whether validation passed
-	Vars  map[string]interface{} `json:"vars,omitempty"`  // Extracted variables
-	Error string                 `json:"error,omitempty"` // Error message if validation failed
+	Valid      bool                     `json:"valid"`                // Whether validation passed
+	Vars       map[string]interface{}   `json:"vars,omitempty"`       // Extracted variables
+	Error      string                   `json:"error,omitempty"`      // Error message if validation failed
+	Violations []models.SchemaViolation `json:"violations,omitempty"` // Schema violations (schema validation only)
+	Reject     bool                     `json:"-"`                    // Answer with 400 instead of trying the next response
 }
 
 // ValidateRequest validates the request body and headers based on the validation config
@@ -27,6 +29,13 @@ func ValidateRequest(validation *models.RequestValidation, body string, reqConte
 		return &ValidationResult{Valid: true, Vars: make(map[string]interface{})}
 	}
 
+	// OpenAPI schema validation of the body and parameters
+	if validation.Schema != nil && validation.Schema.Enabled {
+		if schemaResult := validateSchema(validation.Schema, body, reqContext); !schemaResult.Valid {
+			return schemaResult
+		}
+	}
+
 	// Validate body first
 	bodyResult := &ValidationResult{Valid: true, Vars: make(map[string]interface{})}
 
@@ -252,7 +261,7 @@ func runValidationScript(vm *goja.Runtime, script string, body string, reqContex
 	_, err := vm.RunString(script)
 	if err != nil {
 		if jsErr, ok := err.(*goja.Exception); ok {
-			return nil, fmt.Errorf(jsErr.String())
+			return nil, fmt.Errorf("%s", jsErr.String())
 		}
 		return nil, err
 	}
@@ -463,7 +472,7 @@ func runHeaderValidationScript(vm *goja.Runtime, expression string, headerValue
 	result, err := vm.RunString(expression)
 	if err != nil {
 		if jsErr, ok := err.(*goja.Exception); ok {
-			return false, fmt.Errorf(jsErr.String())
+			return false, fmt.Errorf("%s", jsErr.String())
 		}
 		return false, err
 	}
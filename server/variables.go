@@ -0,0 +1,101 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"mockelot/models"
+)
+
+// variableNamePattern is the form of variable names: letters, digits and underscores
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// variablePattern finds ${NAME} and ${NAME:-default} references; a leading "$" escapes one ($${NAME})
+var variablePattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// Variables are the values ${NAME} references in backend URLs, headers, bodies and container
+// environments resolve to. Names not defined here are looked up in the OS environment.
+type Variables map[string]string
+
+// ConfigVariables returns a copy of the variables of a config: its variables section, overridden
+// by the variables of the active profile
+func ConfigVariables(cfg *models.AppConfig) Variables {
+	if cfg == nil {
+		return nil
+	}
+	profile := cfg.Profiles[cfg.ActiveProfile]
+	variables := make(Variables, len(cfg.Variables)+len(profile))
+	for name, value := range cfg.Variables {
+		variables[name] = value
+	}
+	for name, value := range profile {
+		variables[name] = value
+	}
+	return variables
+}
+
+// Lookup returns the value of a variable: the config's value, or the OS environment variable
+func (v Variables) Lookup(name string) (string, bool) {
+	if value, ok := v[name]; ok {
+		return value, true
+	}
+	return os.LookupEnv(name)
+}
+
+// Expand replaces the ${NAME} references in s. A reference to an undefined variable uses its
+// default (${NAME:-default}), or is left as it is without one.
+func (v Variables) Expand(s string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	return variablePattern.ReplaceAllStringFunc(s, func(reference string) string {
+		if strings.HasPrefix(reference, "$$") {
+			return reference[1:]
+		}
+		match := variablePattern.FindStringSubmatch(reference)
+		if value, ok := v.Lookup(match[1]); ok {
+			return value
+		}
+		if strings.Contains(reference, ":-") {
+			return match[2]
+		}
+		return reference
+	})
+}
+
+// ProfileNames returns the names of the profiles of a config, sorted
+func ProfileNames(cfg *models.AppConfig) []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateProfile checks that the active profile of a config is one of its profiles
+func ValidateProfile(cfg *models.AppConfig) error {
+	if cfg.ActiveProfile == "" {
+		return nil
+	}
+	if _, ok := cfg.Profiles[cfg.ActiveProfile]; !ok {
+		if len(cfg.Profiles) == 0 {
+			return fmt.Errorf("profile %q is not defined, the config has no profiles", cfg.ActiveProfile)
+		}
+		return fmt.Errorf("profile %q is not defined (profiles: %s)", cfg.ActiveProfile, strings.Join(ProfileNames(cfg), ", "))
+	}
+	return nil
+}
+
+// ValidateVariables checks the names of a set of variables
+func ValidateVariables(variables map[string]string) error {
+	for name := range variables {
+		if !variableNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid variable name %q (use letters, digits and underscores)", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mockelot/models"
+)
+
+// selectResponseVariant returns the response to serve after negotiating its variants.
+// The returned response is a copy with the winning variant's overrides and a Vary header;
+// responses without a variant header are returned unchanged.
+func selectResponseVariant(resp *models.MethodResponse, r *http.Request) *models.MethodResponse {
+	if resp.VariantHeader == "" || len(resp.Variants) == 0 {
+		return resp
+	}
+
+	selected := *resp
+	headers := make(map[string]string, len(resp.Headers)+1)
+	for name, value := range resp.Headers {
+		headers[name] = value
+	}
+
+	if variant := matchVariant(resp.VariantHeader, r.Header.Get(resp.VariantHeader), resp.Variants); variant != nil {
+		if variant.StatusCode != 0 {
+			selected.StatusCode = variant.StatusCode
+		}
+		if variant.Body != "" {
+			selected.Body = variant.Body
+			selected.BodyBase64 = false
+		}
+		for name, value := range variant.Headers {
+			setHeader(headers, name, value)
+		}
+	}
+
+	// Caches must key on the variant header
+	vary := http.CanonicalHeaderKey(resp.VariantHeader)
+	if existing := getHeader(headers, "Vary"); existing != "" {
+		if !varyContains(existing, vary) {
+			setHeader(headers, "Vary", existing+", "+vary)
+		}
+	} else {
+		setHeader(headers, "Vary", vary)
+	}
+
+	selected.Headers = headers
+	return &selected
+}
+
+// matchVariant picks the variant for a request header value.
+// Accept-* headers are negotiated by quality value; other headers match case-insensitively.
+// A variant with value "*" is the fallback when nothing else matches.
+func matchVariant(headerName, headerValue string, variants []models.ResponseVariant) *models.ResponseVariant {
+	var fallback *models.ResponseVariant
+	for i := range variants {
+		if variants[i].Value == "*" {
+			fallback = &variants[i]
+			break
+		}
+	}
+
+	if headerValue == "" {
+		return fallback
+	}
+
+	if !strings.HasPrefix(strings.ToLower(headerName), "accept") {
+		for i := range variants {
+			if strings.EqualFold(strings.TrimSpace(headerValue), variants[i].Value) {
+				return &variants[i]
+			}
+		}
+		return fallback
+	}
+
+	for _, accepted := range parseAcceptList(headerValue) {
+		for i := range variants {
+			if variants[i].Value != "*" && acceptMatches(accepted, variants[i].Value) {
+				return &variants[i]
+			}
+		}
+	}
+	return fallback
+}
+
+// parseAcceptList returns the entries of an Accept-style header ordered by quality (q=0 entries dropped)
+func parseAcceptList(value string) []string {
+	type entry struct {
+		value string
+		q     float64
+	}
+
+	var entries []entry
+	for _, part := range strings.Split(value, ",") {
+		params := strings.Split(part, ";")
+		name := strings.TrimSpace(params[0])
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			key, val, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(strings.TrimSpace(key), "q") {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(val), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			entries = append(entries, entry{value: name, q: q})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+
+	result := make([]string, len(entries))
+	for i, e := range entries {
+		result[i] = e.value
+	}
+	return result
+}
+
+// acceptMatches reports whether an accepted entry (e.g. "text/*", "en", "*") covers a variant value
+func acceptMatches(accepted, variant string) bool {
+	accepted = strings.ToLower(accepted)
+	variant = strings.ToLower(variant)
+
+	if accepted == "*" || accepted == "*/*" || accepted == variant {
+		return true
+	}
+	// Media range wildcard: text/* matches text/html
+	if prefix, found := strings.CutSuffix(accepted, "/*"); found {
+		return strings.HasPrefix(variant, prefix+"/")
+	}
+	// Language prefix: en matches en-US and en-US matches en
+	return strings.HasPrefix(variant, accepted+"-") || strings.HasPrefix(accepted, variant+"-")
+}
+
+// varyContains reports whether a Vary header value already lists a header name
+func varyContains(vary, name string) bool {
+	for _, part := range strings.Split(vary, ",") {
+		part = strings.TrimSpace(part)
+		if part == "*" || strings.EqualFold(part, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"mockelot/models"
+)
+
+// selectVersionItems resolves the API version of a request and returns the items to match against.
+// Items of the resolved version come first, followed by the endpoint's shared (unversioned) items.
+// For path-based routing the version segment is removed from the returned path.
+func selectVersionItems(endpoint *models.Endpoint, r *http.Request, path string) ([]models.ResponseItem, string) {
+	routing := endpoint.Versioning
+	if routing == nil || len(routing.Versions) == 0 {
+		return endpoint.Items, path
+	}
+
+	requested := ""
+	switch routing.Source {
+	case models.VersionSourceHeader:
+		requested = strings.TrimSpace(r.Header.Get(routing.Name))
+	case models.VersionSourceQuery:
+		requested = strings.TrimSpace(r.URL.Query().Get(routing.Name))
+	default: // models.VersionSourcePath
+		trimmed := strings.TrimPrefix(path, "/")
+		segment, rest, _ := strings.Cut(trimmed, "/")
+		if findVersion(routing, segment) != nil {
+			requested = segment
+			path = "/" + rest
+		}
+	}
+
+	version := findVersion(routing, requested)
+	if version == nil && routing.Default != "" {
+		version = findVersion(routing, routing.Default)
+	}
+	if version == nil {
+		return endpoint.Items, path
+	}
+
+	items := make([]models.ResponseItem, 0, len(version.Items)+len(endpoint.Items))
+	items = append(items, version.Items...)
+	items = append(items, endpoint.Items...)
+	return items, path
+}
+
+// findVersion returns the configured version with the given name (case-insensitive)
+func findVersion(routing *models.VersionRouting, name string) *models.APIVersion {
+	if name == "" {
+		return nil
+	}
+	for i := range routing.Versions {
+		if strings.EqualFold(routing.Versions[i].Version, name) {
+			return &routing.Versions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"mockelot/logger"
+	"mockelot/models"
+)
+
+var storageLog = logger.For(logger.SubsystemStorage)
+
+const defaultCommitMessage = "Update {file}"
+
+// GitBackend stores configs as files inside a Git repository and commits them on every save
+type GitBackend struct {
+	options models.GitStorage
+}
+
+// Load optionally pulls the repository, then reads the file. A failed pull is logged and the
+// local copy is used.
+func (b *GitBackend) Load(path string) ([]byte, error) {
+	if b.options.PullOnLoad {
+		if root, err := repoRoot(path); err != nil {
+			storageLog.Info("Git storage: %v", err)
+		} else if _, err := runGit(root, "pull", "--ff-only"); err != nil {
+			storageLog.Warn("Git storage: pull failed, loading local copy: %v", err)
+		}
+	}
+	return FileBackend{}.Load(path)
+}
+
+// Save writes the file and commits it (only that file). Nothing is committed when the file is
+// unchanged. The commit is pushed if configured.
+func (b *GitBackend) Save(path string, data []byte, message string) error {
+	root, err := repoRoot(path)
+	if err != nil {
+		return err
+	}
+	if err := (FileBackend{}).Save(path, data, message); err != nil {
+		return err
+	}
+
+	relPath, err := repoRelativePath(root, path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := runGit(root, "add", "--", relPath); err != nil {
+		return err
+	}
+	if _, err := runGit(root, "diff", "--cached", "--quiet", "--", relPath); err == nil {
+		return nil // No changes to commit
+	}
+
+	if message == "" {
+		message = b.options.CommitMessage
+	}
+	if message == "" {
+		message = defaultCommitMessage
+	}
+	message = strings.ReplaceAll(message, "{file}", filepath.Base(path))
+	if _, err := runGit(root, "commit", "-m", message, "--", relPath); err != nil {
+		return err
+	}
+	storageLog.Info("Git storage: committed %s (%s)", relPath, message)
+
+	if b.options.Push {
+		if _, err := runGit(root, "push"); err != nil {
+			return fmt.Errorf("saved and committed, but push failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// SaveFiles writes and removes the files, then commits all of them in one commit. Removed files
+// that were never committed are just deleted.
+func (b *GitBackend) SaveFiles(files []File, message string) error {
+	if len(files) == 0 {
+		return nil
+	}
+	root, err := repoRoot(files[0].Path)
+	if err != nil {
+		return err
+	}
+	if err := (FileBackend{}).SaveFiles(files, message); err != nil {
+		return err
+	}
+
+	var relPaths []string
+	for _, file := range files {
+		relPath, err := repoRelativePath(root, file.Path)
+		if err != nil {
+			return err
+		}
+		if file.Data == nil {
+			if tracked, err := runGit(root, "ls-files", "--", relPath); err != nil || tracked == "" {
+				continue
+			}
+		}
+		relPaths = append(relPaths, relPath)
+	}
+
+	if _, err := runGit(root, append([]string{"add", "-A", "--"}, relPaths...)...); err != nil {
+		return err
+	}
+	if _, err := runGit(root, append([]string{"diff", "--cached", "--quiet", "--"}, relPaths...)...); err == nil {
+		return nil // No changes to commit
+	}
+
+	if message == "" {
+		message = b.options.CommitMessage
+	}
+	if message == "" {
+		message = defaultCommitMessage
+	}
+	message = strings.ReplaceAll(message, "{file}", filepath.Base(files[0].Path))
+	if _, err := runGit(root, append([]string{"commit", "-m", message, "--"}, relPaths...)...); err != nil {
+		return err
+	}
+	storageLog.Info("Git storage: committed %d file(s) (%s)", len(relPaths), message)
+
+	if b.options.Push {
+		if _, err := runGit(root, "push"); err != nil {
+			return fmt.Errorf("saved and committed, but push failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// repoRoot returns the top-level directory of the Git repository containing path
+func repoRoot(path string) (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", fmt.Errorf("git is required for git storage: %w", err)
+	}
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return "", fmt.Errorf("could not resolve %s: %v", path, err)
+	}
+	root, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("%s is not inside a git repository", path)
+	}
+	return root, nil
+}
+
+// repoRelativePath returns path relative to the repository root. Symlinks in the directory are
+// resolved, as git reports the root in resolved form.
+func repoRelativePath(root, path string) (string, error) {
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return "", fmt.Errorf("could not resolve %s: %v", path, err)
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	relPath, err := filepath.Rel(root, filepath.Join(dir, filepath.Base(path)))
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return "", fmt.Errorf("%s is not inside the repository %s", path, root)
+	}
+	return relPath, nil
+}
+
+// runGit runs a git command in dir and returns its trimmed output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %v: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mockelot/models"
+)
+
+const maxRemoteSize = 10 << 20 // 10 MiB cap for downloaded configs
+
+var remoteClient = &http.Client{Timeout: 30 * time.Second}
+
+// remoteStore is a central store of config files keyed by file name
+type remoteStore interface {
+	// get returns the stored file, or found=false if there is none
+	get(name string) (data []byte, found bool, err error)
+	put(name string, data []byte) error
+}
+
+// mirrorBackend keeps the local file as a working copy of a remote store: saves write the local
+// file and upload it, loads prefer the remote copy (falling back to the local file if the remote
+// store has none) and refresh the local file with it
+type mirrorBackend struct {
+	remote remoteStore
+}
+
+// Load fetches the remote copy, or reads the local file if the remote store has none
+func (b *mirrorBackend) Load(path string) ([]byte, error) {
+	name := filepath.Base(path)
+	data, found, err := b.remote.get(name)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return FileBackend{}.Load(path)
+	}
+	if err := (FileBackend{}).Save(path, data, ""); err != nil {
+		storageLog.Error("Remote storage: could not update local copy %s: %v", path, err)
+	}
+	return data, nil
+}
+
+// Save writes the local file, then uploads it
+func (b *mirrorBackend) Save(path string, data []byte, message string) error {
+	if err := (FileBackend{}).Save(path, data, message); err != nil {
+		return err
+	}
+	if err := b.remote.put(filepath.Base(path), data); err != nil {
+		return fmt.Errorf("saved locally, but upload failed: %v", err)
+	}
+	return nil
+}
+
+// httpStore keeps configs on an HTTP server: GET and PUT <base_url>/<name>
+type httpStore struct {
+	options models.HTTPStorage
+}
+
+func (s *httpStore) url(name string) string {
+	return strings.TrimSuffix(s.options.BaseURL, "/") + "/" + awsEscape(name)
+}
+
+func (s *httpStore) get(name string) ([]byte, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, s.url(name), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid storage URL: %v", err)
+	}
+	for key, value := range s.options.Headers {
+		req.Header.Set(key, value)
+	}
+	return doGet(req)
+}
+
+func (s *httpStore) put(name string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, s.url(name), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid storage URL: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/yaml")
+	for key, value := range s.options.Headers {
+		req.Header.Set(key, value)
+	}
+	return doPut(req)
+}
+
+// s3Store keeps configs in an S3-compatible bucket (path-style requests signed with Signature V4)
+type s3Store struct {
+	options models.S3Storage
+}
+
+func (s *s3Store) url(name string) string {
+	endpoint := s.options.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s.options.Region)
+	}
+	key := s.options.Prefix + name
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = awsEscape(segment)
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/" + awsEscape(s.options.Bucket) + "/" + strings.Join(segments, "/")
+}
+
+func (s *s3Store) get(name string) ([]byte, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, s.url(name), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid S3 endpoint: %v", err)
+	}
+	s.sign(req, nil, time.Now())
+	return doGet(req)
+}
+
+func (s *s3Store) put(name string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, s.url(name), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid S3 endpoint: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/yaml")
+	s.sign(req, data, time.Now())
+	return doPut(req)
+}
+
+// sign adds AWS Signature Version 4 headers to a request
+func (s *s3Store) sign(req *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.options.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.options.SessionToken)
+	}
+
+	// Canonical headers: host plus every x-amz-* and content-type header, lower-cased and sorted
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-type" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"", // No query string
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.options.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.options.SecretAccessKey), date)
+	key = hmacSHA256(key, s.options.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.options.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// doGet performs a download; 404 means the store has no such file
+func doGet(req *http.Request) ([]byte, bool, error) {
+	resp, err := remoteClient.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch %s: %w", req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("failed to fetch %s: status %d", req.URL.Redacted(), resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteSize+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %w", req.URL.Redacted(), err)
+	}
+	if len(data) > maxRemoteSize {
+		return nil, false, fmt.Errorf("%s exceeds maximum size of %d bytes", req.URL.Redacted(), maxRemoteSize)
+	}
+	return data, true, nil
+}
+
+// doPut performs an upload; any 2xx status is success
+func doPut(req *http.Request) error {
+	resp, err := remoteClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload to %s: %w", req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to upload to %s: status %d: %s", req.URL.Redacted(), resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// awsEscape percent-encodes everything except unreserved characters (RFC 3986), as SigV4 requires
+func awsEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,127 @@
+// Package storage persists config files through a pluggable backend: the local file system,
+// a Git repository, or a remote HTTP server or S3 bucket mirroring the local files.
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"mockelot/models"
+)
+
+// Backend reads and writes config files. Paths are always local file paths; remote backends keep
+// the local file as a working copy and use its file name as the remote object name.
+type Backend interface {
+	// Load returns the config file contents
+	Load(path string) ([]byte, error)
+	// Save writes the config file; message describes the change (used as the Git commit message)
+	Save(path string, data []byte, message string) error
+}
+
+// File is one file of a set saved together; nil Data removes the file
+type File struct {
+	Path string
+	Data []byte
+}
+
+// FileSetSaver is implemented by backends that can save a set of files as one change, such as a
+// config in directory layout
+type FileSetSaver interface {
+	// SaveFiles writes and removes the files; the first file names the change ({file} in Git
+	// commit messages)
+	SaveFiles(files []File, message string) error
+}
+
+// New creates the backend selected by the settings
+func New(settings models.StorageSettings) (Backend, error) {
+	switch settings.Backend {
+	case "", models.StorageBackendFile:
+		return FileBackend{}, nil
+	case models.StorageBackendGit:
+		options := models.GitStorage{}
+		if settings.Git != nil {
+			options = *settings.Git
+		}
+		return &GitBackend{options: options}, nil
+	case models.StorageBackendHTTP:
+		if settings.HTTP == nil || settings.HTTP.BaseURL == "" {
+			return nil, fmt.Errorf("http storage requires a base URL")
+		}
+		return &mirrorBackend{remote: &httpStore{options: *settings.HTTP}}, nil
+	case models.StorageBackendS3:
+		if settings.S3 == nil || settings.S3.Bucket == "" || settings.S3.Region == "" {
+			return nil, fmt.Errorf("s3 storage requires a bucket and region")
+		}
+		if settings.S3.AccessKeyID == "" || settings.S3.SecretAccessKey == "" {
+			return nil, fmt.Errorf("s3 storage requires an access key and secret key")
+		}
+		return &mirrorBackend{remote: &s3Store{options: *settings.S3}}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", settings.Backend)
+	}
+}
+
+// FileBackend stores configs as local files
+type FileBackend struct{}
+
+// Load reads the file
+func (FileBackend) Load(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("file does not exist: %s", path)
+		}
+		return nil, fmt.Errorf("could not open file: %v", err)
+	}
+	return data, nil
+}
+
+// Save writes the file atomically (temporary file + rename)
+func (FileBackend) Save(path string, data []byte, message string) error {
+	dir := filepath.Dir(path)
+	tempFile, err := os.CreateTemp(dir, ".mockelot-save-*")
+	if err != nil {
+		return fmt.Errorf("could not create file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("could not write file: %v", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("could not write file: %v", err)
+	}
+
+	// Keep the permissions of an existing file
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	os.Chmod(tempFile.Name(), mode)
+
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("could not replace file: %v", err)
+	}
+	return nil
+}
+
+// SaveFiles writes the files (creating their directories) and removes the ones without data
+func (FileBackend) SaveFiles(files []File, message string) error {
+	for _, file := range files {
+		if file.Data == nil {
+			if err := os.Remove(file.Path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("could not remove file: %v", err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil {
+			return fmt.Errorf("could not create directory: %v", err)
+		}
+		if err := (FileBackend{}).Save(file.Path, file.Data, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
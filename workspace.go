@@ -0,0 +1,409 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"mockelot/models"
+	"mockelot/server"
+
+	"github.com/google/uuid"
+)
+
+// workspace is one of the mock server configurations open at the same time. The state of the
+// active workspace lives in the App fields the bindings use; the other workspaces keep theirs
+// here, and their servers and containers keep running in the background.
+type workspace struct {
+	id    string
+	name  string
+	sink  *workspaceSink
+	mutex sync.Mutex     // Protects state
+	state workspaceState // Parked state (empty while the workspace is active)
+}
+
+// workspaceState is the part of the App that belongs to a workspace
+type workspaceState struct {
+	server                 *server.HTTPServer
+	containerHandler       *server.ContainerHandler
+	proxyHandler           *server.ProxyHandler
+	config                 *models.AppConfig
+	savedConfig            *models.AppConfig
+	currentConfigPath      string
+	requestLogs            []models.RequestLog
+	status                 ServerStatus
+	containerStartContexts map[string]context.CancelFunc
+	scriptErrors           map[string][]ScriptErrorLog
+	undoHistory            *undoHistory
+}
+
+func newWorkspace(app *App, name string) *workspace {
+	ws := &workspace{id: uuid.New().String(), name: name}
+	ws.sink = &workspaceSink{app: app, ws: ws}
+	return ws
+}
+
+// workspaceSink receives the request logs, script errors and events of one workspace's server and
+// handlers. The App handles them while the workspace is active; in the background, request logs
+// and script errors are kept with the workspace and events are dropped (the frontend reloads
+// everything when switching).
+type workspaceSink struct {
+	app *App
+	ws  *workspace
+}
+
+// active reports whether the sink's workspace is the active one
+func (s *workspaceSink) active() bool {
+	s.app.workspaceMutex.RLock()
+	defer s.app.workspaceMutex.RUnlock()
+	return s.app.activeWorkspace == s.ws
+}
+
+// LogRequest implements server.RequestLogger
+func (s *workspaceSink) LogRequest(log models.RequestLog) {
+	if s.active() {
+		s.app.LogRequest(log)
+		return
+	}
+	s.ws.mutex.Lock()
+	defer s.ws.mutex.Unlock()
+	s.ws.state.requestLogs = append(s.ws.state.requestLogs, log)
+}
+
+// UpdateRequestLog implements server.RequestLogger
+func (s *workspaceSink) UpdateRequestLog(log models.RequestLog) {
+	if s.active() {
+		s.app.UpdateRequestLog(log)
+		return
+	}
+	s.ws.mutex.Lock()
+	defer s.ws.mutex.Unlock()
+	for i := range s.ws.state.requestLogs {
+		if s.ws.state.requestLogs[i].ID == log.ID {
+			s.ws.state.requestLogs[i] = log
+			return
+		}
+	}
+	s.ws.state.requestLogs = append(s.ws.state.requestLogs, log)
+}
+
+// LogScriptError implements server.ScriptErrorLogger
+func (s *workspaceSink) LogScriptError(responseID, path, method, errorMsg string) {
+	if s.active() {
+		s.app.LogScriptError(responseID, path, method, errorMsg)
+		return
+	}
+	s.ws.mutex.Lock()
+	defer s.ws.mutex.Unlock()
+	errors := append(s.ws.state.scriptErrors[responseID], ScriptErrorLog{
+		Timestamp:  time.Now(),
+		Error:      errorMsg,
+		ResponseID: responseID,
+		Path:       path,
+		Method:     method,
+	})
+	if len(errors) > 100 {
+		errors = errors[len(errors)-100:]
+	}
+	s.ws.state.scriptErrors[responseID] = errors
+}
+
+// SendEvent implements server.EventSender
+func (s *workspaceSink) SendEvent(source string, data interface{}) {
+	if s.active() {
+		s.app.SendEvent(source, data)
+	}
+}
+
+// parkedConfig returns the configuration of a background workspace (nil while it is active)
+func (s *workspaceSink) parkedConfig() *models.AppConfig {
+	s.ws.mutex.Lock()
+	defer s.ws.mutex.Unlock()
+	return s.ws.state.config
+}
+
+// RegistryCredentials implements server.RegistryCredentialSource
+func (s *workspaceSink) RegistryCredentials() []models.RegistryCredential {
+	if cfg := s.parkedConfig(); cfg != nil {
+		return cfg.RegistryCredentials
+	}
+	return s.app.RegistryCredentials()
+}
+
+// ContainerHosts implements server.ContainerHostSource
+func (s *workspaceSink) ContainerHosts() []models.ContainerHost {
+	if cfg := s.parkedConfig(); cfg != nil {
+		return cfg.ContainerHosts
+	}
+	return s.app.ContainerHosts()
+}
+
+// DefaultContainerHost implements server.ContainerHostSource
+func (s *workspaceSink) DefaultContainerHost() string {
+	if cfg := s.parkedConfig(); cfg != nil {
+		return cfg.DefaultContainerHost
+	}
+	return s.app.DefaultContainerHost()
+}
+
+// activeSink returns the sink the active workspace's server reports to
+func (a *App) activeSink() *workspaceSink {
+	a.workspaceMutex.RLock()
+	defer a.workspaceMutex.RUnlock()
+	return a.activeWorkspace.sink
+}
+
+// activateWorkspace parks the state of the active workspace and moves the state of ws into the App
+func (a *App) activateWorkspace(ws *workspace) {
+	a.configMutex.Lock()
+	defer a.configMutex.Unlock()
+	a.logMutex.Lock()
+	defer a.logMutex.Unlock()
+	a.requestLogQueueMutex.Lock()
+	defer a.requestLogQueueMutex.Unlock()
+	a.containerStartMutex.Lock()
+	defer a.containerStartMutex.Unlock()
+	a.scriptErrorsMutex.Lock()
+	defer a.scriptErrorsMutex.Unlock()
+
+	a.workspaceMutex.Lock()
+	defer a.workspaceMutex.Unlock()
+	current := a.activeWorkspace
+
+	current.mutex.Lock()
+	current.state = workspaceState{
+		server:                 a.server,
+		containerHandler:       a.containerHandler,
+		proxyHandler:           a.proxyHandler,
+		config:                 a.config,
+		savedConfig:            a.savedConfig,
+		currentConfigPath:      a.currentConfigPath,
+		requestLogs:            a.requestLogs,
+		status:                 a.status,
+		containerStartContexts: a.containerStartContexts,
+		scriptErrors:           a.scriptErrors,
+		undoHistory:            a.undoHistory,
+	}
+	current.mutex.Unlock()
+
+	ws.mutex.Lock()
+	state := ws.state
+	ws.state = workspaceState{}
+	ws.mutex.Unlock()
+
+	a.server = state.server
+	a.containerHandler = state.containerHandler
+	a.proxyHandler = state.proxyHandler
+	a.config = state.config
+	a.savedConfig = state.savedConfig
+	a.currentConfigPath = state.currentConfigPath
+	a.requestLogs = state.requestLogs
+	a.status = state.status
+	a.containerStartContexts = state.containerStartContexts
+	a.scriptErrors = state.scriptErrors
+	a.undoHistory = state.undoHistory
+	a.requestLogSummaryQueue = make([]models.RequestLogSummary, 0) // The frontend reloads the logs
+	a.activeWorkspace = ws
+}
+
+// findWorkspace returns the open workspace with an ID
+func (a *App) findWorkspace(id string) (*workspace, error) {
+	a.workspaceMutex.RLock()
+	defer a.workspaceMutex.RUnlock()
+	for _, ws := range a.workspaces {
+		if ws.id == id {
+			return ws, nil
+		}
+	}
+	return nil, fmt.Errorf("workspace %s not found", id)
+}
+
+// workspaceInfo describes a workspace; active tells whether its state is in the App fields
+func (a *App) workspaceInfo(ws *workspace, active bool) models.WorkspaceInfo {
+	info := models.WorkspaceInfo{ID: ws.id, Name: ws.name, Active: active}
+	if active {
+		a.configMutex.RLock()
+		info.Port = a.config.Port
+		info.ConfigPath = a.currentConfigPath
+		a.configMutex.RUnlock()
+		info.Running = a.server != nil && a.status.Running
+		if info.Running {
+			info.Port = a.status.Port
+		}
+		info.Dirty = a.IsDirty()
+	} else {
+		ws.mutex.Lock()
+		info.Port = ws.state.config.Port
+		info.ConfigPath = ws.state.currentConfigPath
+		info.Running = ws.state.server != nil && ws.state.status.Running
+		if info.Running {
+			info.Port = ws.state.status.Port
+		}
+		info.Dirty = ws.state.savedConfig != nil && !a.configsEqual(ws.state.config, ws.state.savedConfig)
+		ws.mutex.Unlock()
+	}
+	return info
+}
+
+// ListWorkspaces returns the open workspaces; the active one is marked
+func (a *App) ListWorkspaces() []models.WorkspaceInfo {
+	a.workspaceMutex.RLock()
+	workspaces := append([]*workspace(nil), a.workspaces...)
+	active := a.activeWorkspace
+	a.workspaceMutex.RUnlock()
+
+	infos := make([]models.WorkspaceInfo, 0, len(workspaces))
+	for _, ws := range workspaces {
+		infos = append(infos, a.workspaceInfo(ws, ws == active))
+	}
+	return infos
+}
+
+// CreateWorkspace opens a new, empty workspace and makes it active. The workspace that was active
+// keeps running in the background. Its HTTP port is one above the highest port of the open
+// workspaces, so both servers can run at once.
+func (a *App) CreateWorkspace(name string) (models.WorkspaceInfo, error) {
+	port := 0
+	for _, info := range a.ListWorkspaces() {
+		if info.Port > port {
+			port = info.Port
+		}
+	}
+	port++
+
+	a.workspaceMutex.Lock()
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = fmt.Sprintf("Workspace %d", len(a.workspaces)+1)
+	}
+	ws := newWorkspace(a, name)
+	proxyHandler := server.NewProxyHandler(ws.sink)
+	ws.state = workspaceState{
+		containerHandler:       server.NewContainerHandler(ws.sink, ws.sink, proxyHandler, ws.sink, ws.sink),
+		proxyHandler:           proxyHandler,
+		config:                 defaultAppConfig(port),
+		requestLogs:            make([]models.RequestLog, 0),
+		status:                 ServerStatus{Port: port},
+		containerStartContexts: make(map[string]context.CancelFunc),
+		scriptErrors:           make(map[string][]ScriptErrorLog),
+		undoHistory:            &undoHistory{},
+	}
+	a.workspaces = append(a.workspaces, ws)
+	a.workspaceMutex.Unlock()
+
+	a.activateWorkspace(ws)
+
+	// Ensure system endpoints exist (order matters: overlay before SOCKS5 before rejections)
+	a.ensureDisplayOrder()
+	a.ensureDomainTakeoverEndpoints()
+	a.ensureSOCKS5ProxyEndpoint()
+	a.ensureRejectionsEndpoint()
+
+	a.emitWorkspaceSwitched()
+	appLog.Info("Created workspace %s (port %d)", name, port)
+	return a.workspaceInfo(ws, true), nil
+}
+
+// SwitchWorkspace makes a workspace active. The bindings then act on its configuration, server and
+// request logs; the previously active workspace keeps running in the background.
+func (a *App) SwitchWorkspace(id string) error {
+	ws, err := a.findWorkspace(id)
+	if err != nil {
+		return err
+	}
+	if ws.sink.active() {
+		return nil
+	}
+	a.activateWorkspace(ws)
+	a.emitWorkspaceSwitched()
+	return nil
+}
+
+// RenameWorkspace changes the display name of a workspace
+func (a *App) RenameWorkspace(id string, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("workspace name cannot be empty")
+	}
+	ws, err := a.findWorkspace(id)
+	if err != nil {
+		return err
+	}
+	a.workspaceMutex.Lock()
+	ws.name = name
+	a.workspaceMutex.Unlock()
+	a.emit("workspaces:updated", a.ListWorkspaces())
+	return nil
+}
+
+// CloseWorkspace stops the server and containers of a background workspace and closes it; unsaved
+// changes are lost. The active workspace cannot be closed, switch to another one first.
+func (a *App) CloseWorkspace(id string) error {
+	ws, err := a.findWorkspace(id)
+	if err != nil {
+		return err
+	}
+
+	a.workspaceMutex.Lock()
+	if a.activeWorkspace == ws {
+		a.workspaceMutex.Unlock()
+		return fmt.Errorf("the active workspace cannot be closed, switch to another workspace first")
+	}
+	for i := range a.workspaces {
+		if a.workspaces[i] == ws {
+			a.workspaces = append(a.workspaces[:i], a.workspaces[i+1:]...)
+			break
+		}
+	}
+	a.workspaceMutex.Unlock()
+
+	stopWorkspace(ws)
+	appLog.Info("Closed workspace %s", ws.name)
+	a.emit("workspaces:updated", a.ListWorkspaces())
+	return nil
+}
+
+// stopWorkspace cancels container startups and stops the server and containers of a parked workspace
+func stopWorkspace(ws *workspace) {
+	ws.mutex.Lock()
+	state := ws.state
+	ws.mutex.Unlock()
+
+	for _, cancel := range state.containerStartContexts {
+		cancel()
+	}
+	if state.server != nil {
+		if err := state.server.Stop(); err != nil {
+			appLog.Error("Error stopping server of workspace %s: %v", ws.name, err)
+		}
+	} else if state.containerHandler != nil {
+		state.containerHandler.StopPolling()
+	}
+}
+
+// stopBackgroundWorkspaces stops the servers and containers of all workspaces but the active one
+func (a *App) stopBackgroundWorkspaces() {
+	a.workspaceMutex.RLock()
+	var background []*workspace
+	for _, ws := range a.workspaces {
+		if ws != a.activeWorkspace {
+			background = append(background, ws)
+		}
+	}
+	a.workspaceMutex.RUnlock()
+
+	for _, ws := range background {
+		stopWorkspace(ws)
+	}
+}
+
+// emitWorkspaceSwitched tells the frontend to reload everything after the active workspace changed
+func (a *App) emitWorkspaceSwitched() {
+	a.SendEvent("server:status", a.status)
+	a.emit("config:path", a.currentConfigPath)
+	a.emit("config:dirty", a.IsDirty())
+	a.emit("workspaces:updated", a.ListWorkspaces())
+	a.emit("workspace:switched", a.workspaceInfo(a.activeWorkspace, true))
+}